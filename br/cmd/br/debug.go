@@ -18,6 +18,7 @@ import (
 	"github.com/pingcap/log"
 	"github.com/pingcap/tidb/br/pkg/conn"
 	berrors "github.com/pingcap/tidb/br/pkg/errors"
+	"github.com/pingcap/tidb/br/pkg/gluetikv"
 	"github.com/pingcap/tidb/br/pkg/logutil"
 	"github.com/pingcap/tidb/br/pkg/metautil"
 	"github.com/pingcap/tidb/br/pkg/mock/mockid"
@@ -52,6 +53,7 @@ func NewDebugCommand() *cobra.Command {
 		Aliases: []string{"validate"},
 	}
 	meta.AddCommand(newCheckSumCommand())
+	meta.AddCommand(newVerifyCommand())
 	meta.AddCommand(newBackupMetaCommand())
 	meta.AddCommand(decodeBackupMetaCommand())
 	meta.AddCommand(encodeBackupMetaCommand())
@@ -144,6 +146,30 @@ origin sha256 is %s`,
 	return command
 }
 
+func newVerifyCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "verify",
+		Short: "read every SST file of the backup and verify the checksums",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx, cancel := context.WithCancel(GetDefaultContext())
+			defer cancel()
+
+			var cfg task.VerifyConfig
+			if err := cfg.ParseFromFlags(cmd.Flags()); err != nil {
+				return errors.Trace(err)
+			}
+			if err := task.RunVerify(ctx, gluetikv.Glue{}, "Verify", &cfg); err != nil {
+				log.Error("failed to verify backup", zap.Error(err))
+				return errors.Trace(err)
+			}
+			return nil
+		},
+	}
+	task.DefineVerifyFlags(command.Flags())
+	return command
+}
+
 func newBackupMetaCommand() *cobra.Command {
 	command := &cobra.Command{
 		Use:          "backupmeta",
@@ -37,6 +37,7 @@ func newOperatorCommand() *cobra.Command {
 	cmd.AddCommand(newMigrateToCommand())
 	cmd.AddCommand(newForceFlushCommand())
 	cmd.AddCommand(newChecksumCommand())
+	cmd.AddCommand(newPruneCommand())
 	return cmd
 }
 
@@ -153,3 +154,25 @@ func newForceFlushCommand() *cobra.Command {
 	operator.DefineFlagsForForceFlushConfig(cmd.Flags())
 	return cmd
 }
+
+func newPruneCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "delete the snapshot backups and log backup not needed by the retention policy",
+		Long: "Delete the snapshot backups in the direct sub directories of `--full-backup-storage` " +
+			"and truncate the log backup (specified by `-s`) by the retention policy. " +
+			"The latest `--keep-full` snapshot backups are kept, and so are the snapshot backups and " +
+			"the log backup needed to restore to any point in the `--keep-pitr-window` until now.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := operator.PruneConfig{}
+			if err := cfg.ParseFromFlags(cmd.Flags()); err != nil {
+				return err
+			}
+			ctx := GetDefaultContext()
+			return operator.RunPrune(ctx, tidbGlue, cfg)
+		},
+	}
+	operator.DefineFlagsForPruneConfig(cmd.Flags())
+	return cmd
+}
@@ -16,6 +16,7 @@ const (
 	StorageVendorNameAWS   = "aws"
 	StorageVendorNameAzure = "azure"
 	StorageVendorNameGCP   = "gcp"
+	StorageVendorNameVault = "vault"
 )
 
 // Backend is an interface that defines the methods required for an encryption backend.
@@ -70,6 +71,12 @@ func createCloudBackend(config *encryptionpb.MasterKeyKms) (Backend, error) {
 			return nil, errors.Annotate(err, "new GCP KMS")
 		}
 		return NewKmsBackend(kmsProvider)
+	case StorageVendorNameVault:
+		kmsProvider, err := kms.NewVaultKms(config)
+		if err != nil {
+			return nil, errors.Annotate(err, "new Vault KMS")
+		}
+		return NewKmsBackend(kmsProvider)
 
 	default:
 		return nil, errors.Errorf("vendor not found: %s", config.Vendor)
@@ -0,0 +1,248 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package kms
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/kvproto/pkg/encryptionpb"
+)
+
+const (
+	// EncryptionVendorNameVault is the vendor name of the HashiCorp Vault transit secrets engine.
+	EncryptionVendorNameVault = "vault"
+
+	// The environment variables are the same as the ones used by the Vault CLI.
+	VaultEnvAddress     = "VAULT_ADDR"
+	VaultEnvToken       = "VAULT_TOKEN"
+	VaultEnvNamespace   = "VAULT_NAMESPACE"
+	VaultEnvCACert      = "VAULT_CACERT"
+	VaultEnvRoleID      = "VAULT_ROLE_ID"
+	VaultEnvSecretID    = "VAULT_SECRET_ID"
+	VaultEnvAppRolePath = "VAULT_APPROLE_PATH"
+
+	defaultVaultAppRolePath = "approle"
+	vaultCiphertextPrefix   = "vault:v"
+	vaultRequestTimeout     = 30 * time.Second
+)
+
+// VaultKms decrypts the data keys by the transit secrets engine of HashiCorp
+// Vault. It authenticates with a token, or logs in by AppRole when the role ID
+// and the secret ID are provided.
+type VaultKms struct {
+	client    *http.Client
+	address   string
+	mountPath string
+	keyName   string
+	namespace string
+
+	roleID      string
+	secretID    string
+	appRolePath string
+
+	mu    sync.Mutex
+	token string
+}
+
+// NewVaultKms creates a Vault transit KMS provider. The key id is in the format
+// of `{mount-path}/{key-name}`, and the endpoint is the address of Vault. The
+// credentials are read from the environment variables, so they won't be
+// recorded in the master key config.
+func NewVaultKms(config *encryptionpb.MasterKeyKms) (*VaultKms, error) {
+	keyID := strings.Trim(config.KeyId, "/")
+	idx := strings.LastIndex(keyID, "/")
+	if idx <= 0 || idx == len(keyID)-1 {
+		return nil, errors.Errorf("invalid Vault transit key id %s, expect {mount-path}/{key-name}", config.KeyId)
+	}
+	address := config.Endpoint
+	if address == "" {
+		address = os.Getenv(VaultEnvAddress)
+	}
+	if address == "" {
+		return nil, errors.Errorf("missing Vault address, set ENDPOINT or %s", VaultEnvAddress)
+	}
+
+	v := &VaultKms{
+		address:     strings.TrimSuffix(address, "/"),
+		mountPath:   keyID[:idx],
+		keyName:     keyID[idx+1:],
+		namespace:   os.Getenv(VaultEnvNamespace),
+		roleID:      os.Getenv(VaultEnvRoleID),
+		secretID:    os.Getenv(VaultEnvSecretID),
+		appRolePath: os.Getenv(VaultEnvAppRolePath),
+		token:       os.Getenv(VaultEnvToken),
+	}
+	if v.appRolePath == "" {
+		v.appRolePath = defaultVaultAppRolePath
+	}
+	if v.token == "" && (v.roleID == "" || v.secretID == "") {
+		return nil, errors.Errorf("missing Vault credentials, set %s, or %s and %s for AppRole",
+			VaultEnvToken, VaultEnvRoleID, VaultEnvSecretID)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if caPath := os.Getenv(VaultEnvCACert); caPath != "" {
+		ca, err := os.ReadFile(caPath)
+		if err != nil {
+			return nil, errors.Annotate(err, "failed to read Vault CA certificate")
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, errors.Errorf("no certificate found in %s", caPath)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+	v.client = &http.Client{Transport: transport, Timeout: vaultRequestTimeout}
+	return v, nil
+}
+
+func (v *VaultKms) Name() string {
+	return EncryptionVendorNameVault
+}
+
+// DecryptDataKey decrypts the data key, which is the ciphertext returned by
+// the transit engine, e.g. `vault:v2:...`. The ciphertext records the version
+// of the key, so the data keys encrypted before rotating the key can be
+// decrypted as long as the version isn't below the min decryption version.
+func (v *VaultKms) DecryptDataKey(ctx context.Context, dataKey []byte) ([]byte, error) {
+	version, err := parseVaultKeyVersion(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	req := map[string]string{"ciphertext": string(dataKey)}
+	var resp struct {
+		Data struct {
+			Plaintext string `json:"plaintext"`
+		} `json:"data"`
+	}
+	path := fmt.Sprintf("%s/decrypt/%s", v.mountPath, v.keyName)
+	if err := v.requestWithLogin(ctx, path, req, &resp); err != nil {
+		return nil, errors.Annotatef(err, "failed to decrypt data key by version %d of Vault transit key %s", version, v.keyName)
+	}
+	plaintext, err := base64.StdEncoding.DecodeString(resp.Data.Plaintext)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid plaintext returned by Vault")
+	}
+	return plaintext, nil
+}
+
+func (v *VaultKms) Close() {
+	v.client.CloseIdleConnections()
+}
+
+func parseVaultKeyVersion(ciphertext []byte) (int, error) {
+	s := string(ciphertext)
+	if !strings.HasPrefix(s, vaultCiphertextPrefix) {
+		return 0, errors.New("wrong master key: the data key isn't encrypted by Vault transit engine")
+	}
+	s = s[len(vaultCiphertextPrefix):]
+	idx := strings.IndexByte(s, ':')
+	if idx < 0 {
+		return 0, errors.New("wrong master key: invalid Vault ciphertext")
+	}
+	version, err := strconv.Atoi(s[:idx])
+	if err != nil || version <= 0 {
+		return 0, errors.Errorf("wrong master key: invalid key version %q in Vault ciphertext", s[:idx])
+	}
+	return version, nil
+}
+
+// vaultError is the error returned by the Vault HTTP API.
+type vaultError struct {
+	StatusCode int
+	Errors     []string `json:"errors"`
+}
+
+func (e *vaultError) Error() string {
+	return fmt.Sprintf("vault responded %d: %s", e.StatusCode, strings.Join(e.Errors, "; "))
+}
+
+// requestWithLogin sends the request with the current token, and logs in by
+// AppRole again if the token is missing or expired.
+func (v *VaultKms) requestWithLogin(ctx context.Context, path string, req, resp any) error {
+	v.mu.Lock()
+	token := v.token
+	v.mu.Unlock()
+	canLogin := v.roleID != "" && v.secretID != ""
+	if token == "" {
+		var err error
+		if token, err = v.login(ctx); err != nil {
+			return err
+		}
+	}
+	err := v.request(ctx, path, token, req, resp)
+	// the token is expired or revoked
+	if vErr, ok := err.(*vaultError); ok && canLogin && vErr.StatusCode == http.StatusForbidden {
+		if token, err = v.login(ctx); err != nil {
+			return err
+		}
+		err = v.request(ctx, path, token, req, resp)
+	}
+	return err
+}
+
+func (v *VaultKms) login(ctx context.Context) (string, error) {
+	req := map[string]string{"role_id": v.roleID, "secret_id": v.secretID}
+	var resp struct {
+		Auth struct {
+			ClientToken string `json:"client_token"`
+		} `json:"auth"`
+	}
+	if err := v.request(ctx, fmt.Sprintf("auth/%s/login", v.appRolePath), "", req, &resp); err != nil {
+		return "", errors.Annotate(err, "failed to log in Vault by AppRole")
+	}
+	if resp.Auth.ClientToken == "" {
+		return "", errors.New("no token returned by Vault AppRole login")
+	}
+	v.mu.Lock()
+	v.token = resp.Auth.ClientToken
+	v.mu.Unlock()
+	return resp.Auth.ClientToken, nil
+}
+
+func (v *VaultKms) request(ctx context.Context, path, token string, req, resp any) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, v.address+"/v1/"+path, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		httpReq.Header.Set("X-Vault-Token", token)
+	}
+	if v.namespace != "" {
+		httpReq.Header.Set("X-Vault-Namespace", v.namespace)
+	}
+	httpResp, err := v.client.Do(httpReq)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer httpResp.Body.Close()
+	data, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		vErr := &vaultError{StatusCode: httpResp.StatusCode}
+		_ = json.Unmarshal(data, vErr)
+		return vErr
+	}
+	return errors.Trace(json.Unmarshal(data, resp))
+}
@@ -0,0 +1,137 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package kms
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pingcap/kvproto/pkg/encryptionpb"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeVault is a stand-in of the Vault transit engine, which "encrypts" the
+// plaintext by prefixing the key version.
+type fakeVault struct {
+	token        atomic.Value
+	loginCounter atomic.Int32
+}
+
+func (f *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req map[string]string
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	writeJSON := func(code int, v any) {
+		w.WriteHeader(code)
+		_ = json.NewEncoder(w).Encode(v)
+	}
+	switch r.URL.Path {
+	case "/v1/auth/approle/login":
+		if req["role_id"] != "role" || req["secret_id"] != "secret" {
+			writeJSON(http.StatusBadRequest, map[string]any{"errors": []string{"invalid role or secret ID"}})
+			return
+		}
+		f.loginCounter.Add(1)
+		f.token.Store("approle-token")
+		writeJSON(http.StatusOK, map[string]any{"auth": map[string]any{"client_token": "approle-token"}})
+	case "/v1/transit/decrypt/backup-key":
+		if r.Header.Get("X-Vault-Token") != f.token.Load() {
+			writeJSON(http.StatusForbidden, map[string]any{"errors": []string{"permission denied"}})
+			return
+		}
+		ciphertext := req["ciphertext"]
+		if !strings.HasPrefix(ciphertext, "vault:v1:") && !strings.HasPrefix(ciphertext, "vault:v2:") {
+			writeJSON(http.StatusBadRequest, map[string]any{"errors": []string{"invalid ciphertext"}})
+			return
+		}
+		writeJSON(http.StatusOK, map[string]any{"data": map[string]any{"plaintext": ciphertext[len("vault:v1:"):]}})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestVaultKmsDecryptDataKey(t *testing.T) {
+	ctx := context.Background()
+	vault := &fakeVault{}
+	vault.token.Store("root-token")
+	server := httptest.NewServer(vault)
+	defer server.Close()
+
+	config := &encryptionpb.MasterKeyKms{Vendor: "vault", KeyId: "transit/backup-key", Endpoint: server.URL}
+	t.Setenv(VaultEnvToken, "")
+	t.Setenv(VaultEnvRoleID, "")
+	_, err := NewVaultKms(config)
+	require.ErrorContains(t, err, "missing Vault credentials")
+
+	t.Setenv(VaultEnvToken, "root-token")
+	v, err := NewVaultKms(config)
+	require.NoError(t, err)
+	defer v.Close()
+	require.Equal(t, "vault", v.Name())
+
+	dataKey := []byte("0123456789abcdef0123456789abcdef")
+	encoded := base64.StdEncoding.EncodeToString(dataKey)
+	// the data keys encrypted by the different key versions
+	for _, version := range []string{"v1", "v2"} {
+		plaintext, err := v.DecryptDataKey(ctx, []byte("vault:"+version+":"+encoded))
+		require.NoError(t, err)
+		require.Equal(t, dataKey, plaintext)
+	}
+	_, err = v.DecryptDataKey(ctx, []byte("vault:v3:"+encoded))
+	require.ErrorContains(t, err, "invalid ciphertext")
+	_, err = v.DecryptDataKey(ctx, []byte("not-vault-ciphertext"))
+	require.ErrorContains(t, err, "wrong master key")
+
+	// the token is revoked
+	vault.token.Store("other-token")
+	_, err = v.DecryptDataKey(ctx, []byte("vault:v1:"+encoded))
+	require.ErrorContains(t, err, "permission denied")
+}
+
+func TestVaultKmsAppRole(t *testing.T) {
+	ctx := context.Background()
+	vault := &fakeVault{}
+	server := httptest.NewServer(vault)
+	defer server.Close()
+
+	t.Setenv(VaultEnvAddress, server.URL)
+	t.Setenv(VaultEnvRoleID, "role")
+	t.Setenv(VaultEnvSecretID, "secret")
+	v, err := NewVaultKms(&encryptionpb.MasterKeyKms{Vendor: "vault", KeyId: "transit/backup-key"})
+	require.NoError(t, err)
+	defer v.Close()
+
+	dataKey := []byte("0123456789abcdef0123456789abcdef")
+	ciphertext := []byte("vault:v1:" + base64.StdEncoding.EncodeToString(dataKey))
+	plaintext, err := v.DecryptDataKey(ctx, ciphertext)
+	require.NoError(t, err)
+	require.Equal(t, dataKey, plaintext)
+	require.Equal(t, int32(1), vault.loginCounter.Load())
+
+	// the token is reused until it expires
+	_, err = v.DecryptDataKey(ctx, ciphertext)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), vault.loginCounter.Load())
+	vault.token.Store("expired")
+	plaintext, err = v.DecryptDataKey(ctx, ciphertext)
+	require.NoError(t, err)
+	require.Equal(t, dataKey, plaintext)
+	require.Equal(t, int32(2), vault.loginCounter.Load())
+}
+
+func TestNewVaultKmsInvalidConfig(t *testing.T) {
+	t.Setenv(VaultEnvToken, "token")
+	t.Setenv(VaultEnvAddress, "")
+	_, err := NewVaultKms(&encryptionpb.MasterKeyKms{Vendor: "vault", KeyId: "backup-key", Endpoint: "http://127.0.0.1:8200"})
+	require.ErrorContains(t, err, "invalid Vault transit key id")
+	_, err = NewVaultKms(&encryptionpb.MasterKeyKms{Vendor: "vault", KeyId: "transit/backup-key"})
+	require.ErrorContains(t, err, "missing Vault address")
+}
@@ -1029,6 +1029,8 @@ type GetIDMapConfig struct {
 	CipherInfo              *backuppb.CipherInfo
 	// generated at full restore step that contains all the table ids that need to restore
 	PiTRTableTracker *utils.PiTRIdTracker
+	// the names the databases and tables are restored as
+	NameMapping *utils.NameMapping
 }
 
 const UnsafePITRLogRestoreStartBeforeAnyUpstreamUserDDL = "UNSAFE_PITR_LOG_RESTORE_START_BEFORE_ANY_UPSTREAM_USER_DDL"
@@ -1059,6 +1061,7 @@ func (rc *LogClient) generateDBReplacesFromFullBackupStorage(
 	}
 	for _, t := range filteredFullBackupTables {
 		dbName, _ := utils.GetSysDBCIStrName(t.DB.Name)
+		dbName = cfg.NameMapping.DBName(dbName)
 		newDBInfo, exist := rc.dom.InfoSchema().SchemaByName(dbName)
 		if !exist {
 			log.Info("db does not exist", zap.String("dbName", dbName.String()))
@@ -1075,9 +1078,10 @@ func (rc *LogClient) generateDBReplacesFromFullBackupStorage(
 			// If the db is empty, skip it.
 			continue
 		}
-		newTableInfo, err := restore.GetTableSchema(rc.GetDomain(), dbName, t.Info.Name)
+		tableName := cfg.NameMapping.TableName(t.DB.Name, t.Info.Name)
+		newTableInfo, err := restore.GetTableSchema(rc.GetDomain(), dbName, tableName)
 		if err != nil {
-			log.Info("table doesn't exist", zap.String("tableName", dbName.String()+"."+t.Info.Name.String()))
+			log.Info("table doesn't exist", zap.String("tableName", dbName.String()+"."+tableName.String()))
 			continue
 		}
 
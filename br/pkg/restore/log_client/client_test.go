@@ -1546,7 +1546,7 @@ func TestPITRIDMapOnCheckpointStorage(t *testing.T) {
 	require.NoError(t, err)
 	client := logclient.TEST_NewLogClient(123, 1, 2, 3, s.Mock.Domain, se)
 	client.SetUseCheckpoint()
-	stg, err := storage.NewLocalStorage("local://" + filepath.ToSlash(t.TempDir()))
+	stg, err := storage.NewLocalStorage(t.TempDir())
 	require.NoError(t, err)
 	logCheckpointMetaManager := checkpoint.NewLogStorageMetaManager(
 		stg, nil, 123, "test")
@@ -1560,7 +1560,7 @@ func TestPITRIDMapOnCheckpointStorage(t *testing.T) {
 	require.NoError(t, err)
 	require.Nil(t, newSchemaReplaces)
 	client2 := logclient.TEST_NewLogClient(123, 1, 2, 4, s.Mock.Domain, se)
-	stg, err = storage.NewLocalStorage("local://" + filepath.ToSlash(t.TempDir()) + "/temp_another")
+	stg, err = storage.NewLocalStorage(filepath.Join(t.TempDir(), "temp_another"))
 	require.NoError(t, err)
 	logCheckpointMetaManager2 := checkpoint.NewLogStorageMetaManager(
 		stg, nil, 123, "test")
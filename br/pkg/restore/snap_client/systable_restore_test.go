@@ -117,5 +117,5 @@ func TestCheckSysTableCompatibility(t *testing.T) {
 
 // The above variables are in the file br/pkg/restore/systable_restore.go
 func TestMonitorTheSystemTableIncremental(t *testing.T) {
-	require.Equal(t, int64(247), session.CurrentBootstrapVersion)
+	require.Equal(t, int64(248), session.CurrentBootstrapVersion)
 }
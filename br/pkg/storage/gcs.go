@@ -322,6 +322,34 @@ func (s *GCSStorage) WalkDir(ctx context.Context, opt *WalkOption, fn func(strin
 	return nil
 }
 
+// ListSubDirs implements SubDirLister. It lists the prefixes by the
+// delimiter, so the objects under the sub directories aren't listed.
+func (s *GCSStorage) ListSubDirs(ctx context.Context) ([]string, error) {
+	prefix := s.gcs.Prefix
+	if len(prefix) > 0 && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	query := &storage.Query{Prefix: prefix, Delimiter: "/"}
+	if err := query.SetAttrSelection([]string{"Name"}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	iter := s.GetBucketHandle().Objects(ctx, query)
+	var dirs []string
+	for {
+		attrs, err := iter.Next()
+		if err == iterator.Done {
+			return dirs, nil
+		}
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		// the objects directly in the prefix have empty Prefix
+		if attrs.Prefix != "" {
+			dirs = append(dirs, strings.TrimSuffix(strings.TrimPrefix(attrs.Prefix, prefix), "/"))
+		}
+	}
+}
+
 func (s *GCSStorage) URI() string {
 	return "gcs://" + s.gcs.Bucket + "/" + s.gcs.Prefix
 }
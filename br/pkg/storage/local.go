@@ -112,6 +112,21 @@ func (l *LocalStorage) FileExists(_ context.Context, name string) (bool, error)
 	return pathExists(path)
 }
 
+// ListSubDirs implements SubDirLister.
+func (l *LocalStorage) ListSubDirs(_ context.Context) ([]string, error) {
+	entries, err := os.ReadDir(l.base)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var dirs []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			dirs = append(dirs, entry.Name())
+		}
+	}
+	return dirs, nil
+}
+
 // WalkDir traverse all the files in a dir.
 //
 // fn is the function called for each regular file visited by WalkDir.
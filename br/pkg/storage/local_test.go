@@ -157,6 +157,19 @@ func TestWalkDirSkipSubDir(t *testing.T) {
 	}))
 }
 
+func TestLocalListSubDirs(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, os.WriteFile(path.Join(tempDir, "test1.txt"), []byte("test1"), 0o644))
+	require.NoError(t, os.MkdirAll(path.Join(tempDir, "sub1", "sub2"), 0o755))
+	require.NoError(t, os.MkdirAll(path.Join(tempDir, "sub3"), 0o755))
+
+	store, err := NewLocalStorage(tempDir)
+	require.NoError(t, err)
+	dirs, err := store.ListSubDirs(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []string{"sub1", "sub3"}, dirs)
+}
+
 func TestLocalURI(t *testing.T) {
 	ctx := context.Background()
 
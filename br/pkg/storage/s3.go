@@ -800,6 +800,49 @@ func (rs *S3Storage) WalkDir(ctx context.Context, opt *WalkOption, fn func(strin
 	return nil
 }
 
+// ListSubDirs implements SubDirLister. It lists the common prefixes by the
+// delimiter, so the objects under the sub directories aren't listed.
+func (rs *S3Storage) ListSubDirs(ctx context.Context) ([]string, error) {
+	prefix := rs.options.Prefix
+	if len(prefix) > 0 && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	req := &s3.ListObjectsInput{
+		Bucket:    aws.String(rs.options.Bucket),
+		Prefix:    aws.String(prefix),
+		Delimiter: aws.String("/"),
+	}
+	var dirs []string
+	for {
+		res, err := rs.svc.ListObjectsWithContext(ctx, req)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, p := range res.CommonPrefixes {
+			dirs = append(dirs, strings.TrimSuffix(strings.TrimPrefix(*p.Prefix, prefix), "/"))
+		}
+		if !aws.BoolValue(res.IsTruncated) {
+			return dirs, nil
+		}
+		// `res.NextMarker` is populated when req.Delimiter is specified, but
+		// some S3 compatible storages don't, then the last listed key or
+		// prefix is used as the marker.
+		marker := aws.StringValue(res.NextMarker)
+		if marker == "" {
+			if n := len(res.Contents); n > 0 {
+				marker = aws.StringValue(res.Contents[n-1].Key)
+			}
+			if n := len(res.CommonPrefixes); n > 0 {
+				marker = max(marker, aws.StringValue(res.CommonPrefixes[n-1].Prefix))
+			}
+			if marker == "" {
+				return nil, errors.Errorf("no marker to list the next page of %s", prefix)
+			}
+		}
+		req.Marker = aws.String(marker)
+	}
+}
+
 // URI returns s3://<base>/<prefix>.
 func (rs *S3Storage) URI() string {
 	return "s3://" + rs.options.Bucket + "/" + rs.options.Prefix
@@ -1220,6 +1220,61 @@ func TestWalkDir(t *testing.T) {
 }
 
 // TestWalkDirBucket checks WalkDir retrieves all directory content under a bucket.
+func TestListSubDirs(t *testing.T) {
+	controller := gomock.NewController(t)
+	s3API := mock.NewMockS3API(controller)
+	storage := NewS3StorageForTest(
+		s3API,
+		&backuppb.S3{
+			Region: "us-west-2",
+			Bucket: "bucket",
+			Prefix: "prefix",
+		},
+	)
+	defer controller.Finish()
+	ctx := aws.BackgroundContext()
+
+	firstCall := s3API.EXPECT().
+		ListObjectsWithContext(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, input *s3.ListObjectsInput, opt ...request.Option) (*s3.ListObjectsOutput, error) {
+			require.Equal(t, "bucket", aws.StringValue(input.Bucket))
+			require.Equal(t, "prefix/", aws.StringValue(input.Prefix))
+			require.Equal(t, "/", aws.StringValue(input.Delimiter))
+			require.Equal(t, "", aws.StringValue(input.Marker))
+			return &s3.ListObjectsOutput{
+				IsTruncated:    aws.Bool(true),
+				NextMarker:     aws.String("prefix/b/"),
+				Contents:       []*s3.Object{{Key: aws.String("prefix/a.txt"), Size: aws.Int64(1)}},
+				CommonPrefixes: []*s3.CommonPrefix{{Prefix: aws.String("prefix/a/")}, {Prefix: aws.String("prefix/b/")}},
+			}, nil
+		})
+	secondCall := s3API.EXPECT().
+		ListObjectsWithContext(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, input *s3.ListObjectsInput, opt ...request.Option) (*s3.ListObjectsOutput, error) {
+			require.Equal(t, "prefix/b/", aws.StringValue(input.Marker))
+			// the last prefix is used as the marker without NextMarker
+			return &s3.ListObjectsOutput{
+				IsTruncated:    aws.Bool(true),
+				CommonPrefixes: []*s3.CommonPrefix{{Prefix: aws.String("prefix/c/")}},
+			}, nil
+		}).
+		After(firstCall)
+	s3API.EXPECT().
+		ListObjectsWithContext(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, input *s3.ListObjectsInput, opt ...request.Option) (*s3.ListObjectsOutput, error) {
+			require.Equal(t, "prefix/c/", aws.StringValue(input.Marker))
+			return &s3.ListObjectsOutput{
+				IsTruncated:    aws.Bool(false),
+				CommonPrefixes: []*s3.CommonPrefix{{Prefix: aws.String("prefix/d/")}},
+			}, nil
+		}).
+		After(secondCall)
+
+	dirs, err := storage.ListSubDirs(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b", "c", "d"}, dirs)
+}
+
 func TestWalkDirWithEmptyPrefix(t *testing.T) {
 	controller := gomock.NewController(t)
 	s3API := mock.NewMockS3API(controller)
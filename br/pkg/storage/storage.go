@@ -126,6 +126,14 @@ type CopySpec struct {
 	To   string
 }
 
+// SubDirLister is implemented by the storages which can list the direct sub
+// directories without walking all the files under them.
+type SubDirLister interface {
+	// ListSubDirs returns the names of the direct sub directories of the base
+	// directory.
+	ListSubDirs(ctx context.Context) ([]string, error)
+}
+
 // ExternalStorage represents a kind of file system storage.
 type ExternalStorage interface {
 	// WriteFile writes a complete file to storage, similar to os.WriteFile, but WriteFile should be atomic
@@ -31,6 +31,7 @@ import (
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/meta"
 	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/ast"
 	"go.uber.org/zap"
 )
 
@@ -62,6 +63,8 @@ type SchemasReplace struct {
 	ingestRecorder   *ingestrec.IngestRecorder
 	TiflashRecorder  *tiflashrec.TiFlashRecorder
 	RewriteTS        uint64 // used to rewrite commit ts in meta kv.
+	// NameMapping renames the databases and tables in meta kv.
+	NameMapping *utils.NameMapping
 
 	AfterTableRewrittenFn func(deleted bool, tableInfo *model.TableInfo)
 }
@@ -158,6 +161,7 @@ func (sr *SchemasReplace) rewriteDBInfo(value []byte) ([]byte, error) {
 	}
 
 	dbInfo.ID = dbMap.DbID
+	dbInfo.Name = sr.NameMapping.DBName(dbInfo.Name)
 	newValue, err := json.Marshal(dbInfo)
 	if err != nil {
 		return nil, err
@@ -271,6 +275,7 @@ func (sr *SchemasReplace) rewriteTableInfo(value []byte, dbID int64) ([]byte, er
 
 	// update table ID and partition ID.
 	tableInfo.ID = tableReplace.TableID
+	tableInfo.Name = sr.NameMapping.TableName(ast.NewCIStr(dbReplace.Name), tableInfo.Name)
 	partitions := tableInfo.GetPartitionInfo()
 	if partitions != nil {
 		for i, tbl := range partitions.Definitions {
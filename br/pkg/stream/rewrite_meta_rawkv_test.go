@@ -244,6 +244,51 @@ func TestRewriteTableInfo(t *testing.T) {
 	require.EqualValues(t, tableCount, 2)
 }
 
+func TestRewriteInfoWithNameMapping(t *testing.T) {
+	var (
+		dbID      int64 = 40
+		dbName          = "db"
+		tableID   int64 = 100
+		tableName       = "t1"
+		dbInfo    model.DBInfo
+		tableInfo model.TableInfo
+	)
+
+	dbMap := make(map[UpstreamID]*DBReplace)
+	dbMap[dbID] = NewDBReplace(dbName, dbID+100)
+	dbMap[dbID].TableMap[tableID] = NewTableReplace(tableName, tableID+100)
+	dbMap[dbID].TableMap[tableID+1] = NewTableReplace("t2", tableID+101)
+	sr := MockEmptySchemasReplace(nil, dbMap)
+	mapping, err := utils.ParseNameMapping([]string{"db:db_bak"}, []string{"db.t1:db_bak.t1_bak"})
+	require.NoError(t, err)
+	sr.NameMapping = mapping
+
+	value, err := produceDBInfoValue(dbName, dbID)
+	require.NoError(t, err)
+	newValue, err := sr.rewriteDBInfo(value)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(newValue, &dbInfo))
+	require.Equal(t, dbID+100, dbInfo.ID)
+	require.Equal(t, "db_bak", dbInfo.Name.O)
+
+	value, err = produceTableInfoValue(tableName, tableID)
+	require.NoError(t, err)
+	newValue, err = sr.rewriteTableInfo(value, dbID)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(newValue, &tableInfo))
+	require.Equal(t, tableID+100, tableInfo.ID)
+	require.Equal(t, "t1_bak", tableInfo.Name.O)
+
+	// the tables without rules keep their names
+	value, err = produceTableInfoValue("t2", tableID+1)
+	require.NoError(t, err)
+	newValue, err = sr.rewriteTableInfo(value, dbID)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(newValue, &tableInfo))
+	require.Equal(t, tableID+101, tableInfo.ID)
+	require.Equal(t, "t2", tableInfo.Name.O)
+}
+
 func TestRewriteTableInfoForPartitionTable(t *testing.T) {
 	var (
 		dbId      int64 = 40
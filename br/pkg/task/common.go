@@ -355,7 +355,9 @@ func DefineCommonFlags(flags *pflag.FlagSet) {
 	flags.String(flagMasterKeyConfig, "", "Master key config for point in time restore "+
 		"examples: \"local:///path/to/master/key/file,"+
 		"aws-kms:///{key-id}?AWS_ACCESS_KEY_ID={access-key}&AWS_SECRET_ACCESS_KEY={secret-key}&REGION={region},"+
-		"gcp-kms:///projects/{project-id}/locations/{location}/keyRings/{keyring}/cryptoKeys/{key-name}?AUTH=specified&CREDENTIALS={credentials}\"")
+		"gcp-kms:///projects/{project-id}/locations/{location}/keyRings/{keyring}/cryptoKeys/{key-name}?AUTH=specified&CREDENTIALS={credentials},"+
+		"vault-kms:///{transit-mount-path}/{key-name}?ENDPOINT={vault-address}\", "+
+		"the Vault credentials are read from VAULT_TOKEN, or VAULT_ROLE_ID and VAULT_SECRET_ID for AppRole")
 	_ = flags.MarkHidden(flagMetadataDownloadBatchSize)
 
 	storage.DefineFlags(flags)
@@ -323,6 +323,8 @@ func expectedDefaultRestoreConfig() RestoreConfig {
 		WithPlacementPolicy:      "STRICT",
 		UseCheckpoint:            true,
 		AllowPITRFromIncremental: true,
+		RewriteDBs:               []string{},
+		RewriteTables:            []string{},
 	}
 }
 
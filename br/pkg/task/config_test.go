@@ -292,3 +292,49 @@ func mockBackupMeta(mockSchemas []*backuppb.Schema, mockFiles []*backuppb.File)
 		Schemas: mockSchemas,
 	}
 }
+
+func TestApplyNameMapping(t *testing.T) {
+	newMaps := func() (map[int64]*metautil.Table, map[int64]*metautil.Database) {
+		db1 := &model.DBInfo{ID: 1, Name: ast.NewCIStr("db1")}
+		db2 := &model.DBInfo{ID: 2, Name: ast.NewCIStr("db2")}
+		dbMap := map[int64]*metautil.Database{
+			1: {Info: db1},
+			2: {Info: db2},
+		}
+		tableMap := map[int64]*metautil.Table{
+			11: {DB: db1, Info: &model.TableInfo{ID: 11, Name: ast.NewCIStr("t1")}},
+			12: {DB: db1, Info: &model.TableInfo{ID: 12, Name: ast.NewCIStr("t2")}},
+			21: {DB: db2, Info: &model.TableInfo{ID: 21, Name: ast.NewCIStr("t1")}},
+		}
+		return tableMap, dbMap
+	}
+
+	tableMap, dbMap := newMaps()
+	origDB := dbMap[1].Info
+	m, err := utils.ParseNameMapping([]string{"db1:db1_bak"}, []string{"db1.t1:db1_bak.t1_bak", "db2.t1:db2.t1_bak"})
+	require.NoError(t, err)
+	require.NoError(t, applyNameMapping(m, tableMap, dbMap))
+	require.Equal(t, "db1_bak", dbMap[1].Info.Name.O)
+	require.Equal(t, int64(1), dbMap[1].Info.ID)
+	require.Equal(t, "db2", dbMap[2].Info.Name.O)
+	// the infos in the backup are left untouched
+	require.Equal(t, "db1", origDB.Name.O)
+	require.Same(t, dbMap[1].Info, tableMap[11].DB)
+	require.Same(t, dbMap[1].Info, tableMap[12].DB)
+	require.Equal(t, "t1_bak", tableMap[11].Info.Name.O)
+	require.Equal(t, int64(11), tableMap[11].Info.ID)
+	require.Equal(t, "t2", tableMap[12].Info.Name.O)
+	require.Equal(t, "t1_bak", tableMap[21].Info.Name.O)
+
+	// the renamed database collides with another restored one
+	tableMap, dbMap = newMaps()
+	m, err = utils.ParseNameMapping([]string{"db1:db2"}, nil)
+	require.NoError(t, err)
+	require.ErrorContains(t, applyNameMapping(m, tableMap, dbMap), "more than one database is restored as db2")
+
+	// the renamed table collides with another restored one
+	tableMap, dbMap = newMaps()
+	m, err = utils.ParseNameMapping(nil, []string{"db1.t1:db1.t2"})
+	require.NoError(t, err)
+	require.ErrorContains(t, applyNameMapping(m, tableMap, dbMap), "more than one table is restored as `db1`.`t2`")
+}
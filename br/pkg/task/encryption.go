@@ -16,6 +16,7 @@ const (
 	SchemeAWS   = "aws-kms"
 	SchemeAzure = "azure-kms"
 	SchemeGCP   = "gcp-kms"
+	SchemeVault = "vault-kms"
 
 	AWSVendor      = "aws"
 	AWSRegion      = "REGION"
@@ -31,12 +32,16 @@ const (
 
 	GCPVendor      = "gcp"
 	GCPCredentials = "CREDENTIALS"
+
+	VaultVendor   = "vault"
+	VaultEndpoint = "ENDPOINT"
 )
 
 var (
 	awsRegex   = regexp.MustCompile(`^/([^/]+)$`)
 	azureRegex = regexp.MustCompile(`^/(.+)$`)
 	gcpRegex   = regexp.MustCompile(`^/projects/([^/]+)/locations/([^/]+)/keyRings/([^/]+)/cryptoKeys/([^/]+)/?$`)
+	vaultRegex = regexp.MustCompile(`^/(.+)/([^/]+)$`)
 )
 
 func validateAndParseMasterKeyString(keyString string) (encryptionpb.MasterKey, error) {
@@ -54,6 +59,8 @@ func validateAndParseMasterKeyString(keyString string) (encryptionpb.MasterKey,
 		return parseAzureKmsConfig(u)
 	case SchemeGCP:
 		return parseGcpKmsConfig(u)
+	case SchemeVault:
+		return parseVaultKmsConfig(u)
 	default:
 		return encryptionpb.MasterKey{}, errors.Errorf("unsupported master key type: %s", u.Scheme)
 	}
@@ -169,3 +176,23 @@ func parseGcpKmsConfig(u *url.URL) (encryptionpb.MasterKey, error) {
 		},
 	}, nil
 }
+
+// parseVaultKmsConfig parses the config of the Vault transit secrets engine.
+// The credentials are read from the environment variables, such as VAULT_TOKEN.
+func parseVaultKmsConfig(u *url.URL) (encryptionpb.MasterKey, error) {
+	matches := vaultRegex.FindStringSubmatch(u.Path)
+	if matches == nil {
+		return encryptionpb.MasterKey{}, errors.New("invalid Vault transit key path format")
+	}
+	mountPath, keyName := matches[1], matches[2]
+
+	return encryptionpb.MasterKey{
+		Backend: &encryptionpb.MasterKey_Kms{
+			Kms: &encryptionpb.MasterKeyKms{
+				Vendor:   VaultVendor,
+				KeyId:    fmt.Sprintf("%s/%s", mountPath, keyName),
+				Endpoint: u.Query().Get(VaultEndpoint), // Optional, can read from env
+			},
+		},
+	}, nil
+}
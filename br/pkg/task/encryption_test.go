@@ -194,3 +194,59 @@ func TestParseGcpKmsConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestParseVaultKmsConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expected    encryptionpb.MasterKey
+		expectError bool
+	}{
+		{
+			name:  "Valid Vault config",
+			input: "vault-kms:///transit/backup-key?ENDPOINT=https://vault.example.com:8200",
+			expected: encryptionpb.MasterKey{
+				Backend: &encryptionpb.MasterKey_Kms{
+					Kms: &encryptionpb.MasterKeyKms{
+						Vendor:   "vault",
+						KeyId:    "transit/backup-key",
+						Endpoint: "https://vault.example.com:8200",
+					},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name:  "Nested mount path without endpoint",
+			input: "vault-kms:///team/transit/backup-key",
+			expected: encryptionpb.MasterKey{
+				Backend: &encryptionpb.MasterKey_Kms{
+					Kms: &encryptionpb.MasterKeyKms{
+						Vendor: "vault",
+						KeyId:  "team/transit/backup-key",
+					},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name:        "Missing mount path",
+			input:       "vault-kms:///backup-key",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, _ := url.Parse(tt.input)
+			result, err := parseVaultKmsConfig(u)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
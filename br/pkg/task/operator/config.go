@@ -27,6 +27,9 @@ const (
 	flagBase             = "base"
 	flagYes              = "yes"
 	flagDryRun           = "dry-run"
+	flagFullBackups      = "full-backup-storage"
+	flagKeepFull         = "keep-full"
+	flagKeepPITRWindow   = "keep-pitr-window"
 )
 
 type PauseGcConfig struct {
@@ -234,3 +237,63 @@ func (cfg *ChecksumWithRewriteRulesConfig) ParseFromFlags(flags *pflag.FlagSet)
 	}
 	return cfg.Config.ParseFromFlags(flags)
 }
+
+type PruneConfig struct {
+	task.Config
+
+	// FullBackupStorage is the storage whose direct sub directories are snapshot backups.
+	// The log backup is specified by `--storage` and it's optional.
+	FullBackupStorage string
+	KeepFull          int
+	KeepPITRWindow    time.Duration
+
+	Yes    bool
+	DryRun bool
+}
+
+func DefineFlagsForPruneConfig(f *pflag.FlagSet) {
+	f.String(flagFullBackups, "", "the external storage whose direct sub directories are snapshot backups.")
+	f.Int(flagKeepFull, 1, "the number of the latest full snapshot backups to keep, "+
+		"the incremental backups after them and the backups they depend on are kept as well.")
+	f.Duration(flagKeepPITRWindow, 0, "keep the snapshot backups and the log backup needed "+
+		"to restore to any point in this duration until now. 0 means the log backup isn't pruned.")
+	f.BoolP(flagYes, "y", false, "skip confirming, delete the backups directly.")
+	f.Bool(flagDryRun, false, "do not actually delete anything, just print the backups to delete.")
+}
+
+func (cfg *PruneConfig) ParseFromFlags(flags *pflag.FlagSet) (err error) {
+	cfg.FullBackupStorage, err = flags.GetString(flagFullBackups)
+	if err != nil {
+		return err
+	}
+	cfg.KeepFull, err = flags.GetInt(flagKeepFull)
+	if err != nil {
+		return err
+	}
+	cfg.KeepPITRWindow, err = flags.GetDuration(flagKeepPITRWindow)
+	if err != nil {
+		return err
+	}
+	cfg.Yes, err = flags.GetBool(flagYes)
+	if err != nil {
+		return err
+	}
+	cfg.DryRun, err = flags.GetBool(flagDryRun)
+	if err != nil {
+		return err
+	}
+	return cfg.Config.ParseFromFlags(flags)
+}
+
+func (cfg *PruneConfig) Verify() error {
+	if cfg.FullBackupStorage == "" {
+		return errors.Annotatef(berrors.ErrInvalidArgument, "the --%s flag is required", flagFullBackups)
+	}
+	if cfg.KeepFull < 1 {
+		return errors.Annotatef(berrors.ErrInvalidArgument, "the --%s flag must be at least 1", flagKeepFull)
+	}
+	if cfg.KeepPITRWindow < 0 {
+		return errors.Annotatef(berrors.ErrInvalidArgument, "the --%s flag cannot be negative", flagKeepPITRWindow)
+	}
+	return nil
+}
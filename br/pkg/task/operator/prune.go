@@ -0,0 +1,280 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package operator
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/gogo/protobuf/proto"
+	"github.com/pingcap/errors"
+	backup "github.com/pingcap/kvproto/pkg/brpb"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tidb/br/pkg/glue"
+	"github.com/pingcap/tidb/br/pkg/metautil"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/br/pkg/task"
+	"github.com/tikv/client-go/v2/oracle"
+	"go.uber.org/zap"
+)
+
+// snapshotBackup is a snapshot backup in a sub directory of the full backup storage.
+type snapshotBackup struct {
+	Dir      string
+	BackupTS uint64
+	// StartTS is the start version of an incremental backup, zero for a full backup.
+	// An incremental backup depends on the backup whose BackupTS equals its StartTS.
+	StartTS uint64
+}
+
+func (b snapshotBackup) isIncremental() bool {
+	return b.StartTS != 0
+}
+
+// prunePlan is the result of applying the retention policy to the backups.
+type prunePlan struct {
+	// Keep and Delete are sorted by the backup ts in descending order.
+	Keep   []snapshotBackup
+	Delete []snapshotBackup
+	// TruncateLogUntil is the ts before which the log backup isn't needed by
+	// any restorable point in the window. Zero means the log backup is kept.
+	TruncateLogUntil uint64
+}
+
+// planPrune decides the backups to keep. The latest keepFull full backups and
+// the incremental backups after them are kept. If windowStartTS isn't zero,
+// restoring to any point after it needs the latest backup before it, the
+// backups after it and the log backup since the former one, so they are kept
+// as well. At last, the backups that a kept incremental backup depends on are
+// kept, so every kept backup can be restored.
+func planPrune(backups []snapshotBackup, keepFull int, windowStartTS uint64) prunePlan {
+	backups = slices.Clone(backups)
+	slices.SortFunc(backups, func(a, b snapshotBackup) int {
+		switch {
+		case a.BackupTS > b.BackupTS:
+			return -1
+		case a.BackupTS < b.BackupTS:
+			return 1
+		default:
+			return strings.Compare(a.Dir, b.Dir)
+		}
+	})
+
+	plan := prunePlan{}
+	keep := make([]bool, len(backups))
+	fullCount := 0
+	baseFound := false
+	for i, b := range backups {
+		keep[i] = fullCount < keepFull
+		if !b.isIncremental() {
+			fullCount++
+		}
+		if windowStartTS != 0 && !baseFound {
+			keep[i] = true
+			if b.BackupTS <= windowStartTS {
+				baseFound = true
+				plan.TruncateLogUntil = b.BackupTS
+			}
+		}
+	}
+	// the log backup before the oldest snapshot backup can't be restored anyway
+	if windowStartTS != 0 && !baseFound && len(backups) > 0 {
+		plan.TruncateLogUntil = backups[len(backups)-1].BackupTS
+	}
+
+	// the dependency is always older than the incremental backup, so one pass
+	// in the descending order keeps the whole chain.
+	byTS := make(map[uint64]int, len(backups))
+	for i := len(backups) - 1; i >= 0; i-- {
+		byTS[backups[i].BackupTS] = i
+	}
+	for i, b := range backups {
+		if !keep[i] || !b.isIncremental() {
+			continue
+		}
+		if dep, ok := byTS[b.StartTS]; ok && dep > i {
+			keep[dep] = true
+		}
+	}
+
+	for i, b := range backups {
+		if keep[i] {
+			plan.Keep = append(plan.Keep, b)
+		} else {
+			plan.Delete = append(plan.Delete, b)
+		}
+	}
+	return plan
+}
+
+// loadSnapshotBackups finds the snapshot backups in the direct sub directories
+// of the storage. The backups without backupmeta may be still running, so they are ignored.
+func loadSnapshotBackups(ctx context.Context, s storage.ExternalStorage, cipher *backup.CipherInfo) ([]snapshotBackup, error) {
+	metaPaths, err := findBackupMetas(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+
+	backups := make([]snapshotBackup, 0, len(metaPaths))
+	for _, p := range metaPaths {
+		data, err := s.ReadFile(ctx, p)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		data, err = metautil.DecryptFullBackupMetaIfNeeded(data, cipher)
+		if err != nil {
+			return nil, errors.Annotatef(err, "failed to decrypt %s", p)
+		}
+		meta := &backup.BackupMeta{}
+		if err = proto.Unmarshal(data, meta); err != nil {
+			return nil, errors.Annotatef(err, "failed to parse %s, is it encrypted?", p)
+		}
+		backups = append(backups, snapshotBackup{
+			Dir:      strings.Trim(path.Dir(p), "/"),
+			BackupTS: meta.GetEndVersion(),
+			StartTS:  meta.GetStartVersion(),
+		})
+	}
+	return backups, nil
+}
+
+// findBackupMetas returns the paths of the backupmeta in the direct sub
+// directories. The backupmeta is checked in every sub directory if the
+// storage can list them, otherwise all the files are walked.
+func findBackupMetas(ctx context.Context, s storage.ExternalStorage) ([]string, error) {
+	lister, ok := s.(storage.SubDirLister)
+	if !ok {
+		var metaPaths []string
+		err := s.WalkDir(ctx, &storage.WalkOption{}, func(p string, _ int64) error {
+			dir, file := path.Split(p)
+			dir = strings.Trim(dir, "/")
+			if file == metautil.MetaFile && dir != "" && !strings.Contains(dir, "/") {
+				metaPaths = append(metaPaths, p)
+			}
+			return nil
+		})
+		return metaPaths, errors.Trace(err)
+	}
+
+	dirs, err := lister.ListSubDirs(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	metaPaths := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		p := path.Join(dir, metautil.MetaFile)
+		exists, err := s.FileExists(ctx, p)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if exists {
+			metaPaths = append(metaPaths, p)
+		}
+	}
+	return metaPaths, nil
+}
+
+func deleteSnapshotBackup(ctx context.Context, s storage.ExternalStorage, dir string) error {
+	var files []string
+	err := s.WalkDir(ctx, &storage.WalkOption{SubDir: dir + "/"}, func(p string, _ int64) error {
+		files = append(files, p)
+		return nil
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// delete the backupmeta at last, so a partially deleted backup can be found and pruned again
+	slices.SortStableFunc(files, func(a, b string) int {
+		aMeta, bMeta := path.Base(a) == metautil.MetaFile, path.Base(b) == metautil.MetaFile
+		switch {
+		case aMeta == bMeta:
+			return 0
+		case aMeta:
+			return 1
+		default:
+			return -1
+		}
+	})
+	return errors.Trace(s.DeleteFiles(ctx, files))
+}
+
+func formatBackupTS(ts uint64) string {
+	return oracle.GetTimeFromTS(ts).Format("2006-01-02 15:04:05")
+}
+
+func describeBackup(b snapshotBackup) string {
+	if b.isIncremental() {
+		return fmt.Sprintf("incremental backup time %s since %s", formatBackupTS(b.BackupTS), formatBackupTS(b.StartTS))
+	}
+	return "backup time " + formatBackupTS(b.BackupTS)
+}
+
+// RunPrune prunes the snapshot backups and the log backup by the retention policy.
+func RunPrune(ctx context.Context, g glue.Glue, cfg PruneConfig) error {
+	if err := cfg.Verify(); err != nil {
+		return err
+	}
+	console := glue.GetConsole(g)
+
+	backend, err := storage.ParseBackend(cfg.FullBackupStorage, &cfg.BackendOptions)
+	if err != nil {
+		return err
+	}
+	st, err := storage.Create(ctx, backend, false)
+	if err != nil {
+		return err
+	}
+	backups, err := loadSnapshotBackups(ctx, st, &cfg.CipherInfo)
+	if err != nil {
+		return err
+	}
+	var windowStartTS uint64
+	if cfg.KeepPITRWindow > 0 {
+		windowStartTS = oracle.GoTimeToTS(time.Now().Add(-cfg.KeepPITRWindow))
+	}
+	plan := planPrune(backups, cfg.KeepFull, windowStartTS)
+
+	console.Println(statusOK(fmt.Sprintf("Found %d snapshot backups, %d of them will be deleted.", len(backups), len(plan.Delete))))
+	tbl := console.CreateTable()
+	for _, b := range plan.Keep {
+		tbl.Add(b.Dir, "keep, "+describeBackup(b))
+	}
+	for _, b := range plan.Delete {
+		tbl.Add(b.Dir, color.HiRedString("delete")+", "+describeBackup(b))
+	}
+	tbl.Print()
+	truncateLog := cfg.Storage != "" && plan.TruncateLogUntil != 0
+	if truncateLog {
+		console.Printf("The log backup before %s will be truncated.\n", formatBackupTS(plan.TruncateLogUntil))
+	} else {
+		console.Println("The log backup won't be truncated.")
+	}
+
+	if !cfg.DryRun {
+		if !cfg.Yes && !console.PromptBool(color.HiRedString("Are you sure? ")) {
+			return nil
+		}
+		for _, b := range plan.Delete {
+			if err := deleteSnapshotBackup(ctx, st, b.Dir); err != nil {
+				return errors.Annotatef(err, "failed to delete snapshot backup %s", b.Dir)
+			}
+			log.Info("deleted snapshot backup", zap.String("dir", b.Dir),
+				zap.Uint64("backup-ts", b.BackupTS), zap.Uint64("start-ts", b.StartTS))
+		}
+	}
+	if !truncateLog {
+		return nil
+	}
+	// the truncation prints the files to truncate in dry run
+	return task.RunStreamTruncate(ctx, g, "prune", &task.StreamConfig{
+		Config:     cfg.Config,
+		Until:      plan.TruncateLogUntil,
+		DryRun:     cfg.DryRun,
+		SkipPrompt: true,
+	})
+}
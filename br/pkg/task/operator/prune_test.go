@@ -0,0 +1,140 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package operator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	backup "github.com/pingcap/kvproto/pkg/brpb"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanPrune(t *testing.T) {
+	backups := []snapshotBackup{
+		{Dir: "d1", BackupTS: 100},
+		{Dir: "d3", BackupTS: 300},
+		{Dir: "d2", BackupTS: 200},
+		{Dir: "d5", BackupTS: 500},
+		{Dir: "d4", BackupTS: 400},
+	}
+	dirs := func(bs []snapshotBackup) []string {
+		res := make([]string, 0, len(bs))
+		for _, b := range bs {
+			res = append(res, b.Dir)
+		}
+		return res
+	}
+
+	// only keep the latest snapshot backups
+	plan := planPrune(backups, 2, 0)
+	require.Equal(t, []string{"d5", "d4"}, dirs(plan.Keep))
+	require.Equal(t, []string{"d3", "d2", "d1"}, dirs(plan.Delete))
+	require.Zero(t, plan.TruncateLogUntil)
+
+	// the latest snapshot backup before the window is the base of the window
+	plan = planPrune(backups, 1, 250)
+	require.Equal(t, []string{"d5", "d4", "d3", "d2"}, dirs(plan.Keep))
+	require.Equal(t, []string{"d1"}, dirs(plan.Delete))
+	require.Equal(t, uint64(200), plan.TruncateLogUntil)
+	plan = planPrune(backups, 1, 300)
+	require.Equal(t, []string{"d5", "d4", "d3"}, dirs(plan.Keep))
+	require.Equal(t, uint64(300), plan.TruncateLogUntil)
+
+	// --keep-full keeps more snapshot backups than the window
+	plan = planPrune(backups, 4, 450)
+	require.Equal(t, []string{"d5", "d4", "d3", "d2"}, dirs(plan.Keep))
+	require.Equal(t, uint64(400), plan.TruncateLogUntil)
+
+	// no snapshot backup before the window
+	plan = planPrune(backups, 1, 50)
+	require.Len(t, plan.Keep, 5)
+	require.Empty(t, plan.Delete)
+	require.Equal(t, uint64(100), plan.TruncateLogUntil)
+
+	plan = planPrune(nil, 1, 50)
+	require.Empty(t, plan.Keep)
+	require.Zero(t, plan.TruncateLogUntil)
+}
+
+func TestPlanPruneIncremental(t *testing.T) {
+	// full1 <- inc1 <- inc2, full2 <- inc3
+	backups := []snapshotBackup{
+		{Dir: "full1", BackupTS: 100},
+		{Dir: "inc1", BackupTS: 200, StartTS: 100},
+		{Dir: "inc2", BackupTS: 300, StartTS: 200},
+		{Dir: "full2", BackupTS: 400},
+		{Dir: "inc3", BackupTS: 500, StartTS: 400},
+	}
+	dirs := func(bs []snapshotBackup) []string {
+		res := make([]string, 0, len(bs))
+		for _, b := range bs {
+			res = append(res, b.Dir)
+		}
+		return res
+	}
+
+	// --keep-full counts the full backups only
+	plan := planPrune(backups, 1, 0)
+	require.Equal(t, []string{"inc3", "full2"}, dirs(plan.Keep))
+	require.Equal(t, []string{"inc2", "inc1", "full1"}, dirs(plan.Delete))
+	plan = planPrune(backups, 2, 0)
+	require.Len(t, plan.Keep, 5)
+	require.Empty(t, plan.Delete)
+
+	// the incremental backup as the base of the window keeps the whole chain
+	plan = planPrune(backups, 1, 350)
+	require.Len(t, plan.Keep, 5)
+	require.Empty(t, plan.Delete)
+	require.Equal(t, uint64(300), plan.TruncateLogUntil)
+	plan = planPrune(backups, 1, 250)
+	require.Equal(t, []string{"inc3", "full2", "inc2", "inc1", "full1"}, dirs(plan.Keep))
+	require.Equal(t, uint64(200), plan.TruncateLogUntil)
+
+	// the chain without the full backup
+	plan = planPrune(backups[1:], 1, 350)
+	require.Equal(t, []string{"inc3", "full2", "inc2", "inc1"}, dirs(plan.Keep))
+	require.Empty(t, plan.Delete)
+}
+
+func TestLoadAndDeleteSnapshotBackups(t *testing.T) {
+	ctx := context.Background()
+	s, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+	writeMeta := func(p string, startTS, ts uint64) {
+		data, err := proto.Marshal(&backup.BackupMeta{StartVersion: startTS, EndVersion: ts})
+		require.NoError(t, err)
+		require.NoError(t, s.WriteFile(ctx, p, data))
+	}
+	writeMeta("full-1/backupmeta", 0, 100)
+	require.NoError(t, s.WriteFile(ctx, "full-1/1.sst", []byte("x")))
+	writeMeta("full-2/backupmeta", 100, 200)
+	// running backup without backupmeta
+	require.NoError(t, s.WriteFile(ctx, "full-3/backup.lock", []byte("x")))
+	// not a direct sub directory
+	writeMeta("a/b/backupmeta", 0, 300)
+	require.NoError(t, s.WriteFile(ctx, "log/v1/backupmeta", []byte("x")))
+
+	// the backupmeta of the sub directories are checked directly, and all
+	// the files are walked if the storage can't list the sub directories.
+	for _, st := range []storage.ExternalStorage{s, struct{ storage.ExternalStorage }{s}} {
+		backups, err := loadSnapshotBackups(ctx, st, nil)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []snapshotBackup{
+			{Dir: "full-1", BackupTS: 100},
+			{Dir: "full-2", BackupTS: 200, StartTS: 100},
+		}, backups)
+	}
+
+	require.NoError(t, deleteSnapshotBackup(ctx, s, "full-1"))
+	for _, p := range []string{"full-1/backupmeta", "full-1/1.sst"} {
+		exists, err := s.FileExists(ctx, p)
+		require.NoError(t, err)
+		require.False(t, exists, p)
+	}
+	exists, err := s.FileExists(ctx, "full-2/backupmeta")
+	require.NoError(t, err)
+	require.True(t, exists)
+}
@@ -90,6 +90,10 @@ const (
 	// FlagWaitTiFlashReady represents whether wait tiflash replica ready after table restored and checksumed.
 	FlagWaitTiFlashReady = "wait-tiflash-ready"
 
+	// FlagRewriteDB and FlagRewriteTable restore the databases and tables under different names.
+	FlagRewriteDB    = "rewrite-db"
+	FlagRewriteTable = "rewrite-table"
+
 	// FlagStreamStartTS and FlagStreamRestoreTS is used for log restore timestamp range.
 	FlagStreamStartTS   = "start-ts"
 	FlagStreamRestoreTS = "restored-ts"
@@ -273,6 +277,10 @@ type RestoreConfig struct {
 
 	WaitTiflashReady bool `json:"wait-tiflash-ready" toml:"wait-tiflash-ready"`
 
+	RewriteDBs    []string           `json:"rewrite-db" toml:"rewrite-db"`
+	RewriteTables []string           `json:"rewrite-table" toml:"rewrite-table"`
+	nameMapping   *utils.NameMapping `json:"-" toml:"-"`
+
 	// for ebs-based restore
 	FullBackupType      FullBackupType        `json:"full-backup-type" toml:"full-backup-type"`
 	Prepare             bool                  `json:"prepare" toml:"prepare"`
@@ -311,6 +319,10 @@ func DefineRestoreFlags(flags *pflag.FlagSet) {
 		" default is true, the incremental restore will not perform rewrite on the incremental data"+
 		" meanwhile the incremental restore will not allow to restore 3 backfilled type ddl jobs,"+
 		" these ddl jobs are Add index, Modify column and Reorganize partition")
+	flags.StringArray(FlagRewriteDB, nil, "restore the database under a new name, in the format of old:new, "+
+		"can be specified multiple times")
+	flags.StringArray(FlagRewriteTable, nil, "restore the table under a new name, in the format of db.old:db.new, "+
+		"the db must be the name the database is restored as, can be specified multiple times")
 
 	DefineRestoreCommonFlags(flags)
 }
@@ -439,6 +451,18 @@ func (cfg *RestoreConfig) ParseFromFlags(flags *pflag.FlagSet, skipCommonConfig
 	if err != nil {
 		return errors.Annotatef(err, "failed to get flag %s", flagAllowPITRFromIncremental)
 	}
+	cfg.RewriteDBs, err = flags.GetStringArray(FlagRewriteDB)
+	if err != nil {
+		return errors.Annotatef(err, "failed to get flag %s", FlagRewriteDB)
+	}
+	cfg.RewriteTables, err = flags.GetStringArray(FlagRewriteTable)
+	if err != nil {
+		return errors.Annotatef(err, "failed to get flag %s", FlagRewriteTable)
+	}
+	cfg.nameMapping, err = utils.ParseNameMapping(cfg.RewriteDBs, cfg.RewriteTables)
+	if err != nil {
+		return errors.Trace(err)
+	}
 
 	if flags.Lookup(flagFullBackupType) != nil {
 		// for restore full only
@@ -997,6 +1021,15 @@ func runSnapshotRestore(c context.Context, mgr *conn.Mgr, g glue.Glue, cmdName s
 			zap.Int("tables", len(tableMap)),
 			zap.Int("db", len(dbMap)))
 	}
+	if cfg.nameMapping != nil {
+		// the ddl jobs of incremental restore refer to the original names
+		if client.IsIncremental() {
+			return errors.Annotate(berrors.ErrInvalidArgument, "rewriting names isn't supported by incremental restore")
+		}
+		if err = applyNameMapping(cfg.nameMapping, tableMap, dbMap); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	tables := utils.Values(tableMap)
 	dbs := utils.Values(dbMap)
 
@@ -1560,6 +1593,51 @@ func filterRestoreFiles(
 	return
 }
 
+// applyNameMapping renames the databases and tables to restore, so they are
+// created under the new names. The table IDs are kept, so the data is still
+// rewritten by the rules between the old and new table IDs.
+func applyNameMapping(
+	m *utils.NameMapping,
+	tableMap map[int64]*metautil.Table,
+	dbMap map[int64]*metautil.Database,
+) error {
+	newDBInfos := make(map[int64]*model.DBInfo, len(dbMap))
+	dbNames := make(map[string]int64, len(dbMap))
+	for dbID, db := range dbMap {
+		newName := m.DBName(db.Info.Name)
+		if otherID, ok := dbNames[newName.L]; ok && otherID != dbID {
+			return errors.Annotatef(berrors.ErrInvalidArgument, "more than one database is restored as %s", newName.O)
+		}
+		dbNames[newName.L] = dbID
+		if newName != db.Info.Name {
+			info := db.Info.Clone()
+			info.Name = newName
+			db.Info = info
+		}
+		newDBInfos[dbID] = db.Info
+	}
+
+	tableNames := make(map[[2]string]int64, len(tableMap))
+	for tableID, table := range tableMap {
+		newName := m.TableName(table.DB.Name, table.Info.Name)
+		if info, ok := newDBInfos[table.DB.ID]; ok {
+			table.DB = info
+		}
+		key := [2]string{table.DB.Name.L, newName.L}
+		if otherID, ok := tableNames[key]; ok && otherID != tableID {
+			return errors.Annotatef(berrors.ErrInvalidArgument, "more than one table is restored as %s",
+				utils.EncloseDBAndTable(table.DB.Name.O, newName.O))
+		}
+		tableNames[key] = tableID
+		if newName != table.Info.Name {
+			info := table.Info.Clone()
+			info.Name = newName
+			table.Info = info
+		}
+	}
+	return nil
+}
+
 // getDBNameFromIDInBackup gets database name from either snapshot or log backup history
 func getDBNameFromIDInBackup(
 	dbID int64,
@@ -2126,6 +2126,7 @@ func buildSchemaReplace(
 	tableMappingManager *stream.TableMappingManager) (*stream.SchemasReplace, error) {
 	schemasReplace := stream.NewSchemasReplace(tableMappingManager.DBReplaceMap, cfg.tiflashRecorder,
 		client.CurrentTS(), client.RecordDeleteRange)
+	schemasReplace.NameMapping = cfg.nameMapping
 	schemasReplace.AfterTableRewrittenFn = func(deleted bool, tableInfo *model.TableInfo) {
 		// When the table replica changed to 0, the tiflash replica might be set to `nil`.
 		// We should remove the table if we meet.
@@ -2155,6 +2156,7 @@ func buildAndSaveIDMapIfNeeded(ctx context.Context, client *logclient.LogClient,
 		PiTRTableTracker:        cfg.PiTRTableTracker,
 		FullBackupStorageConfig: fullBackupStorageConfig,
 		CipherInfo:              &cfg.Config.CipherInfo,
+		NameMapping:             cfg.nameMapping,
 	}, cfg.logCheckpointMetaManager)
 	if err != nil {
 		return errors.Trace(err)
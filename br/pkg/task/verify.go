@@ -0,0 +1,425 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package task
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"hash/crc64"
+	"slices"
+
+	"github.com/cockroachdb/pebble/sstable"
+	"github.com/pingcap/errors"
+	backuppb "github.com/pingcap/kvproto/pkg/brpb"
+	"github.com/pingcap/log"
+	berrors "github.com/pingcap/tidb/br/pkg/errors"
+	"github.com/pingcap/tidb/br/pkg/glue"
+	"github.com/pingcap/tidb/br/pkg/metautil"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/br/pkg/stream"
+	"github.com/pingcap/tidb/br/pkg/utils"
+	"github.com/pingcap/tidb/br/pkg/utils/consts"
+	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/pingcap/tidb/pkg/util/codec"
+	"github.com/spf13/pflag"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	defaultVerifyConcurrency = 4
+	// dataKeyPrefix is the prefix TiKV adds to the keys in its engine.
+	dataKeyPrefix = 'z'
+)
+
+var crc64Table = crc64.MakeTable(crc64.ECMA)
+
+// VerifyConfig is the configuration specific for `br debug verify`.
+type VerifyConfig struct {
+	Config
+}
+
+// DefineVerifyFlags defines the flags used for `br debug verify`.
+func DefineVerifyFlags(flags *pflag.FlagSet) {
+	flags.Uint32(flagConcurrency, defaultVerifyConcurrency, "The number of SST files verified concurrently.")
+}
+
+// ParseFromFlags parses the verify-related flags from the flag set.
+func (cfg *VerifyConfig) ParseFromFlags(flags *pflag.FlagSet) error {
+	if err := cfg.Config.ParseFromFlags(flags); err != nil {
+		return errors.Trace(err)
+	}
+	var err error
+	cfg.Concurrency, err = flags.GetUint32(flagConcurrency)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if cfg.Concurrency == 0 {
+		cfg.Concurrency = defaultVerifyConcurrency
+	}
+	return nil
+}
+
+// kvChecksum is the checksum of the kv pairs, which is the same as `ADMIN CHECKSUM TABLE`.
+type kvChecksum struct {
+	Crc64Xor   uint64
+	TotalKvs   uint64
+	TotalBytes uint64
+}
+
+func (c *kvChecksum) update(key, value []byte) {
+	sum := crc64.Update(0, crc64Table, key)
+	sum = crc64.Update(sum, crc64Table, value)
+	c.Crc64Xor ^= sum
+	c.TotalKvs++
+	c.TotalBytes += uint64(len(key) + len(value))
+}
+
+func (c *kvChecksum) merge(other *kvChecksum) {
+	c.Crc64Xor ^= other.Crc64Xor
+	c.TotalKvs += other.TotalKvs
+	c.TotalBytes += other.TotalBytes
+}
+
+// verifyProblem is a problem found by the verification.
+type verifyProblem struct {
+	Target string
+	Reason string
+}
+
+// backupFileGroup is the files of the same key range, i.e. the default and
+// write CF files backed up from a region.
+type backupFileGroup struct {
+	write    *backuppb.File
+	dfault   *backuppb.File
+	problems []verifyProblem
+	// checksums are the checksums of the kv pairs by the physical table ID.
+	checksums map[int64]*kvChecksum
+}
+
+func (g *backupFileGroup) fileCount() int {
+	n := 0
+	if g.write != nil {
+		n++
+	}
+	if g.dfault != nil {
+		n++
+	}
+	return n
+}
+
+// uniqueBackupFiles returns the files of the tables, a file may contain the kv
+// pairs of several tables but it's returned only once.
+func uniqueBackupFiles(tables []*metautil.Table) []*backuppb.File {
+	var files []*backuppb.File
+	fileNames := make(map[string]struct{})
+	for _, tbl := range tables {
+		for _, physicalFiles := range tbl.FilesOfPhysicals {
+			for _, f := range physicalFiles {
+				if _, ok := fileNames[f.Name]; !ok {
+					fileNames[f.Name] = struct{}{}
+					files = append(files, f)
+				}
+			}
+		}
+	}
+	return files
+}
+
+func groupBackupFiles(files []*backuppb.File) []*backupFileGroup {
+	groups := make(map[string]*backupFileGroup)
+	keys := make([]string, 0)
+	for _, f := range files {
+		key := string(codec.EncodeBytes(slices.Clone(f.StartKey), f.EndKey))
+		g, ok := groups[key]
+		if !ok {
+			g = &backupFileGroup{}
+			groups[key] = g
+			keys = append(keys, key)
+		}
+		if f.Cf == consts.DefaultCF {
+			g.dfault = f
+		} else {
+			g.write = f
+		}
+	}
+	res := make([]*backupFileGroup, 0, len(keys))
+	for _, key := range keys {
+		res = append(res, groups[key])
+	}
+	return res
+}
+
+// readBackupFile reads the file, verifies its size and sha256 and decrypts it.
+func readBackupFile(ctx context.Context, s storage.ExternalStorage, f *backuppb.File, cipher *backuppb.CipherInfo) ([]byte, *verifyProblem) {
+	exists, err := s.FileExists(ctx, f.Name)
+	if err != nil {
+		return nil, &verifyProblem{Target: f.Name, Reason: err.Error()}
+	}
+	if !exists {
+		return nil, &verifyProblem{Target: f.Name, Reason: "file is missing"}
+	}
+	data, err := s.ReadFile(ctx, f.Name)
+	if err != nil {
+		return nil, &verifyProblem{Target: f.Name, Reason: err.Error()}
+	}
+	if f.Size_ != 0 && uint64(len(data)) != f.Size_ {
+		return nil, &verifyProblem{Target: f.Name, Reason: fmt.Sprintf("size is %d, but %d is recorded", len(data), f.Size_)}
+	}
+	if len(f.Sha256) > 0 {
+		sum := sha256.Sum256(data)
+		if !bytes.Equal(sum[:], f.Sha256) {
+			return nil, &verifyProblem{Target: f.Name, Reason: "sha256 mismatch"}
+		}
+	}
+	data, err = utils.Decrypt(data, cipher, f.CipherIv)
+	if err != nil {
+		return nil, &verifyProblem{Target: f.Name, Reason: err.Error()}
+	}
+	return data, nil
+}
+
+func iterateSST(data []byte, fn func(key, value []byte) error) error {
+	reader, err := sstable.NewMemReader(data, sstable.ReaderOptions{})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer reader.Close()
+	iter, err := reader.NewIter(nil, nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer iter.Close()
+	for k, v := iter.First(); k != nil; k, v = iter.Next() {
+		value, _, err := v.Value(nil)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err = fn(k.UserKey, value); err != nil {
+			return err
+		}
+	}
+	return errors.Trace(iter.Error())
+}
+
+// checksumBackupSST computes the checksums of the kv pairs in the write CF SST.
+// The long values are stored in the default CF SST.
+func checksumBackupSST(writeSST, defaultSST []byte) (map[int64]*kvChecksum, error) {
+	defaultValues := make(map[string][]byte)
+	if defaultSST != nil {
+		err := iterateSST(defaultSST, func(key, value []byte) error {
+			defaultValues[string(bytes.TrimPrefix(key, []byte{dataKeyPrefix}))] = slices.Clone(value)
+			return nil
+		})
+		if err != nil {
+			return nil, errors.Annotate(err, "failed to read default CF SST")
+		}
+	}
+
+	checksums := make(map[int64]*kvChecksum)
+	err := iterateSST(writeSST, func(key, value []byte) error {
+		// the key is {mem-comparable encoded key}{bit-wise reversed commit TS}
+		key = bytes.TrimPrefix(key, []byte{dataKeyPrefix})
+		if len(key) <= 8 {
+			return errors.Errorf("invalid write CF key %X", key)
+		}
+		encodedKey := key[:len(key)-8]
+		var write stream.RawWriteCFValue
+		if err := write.ParseFrom(value); err != nil {
+			return errors.Trace(err)
+		}
+		if write.GetWriteType() != stream.WriteTypePut {
+			return nil
+		}
+		rowValue := write.GetShortValue()
+		if !write.HasShortValue() {
+			var ok bool
+			defaultKey := codec.EncodeUintDesc(slices.Clone(encodedKey), write.GetStartTs())
+			if rowValue, ok = defaultValues[string(defaultKey)]; !ok {
+				return errors.Errorf("the value of key %X is missing in default CF SST", encodedKey)
+			}
+		}
+		_, rawKey, err := codec.DecodeBytes(encodedKey, nil)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		tableID := tablecodec.DecodeTableID(rawKey)
+		c, ok := checksums[tableID]
+		if !ok {
+			c = &kvChecksum{}
+			checksums[tableID] = c
+		}
+		c.update(rawKey, rowValue)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "failed to read write CF SST")
+	}
+	return checksums, nil
+}
+
+func (g *backupFileGroup) verify(ctx context.Context, s storage.ExternalStorage, cipher *backuppb.CipherInfo) {
+	var defaultSST, writeSST []byte
+	if g.dfault != nil {
+		data, problem := readBackupFile(ctx, s, g.dfault, cipher)
+		if problem != nil {
+			g.problems = append(g.problems, *problem)
+		}
+		defaultSST = data
+	}
+	if g.write == nil {
+		if g.dfault != nil {
+			g.problems = append(g.problems, verifyProblem{Target: g.dfault.Name, Reason: "the write CF file of the range is missing"})
+		}
+		return
+	}
+	writeSST, problem := readBackupFile(ctx, s, g.write, cipher)
+	if problem != nil {
+		g.problems = append(g.problems, *problem)
+	}
+	if len(g.problems) > 0 {
+		return
+	}
+	checksums, err := checksumBackupSST(writeSST, defaultSST)
+	if err != nil {
+		g.problems = append(g.problems, verifyProblem{Target: g.write.Name, Reason: err.Error()})
+		return
+	}
+	g.checksums = checksums
+}
+
+// verifyBackupTables reads every SST file of the tables, recomputes the
+// checksums of the tables and compares them with the recorded ones.
+func verifyBackupTables(
+	ctx context.Context,
+	s storage.ExternalStorage,
+	cipher *backuppb.CipherInfo,
+	tables []*metautil.Table,
+	concurrency int,
+	onFilesDone func(n int),
+) (verifiedFiles int, problems []verifyProblem, err error) {
+	files := uniqueBackupFiles(tables)
+	groups := groupBackupFiles(files)
+
+	eg, ectx := errgroup.WithContext(ctx)
+	eg.SetLimit(concurrency)
+	for _, g := range groups {
+		eg.Go(func() error {
+			g.verify(ectx, s, cipher)
+			if onFilesDone != nil {
+				onFilesDone(g.fileCount())
+			}
+			return ectx.Err()
+		})
+	}
+	if err = eg.Wait(); err != nil {
+		return 0, nil, errors.Trace(err)
+	}
+
+	checksums := make(map[int64]*kvChecksum)
+	brokenFiles := make(map[string]struct{})
+	for _, g := range groups {
+		for _, p := range g.problems {
+			brokenFiles[p.Target] = struct{}{}
+			problems = append(problems, p)
+		}
+		for tableID, c := range g.checksums {
+			if total, ok := checksums[tableID]; ok {
+				total.merge(c)
+			} else {
+				checksums[tableID] = c
+			}
+		}
+	}
+	verifiedFiles = len(files) - len(brokenFiles)
+
+	for _, tbl := range tables {
+		if tbl.Info == nil {
+			continue
+		}
+		name := fmt.Sprintf("`%s`.`%s`", tbl.DB.Name.O, tbl.Info.Name.O)
+		physicalIDs := []int64{tbl.Info.ID}
+		broken := false
+		for physicalID, physicalFiles := range tbl.FilesOfPhysicals {
+			if physicalID != tbl.Info.ID {
+				physicalIDs = append(physicalIDs, physicalID)
+			}
+			for _, f := range physicalFiles {
+				if _, ok := brokenFiles[f.Name]; ok {
+					broken = true
+				}
+			}
+		}
+		if broken {
+			problems = append(problems, verifyProblem{Target: name, Reason: "some files of the table are broken"})
+			continue
+		}
+		// the checksum isn't recorded if the backup skips the checksum
+		if tbl.Crc64Xor == 0 && tbl.TotalKvs == 0 && tbl.TotalBytes == 0 {
+			log.Warn("checksum of table isn't recorded, skip comparing checksum", zap.String("table", name))
+			continue
+		}
+		total := kvChecksum{}
+		for _, physicalID := range physicalIDs {
+			if c, ok := checksums[physicalID]; ok {
+				total.merge(c)
+			}
+		}
+		if total.Crc64Xor != tbl.Crc64Xor || total.TotalKvs != tbl.TotalKvs {
+			problems = append(problems, verifyProblem{
+				Target: name,
+				Reason: fmt.Sprintf("checksum mismatch, calculated crc64xor %d, kvs %d, but crc64xor %d, kvs %d are recorded",
+					total.Crc64Xor, total.TotalKvs, tbl.Crc64Xor, tbl.TotalKvs),
+			})
+		}
+	}
+	return verifiedFiles, problems, nil
+}
+
+// RunVerify reads every SST file of the backup to verify that the backup can be restored.
+func RunVerify(c context.Context, g glue.Glue, cmdName string, cfg *VerifyConfig) error {
+	ctx, cancel := context.WithCancel(c)
+	defer cancel()
+
+	_, s, backupMeta, err := ReadBackupMeta(ctx, metautil.MetaFile, &cfg.Config)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if backupMeta.IsRawKv {
+		return errors.Annotate(berrors.ErrInvalidArgument, "verifying raw kv backup is not supported")
+	}
+	reader := metautil.NewMetaReader(backupMeta, s, &cfg.CipherInfo)
+	dbs, err := metautil.LoadBackupTables(ctx, reader, false)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tables := make([]*metautil.Table, 0)
+	for _, db := range dbs {
+		tables = append(tables, db.Tables...)
+	}
+
+	console := glue.GetConsole(g)
+	totalFiles := len(uniqueBackupFiles(tables))
+	progress := g.StartProgress(ctx, cmdName, int64(totalFiles), !cfg.LogProgress)
+	verifiedFiles, problems, err := verifyBackupTables(ctx, s, &cfg.CipherInfo, tables, int(cfg.Concurrency), func(n int) {
+		progress.IncBy(int64(n))
+	})
+	progress.Close()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	console.Printf("Verified %d files of %d tables.\n", verifiedFiles, len(tables))
+	if len(problems) == 0 {
+		console.Println("The backup is verified successfully!")
+		return nil
+	}
+	console.Printf("Found %d problems:\n", len(problems))
+	for _, p := range problems {
+		console.Printf("- %s: %s\n", p.Target, p.Reason)
+		log.Error("backup verification failed", zap.String("target", p.Target), zap.String("reason", p.Reason))
+	}
+	return errors.Annotatef(berrors.ErrBackupChecksumMismatch, "found %d problems in the backup", len(problems))
+}
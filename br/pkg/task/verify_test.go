@@ -0,0 +1,158 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package task
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"sync/atomic"
+	"testing"
+
+	"github.com/cockroachdb/pebble/sstable"
+	backuppb "github.com/pingcap/kvproto/pkg/brpb"
+	"github.com/pingcap/kvproto/pkg/encryptionpb"
+	"github.com/pingcap/tidb/br/pkg/metautil"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/br/pkg/stream"
+	"github.com/pingcap/tidb/br/pkg/utils/consts"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/pingcap/tidb/pkg/util/codec"
+	"github.com/stretchr/testify/require"
+)
+
+type memWritable struct {
+	bytes.Buffer
+}
+
+func (w *memWritable) Write(p []byte) error {
+	_, err := w.Buffer.Write(p)
+	return err
+}
+
+func (*memWritable) Finish() error { return nil }
+
+func (*memWritable) Abort() {}
+
+type testKV struct {
+	key   []byte
+	value []byte
+}
+
+// buildBackupSSTs builds the write and default CF SSTs in the format of TiKV backup.
+func buildBackupSSTs(t *testing.T, kvs []testKV) (writeSST, defaultSST []byte) {
+	const startTS, commitTS = 449567347367280640, 449567347367280641
+	writeW, defaultW := &memWritable{}, &memWritable{}
+	writeWriter := sstable.NewWriter(writeW, sstable.WriterOptions{})
+	defaultWriter := sstable.NewWriter(defaultW, sstable.WriterOptions{})
+	for _, pair := range kvs {
+		encodedKey := codec.EncodeBytes([]byte{dataKeyPrefix}, pair.key)
+		value := []byte{stream.WriteTypePut}
+		value = codec.EncodeUvarint(value, startTS)
+		if len(pair.value) <= 8 {
+			value = append(value, 'v', byte(len(pair.value)))
+			value = append(value, pair.value...)
+		} else {
+			require.NoError(t, defaultWriter.Set(codec.EncodeUintDesc(bytes.Clone(encodedKey), startTS), pair.value))
+		}
+		require.NoError(t, writeWriter.Set(codec.EncodeUintDesc(encodedKey, commitTS), value))
+	}
+	require.NoError(t, writeWriter.Close())
+	require.NoError(t, defaultWriter.Close())
+	return writeW.Bytes(), defaultW.Bytes()
+}
+
+func TestVerifyBackupTables(t *testing.T) {
+	ctx := context.Background()
+	s, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+
+	kvs := []testKV{
+		{key: tablecodec.EncodeRowKeyWithHandle(100, kv.IntHandle(1)), value: []byte("short")},
+		{key: tablecodec.EncodeRowKeyWithHandle(100, kv.IntHandle(2)), value: []byte("a long value in default CF")},
+		{key: tablecodec.EncodeRowKeyWithHandle(101, kv.IntHandle(1)), value: []byte("partition")},
+		{key: tablecodec.EncodeRowKeyWithHandle(200, kv.IntHandle(1)), value: []byte("other")},
+	}
+	writeSST, defaultSST := buildBackupSSTs(t, kvs)
+	checksums, err := checksumBackupSST(writeSST, defaultSST)
+	require.NoError(t, err)
+	require.Len(t, checksums, 3)
+	require.Equal(t, uint64(2), checksums[100].TotalKvs)
+	expected := kvChecksum{}
+	expected.update(kvs[3].key, kvs[3].value)
+	require.Equal(t, expected, *checksums[200])
+
+	writeFile := func(name string, data []byte) *backuppb.File {
+		require.NoError(t, s.WriteFile(ctx, name, data))
+		sum := sha256.Sum256(data)
+		return &backuppb.File{Name: name, Sha256: sum[:], Size_: uint64(len(data))}
+	}
+	wf := writeFile("1_write.sst", writeSST)
+	wf.Cf = consts.WriteCF
+	df := writeFile("1_default.sst", defaultSST)
+	df.Cf = consts.DefaultCF
+	cipher := &backuppb.CipherInfo{CipherType: encryptionpb.EncryptionMethod_PLAINTEXT}
+	db := &model.DBInfo{Name: ast.NewCIStr("test")}
+	t1Sum := kvChecksum{}
+	for _, pair := range kvs[:3] {
+		t1Sum.update(pair.key, pair.value)
+	}
+	tables := []*metautil.Table{
+		{
+			DB:               db,
+			Info:             &model.TableInfo{ID: 100, Name: ast.NewCIStr("t1")},
+			Crc64Xor:         t1Sum.Crc64Xor,
+			TotalKvs:         t1Sum.TotalKvs,
+			TotalBytes:       t1Sum.TotalBytes,
+			FilesOfPhysicals: map[int64][]*backuppb.File{101: {wf, df}},
+		},
+		{
+			DB:               db,
+			Info:             &model.TableInfo{ID: 200, Name: ast.NewCIStr("t2")},
+			Crc64Xor:         expected.Crc64Xor,
+			TotalKvs:         expected.TotalKvs,
+			TotalBytes:       expected.TotalBytes,
+			FilesOfPhysicals: map[int64][]*backuppb.File{200: {wf, df}},
+		},
+	}
+	// the files shared by the tables are verified and counted once
+	require.Len(t, uniqueBackupFiles(tables), 2)
+	var doneFiles atomic.Int64
+	verified, problems, err := verifyBackupTables(ctx, s, cipher, tables, 2, func(n int) {
+		doneFiles.Add(int64(n))
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, verified)
+	require.Empty(t, problems)
+	require.Equal(t, int64(2), doneFiles.Load())
+
+	// the recorded checksum mismatches
+	tables[1].TotalKvs = 2
+	_, problems, err = verifyBackupTables(ctx, s, cipher, tables, 2, nil)
+	require.NoError(t, err)
+	require.Len(t, problems, 1)
+	require.Equal(t, "`test`.`t2`", problems[0].Target)
+	require.Contains(t, problems[0].Reason, "checksum mismatch")
+	tables[1].TotalKvs = 1
+
+	// the default CF file is missing
+	require.NoError(t, s.DeleteFile(ctx, df.Name))
+	verified, problems, err = verifyBackupTables(ctx, s, cipher, tables, 2, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, verified)
+	require.Len(t, problems, 3)
+	require.Equal(t, verifyProblem{Target: df.Name, Reason: "file is missing"}, problems[0])
+	require.Equal(t, "some files of the table are broken", problems[1].Reason)
+
+	// the write CF file is corrupted
+	require.NoError(t, s.WriteFile(ctx, df.Name, defaultSST))
+	corrupted := bytes.Clone(writeSST)
+	corrupted[0] ^= 0xff
+	require.NoError(t, s.WriteFile(ctx, wf.Name, corrupted))
+	_, problems, err = verifyBackupTables(ctx, s, cipher, tables, 2, nil)
+	require.NoError(t, err)
+	require.Equal(t, verifyProblem{Target: wf.Name, Reason: "sha256 mismatch"}, problems[0])
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package utils
+
+import (
+	"strings"
+
+	"github.com/pingcap/errors"
+	berrors "github.com/pingcap/tidb/br/pkg/errors"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+)
+
+// NameMapping maps the names of the databases and tables in the backup to the
+// names they are restored as. A nil NameMapping keeps all the names.
+type NameMapping struct {
+	// dbs maps the lower case name of the database to its new name.
+	dbs map[string]ast.CIStr
+	// tables maps the lower case name of the database and table to the new
+	// table name. The table is always restored into the database it belongs
+	// to, which may be renamed by dbs.
+	tables map[[2]string]ast.CIStr
+}
+
+// ParseNameMapping parses the rules in the format of `old:new` for databases
+// and `db.old:db.new` for tables. The database of a renamed table must be the
+// name its database is restored as, i.e. moving a table across databases isn't
+// supported.
+func ParseNameMapping(dbRules, tableRules []string) (*NameMapping, error) {
+	if len(dbRules) == 0 && len(tableRules) == 0 {
+		return nil, nil
+	}
+	m := &NameMapping{
+		dbs:    make(map[string]ast.CIStr, len(dbRules)),
+		tables: make(map[[2]string]ast.CIStr, len(tableRules)),
+	}
+	newDBs := make(map[string]struct{}, len(dbRules))
+	for _, rule := range dbRules {
+		from, to, ok := strings.Cut(rule, ":")
+		if !ok || from == "" || to == "" {
+			return nil, errors.Annotatef(berrors.ErrInvalidArgument, "invalid database rewrite rule %q, expect old:new", rule)
+		}
+		if err := checkRenamedDB(from); err != nil {
+			return nil, err
+		}
+		if err := checkRenamedDB(to); err != nil {
+			return nil, err
+		}
+		fromName := strings.ToLower(from)
+		if _, ok := m.dbs[fromName]; ok {
+			return nil, errors.Annotatef(berrors.ErrInvalidArgument, "database %s is rewritten more than once", from)
+		}
+		if _, ok := newDBs[strings.ToLower(to)]; ok {
+			return nil, errors.Annotatef(berrors.ErrInvalidArgument, "more than one database is rewritten to %s", to)
+		}
+		m.dbs[fromName] = ast.NewCIStr(to)
+		newDBs[strings.ToLower(to)] = struct{}{}
+	}
+
+	for _, rule := range tableRules {
+		from, to, ok := strings.Cut(rule, ":")
+		fromDB, fromTable, ok1 := strings.Cut(from, ".")
+		toDB, toTable, ok2 := strings.Cut(to, ".")
+		if !ok || !ok1 || !ok2 || fromDB == "" || fromTable == "" || toDB == "" || toTable == "" {
+			return nil, errors.Annotatef(berrors.ErrInvalidArgument, "invalid table rewrite rule %q, expect db.old:db.new", rule)
+		}
+		if err := checkRenamedDB(fromDB); err != nil {
+			return nil, err
+		}
+		if newDB := m.DBName(ast.NewCIStr(fromDB)); newDB.L != strings.ToLower(toDB) {
+			return nil, errors.Annotatef(berrors.ErrInvalidArgument,
+				"table %s is restored into database %s, moving tables across databases isn't supported", from, newDB.O)
+		}
+		key := [2]string{strings.ToLower(fromDB), strings.ToLower(fromTable)}
+		if _, ok := m.tables[key]; ok {
+			return nil, errors.Annotatef(berrors.ErrInvalidArgument, "table %s is rewritten more than once", from)
+		}
+		m.tables[key] = ast.NewCIStr(toTable)
+	}
+	return m, nil
+}
+
+func checkRenamedDB(name string) error {
+	if IsSysDB(strings.ToLower(name)) || IsSysOrTempSysDB(name) {
+		return errors.Annotatef(berrors.ErrInvalidArgument, "system database %s can't be rewritten", name)
+	}
+	return nil
+}
+
+// DBName returns the name the database is restored as.
+func (m *NameMapping) DBName(db ast.CIStr) ast.CIStr {
+	if m == nil {
+		return db
+	}
+	if newName, ok := m.dbs[db.L]; ok {
+		return newName
+	}
+	return db
+}
+
+// TableName returns the name the table is restored as. db is the name of the
+// database in the backup.
+func (m *NameMapping) TableName(db, table ast.CIStr) ast.CIStr {
+	if m == nil {
+		return table
+	}
+	if newName, ok := m.tables[[2]string{db.L, table.L}]; ok {
+		return newName
+	}
+	return table
+}
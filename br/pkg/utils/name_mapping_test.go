@@ -0,0 +1,47 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNameMapping(t *testing.T) {
+	m, err := ParseNameMapping(nil, nil)
+	require.NoError(t, err)
+	require.Nil(t, m)
+	require.Equal(t, ast.NewCIStr("db"), m.DBName(ast.NewCIStr("db")))
+	require.Equal(t, ast.NewCIStr("t"), m.TableName(ast.NewCIStr("db"), ast.NewCIStr("t")))
+
+	m, err = ParseNameMapping([]string{"DB1:db1_Bak"}, []string{"db1.T1:db1_bak.t1_bak", "db2.t2:db2.t2_bak"})
+	require.NoError(t, err)
+	require.Equal(t, ast.NewCIStr("db1_Bak"), m.DBName(ast.NewCIStr("db1")))
+	require.Equal(t, ast.NewCIStr("db2"), m.DBName(ast.NewCIStr("db2")))
+	require.Equal(t, ast.NewCIStr("t1_bak"), m.TableName(ast.NewCIStr("Db1"), ast.NewCIStr("t1")))
+	require.Equal(t, ast.NewCIStr("t2"), m.TableName(ast.NewCIStr("db1"), ast.NewCIStr("t2")))
+	require.Equal(t, ast.NewCIStr("t2_bak"), m.TableName(ast.NewCIStr("db2"), ast.NewCIStr("t2")))
+
+	for _, c := range []struct {
+		dbRules    []string
+		tableRules []string
+		err        string
+	}{
+		{dbRules: []string{"db1"}, err: "invalid database rewrite rule"},
+		{dbRules: []string{"db1:"}, err: "invalid database rewrite rule"},
+		{dbRules: []string{"mysql:mysql_bak"}, err: "system database mysql can't be rewritten"},
+		{dbRules: []string{"db1:sys"}, err: "system database sys can't be rewritten"},
+		{dbRules: []string{"db1:a", "DB1:b"}, err: "rewritten more than once"},
+		{dbRules: []string{"db1:a", "db2:A"}, err: "more than one database is rewritten to"},
+		{tableRules: []string{"db1.t1:t2"}, err: "invalid table rewrite rule"},
+		{tableRules: []string{"db1.t1:db1."}, err: "invalid table rewrite rule"},
+		{tableRules: []string{"db1.t1:db2.t1"}, err: "moving tables across databases isn't supported"},
+		{dbRules: []string{"db1:a"}, tableRules: []string{"db1.t1:db1.t2"}, err: "restored into database a"},
+		{tableRules: []string{"db1.t1:db1.a", "db1.T1:db1.b"}, err: "rewritten more than once"},
+	} {
+		_, err := ParseNameMapping(c.dbRules, c.tableRules)
+		require.ErrorContains(t, err, c.err)
+	}
+}
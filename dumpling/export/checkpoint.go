@@ -0,0 +1,342 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	tcontext "github.com/pingcap/tidb/dumpling/context"
+	"github.com/xitongsys/parquet-go/parquet"
+	"go.uber.org/zap"
+)
+
+// checkpointPath is the path of the checkpoint file in the output storage.
+const checkpointPath = "dumpling-checkpoint.json"
+
+// checkpointSaveInterval is the minimal interval to save the checkpoint file
+// for the finished chunks. The chunks finished after the last save are dumped
+// again on resuming.
+var checkpointSaveInterval = 10 * time.Second
+
+// dumpCheckpoint is the content of the checkpoint file.
+type dumpCheckpoint struct {
+	Snapshot string                      `json:"snapshot"`
+	Config   checkpointConfig            `json:"config"`
+	Tables   map[string]*tableCheckpoint `json:"tables"`
+}
+
+// checkpointConfig is the config which decides the tables, the chunks and the
+// files of a dump, the dump can only be resumed with the same config.
+type checkpointConfig struct {
+	FileType        string                   `json:"filetype"`
+	Rows            uint64                   `json:"rows"`
+	FileSize        uint64                   `json:"filesize"`
+	Databases       []string                 `json:"databases"`
+	TablesList      []string                 `json:"tables-list"`
+	Filters         []string                 `json:"filters"`
+	CaseSensitive   bool                     `json:"case-sensitive"`
+	Where           string                   `json:"where"`
+	Compress        storage.CompressType     `json:"compress"`
+	ParquetCompress parquet.CompressionCodec `json:"parquet-compress"`
+}
+
+func newCheckpointConfig(conf *Config) checkpointConfig {
+	var tablesList []string
+	for db, tables := range conf.Tables {
+		for _, tbl := range tables {
+			tablesList = append(tablesList, db+"."+tbl.Name)
+		}
+	}
+	slices.Sort(tablesList)
+	return checkpointConfig{
+		FileType:        conf.FileType,
+		Rows:            conf.Rows,
+		FileSize:        conf.FileSize,
+		Databases:       conf.Databases,
+		TablesList:      tablesList,
+		Filters:         conf.TableFilterRules,
+		CaseSensitive:   conf.CaseSensitive,
+		Where:           conf.Where,
+		Compress:        conf.CompressType,
+		ParquetCompress: conf.ParquetCompressType,
+	}
+}
+
+// diff returns the argument which differs from another config, it returns
+// an empty string if the configs are the same.
+func (c *checkpointConfig) diff(other *checkpointConfig) string {
+	switch {
+	case c.FileType != other.FileType:
+		return "--filetype"
+	case c.Rows != other.Rows:
+		return "--rows"
+	case c.FileSize != other.FileSize:
+		return "--filesize"
+	case !slices.Equal(c.Databases, other.Databases):
+		return "--database"
+	case !slices.Equal(c.TablesList, other.TablesList):
+		return "--tables-list"
+	case !slices.Equal(c.Filters, other.Filters):
+		return "--filter"
+	case c.CaseSensitive != other.CaseSensitive:
+		return "--case-sensitive"
+	case c.Where != other.Where:
+		return "--where"
+	case c.Compress != other.Compress:
+		return "--compress"
+	case c.ParquetCompress != other.ParquetCompress:
+		return "--parquet-compress"
+	}
+	return ""
+}
+
+// tableCheckpoint records all the chunks of a table. The chunks are recorded
+// before any of them is dumped, so a resumed dump uses the same boundaries.
+type tableCheckpoint struct {
+	Chunks []*chunkCheckpoint `json:"chunks"`
+}
+
+type chunkCheckpoint struct {
+	Index    int      `json:"index"`
+	Total    int      `json:"total"`
+	Queries  []string `json:"queries"`
+	ColLen   int      `json:"col-len"`
+	Finished bool     `json:"finished"`
+}
+
+func newChunkCheckpoint(task *TaskTableData) (*chunkCheckpoint, error) {
+	chunk := &chunkCheckpoint{Index: task.ChunkIndex, Total: task.TotalChunks}
+	switch data := task.Data.(type) {
+	case *tableData:
+		chunk.Queries, chunk.ColLen = []string{data.query}, data.colLen
+	case *multiQueriesChunk:
+		chunk.Queries, chunk.ColLen = data.queries, data.colLen
+	case *incrementalChunk:
+		chunk.Queries, chunk.ColLen = data.queries, data.colLen
+	default:
+		return nil, errors.Errorf("unsupported table data %T in checkpoint", task.Data)
+	}
+	return chunk, nil
+}
+
+func (c *chunkCheckpoint) tableData() TableDataIR {
+	if len(c.Queries) == 1 {
+		return newTableData(c.Queries[0], c.ColLen, false)
+	}
+	return newMultiQueriesChunk(c.Queries, c.ColLen)
+}
+
+// checkpointManager maintains the checkpoint file, it's safe for concurrent use.
+type checkpointManager struct {
+	// saveMu serializes the writes of the checkpoint file, which are done
+	// without holding mu.
+	saveMu   sync.Mutex
+	mu       sync.Mutex
+	extStore storage.ExternalStorage
+	cp       dumpCheckpoint
+	// dirty means the checkpoint is changed after the last save.
+	dirty    bool
+	lastSave time.Time
+}
+
+func newCheckpointManager(extStore storage.ExternalStorage, snapshot string, cfg checkpointConfig) *checkpointManager {
+	return &checkpointManager{
+		extStore: extStore,
+		cp: dumpCheckpoint{
+			Snapshot: snapshot,
+			Config:   cfg,
+			Tables:   make(map[string]*tableCheckpoint),
+		},
+	}
+}
+
+// loadCheckpointManager loads the checkpoint file from the output storage,
+// it returns nil if the file doesn't exist.
+func loadCheckpointManager(ctx context.Context, extStore storage.ExternalStorage) (*checkpointManager, error) {
+	exists, err := extStore.FileExists(ctx, checkpointPath)
+	if err != nil || !exists {
+		return nil, errors.Trace(err)
+	}
+	data, err := extStore.ReadFile(ctx, checkpointPath)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	m := newCheckpointManager(extStore, "", checkpointConfig{})
+	if err = json.Unmarshal(data, &m.cp); err != nil {
+		return nil, errors.Annotatef(err, "invalid checkpoint file %s", checkpointPath)
+	}
+	if m.cp.Snapshot == "" {
+		return nil, errors.Errorf("invalid checkpoint file %s, snapshot is missing", checkpointPath)
+	}
+	if m.cp.Tables == nil {
+		m.cp.Tables = make(map[string]*tableCheckpoint)
+	}
+	return m, nil
+}
+
+func checkpointTableKey(db, tbl string) string {
+	return fmt.Sprintf("`%s`.`%s`", escapeString(db), escapeString(tbl))
+}
+
+// tableChunks returns the recorded chunks of the table.
+func (m *checkpointManager) tableChunks(db, tbl string) ([]*chunkCheckpoint, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	t, ok := m.cp.Tables[checkpointTableKey(db, tbl)]
+	if !ok {
+		return nil, false
+	}
+	chunks := make([]*chunkCheckpoint, 0, len(t.Chunks))
+	for _, c := range t.Chunks {
+		cc := *c
+		chunks = append(chunks, &cc)
+	}
+	return chunks, true
+}
+
+// recordTable records all the chunks of the table and saves the checkpoint
+// immediately, so that the chunks are recorded before they are dumped.
+func (m *checkpointManager) recordTable(ctx context.Context, db, tbl string, chunks []*chunkCheckpoint) error {
+	m.mu.Lock()
+	m.cp.Tables[checkpointTableKey(db, tbl)] = &tableCheckpoint{Chunks: chunks}
+	m.dirty = true
+	m.mu.Unlock()
+	return m.flush(ctx)
+}
+
+// finishChunk marks the chunk finished. The checkpoint is saved if it's not
+// saved in checkpointSaveInterval and no other save is in progress, otherwise
+// it's left to the later saves and flush.
+func (m *checkpointManager) finishChunk(ctx context.Context, db, tbl string, index int) error {
+	m.mu.Lock()
+	t, ok := m.cp.Tables[checkpointTableKey(db, tbl)]
+	if !ok {
+		m.mu.Unlock()
+		return nil
+	}
+	for _, c := range t.Chunks {
+		if c.Index == index {
+			c.Finished = true
+			m.dirty = true
+			break
+		}
+	}
+	due := m.dirty && time.Since(m.lastSave) >= checkpointSaveInterval
+	m.mu.Unlock()
+	if !due || !m.saveMu.TryLock() {
+		return nil
+	}
+	defer m.saveMu.Unlock()
+	return m.save(ctx)
+}
+
+// flush saves the checkpoint if it's changed after the last save.
+func (m *checkpointManager) flush(ctx context.Context) error {
+	m.saveMu.Lock()
+	defer m.saveMu.Unlock()
+	return m.save(ctx)
+}
+
+// save saves the checkpoint if it's dirty, the caller must hold saveMu.
+func (m *checkpointManager) save(ctx context.Context) error {
+	m.mu.Lock()
+	if !m.dirty {
+		m.mu.Unlock()
+		return nil
+	}
+	data, err := json.Marshal(&m.cp)
+	if err == nil {
+		m.dirty = false
+		m.lastSave = time.Now()
+	}
+	m.mu.Unlock()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err = m.extStore.WriteFile(ctx, checkpointPath, data); err != nil {
+		m.mu.Lock()
+		m.dirty = true
+		m.mu.Unlock()
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// remove removes the checkpoint file after the dump finishes.
+func (m *checkpointManager) remove(ctx context.Context) error {
+	m.saveMu.Lock()
+	defer m.saveMu.Unlock()
+	exists, err := m.extStore.FileExists(ctx, checkpointPath)
+	if err != nil || !exists {
+		return errors.Trace(err)
+	}
+	return errors.Trace(m.extStore.DeleteFile(ctx, checkpointPath))
+}
+
+// dumpTableDataWithCheckpoint dumps the unfinished chunks of the table if the
+// chunks are recorded in the checkpoint. Otherwise, it splits the table and
+// records the chunks before sending them to the writers.
+func (d *Dumper) dumpTableDataWithCheckpoint(tctx *tcontext.Context, conn *BaseConn, meta TableMeta, taskChan chan<- Task) error {
+	db, tbl := meta.DatabaseName(), meta.TableName()
+	if chunks, ok := d.checkpoint.tableChunks(db, tbl); ok {
+		finished := 0
+		for _, c := range chunks {
+			if c.Finished {
+				finished++
+				continue
+			}
+			task := d.newTaskTableData(meta, c.tableData(), c.Index, c.Total)
+			if ctxDone := d.sendTaskToChan(tctx, task, taskChan); ctxDone {
+				return tctx.Err()
+			}
+		}
+		tctx.L().Info("resume dumping table from checkpoint",
+			zap.String("database", db),
+			zap.String("table", tbl),
+			zap.Int("finishedChunks", finished),
+			zap.Int("totalChunks", len(chunks)))
+		return nil
+	}
+
+	tableIn, tableOut := infiniteChan[Task]()
+	var err error
+	if d.conf.Rows == UnspecifiedSize {
+		err = d.sequentialDumpTable(tctx, conn, meta, tableIn)
+	} else {
+		err = d.concurrentDumpTable(tctx, conn, meta, tableIn)
+	}
+	close(tableIn)
+	tasks := make([]Task, 0, 1)
+	for task := range tableOut {
+		// the task is sent to the channel again below
+		IncGauge(d.metrics.taskChannelCapacity)
+		tasks = append(tasks, task)
+	}
+	if err != nil {
+		return err
+	}
+	chunks := make([]*chunkCheckpoint, 0, len(tasks))
+	for _, task := range tasks {
+		chunk, err := newChunkCheckpoint(task.(*TaskTableData))
+		if err != nil {
+			return err
+		}
+		chunks = append(chunks, chunk)
+	}
+	if err = d.checkpoint.recordTable(tctx, db, tbl, chunks); err != nil {
+		return errors.Annotate(err, "fail to save checkpoint")
+	}
+	for _, task := range tasks {
+		if ctxDone := d.sendTaskToChan(tctx, task, taskChan); ctxDone {
+			return tctx.Err()
+		}
+	}
+	return nil
+}
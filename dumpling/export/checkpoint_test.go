@@ -0,0 +1,175 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package export
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/br/pkg/storage"
+	tcontext "github.com/pingcap/tidb/dumpling/context"
+	"github.com/pingcap/tidb/pkg/util/promutil"
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go/parquet"
+)
+
+func TestCheckpointManager(t *testing.T) {
+	tctx := tcontext.Background().WithLogger(appLogger)
+	extStore, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+
+	cp, err := loadCheckpointManager(tctx, extStore)
+	require.NoError(t, err)
+	require.Nil(t, cp)
+
+	cp = newCheckpointManager(extStore, "123", checkpointConfig{})
+	chunks := []*chunkCheckpoint{
+		{Index: 0, Total: 2, Queries: []string{"SELECT * FROM `test`.`t` WHERE a < 10"}, ColLen: 2},
+		{Index: 1, Total: 2, Queries: []string{"SELECT * FROM `test`.`t` WHERE a >= 10", "SELECT 1"}, ColLen: 2},
+	}
+	require.NoError(t, cp.recordTable(tctx, "test", "t", chunks))
+	require.NoError(t, cp.finishChunk(tctx, "test", "t", 1))
+	// unknown tables and chunks are ignored
+	require.NoError(t, cp.finishChunk(tctx, "test", "t2", 0))
+	require.NoError(t, cp.finishChunk(tctx, "test", "t", 5))
+
+	// the finished chunk isn't saved until the save interval passes or it's flushed
+	loaded, err := loadCheckpointManager(tctx, extStore)
+	require.NoError(t, err)
+	require.Equal(t, "123", loaded.cp.Snapshot)
+	loadedChunks, ok := loaded.tableChunks("test", "t")
+	require.True(t, ok)
+	require.Len(t, loadedChunks, 2)
+	require.False(t, loadedChunks[1].Finished)
+	require.NoError(t, cp.flush(tctx))
+
+	loaded, err = loadCheckpointManager(tctx, extStore)
+	require.NoError(t, err)
+	loadedChunks, ok = loaded.tableChunks("test", "t")
+	require.True(t, ok)
+	require.Len(t, loadedChunks, 2)
+	require.False(t, loadedChunks[0].Finished)
+	require.True(t, loadedChunks[1].Finished)
+	require.Equal(t, chunks[0].Queries, loadedChunks[0].Queries)
+	require.IsType(t, &tableData{}, loadedChunks[0].tableData())
+	require.IsType(t, &multiQueriesChunk{}, loadedChunks[1].tableData())
+	_, ok = loaded.tableChunks("test", "t2")
+	require.False(t, ok)
+
+	require.NoError(t, loaded.remove(tctx))
+	exists, err := extStore.FileExists(tctx, checkpointPath)
+	require.NoError(t, err)
+	require.False(t, exists)
+	require.NoError(t, loaded.remove(tctx))
+
+	require.NoError(t, extStore.WriteFile(tctx, checkpointPath, []byte(`{"tables": {}}`)))
+	_, err = loadCheckpointManager(tctx, extStore)
+	require.ErrorContains(t, err, "snapshot is missing")
+
+	// the finished chunk is saved if the save interval passes
+	interval := checkpointSaveInterval
+	checkpointSaveInterval = 0
+	defer func() {
+		checkpointSaveInterval = interval
+	}()
+	cp = newCheckpointManager(extStore, "123", checkpointConfig{})
+	require.NoError(t, cp.recordTable(tctx, "test", "t", chunks))
+	require.NoError(t, cp.finishChunk(tctx, "test", "t", 0))
+	loaded, err = loadCheckpointManager(tctx, extStore)
+	require.NoError(t, err)
+	loadedChunks, ok = loaded.tableChunks("test", "t")
+	require.True(t, ok)
+	require.True(t, loadedChunks[0].Finished)
+}
+
+func TestDumpTableDataWithCheckpoint(t *testing.T) {
+	tctx := tcontext.Background().WithLogger(appLogger)
+	extStore, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+
+	conf := defaultConfigForTest(t)
+	d := &Dumper{
+		tctx:       tctx,
+		conf:       conf,
+		metrics:    newMetrics(promutil.NewDefaultFactory(), nil),
+		checkpoint: newCheckpointManager(extStore, "123", checkpointConfig{}),
+	}
+	meta := &mockTableIR{
+		dbName:           database,
+		tblName:          table,
+		selectedField:    "*",
+		selectedLen:      1,
+		hasImplicitRowID: true,
+	}
+
+	// the chunks of a new table are recorded before they are sent
+	taskChan := make(chan Task, 8)
+	require.NoError(t, d.dumpTableDataWithCheckpoint(tctx, nil, meta, taskChan))
+	require.Len(t, taskChan, 1)
+	task := (<-taskChan).(*TaskTableData)
+	chunks, ok := d.checkpoint.tableChunks(database, table)
+	require.True(t, ok)
+	require.Equal(t, []*chunkCheckpoint{{
+		Index:   0,
+		Total:   1,
+		Queries: []string{task.Data.(*tableData).query},
+		ColLen:  1,
+	}}, chunks)
+
+	// the recorded chunks are reused and the finished chunks are skipped
+	require.NoError(t, d.checkpoint.recordTable(tctx, database, table, []*chunkCheckpoint{
+		{Index: 0, Total: 3, Queries: []string{"q0"}, ColLen: 1, Finished: true},
+		{Index: 1, Total: 3, Queries: []string{"q1"}, ColLen: 1},
+		{Index: 2, Total: 3, Queries: []string{"q2", "q3"}, ColLen: 1},
+	}))
+	require.NoError(t, d.dumpTableDataWithCheckpoint(tctx, nil, meta, taskChan))
+	require.Len(t, taskChan, 2)
+	task = (<-taskChan).(*TaskTableData)
+	require.Equal(t, 1, task.ChunkIndex)
+	require.Equal(t, 3, task.TotalChunks)
+	require.Equal(t, "q1", task.Data.(*tableData).query)
+	task = (<-taskChan).(*TaskTableData)
+	require.Equal(t, 2, task.ChunkIndex)
+	require.Equal(t, []string{"q2", "q3"}, task.Data.(*multiQueriesChunk).queries)
+}
+
+func TestLoadCheckpointWithDifferentConfig(t *testing.T) {
+	tctx := tcontext.Background().WithLogger(appLogger)
+	extStore, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+
+	conf := defaultConfigForTest(t)
+	conf.Resume = true
+	conf.Consistency = ConsistencyTypeSnapshot
+	conf.FileType = FileFormatCSVString
+	conf.Rows = 1000
+	conf.Tables, err = GetConfTables([]string{"test.t2", "test.t1"})
+	require.NoError(t, err)
+	conf.TableFilterRules = []string{"test.*"}
+	cp := newCheckpointManager(extStore, "123", newCheckpointConfig(conf))
+	require.NoError(t, cp.recordTable(tctx, "test", "t1", nil))
+
+	loaded, err := loadCheckpointManager(tctx, extStore)
+	require.NoError(t, err)
+	require.Equal(t, cp.cp.Config, loaded.cp.Config)
+	require.Equal(t, []string{"test.t1", "test.t2"}, loaded.cp.Config.TablesList)
+
+	for arg, change := range map[string]func(*Config){
+		"--filetype":         func(c *Config) { c.FileType = FileFormatSQLTextString },
+		"--rows":             func(c *Config) { c.Rows = UnspecifiedSize },
+		"--filesize":         func(c *Config) { c.FileSize = 1 << 20 },
+		"--database":         func(c *Config) { c.Databases = []string{"test"} },
+		"--tables-list":      func(c *Config) { c.Tables = DatabaseTables{} },
+		"--filter":           func(c *Config) { c.TableFilterRules = []string{"*.*"} },
+		"--case-sensitive":   func(c *Config) { c.CaseSensitive = true },
+		"--where":            func(c *Config) { c.Where = "a > 1" },
+		"--compress":         func(c *Config) { c.CompressType = storage.Gzip },
+		"--parquet-compress": func(c *Config) { c.ParquetCompressType = parquet.CompressionCodec_ZSTD },
+	} {
+		changed := *conf
+		change(&changed)
+		d := &Dumper{tctx: tctx, conf: &changed, extStore: extStore}
+		err = loadCheckpoint(d)
+		require.ErrorContains(t, err, arg+" is different from the interrupted dump", arg)
+		require.Nil(t, d.checkpoint)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"os"
 	"strconv"
 	"strings"
 	"text/template"
@@ -25,6 +26,7 @@ import (
 	filter "github.com/pingcap/tidb/pkg/util/table-filter"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/spf13/pflag"
+	"github.com/xitongsys/parquet-go/parquet"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 )
@@ -76,6 +78,13 @@ const (
 	flagTransactionalConsistency = "transactional-consistency"
 	flagCompress                 = "compress"
 	flagCsvOutputDialect         = "csv-output-dialect"
+	flagParquetRowGroupSize      = "parquet-row-group-size"
+	flagParquetCompress          = "parquet-compress"
+	flagResume                   = "resume"
+	flagCheckpoint               = "checkpoint"
+	flagMaskRules                = "mask-rules"
+	flagSinceSnapshot            = "since-snapshot"
+	flagUpdatedAtColumn          = "updated-at-column"
 
 	// FlagHelp represents the help flag
 	FlagHelp = "help"
@@ -133,6 +142,8 @@ type Config struct {
 	EscapeBackslash          bool
 	DumpEmptyDatabase        bool
 	PosAfterConnect          bool
+	Resume                   bool
+	Checkpoint               bool
 	CompressType             storage.CompressType
 
 	Host     string
@@ -156,6 +167,8 @@ type Config struct {
 	OutputDirPath     string
 	StatusAddr        string
 	Snapshot          string
+	SinceSnapshot     string
+	UpdatedAtColumn   string
 	Consistency       string
 	CsvNullValue      string
 	SQL               string
@@ -164,7 +177,12 @@ type Config struct {
 	CsvLineTerminator string
 	Databases         []string
 
-	TableFilter         filter.Filter `json:"-"`
+	TableFilter filter.Filter `json:"-"`
+	// TableFilterRules and CaseSensitive are the --filter and --case-sensitive
+	// arguments which TableFilter is parsed from, they're only used to check
+	// the config of a resumed dump.
+	TableFilterRules    []string
+	CaseSensitive       bool
 	Where               string
 	FileType            string
 	ServerInfo          version.ServerInfo
@@ -180,6 +198,12 @@ type Config struct {
 	CollationCompatible string
 	CsvOutputDialect    CSVDialect
 
+	ParquetRowGroupSize uint64
+	ParquetCompressType parquet.CompressionCodec
+
+	// Mask is the rules to mask the column values, nil means no column is masked.
+	Mask *MaskConfig
+
 	Labels        prometheus.Labels       `json:"-"`
 	PromFactory   promutil.Factory        `json:"-"`
 	PromRegistry  promutil.Registry       `json:"-"`
@@ -237,6 +261,8 @@ func DefaultConfig() *Config {
 		PosAfterConnect:          false,
 		CollationCompatible:      LooseCollationCompatible,
 		CsvOutputDialect:         CSVDialectDefault,
+		ParquetRowGroupSize:      DefaultParquetRowGroupSize,
+		ParquetCompressType:      parquet.CompressionCodec_SNAPPY,
 		SpecifiedTables:          false,
 		PromFactory:              promutil.NewDefaultFactory(),
 		PromRegistry:             promutil.NewDefaultRegistry(),
@@ -330,7 +356,7 @@ func (*Config) DefineFlags(flags *pflag.FlagSet) {
 		"If not specified, dumpling will dump table without inner-concurrency which could be relatively slow. default unlimited")
 	flags.String(flagWhere, "", "Dump only selected records")
 	flags.Bool(flagEscapeBackslash, true, "use backslash to escape special characters")
-	flags.String(flagFiletype, "", "The type of export file (sql/csv)")
+	flags.String(flagFiletype, "", "The type of export file (sql/csv/parquet)")
 	flags.Bool(flagNoHeader, false, "whether not to dump CSV table header")
 	flags.BoolP(flagNoSchemas, "m", false, "Do not dump table schemas with the data")
 	flags.BoolP(flagNoData, "d", false, "Do not dump table data")
@@ -357,6 +383,13 @@ func (*Config) DefineFlags(flags *pflag.FlagSet) {
 	_ = flags.MarkHidden(flagTransactionalConsistency)
 	flags.StringP(flagCompress, "c", "", "Compress output file type, support 'gzip', 'snappy', 'zstd', 'no-compression' now")
 	flags.String(flagCsvOutputDialect, "", "The dialect of output CSV file, support 'snowflake', 'redshift', 'bigquery' now")
+	flags.String(flagParquetRowGroupSize, "128MiB", "The uncompressed size of a row group in parquet files")
+	flags.String(flagParquetCompress, "snappy", "Compress type of parquet pages, support 'snappy', 'gzip', 'zstd', 'no-compression' now")
+	flags.String(flagMaskRules, "", "The path of the TOML file of column masking rules, the matched columns are masked when they are written to sql or csv files")
+	flags.String(flagSinceSnapshot, "", "Dump only the rows changed between this snapshot position (uint64 or MySQL style string timestamp) and --snapshot. The changed rows are written as REPLACE statements and the keys of the deleted rows are written to the -deletes files, which should be applied before the data files. The table is always split into chunks, and --rows defaults to 200000. Every chunk of a table with primary key is read entirely at both snapshots to find the changed rows, unless the table has the --updated-at-column. A table without primary key is dumped entirely. Valid only when consistency=snapshot")
+	flags.String(flagUpdatedAtColumn, "", "The column which is set to the current time whenever the row is inserted or updated, e.g. a TIMESTAMP column with DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP. For the tables having the column, --since-snapshot reads only the primary key at both snapshots, and only the rows whose column is later than 1 hour before the since-snapshot. The rows changed with the column unchanged or set to an earlier time are missed")
+	flags.Bool(flagResume, false, "Resume the interrupted dump from the checkpoint file in the output directory, the snapshot of the interrupted dump is reused and the finished chunks are skipped. The options which decide the dumped tables and files, such as --filetype, --rows, --filesize, --filter, --where and --compress, must be the same as the interrupted dump. Valid only when consistency=snapshot")
+	flags.Bool(flagCheckpoint, false, "Record the finished chunks in a checkpoint file in the output directory, so that the dump can be resumed by --resume if it's interrupted. It's enabled by --resume. Valid only when consistency=snapshot")
 }
 
 // ParseFromFlags parses dumpling's export.Config from flags
@@ -419,6 +452,14 @@ func (conf *Config) ParseFromFlags(flags *pflag.FlagSet) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	conf.SinceSnapshot, err = flags.GetString(flagSinceSnapshot)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	conf.UpdatedAtColumn, err = flags.GetString(flagUpdatedAtColumn)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	conf.NoViews, err = flags.GetBool(flagNoViews)
 	if err != nil {
 		return errors.Trace(err)
@@ -515,6 +556,14 @@ func (conf *Config) ParseFromFlags(flags *pflag.FlagSet) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	conf.Resume, err = flags.GetBool(flagResume)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	conf.Checkpoint, err = flags.GetBool(flagCheckpoint)
+	if err != nil {
+		return errors.Trace(err)
+	}
 
 	if conf.Threads <= 0 {
 		return errors.Errorf("--threads is set to %d. It should be greater than 0", conf.Threads)
@@ -562,6 +611,7 @@ func (conf *Config) ParseFromFlags(flags *pflag.FlagSet) error {
 	if err != nil {
 		return errors.Errorf("failed to parse filter: %s", err)
 	}
+	conf.TableFilterRules, conf.CaseSensitive = filters, caseSensitive
 
 	if !caseSensitive {
 		conf.TableFilter = filter.CaseInsensitive(conf.TableFilter)
@@ -602,6 +652,39 @@ func (conf *Config) ParseFromFlags(flags *pflag.FlagSet) error {
 		return errors.Trace(err)
 	}
 
+	rowGroupSize, err := flags.GetString(flagParquetRowGroupSize)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	size, err := units.RAMInBytes(rowGroupSize)
+	if err != nil || size <= 0 {
+		return errors.Errorf("failed to parse parquet row group size (--%s '%s')", flagParquetRowGroupSize, rowGroupSize)
+	}
+	conf.ParquetRowGroupSize = uint64(size)
+	parquetCompress, err := flags.GetString(flagParquetCompress)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	conf.ParquetCompressType, err = ParseParquetCompressType(parquetCompress)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	maskRules, err := flags.GetString(flagMaskRules)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if maskRules != "" {
+		data, err := os.ReadFile(maskRules)
+		if err != nil {
+			return errors.Annotatef(err, "failed to read masking rules (--%s '%s')", flagMaskRules, maskRules)
+		}
+		conf.Mask, err = ParseMaskConfig(string(data))
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	for k, v := range params {
 		conf.SessionParams[k] = v
 	}
@@ -686,6 +769,22 @@ func ParseCompressType(compressType string) (storage.CompressType, error) {
 	}
 }
 
+// ParseParquetCompressType parses compressType string to the compression codec of parquet pages
+func ParseParquetCompressType(compressType string) (parquet.CompressionCodec, error) {
+	switch compressType {
+	case "no-compression":
+		return parquet.CompressionCodec_UNCOMPRESSED, nil
+	case "", "snappy":
+		return parquet.CompressionCodec_SNAPPY, nil
+	case "gzip", "gz":
+		return parquet.CompressionCodec_GZIP, nil
+	case "zstd", "zst":
+		return parquet.CompressionCodec_ZSTD, nil
+	default:
+		return parquet.CompressionCodec_UNCOMPRESSED, errors.Errorf("unknown parquet compress type %s", compressType)
+	}
+}
+
 // ParseOutputDialect parses output dialect string to Dialect
 func ParseOutputDialect(outputDialect string) (CSVDialect, error) {
 	switch outputDialect {
@@ -718,8 +817,12 @@ func (conf *Config) createExternalStorage(ctx context.Context) (storage.External
 const (
 	// UnspecifiedSize means the filesize/statement-size is unspecified
 	UnspecifiedSize = 0
+	// defaultSinceSnapshotRows is the --rows used by the dump with --since-snapshot when --rows is unspecified
+	defaultSinceSnapshotRows = 200000
 	// DefaultStatementSize is the default statement size
 	DefaultStatementSize = 1000000
+	// DefaultParquetRowGroupSize is the default uncompressed size of a row group in parquet files
+	DefaultParquetRowGroupSize = 128 * units.MiB
 	// TiDBMemQuotaQueryName is the session variable TiDBMemQuotaQuery's name in TiDB
 	TiDBMemQuotaQueryName = "tidb_mem_quota_query"
 	// DefaultTableFilter is the default exclude table filter. It will exclude all system databases
@@ -773,6 +876,34 @@ func validateSpecifiedSQL(conf *Config) error {
 	if conf.SQL != "" && conf.Where != "" {
 		return errors.New("can't specify both --sql and --where at the same time. Please try to combine them into --sql")
 	}
+	if conf.SQL != "" && conf.Resume {
+		return errors.New("can't specify both --sql and --resume at the same time")
+	}
+	if conf.SQL != "" && conf.Checkpoint {
+		return errors.New("can't specify both --sql and --checkpoint at the same time")
+	}
+	if conf.SQL != "" && conf.SinceSnapshot != "" {
+		return errors.New("can't specify both --sql and --since-snapshot at the same time")
+	}
+	return nil
+}
+
+func validateSinceSnapshot(conf *Config) error {
+	if conf.SinceSnapshot == "" {
+		if conf.UpdatedAtColumn != "" {
+			return errors.Errorf("--%s is valid only with --%s", flagUpdatedAtColumn, flagSinceSnapshot)
+		}
+		return nil
+	}
+	// the keys of the deleted rows are not masked, so they can't match the masked rows
+	if conf.Mask != nil && len(conf.Mask.Rules) > 0 {
+		return errors.Errorf("can't specify both --%s and --%s at the same time", flagMaskRules, flagSinceSnapshot)
+	}
+	// the rows of a chunk at the since-snapshot are kept in memory to find the changed rows,
+	// so the table must be split into chunks to bound the memory usage
+	if conf.Rows == UnspecifiedSize {
+		conf.Rows = defaultSinceSnapshotRows
+	}
 	return nil
 }
 
@@ -790,6 +921,13 @@ func adjustFileFormat(conf *Config) error {
 			return errors.Errorf("unsupported config.FileType '%s' when we specify --sql, please unset --filetype or set it to 'csv'", conf.FileType)
 		}
 	case FileFormatCSVString:
+	case FileFormatParquetString:
+		if conf.CompressType != storage.NoCompression {
+			return errors.Errorf("unsupported --compress when config.FileType is '%s', please use --%s to compress the parquet pages", conf.FileType, flagParquetCompress)
+		}
+		if conf.Mask != nil && len(conf.Mask.Rules) > 0 {
+			return errors.Errorf("unsupported --%s when config.FileType is '%s', please set it to 'sql' or 'csv'", flagMaskRules, conf.FileType)
+		}
 	default:
 		return errors.Errorf("unknown config.FileType '%s'", conf.FileType)
 	}
@@ -62,6 +62,10 @@ type Dumper struct {
 
 	extStore storage.ExternalStorage
 	dbHandle *sql.DB
+	// sinceDBHandle reads at the --since-snapshot, it's nil if the dump isn't incremental.
+	sinceDBHandle *sql.DB
+	// sinceTS is the TSO of the --since-snapshot.
+	sinceTS uint64
 
 	tidbPDClientForGC             pd.Client
 	selectTiDBTableRegionFunc     func(tctx *tcontext.Context, conn *BaseConn, meta TableMeta) (pkFields []string, pkVals [][]string, err error)
@@ -69,6 +73,7 @@ type Dumper struct {
 	charsetAndDefaultCollationMap map[string]string
 
 	speedRecorder *SpeedRecorder
+	checkpoint    *checkpointManager
 }
 
 // NewDumper returns a new Dumper
@@ -108,6 +113,7 @@ func NewDumper(ctx context.Context, conf *Config) (*Dumper, error) {
 	err = adjustConfig(conf,
 		buildTLSConfig,
 		validateSpecifiedSQL,
+		validateSinceSnapshot,
 		adjustFileFormat)
 	if err != nil {
 		return nil, err
@@ -132,11 +138,15 @@ func NewDumper(ctx context.Context, conf *Config) (*Dumper, error) {
 		resolveAutoConsistency,
 
 		validateResolveAutoConsistency,
+		loadCheckpoint,
 		tidbSetPDClientForGC,
 		tidbGetSnapshot,
+		tidbCheckSinceSnapshot,
+		initCheckpoint,
 		tidbStartGCSavepointUpdateService,
 
-		setSessionParam)
+		setSessionParam,
+		openSinceSnapshotDB)
 	return d, err
 }
 
@@ -156,6 +166,11 @@ func (d *Dumper) Dump() (dumpErr error) {
 	defer func() {
 		if dumpErr == nil {
 			_ = m.writeGlobalMetaData()
+		} else if d.checkpoint != nil {
+			// the context may be canceled, the finished chunks are saved anyway
+			if err := d.checkpoint.flush(context.Background()); err != nil {
+				tctx.L().Warn("fail to save checkpoint", log.ShortError(err))
+			}
 		}
 	}()
 
@@ -335,6 +350,11 @@ func (d *Dumper) Dump() (dumpErr error) {
 
 	summary.SetSuccessStatus(true)
 	m.recordFinishTime(time.Now())
+	if d.checkpoint != nil {
+		if err = d.checkpoint.remove(tctx); err != nil {
+			tctx.L().Warn("fail to remove checkpoint file", log.ShortError(err))
+		}
+	}
 	return nil
 }
 
@@ -372,6 +392,11 @@ func (d *Dumper) startWriters(tctx *tcontext.Context, wg *errgroup.Group, taskCh
 					zap.String("database", td.Meta.DatabaseName()),
 					zap.String("table", td.Meta.TableName()),
 					zap.Int("chunkIdx", td.ChunkIndex))
+				if d.checkpoint != nil {
+					if err := d.checkpoint.finishChunk(tctx, td.Meta.DatabaseName(), td.Meta.TableName(), td.ChunkIndex); err != nil {
+						tctx.L().Warn("fail to save checkpoint", log.ShortError(err))
+					}
+				}
 			}
 		})
 		wg.Go(func() error {
@@ -623,6 +648,16 @@ func (d *Dumper) dumpTableData(tctx *tcontext.Context, conn *BaseConn, meta Tabl
 	c := estimateCount(tctx, meta.DatabaseName(), meta.TableName(), conn, fieldName, conf)
 	AddCounter(d.metrics.estimateTotalRowsCounter, float64(c))
 
+	if d.sinceDBHandle != nil {
+		var err error
+		// the chunks of the table are converted to incremental chunks in newTaskTableData
+		if meta, err = newIncrementalTableMeta(tctx, conn, meta, conf.UpdatedAtColumn, d.sinceTS); err != nil {
+			return err
+		}
+	}
+	if d.checkpoint != nil {
+		return d.dumpTableDataWithCheckpoint(tctx, conn, meta, taskChan)
+	}
 	if conf.Rows == UnspecifiedSize {
 		return d.sequentialDumpTable(tctx, conn, meta, taskChan)
 	}
@@ -632,10 +667,15 @@ func (d *Dumper) dumpTableData(tctx *tcontext.Context, conn *BaseConn, meta Tabl
 func (d *Dumper) buildConcatTask(tctx *tcontext.Context, conn *BaseConn, meta TableMeta) (*TaskTableData, error) {
 	tableChan := make(chan Task, 128)
 	errCh := make(chan error, 1)
+	// the sub tasks are concatenated into one task below, which is converted to the incremental chunk
+	subTaskMeta := meta
+	if m, ok := meta.(*incrementalTableMeta); ok {
+		subTaskMeta = m.TableMeta
+	}
 	go func() {
 		// adjust rows to suitable rows for this table
 		d.conf.Rows = GetSuitableRows(meta.AvgRowLength())
-		err := d.concurrentDumpTable(tctx, conn, meta, tableChan)
+		err := d.concurrentDumpTable(tctx, conn, subTaskMeta, tableChan)
 		d.conf.Rows = UnspecifiedSize
 		if err != nil {
 			errCh <- err
@@ -1290,6 +1330,9 @@ func canRebuildConn(consistency string, trxConsistencyOnly bool) bool {
 func (d *Dumper) Close() error {
 	d.cancelCtx()
 	d.metrics.unregisterFrom(d.conf.PromRegistry)
+	if d.sinceDBHandle != nil {
+		_ = d.sinceDBHandle.Close()
+	}
 	if d.dbHandle != nil {
 		return d.dbHandle.Close()
 	}
@@ -1442,6 +1485,101 @@ func validateResolveAutoConsistency(d *Dumper) error {
 	if conf.Consistency != ConsistencyTypeSnapshot && conf.Snapshot != "" {
 		return errors.Errorf("can't specify --snapshot when --consistency isn't snapshot, resolved consistency: %s", conf.Consistency)
 	}
+	if conf.Consistency != ConsistencyTypeSnapshot && conf.SinceSnapshot != "" {
+		return errors.Errorf("can't specify --since-snapshot when --consistency isn't snapshot, resolved consistency: %s", conf.Consistency)
+	}
+	return nil
+}
+
+// loadCheckpoint is an initialization step of Dumper.
+func loadCheckpoint(d *Dumper) error {
+	tctx, conf := d.tctx, d.conf
+	if !conf.Resume {
+		return nil
+	}
+	if conf.Consistency != ConsistencyTypeSnapshot {
+		return errors.Errorf("can't specify --resume when --consistency isn't snapshot, resolved consistency: %s", conf.Consistency)
+	}
+	cp, err := loadCheckpointManager(tctx, d.extStore)
+	if err != nil {
+		return err
+	}
+	if cp == nil {
+		tctx.L().Info("checkpoint file is not found, start a new dump", zap.String("path", checkpointPath))
+		return nil
+	}
+	if conf.Snapshot != "" && conf.Snapshot != cp.cp.Snapshot {
+		return errors.Errorf("--snapshot %s is different from the snapshot %s in checkpoint file", conf.Snapshot, cp.cp.Snapshot)
+	}
+	cfg := newCheckpointConfig(conf)
+	if arg := cfg.diff(&cp.cp.Config); arg != "" {
+		return errors.Errorf("%s is different from the interrupted dump in checkpoint file, the dump can't be resumed", arg)
+	}
+	if err = checkSnapshotNotGC(tctx, d.dbHandle, cp.cp.Snapshot); err != nil {
+		return err
+	}
+	conf.Snapshot = cp.cp.Snapshot
+	d.checkpoint = cp
+	tctx.L().Info("resume dump from checkpoint", zap.String("snapshot", conf.Snapshot))
+	return nil
+}
+
+// tidbCheckSinceSnapshot is an initialization step of Dumper.
+// It checks the --since-snapshot is supported by the server and is before the snapshot of the dump.
+func tidbCheckSinceSnapshot(d *Dumper) error {
+	conf, pool := d.conf, d.dbHandle
+	if conf.SinceSnapshot == "" {
+		return nil
+	}
+	if conf.ServerInfo.ServerType != version.ServerTypeTiDB {
+		return errors.New("--since-snapshot is only supported for TiDB")
+	}
+	sinceTS, err := parseSnapshotToTSO(pool, conf.SinceSnapshot)
+	if err != nil {
+		return err
+	}
+	snapshotTS, err := parseSnapshotToTSO(pool, conf.Snapshot)
+	if err != nil {
+		return err
+	}
+	if sinceTS >= snapshotTS {
+		return errors.Errorf("--since-snapshot %s must be before the snapshot %s of the dump", conf.SinceSnapshot, conf.Snapshot)
+	}
+	d.sinceTS = sinceTS
+	return nil
+}
+
+// openSinceSnapshotDB opens the connection pool reading at the --since-snapshot.
+func openSinceSnapshotDB(d *Dumper) error {
+	conf := d.conf
+	if conf.SinceSnapshot == "" {
+		return nil
+	}
+	params := make(map[string]any, len(conf.SessionParams)+1)
+	for k, v := range conf.SessionParams {
+		params[k] = v
+	}
+	params[snapshotVar] = conf.SinceSnapshot
+	driverCfg := conf.GetDriverConfig("")
+	c, err := mysql.NewConnector(driverCfg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	d.sinceDBHandle, err = resetDBWithSessionParams(d.tctx, sql.OpenDB(c), driverCfg, params)
+	return errors.Annotate(err, "fail to open connections at --since-snapshot")
+}
+
+// initCheckpoint is an initialization step of Dumper.
+func initCheckpoint(d *Dumper) error {
+	conf := d.conf
+	if d.checkpoint != nil || !(conf.Checkpoint || conf.Resume) || conf.NoData {
+		return nil
+	}
+	// only the dump with a snapshot can be resumed
+	if conf.Consistency != ConsistencyTypeSnapshot || conf.Snapshot == "" {
+		return errors.Errorf("can't specify --checkpoint when --consistency isn't snapshot, resolved consistency: %s", conf.Consistency)
+	}
+	d.checkpoint = newCheckpointManager(d.extStore, conf.Snapshot, newCheckpointConfig(conf))
 	return nil
 }
 
@@ -1508,6 +1646,10 @@ func tidbGetSnapshot(d *Dumper) error {
 func tidbStartGCSavepointUpdateService(d *Dumper) error {
 	tctx, pool, conf := d.tctx, d.dbHandle, d.conf
 	snapshot, si := conf.Snapshot, conf.ServerInfo
+	// the older snapshot must be kept from GC in the incremental dump
+	if conf.SinceSnapshot != "" {
+		snapshot = conf.SinceSnapshot
+	}
 	if d.tidbPDClientForGC != nil {
 		snapshotTS, err := parseSnapshotToTSO(pool, snapshot)
 		if err != nil {
@@ -1707,5 +1849,8 @@ func (d *Dumper) renewSelectTableRegionFuncForLowerTiDB(tctx *tcontext.Context)
 
 func (d *Dumper) newTaskTableData(meta TableMeta, data TableDataIR, currentChunk, totalChunks int) *TaskTableData {
 	d.metrics.totalChunks.Add(1)
+	if m, ok := meta.(*incrementalTableMeta); ok {
+		data = toIncrementalChunk(d.sinceDBHandle, data, m)
+	}
 	return NewTaskTableData(meta, data, currentChunk, totalChunks)
 }
@@ -0,0 +1,551 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package export
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	tcontext "github.com/pingcap/tidb/dumpling/context"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/opcode"
+	"go.uber.org/zap"
+)
+
+// updatedAtColumnLag is how much earlier than the since-snapshot the rows are
+// read from by the --updated-at-column. The column is set when the row is
+// changed, which is earlier than the commit of the change, and a transaction
+// can't last longer than the max-txn-ttl of TiDB, which is 1 hour by default.
+const updatedAtColumnLag = time.Hour
+
+// incrementalTableMeta is the meta of a table dumped with --since-snapshot.
+type incrementalTableMeta struct {
+	TableMeta
+	// keyColumns are the offsets of the primary key columns in the selected
+	// columns. It's empty if the table has no primary key, then all the rows
+	// of the table are deleted and dumped again.
+	keyColumns []int
+	// changedCond selects the rows changed since the since-snapshot by the
+	// --updated-at-column. It's empty if the table has no such column or no
+	// usable primary key, then the whole chunk is compared at both snapshots.
+	changedCond string
+}
+
+func newIncrementalTableMeta(tctx *tcontext.Context, conn *BaseConn, meta TableMeta, updatedAtColumn string, sinceTS uint64) (*incrementalTableMeta, error) {
+	pkCols, err := GetPrimaryKeyColumns(tctx, conn, meta.DatabaseName(), meta.TableName())
+	if err != nil {
+		return nil, err
+	}
+	colNames := meta.ColumnNames()
+	keyColumns := make([]int, 0, len(pkCols))
+	for _, pkCol := range pkCols {
+		idx := slices.IndexFunc(colNames, func(name string) bool {
+			return strings.EqualFold(name, pkCol)
+		})
+		// the primary key column is not selected, e.g. it's a generated column
+		if idx < 0 {
+			keyColumns = nil
+			break
+		}
+		keyColumns = append(keyColumns, idx)
+	}
+	if len(keyColumns) == 0 {
+		tctx.L().Warn("no usable primary key to compare the snapshots, the whole table will be dumped and the old rows will be deleted",
+			zap.String("database", meta.DatabaseName()),
+			zap.String("table", meta.TableName()))
+		return &incrementalTableMeta{TableMeta: meta}, nil
+	}
+	m := &incrementalTableMeta{TableMeta: meta, keyColumns: keyColumns}
+	if updatedAtColumn != "" && slices.ContainsFunc(colNames, func(name string) bool {
+		return strings.EqualFold(name, updatedAtColumn)
+	}) {
+		m.changedCond = fmt.Sprintf("%s > TIDB_PARSE_TSO(%d) - INTERVAL %d SECOND",
+			wrapBackTicks(escapeString(updatedAtColumn)), sinceTS, int64(updatedAtColumnLag/time.Second))
+	}
+	return m, nil
+}
+
+// incrementalRow is a row at the since-snapshot.
+type incrementalRow struct {
+	digest [sha256.Size]byte
+	key    [][]byte
+}
+
+// incrementalChunk is a table chunk dumped with --since-snapshot. The chunk is
+// read at both the since-snapshot and the snapshot, only the inserted and
+// updated rows are returned and the keys of the deleted rows are collected.
+// If the table has the --updated-at-column, only the key columns are read at
+// both snapshots, and only the rows changed by the column are read at the
+// snapshot.
+type incrementalChunk struct {
+	sinceDB     *sql.DB
+	queries     []string
+	colLen      int
+	keyColumns  []int
+	keyNames    []string
+	changedCond string
+
+	tctx     *tcontext.Context
+	conn     *sql.Conn
+	prevRows map[string]*incrementalRow
+	// changedQueries are the queries with changedCond, it's set by Start.
+	changedQueries []string
+	SQLRowIter
+}
+
+// toIncrementalChunk converts the table data planned by the dumper to an incremental chunk.
+func toIncrementalChunk(sinceDB *sql.DB, data TableDataIR, meta *incrementalTableMeta) TableDataIR {
+	switch td := data.(type) {
+	case *tableData:
+		return newIncrementalChunk(sinceDB, []string{td.query}, td.colLen, meta)
+	case *multiQueriesChunk:
+		return newIncrementalChunk(sinceDB, td.queries, td.colLen, meta)
+	default:
+		return data
+	}
+}
+
+func newIncrementalChunk(sinceDB *sql.DB, queries []string, colLen int, meta *incrementalTableMeta) *incrementalChunk {
+	colNames := meta.ColumnNames()
+	keyNames := make([]string, 0, len(meta.keyColumns))
+	for _, col := range meta.keyColumns {
+		keyNames = append(keyNames, colNames[col])
+	}
+	return &incrementalChunk{
+		sinceDB:     sinceDB,
+		queries:     queries,
+		colLen:      colLen,
+		keyColumns:  meta.keyColumns,
+		keyNames:    keyNames,
+		changedCond: meta.changedCond,
+	}
+}
+
+func (c *incrementalChunk) Start(tctx *tcontext.Context, conn *sql.Conn) error {
+	c.tctx = tctx
+	c.conn = conn
+	c.SQLRowIter = nil
+	c.prevRows = nil
+	c.changedQueries = nil
+	if len(c.keyColumns) == 0 {
+		return nil
+	}
+	if c.changedCond == "" {
+		return c.loadPrevRows(tctx)
+	}
+	return c.loadDeletedKeys(tctx)
+}
+
+// loadPrevRows reads the chunk at the since-snapshot and remembers the digest of every row.
+// The memory usage is bounded by the size of the chunk, see validateSinceSnapshot.
+func (c *incrementalChunk) loadPrevRows(tctx *tcontext.Context) error {
+	conn, err := c.sinceDB.Conn(tctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer conn.Close()
+
+	prevRows := make(map[string]*incrementalRow)
+	h := sha256.New()
+	var keyBuf []byte
+	err = scanRows(tctx, conn, c.queries, c.colLen, func(row rawRow) {
+		keyBuf = appendRowKey(keyBuf[:0], row, c.keyColumns)
+		prevRows[string(keyBuf)] = &incrementalRow{digest: rowDigest(h, row), key: cloneRowKey(row, c.keyColumns)}
+	})
+	if err != nil {
+		return err
+	}
+	c.prevRows = prevRows
+	return nil
+}
+
+// loadDeletedKeys reads the key columns of the chunk at both snapshots, the
+// keys which only exist at the since-snapshot are the deleted ones.
+func (c *incrementalChunk) loadDeletedKeys(tctx *tcontext.Context) error {
+	keyQueries := make([]string, 0, len(c.queries))
+	changedQueries := make([]string, 0, len(c.queries))
+	for _, query := range c.queries {
+		keyQuery, err := rewriteChunkQuery(query, c.keyNames, "")
+		if err != nil {
+			return err
+		}
+		changedQuery, err := rewriteChunkQuery(query, nil, c.changedCond)
+		if err != nil {
+			return err
+		}
+		keyQueries = append(keyQueries, keyQuery)
+		changedQueries = append(changedQueries, changedQuery)
+	}
+
+	conn, err := c.sinceDB.Conn(tctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer conn.Close()
+
+	// the key columns are the only selected columns of the key queries
+	keyColumns := make([]int, len(c.keyColumns))
+	for i := range keyColumns {
+		keyColumns[i] = i
+	}
+	prevRows := make(map[string]*incrementalRow)
+	var keyBuf []byte
+	err = scanRows(tctx, conn, keyQueries, len(keyColumns), func(row rawRow) {
+		keyBuf = appendRowKey(keyBuf[:0], row, keyColumns)
+		prevRows[string(keyBuf)] = &incrementalRow{key: cloneRowKey(row, keyColumns)}
+	})
+	if err != nil {
+		return err
+	}
+	err = scanRows(tctx, c.conn, keyQueries, len(keyColumns), func(row rawRow) {
+		keyBuf = appendRowKey(keyBuf[:0], row, keyColumns)
+		delete(prevRows, string(keyBuf))
+	})
+	if err != nil {
+		return err
+	}
+	c.prevRows = prevRows
+	c.changedQueries = changedQueries
+	return nil
+}
+
+// scanRows runs the queries on the connection and calls fn with every row.
+// The row is reused by the next call.
+func scanRows(tctx *tcontext.Context, conn *sql.Conn, queries []string, colLen int, fn func(row rawRow)) error {
+	row := make(rawRow, colLen)
+	args := make([]any, colLen)
+	row.BindAddress(args)
+	for _, query := range queries {
+		err := func() error {
+			rows, err := conn.QueryContext(tctx, query)
+			if err != nil {
+				return errors.Annotatef(err, "sql: %s", query)
+			}
+			defer rows.Close()
+			for rows.Next() {
+				if err = rows.Scan(args...); err != nil {
+					return errors.Trace(err)
+				}
+				fn(row)
+			}
+			return errors.Trace(rows.Err())
+		}()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rewriteChunkQuery replaces the selected fields of the chunk query with the
+// columns if they're not empty, and adds the condition to its WHERE clause if
+// it's not empty.
+func rewriteChunkQuery(query string, columns []string, cond string) (string, error) {
+	p := parser.New()
+	stmt, err := p.ParseOneStmt(query, "", "")
+	if err != nil {
+		return "", errors.Annotatef(err, "sql: %s", query)
+	}
+	sel, ok := stmt.(*ast.SelectStmt)
+	if !ok {
+		return "", errors.Errorf("unexpected chunk query %s", query)
+	}
+	if len(columns) > 0 {
+		fields := make([]*ast.SelectField, 0, len(columns))
+		for _, col := range columns {
+			fields = append(fields, &ast.SelectField{
+				Expr: &ast.ColumnNameExpr{Name: &ast.ColumnName{Name: ast.NewCIStr(col)}},
+			})
+		}
+		sel.Fields = &ast.FieldList{Fields: fields}
+	}
+	if cond != "" {
+		condStmt, err := p.ParseOneStmt("SELECT 1 FROM DUAL WHERE "+cond, "", "")
+		if err != nil {
+			return "", errors.Annotatef(err, "condition: %s", cond)
+		}
+		condExpr := condStmt.(*ast.SelectStmt).Where
+		if sel.Where != nil {
+			condExpr = &ast.BinaryOperationExpr{
+				Op: opcode.LogicAnd,
+				L:  &ast.ParenthesesExpr{Expr: sel.Where},
+				R:  condExpr,
+			}
+		}
+		sel.Where = condExpr
+	}
+	var sb strings.Builder
+	if err = sel.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+		return "", errors.Trace(err)
+	}
+	return sb.String(), nil
+}
+
+func (c *incrementalChunk) Rows() SQLRowIter {
+	if c.SQLRowIter == nil {
+		if c.changedQueries != nil {
+			// the changed rows are selected by the queries
+			c.SQLRowIter = newMultiQueryChunkIter(c.tctx, c.conn, c.changedQueries, c.colLen)
+			return c.SQLRowIter
+		}
+		iter := newMultiQueryChunkIter(c.tctx, c.conn, c.queries, c.colLen)
+		if len(c.keyColumns) == 0 {
+			c.SQLRowIter = iter
+		} else {
+			c.SQLRowIter = newIncrementalRowIter(iter, c.prevRows, c.colLen, c.keyColumns)
+		}
+	}
+	return c.SQLRowIter
+}
+
+func (c *incrementalChunk) Close() error {
+	if c.SQLRowIter != nil {
+		return c.SQLRowIter.Close()
+	}
+	return nil
+}
+
+func (*incrementalChunk) RawRows() *sql.Rows {
+	return nil
+}
+
+// deletedRows returns the keys of the rows deleted since the since-snapshot.
+// It's valid only after all the rows of the chunk are iterated.
+func (c *incrementalChunk) deletedRows() [][][]byte {
+	keys := make([]string, 0, len(c.prevRows))
+	for key := range c.prevRows {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	deleted := make([][][]byte, 0, len(keys))
+	for _, key := range keys {
+		deleted = append(deleted, c.prevRows[key].key)
+	}
+	return deleted
+}
+
+// rawRow receives the columns of a row as raw bytes.
+type rawRow []sql.RawBytes
+
+// BindAddress implements RowReceiver.BindAddress
+func (r rawRow) BindAddress(args []any) {
+	for i := range args {
+		args[i] = &r[i]
+	}
+}
+
+// incrementalRowIter skips the rows not changed since the since-snapshot. The
+// remaining rows in prevRows are the deleted ones after the iteration.
+type incrementalRowIter struct {
+	iter       SQLRowIter
+	prevRows   map[string]*incrementalRow
+	keyColumns []int
+	row        rawRow
+	args       []any
+	keyBuf     []byte
+	hash       hash.Hash
+	hasNext    bool
+	err        error
+}
+
+func newIncrementalRowIter(iter SQLRowIter, prevRows map[string]*incrementalRow, colLen int, keyColumns []int) *incrementalRowIter {
+	r := &incrementalRowIter{
+		iter:       iter,
+		prevRows:   prevRows,
+		keyColumns: keyColumns,
+		row:        make(rawRow, colLen),
+		args:       make([]any, colLen),
+		hash:       sha256.New(),
+	}
+	r.seek()
+	return r
+}
+
+// seek moves to the next inserted or updated row.
+func (r *incrementalRowIter) seek() {
+	r.hasNext = false
+	for r.iter.HasNext() {
+		if err := r.iter.Decode(r.row); err != nil {
+			r.err = err
+			return
+		}
+		r.keyBuf = appendRowKey(r.keyBuf[:0], r.row, r.keyColumns)
+		prev, ok := r.prevRows[string(r.keyBuf)]
+		if !ok {
+			r.hasNext = true
+			return
+		}
+		delete(r.prevRows, string(r.keyBuf))
+		if prev.digest != rowDigest(r.hash, r.row) {
+			r.hasNext = true
+			return
+		}
+		r.iter.Next()
+	}
+}
+
+func (r *incrementalRowIter) Decode(row RowReceiver) error {
+	if r.err != nil {
+		return r.err
+	}
+	row.BindAddress(r.args)
+	for i, arg := range r.args {
+		dest, ok := arg.(*sql.RawBytes)
+		if !ok {
+			return errors.Errorf("unsupported row receiver %T in incremental dump", arg)
+		}
+		*dest = r.row[i]
+	}
+	return nil
+}
+
+func (r *incrementalRowIter) Next() {
+	if r.err == nil {
+		r.iter.Next()
+		r.seek()
+	}
+}
+
+func (r *incrementalRowIter) Error() error {
+	if r.err != nil {
+		return r.err
+	}
+	return r.iter.Error()
+}
+
+func (r *incrementalRowIter) HasNext() bool {
+	return r.hasNext
+}
+
+func (r *incrementalRowIter) Close() error {
+	return r.iter.Close()
+}
+
+// cloneRowKey returns a copy of the key columns of the row.
+func cloneRowKey(row rawRow, keyColumns []int) [][]byte {
+	key := make([][]byte, 0, len(keyColumns))
+	for _, col := range keyColumns {
+		key = append(key, bytes.Clone(row[col]))
+	}
+	return key
+}
+
+// appendRowKey appends the encoded key columns of the row to buf.
+func appendRowKey(buf []byte, row []sql.RawBytes, keyColumns []int) []byte {
+	for _, col := range keyColumns {
+		buf = appendRawBytes(buf, row[col])
+	}
+	return buf
+}
+
+func rowDigest(h hash.Hash, row []sql.RawBytes) (digest [sha256.Size]byte) {
+	h.Reset()
+	var buf []byte
+	for _, col := range row {
+		buf = appendRawBytes(buf[:0], col)
+		h.Write(buf)
+	}
+	h.Sum(digest[:0])
+	return digest
+}
+
+// appendRawBytes appends the length prefixed value to buf, so NULL and the
+// values of different columns can't be confused.
+func appendRawBytes(buf []byte, value sql.RawBytes) []byte {
+	if value == nil {
+		return binary.AppendUvarint(buf, 0)
+	}
+	buf = binary.AppendUvarint(buf, uint64(len(value))+1)
+	return append(buf, value...)
+}
+
+// writeDeletedRows writes the statements deleting the rows removed since the
+// since-snapshot. A table without primary key is deleted entirely by its first chunk.
+func (w *Writer) writeDeletedRows(tctx *tcontext.Context, meta TableMeta, ir *incrementalChunk, curChkIdx int) error {
+	conf := w.conf
+	deleted := ir.deletedRows()
+	deleteAll := len(ir.keyColumns) == 0 && curChkIdx == 0
+	if len(deleted) == 0 && !deleteAll {
+		return nil
+	}
+	fileName, err := newOutputFileNamer(meta, curChkIdx, false, false).render(conf.OutputFileTemplate, outputFileTemplateDeletes)
+	if err != nil {
+		return err
+	}
+	var bf bytes.Buffer
+	for _, cmt := range getSpecialComments(conf.ServerInfo.ServerType) {
+		bf.WriteString(cmt)
+		bf.WriteByte('\n')
+	}
+	if deleteAll {
+		fmt.Fprintf(&bf, "DELETE FROM %s;\n", wrapBackTicks(escapeString(meta.TableName())))
+	} else {
+		writeDeleteStatements(&bf, meta, ir.keyColumns, deleted, conf.StatementSize, conf.EscapeBackslash)
+	}
+
+	fileWriter, tearDown, err := buildFileWriter(tctx, w.extStorage, fileName+".sql", conf.CompressType)
+	if err != nil {
+		return err
+	}
+	_, err = fileWriter.Write(tctx, bf.Bytes())
+	tearDownErr := tearDown(tctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if tearDownErr != nil {
+		return tearDownErr
+	}
+	tctx.L().Debug("finish dumping deleted rows of table(chunk)",
+		zap.String("database", meta.DatabaseName()),
+		zap.String("table", meta.TableName()),
+		zap.Int("chunkIdx", curChkIdx),
+		zap.Int("deleted rows", len(deleted)))
+	return nil
+}
+
+// writeDeleteStatements writes the DELETE statements of the keys, every statement
+// is about statementSize bytes.
+func writeDeleteStatements(bf *bytes.Buffer, meta TableMeta, keyColumns []int, keys [][][]byte, statementSize uint64, escapeBackslash bool) {
+	colNames, colTypes := meta.ColumnNames(), meta.ColumnTypes()
+	keyNames := make([]string, 0, len(keyColumns))
+	keyTypes := make([]string, 0, len(keyColumns))
+	for _, col := range keyColumns {
+		keyNames = append(keyNames, wrapBackTicks(escapeString(colNames[col])))
+		keyTypes = append(keyTypes, colTypes[col])
+	}
+	prefix := fmt.Sprintf("DELETE FROM %s WHERE (%s) IN (\n",
+		wrapBackTicks(escapeString(meta.TableName())), strings.Join(keyNames, ","))
+	row := MakeRowReceiver(keyTypes)
+	cols := make([]*sql.RawBytes, 0, len(row.receivers))
+	for _, receiver := range row.receivers {
+		cols = append(cols, receiverRawBytes(receiver))
+	}
+
+	statementStart := -1
+	for i, key := range keys {
+		if statementStart < 0 {
+			statementStart = bf.Len()
+			bf.WriteString(prefix)
+		}
+		for j, col := range cols {
+			*col = key[j]
+		}
+		row.WriteToBuffer(bf, escapeBackslash)
+		if i == len(keys)-1 || (statementSize != UnspecifiedSize && uint64(bf.Len()-statementStart) >= statementSize) {
+			bf.WriteString(");\n")
+			statementStart = -1
+		} else {
+			bf.WriteString(",\n")
+		}
+	}
+}
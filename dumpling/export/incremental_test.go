@@ -0,0 +1,154 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package export
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	tcontext "github.com/pingcap/tidb/dumpling/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIncrementalChunk(t *testing.T) {
+	sinceDB, sinceMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() {
+		_ = sinceDB.Close()
+	}()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() {
+		_ = db.Close()
+	}()
+
+	queries := []string{"SELECT * FROM `test`.`t` WHERE `id` < 3", "SELECT * FROM `test`.`t` WHERE `id` >= 3"}
+	sinceMock.ExpectQuery("WHERE `id` < 3").WillReturnRows(
+		sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "a").AddRow(2, "b"))
+	sinceMock.ExpectQuery("WHERE `id` >= 3").WillReturnRows(
+		sqlmock.NewRows([]string{"id", "name"}).AddRow(3, nil).AddRow(4, "d").AddRow(5, "e"))
+	mock.ExpectQuery("WHERE `id` < 3").WillReturnRows(
+		sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "a").AddRow(2, "bb"))
+	mock.ExpectQuery("WHERE `id` >= 3").WillReturnRows(
+		sqlmock.NewRows([]string{"id", "name"}).AddRow(3, "").AddRow(5, "e").AddRow(6, "f"))
+
+	tctx := tcontext.Background().WithLogger(appLogger)
+	conn, err := db.Conn(context.Background())
+	require.NoError(t, err)
+	meta := newMockTableIR("test", "t", nil, nil, []string{"INT", "VARCHAR"})
+	meta.colNames = []string{"id", "name"}
+	incMeta := &incrementalTableMeta{TableMeta: meta, keyColumns: []int{0}}
+	chunk := toIncrementalChunk(sinceDB, newMultiQueriesChunk(queries, 2), incMeta).(*incrementalChunk)
+	require.NoError(t, chunk.Start(tctx, conn))
+
+	conf := configForWriteSQL(createMockConfig(), UnspecifiedSize, UnspecifiedSize)
+	conf.SinceSnapshot = "1"
+	bf := storage.NewBufferWriter()
+	n, err := WriteInsert(tctx, conf, meta, chunk, bf, newMetrics(conf.PromFactory, conf.Labels))
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), n)
+	require.Equal(t, "REPLACE INTO `t` VALUES\n"+
+		"(2,'bb'),\n"+
+		"(3,''),\n"+
+		"(6,'f');\n", bf.String())
+	require.NoError(t, chunk.Close())
+	require.Equal(t, [][][]byte{{[]byte("4")}}, chunk.deletedRows())
+	require.NoError(t, sinceMock.ExpectationsWereMet())
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// the chunk of the checkpoint keeps the queries
+	cp, err := newChunkCheckpoint(NewTaskTableData(meta, chunk, 0, 1))
+	require.NoError(t, err)
+	require.Equal(t, queries, cp.Queries)
+	require.Equal(t, 2, cp.ColLen)
+}
+
+func TestIncrementalChunkWithUpdatedAtColumn(t *testing.T) {
+	sinceDB, sinceMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() {
+		_ = sinceDB.Close()
+	}()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() {
+		_ = db.Close()
+	}()
+
+	queries := []string{"SELECT * FROM `test`.`t` WHERE `id` < 3 ORDER BY `id`", "SELECT * FROM `test`.`t` WHERE `id` >= 3 ORDER BY `id`"}
+	// only the keys are read at both snapshots
+	sinceMock.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `test`.`t` WHERE `id`<3 ORDER BY `id`")).WillReturnRows(
+		sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	sinceMock.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `test`.`t` WHERE `id`>=3 ORDER BY `id`")).WillReturnRows(
+		sqlmock.NewRows([]string{"id"}).AddRow(3).AddRow(4))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `test`.`t` WHERE `id`<3 ORDER BY `id`")).WillReturnRows(
+		sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `test`.`t` WHERE `id`>=3 ORDER BY `id`")).WillReturnRows(
+		sqlmock.NewRows([]string{"id"}).AddRow(3).AddRow(5))
+	// only the changed rows are read at the snapshot
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `test`.`t` WHERE (`id`<3) AND `mtime`>DATE_SUB(TIDB_PARSE_TSO(100), INTERVAL 3600 SECOND) ORDER BY `id`")).WillReturnRows(
+		sqlmock.NewRows([]string{"id", "mtime"}).AddRow(2, "2026-01-01 00:00:00"))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `test`.`t` WHERE (`id`>=3) AND `mtime`>DATE_SUB(TIDB_PARSE_TSO(100), INTERVAL 3600 SECOND) ORDER BY `id`")).WillReturnRows(
+		sqlmock.NewRows([]string{"id", "mtime"}).AddRow(5, "2026-01-01 00:00:00"))
+
+	tctx := tcontext.Background().WithLogger(appLogger)
+	conn, err := db.Conn(context.Background())
+	require.NoError(t, err)
+	meta := newMockTableIR("test", "t", nil, nil, []string{"INT", "TIMESTAMP"})
+	meta.colNames = []string{"id", "mtime"}
+	incMeta := &incrementalTableMeta{
+		TableMeta:   meta,
+		keyColumns:  []int{0},
+		changedCond: "`mtime` > TIDB_PARSE_TSO(100) - INTERVAL 3600 SECOND",
+	}
+	chunk := toIncrementalChunk(sinceDB, newMultiQueriesChunk(queries, 2), incMeta).(*incrementalChunk)
+	require.NoError(t, chunk.Start(tctx, conn))
+
+	conf := configForWriteSQL(createMockConfig(), UnspecifiedSize, UnspecifiedSize)
+	conf.SinceSnapshot = "1"
+	bf := storage.NewBufferWriter()
+	n, err := WriteInsert(tctx, conf, meta, chunk, bf, newMetrics(conf.PromFactory, conf.Labels))
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), n)
+	require.Equal(t, "REPLACE INTO `t` VALUES\n"+
+		"(2,'2026-01-01 00:00:00'),\n"+
+		"(5,'2026-01-01 00:00:00');\n", bf.String())
+	require.NoError(t, chunk.Close())
+	require.Equal(t, [][][]byte{{[]byte("4")}}, chunk.deletedRows())
+	require.NoError(t, sinceMock.ExpectationsWereMet())
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// the chunk of the checkpoint keeps the original queries
+	cp, err := newChunkCheckpoint(NewTaskTableData(meta, chunk, 0, 1))
+	require.NoError(t, err)
+	require.Equal(t, queries, cp.Queries)
+}
+
+func TestWriteDeleteStatements(t *testing.T) {
+	meta := newMockTableIR("test", "t", nil, nil, []string{"INT", "VARCHAR", "TEXT"})
+	meta.colNames = []string{"a", "b", "c"}
+	keys := [][][]byte{
+		{[]byte("1"), []byte("x")},
+		{[]byte("2"), []byte("y'z")},
+		{[]byte("3"), []byte("w")},
+	}
+
+	var bf bytes.Buffer
+	writeDeleteStatements(&bf, meta, []int{0, 1}, keys, UnspecifiedSize, false)
+	require.Equal(t, "DELETE FROM `t` WHERE (`a`,`b`) IN (\n"+
+		"(1,'x'),\n"+
+		"(2,'y''z'),\n"+
+		"(3,'w'));\n", bf.String())
+
+	bf.Reset()
+	writeDeleteStatements(&bf, meta, []int{0, 1}, keys, 50, false)
+	require.Equal(t, "DELETE FROM `t` WHERE (`a`,`b`) IN (\n"+
+		"(1,'x'),\n"+
+		"(2,'y''z'));\n"+
+		"DELETE FROM `t` WHERE (`a`,`b`) IN (\n"+
+		"(3,'w'));\n", bf.String())
+}
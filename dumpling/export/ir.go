@@ -28,6 +28,7 @@ type TableMeta interface {
 	ColumnCount() uint
 	ColumnTypes() []string
 	ColumnNames() []string
+	ColumnDecimalSizes() []DecimalSize
 	SelectedField() string
 	SelectedLen() int
 	SpecialComments() StringIter
@@ -37,6 +38,13 @@ type TableMeta interface {
 	HasImplicitRowID() bool
 }
 
+// DecimalSize is the precision and scale of a column. OK is false if the column type doesn't have them.
+type DecimalSize struct {
+	Precision int64
+	Scale     int64
+	OK        bool
+}
+
 // SQLRowIter is the iterator on a collection of sql.Row.
 type SQLRowIter interface {
 	Decode(RowReceiver) error
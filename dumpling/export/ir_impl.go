@@ -283,6 +283,14 @@ func (tm *tableMeta) ColumnNames() []string {
 	return colNames
 }
 
+func (tm *tableMeta) ColumnDecimalSizes() []DecimalSize {
+	sizes := make([]DecimalSize, len(tm.colTypes))
+	for i, ct := range tm.colTypes {
+		sizes[i].Precision, sizes[i].Scale, sizes[i].OK = ct.DecimalSize()
+	}
+	return sizes
+}
+
 func (tm *tableMeta) DatabaseName() string {
 	return tm.database
 }
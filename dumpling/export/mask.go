@@ -0,0 +1,240 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package export
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"hash"
+	"math/rand/v2"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/BurntSushi/toml"
+	"github.com/pingcap/errors"
+	filter "github.com/pingcap/tidb/pkg/util/table-filter"
+)
+
+const (
+	// MaskTransformHash replaces the value with the hex encoded HMAC-SHA256 of it.
+	MaskTransformHash = "hash"
+	// MaskTransformNull replaces the value with NULL.
+	MaskTransformNull = "null"
+	// MaskTransformFixed replaces the value with a fixed value.
+	MaskTransformFixed = "fixed"
+	// MaskTransformRandom replaces every digit and letter of the value with a random one of the same kind.
+	MaskTransformRandom = "random"
+	// MaskTransformRedact replaces the value with '*' except the kept prefix and suffix.
+	MaskTransformRedact = "redact"
+
+	maskRedactChar = '*'
+)
+
+// MaskRule is a rule to mask the values of a column in the matched tables.
+type MaskRule struct {
+	// Tables is the table filter of the rule, all tables are matched if it's empty.
+	Tables    []string `toml:"tables" json:"tables"`
+	Column    string   `toml:"column" json:"column"`
+	Transform string   `toml:"transform" json:"transform"`
+	// Value is the value of the fixed transform.
+	Value string `toml:"value" json:"value"`
+	// KeepPrefix and KeepSuffix are the number of characters kept by the redact transform.
+	KeepPrefix int `toml:"keep-prefix" json:"keep-prefix"`
+	KeepSuffix int `toml:"keep-suffix" json:"keep-suffix"`
+
+	tableFilter filter.Filter
+}
+
+// MaskConfig is the content of the masking rule file.
+type MaskConfig struct {
+	// HashKey is the secret key of the hash transform. A value is always hashed to
+	// the same result with the same key, so the masked columns can still be joined.
+	HashKey string      `toml:"hash-key" json:"-"`
+	Rules   []*MaskRule `toml:"rules" json:"rules"`
+}
+
+// ParseMaskConfig parses and validates the masking rules in TOML format.
+func ParseMaskConfig(data string) (*MaskConfig, error) {
+	cfg := &MaskConfig{}
+	meta, err := toml.Decode(data, cfg)
+	if err != nil {
+		return nil, errors.Annotate(err, "failed to parse masking rules")
+	}
+	if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+		return nil, errors.Errorf("unknown keys in masking rules: %v", undecoded)
+	}
+	for i, rule := range cfg.Rules {
+		if err = rule.adjust(); err != nil {
+			return nil, errors.Annotatef(err, "invalid masking rule #%d", i+1)
+		}
+		// the hash of a value without the secret key can be reversed by a dictionary attack
+		if rule.Transform == MaskTransformHash && cfg.HashKey == "" {
+			return nil, errors.Errorf("invalid masking rule #%d: hash-key must be specified for the hash transform", i+1)
+		}
+	}
+	return cfg, nil
+}
+
+func (r *MaskRule) adjust() error {
+	if r.Column == "" {
+		return errors.New("column is not specified")
+	}
+	r.Transform = strings.ToLower(r.Transform)
+	switch r.Transform {
+	case MaskTransformHash, MaskTransformNull, MaskTransformFixed, MaskTransformRandom:
+	case MaskTransformRedact:
+		if r.KeepPrefix < 0 || r.KeepSuffix < 0 {
+			return errors.New("keep-prefix and keep-suffix can't be negative")
+		}
+	default:
+		return errors.Errorf("unknown transform '%s'", r.Transform)
+	}
+	tables := r.Tables
+	if len(tables) == 0 {
+		tables = []string{"*.*"}
+	}
+	f, err := filter.Parse(tables)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	r.tableFilter = filter.CaseInsensitive(f)
+	return nil
+}
+
+// columnMasker masks the columns of the rows of a table. It's not safe for concurrent use.
+type columnMasker struct {
+	rules []*MaskRule
+	cols  []*sql.RawBytes
+	// numeric marks the numeric columns, only the digits of them are replaced by
+	// the random transform, so the value is still a number like 1e5.
+	numeric []bool
+	bufs    [][]byte
+	mac     hash.Hash
+}
+
+// newColumnMasker returns a masker for the row receiver of the table, it returns
+// nil if no column needs to be masked.
+func newColumnMasker(cfg *MaskConfig, meta TableMeta, row *RowReceiverArr) *columnMasker {
+	if cfg == nil || len(cfg.Rules) == 0 {
+		return nil
+	}
+	db, tbl := meta.DatabaseName(), meta.TableName()
+	var m *columnMasker
+	for i, col := range meta.ColumnNames() {
+		if i >= len(row.receivers) {
+			break
+		}
+		var rule *MaskRule
+		// the last matched rule takes effect
+		for _, r := range cfg.Rules {
+			if strings.EqualFold(r.Column, col) && r.tableFilter.MatchTable(db, tbl) {
+				rule = r
+			}
+		}
+		if rule == nil {
+			continue
+		}
+		if m == nil {
+			m = &columnMasker{mac: hmac.New(sha256.New, []byte(cfg.HashKey))}
+		}
+		// the masked value of numeric columns may be not a number
+		_, numeric := row.receivers[i].(*SQLTypeNumber)
+		if numeric && rule.Transform != MaskTransformRandom {
+			row.receivers[i] = SQLTypeStringMaker()
+			numeric = false
+		}
+		m.rules = append(m.rules, rule)
+		m.numeric = append(m.numeric, numeric)
+		m.cols = append(m.cols, receiverRawBytes(row.receivers[i]))
+		m.bufs = append(m.bufs, nil)
+	}
+	return m
+}
+
+func receiverRawBytes(r RowReceiverStringer) *sql.RawBytes {
+	switch v := r.(type) {
+	case *SQLTypeString:
+		return &v.RawBytes
+	case *SQLTypeNumber:
+		return &v.RawBytes
+	case *SQLTypeBytes:
+		return &v.RawBytes
+	default:
+		return nil
+	}
+}
+
+// mask masks the decoded row in place. NULL values are kept.
+func (m *columnMasker) mask() {
+	for i, col := range m.cols {
+		if col == nil || *col == nil {
+			continue
+		}
+		// the raw bytes belong to the driver, so the masked value is written to our own buffer.
+		buf := m.bufs[i][:0]
+		rule := m.rules[i]
+		switch rule.Transform {
+		case MaskTransformNull:
+			*col = nil
+			continue
+		case MaskTransformHash:
+			m.mac.Reset()
+			m.mac.Write(*col)
+			buf = hex.AppendEncode(buf, m.mac.Sum(nil))
+		case MaskTransformFixed:
+			buf = append(buf, rule.Value...)
+		case MaskTransformRandom:
+			buf = maskRandom(buf, *col, m.numeric[i])
+		case MaskTransformRedact:
+			buf = maskRedact(buf, *col, rule.KeepPrefix, rule.KeepSuffix)
+		}
+		m.bufs[i] = buf
+		*col = buf
+	}
+}
+
+// maskRandom replaces every digit and letter with a random one of the same kind,
+// so the format of the value is kept. Only the digits are replaced if digitsOnly is true.
+func maskRandom(buf, value []byte, digitsOnly bool) []byte {
+	for len(value) > 0 {
+		r, size := utf8.DecodeRune(value)
+		value = value[size:]
+		switch {
+		case r >= '0' && r <= '9':
+			buf = append(buf, byte('0'+rand.IntN(10)))
+		case digitsOnly:
+			buf = utf8.AppendRune(buf, r)
+		case r >= 'a' && r <= 'z':
+			buf = append(buf, byte('a'+rand.IntN(26)))
+		case r >= 'A' && r <= 'Z':
+			buf = append(buf, byte('A'+rand.IntN(26)))
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			buf = append(buf, byte('a'+rand.IntN(26)))
+		default:
+			buf = utf8.AppendRune(buf, r)
+		}
+	}
+	return buf
+}
+
+// maskRedact replaces the characters with '*' except the first keepPrefix and
+// the last keepSuffix ones. All characters are replaced if the value is too short.
+func maskRedact(buf, value []byte, keepPrefix, keepSuffix int) []byte {
+	n := utf8.RuneCount(value)
+	if n <= keepPrefix+keepSuffix {
+		keepPrefix, keepSuffix = 0, 0
+	}
+	for i := 0; len(value) > 0; i++ {
+		r, size := utf8.DecodeRune(value)
+		value = value[size:]
+		if i < keepPrefix || i >= n-keepSuffix {
+			buf = utf8.AppendRune(buf, r)
+		} else {
+			buf = append(buf, maskRedactChar)
+		}
+	}
+	return buf
+}
@@ -0,0 +1,152 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package export
+
+import (
+	"database/sql/driver"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/pingcap/tidb/br/pkg/storage"
+	tcontext "github.com/pingcap/tidb/dumpling/context"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMaskConfig(t *testing.T) {
+	cfg, err := ParseMaskConfig(`
+hash-key = "secret"
+
+[[rules]]
+tables = ["test.employee"]
+column = "email"
+transform = "HASH"
+
+[[rules]]
+column = "phone"
+transform = "redact"
+keep-prefix = 2
+keep-suffix = 2
+`)
+	require.NoError(t, err)
+	require.Equal(t, "secret", cfg.HashKey)
+	require.Len(t, cfg.Rules, 2)
+	require.Equal(t, MaskTransformHash, cfg.Rules[0].Transform)
+	require.True(t, cfg.Rules[0].tableFilter.MatchTable("Test", "Employee"))
+	require.False(t, cfg.Rules[0].tableFilter.MatchTable("test", "t"))
+	require.True(t, cfg.Rules[1].tableFilter.MatchTable("any", "table"))
+
+	for data, msg := range map[string]string{
+		`[[rules]]
+transform = "null"`: "column is not specified",
+		`[[rules]]
+column = "a"
+transform = "shuffle"`: "unknown transform 'shuffle'",
+		`[[rules]]
+column = "a"
+transform = "redact"
+keep-prefix = -1`: "keep-prefix and keep-suffix can't be negative",
+		`[[rules]]
+column = "a"
+transform = "null"
+tables = ["[a"]`: "invalid masking rule #1",
+		`[[rules]]
+column = "a"
+transform = "null"
+keep = 1`: "unknown keys in masking rules",
+		`[[rules]]
+column = "a"
+transform = "hash"`: "hash-key must be specified for the hash transform",
+	} {
+		_, err = ParseMaskConfig(data)
+		require.ErrorContains(t, err, msg, data)
+	}
+}
+
+func TestMaskTransforms(t *testing.T) {
+	require.Equal(t, "ab*****yz", string(maskRedact(nil, []byte("abcdefxyz"), 2, 2)))
+	require.Equal(t, "张***", string(maskRedact(nil, []byte("张三丰四"), 1, 0)))
+	require.Equal(t, "***", string(maskRedact(nil, []byte("abc"), 2, 2)))
+
+	masked := string(maskRandom(nil, []byte("Ab-12.x@"), false))
+	require.Regexp(t, regexp.MustCompile(`^[A-Z][a-z]-[0-9]{2}\.[a-z]@$`), masked)
+	masked = string(maskRandom(nil, []byte("-1.5e-3"), true))
+	require.Regexp(t, regexp.MustCompile(`^-[0-9]\.[0-9]e-[0-9]$`), masked)
+}
+
+func TestWriteInsertWithMask(t *testing.T) {
+	cfg := createMockConfig()
+	var err error
+	cfg.Mask, err = ParseMaskConfig(`
+hash-key = "secret"
+
+[[rules]]
+column = "email"
+transform = "hash"
+
+[[rules]]
+tables = ["test.employee"]
+column = "id"
+transform = "fixed"
+value = "x"
+
+[[rules]]
+tables = ["test.other"]
+column = "gender"
+transform = "null"
+
+[[rules]]
+column = "phone"
+transform = "redact"
+keep-prefix = 3
+
+[[rules]]
+column = "remark"
+transform = "null"
+`)
+	require.NoError(t, err)
+
+	data := [][]driver.Value{
+		{"1", "male", "bob@mail.com", "020-1234", nil},
+		{"2", "female", "sarah@mail.com", "020-1253", "healthy"},
+		{"3", "female", "sarah@mail.com", nil, "healthy"},
+	}
+	colTypes := []string{"INT", "SET", "VARCHAR", "VARCHAR", "TEXT"}
+	tableIR := newMockTableIR("test", "employee", data, nil, colTypes)
+	tableIR.colNames = []string{"id", "gender", "email", "phone", "remark"}
+
+	bf := storage.NewBufferWriter()
+	conf := configForWriteSQL(cfg, UnspecifiedSize, UnspecifiedSize)
+	m := newMetrics(conf.PromFactory, conf.Labels)
+	n, err := WriteInsert(tcontext.Background(), conf, tableIR, tableIR, bf, m)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), n)
+
+	lines := strings.Split(bf.String(), "\n")
+	require.Equal(t, "INSERT INTO `employee` VALUES", lines[0])
+	hashRe := `'([0-9a-f]{64})'`
+	rowRe := regexp.MustCompile(`^\('x','(male|female)',` + hashRe + `,('020\*\*\*\*\*'|NULL),NULL\)[,;]$`)
+	hashes := make([]string, 0, 3)
+	for _, line := range lines[1:4] {
+		matches := rowRe.FindStringSubmatch(line)
+		require.NotNil(t, matches, line)
+		hashes = append(hashes, matches[2])
+	}
+	// the hash is deterministic
+	require.NotEqual(t, hashes[0], hashes[1])
+	require.Equal(t, hashes[1], hashes[2])
+
+	// csv output uses the same rules
+	tableIR = newMockTableIR("test", "employee", data, nil, colTypes)
+	tableIR.colNames = []string{"id", "gender", "email", "phone", "remark"}
+	bf = storage.NewBufferWriter()
+	opt := &csvOption{separator: []byte(","), delimiter: []byte{'"'}, nullValue: "\\N", lineTerminator: []byte("\n")}
+	conf = configForWriteCSV(cfg, true, opt)
+	conf.NoHeader = true
+	n, err = WriteInsertInCsv(tcontext.Background(), conf, tableIR, tableIR, bf, m)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), n)
+	lines = strings.Split(bf.String(), "\n")
+	require.Equal(t, `"x","male","`+hashes[0]+`","020*****",\N`, lines[0])
+	require.Equal(t, `"x","female","`+hashes[2]+`",\N,\N`, lines[2])
+}
@@ -0,0 +1,219 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package export
+
+import (
+	"database/sql"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/xitongsys/parquet-go/parquet"
+)
+
+const (
+	parquetDateLayout     = "2006-01-02"
+	parquetDatetimeLayout = "2006-01-02 15:04:05.999999"
+	// maxParquetDecimalPrecision is the max precision of MySQL DECIMAL.
+	maxParquetDecimalPrecision = 65
+)
+
+// parquetColumn describes how a column is written to parquet files.
+type parquetColumn struct {
+	schema *parquet.SchemaElement
+	// convert converts the text value returned by the server to the parquet value.
+	// It returns nil if the value can't be represented, for example, the zero date.
+	convert func(sql.RawBytes) (any, error)
+}
+
+// newParquetColumn maps a column to the parquet type by its database type name:
+//
+//	integer types        -> INT64 (UINT_64 for BIGINT UNSIGNED)
+//	FLOAT / DOUBLE       -> FLOAT / DOUBLE
+//	DECIMAL              -> BYTE_ARRAY annotated as DECIMAL(precision, scale)
+//	DATE                 -> INT32 annotated as DATE
+//	DATETIME / TIMESTAMP -> INT64 annotated as TIMESTAMP(MICROS) which isn't adjusted to UTC
+//	binary types         -> BYTE_ARRAY
+//	other types          -> BYTE_ARRAY annotated as STRING, including JSON, TIME, ENUM and SET
+//
+// All the columns are optional, NULL is written as null.
+func newParquetColumn(name, tp string, decimalSize DecimalSize) *parquetColumn {
+	schema := parquet.NewSchemaElement()
+	schema.Name = name
+	schema.RepetitionType = parquet.FieldRepetitionTypePtr(parquet.FieldRepetitionType_OPTIONAL)
+	col := &parquetColumn{schema: schema}
+	_, isInt := dataTypeInt[tp]
+	_, isBin := dataTypeBin[tp]
+	switch {
+	case tp == "UNSIGNED BIGINT":
+		schema.Type = parquet.TypePtr(parquet.Type_INT64)
+		schema.ConvertedType = parquet.ConvertedTypePtr(parquet.ConvertedType_UINT_64)
+		schema.LogicalType = &parquet.LogicalType{INTEGER: &parquet.IntType{BitWidth: 64, IsSigned: false}}
+		col.convert = func(b sql.RawBytes) (any, error) {
+			v, err := strconv.ParseUint(string(b), 10, 64)
+			return int64(v), errors.Trace(err)
+		}
+	case isInt || strings.HasPrefix(tp, "UNSIGNED ") || tp == "YEAR":
+		schema.Type = parquet.TypePtr(parquet.Type_INT64)
+		col.convert = func(b sql.RawBytes) (any, error) {
+			v, err := strconv.ParseInt(string(b), 10, 64)
+			return v, errors.Trace(err)
+		}
+	case tp == "FLOAT":
+		schema.Type = parquet.TypePtr(parquet.Type_FLOAT)
+		col.convert = func(b sql.RawBytes) (any, error) {
+			v, err := strconv.ParseFloat(string(b), 32)
+			return float32(v), errors.Trace(err)
+		}
+	case tp == "DOUBLE" || tp == "REAL" || tp == "DOUBLE PRECISION":
+		schema.Type = parquet.TypePtr(parquet.Type_DOUBLE)
+		col.convert = func(b sql.RawBytes) (any, error) {
+			v, err := strconv.ParseFloat(string(b), 64)
+			return v, errors.Trace(err)
+		}
+	case (tp == "DECIMAL" || tp == "NUMERIC" || tp == "FIXED") && decimalSize.OK:
+		scale := int32(decimalSize.Scale)
+		precision := int32(min(max(decimalSize.Precision, decimalSize.Scale, 1), maxParquetDecimalPrecision))
+		schema.Type = parquet.TypePtr(parquet.Type_BYTE_ARRAY)
+		schema.ConvertedType = parquet.ConvertedTypePtr(parquet.ConvertedType_DECIMAL)
+		schema.Scale = &scale
+		schema.Precision = &precision
+		schema.LogicalType = &parquet.LogicalType{DECIMAL: &parquet.DecimalType{Scale: scale, Precision: precision}}
+		col.convert = func(b sql.RawBytes) (any, error) {
+			return decimalToParquetBytes(b, int(scale))
+		}
+	case tp == "DATE":
+		schema.Type = parquet.TypePtr(parquet.Type_INT32)
+		schema.ConvertedType = parquet.ConvertedTypePtr(parquet.ConvertedType_DATE)
+		schema.LogicalType = &parquet.LogicalType{DATE: parquet.NewDateType()}
+		col.convert = func(b sql.RawBytes) (any, error) {
+			t, err := time.Parse(parquetDateLayout, string(b))
+			if err != nil {
+				// zero date or invalid date
+				return nil, nil
+			}
+			return int32(t.Unix() / 86400), nil
+		}
+	case tp == "DATETIME" || tp == "TIMESTAMP":
+		// The value is the wall clock time in the session time zone, so it's not adjusted to UTC.
+		schema.Type = parquet.TypePtr(parquet.Type_INT64)
+		schema.LogicalType = &parquet.LogicalType{TIMESTAMP: &parquet.TimestampType{
+			IsAdjustedToUTC: false,
+			Unit:            &parquet.TimeUnit{MICROS: parquet.NewMicroSeconds()},
+		}}
+		col.convert = func(b sql.RawBytes) (any, error) {
+			t, err := time.Parse(parquetDatetimeLayout, string(b))
+			if err != nil {
+				// zero datetime or invalid datetime
+				return nil, nil
+			}
+			return t.UnixMicro(), nil
+		}
+	case isBin:
+		schema.Type = parquet.TypePtr(parquet.Type_BYTE_ARRAY)
+		col.convert = func(b sql.RawBytes) (any, error) {
+			return string(b), nil
+		}
+	default:
+		schema.Type = parquet.TypePtr(parquet.Type_BYTE_ARRAY)
+		schema.ConvertedType = parquet.ConvertedTypePtr(parquet.ConvertedType_UTF8)
+		schema.LogicalType = &parquet.LogicalType{STRING: parquet.NewStringType()}
+		col.convert = func(b sql.RawBytes) (any, error) {
+			return string(b), nil
+		}
+	}
+	return col
+}
+
+// newParquetSchema returns the parquet columns of the table and the schema elements including the root.
+func newParquetSchema(meta TableMeta) ([]*parquetColumn, []*parquet.SchemaElement) {
+	colTypes, colNames, decimalSizes := meta.ColumnTypes(), meta.ColumnNames(), meta.ColumnDecimalSizes()
+	root := parquet.NewSchemaElement()
+	root.Name = "schema"
+	root.RepetitionType = parquet.FieldRepetitionTypePtr(parquet.FieldRepetitionType_REQUIRED)
+	numChildren := int32(len(colTypes))
+	root.NumChildren = &numChildren
+
+	cols := make([]*parquetColumn, 0, len(colTypes))
+	schemas := make([]*parquet.SchemaElement, 0, len(colTypes)+1)
+	schemas = append(schemas, root)
+	for i, tp := range colTypes {
+		var decimalSize DecimalSize
+		if i < len(decimalSizes) {
+			decimalSize = decimalSizes[i]
+		}
+		name := "c" + strconv.Itoa(i)
+		if i < len(colNames) {
+			name = colNames[i]
+		}
+		col := newParquetColumn(name, tp, decimalSize)
+		cols = append(cols, col)
+		schemas = append(schemas, col.schema)
+	}
+	return cols, schemas
+}
+
+// decimalToParquetBytes encodes the decimal text to the big-endian two's complement of the unscaled value.
+func decimalToParquetBytes(b sql.RawBytes, scale int) (any, error) {
+	intPart, fracPart, _ := strings.Cut(string(b), ".")
+	if len(fracPart) < scale {
+		fracPart += strings.Repeat("0", scale-len(fracPart))
+	}
+	unscaled, ok := new(big.Int).SetString(intPart+fracPart[:scale], 10)
+	if !ok {
+		return nil, errors.Errorf("invalid decimal value %s", b)
+	}
+	if unscaled.Sign() >= 0 {
+		bs := unscaled.Bytes()
+		if len(bs) == 0 || bs[0]&0x80 != 0 {
+			bs = append([]byte{0}, bs...)
+		}
+		return string(bs), nil
+	}
+	// 2^(8*n) + unscaled is the two's complement of a negative value in n bytes.
+	n := unscaled.BitLen()/8 + 1
+	complement := new(big.Int).Lsh(big.NewInt(1), uint(8*n))
+	complement.Add(complement, unscaled)
+	bs := complement.Bytes()
+	return string(append(make([]byte, n-len(bs)), bs...)), nil
+}
+
+// parquetRowReceiver receives the text values of a row to convert them to parquet values.
+type parquetRowReceiver struct {
+	bound  bool
+	values []sql.RawBytes
+}
+
+func newParquetRowReceiver(colCount int) *parquetRowReceiver {
+	return &parquetRowReceiver{values: make([]sql.RawBytes, colCount)}
+}
+
+// BindAddress implements RowReceiver.BindAddress
+func (r *parquetRowReceiver) BindAddress(args []any) {
+	if r.bound {
+		return
+	}
+	r.bound = true
+	for i := range args {
+		args[i] = &r.values[i]
+	}
+}
+
+// convert converts the received row to parquet values, it also returns the size of the text values.
+func (r *parquetRowReceiver) convert(cols []*parquetColumn) (rec []any, size int, err error) {
+	// The parquet writer holds the records until they are flushed, so a new slice is needed for each row.
+	rec = make([]any, len(cols))
+	for i, col := range cols {
+		v := r.values[i]
+		if v == nil {
+			continue
+		}
+		size += len(v)
+		if rec[i], err = col.convert(v); err != nil {
+			return nil, size, errors.Annotatef(err, "convert column %s", col.schema.Name)
+		}
+	}
+	return rec, size, nil
+}
@@ -20,6 +20,7 @@ const (
 	outputFileTemplateSequence = "sequence"
 	outputFileTemplateData     = "data"
 	outputFileTemplatePolicy   = "placement-policy"
+	outputFileTemplateDeletes  = "deletes"
 
 	defaultOutputFileTemplateBase = `
 		{{- define "objectName" -}}
@@ -52,6 +53,9 @@ const (
 		{{- define "data" -}}
 			{{template "objectName" .}}.{{.Index}}
 		{{- end -}}
+		{{- define "deletes" -}}
+			{{template "objectName" .}}-deletes.{{.Index}}
+		{{- end -}}
 		{{- define "placement-policy" -}}
             {{fn .Policy}}-placement-policy-create
 		{{- end -}}
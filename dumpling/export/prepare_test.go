@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pingcap/tidb/br/pkg/storage"
 	tcontext "github.com/pingcap/tidb/dumpling/context"
 	"github.com/stretchr/testify/require"
 )
@@ -272,6 +273,24 @@ func TestConfigValidation(t *testing.T) {
 
 	conf.Where = ""
 	require.NoError(t, validateSpecifiedSQL(conf))
+	conf.Resume = true
+	require.EqualError(t, validateSpecifiedSQL(conf), "can't specify both --sql and --resume at the same time")
+	conf.Resume = false
+	conf.Checkpoint = true
+	require.EqualError(t, validateSpecifiedSQL(conf), "can't specify both --sql and --checkpoint at the same time")
+	conf.Checkpoint = false
+	conf.SinceSnapshot = "1"
+	require.EqualError(t, validateSpecifiedSQL(conf), "can't specify both --sql and --since-snapshot at the same time")
+	require.NoError(t, validateSinceSnapshot(conf))
+	require.Equal(t, uint64(defaultSinceSnapshotRows), conf.Rows)
+	conf.Rows = 100
+	require.NoError(t, validateSinceSnapshot(conf))
+	require.Equal(t, uint64(100), conf.Rows)
+	conf.Rows = UnspecifiedSize
+	conf.Mask = &MaskConfig{Rules: []*MaskRule{{Column: "a", Transform: MaskTransformNull}}}
+	require.EqualError(t, validateSinceSnapshot(conf), "can't specify both --mask-rules and --since-snapshot at the same time")
+	conf.SinceSnapshot = ""
+	conf.Mask = nil
 
 	conf.FileType = FileFormatSQLTextString
 	err := adjustFileFormat(conf)
@@ -293,6 +312,15 @@ func TestConfigValidation(t *testing.T) {
 	require.NoError(t, adjustFileFormat(conf))
 	require.Equal(t, FileFormatSQLTextString, conf.FileType)
 
+	conf.FileType = FileFormatParquetString
+	require.NoError(t, adjustFileFormat(conf))
+	conf.CompressType = storage.Gzip
+	require.ErrorContains(t, adjustFileFormat(conf), "please use --parquet-compress to compress the parquet pages")
+	conf.CompressType = storage.NoCompression
+	conf.Mask = &MaskConfig{Rules: []*MaskRule{{Column: "a", Transform: MaskTransformNull}}}
+	require.ErrorContains(t, adjustFileFormat(conf), "unsupported --mask-rules when config.FileType is 'parquet'")
+	conf.Mask = nil
+
 	conf.FileType = "rand_str"
 	require.EqualError(t, adjustFileFormat(conf), "unknown config.FileType 'rand_str'")
 }
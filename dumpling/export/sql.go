@@ -12,6 +12,7 @@ import (
 	"math"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-sql-driver/mysql"
 	"github.com/pingcap/errors"
@@ -31,6 +32,8 @@ import (
 const (
 	orderByTiDBRowID = "ORDER BY `_tidb_rowid`"
 	snapshotVar      = "tidb_snapshot"
+	// gcSafePointFormat is the format of tikv_gc_safe_point in mysql.tidb
+	gcSafePointFormat = "20060102-15:04:05.999 -0700"
 )
 
 type listTableType int
@@ -1405,6 +1408,32 @@ func parseSnapshotToTSO(pool *sql.DB, snapshot string) (uint64, error) {
 	return (uint64(tso.Int64) << 18) * 1000, nil
 }
 
+// checkSnapshotNotGC checks the snapshot is still above the GC safe point of TiDB.
+func checkSnapshotNotGC(tctx *tcontext.Context, pool *sql.DB, snapshot string) error {
+	snapshotTS, err := parseSnapshotToTSO(pool, snapshot)
+	if err != nil {
+		return err
+	}
+	var safePoint string
+	const query = "SELECT VARIABLE_VALUE FROM mysql.tidb WHERE VARIABLE_NAME = 'tikv_gc_safe_point'"
+	err = pool.QueryRowContext(tctx, query).Scan(&safePoint)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return errors.Annotatef(err, "sql: %s", query)
+	}
+	t, err := time.Parse(gcSafePointFormat, safePoint)
+	if err != nil {
+		return errors.Annotatef(err, "invalid GC safe point %s", safePoint)
+	}
+	if safePointTS := uint64(t.UnixMilli()) << 18; safePointTS > snapshotTS {
+		return errors.Errorf("the snapshot %s in checkpoint file is older than the GC safe point %s, "+
+			"please remove the checkpoint file and dump again", snapshot, safePoint)
+	}
+	return nil
+}
+
 func buildWhereCondition(conf *Config, where string) string {
 	var query strings.Builder
 	separator := "WHERE"
@@ -338,6 +338,29 @@ func TestParseSnapshotToTSO(t *testing.T) {
 	require.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestCheckSnapshotNotGC(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() {
+		_ = db.Close()
+	}()
+	tctx := tcontext.Background().WithLogger(appLogger)
+	// 2026-02-18 17:46:21 UTC
+	snapshot := strconv.FormatUint(uint64(1771436781000)<<18, 10)
+
+	mock.ExpectQuery("tikv_gc_safe_point").
+		WillReturnRows(sqlmock.NewRows([]string{"VARIABLE_VALUE"}).AddRow("20260218-17:46:20.000 +0000"))
+	require.NoError(t, checkSnapshotNotGC(tctx, db, snapshot))
+
+	mock.ExpectQuery("tikv_gc_safe_point").
+		WillReturnRows(sqlmock.NewRows([]string{"VARIABLE_VALUE"}).AddRow("20260218-17:46:22.000 +0000"))
+	require.ErrorContains(t, checkSnapshotNotGC(tctx, db, snapshot), "is older than the GC safe point 20260218-17:46:22.000 +0000")
+
+	mock.ExpectQuery("tikv_gc_safe_point").WillReturnRows(sqlmock.NewRows([]string{"VARIABLE_VALUE"}))
+	require.NoError(t, checkSnapshotNotGC(tctx, db, snapshot))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestShowCreateView(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	require.NoError(t, err)
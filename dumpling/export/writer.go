@@ -58,6 +58,8 @@ func NewWriter(
 		sw.fileFmt = FileFormatSQLText
 	case FileFormatCSVString:
 		sw.fileFmt = FileFormatCSV
+	case FileFormatParquetString:
+		sw.fileFmt = FileFormatParquet
 	}
 	return sw
 }
@@ -225,7 +227,13 @@ func (w *Writer) WriteTableData(meta TableMeta, ir TableDataIR, currentChunk int
 		defer func() {
 			_ = ir.Close()
 		}()
-		return w.tryToWriteTableData(tctx, meta, ir, currentChunk)
+		if err = w.tryToWriteTableData(tctx, meta, ir, currentChunk); err != nil {
+			return err
+		}
+		if chunk, ok := ir.(*incrementalChunk); ok {
+			return w.writeDeletedRows(tctx, meta, chunk, currentChunk)
+		}
+		return nil
 	}, newRebuildConnBackOffer(canRebuildConn(conf.Consistency, conf.TransactionalConsistency)))
 }
 
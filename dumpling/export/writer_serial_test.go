@@ -5,14 +5,21 @@ package export
 import (
 	"database/sql/driver"
 	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/br/pkg/storage"
 	tcontext "github.com/pingcap/tidb/dumpling/context"
 	"github.com/pingcap/tidb/pkg/util/promutil"
 	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
 )
 
 func TestWriteMeta(t *testing.T) {
@@ -314,6 +321,169 @@ func TestWriteInsertInCsvWithDialect(t *testing.T) {
 	}
 }
 
+func TestWriteInsertInParquet(t *testing.T) {
+	cfg := createMockConfig()
+	cfg.ParquetRowGroupSize = DefaultParquetRowGroupSize
+	cfg.ParquetCompressType = parquet.CompressionCodec_SNAPPY
+
+	data := [][]driver.Value{
+		{"1", "18446744073709551615", "-12.30", "2024-01-02", "2024-01-02 03:04:05.123456", `{"a": 1}`, []byte{0xff, 0}, "1.5"},
+		{nil, "0", "0.05", "0000-00-00", "1969-12-31 23:59:59", nil, nil, nil},
+	}
+	colTypes := []string{"INT", "UNSIGNED BIGINT", "DECIMAL", "DATE", "DATETIME", "JSON", "BLOB", "DOUBLE"}
+	tableIR := newMockTableIR("test", "t", data, nil, colTypes)
+	tableIR.colNames = []string{"id", "u", "d", "dt", "ts", "j", "b", "f"}
+	tableIR.decimalSizes = []DecimalSize{2: {Precision: 10, Scale: 2, OK: true}}
+	bf := storage.NewBufferWriter()
+
+	m := newMetrics(cfg.PromFactory, cfg.Labels)
+	n, err := WriteInsertInParquet(tcontext.Background(), cfg, tableIR, tableIR, bf, m)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), n)
+	require.Equal(t, float64(len(data)), ReadGauge(m.finishedRowsGauge))
+	require.Equal(t, float64(len(bf.Bytes())), ReadGauge(m.finishedSizeGauge))
+
+	pr := openParquetColumnReader(t, bf.Bytes())
+	require.Equal(t, int64(2), pr.GetNumRows())
+
+	schema := pr.Footer.Schema
+	require.Equal(t, "d", pr.SchemaHandler.GetExName(3))
+	require.Equal(t, parquet.ConvertedType_DECIMAL, schema[3].GetConvertedType())
+	require.Equal(t, int32(10), schema[3].GetPrecision())
+	require.Equal(t, int32(2), schema[3].GetScale())
+	require.False(t, schema[5].GetLogicalType().GetTIMESTAMP().GetIsAdjustedToUTC())
+	require.Equal(t, parquet.ConvertedType_UTF8, schema[6].GetConvertedType())
+
+	expected := [][]any{
+		{int64(1), nil},
+		{int64(-1), int64(0)},
+		{"\xfb\x32", "\x05"},
+		{int32(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC).Unix() / 86400), nil},
+		{time.Date(2024, 1, 2, 3, 4, 5, 123456000, time.UTC).UnixMicro(), int64(-1000000)},
+		{`{"a": 1}`, nil},
+		{"\xff\x00", nil},
+		{1.5, nil},
+	}
+	for i, values := range expected {
+		actual, _, _, err := pr.ReadColumnByIndex(int64(i), 2)
+		require.NoError(t, err)
+		require.Equal(t, values, actual, tableIR.colNames[i])
+	}
+}
+
+func TestWriteInsertInParquetTypes(t *testing.T) {
+	cfg := createMockConfig()
+	cfg.ParquetRowGroupSize = DefaultParquetRowGroupSize
+	cfg.ParquetCompressType = parquet.CompressionCodec_SNAPPY
+
+	const highScaleDecimal = "-12345678901234567890123456789012345.123456789012345678901234567890"
+	data := [][]driver.Value{
+		{"-9223372036854775808", "9223372036854775808", "-1.5", "1e308", "-0.05", highScaleDecimal,
+			"0000-00-00", "0000-00-00 00:00:00", "0000-00-00 00:00:00", []byte{0}, "abc", "[]", "-838:59:59", "2155"},
+		{"1", "18446744073709551615", "0", "-2.5e-308", "-128.00", "0.000000000000000000000000000001",
+			"1000-01-01", "2024-02-29 23:59:59.999999", "1970-01-01 00:00:00", []byte{}, "", "null", "00:00:00", "0"},
+		{nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil},
+	}
+	colTypes := []string{"BIGINT", "UNSIGNED BIGINT", "FLOAT", "DOUBLE", "DECIMAL", "DECIMAL",
+		"DATE", "DATETIME", "TIMESTAMP", "BLOB", "VARCHAR", "JSON", "TIME", "YEAR"}
+	tableIR := newMockTableIR("test", "t", data, nil, colTypes)
+	tableIR.colNames = []string{"i", "u", "f", "dbl", "d", "hd", "dt", "dtt", "ts", "b", "s", "j", "tm", "y"}
+	tableIR.decimalSizes = []DecimalSize{4: {Precision: 10, Scale: 2, OK: true}, 5: {Precision: 65, Scale: 30, OK: true}}
+	bf := storage.NewBufferWriter()
+
+	m := newMetrics(cfg.PromFactory, cfg.Labels)
+	n, err := WriteInsertInParquet(tcontext.Background(), cfg, tableIR, tableIR, bf, m)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), n)
+
+	pr := openParquetColumnReader(t, bf.Bytes())
+	require.Equal(t, int64(3), pr.GetNumRows())
+	schema := pr.Footer.Schema
+	require.Equal(t, int32(65), schema[6].GetPrecision())
+	require.Equal(t, int32(30), schema[6].GetScale())
+	require.Equal(t, parquet.ConvertedType_UINT_64, schema[2].GetConvertedType())
+
+	// The unsigned values above MaxInt64 are stored in the bits of INT64.
+	maxUint64, minInt64 := uint64(18446744073709551615), int64(-9223372036854775808)
+	expected := [][]any{
+		{minInt64, int64(1), nil},
+		{minInt64, int64(maxUint64), nil},
+		{float32(-1.5), float32(0), nil},
+		{1e308, -2.5e-308, nil},
+		nil, // decimals
+		nil,
+		// The zero dates can't be represented, so they are written as null.
+		{nil, int32(time.Date(1000, 1, 1, 0, 0, 0, 0, time.UTC).Unix() / 86400), nil},
+		{nil, time.Date(2024, 2, 29, 23, 59, 59, 999999000, time.UTC).UnixMicro(), nil},
+		{nil, int64(0), nil},
+		{"\x00", "", nil},
+		{"abc", "", nil},
+		{"[]", "null", nil},
+		{"-838:59:59", "00:00:00", nil},
+		{int64(2155), int64(0), nil},
+	}
+	columns := make([][]any, len(expected))
+	for i, values := range expected {
+		columns[i], _, _, err = pr.ReadColumnByIndex(int64(i), 3)
+		require.NoError(t, err)
+		if values != nil {
+			require.Equal(t, values, columns[i], tableIR.colNames[i])
+		}
+	}
+	// The decimals are checked by decoding the two's complement of the unscaled values.
+	decimals := []struct {
+		col      int
+		scale    int
+		expected []string
+	}{
+		{4, 2, []string{"-0.05", "-128.00"}},
+		{5, 30, []string{highScaleDecimal, "0.000000000000000000000000000001"}},
+	}
+	for _, d := range decimals {
+		actual := columns[d.col]
+		require.Len(t, actual, 3)
+		for i, expected := range d.expected {
+			require.Equal(t, expected, decodeParquetDecimal(actual[i].(string), d.scale), tableIR.colNames[d.col])
+		}
+		require.Nil(t, actual[2])
+	}
+}
+
+func openParquetColumnReader(t *testing.T, data []byte) *reader.ParquetReader {
+	path := filepath.Join(t.TempDir(), "t.parquet")
+	require.NoError(t, os.WriteFile(path, data, 0o644))
+	pf, err := local.NewLocalFileReader(path)
+	require.NoError(t, err)
+	pr, err := reader.NewParquetColumnReader(pf, 1)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		pr.ReadStop()
+		require.NoError(t, pf.Close())
+	})
+	return pr
+}
+
+// decodeParquetDecimal decodes the big-endian two's complement of an unscaled decimal value.
+func decodeParquetDecimal(b string, scale int) string {
+	v := new(big.Int).SetBytes([]byte(b))
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		v.Sub(v, new(big.Int).Lsh(big.NewInt(1), uint(8*len(b))))
+	}
+	sign := ""
+	if v.Sign() < 0 {
+		sign = "-"
+		v.Neg(v)
+	}
+	digits := v.String()
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+	if scale == 0 {
+		return sign + digits
+	}
+	return sign + digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+}
+
 func TestSQLDataTypes(t *testing.T) {
 	cfg := createMockConfig()
 
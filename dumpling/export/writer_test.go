@@ -252,6 +252,45 @@ func TestWriteTableDataWithFileSizeAndRows(t *testing.T) {
 	}
 }
 
+func TestWriteTableDataInParquetWithFileSize(t *testing.T) {
+	dir := t.TempDir()
+	config := defaultConfigForTest(t)
+	config.OutputDirPath = dir
+	config.FileType = FileFormatParquetString
+	// The size of a parquet file is estimated by the size of the text values.
+	config.FileSize = 50
+
+	writer := createTestWriter(config, t)
+
+	data := [][]driver.Value{
+		{"1", "male", "bob@mail.com", "020-1234", nil},
+		{"2", "female", "sarah@mail.com", "020-1253", "healthy"},
+		{"3", "male", "john@mail.com", "020-1256", "healthy"},
+		{"4", "female", "sarah@mail.com", "020-1235", "healthy"},
+	}
+	colTypes := []string{"INT", "SET", "VARCHAR", "VARCHAR", "TEXT"}
+	tableIR := newMockTableIR("test", "employee", data, nil, colTypes)
+	err := writer.WriteTableData(tableIR, tableIR, 0)
+	require.NoError(t, err)
+
+	cases := map[string][]any{
+		"test.employee.000000000.parquet": {int64(1), int64(2)},
+		"test.employee.000000001.parquet": {int64(3), int64(4)},
+	}
+	for p, expected := range cases {
+		bytes, err := os.ReadFile(path.Join(dir, p))
+		require.NoError(t, err)
+		// Every file is a complete parquet file.
+		pr := openParquetColumnReader(t, bytes)
+		require.Equal(t, int64(len(expected)), pr.GetNumRows())
+		ids, _, _, err := pr.ReadColumnByIndex(0, pr.GetNumRows())
+		require.NoError(t, err)
+		require.Equal(t, expected, ids, p)
+	}
+	_, err = os.Stat(path.Join(dir, "test.employee.000000002.parquet"))
+	require.True(t, os.IsNotExist(err))
+}
+
 func TestWriteTableDataWithStatementSize(t *testing.T) {
 	dir := t.TempDir()
 	config := defaultConfigForTest(t)
@@ -18,6 +18,8 @@ import (
 	tcontext "github.com/pingcap/tidb/dumpling/context"
 	"github.com/pingcap/tidb/dumpling/log"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/xitongsys/parquet-go/marshal"
+	"github.com/xitongsys/parquet-go/writer"
 	"go.uber.org/zap"
 )
 
@@ -184,6 +186,7 @@ func WriteInsert(
 	var (
 		insertStatementPrefix string
 		row                   = MakeRowReceiver(meta.ColumnTypes())
+		masker                = newColumnMasker(cfg.Mask, meta, row)
 		counter               uint64
 		lastCounter           uint64
 		escapeBackslash       = cfg.EscapeBackslash
@@ -211,13 +214,18 @@ func WriteInsert(
 	}()
 
 	selectedField := meta.SelectedField()
+	// the rows of the incremental dump may already exist in the previous dump
+	insertVerb := "INSERT"
+	if cfg.SinceSnapshot != "" {
+		insertVerb = "REPLACE"
+	}
 
 	// if has generated column
 	if selectedField != "" && selectedField != "*" {
-		insertStatementPrefix = fmt.Sprintf("INSERT INTO %s (%s) VALUES\n",
+		insertStatementPrefix = fmt.Sprintf("%s INTO %s (%s) VALUES\n", insertVerb,
 			wrapBackTicks(escapeString(meta.TableName())), selectedField)
 	} else {
-		insertStatementPrefix = fmt.Sprintf("INSERT INTO %s VALUES\n",
+		insertStatementPrefix = fmt.Sprintf("%s INTO %s VALUES\n", insertVerb,
 			wrapBackTicks(escapeString(meta.TableName())))
 	}
 	insertStatementPrefixLen := uint64(len(insertStatementPrefix))
@@ -233,6 +241,9 @@ func WriteInsert(
 				if err = fileRowIter.Decode(row); err != nil {
 					return counter, errors.Trace(err)
 				}
+				if masker != nil {
+					masker.mask()
+				}
 				row.WriteToBuffer(bf, escapeBackslash)
 			} else {
 				bf.WriteString("()")
@@ -331,6 +342,7 @@ func WriteInsertInCsv(
 
 	var (
 		row             = MakeRowReceiver(meta.ColumnTypes())
+		masker          = newColumnMasker(cfg.Mask, meta, row)
 		counter         uint64
 		lastCounter     uint64
 		escapeBackslash = cfg.EscapeBackslash
@@ -377,6 +389,9 @@ func WriteInsertInCsv(
 			if err = fileRowIter.Decode(row); err != nil {
 				return counter, errors.Trace(err)
 			}
+			if masker != nil {
+				masker.mask()
+			}
 			row.WriteToBufferInCsv(bf, escapeBackslash, opt)
 		}
 		counter++
@@ -417,6 +432,140 @@ func WriteInsertInCsv(
 	return counter, wp.Error()
 }
 
+// WriteInsertInParquet writes TableDataIR to a storage.ExternalFileWriter in parquet type
+func WriteInsertInParquet(
+	pCtx *tcontext.Context,
+	cfg *Config,
+	meta TableMeta,
+	tblIR TableDataIR,
+	w storage.ExternalFileWriter,
+	metrics *metrics,
+) (n uint64, err error) {
+	fileRowIter := tblIR.Rows()
+	if !fileRowIter.HasNext() {
+		return 0, fileRowIter.Error()
+	}
+
+	wp := newWriterPipe(w, cfg.FileSize, UnspecifiedSize, metrics, cfg.Labels)
+
+	// use context.Background here to make sure writerPipe can deplete all the chunks in pipeline
+	ctx, cancel := tcontext.Background().WithLogger(pCtx.L()).WithCancel()
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		wp.Run(ctx)
+		wg.Done()
+	}()
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	var (
+		cols, schemas = newParquetSchema(meta)
+		row           = newParquetRowReceiver(len(cols))
+		rec           []any
+		size          int
+		counter       uint64
+		lastCounter   uint64
+		gaugedSize    uint64
+	)
+
+	defer func() {
+		if err != nil {
+			pCtx.L().Warn("fail to dumping table(chunk), will revert some metrics and start a retry if possible",
+				zap.String("database", meta.DatabaseName()),
+				zap.String("table", meta.TableName()),
+				zap.Uint64("finished rows", lastCounter),
+				zap.Uint64("finished size", wp.finishedFileSize),
+				log.ShortError(err))
+			SubGauge(metrics.finishedRowsGauge, float64(lastCounter))
+			SubGauge(metrics.finishedSizeGauge, float64(wp.finishedFileSize))
+		} else {
+			pCtx.L().Debug("finish dumping table(chunk)",
+				zap.String("database", meta.DatabaseName()),
+				zap.String("table", meta.TableName()),
+				zap.Uint64("finished rows", counter),
+				zap.Uint64("finished size", wp.finishedFileSize))
+			summary.CollectSuccessUnit(summary.TotalBytes, 1, wp.finishedFileSize)
+			summary.CollectSuccessUnit("total rows", 1, counter)
+		}
+	}()
+
+	pw, err := writer.NewParquetWriterFromWriter(&parquetPipeWriter{ctx: pCtx, wp: wp}, schemas, 1)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	pw.MarshalFunc = marshal.MarshalCSV
+	pw.RowGroupSize = int64(cfg.ParquetRowGroupSize)
+	pw.CompressionType = cfg.ParquetCompressType
+
+	for fileRowIter.HasNext() {
+		if err = fileRowIter.Decode(row); err != nil {
+			return counter, errors.Trace(err)
+		}
+		if rec, size, err = row.convert(cols); err != nil {
+			return counter, errors.Trace(err)
+		}
+		if err = pw.Write(rec); err != nil {
+			return counter, errors.Trace(err)
+		}
+		counter++
+		// The row group is buffered in memory until it's full, so the file size is estimated by the
+		// size of the values returned by the server.
+		wp.currentFileSize += uint64(size)
+		if wp.currentFileSize-gaugedSize >= lengthLimit {
+			select {
+			case <-pCtx.Done():
+				return counter, pCtx.Err()
+			case err = <-wp.errCh:
+				return counter, err
+			default:
+			}
+			AddGauge(metrics.finishedRowsGauge, float64(counter-lastCounter))
+			lastCounter = counter
+			gaugedSize = wp.currentFileSize
+		}
+
+		fileRowIter.Next()
+		if wp.ShouldSwitchFile() {
+			break
+		}
+	}
+
+	if err = pw.WriteStop(); err != nil {
+		return counter, errors.Trace(err)
+	}
+	close(wp.input)
+	<-wp.closed
+	AddGauge(metrics.finishedRowsGauge, float64(counter-lastCounter))
+	lastCounter = counter
+	if err = fileRowIter.Error(); err != nil {
+		return counter, errors.Trace(err)
+	}
+	return counter, wp.Error()
+}
+
+// parquetPipeWriter is an io.Writer which sends the data written by the parquet writer to the writerPipe.
+type parquetPipeWriter struct {
+	ctx *tcontext.Context
+	wp  *writerPipe
+}
+
+// Write implements io.Writer.
+func (w *parquetPipeWriter) Write(p []byte) (int, error) {
+	bf := pool.Get().(*bytes.Buffer)
+	bf.Write(p)
+	select {
+	case <-w.ctx.Done():
+		return 0, w.ctx.Err()
+	case err := <-w.wp.errCh:
+		return 0, err
+	case w.wp.input <- bf:
+		return len(p), nil
+	}
+}
+
 func write(tctx *tcontext.Context, writer storage.ExternalFileWriter, str string) error {
 	_, err := writer.Write(tctx, []byte(str))
 	if err != nil {
@@ -601,7 +750,7 @@ func compressFileSuffix(compressType storage.CompressType) string {
 	}
 }
 
-// FileFormat is the format that output to file. Currently we support SQL text and CSV file format.
+// FileFormat is the format that output to file. Currently we support SQL text, CSV and parquet file format.
 type FileFormat int32
 
 const (
@@ -611,6 +760,8 @@ const (
 	FileFormatSQLText
 	// FileFormatCSV indicates the given file type is csv type
 	FileFormatCSV
+	// FileFormatParquet indicates the given file type is parquet type
+	FileFormatParquet
 )
 
 const (
@@ -618,6 +769,8 @@ const (
 	FileFormatSQLTextString = "sql"
 	// FileFormatCSVString indicates the string/suffix of csv type file
 	FileFormatCSVString = "csv"
+	// FileFormatParquetString indicates the string/suffix of parquet type file
+	FileFormatParquetString = "parquet"
 )
 
 // String implement Stringer.String method.
@@ -627,6 +780,8 @@ func (f FileFormat) String() string {
 		return strings.ToUpper(FileFormatSQLTextString)
 	case FileFormatCSV:
 		return strings.ToUpper(FileFormatCSVString)
+	case FileFormatParquet:
+		return strings.ToUpper(FileFormatParquetString)
 	default:
 		return "unknown"
 	}
@@ -634,20 +789,23 @@ func (f FileFormat) String() string {
 
 // Extension returns the extension for specific format.
 //
-//	text -> "sql"
-//	csv  -> "csv"
+//	text    -> "sql"
+//	csv     -> "csv"
+//	parquet -> "parquet"
 func (f FileFormat) Extension() string {
 	switch f {
 	case FileFormatSQLText:
 		return FileFormatSQLTextString
 	case FileFormatCSV:
 		return FileFormatCSVString
+	case FileFormatParquet:
+		return FileFormatParquetString
 	default:
 		return "unknown_format"
 	}
 }
 
-// WriteInsert writes TableDataIR to a storage.ExternalFileWriter in sql/csv type
+// WriteInsert writes TableDataIR to a storage.ExternalFileWriter in sql/csv/parquet type
 func (f FileFormat) WriteInsert(
 	pCtx *tcontext.Context,
 	cfg *Config,
@@ -661,6 +819,8 @@ func (f FileFormat) WriteInsert(
 		return WriteInsert(pCtx, cfg, meta, tblIR, w, metrics)
 	case FileFormatCSV:
 		return WriteInsertInCsv(pCtx, cfg, meta, tblIR, w, metrics)
+	case FileFormatParquet:
+		return WriteInsertInParquet(pCtx, cfg, meta, tblIR, w, metrics)
 	default:
 		return 0, errors.Errorf("unknown file format")
 	}
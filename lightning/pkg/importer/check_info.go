@@ -132,6 +132,13 @@ func (rc *Controller) checkCSVHeader(ctx context.Context) error {
 	return rc.doPreCheckOnItem(ctx, precheck.CheckCSVHeader)
 }
 
+func (rc *Controller) checkColumnMapping(ctx context.Context) error {
+	if !rc.cfg.Mydumper.ColumnMapping.Enable {
+		return nil
+	}
+	return rc.doPreCheckOnItem(ctx, precheck.CheckColumnMapping)
+}
+
 func (rc *Controller) checkTableEmpty(ctx context.Context) error {
 	if rc.cfg.TikvImporter.Backend == config.BackendTiDB || rc.cfg.TikvImporter.ParallelImport {
 		return nil
@@ -62,9 +62,10 @@ func newChunkProcessor(
 	chunk *checkpoints.ChunkCheckpoint,
 	ioWorkers *worker.Pool,
 	store storage.ExternalStorage,
+	dbName string,
 	tableInfo *model.TableInfo,
 ) (*chunkProcessor, error) {
-	parser, err := openParser(ctx, cfg, chunk, ioWorkers, store, tableInfo)
+	parser, err := openParser(ctx, cfg, chunk, ioWorkers, store, dbName, tableInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -81,6 +82,7 @@ func openParser(
 	chunk *checkpoints.ChunkCheckpoint,
 	ioWorkers *worker.Pool,
 	store storage.ExternalStorage,
+	dbName string,
 	tblInfo *model.TableInfo,
 ) (mydump.Parser, error) {
 	blockBufSize := int64(cfg.Mydumper.ReadBlockSize)
@@ -111,6 +113,21 @@ func openParser(
 		if err != nil {
 			return nil, err
 		}
+	case mydump.SourceTypeNDJSON:
+		parser, err = mydump.NewNDJSONParser(ctx, cfg.Mydumper.FieldPaths, reader, blockBufSize, ioWorkers)
+		if err != nil {
+			return nil, err
+		}
+	case mydump.SourceTypeAvro:
+		parser, err = mydump.NewAvroParser(ctx, cfg.Mydumper.FieldPaths, reader)
+		if err != nil {
+			return nil, err
+		}
+	case mydump.SourceTypeLive:
+		parser, err = mydump.NewLiveParser(ctx, reader)
+		if err != nil {
+			return nil, err
+		}
 	default:
 		return nil, errors.Errorf("file '%s' with unknown source type '%s'", chunk.Key.Path, chunk.FileMeta.Type.String())
 	}
@@ -127,6 +144,24 @@ func openParser(
 		}
 		parser.SetRowID(chunk.Chunk.PrevRowIDMax)
 	}
+	if chunk.FileMeta.Type == mydump.SourceTypeCSV && cfg.Mydumper.ColumnMapping.Enable {
+		var header []string
+		if chunk.Chunk.Offset > 0 {
+			header = chunk.Chunk.Columns
+			if len(header) == 0 {
+				if header, err = readCSVHeader(ctx, cfg, store, &chunk.FileMeta); err != nil {
+					_ = parser.Close()
+					return nil, err
+				}
+			}
+		}
+		mapped, err := newColumnMappedParser(cfg, parser, dbName, tblInfo, header)
+		if err != nil {
+			_ = parser.Close()
+			return nil, err
+		}
+		parser = mapped
+	}
 	if len(chunk.ColumnPermutation) > 0 {
 		parser.SetColumns(getColumnNames(tblInfo, chunk.ColumnPermutation))
 	}
@@ -348,7 +383,7 @@ func (cr *chunkProcessor) encodeLoop(
 			err = cr.parser.ReadRow()
 			columnNames := cr.parser.Columns()
 			newOffset, rowID = cr.parser.Pos()
-			if cr.chunk.FileMeta.Compression != mydump.CompressionNone || cr.chunk.FileMeta.Type == mydump.SourceTypeParquet {
+			if cr.chunk.FileMeta.Compression != mydump.CompressionNone || cr.chunk.FileMeta.Type.IsRowCountPos() {
 				newScannedOffset, scannedOffsetErr = cr.parser.ScannedPos()
 				if scannedOffsetErr != nil {
 					logger.Warn("fail to get data engine ScannedPos, progress may not be accurate",
@@ -498,7 +533,7 @@ func (cr *chunkProcessor) encodeLoop(
 		if m, ok := metric.FromContext(ctx); ok {
 			m.RowEncodeSecondsHistogram.Observe(encodeDur.Seconds())
 			m.RowReadSecondsHistogram.Observe(readDur.Seconds())
-			if cr.chunk.FileMeta.Type == mydump.SourceTypeParquet {
+			if cr.chunk.FileMeta.Type.IsRowCountPos() {
 				m.RowReadBytesHistogram.Observe(float64(newScannedOffset - scannedOffset))
 			} else {
 				m.RowReadBytesHistogram.Observe(float64(newOffset - offset))
@@ -715,7 +750,7 @@ func (cr *chunkProcessor) deliverLoop(
 			}
 			delta := highOffset - lowOffset
 			if delta >= 0 {
-				if cr.chunk.FileMeta.Type == mydump.SourceTypeParquet {
+				if cr.chunk.FileMeta.Type.IsRowCountPos() {
 					if currRealOffset > startRealOffset {
 						m.BytesCounter.WithLabelValues(metric.StateRestored).Add(float64(currRealOffset - startRealOffset))
 					}
@@ -0,0 +1,306 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/pkg/lightning/common"
+	"github.com/pingcap/tidb/pkg/lightning/config"
+	"github.com/pingcap/tidb/pkg/lightning/mydump"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+)
+
+// columnMapping maps the header columns of a CSV file to the columns of a
+// table by config.ColumnMapping.
+type columnMapping struct {
+	// header is the lower-case header columns of the CSV file.
+	header []string
+	// columns are the mapped names of the kept header columns, with the
+	// overflow column at the end if there are unknown columns to store.
+	columns []string
+	// keep is the indexes of the kept header columns.
+	keep []int
+	// unknown is the indexes of the header columns which don't exist in the
+	// table, they're ignored or stored into the overflow column.
+	unknown  []int
+	overflow bool
+	// aliased maps the indexes of the aliased header columns to the columns.
+	aliased map[int]string
+	missing []string
+}
+
+func newColumnMapping(
+	cfg *config.ColumnMapping,
+	tableInfo *model.TableInfo,
+	header []string,
+	ignoreColumns map[string]struct{},
+) (*columnMapping, error) {
+	m := &columnMapping{
+		header:  header,
+		aliased: make(map[int]string),
+	}
+	tableColumns := make(map[string]*model.ColumnInfo, len(tableInfo.Columns))
+	for _, col := range tableInfo.Columns {
+		tableColumns[col.Name.L] = col
+	}
+
+	mappedFrom := make(map[string]string, len(header))
+	for i, name := range header {
+		col := name
+		if _, ok := tableColumns[col]; !ok {
+			if alias, ok := cfg.Aliases[col]; ok {
+				if _, ok = tableColumns[alias]; ok {
+					col = alias
+					m.aliased[i] = alias
+				}
+			}
+		}
+		_, known := tableColumns[col]
+		_, ignored := ignoreColumns[col]
+		if !known && !ignored && col != model.ExtraHandleName.L && cfg.UnknownColumns != config.UnknownColumnsError {
+			m.unknown = append(m.unknown, i)
+			continue
+		}
+		if from, ok := mappedFrom[col]; ok {
+			return nil, errors.Errorf("header columns %s and %s of table %s are both mapped to column %s",
+				from, name, tableInfo.Name, col)
+		}
+		mappedFrom[col] = name
+		m.keep = append(m.keep, i)
+		m.columns = append(m.columns, col)
+	}
+
+	if len(m.unknown) > 0 && cfg.UnknownColumns == config.UnknownColumnsOverflow {
+		col, ok := tableColumns[cfg.OverflowColumn]
+		if !ok {
+			return nil, common.ErrUnknownColumns.GenWithStackByArgs(m.unknownNames(), tableInfo.Name)
+		}
+		if col.GetType() != mysql.TypeJSON {
+			return nil, errors.Errorf("overflow column %s of table %s is not a JSON column", col.Name, tableInfo.Name)
+		}
+		if from, ok := mappedFrom[col.Name.L]; ok {
+			return nil, errors.Errorf("overflow column %s of table %s is mapped from header column %s",
+				col.Name, tableInfo.Name, from)
+		}
+		m.overflow = true
+		m.columns = append(m.columns, col.Name.L)
+		mappedFrom[col.Name.L] = ""
+	}
+
+	for _, col := range tableInfo.Columns {
+		if _, ok := mappedFrom[col.Name.L]; !ok && !col.Hidden && !col.IsGenerated() {
+			m.missing = append(m.missing, col.Name.L)
+		}
+	}
+	return m, nil
+}
+
+func (m *columnMapping) unknownNames() string {
+	names := make([]string, 0, len(m.unknown))
+	for _, i := range m.unknown {
+		names = append(names, m.header[i])
+	}
+	return strings.Join(names, ",")
+}
+
+// overflowValue returns the JSON object of the unknown columns of a row.
+func (m *columnMapping) overflowValue(row []types.Datum) (types.Datum, error) {
+	obj := make(map[string]any, len(m.unknown))
+	for _, i := range m.unknown {
+		var v any
+		if i < len(row) && !row[i].IsNull() {
+			s, err := row[i].ToString()
+			if err != nil {
+				return types.Datum{}, errors.Trace(err)
+			}
+			v = s
+		}
+		obj[m.header[i]] = v
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return types.Datum{}, errors.Trace(err)
+	}
+	return types.NewStringDatum(string(data)), nil
+}
+
+// String describes the mapping for the precheck report.
+func (m *columnMapping) String() string {
+	var parts []string
+	for _, i := range m.keep {
+		if col, ok := m.aliased[i]; ok {
+			parts = append(parts, fmt.Sprintf("%s -> %s", m.header[i], col))
+		}
+	}
+	if len(m.unknown) > 0 {
+		if m.overflow {
+			parts = append(parts, fmt.Sprintf("unknown columns [%s] are stored in %s",
+				m.unknownNames(), m.columns[len(m.columns)-1]))
+		} else {
+			parts = append(parts, fmt.Sprintf("unknown columns [%s] are ignored", m.unknownNames()))
+		}
+	}
+	if len(m.missing) > 0 {
+		parts = append(parts, fmt.Sprintf("missing columns [%s] are filled with default values",
+			strings.Join(m.missing, ",")))
+	}
+	if len(parts) == 0 {
+		return "all columns are matched"
+	}
+	return strings.Join(parts, "; ")
+}
+
+// columnMappedParser presents the rows of a CSV file with the columns mapped
+// by columnMapping, so the rest of the import sees the file as if its header
+// matches the table.
+type columnMappedParser struct {
+	mydump.Parser
+
+	cfg       *config.ColumnMapping
+	tableInfo *model.TableInfo
+	ignore    map[string]struct{}
+	// header is read from the file if the chunk doesn't start at the beginning.
+	header  []string
+	mapping *columnMapping
+	lastRow mydump.Row
+	free    []types.Datum
+}
+
+// newColumnMappedParser wraps the parser of a CSV file if column mapping is
+// enabled. header is the header of the file if the parser doesn't read it.
+func newColumnMappedParser(
+	cfg *config.Config,
+	parser mydump.Parser,
+	dbName string,
+	tableInfo *model.TableInfo,
+	header []string,
+) (mydump.Parser, error) {
+	if !cfg.Mydumper.ColumnMapping.Enable {
+		return parser, nil
+	}
+	igCols, err := cfg.Mydumper.IgnoreColumns.GetIgnoreColumns(
+		dbName, tableInfo.Name.O, cfg.Mydumper.CaseSensitive)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &columnMappedParser{
+		Parser:    parser,
+		cfg:       &cfg.Mydumper.ColumnMapping,
+		tableInfo: tableInfo,
+		ignore:    igCols.ColumnsMap(),
+		header:    header,
+	}, nil
+}
+
+// readCSVHeader reads the header columns of a CSV file.
+func readCSVHeader(
+	ctx context.Context,
+	cfg *config.Config,
+	store storage.ExternalStorage,
+	fileMeta *mydump.SourceFileMeta,
+) ([]string, error) {
+	reader, err := mydump.OpenReader(ctx, fileMeta, store, storage.DecompressConfig{
+		ZStdDecodeConcurrency: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	charsetConvertor, err := mydump.NewCharsetConvertor(cfg.Mydumper.DataCharacterSet, cfg.Mydumper.DataInvalidCharReplace)
+	if err != nil {
+		_ = reader.Close()
+		return nil, err
+	}
+	parser, err := mydump.NewCSVParser(ctx, &cfg.Mydumper.CSV, reader, int64(cfg.Mydumper.ReadBlockSize), nil, true, charsetConvertor)
+	if err != nil {
+		_ = reader.Close()
+		return nil, err
+	}
+	//nolint: errcheck
+	defer parser.Close()
+	if err = parser.ReadColumns(); err != nil {
+		return nil, errors.Annotatef(err, "read header of file %s", fileMeta.Path)
+	}
+	return parser.Columns(), nil
+}
+
+// ReadRow reads a row and maps its columns.
+// It implements the Parser interface.
+func (p *columnMappedParser) ReadRow() error {
+	if err := p.Parser.ReadRow(); err != nil {
+		return err
+	}
+	if p.mapping == nil {
+		header := p.header
+		if len(header) == 0 {
+			header = p.Parser.Columns()
+		}
+		mapping, err := newColumnMapping(p.cfg, p.tableInfo, header, p.ignore)
+		if err != nil {
+			return err
+		}
+		p.mapping = mapping
+	}
+
+	inner := p.Parser.LastRow()
+	row := p.free[:0]
+	p.free = nil
+	for _, i := range p.mapping.keep {
+		if i < len(inner.Row) {
+			row = append(row, inner.Row[i])
+		}
+	}
+	if p.mapping.overflow {
+		v, err := p.mapping.overflowValue(inner.Row)
+		if err != nil {
+			return err
+		}
+		row = append(row, v)
+	}
+	p.lastRow = mydump.Row{RowID: inner.RowID, Row: row, Length: inner.Length}
+	p.Parser.RecycleRow(inner)
+	return nil
+}
+
+// LastRow gets the last row with the mapped columns.
+// It implements the Parser interface.
+func (p *columnMappedParser) LastRow() mydump.Row {
+	return p.lastRow
+}
+
+// RecycleRow implements the Parser interface.
+func (p *columnMappedParser) RecycleRow(row mydump.Row) {
+	p.free = row.Row[:0]
+}
+
+// Columns returns the mapped column names.
+// It implements the Parser interface.
+func (p *columnMappedParser) Columns() []string {
+	if p.mapping == nil {
+		return nil
+	}
+	return p.mapping.columns
+}
+
+// SetColumns is a no-op, the columns are always mapped from the header.
+// It implements the Parser interface.
+func (*columnMappedParser) SetColumns([]string) {}
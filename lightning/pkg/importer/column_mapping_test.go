@@ -0,0 +1,148 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package importer
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/pkg/lightning/checkpoints"
+	"github.com/pingcap/tidb/pkg/lightning/config"
+	"github.com/pingcap/tidb/pkg/lightning/log"
+	"github.com/pingcap/tidb/pkg/lightning/mydump"
+	"github.com/pingcap/tidb/pkg/lightning/worker"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestColumnMapping(t *testing.T) {
+	tableInfo := mockTiflashTableInfo(t,
+		"CREATE TABLE t (id INT PRIMARY KEY, name VARCHAR(20), age INT DEFAULT 1, g INT AS (id + 1), extra JSON)", 0)
+
+	cfg := &config.ColumnMapping{
+		Enable:         true,
+		Aliases:        map[string]string{"full_name": "name", "identifier": "id"},
+		UnknownColumns: config.UnknownColumnsError,
+	}
+	m, err := newColumnMapping(cfg, tableInfo, []string{"id", "full_name", "color"}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"id", "name", "color"}, m.columns)
+	require.Equal(t, "full_name -> name; missing columns [age,extra] are filled with default values", m.String())
+	_, err = parseColumnPermutations(tableInfo, m.columns, nil, log.L())
+	require.ErrorContains(t, err, "unknown columns in header (color)")
+
+	// aliases are not applied to the header columns which exist in the table
+	_, err = newColumnMapping(cfg, tableInfo, []string{"id", "identifier"}, nil)
+	require.ErrorContains(t, err, "header columns id and identifier of table t are both mapped to column id")
+
+	cfg.UnknownColumns = config.UnknownColumnsIgnore
+	m, err = newColumnMapping(cfg, tableInfo, []string{"color", "id", "name", "age", "extra", "size"}, map[string]struct{}{"size": {}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"id", "name", "age", "extra", "size"}, m.columns)
+	require.Equal(t, []int{1, 2, 3, 4, 5}, m.keep)
+	require.Equal(t, "unknown columns [color] are ignored", m.String())
+
+	cfg.UnknownColumns = config.UnknownColumnsOverflow
+	cfg.OverflowColumn = "extra"
+	m, err = newColumnMapping(cfg, tableInfo, []string{"color", "id", "size"}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"id", "extra"}, m.columns)
+	require.Equal(t, "unknown columns [color,size] are stored in extra; missing columns [name,age] are filled with default values", m.String())
+	v, err := m.overflowValue([]types.Datum{types.NewStringDatum("red"), types.NewStringDatum("1"), {}})
+	require.NoError(t, err)
+	require.Equal(t, `{"color":"red","size":null}`, v.GetString())
+
+	// no overflow column is needed if all columns are known
+	m, err = newColumnMapping(cfg, tableInfo, []string{"id", "extra"}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"id", "extra"}, m.columns)
+
+	_, err = newColumnMapping(cfg, tableInfo, []string{"id", "extra", "color"}, nil)
+	require.ErrorContains(t, err, "overflow column extra of table t is mapped from header column extra")
+	cfg.OverflowColumn = "name"
+	_, err = newColumnMapping(cfg, tableInfo, []string{"id", "color"}, nil)
+	require.ErrorContains(t, err, "overflow column name of table t is not a JSON column")
+	cfg.OverflowColumn = "missing"
+	_, err = newColumnMapping(cfg, tableInfo, []string{"id", "color"}, nil)
+	require.ErrorContains(t, err, "unknown columns in header (color)")
+}
+
+func TestColumnMappedParser(t *testing.T) {
+	ctx := context.Background()
+	tableInfo := mockTiflashTableInfo(t, "CREATE TABLE t (id INT PRIMARY KEY, name VARCHAR(20), extra JSON)", 0)
+
+	store := storage.NewMemStorage()
+	content := []byte("Color,ID,Full_Name\nred,1,a\nblue,2,b\n")
+	require.NoError(t, store.WriteFile(ctx, "/t.csv", content))
+
+	cfg := config.NewConfig()
+	cfg.Mydumper.CSV.Header = true
+	cfg.Mydumper.CSV.HeaderSchemaMatch = true
+	cfg.Mydumper.ColumnMapping = config.ColumnMapping{
+		Enable:         true,
+		Aliases:        map[string]string{"full_name": "name"},
+		UnknownColumns: config.UnknownColumnsOverflow,
+		OverflowColumn: "extra",
+	}
+	ioWorkers := worker.NewPool(ctx, 1, "io")
+	fileMeta := mydump.SourceFileMeta{Path: "/t.csv", Type: mydump.SourceTypeCSV, FileSize: int64(len(content))}
+
+	readAll := func(chunk *checkpoints.ChunkCheckpoint) [][]types.Datum {
+		parser, err := openParser(ctx, cfg, chunk, ioWorkers, store, "db", tableInfo)
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, parser.Close())
+		}()
+		var rows [][]types.Datum
+		for {
+			err = parser.ReadRow()
+			if errors.Cause(err) == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			require.Equal(t, []string{"id", "name", "extra"}, parser.Columns())
+			row := parser.LastRow()
+			rows = append(rows, append([]types.Datum(nil), row.Row...))
+			parser.RecycleRow(row)
+		}
+		return rows
+	}
+
+	rows := readAll(&checkpoints.ChunkCheckpoint{
+		FileMeta: fileMeta,
+		Chunk:    mydump.Chunk{EndOffset: int64(len(content))},
+	})
+	require.Len(t, rows, 2)
+	require.Equal(t, []types.Datum{
+		types.NewStringDatum("1"), types.NewStringDatum("a"), types.NewStringDatum(`{"color":"red"}`),
+	}, rows[0])
+	require.Equal(t, []types.Datum{
+		types.NewStringDatum("2"), types.NewStringDatum("b"), types.NewStringDatum(`{"color":"blue"}`),
+	}, rows[1])
+
+	// the header is read from the file when the chunk starts in the middle
+	offset := int64(len("Color,ID,Full_Name\nred,1,a\n"))
+	rows = readAll(&checkpoints.ChunkCheckpoint{
+		FileMeta: fileMeta,
+		Chunk:    mydump.Chunk{Offset: offset, EndOffset: int64(len(content)), PrevRowIDMax: 1},
+	})
+	require.Len(t, rows, 1)
+	require.Equal(t, []types.Datum{
+		types.NewStringDatum("2"), types.NewStringDatum("b"), types.NewStringDatum(`{"color":"blue"}`),
+	}, rows[0])
+}
@@ -494,6 +494,21 @@ func (p *PreImportInfoGetterImpl) ReadFirstNRowsByFileMeta(ctx context.Context,
 		if err != nil {
 			return nil, nil, errors.Trace(err)
 		}
+	case mydump.SourceTypeNDJSON:
+		parser, err = mydump.NewNDJSONParser(ctx, p.cfg.Mydumper.FieldPaths, reader, blockBufSize, p.ioWorkers)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+	case mydump.SourceTypeAvro:
+		parser, err = mydump.NewAvroParser(ctx, p.cfg.Mydumper.FieldPaths, reader)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+	case mydump.SourceTypeLive:
+		parser, err = mydump.NewLiveParser(ctx, reader)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
 	default:
 		panic(fmt.Sprintf("unknown file type '%s'", dataFileMeta.Type))
 	}
@@ -657,6 +672,10 @@ func (p *PreImportInfoGetterImpl) sampleDataFromTable(
 		if err != nil {
 			return 0.0, false, errors.Trace(err)
 		}
+		parser, err = newColumnMappedParser(p.cfg, parser, dbName, tableInfo, nil)
+		if err != nil {
+			return 0.0, false, errors.Trace(err)
+		}
 	case mydump.SourceTypeSQL:
 		parser = mydump.NewChunkParser(ctx, p.cfg.TiDB.SQLMode, reader, blockBufSize, p.ioWorkers)
 	case mydump.SourceTypeParquet:
@@ -664,6 +683,21 @@ func (p *PreImportInfoGetterImpl) sampleDataFromTable(
 		if err != nil {
 			return 0.0, false, errors.Trace(err)
 		}
+	case mydump.SourceTypeNDJSON:
+		parser, err = mydump.NewNDJSONParser(ctx, p.cfg.Mydumper.FieldPaths, reader, blockBufSize, p.ioWorkers)
+		if err != nil {
+			return 0.0, false, errors.Trace(err)
+		}
+	case mydump.SourceTypeAvro:
+		parser, err = mydump.NewAvroParser(ctx, p.cfg.Mydumper.FieldPaths, reader)
+		if err != nil {
+			return 0.0, false, errors.Trace(err)
+		}
+	case mydump.SourceTypeLive:
+		parser, err = mydump.NewLiveParser(ctx, reader)
+		if err != nil {
+			return 0.0, false, errors.Trace(err)
+		}
 	default:
 		panic(fmt.Sprintf("file '%s' with unknown source type '%s'", sampleFile.Path, sampleFile.Type.String()))
 	}
@@ -1499,9 +1499,9 @@ func (rc *Controller) importTables(ctx context.Context) (finalErr error) {
 			} else {
 				for _, eng := range cp.Engines {
 					for _, chunk := range eng.Chunks {
-						// for parquet files filesize is more accurate, we can calculate correct unfinished bytes unless
+						// for parquet and avro files filesize is more accurate, we can calculate correct unfinished bytes unless
 						//  we set up the reader, so we directly use filesize here
-						if chunk.FileMeta.Type == mydump.SourceTypeParquet {
+						if chunk.FileMeta.Type.IsRowCountPos() {
 							totalDataSizeToRestore += chunk.FileMeta.FileSize
 							if m, ok := metric.FromContext(ctx); ok {
 								m.RowsCounter.WithLabelValues(metric.StateTotalRestore, tableName).Add(float64(chunk.UnfinishedSize()))
@@ -1972,6 +1972,9 @@ func (rc *Controller) DataCheck(ctx context.Context) error {
 	if err := rc.checkCSVHeader(ctx); err != nil {
 		return common.ErrCheckCSVHeader.Wrap(err).GenWithStackByArgs()
 	}
+	if err := rc.checkColumnMapping(ctx); err != nil {
+		return errors.Trace(err)
+	}
 
 	return nil
 }
@@ -129,6 +129,10 @@ func NewImportSource(dbSrcDataMap map[string]*DBSourceData) (*ImportSource, erro
 					fileInfo.FileMeta.Type = mydump.SourceTypeSQL
 				case strings.HasSuffix(fileName, ".parquet"):
 					fileInfo.FileMeta.Type = mydump.SourceTypeParquet
+				case strings.HasSuffix(fileName, ".ndjson"), strings.HasSuffix(fileName, ".jsonl"):
+					fileInfo.FileMeta.Type = mydump.SourceTypeNDJSON
+				case strings.HasSuffix(fileName, ".avro"):
+					fileInfo.FileMeta.Type = mydump.SourceTypeAvro
 				default:
 					return nil, errors.Errorf("unsupported file type: %s", tblDataFile.FileName)
 				}
@@ -429,6 +429,10 @@ func (ci *storagePermissionCheckItem) Check(ctx context.Context) (*precheck.Chec
 		Passed:   true,
 		Message:  "Lightning has the correct storage permission",
 	}
+	// the privileges of the source database are checked on connecting
+	if ci.cfg.Mydumper.SourceDB.IsEnabled() {
+		return theResult, nil
+	}
 
 	u, err := storage.ParseBackend(ci.cfg.Mydumper.SourceDir, nil)
 	if err != nil {
@@ -1024,7 +1028,10 @@ func (ci *schemaCheckItem) SchemaIsValid(ctx context.Context, tableInfo *mydump.
 	// get columns name from data file.
 	dataFileMeta := dataFile.FileMeta
 
-	if tp := dataFileMeta.Type; tp != mydump.SourceTypeCSV && tp != mydump.SourceTypeSQL && tp != mydump.SourceTypeParquet {
+	switch dataFileMeta.Type {
+	case mydump.SourceTypeCSV, mydump.SourceTypeSQL, mydump.SourceTypeParquet, mydump.SourceTypeNDJSON, mydump.SourceTypeAvro,
+		mydump.SourceTypeLive:
+	default:
 		msgs = append(msgs, fmt.Sprintf("file '%s' with unknown source type '%s'", dataFileMeta.Path, dataFileMeta.Type.String()))
 		return msgs, nil
 	}
@@ -1054,6 +1061,14 @@ func (ci *schemaCheckItem) SchemaIsValid(ctx context.Context, tableInfo *mydump.
 		}
 		return msgs, nil
 	}
+	if dataFileMeta.Type == mydump.SourceTypeCSV && ci.cfg.Mydumper.ColumnMapping.Enable {
+		mapping, err := newColumnMapping(&ci.cfg.Mydumper.ColumnMapping, core, colsFromDataFile, igCols)
+		if err != nil {
+			msgs = append(msgs, err.Error())
+			return msgs, nil
+		}
+		colsFromDataFile = mapping.columns
+	}
 
 	// compare column names and make sure
 	// 1. TiDB table info has data file's all columns(besides ignore columns)
@@ -1329,6 +1344,83 @@ func checkFieldCompatibility(
 	return true, nil
 }
 
+type columnMappingCheckItem struct {
+	cfg           *config.Config
+	preInfoGetter PreImportInfoGetter
+	dbMetas       []*mydump.MDDatabaseMeta
+}
+
+// NewColumnMappingCheckItem creates a new columnMappingCheckItem.
+func NewColumnMappingCheckItem(cfg *config.Config, preInfoGetter PreImportInfoGetter, dbMetas []*mydump.MDDatabaseMeta) precheck.Checker {
+	return &columnMappingCheckItem{
+		cfg:           cfg,
+		preInfoGetter: preInfoGetter,
+		dbMetas:       dbMetas,
+	}
+}
+
+// GetCheckItemID implements Checker interface.
+func (*columnMappingCheckItem) GetCheckItemID() precheck.CheckItemID {
+	return precheck.CheckColumnMapping
+}
+
+// Check reports how the header columns of the first CSV file of each table are
+// mapped to the table columns. The errors of the mapping are reported by the
+// schema check, so this check always passes.
+func (ci *columnMappingCheckItem) Check(ctx context.Context) (*precheck.CheckResult, error) {
+	if !ci.cfg.Mydumper.ColumnMapping.Enable {
+		return nil, nil
+	}
+	theResult := &precheck.CheckResult{
+		Item:     ci.GetCheckItemID(),
+		Severity: precheck.Warn,
+		Passed:   true,
+	}
+	dbInfos, err := ci.preInfoGetter.GetAllTableStructures(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	msgs := make([]string, 0)
+	for _, dbMeta := range ci.dbMetas {
+		for _, tblMeta := range dbMeta.Tables {
+			info, ok := dbInfos[tblMeta.DB].Tables[tblMeta.Name]
+			if !ok {
+				continue
+			}
+			for _, f := range tblMeta.DataFiles {
+				if f.FileMeta.Type != mydump.SourceTypeCSV {
+					continue
+				}
+				cols, _, err := ci.preInfoGetter.ReadFirstNRowsByFileMeta(ctx, f.FileMeta, 1)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				if len(cols) == 0 {
+					break
+				}
+				igCols, err := ci.cfg.Mydumper.IgnoreColumns.GetIgnoreColumns(tblMeta.DB, tblMeta.Name, ci.cfg.Mydumper.CaseSensitive)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				mapping, err := newColumnMapping(&ci.cfg.Mydumper.ColumnMapping, info.Core, cols, igCols.ColumnsMap())
+				if err != nil {
+					msgs = append(msgs, fmt.Sprintf("table `%s`.`%s`: %s", tblMeta.DB, tblMeta.Name, err.Error()))
+				} else {
+					msgs = append(msgs, fmt.Sprintf("table `%s`.`%s`: %s", tblMeta.DB, tblMeta.Name, mapping))
+				}
+				// only check the first CSV file of this table.
+				break
+			}
+		}
+	}
+	if len(msgs) == 0 {
+		theResult.Message = "no CSV file with header is found"
+	} else {
+		theResult.Message = strings.Join(msgs, "\n")
+	}
+	return theResult, nil
+}
+
 type tableEmptyCheckItem struct {
 	cfg           *config.Config
 	preInfoGetter PreImportInfoGetter
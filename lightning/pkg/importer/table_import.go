@@ -313,9 +313,17 @@ func (tr *TableImporter) populateChunks(ctx context.Context, rc *Controller, cp
 				Timestamp:         timestamp,
 			}
 			if len(region.Chunk.Columns) > 0 {
+				columns := region.Chunk.Columns
+				if rc.cfg.Mydumper.ColumnMapping.Enable && region.FileMeta.Type == mydump.SourceTypeCSV {
+					mapping, err := newColumnMapping(&rc.cfg.Mydumper.ColumnMapping, tr.tableInfo.Core, columns, tr.ignoreColumns)
+					if err != nil {
+						return errors.Trace(err)
+					}
+					columns = mapping.columns
+				}
 				perms, err := parseColumnPermutations(
 					tr.tableInfo.Core,
-					region.Chunk.Columns,
+					columns,
 					tr.ignoreColumns,
 					log.FromContext(ctx))
 				if err != nil {
@@ -780,7 +788,7 @@ ChunkLoop:
 			setError(err)
 			break
 		}
-		cr, err := newChunkProcessor(ctx, chunkIndex, rc.cfg, chunk, rc.ioWorkers, rc.store, tr.tableInfo.Core)
+		cr, err := newChunkProcessor(ctx, chunkIndex, rc.cfg, chunk, rc.ioWorkers, rc.store, tr.dbInfo.Name, tr.tableInfo.Core)
 		if err != nil {
 			setError(err)
 			break
@@ -854,7 +862,7 @@ ChunkLoop:
 	for _, chunk := range cp.Chunks {
 		totalKVSize += chunk.Checksum.SumSize()
 		totalSQLSize += chunk.UnfinishedSize()
-		if chunk.FileMeta.Type == mydump.SourceTypeParquet {
+		if chunk.FileMeta.Type.IsRowCountPos() {
 			logKeyName = "read(rows)"
 		}
 	}
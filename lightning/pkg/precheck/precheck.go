@@ -46,6 +46,7 @@ const (
 	CheckLocalTempKVDir           CheckItemID = "CHECK_LOCAL_TEMP_KV_DIR"
 	CheckTargetUsingCDCPITR       CheckItemID = "CHECK_TARGET_USING_CDC_PITR"
 	CheckPDTiDBFromSameCluster    CheckItemID = "CHECK_PD_TIDB_FROM_SAME_CLUSTER"
+	CheckColumnMapping            CheckItemID = "CHECK_COLUMN_MAPPING"
 )
 
 var (
@@ -65,6 +66,7 @@ var (
 		CheckLocalTempKVDir:           "Local temp KV dir",
 		CheckTargetUsingCDCPITR:       "Target using CDC/PITR",
 		CheckPDTiDBFromSameCluster:    "PD and TiDB are from the same cluster",
+		CheckColumnMapping:            "Column mapping",
 	}
 )
 
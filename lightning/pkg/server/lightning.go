@@ -507,7 +507,19 @@ func (l *Lightning) run(taskCtx context.Context, taskCfg *config.Config, o *opti
 	}
 
 	s := o.dumpFileStorage
-	if s == nil {
+	if s == nil && taskCfg.Mydumper.SourceDB.IsEnabled() {
+		if err := resumeSourceDBSnapshot(ctx, taskCfg); err != nil {
+			return errors.Trace(err)
+		}
+		loadTask := o.logger.Begin(zap.InfoLevel, "load source database")
+		src, err := mydump.NewLiveSource(ctx, taskCfg)
+		loadTask.End(zap.ErrorLevel, err)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer src.Close()
+		s = src
+	} else if s == nil {
 		u, err := storage.ParseBackend(taskCfg.Mydumper.SourceDir, nil)
 		if err != nil {
 			return common.NormalizeError(err)
@@ -1026,6 +1038,37 @@ func checkSchemaConflict(cfg *config.Config, dbsMeta []*mydump.MDDatabaseMeta) e
 	return nil
 }
 
+// resumeSourceDBSnapshot sets the snapshot of the source database to the one
+// saved in the checkpoints if it's not specified, so that the resumed import
+// reads the same snapshot as the previous runs.
+func resumeSourceDBSnapshot(ctx context.Context, cfg *config.Config) error {
+	src := &cfg.Mydumper.SourceDB
+	if len(src.Snapshot) > 0 ||
+		(src.Consistency != config.SourceConsistencyAuto && src.Consistency != config.SourceConsistencySnapshot) {
+		return nil
+	}
+	exists, err := checkpoints.IsCheckpointsDBExists(ctx, cfg)
+	if err != nil || !exists {
+		return errors.Trace(err)
+	}
+	cpdb, err := checkpoints.OpenCheckpointsDB(ctx, cfg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	//nolint: errcheck
+	defer cpdb.Close()
+	taskCp, err := cpdb.TaskCheckpoint(ctx)
+	if err != nil || taskCp == nil {
+		return errors.Trace(err)
+	}
+	src.Snapshot = config.SourceDBSnapshotFromURL(taskCp.SourceDir)
+	if len(src.Snapshot) > 0 {
+		log.FromContext(ctx).Info("resume the import from the source database under the snapshot in the checkpoints",
+			zap.String("snapshot", src.Snapshot))
+	}
+	return nil
+}
+
 // CheckpointRemove removes the checkpoint of the given table.
 func CheckpointRemove(ctx context.Context, cfg *config.Config, tableName string) error {
 	cpdb, err := checkpoints.OpenCheckpointsDB(ctx, cfg)
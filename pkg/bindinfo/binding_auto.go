@@ -75,7 +75,7 @@ type bindingAuto struct {
 func newBindingAuto(sPool util.DestroyableSessionPool) BindingPlanEvolution {
 	return &bindingAuto{
 		sPool:              sPool,
-		planGenerator:      new(knobBasedPlanGenerator),
+		planGenerator:      &knobBasedPlanGenerator{sPool: sPool},
 		ruleBasedPredictor: new(ruleBasedPlanPerfPredictor),
 		llmPredictor:       new(llmBasedPlanPerfPredictor),
 	}
@@ -107,10 +107,9 @@ func (ba *bindingAuto) ShowPlansForSQL(currentDB, sqlOrDigest, charset, collatio
 
 func (ba *bindingAuto) getHistoricalPlanInfo(currentDB, sqlOrDigest, charset, collation string) ([]*BindingPlanInfo, error) {
 	// parse and normalize sqlOrDigest
-	// if the length is 64 and it has no " ", treat it as a digest.
 	var whereCond string
 	sqlOrDigest = strings.TrimSpace(sqlOrDigest)
-	if len(sqlOrDigest) == 64 && !strings.Contains(sqlOrDigest, " ") {
+	if isSQLDigest(sqlOrDigest) {
 		whereCond = "where sql_digest = %?"
 	} else {
 		p := parser.New()
@@ -226,6 +225,11 @@ func (ba *bindingAuto) getPlanExecInfo(planDigest string) (plan *planExecInfo, e
 	}, nil
 }
 
+// isSQLDigest treats the string as a SQL digest if the length is 64 and it has no " ".
+func isSQLDigest(sqlOrDigest string) bool {
+	return len(sqlOrDigest) == 64 && !strings.Contains(sqlOrDigest, " ")
+}
+
 // planExecInfo represents the plan info from information_schema.tidb_statements_stats table.
 type planExecInfo struct {
 	Plan          string
@@ -30,29 +30,39 @@ func TestExplainExploreBasic(t *testing.T) {
 	tk.MustExec("use test")
 
 	tk.MustExec(`create table t (a int, b int, c varchar(10), key(a))`)
-	require.True(t, len(tk.MustQuery(`explain explore select a from t where b=1`).Rows()) == 0)
+	// the created bindings have no hints, while the generated plans always have.
+	countBindings := func(sql string) int {
+		cnt := 0
+		for _, row := range tk.MustQuery(sql).Rows() {
+			if row[1] == "" {
+				cnt++
+			}
+		}
+		return cnt
+	}
+	require.True(t, countBindings(`explain explore select a from t where b=1`) == 0)
 	tk.MustExec(`create global binding using select a from t where b=1`)
-	require.True(t, len(tk.MustQuery(`explain explore select a from t where b=1`).Rows()) == 1)
-	require.True(t, len(tk.MustQuery(`explain explore SELECT a FROM t WHERE b=1`).Rows()) == 1)
-	require.True(t, len(tk.MustQuery(`explain explore SELECT a FROM t WHERE b= 1`).Rows()) == 1)
-	require.True(t, len(tk.MustQuery(`explain explore      SELECT  a FROM test.t WHERE b= 1`).Rows()) == 1)
-	require.True(t, len(tk.MustQuery(`explain explore "23109784b802bcef5398dd81d3b1c5b79200c257c101a5b9f90758206f3d09ed"`).Rows()) == 1)
+	require.True(t, countBindings(`explain explore select a from t where b=1`) == 1)
+	require.True(t, countBindings(`explain explore SELECT a FROM t WHERE b=1`) == 1)
+	require.True(t, countBindings(`explain explore SELECT a FROM t WHERE b= 1`) == 1)
+	require.True(t, countBindings(`explain explore      SELECT  a FROM test.t WHERE b= 1`) == 1)
+	require.True(t, countBindings(`explain explore "23109784b802bcef5398dd81d3b1c5b79200c257c101a5b9f90758206f3d09ed"`) == 1)
 
-	require.True(t, len(tk.MustQuery(`explain explore select a from t where b in (1, 2, 3)`).Rows()) == 0)
+	require.True(t, countBindings(`explain explore select a from t where b in (1, 2, 3)`) == 0)
 	tk.MustExec(`create global binding using select a from t where b in (1, 2, 3)`)
-	require.True(t, len(tk.MustQuery(`explain explore select a from t where b in (1, 2, 3)`).Rows()) == 1)
-	require.True(t, len(tk.MustQuery(`explain explore select a from t where b in (1, 2)`).Rows()) == 1)
-	require.True(t, len(tk.MustQuery(`explain explore select a from t where b in (1)`).Rows()) == 1)
-	require.True(t, len(tk.MustQuery(`explain explore SELECT a from t WHere b in (1)`).Rows()) == 1)
+	require.True(t, countBindings(`explain explore select a from t where b in (1, 2, 3)`) == 1)
+	require.True(t, countBindings(`explain explore select a from t where b in (1, 2)`) == 1)
+	require.True(t, countBindings(`explain explore select a from t where b in (1)`) == 1)
+	require.True(t, countBindings(`explain explore SELECT a from t WHere b in (1)`) == 1)
 
-	require.True(t, len(tk.MustQuery(`explain explore select a from t where c = ''`).Rows()) == 0)
+	require.True(t, countBindings(`explain explore select a from t where c = ''`) == 0)
 	tk.MustExec(`create global binding using select a from t where c = ''`)
-	require.True(t, len(tk.MustQuery(`explain explore select a from t where c = ''`).Rows()) == 1)
-	require.True(t, len(tk.MustQuery(`explain explore select a from t where c = '123'`).Rows()) == 1)
-	require.True(t, len(tk.MustQuery(`explain explore select a from t where c = '\"'`).Rows()) == 1)
-	require.True(t, len(tk.MustQuery(`explain explore select a from t where c = '              '`).Rows()) == 1)
-	require.True(t, len(tk.MustQuery(`explain explore select a from t where c = ""`).Rows()) == 1)
-	require.True(t, len(tk.MustQuery(`explain explore select a from t where c = "\'"`).Rows()) == 1)
+	require.True(t, countBindings(`explain explore select a from t where c = ''`) == 1)
+	require.True(t, countBindings(`explain explore select a from t where c = '123'`) == 1)
+	require.True(t, countBindings(`explain explore select a from t where c = '\"'`) == 1)
+	require.True(t, countBindings(`explain explore select a from t where c = '              '`) == 1)
+	require.True(t, countBindings(`explain explore select a from t where c = ""`) == 1)
+	require.True(t, countBindings(`explain explore select a from t where c = "\'"`) == 1)
 
 	tk.MustExecToErr("explain explore 'xxx'", "")
 	tk.MustExecToErr("explain explore SELECT A FROM", "")
@@ -123,3 +133,62 @@ func TestRelevantOptVarsAndFixes(t *testing.T) {
 		require.Equal(t, fmt.Sprintf("%v", fixes), c.fixes)
 	}
 }
+
+func TestExplainExploreGeneratedPlans(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec(`create table t1 (a int, b int, c varchar(10), key(a), key(b))`)
+	tk.MustExec(`create table t2 (a int, b int, c varchar(10), key(a), key(b))`)
+	tk.MustExec(`insert into t1 values (1, 1, 'a'), (2, 2, 'b'), (3, 3, 'c')`)
+	tk.MustExec(`insert into t2 values (1, 1, 'a'), (2, 2, 'b'), (3, 3, 'c')`)
+
+	query := `select * from t1, t2 where t1.a=t2.a and t1.b=1`
+	rows := tk.MustQuery(`explain explore ` + query).Rows()
+	require.NotEmpty(t, rows)
+	planDigests := make(map[string]struct{}, len(rows))
+	for _, row := range rows {
+		require.NotEmpty(t, row[1])   // hint
+		require.NotEmpty(t, row[2])   // plan
+		require.Equal(t, "0", row[5]) // exec_times
+		planDigests[row[3].(string)] = struct{}{}
+		// the generated binding can be created
+		tk.MustExec(fmt.Sprintf("explain select /*+ %s */ * from t1, t2 where t1.a=t2.a and t1.b=1", row[1]))
+	}
+	require.Len(t, planDigests, len(rows))
+	require.Contains(t, fmt.Sprintf("%v", rows), "leading(@`sel_1` `t2`, `t1`)")
+
+	// the plans are executed within the budget
+	tk.MustExec(`set global tidb_opt_explore_trial_budget='1m'`)
+	defer tk.MustExec(`set global tidb_opt_explore_trial_budget=default`)
+	rows = tk.MustQuery(`explain explore ` + query).Rows()
+	require.NotEmpty(t, rows)
+	for _, row := range rows {
+		require.Equal(t, "1", row[5])        // exec_times
+		require.Equal(t, "1", row[7])        // avg_returned_rows
+		require.Contains(t, row[2], "time:") // execution info
+	}
+	// the statements with side effects are not executed
+	for _, q := range []string{
+		`select *, sleep(1) from t1, t2 where t1.a=t2.a and t1.b=1`,
+		`select *, @v := t1.c from t1, t2 where t1.a=t2.a and t1.b=1`,
+	} {
+		rows = tk.MustQuery(`explain explore ` + q).Rows()
+		require.NotEmpty(t, rows)
+		for _, row := range rows {
+			require.Equal(t, "0", row[5]) // exec_times
+		}
+	}
+	tk.MustQuery(`select @v`).Check(testkit.Rows("<nil>"))
+
+	// the SET_VAR hints of the generated plans don't affect the internal session, otherwise the plan generated by
+	// the hint would become the default plan next time.
+	for range 2 {
+		rows = tk.MustQuery(`explain explore select * from t1 where a > 1 and b > 1`).Rows()
+		require.Contains(t, fmt.Sprintf("%v", rows), "set_var(tidb_opt_fix_control = '52869:on')")
+	}
+
+	// no plan is generated for the SQL digest and the non-read-only queries
+	require.Empty(t, tk.MustQuery(`explain explore "23109784b802bcef5398dd81d3b1c5b79200c257c101a5b9f90758206f3d09ed"`).Rows())
+	require.Empty(t, tk.MustQuery(`explain explore select * from t1 where a = 1 for update`).Rows())
+}
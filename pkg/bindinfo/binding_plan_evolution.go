@@ -15,7 +15,29 @@
 package bindinfo
 
 import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
 	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/planner/util/fixcontrol"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/vardef"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/util"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	utilparser "github.com/pingcap/tidb/pkg/util/parser"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	"go.uber.org/zap"
 )
 
 // PlanGenerator is used to generate new Plan Candidates for this specified query.
@@ -24,12 +46,428 @@ type PlanGenerator interface {
 }
 
 // knobBasedPlanGenerator generates new plan candidates via adjusting knobs like cost factors, hints, etc.
+// Every candidate only adjusts one knob, and the candidates whose plans are the same as the default plan or
+// any previous candidate are discarded.
+// If tidb_opt_explore_trial_budget is set, the generated plans are executed within the budget to get their
+// execution info, otherwise only their plans are explained.
 type knobBasedPlanGenerator struct {
+	sPool util.DestroyableSessionPool
 }
 
-func (*knobBasedPlanGenerator) Generate(string, string) (plans []*BindingPlanInfo, err error) {
-	// TODO: implement this
-	return nil, nil
+// maxGeneratedPlans is the max number of plans generated for a query.
+const maxGeneratedPlans = 10
+
+var (
+	// costFactorScales are the scales applied to the relevant cost factors.
+	costFactorScales = []float64{0.1, 10}
+	// fixControlValues are the values tried for the relevant optimizer fixes.
+	fixControlValues = map[uint64][]string{
+		fixcontrol.Fix44855: {"ON", "OFF"},
+		fixcontrol.Fix45132: {"0", "100"},
+		fixcontrol.Fix52869: {"ON", "OFF"},
+	}
+	// joinMethodHints are the hints used to adjust the join method of a table.
+	joinMethodHints = []string{"hash_join", "merge_join", "inl_join"}
+)
+
+func (g *knobBasedPlanGenerator) Generate(defaultSchema, sql string) (plans []*BindingPlanInfo, err error) {
+	sql = strings.TrimSpace(sql)
+	if isSQLDigest(sql) { // the SQL text is required to generate plans
+		return nil, nil
+	}
+	err = callWithSCtx(g.sPool, false, func(sctx sessionctx.Context) (err error) {
+		plans, err = generatePlans(sctx, defaultSchema, sql)
+		return err
+	})
+	return
+}
+
+func generatePlans(sctx sessionctx.Context, defaultSchema, sql string) ([]*BindingPlanInfo, error) {
+	vars := sctx.GetSessionVars()
+	defer func(originalBaseline bool, originalDB string) {
+		vars.UsePlanBaselines = originalBaseline
+		vars.CurrentDB = originalDB
+	}(vars.UsePlanBaselines, vars.CurrentDB)
+	vars.UsePlanBaselines = false
+	vars.CurrentDB = defaultSchema
+	// the variables might be changed by the SET_VAR hints of the last statement executed in this session.
+	restoreSetVarHints(vars)
+
+	p := parser.New()
+	charset, collation := vars.GetCharsetInfo()
+	stmt, err := p.ParseOneStmt(sql, charset, collation)
+	if err != nil {
+		return nil, errors.NewNoStackErrorf("failed to parse the SQL: %v", err)
+	}
+	// only generate plans for read-only queries, since the plans might be executed.
+	sel, ok := stmt.(*ast.SelectStmt)
+	if !ok || sel.LockInfo != nil || sel.SelectIntoOpt != nil || hasParam(stmt) {
+		return nil, nil
+	}
+
+	defaultPlanDigest, err := calculatePlanDigest(sctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+	varNames, fixIDs, err := recordRelevantOptVarsAndFixes(sctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+	planHints := costFactorKnobs(vars, varNames)
+	planHints = append(planHints, fixControlKnobs(vars, fixIDs)...)
+	planHints = append(planHints, hintKnobs(sctx, sel, defaultSchema)...)
+
+	normalizedSQL, sqlDigest := NormalizeStmtForBinding(stmt, defaultSchema, false)
+	db := utilparser.GetDefaultDB(stmt, defaultSchema)
+	planDigests := map[string]struct{}{defaultPlanDigest: {}}
+	plans := make([]*BindingPlanInfo, 0, maxGeneratedPlans)
+	for _, planHint := range planHints {
+		if len(plans) >= maxGeneratedPlans {
+			break
+		}
+		// GenerateBindingSQL removes the hints of the statement, so parse it again.
+		node, err := p.ParseOneStmt(sql, charset, collation)
+		if err != nil {
+			return nil, err
+		}
+		bindSQL := GenerateBindingSQL(node, planHint, defaultSchema)
+		if bindSQL == "" {
+			continue
+		}
+		bindStmt, err := p.ParseOneStmt(bindSQL, charset, collation)
+		if err != nil {
+			bindingLogger().Warn("parse generated binding failed", zap.String("bind_sql", bindSQL), zap.Error(err))
+			continue
+		}
+		planDigest, err := calculatePlanDigest(sctx, bindStmt)
+		if err != nil {
+			bindingLogger().Warn("calculate plan digest of generated binding failed",
+				zap.String("bind_sql", bindSQL), zap.Error(err))
+			continue
+		}
+		if _, ok := planDigests[planDigest]; ok {
+			continue
+		}
+		planDigests[planDigest] = struct{}{}
+
+		binding := &Binding{
+			OriginalSQL: normalizedSQL,
+			Db:          db,
+			BindSQL:     RestoreDBForBinding(bindStmt, defaultSchema),
+			Source:      SourceGenerated,
+			Charset:     charset,
+			Collation:   collation,
+			SQLDigest:   sqlDigest,
+			PlanDigest:  planDigest,
+		}
+		err = prepareHints(sctx, binding)
+		restoreSetVarHints(vars) // the binding is validated by executing it
+		if err != nil {
+			bindingLogger().Warn("prepare hints of generated binding failed",
+				zap.String("bind_sql", binding.BindSQL), zap.Error(err))
+			continue
+		}
+		plans = append(plans, &BindingPlanInfo{Binding: binding})
+	}
+
+	budget := vardef.OptExploreTrialBudget.Load()
+	if hasSideEffects(stmt) {
+		// executing the statement may change the data or the state of the session,
+		// and its execution info is meaningless, so only explain the plans.
+		budget = 0
+	}
+	start := time.Now()
+	for _, plan := range plans {
+		if remaining := budget - time.Since(start); remaining > 0 {
+			err := trialExecute(sctx, plan, remaining)
+			if err == nil {
+				continue
+			}
+			bindingLogger().Warn("trial execution of generated binding failed",
+				zap.String("bind_sql", plan.BindSQL), zap.Duration("timeout", remaining), zap.Error(err))
+		}
+		if plan.Plan, err = explainPlan(sctx, plan.BindSQL); err != nil {
+			return nil, err
+		}
+	}
+	return plans, nil
+}
+
+// explainPlan returns the plan of the binding SQL.
+func explainPlan(sctx sessionctx.Context, bindSQL string) (string, error) {
+	defer restoreSetVarHints(sctx.GetSessionVars())
+	rows, _, err := execRows(sctx, "EXPLAIN FORMAT='brief' "+bindSQL)
+	if err != nil {
+		return "", err
+	}
+	return formatPlanRows(rows), nil
+}
+
+// calculatePlanDigest calculates the plan digest and restores the variables changed by SET_VAR hints.
+func calculatePlanDigest(sctx sessionctx.Context, stmt ast.StmtNode) (string, error) {
+	defer restoreSetVarHints(sctx.GetSessionVars())
+	return CalculatePlanDigest(sctx, stmt)
+}
+
+// recordRelevantOptVarsAndFixes records the relevant optimizer variables and fixes, and restores the variables
+// changed by SET_VAR hints.
+func recordRelevantOptVarsAndFixes(sctx sessionctx.Context, stmt ast.StmtNode) ([]string, []uint64, error) {
+	defer restoreSetVarHints(sctx.GetSessionVars())
+	return RecordRelevantOptVarsAndFixes(sctx, stmt)
+}
+
+// restoreSetVarHints restores the variables changed by SET_VAR hints. The executor only restores them when the
+// next statement starts, which is too late for the optimizer calls here.
+func restoreSetVarHints(vars *variable.SessionVars) {
+	for name, val := range vars.StmtCtx.SetVarHintRestore {
+		if err := vars.SetSystemVar(name, val); err != nil {
+			bindingLogger().Warn("restore the variable after SET_VAR hint failed",
+				zap.String("name", name), zap.String("value", val), zap.Error(err))
+		}
+	}
+	vars.StmtCtx.SetVarHintRestore = nil
+}
+
+// costFactorKnobs generates SET_VAR hints to scale the relevant cost factors.
+func costFactorKnobs(vars *variable.SessionVars, varNames []string) []string {
+	planHints := make([]string, 0, len(varNames)*len(costFactorScales))
+	for _, name := range varNames {
+		if !strings.HasSuffix(name, "_cost_factor") {
+			continue
+		}
+		val, err := vars.GetSessionOrGlobalSystemVar(context.Background(), name)
+		if err != nil {
+			continue
+		}
+		factor, err := strconv.ParseFloat(val, 64)
+		if err != nil || factor <= 0 {
+			continue
+		}
+		for _, scale := range costFactorScales {
+			planHints = append(planHints, fmt.Sprintf("set_var(%s=%s)", name, strconv.FormatFloat(factor*scale, 'f', -1, 64)))
+		}
+	}
+	return planHints
+}
+
+// fixControlKnobs generates SET_VAR hints to change the relevant optimizer fixes.
+func fixControlKnobs(vars *variable.SessionVars, fixIDs []uint64) []string {
+	var planHints []string
+	for _, fixID := range fixIDs {
+		for _, val := range fixControlValues[fixID] {
+			if cur, ok := vars.OptimizerFixControl[fixID]; ok && strings.EqualFold(cur, val) {
+				continue
+			}
+			fixes := maps.Clone(vars.OptimizerFixControl)
+			if fixes == nil {
+				fixes = make(map[uint64]string, 1)
+			}
+			fixes[fixID] = val
+			items := make([]string, 0, len(fixes))
+			for _, id := range slices.Sorted(maps.Keys(fixes)) {
+				items = append(items, fmt.Sprintf("%d:%s", id, fixes[id]))
+			}
+			planHints = append(planHints, fmt.Sprintf("set_var(%s='%s')", vardef.TiDBOptFixControl, strings.Join(items, ",")))
+		}
+	}
+	return planHints
+}
+
+// hintTable is a table in the outermost query block, which can be referenced by hints directly.
+type hintTable struct {
+	schema ast.CIStr
+	name   ast.CIStr
+	alias  ast.CIStr
+}
+
+func collectHintTables(node ast.ResultSetNode, defaultSchema string, tables []hintTable) []hintTable {
+	switch x := node.(type) {
+	case *ast.Join:
+		tables = collectHintTables(x.Left, defaultSchema, tables)
+		if x.Right != nil {
+			tables = collectHintTables(x.Right, defaultSchema, tables)
+		}
+	case *ast.TableSource:
+		if tn, ok := x.Source.(*ast.TableName); ok {
+			t := hintTable{schema: tn.Schema, name: tn.Name, alias: x.AsName}
+			if t.schema.L == "" {
+				t.schema = ast.NewCIStr(defaultSchema)
+			}
+			if t.alias.L == "" {
+				t.alias = tn.Name
+			}
+			tables = append(tables, t)
+		}
+	}
+	return tables
+}
+
+// hintKnobs generates hints to adjust the access path, join method and join order of the tables in the
+// outermost query block.
+func hintKnobs(sctx sessionctx.Context, sel *ast.SelectStmt, defaultSchema string) []string {
+	if sel.From == nil || sel.From.TableRefs == nil {
+		return nil
+	}
+	tables := collectHintTables(sel.From.TableRefs, defaultSchema, nil)
+	is := sctx.GetDomainInfoSchema()
+	var planHints []string
+	for _, t := range tables {
+		tblInfo, err := is.TableInfoByName(t.schema, t.name)
+		if err != nil || tblInfo.IsView() || tblInfo.IsSequence() {
+			continue
+		}
+		// use_index without any index means table scan.
+		planHints = append(planHints, fmt.Sprintf("use_index(%s)", quoteName(t.alias.O)))
+		for _, idx := range tblInfo.Indices {
+			if idx.State != model.StatePublic || idx.Invisible {
+				continue
+			}
+			planHints = append(planHints, fmt.Sprintf("use_index(%s, %s)", quoteName(t.alias.O), quoteName(idx.Name.O)))
+		}
+	}
+	if len(tables) < 2 {
+		return planHints
+	}
+	for i, t := range tables {
+		for _, method := range joinMethodHints {
+			planHints = append(planHints, fmt.Sprintf("%s(%s)", method, quoteName(t.alias.O)))
+		}
+		// join the table first, and keep the order of the others.
+		order := make([]string, 0, len(tables))
+		order = append(order, quoteName(t.alias.O))
+		for j, other := range tables {
+			if j != i {
+				order = append(order, quoteName(other.alias.O))
+			}
+		}
+		planHints = append(planHints, fmt.Sprintf("leading(%s)", strings.Join(order, ", ")))
+	}
+	return planHints
+}
+
+func quoteName(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+// sideEffectFunctions are the functions which have side effects or return
+// different results every time, so the statements using them can't be executed
+// by the trial execution.
+var sideEffectFunctions = map[string]struct{}{
+	ast.NextVal:         {},
+	ast.LastVal:         {},
+	ast.SetVal:          {},
+	ast.GetLock:         {},
+	ast.ReleaseLock:     {},
+	ast.ReleaseAllLocks: {},
+	ast.IsFreeLock:      {},
+	ast.IsUsedLock:      {},
+	ast.Sleep:           {},
+	ast.Benchmark:       {},
+	ast.SetVar:          {},
+	ast.LastInsertId:    {},
+	ast.Rand:            {},
+	ast.RandomBytes:     {},
+	ast.UUID:            {},
+	ast.UUIDShort:       {},
+	ast.Sysdate:         {},
+}
+
+type sideEffectChecker struct {
+	hasSideEffects bool
+}
+
+func (e *sideEffectChecker) Enter(in ast.Node) (ast.Node, bool) {
+	switch x := in.(type) {
+	case *ast.FuncCallExpr:
+		if _, ok := sideEffectFunctions[x.FnName.L]; ok {
+			e.hasSideEffects = true
+		}
+	case *ast.VariableExpr:
+		// the assignment to a user variable like `@a := 1`
+		if x.Value != nil && !x.IsSystem {
+			e.hasSideEffects = true
+		}
+	}
+	return in, e.hasSideEffects
+}
+
+func (*sideEffectChecker) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+// hasSideEffects checks whether the statement calls any function in sideEffectFunctions
+// or assigns any user variable.
+func hasSideEffects(stmt ast.Node) bool {
+	c := new(sideEffectChecker)
+	stmt.Accept(c)
+	return c.hasSideEffects
+}
+
+// trialExecute executes the plan by EXPLAIN ANALYZE within the timeout to fill its execution info.
+func trialExecute(sctx sessionctx.Context, plan *BindingPlanInfo, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(kv.WithInternalSourceType(context.Background(), kv.InternalTxnBindInfo), timeout)
+	defer cancel()
+	defer restoreSetVarHints(sctx.GetSessionVars())
+	start := time.Now()
+	rows, fields, err := sctx.GetRestrictedSQLExecutor().ExecRestrictedSQL(ctx,
+		[]sqlexec.OptionFuncAlias{sqlexec.ExecOptionUseCurSession}, "EXPLAIN ANALYZE FORMAT='brief' "+plan.BindSQL)
+	if err != nil {
+		return err
+	}
+	latency := time.Since(start)
+
+	idCol, actRowsCol := -1, -1
+	for i, field := range fields {
+		switch field.Column.Name.L {
+		case "id":
+			idCol = i
+		case "actrows":
+			actRowsCol = i
+		}
+	}
+	if idCol < 0 || actRowsCol < 0 || len(rows) == 0 {
+		return errors.New("unexpected result of EXPLAIN ANALYZE")
+	}
+	var scanRows, returnedRows float64
+	for i, row := range rows {
+		actRows, err := strconv.ParseFloat(row.GetString(actRowsCol), 64)
+		if err != nil {
+			return err
+		}
+		if i == 0 {
+			returnedRows = actRows
+		}
+		if id := row.GetString(idCol); strings.Contains(id, "Scan") || strings.Contains(id, "Point_Get") {
+			scanRows += actRows
+		}
+	}
+
+	plan.Plan = formatPlanRows(rows)
+	plan.ExecTimes = 1
+	plan.AvgLatency = float64(latency.Nanoseconds())
+	plan.AvgScanRows = scanRows
+	plan.AvgReturnedRows = returnedRows
+	if returnedRows > 0 {
+		plan.LatencyPerReturnRow = plan.AvgLatency / returnedRows
+		plan.ScanRowsPerReturnRow = plan.AvgScanRows / returnedRows
+	}
+	return nil
+}
+
+func formatPlanRows(rows []chunk.Row) string {
+	var sb strings.Builder
+	for i, row := range rows {
+		if i > 0 {
+			sb.WriteByte('\n')
+		}
+		for j := range row.Len() {
+			if j > 0 {
+				sb.WriteByte('\t')
+			}
+			sb.WriteString(row.GetString(j))
+		}
+	}
+	return sb.String()
 }
 
 // PlanPerfPredictor is used to score these plan candidates, returns their scores and gives some explanations.
@@ -69,15 +507,25 @@ func (*ruleBasedPlanPerfPredictor) PerfPredicate(plans []*BindingPlanInfo) (scor
 		}
 	}
 
+	// the generated plans which are not executed are ignored by rule 2 & 3.
+	executed := 0
 	for _, p := range plans {
-		if p.ExecTimes == 0 { // no execution info
+		if p.ExecTimes > 0 {
+			executed++
+		} else if p.Binding == nil || p.Source != SourceGenerated { // no execution info
 			return
 		}
 	}
+	if executed < 2 {
+		return
+	}
 
 	// sort for rule 2 & 3.
 	// only the first binding could be the candidate for rule 2 & 3.
 	sort.Slice(plans, func(i, j int) bool {
+		if (plans[i].ExecTimes > 0) != (plans[j].ExecTimes > 0) {
+			return plans[i].ExecTimes > 0
+		}
 		if plans[i].ScanRowsPerReturnRow == plans[j].ScanRowsPerReturnRow {
 			return plans[i].AvgLatency < plans[j].AvgLatency &&
 				plans[i].AvgScanRows < plans[j].AvgScanRows &&
@@ -94,14 +542,14 @@ func (*ruleBasedPlanPerfPredictor) PerfPredicate(plans []*BindingPlanInfo) (scor
 	}
 
 	// rule 3
-	for i := 1; i < len(plans); i++ {
+	for i := 1; i < executed; i++ {
 		hitRule3 := plans[0].AvgLatency <= plans[i].AvgLatency/2 &&
 			plans[0].AvgScanRows <= plans[i].AvgScanRows/2 &&
 			plans[0].LatencyPerReturnRow <= plans[i].LatencyPerReturnRow/2
 		if !hitRule3 {
 			break
 		}
-		if i == len(plans)-1 { // the last one
+		if i == executed-1 { // the last one
 			scores[0] = 1
 			explanations[0] = "Plan's latency, scan_rows and latency_per_returned_row are 50% better than others'"
 			return
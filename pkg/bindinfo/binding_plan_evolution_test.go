@@ -17,6 +17,7 @@ package bindinfo
 import (
 	"testing"
 
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/stretchr/testify/require"
 )
 
@@ -82,4 +83,31 @@ func TestRuleBasedPlanPerfPredictor(t *testing.T) {
 	p1.AvgLatency = 60
 	scores, _, _ = p.PerfPredicate([]*BindingPlanInfo{p1, p2})
 	require.Equal(t, scores, []float64{0, 0}) // no recommendation
+
+	// the generated plans without execution info are ignored by rule 2 & 3
+	p1.AvgLatency = 30
+	p3 := &BindingPlanInfo{Binding: &Binding{Source: SourceGenerated}, Plan: nonPointPlan}
+	scores, explanations, _ = p.PerfPredicate([]*BindingPlanInfo{p3, p2, p1})
+	require.Equal(t, scores, []float64{1, 0, 0})
+	require.Equal(t, explanations, []string{"Plan's latency, scan_rows and latency_per_returned_row are 50% better than others'", "", ""})
+	p3.Source = SourceManual
+	scores, _, _ = p.PerfPredicate([]*BindingPlanInfo{p3, p2, p1})
+	require.Equal(t, scores, []float64{0, 0, 0}) // no execution info
+}
+
+func TestPlanGeneratorKnobs(t *testing.T) {
+	vars := variable.NewSessionVars(nil)
+	require.NoError(t, vars.SetSystemVar("tidb_opt_hash_join_cost_factor", "2"))
+	require.Equal(t, []string{
+		"set_var(tidb_opt_hash_join_cost_factor=0.2)",
+		"set_var(tidb_opt_hash_join_cost_factor=20)",
+	}, costFactorKnobs(vars, []string{"tidb_opt_hash_join_cost_factor", "tidb_opt_ordering_index_selectivity_ratio"}))
+
+	vars.OptimizerFixControl = map[uint64]string{44855: "ON", 12345: "1"}
+	require.Equal(t, []string{
+		"set_var(tidb_opt_fix_control='12345:1,44855:OFF')",
+		"set_var(tidb_opt_fix_control='12345:1,44855:ON,45132:0')",
+		"set_var(tidb_opt_fix_control='12345:1,44855:ON,45132:100')",
+	}, fixControlKnobs(vars, []uint64{44855, 45132, 99999}))
+	require.Equal(t, map[uint64]string{44855: "ON", 12345: "1"}, vars.OptimizerFixControl)
 }
@@ -1196,11 +1196,12 @@ func checkColumnDefaultValue(ctx exprctx.BuildContext, col *table.Column, value
 	}
 	if value != nil && (col.GetType() == mysql.TypeJSON ||
 		col.GetType() == mysql.TypeTinyBlob || col.GetType() == mysql.TypeMediumBlob ||
-		col.GetType() == mysql.TypeLongBlob || col.GetType() == mysql.TypeBlob) {
+		col.GetType() == mysql.TypeLongBlob || col.GetType() == mysql.TypeBlob ||
+		col.GetType() == mysql.TypeGeometry) {
 		// In non-strict SQL mode.
 		if !ctx.GetEvalCtx().SQLMode().HasStrictMode() && value == "" {
-			if col.GetType() == mysql.TypeBlob || col.GetType() == mysql.TypeLongBlob {
-				// The TEXT/BLOB default value can be ignored.
+			if col.GetType() == mysql.TypeBlob || col.GetType() == mysql.TypeLongBlob || col.GetType() == mysql.TypeGeometry {
+				// The TEXT/BLOB/GEOMETRY default value can be ignored.
 				hasDefaultValue = false
 			}
 			// In non-strict SQL mode, if the column type is json and the default value is null, it is initialized to an empty array.
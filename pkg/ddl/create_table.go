@@ -544,9 +544,6 @@ func checkColumnarIndexIfNeedTiFlashReplica(store kv.Storage, dbName ast.CIStr,
 	if store == nil {
 		return errors.New("the store is nil")
 	}
-	if err := isTableTiFlashSupported(dbName, tblInfo); err != nil {
-		return errors.Trace(err)
-	}
 
 	if tblInfo.TiFlashReplica == nil || tblInfo.TiFlashReplica.Count == 0 {
 		replicas, err := infoschema.GetTiFlashStoreCount(store)
@@ -554,8 +551,15 @@ func checkColumnarIndexIfNeedTiFlashReplica(store kv.Storage, dbName ast.CIStr,
 			return errors.Trace(err)
 		}
 		if replicas == 0 {
+			// Without TiFlash, FULLTEXT indexes are stored in TiKV.
+			if storeFullTextIndexesInTiKV(tblInfo) {
+				return nil
+			}
 			return errors.Trace(dbterror.ErrUnsupportedAddColumnarIndex.FastGenByArgs("unsupported TiFlash store count is 0"))
 		}
+		if err := isTableTiFlashSupported(dbName, tblInfo); err != nil {
+			return errors.Trace(err)
+		}
 
 		// Always try to set to 1 as the default replica count.
 		defaultReplicas := uint64(1)
@@ -563,11 +567,29 @@ func checkColumnarIndexIfNeedTiFlashReplica(store kv.Storage, dbName ast.CIStr,
 			Count:          defaultReplicas,
 			LocationLabels: make([]string, 0),
 		}
+	} else if err := isTableTiFlashSupported(dbName, tblInfo); err != nil {
+		return errors.Trace(err)
 	}
 
 	return errors.Trace(checkTableTypeForColumnarIndex(tblInfo))
 }
 
+// storeFullTextIndexesInTiKV turns the FULLTEXT indexes of the table into multi-valued indexes
+// stored in TiKV. It returns false and changes nothing if the table has other kinds of columnar indexes.
+func storeFullTextIndexesInTiKV(tblInfo *model.TableInfo) bool {
+	for _, idx := range tblInfo.Indices {
+		if idx.IsColumnarIndex() && idx.FullTextInfo == nil {
+			return false
+		}
+	}
+	for _, idx := range tblInfo.Indices {
+		if idx.FullTextInfo != nil {
+			idx.MVIndex = true
+		}
+	}
+	return true
+}
+
 // checkTableInfoValidExtra is like checkTableInfoValid, but also assumes the
 // table info comes from untrusted source and performs further checks such as
 // name length and column count.
@@ -41,12 +41,14 @@ import (
 	"github.com/pingcap/tidb/pkg/infoschema"
 	infoschemacontext "github.com/pingcap/tidb/pkg/infoschema/context"
 	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/materializedview"
 	"github.com/pingcap/tidb/pkg/meta"
 	"github.com/pingcap/tidb/pkg/meta/autoid"
 	"github.com/pingcap/tidb/pkg/meta/metabuild"
 	"github.com/pingcap/tidb/pkg/meta/model"
 	"github.com/pingcap/tidb/pkg/metrics"
 	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/auth"
 	"github.com/pingcap/tidb/pkg/parser/charset"
 	"github.com/pingcap/tidb/pkg/parser/format"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
@@ -133,6 +135,10 @@ type Executor interface {
 	AddResourceGroup(ctx sessionctx.Context, stmt *ast.CreateResourceGroupStmt) error
 	AlterResourceGroup(ctx sessionctx.Context, stmt *ast.AlterResourceGroupStmt) error
 	DropResourceGroup(ctx sessionctx.Context, stmt *ast.DropResourceGroupStmt) error
+	CreateTrigger(ctx sessionctx.Context, stmt *ast.CreateTriggerStmt) error
+	DropTrigger(ctx sessionctx.Context, stmt *ast.DropTriggerStmt) error
+	CreateMaterializedView(ctx sessionctx.Context, stmt *ast.CreateMaterializedViewStmt) error
+	DropMaterializedView(ctx sessionctx.Context, stmt *ast.DropMaterializedViewStmt) error
 	FlashbackCluster(ctx sessionctx.Context, flashbackTS uint64) error
 	// RefreshMeta can only be called by BR during the log restore phase.
 	RefreshMeta(ctx sessionctx.Context, args *model.RefreshMetaArgs) error
@@ -1717,6 +1723,9 @@ func (e *executor) AlterTable(ctx context.Context, sctx sessionctx.Context, stmt
 	if tb.Meta().IsView() || tb.Meta().IsSequence() {
 		return dbterror.ErrWrongObject.GenWithStackByArgs(ident.Schema, ident.Name, "BASE TABLE")
 	}
+	if err = checkMaterializedViewOpt(tb.Meta(), "Alter Table"); err != nil {
+		return err
+	}
 	if tb.Meta().TableCacheStatusType != model.TableCacheStatusDisable {
 		if len(validSpecs) != 1 {
 			return dbterror.ErrOptOnCacheTable.GenWithStackByArgs("Alter Table")
@@ -3830,10 +3839,6 @@ func (e *executor) AlterTableAddStatistics(ctx sessionctx.Context, ident ast.Ide
 	if !ctx.GetSessionVars().EnableExtendedStats {
 		return errors.New("Extended statistics feature is not generally available now, and tidb_enable_extended_stats is OFF")
 	}
-	// Not support Cardinality and Dependency statistics type for now.
-	if stats.StatsType == ast.StatsTypeCardinality || stats.StatsType == ast.StatsTypeDependency {
-		return errors.New("Cardinality and Dependency statistics types are not supported now")
-	}
 	_, tbl, err := e.getSchemaAndTableByIdent(ident)
 	if err != nil {
 		return err
@@ -3863,10 +3868,9 @@ func (e *executor) AlterTableAddStatistics(ctx sessionctx.Context, ident ast.Ide
 	if len(colIDs) != 2 && (stats.StatsType == ast.StatsTypeCorrelation || stats.StatsType == ast.StatsTypeDependency) {
 		return errors.New("Only support Correlation and Dependency statistics types on 2 columns")
 	}
-	if len(colIDs) < 1 && stats.StatsType == ast.StatsTypeCardinality {
+	if len(colIDs) < 2 && stats.StatsType == ast.StatsTypeCardinality {
 		return errors.New("Only support Cardinality statistics type on at least 2 columns")
 	}
-	// TODO: check whether covering index exists for cardinality / dependency types.
 
 	// Call utilities of statistics.Handle to modify system tables instead of doing DML directly,
 	// because locking in Handle can guarantee the correctness of `version` in system tables.
@@ -4190,6 +4194,9 @@ func (e *executor) dropTableObject(
 			if tableInfo.Meta().TableCacheStatusType != model.TableCacheStatusDisable {
 				return dbterror.ErrOptOnCacheTable.GenWithStackByArgs("Drop Table")
 			}
+			if err = checkMaterializedViewOpt(tableInfo.Meta(), "Drop Table"); err != nil {
+				return err
+			}
 		case viewObject:
 			if !tableInfo.Meta().IsView() {
 				return dbterror.ErrWrongObject.GenWithStackByArgs(fullti.Schema, fullti.Name, "VIEW")
@@ -4275,6 +4282,9 @@ func (e *executor) TruncateTable(ctx sessionctx.Context, ti ast.Ident) error {
 	if tblInfo.TableCacheStatusType != model.TableCacheStatusDisable {
 		return dbterror.ErrOptOnCacheTable.GenWithStackByArgs("Truncate Table")
 	}
+	if err = checkMaterializedViewOpt(tblInfo, "Truncate Table"); err != nil {
+		return err
+	}
 	fkCheck := ctx.GetSessionVars().ForeignKeyChecks
 	referredFK := checkTableHasForeignKeyReferred(e.infoCache.GetLatest(), ti.Schema.L, ti.Name.L, []ast.Ident{{Name: ti.Name, Schema: ti.Schema}}, fkCheck)
 	if referredFK != nil {
@@ -4349,6 +4359,9 @@ func (e *executor) renameTable(ctx sessionctx.Context, oldIdent, newIdent ast.Id
 		if tbl.Meta().TableCacheStatusType != model.TableCacheStatusDisable {
 			return errors.Trace(dbterror.ErrOptOnCacheTable.GenWithStackByArgs("Rename Table"))
 		}
+		if err = checkMaterializedViewOpt(tbl.Meta(), "Rename Table"); err != nil {
+			return err
+		}
 		if err = dbutil.CheckTableModeIsNormal(tbl.Meta().Name, tbl.Meta().Mode); err != nil {
 			return err
 		}
@@ -4399,6 +4412,9 @@ func (e *executor) renameTables(ctx sessionctx.Context, oldIdents, newIdents []a
 			if t.Meta().TableCacheStatusType != model.TableCacheStatusDisable {
 				return errors.Trace(dbterror.ErrOptOnCacheTable.GenWithStackByArgs("Rename Tables"))
 			}
+			if err = checkMaterializedViewOpt(t.Meta(), "Rename Tables"); err != nil {
+				return err
+			}
 			if err = dbutil.CheckTableModeIsNormal(t.Meta().Name, t.Meta().Mode); err != nil {
 				return err
 			}
@@ -4752,6 +4768,9 @@ func (e *executor) createColumnarIndex(ctx sessionctx.Context, ti ast.Ident, ind
 	}
 
 	tblInfo := t.Meta()
+	if indexOption.Tp == ast.IndexTypeFulltext && (tblInfo.TiFlashReplica == nil || tblInfo.TiFlashReplica.Count == 0) {
+		return e.createRowStoreFullTextIndex(ctx, schema, t, indexName, indexPartSpecifications, indexOption, ifNotExists)
+	}
 	if err := checkTableTypeForColumnarIndex(tblInfo); err != nil {
 		return errors.Trace(err)
 	}
@@ -4837,6 +4856,59 @@ func (e *executor) createColumnarIndex(ctx sessionctx.Context, ti ast.Ident, ind
 	return errors.Trace(err)
 }
 
+// createRowStoreFullTextIndex creates a FULLTEXT index stored in TiKV for the table without columnar replica.
+// Like a general index, it's backfilled by the add index job.
+func (e *executor) createRowStoreFullTextIndex(ctx sessionctx.Context, schema *model.DBInfo, t table.Table, indexName ast.CIStr,
+	indexPartSpecifications []*ast.IndexPartSpecification, indexOption *ast.IndexOption, ifNotExists bool) error {
+	tblInfo := t.Meta()
+	if tblInfo.TableCacheStatusType != model.TableCacheStatusDisable {
+		return errors.Trace(dbterror.ErrOptOnCacheTable.GenWithStackByArgs("Create Index"))
+	}
+
+	metaBuildCtx := NewMetaBuildContextWithSctx(ctx)
+	indexName, _, err := checkIndexNameAndColumns(metaBuildCtx, t, indexName, indexPartSpecifications, model.ColumnarIndexTypeFulltext, ifNotExists)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err = buildFullTextInfoWithCheck(indexPartSpecifications, indexOption, tblInfo); err != nil {
+		return errors.Trace(err)
+	}
+	if _, _, err = buildIndexColumns(metaBuildCtx, tblInfo.Columns, indexPartSpecifications, model.ColumnarIndexTypeFulltext); err != nil {
+		return errors.Trace(err)
+	}
+
+	sessionVars := ctx.GetSessionVars()
+	if _, err = validateCommentLength(sessionVars.StmtCtx.ErrCtx(), sessionVars.SQLMode, indexName.String(), &indexOption.Comment, dbterror.ErrTooLongIndexComment); err != nil {
+		return errors.Trace(err)
+	}
+
+	job := buildAddIndexJobWithoutTypeAndArgs(ctx, schema, t)
+	job.Version = model.GetJobVerInUse()
+	job.Type = model.ActionAddIndex
+	job.CDCWriteSource = sessionVars.CDCWriteSource
+	if err = initJobReorgMetaFromVariables(job, ctx); err != nil {
+		return errors.Trace(err)
+	}
+
+	args := &model.ModifyIndexArgs{
+		IndexArgs: []*model.IndexArg{{
+			IndexName:               indexName,
+			IndexPartSpecifications: indexPartSpecifications,
+			IndexOption:             indexOption,
+			ColumnarIndexType:       model.ColumnarIndexTypeFulltext,
+		}},
+		OpType: model.OpAddIndex,
+	}
+
+	err = e.doDDLJob2(ctx, job, args)
+	// key exists, but if_not_exists flags is true, so we ignore this error.
+	if dbterror.ErrDupKeyName.Equal(err) && ifNotExists {
+		ctx.GetSessionVars().StmtCtx.AppendNote(err)
+		return nil
+	}
+	return errors.Trace(err)
+}
+
 func buildAddIndexJobWithoutTypeAndArgs(ctx sessionctx.Context, schema *model.DBInfo, t table.Table) *model.Job {
 	charset, collate := ctx.GetSessionVars().GetCharsetInfo()
 	job := &model.Job{
@@ -7080,3 +7152,332 @@ func (e *executor) RefreshMeta(sctx sessionctx.Context, args *model.RefreshMetaA
 	err := e.doDDLJob2(sctx, job, args)
 	return errors.Trace(err)
 }
+
+// CreateTrigger creates a row trigger on the table. The body of the trigger is
+// expected to be checked by the caller.
+func (e *executor) CreateTrigger(ctx sessionctx.Context, stmt *ast.CreateTriggerStmt) error {
+	if stmt.TriggerName.Schema.L != stmt.Table.Schema.L {
+		return dbterror.ErrTrgInWrongSchema.GenWithStackByArgs()
+	}
+	is := e.infoCache.GetLatest()
+	schema, ok := is.SchemaByName(stmt.Table.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(stmt.Table.Schema)
+	}
+	if trigger, _ := findTriggerInSchema(e.ctx, is, schema.Name, stmt.TriggerName.Name); trigger != nil {
+		err := dbterror.ErrTrgAlreadyExists.GenWithStackByArgs()
+		if stmt.IfNotExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+	t, err := is.TableByName(e.ctx, stmt.Table.Schema, stmt.Table.Name)
+	if err != nil {
+		return infoschema.ErrTableNotExists.GenWithStackByArgs(stmt.Table.Schema, stmt.Table.Name)
+	}
+	tblInfo := t.Meta()
+	if tblInfo.IsView() || tblInfo.IsSequence() || tblInfo.TempTableType != model.TempTableNone {
+		return dbterror.ErrTrgOnViewOrTempTable.GenWithStackByArgs(stmt.Table.Name.O)
+	}
+
+	definition := stmt.Text()
+	if definition == "" {
+		var sb strings.Builder
+		if err := stmt.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+			return errors.Trace(err)
+		}
+		definition = sb.String()
+	}
+	sessVars := ctx.GetSessionVars()
+	charsetClient, _ := sessVars.GetSystemVar(vardef.CharacterSetClient)
+	collationConnection, _ := sessVars.GetSystemVar(vardef.CollationConnection)
+	trigger := &model.TriggerInfo{
+		Name:                stmt.TriggerName.Name,
+		Timing:              stmt.Timing,
+		Event:               stmt.Event,
+		Definition:          definition,
+		SQLMode:             sessVars.SQLMode,
+		CharsetClient:       charsetClient,
+		CollationConnection: collationConnection,
+	}
+	if sessVars.User != nil {
+		trigger.Definer = &auth.UserIdentity{Username: sessVars.User.AuthUsername, Hostname: sessVars.User.AuthHostname}
+	}
+
+	job := &model.Job{
+		Version:        model.GetJobVerInUse(),
+		SchemaID:       schema.ID,
+		TableID:        tblInfo.ID,
+		SchemaName:     schema.Name.L,
+		TableName:      tblInfo.Name.L,
+		Type:           model.ActionCreateTrigger,
+		BinlogInfo:     &model.HistoryInfo{},
+		CDCWriteSource: sessVars.CDCWriteSource,
+		SQLMode:        sessVars.SQLMode,
+	}
+	args := &model.CreateTriggerArgs{
+		Trigger: trigger,
+	}
+	err = e.doDDLJob2(ctx, job, args)
+	if err != nil && stmt.IfNotExists && dbterror.ErrTrgAlreadyExists.Equal(err) {
+		ctx.GetSessionVars().StmtCtx.AppendNote(err)
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// DropTrigger drops a row trigger.
+func (e *executor) DropTrigger(ctx sessionctx.Context, stmt *ast.DropTriggerStmt) error {
+	is := e.infoCache.GetLatest()
+	schema, ok := is.SchemaByName(stmt.TriggerName.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(stmt.TriggerName.Schema)
+	}
+	_, tblInfo := findTriggerInSchema(e.ctx, is, schema.Name, stmt.TriggerName.Name)
+	if tblInfo == nil {
+		err := dbterror.ErrTrgDoesNotExist.GenWithStackByArgs()
+		if stmt.IfExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+
+	job := &model.Job{
+		Version:        model.GetJobVerInUse(),
+		SchemaID:       schema.ID,
+		TableID:        tblInfo.ID,
+		SchemaName:     schema.Name.L,
+		TableName:      tblInfo.Name.L,
+		Type:           model.ActionDropTrigger,
+		BinlogInfo:     &model.HistoryInfo{},
+		CDCWriteSource: ctx.GetSessionVars().CDCWriteSource,
+		SQLMode:        ctx.GetSessionVars().SQLMode,
+	}
+	args := &model.DropTriggerArgs{
+		TriggerName: stmt.TriggerName.Name,
+	}
+	err := e.doDDLJob2(ctx, job, args)
+	if err != nil && stmt.IfExists && dbterror.ErrTrgDoesNotExist.Equal(err) {
+		ctx.GetSessionVars().StmtCtx.AppendNote(err)
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// findTriggerInSchema finds the trigger and its table by the trigger name.
+// The names of triggers are unique in a schema.
+func findTriggerInSchema(ctx context.Context, is infoschema.InfoSchema, schema, name ast.CIStr) (*model.TriggerInfo, *model.TableInfo) {
+	tblInfos, err := is.SchemaTableInfos(ctx, schema)
+	if err != nil {
+		return nil, nil
+	}
+	for _, tblInfo := range tblInfos {
+		if trigger := tblInfo.FindTrigger(name.L); trigger != nil {
+			return trigger, tblInfo
+		}
+	}
+	return nil, nil
+}
+
+// CreateMaterializedView creates a materialized view, the columns of the view
+// are expected to be filled by the caller. If the view is an aggregate query
+// of a single table in the same schema, the view is linked to the table, and
+// a log table is created to record the row changes of the table if the view
+// can be fast refreshed.
+func (e *executor) CreateMaterializedView(ctx sessionctx.Context, stmt *ast.CreateMaterializedViewStmt) error {
+	ident := ast.Ident{Schema: stmt.ViewName.Schema, Name: stmt.ViewName.Name}
+	is := e.infoCache.GetLatest()
+	schema, ok := is.SchemaByName(ident.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(ident.Schema)
+	}
+	if is.TableExists(ident.Schema, ident.Name) {
+		err := infoschema.ErrTableExists.GenWithStackByArgs(ident)
+		if stmt.IfNotExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+	if len(stmt.Cols) != len(stmt.ColTypes) {
+		return dbterror.ErrViewWrongList
+	}
+
+	// Always Use `format.RestoreNameBackQuotes` to restore `SELECT` statement despite the `ANSI_QUOTES` SQL Mode is enabled or not.
+	restoreFlag := format.RestoreStringSingleQuotes | format.RestoreKeyWordUppercase | format.RestoreNameBackQuotes
+	var sb strings.Builder
+	if err := stmt.Select.Restore(format.NewRestoreCtx(restoreFlag, &sb)); err != nil {
+		return errors.Trace(err)
+	}
+
+	cols := make([]*table.Column, 0, len(stmt.Cols))
+	for i, name := range stmt.Cols {
+		cols = append(cols, buildMaterializedViewColumn(name, stmt.ColTypes[i], i))
+	}
+	metaBuildCtx := NewMetaBuildContextWithSctx(ctx)
+	tbInfo, err := BuildTableInfo(metaBuildCtx, ident.Name, cols, nil, schema.Charset, schema.Collate)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tbInfo.MaterializedView = &model.MaterializedViewInfo{Definition: sb.String()}
+	if err = checkTableInfoValidExtra(ctx.GetSessionVars().StmtCtx.ErrCtx(), ctx.GetStore(), schema.Name, tbInfo); err != nil {
+		return err
+	}
+
+	involvingSchemas := []model.InvolvingSchemaInfo{{Database: schema.Name.L, Table: tbInfo.Name.L}}
+	var logInfo *model.TableInfo
+	if d := materializedview.Analyze(stmt.Select, stmt.Cols); d != nil && d.Table.Schema.L == schema.Name.L {
+		base, err := is.TableByName(e.ctx, d.Table.Schema, d.Table.Name)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		baseInfo := base.Meta()
+		if baseInfo.IsBaseTable() && baseInfo.TempTableType == model.TempTableNone &&
+			!baseInfo.IsMaterializedView() && !baseInfo.IsMaterializedViewLog() {
+			tbInfo.MaterializedView.BaseTableID = baseInfo.ID
+			involvingSchemas = append(involvingSchemas, model.InvolvingSchemaInfo{Database: schema.Name.L, Table: baseInfo.Name.L})
+			if d.CheckFastRefresh() == nil {
+				logInfo, err = buildMaterializedViewLogInfo(metaBuildCtx, tbInfo.Name, baseInfo)
+				if err != nil {
+					return errors.Trace(err)
+				}
+				if is.TableExists(schema.Name, logInfo.Name) {
+					return infoschema.ErrTableExists.GenWithStackByArgs(ast.Ident{Schema: schema.Name, Name: logInfo.Name})
+				}
+				if err = checkTableInfoValidExtra(ctx.GetSessionVars().StmtCtx.ErrCtx(), ctx.GetStore(), schema.Name, logInfo); err != nil {
+					return err
+				}
+				involvingSchemas = append(involvingSchemas, model.InvolvingSchemaInfo{Database: schema.Name.L, Table: logInfo.Name.L})
+			}
+		}
+	}
+
+	job := &model.Job{
+		Version:             model.GetJobVerInUse(),
+		SchemaID:            schema.ID,
+		SchemaName:          schema.Name.L,
+		TableName:           tbInfo.Name.L,
+		Type:                model.ActionCreateMaterializedView,
+		BinlogInfo:          &model.HistoryInfo{},
+		CDCWriteSource:      ctx.GetSessionVars().CDCWriteSource,
+		InvolvingSchemaInfo: involvingSchemas,
+		SQLMode:             ctx.GetSessionVars().SQLMode,
+	}
+	args := &model.CreateMaterializedViewArgs{
+		TableInfo:    tbInfo,
+		LogTableInfo: logInfo,
+	}
+	err = e.doDDLJob2(ctx, job, args)
+	if err != nil && stmt.IfNotExists && infoschema.ErrTableExists.Equal(err) {
+		ctx.GetSessionVars().StmtCtx.AppendNote(err)
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// buildMaterializedViewColumn builds a column of a materialized view from the
+// type of the field in the SELECT list.
+func buildMaterializedViewColumn(name ast.CIStr, ft *types.FieldType, offset int) *table.Column {
+	tp := ft.Clone()
+	if tp.GetType() == mysql.TypeNull {
+		tp = types.NewFieldType(mysql.TypeString)
+		tp.SetFlen(0)
+		tp.SetCharset(charset.CharsetBin)
+		tp.SetCollate(charset.CollationBin)
+		tp.AddFlag(mysql.BinaryFlag)
+	}
+	tp.DelFlag(mysql.PriKeyFlag | mysql.UniqueKeyFlag | mysql.MultipleKeyFlag |
+		mysql.AutoIncrementFlag | mysql.OnUpdateNowFlag)
+	return table.ToColumn(&model.ColumnInfo{
+		Name:      name,
+		Offset:    offset,
+		State:     model.StatePublic,
+		FieldType: *tp,
+	})
+}
+
+// buildMaterializedViewLogInfo builds the log table of a materialized view. It
+// has the visible columns of the base table and an extra column for the kind
+// of the row changes.
+func buildMaterializedViewLogInfo(ctx *metabuild.Context, view ast.CIStr, baseInfo *model.TableInfo) (*model.TableInfo, error) {
+	cols := make([]*table.Column, 0, len(baseInfo.Columns)+1)
+	for _, col := range baseInfo.Cols() {
+		if col.Hidden {
+			continue
+		}
+		tp := col.FieldType.Clone()
+		tp.DelFlag(mysql.PriKeyFlag | mysql.UniqueKeyFlag | mysql.MultipleKeyFlag |
+			mysql.AutoIncrementFlag | mysql.OnUpdateNowFlag)
+		cols = append(cols, table.ToColumn(&model.ColumnInfo{
+			Name:      col.Name,
+			Offset:    len(cols),
+			State:     model.StatePublic,
+			FieldType: *tp,
+		}))
+	}
+	opType := types.NewFieldType(mysql.TypeTiny)
+	opType.AddFlag(mysql.NotNullFlag)
+	cols = append(cols, table.ToColumn(&model.ColumnInfo{
+		Name:      ast.NewCIStr(materializedview.LogOpColumn),
+		Offset:    len(cols),
+		State:     model.StatePublic,
+		FieldType: *opType,
+	}))
+	name := ast.NewCIStr(materializedview.LogTableName(view.O))
+	return BuildTableInfo(ctx, name, cols, nil, baseInfo.Charset, baseInfo.Collate)
+}
+
+// DropMaterializedView drops a materialized view and its log table.
+func (e *executor) DropMaterializedView(ctx sessionctx.Context, stmt *ast.DropMaterializedViewStmt) error {
+	ident := ast.Ident{Schema: stmt.ViewName.Schema, Name: stmt.ViewName.Name}
+	is := e.infoCache.GetLatest()
+	schema, ok := is.SchemaByName(ident.Schema)
+	if !ok {
+		err := infoschema.ErrTableDropExists.FastGenByArgs(ident.String())
+		if stmt.IfExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+	tbl, err := is.TableByName(e.ctx, ident.Schema, ident.Name)
+	if err != nil {
+		err = infoschema.ErrTableDropExists.FastGenByArgs(ident.String())
+		if stmt.IfExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+	if !tbl.Meta().IsMaterializedView() {
+		return dbterror.ErrWrongObject.GenWithStackByArgs(ident.Schema, ident.Name, "MATERIALIZED VIEW")
+	}
+
+	tblInfo := tbl.Meta()
+	involvingSchemas := []model.InvolvingSchemaInfo{{Database: schema.Name.L, Table: tblInfo.Name.L}}
+	for _, id := range []int64{tblInfo.MaterializedView.BaseTableID, tblInfo.MaterializedView.LogTableID} {
+		if t, ok := is.TableByID(e.ctx, id); ok {
+			involvingSchemas = append(involvingSchemas, model.InvolvingSchemaInfo{Database: schema.Name.L, Table: t.Meta().Name.L})
+		}
+	}
+	job := &model.Job{
+		Version:             model.GetJobVerInUse(),
+		SchemaID:            schema.ID,
+		TableID:             tblInfo.ID,
+		SchemaName:          schema.Name.L,
+		TableName:           tblInfo.Name.L,
+		Type:                model.ActionDropMaterializedView,
+		BinlogInfo:          &model.HistoryInfo{},
+		CDCWriteSource:      ctx.GetSessionVars().CDCWriteSource,
+		InvolvingSchemaInfo: involvingSchemas,
+		SQLMode:             ctx.GetSessionVars().SQLMode,
+	}
+	err = e.doDDLJob2(ctx, job, &model.DropMaterializedViewArgs{})
+	if err != nil && stmt.IfExists && (infoschema.ErrTableNotExists.Equal(err) || infoschema.ErrDatabaseNotExists.Equal(err)) {
+		ctx.GetSessionVars().StmtCtx.AppendNote(infoschema.ErrTableDropExists.FastGenByArgs(ident.String()))
+		return nil
+	}
+	return errors.Trace(err)
+}
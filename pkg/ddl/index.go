@@ -236,8 +236,9 @@ func checkIndexColumn(col *model.ColumnInfo, indexColumnLen int, suppressTooLong
 		return dbterror.ErrUnsupportedAddColumnarIndex.FastGen("only VECTOR INDEX can be added to vector column")
 	}
 
-	// Length must be specified and non-zero for BLOB and TEXT column indexes.
-	if types.IsTypeBlob(col.FieldType.GetType()) {
+	// Length must be specified and non-zero for BLOB, TEXT and GEOMETRY column indexes.
+	isGeometry := col.FieldType.GetType() == mysql.TypeGeometry
+	if types.IsTypeBlob(col.FieldType.GetType()) || isGeometry {
 		if indexColumnLen == types.UnspecifiedLength {
 			if col.Hidden {
 				return dbterror.ErrFunctionalIndexOnBlob
@@ -250,7 +251,7 @@ func checkIndexColumn(col *model.ColumnInfo, indexColumnLen int, suppressTooLong
 	}
 
 	// Length can only be specified for specifiable types.
-	if indexColumnLen != types.UnspecifiedLength && !types.IsTypePrefixable(col.FieldType.GetType()) {
+	if indexColumnLen != types.UnspecifiedLength && !types.IsTypePrefixable(col.FieldType.GetType()) && !isGeometry {
 		return errors.Trace(dbterror.ErrIncorrectPrefixKey)
 	}
 
@@ -302,7 +303,7 @@ func getIndexColumnLength(col *model.ColumnInfo, colLen int, columnarIndexType m
 	switch col.GetType() {
 	case mysql.TypeBit:
 		return (length + 7) >> 3, nil
-	case mysql.TypeVarchar, mysql.TypeString, mysql.TypeVarString, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeBlob, mysql.TypeLongBlob:
+	case mysql.TypeVarchar, mysql.TypeString, mysql.TypeVarString, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeBlob, mysql.TypeLongBlob, mysql.TypeGeometry:
 		// Different charsets occupy different numbers of bytes on each character.
 		desc, err := charset.GetCharsetInfo(col.GetCharset())
 		if err != nil {
@@ -799,6 +800,10 @@ func checkAndBuildIndexInfo(
 			return nil, err
 		}
 	}
+	if columnarIndexType == model.ColumnarIndexTypeFulltext && !args.IsColumnar {
+		// The FULLTEXT index is stored in TiKV, every token of the column has an index entry.
+		indexInfo.MVIndex = true
+	}
 	indexInfo.ID = AllocateIndexID(tblInfo)
 	tblInfo.Indices = append(tblInfo.Indices, indexInfo)
 	if err = checkTooManyIndexes(tblInfo.Indices); err != nil {
@@ -1073,7 +1078,7 @@ func (w *worker) onCreateIndex(jobCtx *jobContext, job *model.Job, isPK bool) (v
 
 	allIndexInfos := make([]*model.IndexInfo, 0, len(args.IndexArgs))
 	for _, arg := range args.IndexArgs {
-		indexInfo, err := checkAndBuildIndexInfo(job, tblInfo, model.ColumnarIndexTypeNA, job.Type == model.ActionAddPrimaryKey, arg)
+		indexInfo, err := checkAndBuildIndexInfo(job, tblInfo, arg.GetColumnarIndexType(), job.Type == model.ActionAddPrimaryKey, arg)
 		if err != nil {
 			job.State = model.JobStateCancelled
 			return ver, errors.Trace(err)
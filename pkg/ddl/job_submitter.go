@@ -423,6 +423,12 @@ func getRequiredGIDCount(jobWs []*JobWrapper) int {
 			for _, tblArgs := range args.Tables {
 				count += idCountForTable(tblArgs.TableInfo)
 			}
+		case model.ActionCreateMaterializedView:
+			args := jobW.JobArgs.(*model.CreateMaterializedViewArgs)
+			count += idCountForTable(args.TableInfo)
+			if args.LogTableInfo != nil {
+				count += idCountForTable(args.LogTableInfo)
+			}
 		case model.ActionCreateSchema, model.ActionCreateResourceGroup:
 			count++
 		case model.ActionAlterTablePartitioning:
@@ -463,6 +469,15 @@ func assignGIDsForJobs(jobWs []*JobWrapper, ids []int64) {
 					alloc.assignIDsForTable(tblArgs.TableInfo)
 				}
 			}
+		case model.ActionCreateMaterializedView:
+			args := jobW.JobArgs.(*model.CreateMaterializedViewArgs)
+			if !jobW.IDAllocated {
+				alloc.assignIDsForTable(args.TableInfo)
+				if args.LogTableInfo != nil {
+					alloc.assignIDsForTable(args.LogTableInfo)
+				}
+			}
+			jobW.TableID = args.TableInfo.ID
 		case model.ActionCreateSchema:
 			dbInfo := jobW.JobArgs.(*model.CreateSchemaArgs).DBInfo
 			if !jobW.IDAllocated {
@@ -354,7 +354,7 @@ func JobNeedGC(job *model.Job) bool {
 			model.ActionDropColumn, model.ActionModifyColumn,
 			model.ActionAddIndex, model.ActionAddPrimaryKey,
 			model.ActionReorganizePartition, model.ActionRemovePartitioning,
-			model.ActionAlterTablePartitioning:
+			model.ActionAlterTablePartitioning, model.ActionDropMaterializedView:
 			return true
 		case model.ActionDropIndex:
 			args, err := model.GetFinishedModifyIndexArgs(job)
@@ -1048,6 +1048,14 @@ func (w *worker) runOneJobStep(
 		ver, err = w.onAlterCheckConstraint(jobCtx, job)
 	case model.ActionRefreshMeta:
 		ver, err = onRefreshMeta(jobCtx, job)
+	case model.ActionCreateTrigger:
+		ver, err = onCreateTrigger(jobCtx, job)
+	case model.ActionDropTrigger:
+		ver, err = onDropTrigger(jobCtx, job)
+	case model.ActionCreateMaterializedView:
+		ver, err = w.onCreateMaterializedView(jobCtx, job)
+	case model.ActionDropMaterializedView:
+		ver, err = w.onDropMaterializedView(jobCtx, job)
 	default:
 		// Invalid job, cancel it.
 		job.State = model.JobStateCancelled
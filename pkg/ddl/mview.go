@@ -0,0 +1,172 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/ddl/notifier"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+)
+
+// onCreateMaterializedView creates the materialized view and its log table,
+// and links the view to its base table. The view is empty when it's created,
+// the caller fills it by a complete refresh after the job is done, when every
+// TiDB writes the row changes of the base table to the log table.
+func (w *worker) onCreateMaterializedView(jobCtx *jobContext, job *model.Job) (ver int64, _ error) {
+	args, err := model.GetCreateMaterializedViewArgs(job)
+	if err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	metaMut := jobCtx.metaMut
+	tbInfo, logInfo := args.TableInfo, args.LogTableInfo
+
+	var baseInfo *model.TableInfo
+	if baseID := tbInfo.MaterializedView.BaseTableID; baseID != 0 {
+		baseInfo, err = getTableInfo(metaMut, baseID, job.SchemaID)
+		if err != nil {
+			job.State = model.JobStateCancelled
+			return ver, errors.Trace(err)
+		}
+	}
+
+	tableInfos := make([]*model.TableInfo, 0, 2)
+	multiInfos := make([]schemaIDAndTableInfo, 0, 2)
+	if logInfo != nil && baseInfo != nil {
+		tbInfo.MaterializedView.LogTableID = logInfo.ID
+		logInfo.MaterializedViewLog = &model.MaterializedViewLogInfo{MViewID: tbInfo.ID, BaseTableID: baseInfo.ID}
+		stubJob := job.Clone()
+		stubJob.TableID = logInfo.ID
+		logInfo, err = createTable(jobCtx, stubJob, &model.CreateTableArgs{TableInfo: logInfo})
+		if err != nil {
+			job.State = stubJob.State
+			return ver, errors.Trace(err)
+		}
+		tableInfos = append(tableInfos, logInfo)
+		multiInfos = append(multiInfos, schemaIDAndTableInfo{schemaID: job.SchemaID, tblInfo: logInfo})
+	}
+	tbInfo, err = createTable(jobCtx, job, &model.CreateTableArgs{TableInfo: tbInfo})
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	tableInfos = append(tableInfos, tbInfo)
+
+	if baseInfo != nil {
+		baseInfo.MaterializedViewIDs = append(baseInfo.MaterializedViewIDs, tbInfo.ID)
+		if err = updateTable(metaMut, job.SchemaID, baseInfo, true); err != nil {
+			return ver, errors.Trace(err)
+		}
+		multiInfos = append(multiInfos, schemaIDAndTableInfo{schemaID: job.SchemaID, tblInfo: baseInfo})
+	}
+
+	ver, err = updateSchemaVersion(jobCtx, job, multiInfos...)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	for i, info := range tableInfos {
+		createTableEvent := notifier.NewCreateTableEvent(info)
+		err = asyncNotifyEvent(jobCtx, createTableEvent, job, int64(i), w.sess)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+	}
+
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tbInfo)
+	return ver, nil
+}
+
+// onDropMaterializedView drops the materialized view and its log table, and
+// unlinks the view from its base table. Only the refresh statements write to
+// the view, so it's dropped in one step.
+func (w *worker) onDropMaterializedView(jobCtx *jobContext, job *model.Job) (ver int64, _ error) {
+	metaMut := jobCtx.metaMut
+	tblInfo, err := GetTableInfoAndCancelFaultJob(metaMut, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	if !tblInfo.IsMaterializedView() {
+		job.State = model.JobStateCancelled
+		return ver, dbterror.ErrWrongObject.GenWithStackByArgs(job.SchemaName, tblInfo.Name, "MATERIALIZED VIEW")
+	}
+
+	mv := tblInfo.MaterializedView
+	droppedInfos := []*model.TableInfo{tblInfo}
+	multiInfos := make([]schemaIDAndTableInfo, 0, 2)
+	if mv.BaseTableID != 0 {
+		baseInfo, err := getTableInfo(metaMut, mv.BaseTableID, job.SchemaID)
+		if err != nil && !infoschema.ErrTableNotExists.Equal(err) {
+			return ver, errors.Trace(err)
+		}
+		if baseInfo != nil {
+			baseInfo.RemoveMaterializedView(tblInfo.ID)
+			if err = updateTable(metaMut, job.SchemaID, baseInfo, true); err != nil {
+				return ver, errors.Trace(err)
+			}
+			multiInfos = append(multiInfos, schemaIDAndTableInfo{schemaID: job.SchemaID, tblInfo: baseInfo})
+		}
+	}
+	if mv.LogTableID != 0 {
+		logInfo, err := getTableInfo(metaMut, mv.LogTableID, job.SchemaID)
+		if err != nil && !infoschema.ErrTableNotExists.Equal(err) {
+			return ver, errors.Trace(err)
+		}
+		if logInfo != nil {
+			droppedInfos = append(droppedInfos, logInfo)
+			multiInfos = append(multiInfos, schemaIDAndTableInfo{schemaID: job.SchemaID, tblInfo: logInfo})
+		}
+	}
+
+	oldTableIDs := make([]int64, 0, len(droppedInfos))
+	for _, info := range droppedInfos {
+		if err = metaMut.DropTableOrView(job.SchemaID, info.ID); err != nil {
+			return ver, errors.Trace(err)
+		}
+		if err = metaMut.GetAutoIDAccessors(job.SchemaID, info.ID).Del(); err != nil {
+			return ver, errors.Trace(err)
+		}
+		oldTableIDs = append(oldTableIDs, info.ID)
+	}
+
+	ver, err = updateSchemaVersion(jobCtx, job, multiInfos...)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	for i, info := range droppedInfos {
+		dropTableEvent := notifier.NewDropTableEvent(info)
+		err = asyncNotifyEvent(jobCtx, dropTableEvent, job, int64(i), w.sess)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+	}
+
+	job.FinishTableJob(model.JobStateDone, model.StateNone, ver, tblInfo)
+	job.FillFinishedArgs(&model.DropMaterializedViewArgs{OldTableIDs: oldTableIDs})
+	return ver, nil
+}
+
+// checkMaterializedViewOpt returns an error if the operation can't be executed
+// on the table because it's a materialized view, a log table, or the base
+// table of materialized views.
+func checkMaterializedViewOpt(tblInfo *model.TableInfo, op string) error {
+	if tblInfo.IsMaterializedView() || tblInfo.IsMaterializedViewLog() {
+		return dbterror.ErrOptOnMaterializedView.GenWithStackByArgs(op)
+	}
+	if tblInfo.HasMaterializedViews() {
+		return dbterror.ErrOptOnMViewBaseTable.GenWithStackByArgs(op, tblInfo.Name.O)
+	}
+	return nil
+}
@@ -634,7 +634,9 @@ func convertJob2RollbackJob(w *worker, jobCtx *jobContext, job *model.Job) (ver
 		model.ActionModifyTableCharsetAndCollate,
 		model.ActionModifySchemaCharsetAndCollate, model.ActionRepairTable,
 		model.ActionModifyTableAutoIDCache, model.ActionAlterIndexVisibility,
-		model.ActionModifySchemaDefaultPlacement, model.ActionRecoverSchema:
+		model.ActionModifySchemaDefaultPlacement, model.ActionRecoverSchema,
+		model.ActionCreateTrigger, model.ActionDropTrigger,
+		model.ActionCreateMaterializedView, model.ActionDropMaterializedView:
 		ver, err = cancelOnlyNotHandledJob(job, model.StateNone)
 	case model.ActionMultiSchemaChange:
 		err = rollingBackMultiSchemaChange(job)
@@ -404,6 +404,26 @@ func (d *Checker) AlterTableMode(ctx sessionctx.Context, args *model.AlterTableM
 	return d.realExecutor.AlterTableMode(ctx, args)
 }
 
+// CreateTrigger implements the DDL interface.
+func (d *Checker) CreateTrigger(ctx sessionctx.Context, stmt *ast.CreateTriggerStmt) error {
+	return d.realExecutor.CreateTrigger(ctx, stmt)
+}
+
+// DropTrigger implements the DDL interface.
+func (d *Checker) DropTrigger(ctx sessionctx.Context, stmt *ast.DropTriggerStmt) error {
+	return d.realExecutor.DropTrigger(ctx, stmt)
+}
+
+// CreateMaterializedView implements the DDL interface.
+func (d *Checker) CreateMaterializedView(ctx sessionctx.Context, stmt *ast.CreateMaterializedViewStmt) error {
+	return d.realExecutor.CreateMaterializedView(ctx, stmt)
+}
+
+// DropMaterializedView implements the DDL interface.
+func (d *Checker) DropMaterializedView(ctx sessionctx.Context, stmt *ast.DropMaterializedViewStmt) error {
+	return d.realExecutor.DropMaterializedView(ctx, stmt)
+}
+
 // RefreshMeta implements the DDL interface.
 func (d *Checker) RefreshMeta(ctx sessionctx.Context, args *model.RefreshMetaArgs) error {
 	return d.realExecutor.RefreshMeta(ctx, args)
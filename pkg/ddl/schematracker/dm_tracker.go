@@ -1193,6 +1193,26 @@ func (*SchemaTracker) CreatePlacementPolicyWithInfo(_ sessionctx.Context, _ *mod
 	return nil
 }
 
+// CreateTrigger implements the DDL interface, it's no-op in DM's case.
+func (*SchemaTracker) CreateTrigger(_ sessionctx.Context, _ *ast.CreateTriggerStmt) error {
+	return nil
+}
+
+// DropTrigger implements the DDL interface, it's no-op in DM's case.
+func (*SchemaTracker) DropTrigger(_ sessionctx.Context, _ *ast.DropTriggerStmt) error {
+	return nil
+}
+
+// CreateMaterializedView implements the DDL interface, it's no-op in DM's case.
+func (*SchemaTracker) CreateMaterializedView(_ sessionctx.Context, _ *ast.CreateMaterializedViewStmt) error {
+	return nil
+}
+
+// DropMaterializedView implements the DDL interface, it's no-op in DM's case.
+func (*SchemaTracker) DropMaterializedView(_ sessionctx.Context, _ *ast.DropMaterializedViewStmt) error {
+	return nil
+}
+
 // RefreshMeta implements the DDL interface, it's no-op in DM's case.
 func (*SchemaTracker) RefreshMeta(_ sessionctx.Context, _ *model.RefreshMetaArgs) error {
 	return nil
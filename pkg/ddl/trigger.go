@@ -0,0 +1,79 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+)
+
+// onCreateTrigger adds the trigger to the table info. A trigger has no data to
+// backfill, so it becomes public in one step.
+func onCreateTrigger(jobCtx *jobContext, job *model.Job) (ver int64, _ error) {
+	args, err := model.GetCreateTriggerArgs(job)
+	if err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	tblInfo, err := GetTableInfoAndCancelFaultJob(jobCtx.metaMut, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	// double check with trigger existence.
+	if tblInfo.FindTrigger(args.Trigger.Name.L) != nil {
+		job.State = model.JobStateCancelled
+		return ver, dbterror.ErrTrgAlreadyExists.GenWithStackByArgs()
+	}
+
+	tblInfo.Triggers = append(tblInfo.Triggers, args.Trigger)
+	ver, err = updateVersionAndTableInfo(jobCtx, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	return ver, nil
+}
+
+func onDropTrigger(jobCtx *jobContext, job *model.Job) (ver int64, _ error) {
+	args, err := model.GetDropTriggerArgs(job)
+	if err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	tblInfo, err := GetTableInfoAndCancelFaultJob(jobCtx.metaMut, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	idx := -1
+	for i, trigger := range tblInfo.Triggers {
+		if trigger.Name.L == args.TriggerName.L {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		job.State = model.JobStateCancelled
+		return ver, dbterror.ErrTrgDoesNotExist.GenWithStackByArgs()
+	}
+	tblInfo.Triggers = append(tblInfo.Triggers[:idx], tblInfo.Triggers[idx+1:]...)
+	ver, err = updateVersionAndTableInfo(jobCtx, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StateNone, ver, tblInfo)
+	return ver, nil
+}
@@ -127,6 +127,9 @@ type SharedVars struct {
 	TableImporter *importer.TableImporter
 	DataEngine    *backend.OpenedEngine
 	IndexEngine   *backend.OpenedEngine
+	// RejectedRowsBase is the number of rejected rows of TableImporter when
+	// the subtask starts.
+	RejectedRowsBase int64
 
 	mu       sync.Mutex
 	Checksum *verification.KVGroupChecksum
@@ -186,4 +189,6 @@ type Checksum struct {
 // This portion of the code may be implemented uniformly in the framework in the future.
 type Result struct {
 	LoadedRowCnt uint64
+	// RejectedRowCnt is the number of rows which fail to encode and are skipped.
+	RejectedRowCnt uint64 `json:",omitempty"`
 }
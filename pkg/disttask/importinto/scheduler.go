@@ -17,6 +17,7 @@ package importinto
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"strconv"
 	"strings"
 	"sync"
@@ -292,6 +293,15 @@ func (sch *importScheduler) OnNextSubtasksBatch(
 		if err != nil {
 			return nil, err
 		}
+		// each node only knows the rows rejected by itself, so check the limit
+		// of the whole job before the encoded data is ingested.
+		rejected, err := sumRejectedRows(sortAndEncodeMeta)
+		if err != nil {
+			return nil, err
+		}
+		if err = checkMaxErrors(rejected, taskMeta.Plan.MaxErrors); err != nil {
+			return nil, err
+		}
 		previousSubtaskMetas[proto.ImportStepEncodeAndSort] = sortAndEncodeMeta
 	case proto.ImportStepWriteAndIngest:
 		failpoint.Inject("failWhenDispatchWriteIngestSubtask", func() {
@@ -575,6 +585,12 @@ func updateResult(handle storage.TaskHandle, task *proto.Task, taskMeta *TaskMet
 	}
 	for _, subtaskMeta := range subtaskMetas {
 		taskMeta.Result.LoadedRowCnt += subtaskMeta.Result.LoadedRowCnt
+		taskMeta.Result.RejectedRowCnt += subtaskMeta.Result.RejectedRowCnt
+	}
+	// the rows rejected by all the nodes are only known after the encode step,
+	// the data is already ingested when using local sort, see checkMaxErrors.
+	if err := checkMaxErrors(taskMeta.Result.RejectedRowCnt, taskMeta.Plan.MaxErrors); err != nil {
+		return err
 	}
 
 	if globalSort {
@@ -587,6 +603,38 @@ func updateResult(handle storage.TaskHandle, task *proto.Task, taskMeta *TaskMet
 	return updateMeta(task, taskMeta)
 }
 
+// sumRejectedRows returns the number of rows rejected by the subtasks of the
+// encode step.
+func sumRejectedRows(encodeStepMetas [][]byte) (uint64, error) {
+	var rejected uint64
+	for _, bs := range encodeStepMetas {
+		var subtaskMeta ImportStepMeta
+		if err := json.Unmarshal(bs, &subtaskMeta); err != nil {
+			return 0, errors.Trace(err)
+		}
+		rejected += subtaskMeta.Result.RejectedRowCnt
+	}
+	return rejected, nil
+}
+
+// checkMaxErrors checks the rows rejected by the whole job against max_errors.
+//
+// The limit is approximate: each node stops encoding when the rows rejected by
+// itself exceed max_errors, and the rows rejected by all the nodes are only
+// known when their subtasks are done. When using global sort, the limit is
+// checked at the end of the encode step, so nothing is ingested if it's
+// exceeded. When using local sort, each subtask checks the rows rejected by
+// the finished subtasks and itself before ingesting its data, the rows
+// rejected by the subtasks running at the same time are not counted, so the
+// job might fail after some data is ingested, such data is removed by
+// truncating the table when failing the job, see failJob.
+func checkMaxErrors(rejected uint64, maxErrors int64) error {
+	if rejected > uint64(maxErrors) {
+		return importer.ErrMaxErrorsExceeded.GenWithStackByArgs(maxErrors, fmt.Sprintf("%d rows fail to encode", rejected))
+	}
+	return nil
+}
+
 func getLoadedRowCountOnGlobalSort(handle storage.TaskHandle, task *proto.Task) (uint64, error) {
 	metas, err := handle.GetPreviousSubtaskMetas(task.ID, proto.ImportStepWriteAndIngest)
 	if err != nil {
@@ -646,7 +694,10 @@ func (sch *importScheduler) finishJob(ctx context.Context, logger *zap.Logger,
 	taskHandle storage.TaskHandle, task *proto.Task, taskMeta *TaskMeta) error {
 	// we have already switch import-mode when switch to post-process step.
 	sch.unregisterTask(ctx, task)
-	summary := &importer.JobSummary{ImportedRows: taskMeta.Result.LoadedRowCnt}
+	summary := &importer.JobSummary{
+		ImportedRows: taskMeta.Result.LoadedRowCnt,
+		RejectedRows: taskMeta.Result.RejectedRowCnt,
+	}
 	// retry for 3+6+12+24+(30-4)*30 ~= 825s ~= 14 minutes
 	backoffer := backoff.NewExponential(scheduler.RetrySQLInterval, 2, scheduler.RetrySQLMaxInterval)
 	return handle.RunWithRetry(ctx, scheduler.RetrySQLTimes, backoffer, logger,
@@ -668,12 +719,25 @@ func (sch *importScheduler) failJob(ctx context.Context, taskHandle storage.Task
 	taskMeta *TaskMeta, logger *zap.Logger, errorMsg string) error {
 	sch.switchTiKV2NormalMode(ctx, task, logger)
 	sch.unregisterTask(ctx, task)
+	// when using local sort, the data of some subtasks might be ingested before
+	// the job exceeds max_errors, see checkMaxErrors. The target table is
+	// required to be empty, so we truncate it to remove such data.
+	truncateTable := !taskMeta.Plan.IsGlobalSort() && importer.ErrMaxErrorsExceeded.Equal(task.Error)
+	if truncateTable {
+		errorMsg += ", the imported data is removed"
+	}
 	// retry for 3+6+12+24+(30-4)*30 ~= 825s ~= 14 minutes
 	backoffer := backoff.NewExponential(scheduler.RetrySQLInterval, 2, scheduler.RetrySQLMaxInterval)
 	return handle.RunWithRetry(ctx, scheduler.RetrySQLTimes, backoffer, logger,
 		func(ctx context.Context) (bool, error) {
 			return true, taskHandle.WithNewSession(func(se sessionctx.Context) error {
 				exec := se.GetSQLExecutor()
+				if truncateTable {
+					logger.Info("truncate the target table as the job exceeds max_errors")
+					if err := importer.TruncateTable(ctx, exec, taskMeta.Plan.DBName, taskMeta.Plan.TableInfo.Name.O); err != nil {
+						return err
+					}
+				}
 				return importer.FailJob(ctx, exec, taskMeta.JobID, errorMsg)
 			})
 		},
@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
 	"github.com/pingcap/tidb/pkg/disttask/framework/scheduler"
@@ -149,3 +150,28 @@ func TestIsImporting2TiKV(t *testing.T) {
 	require.True(t, ext.isImporting2TiKV(&proto.Task{TaskBase: proto.TaskBase{Step: proto.ImportStepImport}}))
 	require.True(t, ext.isImporting2TiKV(&proto.Task{TaskBase: proto.TaskBase{Step: proto.ImportStepWriteAndIngest}}))
 }
+
+func TestCheckMaxErrors(t *testing.T) {
+	metas := make([][]byte, 0, 3)
+	for _, rejected := range []uint64{1, 0, 2} {
+		bs, err := json.Marshal(&ImportStepMeta{Result: Result{LoadedRowCnt: 10, RejectedRowCnt: rejected}})
+		require.NoError(t, err)
+		metas = append(metas, bs)
+	}
+	rejected, err := sumRejectedRows(metas)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, rejected)
+	require.NoError(t, checkMaxErrors(rejected, 3))
+	err = checkMaxErrors(rejected, 2)
+	require.ErrorContains(t, err, "exceeds max_errors 2, 3 rows fail to encode")
+	// the error is still recognized after it's persisted as the task error.
+	bs, err := errors.Cause(err).(*errors.Error).MarshalJSON()
+	require.NoError(t, err)
+	taskErr := errors.Normalize("")
+	require.NoError(t, taskErr.UnmarshalJSON(bs))
+	require.True(t, importer.ErrMaxErrorsExceeded.Equal(taskErr))
+	require.NoError(t, checkMaxErrors(0, 0))
+
+	_, err = sumRejectedRows([][]byte{[]byte("xx")})
+	require.Error(t, err)
+}
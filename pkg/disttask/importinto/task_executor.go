@@ -27,6 +27,7 @@ import (
 	"github.com/pingcap/failpoint"
 	brlogutil "github.com/pingcap/tidb/br/pkg/logutil"
 	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/disttask/framework/storage"
 	"github.com/pingcap/tidb/pkg/disttask/framework/taskexecutor"
 	"github.com/pingcap/tidb/pkg/disttask/framework/taskexecutor/execute"
 	"github.com/pingcap/tidb/pkg/disttask/operator"
@@ -165,6 +166,7 @@ func (s *importStepExecutor) RunSubtask(ctx context.Context, subtask *proto.Subt
 		TableImporter:    s.tableImporter,
 		DataEngine:       dataEngine,
 		IndexEngine:      indexEngine,
+		RejectedRowsBase: s.tableImporter.RejectedRows(),
 		Checksum:         verification.NewKVGroupChecksumWithKeyspace(s.tableImporter.GetKeySpace()),
 		SortedDataMeta:   &external.SortedKVMeta{},
 		SortedIndexMetas: make(map[int64]*external.SortedKVMeta),
@@ -228,6 +230,9 @@ func (s *importStepExecutor) onFinished(ctx context.Context, subtask *proto.Subt
 
 	var dataKVCount uint64
 	if s.tableImporter.IsLocalSort() {
+		if err := s.checkRejectedRows(ctx, uint64(s.tableImporter.RejectedRows()-sharedVars.RejectedRowsBase)); err != nil {
+			return err
+		}
 		// TODO: we should close and cleanup engine in all case, since there's no checkpoint.
 		s.logger.Info("import data engine", zap.Int32("engine-id", subtaskMeta.ID))
 		closedDataEngine, err := sharedVars.DataEngine.Close(ctx)
@@ -260,7 +265,8 @@ func (s *importStepExecutor) onFinished(ctx context.Context, subtask *proto.Subt
 		}
 	}
 	subtaskMeta.Result = Result{
-		LoadedRowCnt: dataKVCount,
+		LoadedRowCnt:   dataKVCount,
+		RejectedRowCnt: uint64(s.tableImporter.RejectedRows() - sharedVars.RejectedRowsBase),
 	}
 	allocators := sharedVars.TableImporter.Allocators()
 	subtaskMeta.MaxIDs = map[autoid.AllocatorType]int64{
@@ -287,6 +293,32 @@ func (s *importStepExecutor) onFinished(ctx context.Context, subtask *proto.Subt
 	return nil
 }
 
+// checkRejectedRows checks the rows rejected by the finished subtasks and the
+// current one against max_errors before the data of the current subtask is
+// ingested, see checkMaxErrors.
+func (s *importStepExecutor) checkRejectedRows(ctx context.Context, rejected uint64) error {
+	if s.taskMeta.Plan.MaxErrors <= 0 {
+		return nil
+	}
+	taskManager, err := storage.GetTaskManager()
+	if err != nil {
+		return err
+	}
+	subtasks, err := taskManager.GetAllSubtasksByStepAndState(ctx, s.taskID, proto.ImportStepImport, proto.SubtaskStateSucceed)
+	if err != nil {
+		return err
+	}
+	metas := make([][]byte, 0, len(subtasks))
+	for _, subtask := range subtasks {
+		metas = append(metas, subtask.Meta)
+	}
+	finished, err := sumRejectedRows(metas)
+	if err != nil {
+		return err
+	}
+	return checkMaxErrors(finished+rejected, s.taskMeta.Plan.MaxErrors)
+}
+
 func (s *importStepExecutor) Cleanup(_ context.Context) (err error) {
 	s.logger.Info("cleanup subtask env")
 	s.importCancel()
@@ -54,6 +54,7 @@ import (
 	"github.com/pingcap/tidb/pkg/domain/globalconfigsync"
 	"github.com/pingcap/tidb/pkg/domain/infosync"
 	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/eventscheduler"
 	"github.com/pingcap/tidb/pkg/infoschema"
 	infoschema_metrics "github.com/pingcap/tidb/pkg/infoschema/metrics"
 	"github.com/pingcap/tidb/pkg/infoschema/perfschema"
@@ -216,6 +217,7 @@ type Domain struct {
 	logBackupAdvancer        *daemon.OwnerDaemon
 	historicalStatsWorker    *HistoricalStatsWorker
 	ttlJobManager            atomic.Pointer[ttlworker.JobManager]
+	eventScheduler           atomic.Pointer[eventscheduler.Scheduler]
 	runawayManager           *runaway.Manager
 	resourceGroupsController *rmclient.ResourceGroupsController
 
@@ -1276,6 +1278,10 @@ func (do *Domain) Close() {
 			logutil.BgLogger().Info("ttlJobManager exited.")
 		}
 	}
+	if eventScheduler := do.eventScheduler.Load(); eventScheduler != nil {
+		logutil.BgLogger().Info("stopping eventScheduler")
+		eventScheduler.Stop()
+	}
 	do.releaseServerID(context.Background())
 	close(do.exit)
 	if do.brOwnerMgr != nil {
@@ -3424,6 +3430,19 @@ func (do *Domain) TTLJobManager() *ttlworker.JobManager {
 	return do.ttlJobManager.Load()
 }
 
+// StartEventScheduler creates and starts the scheduler of events. The body of
+// an event is run by runner.
+func (do *Domain) StartEventScheduler(runner eventscheduler.Runner) {
+	eventScheduler := eventscheduler.NewScheduler(do.advancedSysSessionPool, do.etcdClient, do.ddl.OwnerManager().IsOwner, runner)
+	do.eventScheduler.Store(eventScheduler)
+	eventScheduler.Start()
+}
+
+// EventScheduler returns the scheduler of events on this domain.
+func (do *Domain) EventScheduler() *eventscheduler.Scheduler {
+	return do.eventScheduler.Load()
+}
+
 // StopAutoAnalyze stops (*Domain).autoAnalyzeWorker to launch new auto analyze jobs.
 func (do *Domain) StopAutoAnalyze() {
 	do.stopAutoAnalyze.Store(true)
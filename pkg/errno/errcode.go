@@ -828,6 +828,8 @@ const (
 	ErrInvalidArgumentForLogarithm                           = 3020
 	ErrMaxExecTimeExceeded                                   = 3024
 	ErrAggregateOrderNonAggQuery                             = 3029
+	ErrGISDifferentSRIDs                                     = 3033
+	ErrGISInvalidData                                        = 3037
 	ErrUserLockWrongName                                     = 3057
 	ErrUserLockDeadlock                                      = 3058
 	ErrIncorrectType                                         = 3064
@@ -863,9 +865,11 @@ const (
 	ErrInvalidJSONPathArrayCell                              = 3165
 	ErrInvalidEncryptionOption                               = 3184
 	ErrTooLongValueForType                                   = 3505
+	ErrGISUnsupportedArgument                                = 3516
 	ErrPKIndexCantBeInvisible                                = 3522
 	ErrGrantRole                                             = 3523
 	ErrRoleNotGranted                                        = 3530
+	ErrSRSNotFound                                           = 3548
 	ErrLockAcquireFailAndNoWaitSet                           = 3572
 	ErrCTERecursiveRequiresUnion                             = 3573
 	ErrCTERecursiveRequiresNonRecursiveFirst                 = 3574
@@ -895,6 +899,9 @@ const (
 	ErrWindowFunctionIgnoresFrame                            = 3599
 	ErrInvalidNumberOfArgs                                   = 3601
 	ErrFieldInGroupingNotGroupBy                             = 3602
+	ErrLongitudeOutOfRange                                   = 3616
+	ErrLatitudeOutOfRange                                    = 3617
+	ErrNotImplementedForGeographicSRS                        = 3618
 	ErrIllegalPrivilegeLevel                                 = 3619
 	ErrCTEMaxRecursionDepth                                  = 3636
 	ErrNotHintUpdatable                                      = 3637
@@ -1164,6 +1171,13 @@ const (
 	ErrEngineAttributeInvalidFormat = 8270
 	ErrStorageClassInvalidSpec      = 8271
 
+	// Materialized view errors.
+	ErrMViewNotFastRefreshable = 8272
+	ErrOptOnMaterializedView   = 8273
+	ErrOptOnMViewBaseTable     = 8274
+
+	ErrMergeCardinalityViolation = 8275
+
 	// TiKV/PD/TiFlash errors.
 	ErrPDServerTimeout           = 9001
 	ErrTiKVServerTimeout         = 9002
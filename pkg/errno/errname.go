@@ -846,6 +846,9 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrPasswordExpireAnonymousUser:                           mysql.Message("The password for anonymous user cannot be expired.", nil),
 	ErrInvalidArgumentForLogarithm:                           mysql.Message("Invalid argument for logarithm", nil),
 	ErrAggregateOrderNonAggQuery:                             mysql.Message("Expression #%d of ORDER BY contains aggregate function and applies to the result of a non-aggregated query", nil),
+	ErrGISDifferentSRIDs:                                     mysql.Message("Binary geometry function %s given two geometries of different srids: %d and %d, which should have been identical.", nil),
+	ErrGISInvalidData:                                        mysql.Message("Invalid GIS data provided to function %s.", nil),
+	ErrGISUnsupportedArgument:                                mysql.Message("Calling geometry function %s with unsupported types of arguments.", nil),
 	ErrIncorrectType:                                         mysql.Message("Incorrect type for argument %s in function %s.", nil),
 	ErrFieldInOrderNotSelect:                                 mysql.Message("Expression #%d of ORDER BY clause is not in SELECT list, references column '%s' which is not in SELECT list; this is incompatible with %s", nil),
 	ErrAggregateInOrderNotSelect:                             mysql.Message("Expression #%d of ORDER BY clause is not in SELECT list, contains aggregate function; this is incompatible with %s", nil),
@@ -891,6 +894,7 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrInvalidNumberOfArgs:                                   mysql.Message("Too many arguments for function %s; maximum allowed is %d", nil),
 	ErrFieldInGroupingNotGroupBy:                             mysql.Message("Argument %s of GROUPING function is not in GROUP BY", nil),
 	ErrRoleNotGranted:                                        mysql.Message("%s is not granted to %s", nil),
+	ErrSRSNotFound:                                           mysql.Message("There's no spatial reference system with SRID %d.", nil),
 	ErrMaxExecTimeExceeded:                                   mysql.Message("Query execution was interrupted, maximum statement execution time exceeded", nil),
 	ErrLockAcquireFailAndNoWaitSet:                           mysql.Message("Statement aborted because lock(s) could not be acquired immediately and NOWAIT is set.", nil),
 	ErrNotHintUpdatable:                                      mysql.Message("Variable '%s' might not be affected by SET_VAR hint.", nil),
@@ -933,6 +937,9 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrFunctionalIndexNotApplicable:                          mysql.Message("Cannot use expression index '%s' due to type or collation conversion", nil),
 	ErrUnsupportedConstraintCheck:                            mysql.Message("%s is not supported", nil),
 	ErrDynamicPrivilegeNotRegistered:                         mysql.Message("Dynamic privilege '%s' is not registered with the server.", nil),
+	ErrLongitudeOutOfRange:                                   mysql.Message("Longitude %f is out of range in function %s. It must be within (%f, %f].", nil),
+	ErrLatitudeOutOfRange:                                    mysql.Message("Latitude %f is out of range in function %s. It must be within [%f, %f].", nil),
+	ErrNotImplementedForGeographicSRS:                        mysql.Message("%s(%s) has not been implemented for geographic spatial reference systems.", nil),
 	ErrIllegalPrivilegeLevel:                                 mysql.Message("Illegal privilege level specified for %s", nil),
 	ErrCTERecursiveRequiresUnion:                             mysql.Message("Recursive Common Table Expression '%s' should contain a UNION", nil),
 	ErrCTERecursiveRequiresNonRecursiveFirst:                 mysql.Message("Recursive Common Table Expression '%s' should have one or more non-recursive query blocks followed by one or more recursive ones", nil),
@@ -1161,6 +1168,12 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrEngineAttributeInvalidFormat: mysql.Message("Invalid engine attribute format: %s", nil),
 	ErrStorageClassInvalidSpec:      mysql.Message("Invalid storage class: %s", nil),
 
+	ErrMViewNotFastRefreshable: mysql.Message("Materialized view '%-.192s' can't be fast refreshed: %s", nil),
+	ErrOptOnMaterializedView:   mysql.Message("'%s' is unsupported on materialized views and their log tables.", nil),
+	ErrOptOnMViewBaseTable:     mysql.Message("'%s' is unsupported on table '%-.192s' which has materialized views.", nil),
+
+	ErrMergeCardinalityViolation: mysql.Message("MERGE statement attempted to update or delete the same row of table '%-.192s' more than once", nil),
+
 	// TiKV/PD errors.
 	ErrPDServerTimeout:           mysql.Message("PD server timeout: %s", nil),
 	ErrTiKVServerTimeout:         mysql.Message("TiKV server timeout", nil),
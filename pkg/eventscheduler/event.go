@@ -0,0 +1,211 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventscheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	timerapi "github.com/pingcap/tidb/pkg/timer/api"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/timeutil"
+)
+
+const (
+	// HookClass is the hook class of the timers which run events.
+	HookClass = "tidb.event"
+	// timerKeyPrefix is the key prefix of the timers which run events.
+	timerKeyPrefix = "/tidb/event/"
+)
+
+// TimerKey returns the key of the timer of an event. Like the other schema
+// objects, the names of events are case-insensitive.
+func TimerKey(schema, name string) string {
+	return timerKeyPrefix + strings.ToLower(schema) + "/" + strings.ToLower(name)
+}
+
+// TimerKeyPrefix returns the key prefix of the timers of the events in schema.
+// All the events are returned if schema is empty.
+func TimerKeyPrefix(schema string) string {
+	if schema == "" {
+		return timerKeyPrefix
+	}
+	return timerKeyPrefix + strings.ToLower(schema) + "/"
+}
+
+// Event is the definition of an event. It is saved as the data of the timer
+// which schedules the event.
+type Event struct {
+	Schema string `json:"schema"`
+	Name   string `json:"name"`
+	// Definer is the user whose privileges are used to run the body, in the
+	// form of `user@host`.
+	Definer string `json:"definer"`
+	// Definition is the text of the CREATE EVENT statement.
+	Definition          string `json:"definition"`
+	SQLMode             string `json:"sql_mode"`
+	TimeZone            string `json:"time_zone"`
+	CharsetClient       string `json:"character_set_client"`
+	CollationConnection string `json:"collation_connection"`
+	DatabaseCollation   string `json:"database_collation"`
+	// ExecuteAt is the time to run a one-time event. It is nil for a recurring
+	// event.
+	ExecuteAt *time.Time `json:"execute_at,omitempty"`
+	// IntervalValue and IntervalField are the interval of a recurring event,
+	// for example, '1' and 'DAY' for EVERY 1 DAY.
+	IntervalValue string     `json:"interval_value,omitempty"`
+	IntervalField string     `json:"interval_field,omitempty"`
+	Starts        *time.Time `json:"starts,omitempty"`
+	Ends          *time.Time `json:"ends,omitempty"`
+	// Preserve indicates the event is kept after it expires.
+	Preserve    bool      `json:"preserve"`
+	Comment     string    `json:"comment"`
+	Created     time.Time `json:"created"`
+	LastAltered time.Time `json:"last_altered"`
+}
+
+// Summary is the result of the last execution of an event. It is saved as the
+// summary data of the timer.
+type Summary struct {
+	LastExecuted time.Time `json:"last_executed,omitempty"`
+	LastError    string    `json:"last_error,omitempty"`
+	// RunningEventID is the id of the timer event whose body is running. It is
+	// used to avoid running the body again for the same timer event when the
+	// scheduler is restarted.
+	RunningEventID string `json:"running_event_id,omitempty"`
+}
+
+// UnmarshalEvent decodes the event from the data of a timer.
+func UnmarshalEvent(data []byte) (*Event, error) {
+	var e Event
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &e, nil
+}
+
+// UnmarshalSummary decodes the summary from the summary data of a timer.
+func UnmarshalSummary(data []byte) (*Summary, error) {
+	var s Summary
+	if len(data) == 0 {
+		return &s, nil
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &s, nil
+}
+
+// Marshal encodes the event as the data of a timer.
+func (e *Event) Marshal() ([]byte, error) {
+	data, err := json.Marshal(e)
+	return data, errors.Trace(err)
+}
+
+// FullName returns the name of the event qualified by its schema.
+func (e *Event) FullName() string {
+	return e.Schema + "." + e.Name
+}
+
+// IsOneTime returns whether the event runs only once.
+func (e *Event) IsOneTime() bool {
+	return e.ExecuteAt != nil
+}
+
+// Interval is the parsed interval of a recurring event. Intervals with months
+// or years do not have a fixed length, so they are kept apart from the rest.
+type Interval struct {
+	Months   int64
+	Duration time.Duration
+}
+
+// ParseInterval parses the interval of a recurring event.
+func ParseInterval(value, field string) (Interval, error) {
+	field = strings.ToUpper(field)
+	if strings.Contains(field, "MICROSECOND") {
+		return Interval{}, errors.Errorf("unsupported event interval unit %s", field)
+	}
+	y, m, d, n, _, err := types.ParseDurationValue(field, value)
+	if err != nil {
+		return Interval{}, err
+	}
+	iv := Interval{Months: y*12 + m, Duration: time.Duration(d)*24*time.Hour + time.Duration(n)}
+	if iv.Months < 0 || iv.Duration < 0 || (iv.Months == 0 && iv.Duration <= 0) {
+		return Interval{}, errors.Errorf("the interval of event should be positive")
+	}
+	return iv, nil
+}
+
+// Schedule returns the schedule of the timer which runs the event. The watermark
+// makes the first event of the timer happen at the start time of the event, or
+// now for the recurring events without STARTS.
+func (e *Event) Schedule(now time.Time) (tp timerapi.SchedPolicyType, expr string, watermark time.Time, err error) {
+	if e.IsOneTime() {
+		return timerapi.SchedEventInterval, "1m", e.ExecuteAt.Add(-time.Minute), nil
+	}
+	iv, err := ParseInterval(e.IntervalValue, e.IntervalField)
+	if err != nil {
+		return "", "", watermark, err
+	}
+	start := now
+	if e.Starts != nil {
+		start = *e.Starts
+	}
+	if iv.Months == 0 {
+		// The interval policy is evaluated in minutes.
+		if iv.Duration%time.Minute != 0 {
+			return "", "", watermark, errors.Errorf("the interval of event should be a multiple of one minute")
+		}
+		return timerapi.SchedEventInterval, strconv.FormatInt(int64(iv.Duration/time.Minute), 10) + "m", start.Add(-iv.Duration), nil
+	}
+	if iv.Duration != 0 || 12%iv.Months != 0 {
+		return "", "", watermark, errors.Errorf("the interval of event should be a divisor of one year if it has months")
+	}
+	// Run the event at the time of start in every months which are
+	// iv.Months apart from the month of start. The cron expression is
+	// evaluated in the time zone of the timer.
+	if loc, err := timeutil.ParseTimeZone(e.TimeZone); err == nil {
+		start = start.In(loc)
+	}
+	months := make([]string, 0, 12/iv.Months)
+	for i := int64(0); i < 12; i += iv.Months {
+		months = append(months, strconv.FormatInt((int64(start.Month())-1+i)%12+1, 10))
+	}
+	expr = fmt.Sprintf("%d %d %d %s *", start.Minute(), start.Hour(), start.Day(), strings.Join(months, ","))
+	return timerapi.SchedEventCron, expr, start.Add(-time.Minute), nil
+}
+
+// NextWatermark returns the watermark of the timer after the event happened at
+// eventStart. For the interval policy, the watermark is kept aligned with the
+// start time of the event.
+func NextWatermark(timer *timerapi.TimerRecord, eventStart time.Time) time.Time {
+	if timer.SchedPolicyType != timerapi.SchedEventInterval || timer.Watermark.IsZero() {
+		return eventStart
+	}
+	policy, err := timerapi.NewSchedIntervalPolicy(timer.SchedPolicyExpr)
+	if err != nil {
+		return eventStart
+	}
+	next, _ := policy.NextEventTime(timer.Watermark)
+	interval := next.Sub(timer.Watermark)
+	if interval <= 0 || eventStart.Before(next) {
+		return eventStart
+	}
+	return timer.Watermark.Add(eventStart.Sub(timer.Watermark) / interval * interval)
+}
@@ -0,0 +1,260 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventscheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/sessionctx/vardef"
+	timerapi "github.com/pingcap/tidb/pkg/timer/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimerKey(t *testing.T) {
+	require.Equal(t, "/tidb/event/test/e1", TimerKey("Test", "E1"))
+	require.Equal(t, "/tidb/event/test/", TimerKeyPrefix("TEST"))
+	require.Equal(t, "/tidb/event/", TimerKeyPrefix(""))
+}
+
+func TestParseInterval(t *testing.T) {
+	cases := []struct {
+		value  string
+		field  string
+		months int64
+		dur    time.Duration
+		err    bool
+	}{
+		{value: "1", field: "DAY", dur: 24 * time.Hour},
+		{value: "90", field: "minute", dur: 90 * time.Minute},
+		{value: "1:30", field: "HOUR_MINUTE", dur: 90 * time.Minute},
+		{value: "2", field: "WEEK", dur: 14 * 24 * time.Hour},
+		{value: "1", field: "QUARTER", months: 3},
+		{value: "1-6", field: "YEAR_MONTH", months: 18},
+		{value: "0", field: "DAY", err: true},
+		{value: "-1", field: "HOUR", err: true},
+		{value: "1", field: "MICROSECOND", err: true},
+	}
+	for _, c := range cases {
+		iv, err := ParseInterval(c.value, c.field)
+		if c.err {
+			require.Error(t, err, c.value+" "+c.field)
+			continue
+		}
+		require.NoError(t, err, c.value+" "+c.field)
+		require.Equal(t, Interval{Months: c.months, Duration: c.dur}, iv, c.value+" "+c.field)
+	}
+}
+
+func TestEventSchedule(t *testing.T) {
+	now := time.Date(2025, 3, 10, 8, 0, 0, 0, time.UTC)
+	at := now.Add(time.Hour)
+	starts := time.Date(2025, 4, 15, 10, 30, 0, 0, time.UTC)
+
+	ev := &Event{ExecuteAt: &at}
+	tp, expr, watermark, err := ev.Schedule(now)
+	require.NoError(t, err)
+	require.Equal(t, timerapi.SchedEventInterval, tp)
+	require.Equal(t, "1m", expr)
+	require.Equal(t, at.Add(-time.Minute), watermark)
+
+	ev = &Event{IntervalValue: "2", IntervalField: "HOUR"}
+	tp, expr, watermark, err = ev.Schedule(now)
+	require.NoError(t, err)
+	require.Equal(t, timerapi.SchedEventInterval, tp)
+	require.Equal(t, "120m", expr)
+	require.Equal(t, now.Add(-2*time.Hour), watermark)
+
+	ev = &Event{IntervalValue: "1", IntervalField: "DAY", Starts: &starts}
+	_, expr, watermark, err = ev.Schedule(now)
+	require.NoError(t, err)
+	require.Equal(t, "1440m", expr)
+	require.Equal(t, starts.Add(-24*time.Hour), watermark)
+
+	ev = &Event{IntervalValue: "1", IntervalField: "QUARTER", Starts: &starts, TimeZone: "UTC"}
+	tp, expr, watermark, err = ev.Schedule(now)
+	require.NoError(t, err)
+	require.Equal(t, timerapi.SchedEventCron, tp)
+	require.Equal(t, "30 10 15 4,7,10,1 *", expr)
+	require.Equal(t, starts.Add(-time.Minute), watermark)
+
+	ev = &Event{IntervalValue: "30", IntervalField: "SECOND"}
+	_, _, _, err = ev.Schedule(now)
+	require.Error(t, err)
+
+	ev = &Event{IntervalValue: "5", IntervalField: "MONTH"}
+	_, _, _, err = ev.Schedule(now)
+	require.Error(t, err)
+}
+
+func TestNextWatermark(t *testing.T) {
+	watermark := time.Date(2025, 3, 10, 8, 0, 0, 0, time.UTC)
+	timer := &timerapi.TimerRecord{
+		TimerSpec: timerapi.TimerSpec{
+			SchedPolicyType: timerapi.SchedEventInterval,
+			SchedPolicyExpr: "10m",
+			Watermark:       watermark,
+		},
+	}
+	// the event is delayed, but the watermark is kept aligned
+	require.Equal(t, watermark.Add(10*time.Minute), NextWatermark(timer, watermark.Add(13*time.Minute)))
+	require.Equal(t, watermark.Add(30*time.Minute), NextWatermark(timer, watermark.Add(35*time.Minute)))
+
+	timer.SchedPolicyType = timerapi.SchedEventCron
+	timer.SchedPolicyExpr = "0 8 * * *"
+	require.Equal(t, watermark.Add(13*time.Minute), NextWatermark(timer, watermark.Add(13*time.Minute)))
+}
+
+type mockTimerSchedEvent struct {
+	eventID string
+	timer   *timerapi.TimerRecord
+}
+
+func (e *mockTimerSchedEvent) EventID() string {
+	return e.eventID
+}
+
+func (e *mockTimerSchedEvent) Timer() *timerapi.TimerRecord {
+	return e.timer
+}
+
+func createTestEventTimer(t *testing.T, cli timerapi.TimerClient, ev *Event) *timerapi.TimerRecord {
+	data, err := ev.Marshal()
+	require.NoError(t, err)
+	tp, expr, watermark, err := ev.Schedule(time.Now())
+	require.NoError(t, err)
+	timer, err := cli.CreateTimer(context.TODO(), timerapi.TimerSpec{
+		Key:             TimerKey(ev.Schema, ev.Name),
+		Data:            data,
+		SchedPolicyType: tp,
+		SchedPolicyExpr: expr,
+		HookClass:       HookClass,
+		Watermark:       watermark,
+		Enable:          true,
+	})
+	require.NoError(t, err)
+	return timer
+}
+
+func triggerTestTimer(t *testing.T, store *timerapi.TimerStore, timerID string, eventID string) *timerapi.TimerRecord {
+	err := store.Update(context.TODO(), timerID, &timerapi.TimerUpdate{
+		EventStatus: timerapi.NewOptionalVal(timerapi.SchedEventTrigger),
+		EventID:     timerapi.NewOptionalVal(eventID),
+		EventStart:  timerapi.NewOptionalVal(time.Now()),
+	})
+	require.NoError(t, err)
+	timer, err := store.GetByID(context.TODO(), timerID)
+	require.NoError(t, err)
+	return timer
+}
+
+func TestEventTimerHook(t *testing.T) {
+	store := timerapi.NewMemoryTimerStore()
+	defer store.Close()
+	cli := timerapi.NewDefaultTimerClient(store)
+
+	origin := vardef.EnableEventScheduler.Load()
+	defer vardef.EnableEventScheduler.Store(origin)
+
+	runCh := make(chan *Event, 1)
+	var runErr error
+	hook := newEventTimerHook(cli, func(_ context.Context, ev *Event) error {
+		runCh <- ev
+		return runErr
+	})
+	hook.Start()
+	defer hook.Stop()
+
+	vardef.EnableEventScheduler.Store(false)
+	r, err := hook.OnPreSchedEvent(context.TODO(), &mockTimerSchedEvent{eventID: "event1"})
+	require.NoError(t, err)
+	require.Equal(t, timerapi.PreSchedEventResult{Delay: time.Minute}, r)
+	vardef.EnableEventScheduler.Store(true)
+	r, err = hook.OnPreSchedEvent(context.TODO(), &mockTimerSchedEvent{eventID: "event1"})
+	require.NoError(t, err)
+	require.Equal(t, timerapi.PreSchedEventResult{}, r)
+
+	waitEventClosed := func(timerID string) *timerapi.TimerRecord {
+		var timer *timerapi.TimerRecord
+		require.Eventually(t, func() bool {
+			timer, err = cli.GetTimerByID(context.TODO(), timerID)
+			if errors.ErrorEqual(err, timerapi.ErrTimerNotExist) {
+				timer = nil
+				return true
+			}
+			require.NoError(t, err)
+			return timer.EventStatus == timerapi.SchedEventIdle
+		}, 5*time.Second, 10*time.Millisecond)
+		return timer
+	}
+
+	// a recurring event runs the body and keeps the timer
+	ev := &Event{Schema: "test", Name: "e1", IntervalValue: "1", IntervalField: "HOUR"}
+	timer := createTestEventTimer(t, cli, ev)
+	timer = triggerTestTimer(t, store, timer.ID, "event1")
+	require.NoError(t, hook.OnSchedEvent(context.TODO(), &mockTimerSchedEvent{eventID: "event1", timer: timer}))
+	require.Equal(t, ev.FullName(), (<-runCh).FullName())
+	timer = waitEventClosed(timer.ID)
+	require.NotNil(t, timer)
+	require.True(t, timer.Enable)
+	summary, err := UnmarshalSummary(timer.SummaryData)
+	require.NoError(t, err)
+	require.Empty(t, summary.RunningEventID)
+	require.Empty(t, summary.LastError)
+	require.False(t, summary.LastExecuted.IsZero())
+
+	// the error of the body is recorded in the summary
+	runErr = errors.New("mock error")
+	timer = triggerTestTimer(t, store, timer.ID, "event2")
+	require.NoError(t, hook.OnSchedEvent(context.TODO(), &mockTimerSchedEvent{eventID: "event2", timer: timer}))
+	<-runCh
+	timer = waitEventClosed(timer.ID)
+	summary, err = UnmarshalSummary(timer.SummaryData)
+	require.NoError(t, err)
+	require.Equal(t, "mock error", summary.LastError)
+	runErr = nil
+
+	// the body is not run again for the same timer event
+	timer = triggerTestTimer(t, store, timer.ID, "event3")
+	require.NoError(t, cli.UpdateTimer(context.TODO(), timer.ID, timerapi.WithSetSummaryData([]byte(`{"running_event_id":"event3"}`))))
+	timer, err = cli.GetTimerByID(context.TODO(), timer.ID)
+	require.NoError(t, err)
+	require.NoError(t, hook.OnSchedEvent(context.TODO(), &mockTimerSchedEvent{eventID: "event3", timer: timer}))
+	require.Len(t, runCh, 0)
+	require.NotNil(t, waitEventClosed(timer.ID))
+
+	// a one-time event is dropped after it runs
+	at := time.Now()
+	ev = &Event{Schema: "test", Name: "e2", ExecuteAt: &at}
+	timer = createTestEventTimer(t, cli, ev)
+	timer = triggerTestTimer(t, store, timer.ID, "event1")
+	require.NoError(t, hook.OnSchedEvent(context.TODO(), &mockTimerSchedEvent{eventID: "event1", timer: timer}))
+	<-runCh
+	require.Nil(t, waitEventClosed(timer.ID))
+
+	// a one-time event with ON COMPLETION PRESERVE is disabled after it runs
+	ev = &Event{Schema: "test", Name: "e3", ExecuteAt: &at, Preserve: true}
+	timer = createTestEventTimer(t, cli, ev)
+	timer = triggerTestTimer(t, store, timer.ID, "event1")
+	require.NoError(t, hook.OnSchedEvent(context.TODO(), &mockTimerSchedEvent{eventID: "event1", timer: timer}))
+	<-runCh
+	require.Eventually(t, func() bool {
+		timer, err = cli.GetTimerByID(context.TODO(), timer.ID)
+		require.NoError(t, err)
+		return !timer.Enable
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,161 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventscheduler
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/sessionctx/vardef"
+	timerapi "github.com/pingcap/tidb/pkg/timer/api"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/zap"
+)
+
+// Runner runs the body of an event with the privileges of its definer.
+type Runner func(ctx context.Context, event *Event) error
+
+type eventTimerHook struct {
+	cli    timerapi.TimerClient
+	runner Runner
+	ctx    context.Context
+	cancel func()
+	wg     sync.WaitGroup
+}
+
+func newEventTimerHook(cli timerapi.TimerClient, runner Runner) *eventTimerHook {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &eventTimerHook{
+		cli:    cli,
+		runner: runner,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+func (h *eventTimerHook) Start() {}
+
+func (h *eventTimerHook) Stop() {
+	h.cancel()
+	h.wg.Wait()
+}
+
+func (*eventTimerHook) OnPreSchedEvent(_ context.Context, _ timerapi.TimerShedEvent) (r timerapi.PreSchedEventResult, err error) {
+	if !vardef.EnableEventScheduler.Load() {
+		r.Delay = time.Minute
+	}
+	return
+}
+
+func (h *eventTimerHook) OnSchedEvent(ctx context.Context, event timerapi.TimerShedEvent) error {
+	timer := event.Timer()
+	eventID := event.EventID()
+	logger := logutil.BgLogger().With(
+		zap.String("key", timer.Key),
+		zap.String("eventID", eventID),
+		zap.Time("eventStart", timer.EventStart),
+	)
+	if err := h.ctx.Err(); err != nil {
+		return err
+	}
+
+	ev, err := UnmarshalEvent(timer.Data)
+	if err != nil {
+		logger.Error("invalid event timer data", zap.ByteString("data", timer.Data))
+		return err
+	}
+	summary, err := UnmarshalSummary(timer.SummaryData)
+	if err != nil {
+		logger.Warn("invalid event timer summary", zap.ByteString("summary", timer.SummaryData))
+		summary = &Summary{}
+	}
+
+	if summary.RunningEventID == eventID {
+		// The body has been started for this timer event before the scheduler
+		// restarted, do not run it again.
+		logger.Warn("skip the event because it has been started before")
+		summary.RunningEventID = ""
+		return h.closeEvent(ctx, timer, eventID, ev, summary)
+	}
+
+	if ev.Ends != nil && timer.EventStart.After(*ev.Ends) {
+		logger.Info("skip the event because it has ended", zap.String("event", ev.FullName()))
+		return h.closeEvent(ctx, timer, eventID, ev, summary)
+	}
+
+	summary.RunningEventID = eventID
+	summaryData, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+	if err = h.cli.UpdateTimer(ctx, timer.ID, timerapi.WithSetSummaryData(summaryData)); err != nil {
+		return err
+	}
+
+	logger.Info("start to run event", zap.String("event", ev.FullName()))
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+		start := time.Now()
+		runErr := h.runner(h.ctx, ev)
+		summary.RunningEventID = ""
+		summary.LastExecuted = start
+		summary.LastError = ""
+		if runErr != nil {
+			summary.LastError = runErr.Error()
+			logger.Warn("failed to run event", zap.String("event", ev.FullName()), zap.Error(runErr))
+		} else {
+			logger.Info("event finished", zap.String("event", ev.FullName()), zap.Duration("duration", time.Since(start)))
+		}
+		if err := h.closeEvent(h.ctx, timer, eventID, ev, summary); err != nil {
+			logger.Error("failed to close event", zap.String("event", ev.FullName()), zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+// closeEvent closes the current timer event and moves the watermark forward.
+// An expired event is dropped, or disabled if ON COMPLETION PRESERVE is set.
+func (h *eventTimerHook) closeEvent(ctx context.Context, timer *timerapi.TimerRecord, eventID string, ev *Event, summary *Summary) error {
+	summaryData, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+	watermark := NextWatermark(timer, timer.EventStart)
+	if err = h.cli.CloseTimerEvent(ctx, timer.ID, eventID,
+		timerapi.WithSetWatermark(watermark), timerapi.WithSetSummaryData(summaryData)); err != nil {
+		return err
+	}
+
+	expired := ev.IsOneTime()
+	if !expired && ev.Ends != nil {
+		updated := timer.Clone()
+		updated.Watermark = watermark
+		next, ok, err := updated.NextEventTime()
+		if err == nil && (!ok || next.After(*ev.Ends)) {
+			expired = true
+		}
+	}
+	if !expired {
+		return nil
+	}
+	if ev.Preserve {
+		return h.cli.UpdateTimer(ctx, timer.ID, timerapi.WithSetEnable(false))
+	}
+	_, err = h.cli.DeleteTimer(ctx, timer.ID)
+	return err
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventscheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/session/syssession"
+	timerapi "github.com/pingcap/tidb/pkg/timer/api"
+	timerrt "github.com/pingcap/tidb/pkg/timer/runtime"
+	"github.com/pingcap/tidb/pkg/timer/tablestore"
+	"github.com/pingcap/tidb/pkg/util/intest"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// Scheduler runs the events. Every event is a timer saved in mysql.tidb_timers,
+// and the timers are only scheduled by the DDL owner.
+type Scheduler struct {
+	store   *timerapi.TimerStore
+	cli     timerapi.TimerClient
+	runner  Runner
+	isOwner func() bool
+	rt      *timerrt.TimerGroupRuntime
+
+	ctx    context.Context
+	cancel func()
+	wg     sync.WaitGroup
+}
+
+// NewScheduler creates a new Scheduler.
+func NewScheduler(pool syssession.Pool, etcd *clientv3.Client, isOwner func() bool, runner Runner) *Scheduler {
+	store := tablestore.NewTableTimerStore(1, pool, "mysql", "tidb_timers", etcd)
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Scheduler{
+		store:   store,
+		cli:     timerapi.NewDefaultTimerClient(store),
+		runner:  runner,
+		isOwner: isOwner,
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+// Client returns the client to manage the timers of events.
+func (s *Scheduler) Client() timerapi.TimerClient {
+	return s.cli
+}
+
+// Start starts the scheduler.
+func (s *Scheduler) Start() {
+	s.wg.Add(1)
+	go s.loop()
+}
+
+// Stop stops the scheduler and waits for the running events.
+func (s *Scheduler) Stop() {
+	s.cancel()
+	s.wg.Wait()
+	s.store.Close()
+}
+
+func (s *Scheduler) loop() {
+	defer func() {
+		s.pause()
+		s.wg.Done()
+		logutil.BgLogger().Info("event scheduler loop exited.")
+	}()
+
+	interval := 5 * time.Second
+	if intest.InTest {
+		interval = 100 * time.Millisecond
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if s.isOwner != nil && s.isOwner() {
+			s.resume()
+		} else {
+			s.pause()
+		}
+	}
+}
+
+func (s *Scheduler) resume() {
+	if s.rt != nil {
+		return
+	}
+
+	s.rt = timerrt.NewTimerRuntimeBuilder("event", s.store).
+		SetCond(&timerapi.TimerCond{Key: timerapi.NewOptionalVal(timerKeyPrefix), KeyPrefix: true}).
+		RegisterHookFactory(HookClass, func(_ string, cli timerapi.TimerClient) timerapi.Hook {
+			return newEventTimerHook(cli, s.runner)
+		}).
+		Build()
+	s.rt.Start()
+}
+
+func (s *Scheduler) pause() {
+	if rt := s.rt; rt != nil {
+		s.rt = nil
+		rt.Stop()
+	}
+}
@@ -775,10 +775,14 @@ func (a *ExecStmt) handleForeignKeyCascade(ctx context.Context, fkc *FKCascadeEx
 }
 
 // prepareFKCascadeContext records a transaction savepoint for foreign key cascade when this ExecStmt has foreign key
-// cascade behaviour and this ExecStmt is in transaction.
+// cascade behaviour and this ExecStmt is in transaction. The row triggers are handled like the foreign key cascades,
+// since their statements also need to read the changes of this ExecStmt.
 func (a *ExecStmt) prepareFKCascadeContext(e exec.Executor) {
 	exec, ok := e.(WithForeignKeyTrigger)
-	if !ok || !exec.HasFKCascades() {
+	if !ok {
+		return
+	}
+	if w, ok := e.(withRowTrigger); !exec.HasFKCascades() && (!ok || len(w.getTriggerExecs()) == 0) {
 		return
 	}
 	sessVar := a.Ctx.GetSessionVars()
@@ -1022,6 +1026,10 @@ func (a *ExecStmt) handleNoDelayExecutor(ctx context.Context, e exec.Executor) (
 
 	err = a.next(ctx, e, exec.TryNewCacheChunk(e))
 	if err != nil {
+		// The row triggers may have flushed the changes of this statement to the txn mem-buffer.
+		if err1 := a.handleFKTriggerError(sctx.GetSessionVars().StmtCtx); err1 != nil {
+			return nil, errors.Errorf("handle trigger error failed, err: %v, original_err: %v", err1, err)
+		}
 		return nil, err
 	}
 	err = a.handleStmtForeignKeyTrigger(ctx, e)
@@ -1208,6 +1216,7 @@ func (a *ExecStmt) handlePessimisticLockError(ctx context.Context, lockErr error
 	if err = a.openExecutor(ctx, e); err != nil {
 		return nil, err
 	}
+	a.prepareFKCascadeContext(e)
 	return e, nil
 }
 
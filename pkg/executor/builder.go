@@ -257,6 +257,8 @@ func (b *executorBuilder) build(p base.Plan) exec.Executor {
 		return b.buildUnionAll(v)
 	case *plannercore.Update:
 		return b.buildUpdate(v)
+	case *plannercore.Merge:
+		return b.buildMerge(v)
 	case *plannercore.PhysicalUnionScan:
 		return b.buildUnionScanExec(v)
 	case *plannercore.PhysicalHashJoin:
@@ -892,6 +894,8 @@ func (b *executorBuilder) buildShow(v *plannercore.PhysicalShow) exec.Executor {
 		Column:                v.Column,
 		IndexName:             v.IndexName,
 		ResourceGroupName:     ast.NewCIStr(v.ResourceGroupName),
+		ProcedureName:         ast.NewCIStr(v.ProcedureName),
+		EventName:             ast.NewCIStr(v.EventName),
 		Flag:                  v.Flag,
 		Roles:                 v.Roles,
 		User:                  v.User,
@@ -1012,6 +1016,23 @@ func (b *executorBuilder) buildInsert(v *plannercore.Insert) exec.Executor {
 	if b.err != nil {
 		return nil
 	}
+	usedTables := map[int64]struct{}{ivs.Table.Meta().ID: {}}
+	ivs.triggers, b.err = b.buildTriggerExec(ivs.Table, ast.TriggerEventInsert, usedTables)
+	if b.err != nil {
+		return nil
+	}
+	if v.IsReplace {
+		ivs.deleteTriggers, b.err = b.buildTriggerExec(ivs.Table, ast.TriggerEventDelete, usedTables)
+	} else if len(v.OnDuplicate) > 0 {
+		ivs.updateTriggers, b.err = b.buildTriggerExec(ivs.Table, ast.TriggerEventUpdate, usedTables)
+	}
+	if b.err != nil {
+		return nil
+	}
+	ivs.mlogs, b.err = b.buildMViewLogWriter(ivs.Table, "INSERT")
+	if b.err != nil {
+		return nil
+	}
 
 	if v.IsReplace {
 		return b.buildReplace(ivs)
@@ -1074,6 +1095,10 @@ func (b *executorBuilder) buildLoadData(v *plannercore.LoadData) exec.Executor {
 		b.err = err
 		return nil
 	}
+	worker.mlogs, b.err = b.buildMViewLogWriter(tbl, "LOAD DATA")
+	if b.err != nil {
+		return nil
+	}
 
 	return &LoadDataExec{
 		BaseExecutor:   base,
@@ -2321,6 +2346,7 @@ func (b *executorBuilder) buildMemTable(v *plannercore.PhysicalMemTable) exec.Ex
 			strings.ToLower(infoschema.TableStatistics),
 			strings.ToLower(infoschema.TableTiDBIndexes),
 			strings.ToLower(infoschema.TableViews),
+			strings.ToLower(infoschema.TableEvents),
 			strings.ToLower(infoschema.TableTables),
 			strings.ToLower(infoschema.TableReferConst),
 			strings.ToLower(infoschema.TableSequences),
@@ -2736,6 +2762,25 @@ func (b *executorBuilder) buildSplitRegion(v *plannercore.SplitRegion) exec.Exec
 
 func (b *executorBuilder) buildUpdate(v *plannercore.Update) exec.Executor {
 	b.inUpdateStmt = true
+	if b.err = b.updateForUpdateTS(); b.err != nil {
+		return nil
+	}
+
+	selExec := b.build(v.SelectPlan)
+	if b.err != nil {
+		return nil
+	}
+	base := exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID(), selExec)
+	base.SetInitCap(chunk.ZeroCapacity)
+	updateExec := b.buildUpdateExec(v, base, selExec.Schema().Len())
+	if b.err != nil {
+		return nil
+	}
+	return updateExec
+}
+
+// buildUpdateExec builds the UpdateExec on base, schemaLen is the length of the rows to update.
+func (b *executorBuilder) buildUpdateExec(v *plannercore.Update, base exec.BaseExecutor, schemaLen int) *UpdateExec {
 	tblID2table := make(map[int64]table.Table, len(v.TblColPosInfos))
 	multiUpdateOnSameTable := make(map[int64]bool)
 	for _, info := range v.TblColPosInfos {
@@ -2756,18 +2801,8 @@ func (b *executorBuilder) buildUpdate(v *plannercore.Update) exec.Executor {
 			}
 		}
 	}
-	if b.err = b.updateForUpdateTS(); b.err != nil {
-		return nil
-	}
-
-	selExec := b.build(v.SelectPlan)
-	if b.err != nil {
-		return nil
-	}
-	base := exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID(), selExec)
-	base.SetInitCap(chunk.ZeroCapacity)
 	var assignFlag []int
-	assignFlag, b.err = getAssignFlag(b.ctx, v, selExec.Schema().Len())
+	assignFlag, b.err = getAssignFlag(b.ctx, v, schemaLen)
 	if b.err != nil {
 		return nil
 	}
@@ -2795,6 +2830,14 @@ func (b *executorBuilder) buildUpdate(v *plannercore.Update) exec.Executor {
 	if b.err != nil {
 		return nil
 	}
+	updateExec.triggers, b.err = b.buildTblID2TriggerExecs(tblID2table, ast.TriggerEventUpdate)
+	if b.err != nil {
+		return nil
+	}
+	updateExec.mlogs, b.err = b.buildTblID2MViewLogWriters(tblID2table, "UPDATE")
+	if b.err != nil {
+		return nil
+	}
 	return updateExec
 }
 
@@ -2818,11 +2861,6 @@ func getAssignFlag(ctx sessionctx.Context, v *plannercore.Update, schemaLen int)
 
 func (b *executorBuilder) buildDelete(v *plannercore.Delete) exec.Executor {
 	b.inDeleteStmt = true
-	tblID2table := make(map[int64]table.Table, len(v.TblColPosInfos))
-	for _, info := range v.TblColPosInfos {
-		tblID2table[info.TblID], _ = b.is.TableByID(context.Background(), info.TblID)
-	}
-
 	if b.err = b.updateForUpdateTS(); b.err != nil {
 		return nil
 	}
@@ -2833,6 +2871,19 @@ func (b *executorBuilder) buildDelete(v *plannercore.Delete) exec.Executor {
 	}
 	base := exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID(), selExec)
 	base.SetInitCap(chunk.ZeroCapacity)
+	deleteExec := b.buildDeleteExec(v, base)
+	if b.err != nil {
+		return nil
+	}
+	return deleteExec
+}
+
+// buildDeleteExec builds the DeleteExec on base.
+func (b *executorBuilder) buildDeleteExec(v *plannercore.Delete, base exec.BaseExecutor) *DeleteExec {
+	tblID2table := make(map[int64]table.Table, len(v.TblColPosInfos))
+	for _, info := range v.TblColPosInfos {
+		tblID2table[info.TblID], _ = b.is.TableByID(context.Background(), info.TblID)
+	}
 	deleteExec := &DeleteExec{
 		BaseExecutor:   base,
 		tblID2Table:    tblID2table,
@@ -2848,9 +2899,61 @@ func (b *executorBuilder) buildDelete(v *plannercore.Delete) exec.Executor {
 	if b.err != nil {
 		return nil
 	}
+	deleteExec.triggers, b.err = b.buildTblID2TriggerExecs(tblID2table, ast.TriggerEventDelete)
+	if b.err != nil {
+		return nil
+	}
+	deleteExec.mlogs, b.err = b.buildTblID2MViewLogWriters(tblID2table, "DELETE")
+	if b.err != nil {
+		return nil
+	}
 	return deleteExec
 }
 
+func (b *executorBuilder) buildMerge(v *plannercore.Merge) exec.Executor {
+	b.inUpdateStmt = true
+	if b.err = b.updateForUpdateTS(); b.err != nil {
+		return nil
+	}
+
+	selExec := b.build(v.SelectPlan)
+	if b.err != nil {
+		return nil
+	}
+	base := exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID(), selExec)
+	base.SetInitCap(chunk.ZeroCapacity)
+	mergeExec := &MergeExec{
+		BaseExecutor:  base,
+		tbl:           v.Table,
+		tblColPosInfo: v.TblColPosInfo,
+		clauses:       make([]*mergeClauseExec, 0, len(v.Clauses)),
+	}
+	// The executors of the actions have no child, the rows are given by the MergeExec.
+	for _, clause := range v.Clauses {
+		clauseExec := &mergeClauseExec{
+			notMatched: clause.NotMatched,
+			condition:  clause.Condition,
+			values:     clause.Values,
+		}
+		switch clause.Action {
+		case ast.MergeActionUpdate:
+			clauseExec.update = b.buildUpdateExec(clause.Update, exec.NewBaseExecutor(b.ctx, nil, clause.Update.ID()), selExec.Schema().Len())
+		case ast.MergeActionDelete:
+			clauseExec.delete = b.buildDeleteExec(clause.Delete, exec.NewBaseExecutor(b.ctx, nil, clause.Delete.ID()))
+		case ast.MergeActionInsert:
+			insertExec := b.buildInsert(clause.Insert)
+			if b.err == nil {
+				clauseExec.insert = insertExec.(*InsertExec)
+			}
+		}
+		if b.err != nil {
+			return nil
+		}
+		mergeExec.clauses = append(mergeExec.clauses, clauseExec)
+	}
+	return mergeExec
+}
+
 func (b *executorBuilder) updateForUpdateTS() error {
 	// GetStmtForUpdateTS will auto update the for update ts if it is necessary
 	_, err := sessiontxn.GetTxnManager(b.ctx).GetStmtForUpdateTS()
@@ -308,6 +308,11 @@ func getStmtDbLabel(stmtNode ast.StmtNode, resolveCtx *resolve.Context) map[stri
 				dbLabelSet[db] = struct{}{}
 			}
 		}
+	case *ast.MergeStmt:
+		dbLabels := getDbFromResultNode(x.TableRefs.TableRefs, resolveCtx)
+		for _, db := range dbLabels {
+			dbLabelSet[db] = struct{}{}
+		}
 	case *ast.CallStmt:
 		if x.Procedure != nil {
 			dbLabel := x.Procedure.Schema.O
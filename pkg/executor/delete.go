@@ -50,6 +50,10 @@ type DeleteExec struct {
 	fkChecks map[int64][]*FKCheckExec
 	// fkCascades contains the foreign key cascade. the map is tableID -> []*FKCascadeExec
 	fkCascades map[int64][]*FKCascadeExec
+	// triggers contains the DELETE triggers. the map is tableID -> *triggerExec
+	triggers map[int64]*triggerExec
+	// mlogs contains the log writers of the materialized views. the map is tableID -> *mviewLogWriter
+	mlogs map[int64]*mviewLogWriter
 
 	ignoreErr bool
 }
@@ -63,7 +67,7 @@ func (e *DeleteExec) Next(ctx context.Context, req *chunk.Chunk) error {
 	return e.deleteSingleTableByChunk(ctx)
 }
 
-func (e *DeleteExec) deleteOneRow(tbl table.Table, colInfo *plannercore.TblColPosInfo, isExtraHandle bool, row []types.Datum) error {
+func (e *DeleteExec) deleteOneRow(ctx context.Context, tbl table.Table, colInfo *plannercore.TblColPosInfo, isExtraHandle bool, row []types.Datum) error {
 	end := len(row)
 	if isExtraHandle {
 		end--
@@ -72,7 +76,7 @@ func (e *DeleteExec) deleteOneRow(tbl table.Table, colInfo *plannercore.TblColPo
 	if err != nil {
 		return err
 	}
-	err = e.removeRow(e.Ctx(), tbl, handle, row[:end], colInfo)
+	err = e.removeRow(ctx, tbl, handle, row[:end], colInfo)
 	if err != nil {
 		return err
 	}
@@ -143,7 +147,7 @@ func (e *DeleteExec) deleteSingleTableByChunk(ctx context.Context) error {
 					continue
 				}
 			}
-			err = e.deleteOneRow(tbl, colPosInfo, isExtraHandle, datumRow)
+			err = e.deleteOneRow(ctx, tbl, colPosInfo, isExtraHandle, datumRow)
 			if err != nil {
 				return err
 			}
@@ -258,7 +262,7 @@ func (e *DeleteExec) removeRowsInTblRowMap(ctx context.Context, tblRowMap tableR
 				}
 			}
 
-			err = e.removeRow(e.Ctx(), e.tblID2Table[id], h, val.handleVal, val.posInfo)
+			err = e.removeRow(ctx, e.tblID2Table[id], h, val.handleVal, val.posInfo)
 			return err == nil
 		})
 		if err != nil {
@@ -268,22 +272,37 @@ func (e *DeleteExec) removeRowsInTblRowMap(ctx context.Context, tblRowMap tableR
 	return nil
 }
 
-func (e *DeleteExec) removeRow(ctx sessionctx.Context, t table.Table, h kv.Handle, data []types.Datum, posInfo *plannercore.TblColPosInfo) error {
-	txn, err := e.Ctx().Txn(true)
+func (e *DeleteExec) removeRow(ctx context.Context, t table.Table, h kv.Handle, data []types.Datum, posInfo *plannercore.TblColPosInfo) error {
+	sctx := e.Ctx()
+	tid := t.Meta().ID
+	trigger := e.triggers[tid]
+	if trigger != nil {
+		if _, err := trigger.fireBefore(ctx, data[:len(t.Cols())], nil); err != nil {
+			return err
+		}
+	}
+	txn, err := sctx.Txn(true)
 	if err != nil {
 		return err
 	}
 
-	err = t.RemoveRecord(ctx.GetTableCtx(), txn, h, data, posInfo.IndexesRowLayout)
+	err = t.RemoveRecord(sctx.GetTableCtx(), txn, h, data, posInfo.IndexesRowLayout)
 	if err != nil {
 		return err
 	}
-	tid := t.Meta().ID
-	err = onRemoveRowForFK(ctx, data, e.fkChecks[tid], e.fkCascades[tid], e.ignoreErr)
+	err = onRemoveRowForFK(sctx, data, e.fkChecks[tid], e.fkCascades[tid], e.ignoreErr)
 	if err != nil {
 		return err
 	}
-	ctx.GetSessionVars().StmtCtx.AddAffectedRows(1)
+	if mlogs := e.mlogs[tid]; mlogs != nil {
+		if err = mlogs.onDelete(ctx, data); err != nil {
+			return err
+		}
+	}
+	sctx.GetSessionVars().StmtCtx.AddAffectedRows(1)
+	if trigger != nil {
+		return trigger.fireAfter(ctx, data[:len(t.Cols())], nil)
+	}
 	return nil
 }
 
@@ -343,6 +362,11 @@ func (e *DeleteExec) HasFKCascades() bool {
 	return len(e.fkCascades) > 0
 }
 
+// getTriggerExecs implements withRowTrigger interface.
+func (e *DeleteExec) getTriggerExecs() []*triggerExec {
+	return sortedTriggerExecs(e.triggers)
+}
+
 type handleInfoPair struct {
 	handleVal []types.Datum
 	posInfo   *plannercore.TblColPosInfo
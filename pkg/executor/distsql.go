@@ -1488,7 +1488,12 @@ func (w *tableWorker) compareData(ctx context.Context, task *lookupTableTask, ta
 				col := w.idxTblCols[i]
 				idxVal := idxRow.GetDatum(i, w.idxColTps[i])
 				tablecodec.TruncateIndexValue(&idxVal, w.idxLookup.index.Columns[i], col.ColumnInfo)
-				cmpRes, err := tables.CompareIndexAndVal(tc, vals[i], idxVal, collators[i], col.FieldType.IsArray() && vals[i].Kind() == types.KindMysqlJSON)
+				var cmpRes int
+				if w.idxLookup.index.IsRowStoreFullTextIndex() {
+					cmpRes = tables.CompareFullTextIndexAndVal(vals[i], idxVal, w.idxLookup.index, collators[i])
+				} else {
+					cmpRes, err = tables.CompareIndexAndVal(tc, vals[i], idxVal, collators[i], col.FieldType.IsArray() && vals[i].Kind() == types.KindMysqlJSON)
+				}
 				if err != nil {
 					return ir().ReportAdminCheckInconsistentWithColInfo(ctx,
 						handle,
@@ -0,0 +1,496 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/eventscheduler"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/vardef"
+	timerapi "github.com/pingcap/tidb/pkg/timer/api"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
+	"github.com/pingcap/tidb/pkg/util/timeutil"
+)
+
+// eventTimerClient returns the client to manage the timers of events.
+func eventTimerClient(sctx sessionctx.Context) (timerapi.TimerClient, error) {
+	if dom := domain.GetDomain(sctx); dom != nil {
+		if scheduler := dom.EventScheduler(); scheduler != nil {
+			return scheduler.Client(), nil
+		}
+	}
+	return nil, errors.New("event scheduler is not initialized")
+}
+
+// getEventTimer returns the timer of an event, the timer is nil if the event
+// does not exist.
+func getEventTimer(ctx context.Context, cli timerapi.TimerClient, schema, name string) (*timerapi.TimerRecord, error) {
+	timer, err := cli.GetTimerByKey(ctx, eventscheduler.TimerKey(schema, name))
+	if errors.ErrorEqual(err, timerapi.ErrTimerNotExist) {
+		return nil, nil
+	}
+	return timer, err
+}
+
+// getEventTimers returns the timers of the events in schema, or all the events
+// if schema is empty.
+func getEventTimers(ctx context.Context, sctx sessionctx.Context, schema string) ([]*timerapi.TimerRecord, error) {
+	cli, err := eventTimerClient(sctx)
+	if err != nil {
+		return nil, err
+	}
+	return cli.GetTimers(ctx, timerapi.WithKeyPrefix(eventscheduler.TimerKeyPrefix(schema)))
+}
+
+func (e *SimpleExec) executeCreateEvent(ctx context.Context, s *ast.CreateEventStmt) error {
+	sessVars := e.Ctx().GetSessionVars()
+	is := e.Ctx().GetInfoSchema().(infoschema.InfoSchema)
+	dbInfo, ok := is.SchemaByName(s.EventName.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(s.EventName.Schema.O)
+	}
+	cli, err := eventTimerClient(e.Ctx())
+	if err != nil {
+		return err
+	}
+	timer, err := getEventTimer(ctx, cli, dbInfo.Name.L, s.EventName.Name.L)
+	if err != nil {
+		return err
+	}
+	if timer != nil {
+		err = exeerrors.ErrEventAlreadyExists.GenWithStackByArgs(s.EventName.Name.O)
+		if s.IfNotExists {
+			sessVars.StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+
+	definer := s.Definer
+	if definer == nil || definer.CurrentUser {
+		definer = &auth.UserIdentity{}
+		if user := sessVars.User; user != nil {
+			definer.Username, definer.Hostname = user.AuthUsername, user.AuthHostname
+		}
+	}
+	now, err := eventStmtTime(e.Ctx())
+	if err != nil {
+		return err
+	}
+	sqlMode, _ := sessVars.GetSystemVar(vardef.SQLModeVar)
+	timeZone, _ := sessVars.GetSystemVar(vardef.TimeZone)
+	charsetClient, _ := sessVars.GetSystemVar(vardef.CharacterSetClient)
+	_, collationConnection := sessVars.GetCharsetInfo()
+	ev := &eventscheduler.Event{
+		Schema:              dbInfo.Name.O,
+		Name:                s.EventName.Name.O,
+		Definer:             fmt.Sprintf("%s@%s", definer.Username, definer.Hostname),
+		SQLMode:             sqlMode,
+		TimeZone:            timeZone,
+		CharsetClient:       charsetClient,
+		CollationConnection: collationConnection,
+		DatabaseCollation:   dbInfo.Collate,
+		Preserve:            s.Completion == ast.EventCompletionPreserve,
+		Comment:             s.Comment,
+		Created:             now,
+		LastAltered:         now,
+	}
+	if err = evalEventSchedule(e.Ctx(), s.Schedule, ev, now); err != nil {
+		return err
+	}
+	enable := s.Status != ast.EventStatusDisable
+	if checkEventExpired(e.Ctx(), ev, now, exeerrors.ErrEventCannotCreateInThePast) {
+		if !ev.Preserve {
+			return nil
+		}
+		enable = false
+	}
+
+	def := *s
+	def.IfNotExists = false
+	def.Definer = definer
+	def.EventName = &ast.TableName{Name: s.EventName.Name}
+	if ev.Definition, err = restoreEventDefinition(&def); err != nil {
+		return err
+	}
+	spec, err := eventTimerSpec(ev, now)
+	if err != nil {
+		return err
+	}
+	spec.Enable = enable
+	_, err = cli.CreateTimer(ctx, spec)
+	if errors.ErrorEqual(err, timerapi.ErrTimerExists) {
+		err = exeerrors.ErrEventAlreadyExists.GenWithStackByArgs(s.EventName.Name.O)
+		if s.IfNotExists {
+			sessVars.StmtCtx.AppendNote(err)
+			return nil
+		}
+	}
+	return err
+}
+
+func (e *SimpleExec) executeAlterEvent(ctx context.Context, s *ast.AlterEventStmt) error {
+	cli, err := eventTimerClient(e.Ctx())
+	if err != nil {
+		return err
+	}
+	timer, err := getEventTimer(ctx, cli, s.EventName.Schema.L, s.EventName.Name.L)
+	if err != nil {
+		return err
+	}
+	if timer == nil {
+		return exeerrors.ErrEventDoesNotExist.GenWithStackByArgs(s.EventName.Name.O)
+	}
+	ev, err := eventscheduler.UnmarshalEvent(timer.Data)
+	if err != nil {
+		return err
+	}
+	def, err := parseEventDefinition(e.Ctx(), ev)
+	if err != nil {
+		return err
+	}
+
+	now, err := eventStmtTime(e.Ctx())
+	if err != nil {
+		return err
+	}
+	ev.LastAltered = now
+	// Like MySQL, the user who alters the event becomes its definer, otherwise a
+	// user with only the EVENT privilege could make the event run any statement
+	// with the privileges of the original definer.
+	if user := e.Ctx().GetSessionVars().User; user != nil {
+		def.Definer = &auth.UserIdentity{Username: user.AuthUsername, Hostname: user.AuthHostname}
+		ev.Definer = fmt.Sprintf("%s@%s", user.AuthUsername, user.AuthHostname)
+	}
+	enable := timer.Enable
+	if s.Schedule != nil {
+		if err = evalEventSchedule(e.Ctx(), s.Schedule, ev, now); err != nil {
+			return err
+		}
+		def.Schedule = s.Schedule
+	}
+	if s.Completion != ast.EventCompletionUnspecified {
+		ev.Preserve = s.Completion == ast.EventCompletionPreserve
+		def.Completion = s.Completion
+	}
+	if s.Status != ast.EventStatusUnspecified {
+		enable = s.Status == ast.EventStatusEnable
+	}
+	if s.Comment != nil {
+		ev.Comment = *s.Comment
+		def.Comment = *s.Comment
+	}
+	if s.Body != nil {
+		def.Body = s.Body
+	}
+	if s.Schedule != nil || s.Completion != ast.EventCompletionUnspecified {
+		if checkEventExpired(e.Ctx(), ev, now, exeerrors.ErrEventCannotAlterInThePast) {
+			if !ev.Preserve {
+				return nil
+			}
+			enable = false
+		}
+	}
+
+	if s.NewName != nil {
+		if s.NewName.Schema.L == s.EventName.Schema.L && s.NewName.Name.L == s.EventName.Name.L {
+			return exeerrors.ErrEventSameName
+		}
+		is := e.Ctx().GetInfoSchema().(infoschema.InfoSchema)
+		dbInfo, ok := is.SchemaByName(s.NewName.Schema)
+		if !ok {
+			return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(s.NewName.Schema.O)
+		}
+		ev.Schema, ev.Name, ev.DatabaseCollation = dbInfo.Name.O, s.NewName.Name.O, dbInfo.Collate
+		def.EventName = &ast.TableName{Name: s.NewName.Name}
+	}
+	def.Status = ast.EventStatusEnable
+	if !enable {
+		def.Status = ast.EventStatusDisable
+	}
+	if ev.Definition, err = restoreEventDefinition(def); err != nil {
+		return err
+	}
+	spec, err := eventTimerSpec(ev, now)
+	if err != nil {
+		return err
+	}
+
+	if s.NewName != nil {
+		// The key of the timer is changed, so the event is moved to a new timer.
+		spec.Enable = enable
+		if s.Schedule == nil {
+			spec.Watermark = timer.Watermark
+		}
+		if _, err = cli.CreateTimer(ctx, spec); err != nil {
+			if errors.ErrorEqual(err, timerapi.ErrTimerExists) {
+				return exeerrors.ErrEventAlreadyExists.GenWithStackByArgs(s.NewName.Name.O)
+			}
+			return err
+		}
+		_, err = cli.DeleteTimer(ctx, timer.ID)
+		return err
+	}
+
+	opts := []timerapi.UpdateTimerOption{
+		timerapi.WithSetData(spec.Data),
+		timerapi.WithSetEnable(enable),
+	}
+	if s.Schedule != nil {
+		opts = append(opts,
+			timerapi.WithSetSchedExpr(spec.SchedPolicyType, spec.SchedPolicyExpr),
+			timerapi.WithSetTimeZone(spec.TimeZone),
+			timerapi.WithSetWatermark(spec.Watermark))
+	}
+	return cli.UpdateTimer(ctx, timer.ID, opts...)
+}
+
+func (e *SimpleExec) executeDropEvent(ctx context.Context, s *ast.DropEventStmt) error {
+	cli, err := eventTimerClient(e.Ctx())
+	if err != nil {
+		return err
+	}
+	timer, err := getEventTimer(ctx, cli, s.EventName.Schema.L, s.EventName.Name.L)
+	if err == nil && timer != nil {
+		var ok bool
+		if ok, err = cli.DeleteTimer(ctx, timer.ID); err == nil && ok {
+			return nil
+		}
+	}
+	if err != nil {
+		return err
+	}
+	err = exeerrors.ErrEventDoesNotExist.GenWithStackByArgs(s.EventName.Name.O)
+	if s.IfExists {
+		e.Ctx().GetSessionVars().StmtCtx.AppendNote(err)
+		return nil
+	}
+	return err
+}
+
+// evalEventSchedule evaluates the schedule of an event in the session and
+// saves the result to ev.
+func evalEventSchedule(sctx sessionctx.Context, schedule *ast.EventSchedule, ev *eventscheduler.Event, now time.Time) error {
+	ev.ExecuteAt, ev.IntervalValue, ev.IntervalField, ev.Starts, ev.Ends = nil, "", "", nil, nil
+	if schedule.At != nil {
+		at, err := evalEventTime(sctx, schedule.At, "AT")
+		if err != nil {
+			return err
+		}
+		ev.ExecuteAt = &at
+		return nil
+	}
+
+	d, err := expression.EvalSimpleAst(sctx.GetExprCtx(), schedule.Every)
+	if err != nil {
+		return err
+	}
+	if d.IsNull() {
+		return exeerrors.ErrEventIntervalNotPositiveOrTooBig
+	}
+	if ev.IntervalValue, err = d.ToString(); err != nil {
+		return err
+	}
+	ev.IntervalField = schedule.Unit.String()
+	if _, err = eventscheduler.ParseInterval(ev.IntervalValue, ev.IntervalField); err != nil {
+		return exeerrors.ErrEventIntervalNotPositiveOrTooBig
+	}
+	// Like MySQL, a recurring event starts when it is created by default.
+	starts := now
+	if schedule.Starts != nil {
+		if starts, err = evalEventTime(sctx, schedule.Starts, "STARTS"); err != nil {
+			return err
+		}
+	}
+	ev.Starts = &starts
+	if schedule.Ends != nil {
+		ends, err := evalEventTime(sctx, schedule.Ends, "ENDS")
+		if err != nil {
+			return err
+		}
+		if ends.Before(starts) {
+			return exeerrors.ErrEventEndsBeforeStarts
+		}
+		ev.Ends = &ends
+	}
+	return nil
+}
+
+func evalEventTime(sctx sessionctx.Context, expr ast.ExprNode, keyword string) (time.Time, error) {
+	d, err := expression.EvalSimpleAst(sctx.GetExprCtx(), expr)
+	if err != nil {
+		return time.Time{}, err
+	}
+	str := "NULL"
+	if !d.IsNull() {
+		str, _ = d.ToString()
+	}
+	tp := types.NewFieldType(mysql.TypeDatetime)
+	tp.SetDecimal(types.MaxFsp)
+	t, err := d.ConvertTo(sctx.GetSessionVars().StmtCtx.TypeCtx(), tp)
+	if err != nil || t.IsNull() || t.GetMysqlTime().IsZero() {
+		return time.Time{}, types.ErrWrongValue.GenWithStackByArgs(keyword, str)
+	}
+	return t.GetMysqlTime().GoTime(sctx.GetSessionVars().Location())
+}
+
+// checkEventExpired checks whether the event will never run again. A warning
+// is appended if so: the event is disabled if ON COMPLETION PRESERVE is set,
+// otherwise it is dropped or unchanged according to errNotPreserve.
+// eventStmtTime returns the time of the statement in seconds, which is the same
+// as CURRENT_TIMESTAMP, so an event scheduled AT CURRENT_TIMESTAMP is not in the past.
+func eventStmtTime(sctx sessionctx.Context) (time.Time, error) {
+	now, err := sctx.GetExprCtx().GetEvalCtx().CurrentTime()
+	if err != nil {
+		return now, err
+	}
+	return now.Truncate(time.Second), nil
+}
+
+func checkEventExpired(sctx sessionctx.Context, ev *eventscheduler.Event, now time.Time, errNotPreserve error) bool {
+	expiredAt := ev.ExecuteAt
+	if expiredAt == nil {
+		expiredAt = ev.Ends
+	}
+	if expiredAt == nil || !expiredAt.Before(now) {
+		return false
+	}
+	if ev.Preserve {
+		sctx.GetSessionVars().StmtCtx.AppendWarning(exeerrors.ErrEventExecTimeInThePast)
+	} else {
+		sctx.GetSessionVars().StmtCtx.AppendNote(errNotPreserve)
+	}
+	return true
+}
+
+// eventTimerSpec returns the spec of the timer which runs the event.
+func eventTimerSpec(ev *eventscheduler.Event, now time.Time) (spec timerapi.TimerSpec, err error) {
+	tp, expr, watermark, err := ev.Schedule(now)
+	if err != nil {
+		return spec, plannererrors.ErrNotSupportedYet.GenWithStackByArgs(err.Error())
+	}
+	data, err := ev.Marshal()
+	if err != nil {
+		return spec, err
+	}
+	return timerapi.TimerSpec{
+		Key:             eventscheduler.TimerKey(ev.Schema, ev.Name),
+		Data:            data,
+		TimeZone:        ev.TimeZone,
+		SchedPolicyType: tp,
+		SchedPolicyExpr: expr,
+		HookClass:       eventscheduler.HookClass,
+		Watermark:       watermark,
+		Enable:          true,
+	}, nil
+}
+
+func restoreEventDefinition(s *ast.CreateEventStmt) (string, error) {
+	var sb strings.Builder
+	if err := s.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// parseEventDefinition parses the saved CREATE EVENT statement of an event.
+func parseEventDefinition(sctx sessionctx.Context, ev *eventscheduler.Event) (*ast.CreateEventStmt, error) {
+	sqlMode, err := mysql.GetSQLMode(ev.SQLMode)
+	if err != nil {
+		return nil, err
+	}
+	p := parser.New()
+	p.SetSQLMode(sqlMode)
+	p.SetParserConfig(sctx.GetSessionVars().BuildParserConfig())
+	node, err := p.ParseOneStmt(ev.Definition, "", "")
+	if err != nil {
+		return nil, err
+	}
+	def, ok := node.(*ast.CreateEventStmt)
+	if !ok {
+		return nil, errors.Errorf("invalid definition of event %s", ev.FullName())
+	}
+	return def, nil
+}
+
+// ExecuteEventBody runs the body of an event through the session of sctx. The
+// session should have been authenticated as the definer of the event.
+func ExecuteEventBody(ctx context.Context, sctx sessionctx.Context, ev *eventscheduler.Event) error {
+	def, err := parseEventDefinition(sctx, ev)
+	if err != nil {
+		return err
+	}
+	e := &procedureExec{sctx: sctx, exec: sctx.GetSQLExecutor()}
+	_, err = e.execStmts(ctx, []ast.StmtNode{def.Body})
+	return err
+}
+
+// eventAttrs holds the attributes of an event shown by SHOW EVENTS and
+// information_schema.EVENTS. The absent attributes are nil.
+type eventAttrs struct {
+	tp            string
+	executeAt     any
+	intervalValue any
+	intervalField any
+	starts        any
+	ends          any
+	status        string
+	lastExecuted  any
+}
+
+// eventRow returns the attributes of an event which are derived from its definition
+// and its timer.
+func eventRow(ev *eventscheduler.Event, timer *timerapi.TimerRecord) *eventAttrs {
+	loc, err := timeutil.ParseTimeZone(ev.TimeZone)
+	if err != nil {
+		loc = timeutil.SystemLocation()
+	}
+	toDatetime := func(t *time.Time) any {
+		if t == nil || t.IsZero() {
+			return nil
+		}
+		return types.NewTime(types.FromGoTime(t.In(loc)), mysql.TypeDatetime, types.DefaultFsp)
+	}
+	row := &eventAttrs{tp: "RECURRING", status: "ENABLED"}
+	if ev.IsOneTime() {
+		row.tp = "ONE TIME"
+		row.executeAt = toDatetime(ev.ExecuteAt)
+	} else {
+		row.intervalValue, row.intervalField = ev.IntervalValue, ev.IntervalField
+		row.starts, row.ends = toDatetime(ev.Starts), toDatetime(ev.Ends)
+	}
+	if !timer.Enable {
+		row.status = "DISABLED"
+	}
+	if summary, err := eventscheduler.UnmarshalSummary(timer.SummaryData); err == nil {
+		row.lastExecuted = toDatetime(&summary.LastExecuted)
+	}
+	return row
+}
@@ -54,7 +54,11 @@ type rowToEncode struct {
 	// endOffset represents the offset after the current row in encode reader.
 	// it will be negative if the data source is not file.
 	endOffset int64
-	resetFn   func()
+	// startPos and endPos are the parser positions before and after the row,
+	// they will be negative if the data source is not file.
+	startPos int64
+	endPos   int64
+	resetFn  func()
 }
 
 type encodeReaderFn func(ctx context.Context) (data rowToEncode, closed bool, err error)
@@ -82,10 +86,13 @@ func parserEncodeReader(parser mydump.Parser, endOffset int64, filename string)
 			return
 		}
 		lastRow := parser.LastRow()
+		endPos, _ := parser.Pos()
 		data = rowToEncode{
 			row:       lastRow.Row,
 			rowID:     lastRow.RowID,
 			endOffset: currOffset,
+			startPos:  readPos,
+			endPos:    endPos,
 			resetFn:   func() { parser.RecycleRow(lastRow) },
 		}
 		return
@@ -122,6 +129,8 @@ func (r *queryChunkEncodeReader) readRow(ctx context.Context) (data rowToEncode,
 		row:       row.GetDatumRow(r.currChk.Fields),
 		rowID:     r.currChk.RowIDOffset + int64(r.cursor),
 		endOffset: -1,
+		startPos:  -1,
+		endPos:    -1,
 		resetFn:   func() {},
 	}
 	return
@@ -196,6 +205,8 @@ type chunkEncoder struct {
 	logger    *zap.Logger
 	encoder   KVEncoder
 	keyspace  []byte
+	// rejecter is nil if the rows which fail to encode are not allowed.
+	rejecter *rowRejecter
 
 	// total duration takes by read/encode.
 	readTotalDur   time.Duration
@@ -212,6 +223,7 @@ func newChunkEncoder(
 	logger *zap.Logger,
 	encoder KVEncoder,
 	keyspace []byte,
+	rejecter *rowRejecter,
 ) *chunkEncoder {
 	return &chunkEncoder{
 		chunkName:     chunkName,
@@ -221,11 +233,12 @@ func newChunkEncoder(
 		logger:        logger,
 		encoder:       encoder,
 		keyspace:      keyspace,
+		rejecter:      rejecter,
 		groupChecksum: verify.NewKVGroupChecksumWithKeyspace(keyspace),
 	}
 }
 
-func (p *chunkEncoder) encodeLoop(ctx context.Context) error {
+func (p *chunkEncoder) encodeLoop(ctx context.Context) (err error) {
 	var (
 		encodedBytesCounter, encodedRowsCounter prometheus.Counter
 		readDur, encodeDur                      time.Duration
@@ -234,6 +247,13 @@ func (p *chunkEncoder) encodeLoop(ctx context.Context) error {
 		rowBatchByteSize                        uint64
 		currOffset                              int64
 	)
+	if p.rejecter != nil {
+		defer func() {
+			if err2 := p.rejecter.close(ctx); err == nil {
+				err = err2
+			}
+		}()
+	}
 	metrics, _ := metric.GetCommonMetric(ctx)
 	if metrics != nil {
 		encodedBytesCounter = metrics.BytesCounter.WithLabelValues(metric.StateRestored)
@@ -301,9 +321,14 @@ func (p *chunkEncoder) encodeLoop(ctx context.Context) error {
 		encodeDurStart := time.Now()
 		kvs, encodeErr := p.encoder.Encode(data.row, data.rowID)
 		currOffset = data.endOffset
+		if encodeErr != nil && p.rejecter != nil {
+			if encodeErr = p.rejecter.reject(ctx, data, encodeErr); encodeErr == nil {
+				data.resetFn()
+				continue
+			}
+		}
 		data.resetFn()
 		if encodeErr != nil {
-			// todo: record and ignore encode error if user set max-errors param
 			return common.ErrEncodeKV.Wrap(encodeErr).GenWithStackByArgs(p.chunkName, data.endOffset)
 		}
 		encodeDur += time.Since(encodeDurStart)
@@ -387,6 +412,7 @@ func NewFileChunkProcessor(
 	dataWriter backend.EngineWriter,
 	indexWriter backend.EngineWriter,
 	groupChecksum *verify.KVGroupChecksum,
+	rejecter *rowRejecter,
 ) ChunkProcessor {
 	chunkLogger := logger.With(zap.String("key", chunk.GetKey()))
 	deliver := &dataDeliver{
@@ -407,6 +433,7 @@ func NewFileChunkProcessor(
 			chunkLogger,
 			encoder,
 			keyspace,
+			rejecter,
 		),
 		logger:        chunkLogger,
 		groupChecksum: groupChecksum,
@@ -551,6 +578,7 @@ func newQueryChunkProcessor(
 			chunkLogger,
 			encoder,
 			keyspace,
+			nil,
 		),
 		logger:        chunkLogger,
 		groupChecksum: groupChecksum,
@@ -103,6 +103,7 @@ func ProcessChunkWithWriter(
 		cp = NewFileChunkProcessor(
 			parser, encoder, tableImporter.GetKeySpace(), chunk, logger,
 			tableImporter.diskQuotaLock, dataWriter, indexWriter, groupChecksum,
+			tableImporter.newRowRejecter(chunk, logger),
 		)
 	case DataSourceTypeQuery:
 		cp = newQueryChunkProcessor(
@@ -16,6 +16,7 @@ package importer
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"math"
@@ -76,6 +77,10 @@ const (
 	DataFormatSQL = "sql"
 	// DataFormatParquet represents the data source file of IMPORT INTO is parquet.
 	DataFormatParquet = "parquet"
+	// DataFormatNDJSON represents the data source file of IMPORT INTO is newline-delimited JSON.
+	DataFormatNDJSON = "ndjson"
+	// DataFormatAvro represents the data source file of IMPORT INTO is avro object container file.
+	DataFormatAvro = "avro"
 
 	// DefaultDiskQuota is the default disk quota for IMPORT INTO
 	DefaultDiskQuota = config.ByteSize(50 << 30) // 50GiB
@@ -96,6 +101,8 @@ const (
 	maxWriteSpeedOption       = "max_write_speed"
 	checksumTableOption       = "checksum_table"
 	recordErrorsOption        = "record_errors"
+	maxErrorsOption           = "max_errors"
+	errorFileOption           = "error_file"
 	detachedOption            = "detached"
 	// if 'import mode' enabled, TiKV will:
 	//  - set level0_stop_writes_trigger = max(old, 1 << 30)
@@ -110,6 +117,7 @@ const (
 	disableTiKVImportModeOption = "disable_tikv_import_mode"
 	cloudStorageURIOption       = "cloud_storage_uri"
 	disablePrecheckOption       = "disable_precheck"
+	fieldPathsOption            = "field_paths"
 	// used for test
 	maxEngineSizeOption  = "__max_engine_size"
 	forceMergeStep       = "__force_merge_step"
@@ -133,6 +141,8 @@ var (
 		maxWriteSpeedOption:         true,
 		checksumTableOption:         true,
 		recordErrorsOption:          true,
+		maxErrorsOption:             true,
+		errorFileOption:             true,
 		detachedOption:              false,
 		disableTiKVImportModeOption: false,
 		maxEngineSizeOption:         true,
@@ -140,6 +150,7 @@ var (
 		manualRecoveryOption:        false,
 		cloudStorageURIOption:       true,
 		disablePrecheckOption:       false,
+		fieldPathsOption:            true,
 	}
 
 	csvOnlyOptions = map[string]struct{}{
@@ -163,6 +174,7 @@ var (
 
 	supportedSuffixForServerDisk = []string{
 		".csv", ".sql", ".parquet",
+		".ndjson", ".jsonl", ".avro",
 		".gz", ".gzip",
 		".zstd", ".zst",
 		".snappy",
@@ -252,6 +264,15 @@ type Plan struct {
 	MaxEngineSize         config.ByteSize
 	CloudStorageURI       string
 	DisablePrecheck       bool
+	// FieldPaths maps the column names to the paths of the fields in NDJSON and
+	// avro files, only used for these formats.
+	FieldPaths map[string]string
+	// MaxErrors is the number of rows which fail to encode that are skipped
+	// before the job fails.
+	MaxErrors int64
+	// ErrorFile is the URI of the external storage where the rejected rows
+	// are written to, only effective when MaxErrors > 0.
+	ErrorFile string
 
 	// used for checksum in physical mode
 	DistSQLScanConcurrency int
@@ -312,6 +333,8 @@ type LoadDataController struct {
 	dataFiles []*mydump.SourceFileMeta
 	// GlobalSortStore is used to store sorted data when using global sort.
 	GlobalSortStore storage.ExternalStorage
+	// errorStore is used to store the rejected rows, see Plan.ErrorFile.
+	errorStore storage.ExternalStorage
 	// ExecuteNodesCnt is the count of execute nodes.
 	ExecuteNodesCnt int
 }
@@ -526,7 +549,9 @@ func (e *LoadDataController) checkFieldParams() error {
 		return exeerrors.ErrLoadDataEmptyPath
 	}
 	if e.InImportInto {
-		if e.Format != DataFormatCSV && e.Format != DataFormatParquet && e.Format != DataFormatSQL {
+		switch e.Format {
+		case DataFormatCSV, DataFormatParquet, DataFormatSQL, DataFormatNDJSON, DataFormatAvro:
+		default:
 			return exeerrors.ErrLoadDataUnsupportedFormat.GenWithStackByArgs(e.Format)
 		}
 	} else {
@@ -600,6 +625,9 @@ func (p *Plan) initOptions(ctx context.Context, seCtx sessionctx.Context, option
 			}
 		}
 	}
+	if _, ok := specifiedOptions[fieldPathsOption]; ok && p.Format != DataFormatNDJSON && p.Format != DataFormatAvro {
+		return exeerrors.ErrLoadDataUnsupportedOption.FastGenByArgs(fieldPathsOption, "non-NDJSON or non-avro format")
+	}
 	if p.DataSourceType == DataSourceTypeQuery {
 		for k := range specifiedOptions {
 			if _, ok := allowedOptionsOfImportFromQuery[k]; !ok {
@@ -727,6 +755,23 @@ func (p *Plan) initOptions(ctx context.Context, seCtx sessionctx.Context, option
 		}
 		p.MaxRecordedErrors = vInt
 	}
+	if opt, ok := specifiedOptions[maxErrorsOption]; ok {
+		vInt, err := optAsInt64(opt)
+		if err != nil || vInt < 0 {
+			return exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+		}
+		p.MaxErrors = vInt
+	}
+	if opt, ok := specifiedOptions[errorFileOption]; ok {
+		v, err := optAsString(opt)
+		if err != nil || v == "" {
+			return exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+		}
+		if _, err = storage.ParseBackend(v, nil); err != nil {
+			return exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+		}
+		p.ErrorFile = v
+	}
 	if _, ok := specifiedOptions[detachedOption]; ok {
 		p.Detached = true
 	}
@@ -764,6 +809,21 @@ func (p *Plan) initOptions(ctx context.Context, seCtx sessionctx.Context, option
 	if _, ok := specifiedOptions[disablePrecheckOption]; ok {
 		p.DisablePrecheck = true
 	}
+	if opt, ok := specifiedOptions[fieldPathsOption]; ok {
+		v, err := optAsString(opt)
+		if err != nil {
+			return exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+		}
+		// the value is a JSON object, like '{"city": "address.city"}'
+		if err = json.Unmarshal([]byte(v), &p.FieldPaths); err != nil {
+			return exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+		}
+		for col, path := range p.FieldPaths {
+			if len(col) == 0 || slices.Contains(strings.Split(path, "."), "") {
+				return exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+			}
+		}
+	}
 	if _, ok := specifiedOptions[forceMergeStep]; ok {
 		p.ForceMergeStep = true
 	}
@@ -791,6 +851,10 @@ func (p *Plan) initOptions(ctx context.Context, seCtx sessionctx.Context, option
 		return exeerrors.ErrInvalidOptionVal.FastGenByArgs("lines_terminated_by, should not be empty when use split_file")
 	}
 
+	if p.ErrorFile != "" && p.MaxErrors == 0 {
+		return exeerrors.ErrInvalidOptionVal.FastGenByArgs("error_file, should be used with max_errors > 0")
+	}
+
 	p.adjustOptions(targetNodeCPUCnt)
 	return nil
 }
@@ -849,7 +913,7 @@ func (p *Plan) initParameters(plan *plannercore.ImportInto) error {
 			// directly convert it to constant.
 			cons := opt.Value.(*expression.Constant)
 			val := fmt.Sprintf("%v", cons.Value.GetValue())
-			if opt.Name == cloudStorageURIOption {
+			if opt.Name == cloudStorageURIOption || opt.Name == errorFileOption {
 				val = ast.RedactURL(val)
 			}
 			optionMap[opt.Name] = val
@@ -1032,6 +1096,20 @@ func (e *LoadDataController) InitDataStore(ctx context.Context) error {
 		}
 		e.GlobalSortStore = s
 	}
+
+	if e.Plan.ErrorFile != "" {
+		target := "error file"
+		errorFileURL, err3 := storage.ParseRawURL(e.Plan.ErrorFile)
+		if err3 != nil {
+			return exeerrors.ErrLoadDataInvalidURI.GenWithStackByArgs(target,
+				err3.Error())
+		}
+		s, err = e.initExternalStore(ctx, errorFileURL, target)
+		if err != nil {
+			return err
+		}
+		e.errorStore = s
+	}
 	return nil
 }
 
@@ -1043,6 +1121,9 @@ func (e *LoadDataController) Close() {
 	if e.GlobalSortStore != nil {
 		e.GlobalSortStore.Close()
 	}
+	if e.errorStore != nil {
+		e.errorStore.Close()
+	}
 }
 
 func (*LoadDataController) initExternalStore(ctx context.Context, u *url.URL, target string) (storage.ExternalStorage, error) {
@@ -1191,6 +1272,10 @@ func (e *LoadDataController) getSourceType() mydump.SourceType {
 	switch e.Format {
 	case DataFormatParquet:
 		return mydump.SourceTypeParquet
+	case DataFormatNDJSON:
+		return mydump.SourceTypeNDJSON
+	case DataFormatAvro:
+		return mydump.SourceTypeAvro
 	case DataFormatDelimitedData, DataFormatCSV:
 		return mydump.SourceTypeCSV
 	default:
@@ -1269,15 +1354,50 @@ func (e *LoadDataController) GetParser(
 			reader,
 			dataFileInfo.Remote.Path,
 		)
+	case DataFormatNDJSON:
+		parser, err = mydump.NewNDJSONParser(
+			ctx,
+			e.FieldPaths,
+			reader,
+			LoadDataReadBlockSize,
+			nil,
+		)
+	case DataFormatAvro:
+		parser, err = mydump.NewAvroParser(
+			ctx,
+			e.FieldPaths,
+			reader,
+		)
 	}
 	if err != nil {
 		return nil, exeerrors.ErrLoadDataWrongFormatConfig.GenWithStack(err.Error())
 	}
+	if e.Format == DataFormatNDJSON || e.Format == DataFormatAvro {
+		// the fields are mapped to the input fields by name.
+		parser.SetColumns(e.getInputFieldNames())
+	}
 	parser.SetLogger(litlog.Logger{Logger: logutil.Logger(ctx)})
 
 	return parser, nil
 }
 
+// getInputFieldNames returns the names of the input fields, which are the names
+// of the columns or the user variables.
+func (e *LoadDataController) getInputFieldNames() []string {
+	names := make([]string, 0, len(e.FieldMappings))
+	for _, m := range e.FieldMappings {
+		switch {
+		case m.Column != nil:
+			names = append(names, m.Column.Name.O)
+		case m.UserVar != nil:
+			names = append(names, m.UserVar.Name)
+		default:
+			names = append(names, "")
+		}
+	}
+	return names
+}
+
 // HandleSkipNRows skips the first N rows of the data file.
 func (e *LoadDataController) HandleSkipNRows(parser mydump.Parser) error {
 	// handle IGNORE N LINES
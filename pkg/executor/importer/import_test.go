@@ -175,6 +175,31 @@ func TestInitOptionsPositiveCase(t *testing.T) {
 	err = plan.initOptions(ctx, sctx, convertOptions(stmt.(*ast.ImportIntoStmt).Options))
 	require.NoError(t, err, sql4)
 	require.Equal(t, "", plan.CloudStorageURI, sql4)
+
+	// field paths of NDJSON
+	sql5 := fmt.Sprintf("import into t from '/file.ndjson' with %s='{\"city\": \"address.city\"}'", fieldPathsOption)
+	stmt, err = p.ParseOneStmt(sql5, "", "")
+	require.NoError(t, err, sql5)
+	plan = &Plan{Format: DataFormatNDJSON}
+	err = plan.initOptions(ctx, sctx, convertOptions(stmt.(*ast.ImportIntoStmt).Options))
+	require.NoError(t, err, sql5)
+	require.Equal(t, map[string]string{"city": "address.city"}, plan.FieldPaths, sql5)
+
+	// rejected rows
+	sql6 := fmt.Sprintf("import into t from '/file.csv' with %s=10, %s='s3://bucket/errors'", maxErrorsOption, errorFileOption)
+	stmt, err = p.ParseOneStmt(sql6, "", "")
+	require.NoError(t, err, sql6)
+	plan = &Plan{Format: DataFormatCSV}
+	err = plan.initOptions(ctx, sctx, convertOptions(stmt.(*ast.ImportIntoStmt).Options))
+	require.NoError(t, err, sql6)
+	require.Equal(t, int64(10), plan.MaxErrors, sql6)
+	require.Equal(t, "s3://bucket/errors", plan.ErrorFile, sql6)
+	sql7 := fmt.Sprintf("import into t from '/file.csv' with %s='s3://bucket/errors'", errorFileOption)
+	stmt, err = p.ParseOneStmt(sql7, "", "")
+	require.NoError(t, err, sql7)
+	plan = &Plan{Format: DataFormatCSV}
+	err = plan.initOptions(ctx, sctx, convertOptions(stmt.(*ast.ImportIntoStmt).Options))
+	require.ErrorIs(t, err, exeerrors.ErrInvalidOptionVal, sql7)
 }
 
 func TestAdjustOptions(t *testing.T) {
@@ -101,6 +101,9 @@ func (ip *ImportParameters) String() string {
 type JobSummary struct {
 	// ImportedRows is the number of rows imported into TiKV.
 	ImportedRows uint64 `json:"imported-rows,omitempty"`
+	// RejectedRows is the number of rows which fail to encode and are skipped,
+	// see Plan.MaxErrors.
+	RejectedRows uint64 `json:"rejected-rows,omitempty"`
 }
 
 // JobInfo is the information of import into job.
@@ -277,6 +280,14 @@ func FailJob(ctx context.Context, conn sqlexec.SQLExecutor, jobID int64, errorMs
 	return err
 }
 
+// TruncateTable truncates the target table of a failed import into job, to
+// remove the data ingested before the job fails.
+func TruncateTable(ctx context.Context, conn sqlexec.SQLExecutor, dbName, tableName string) error {
+	ctx = util.WithInternalSourceType(ctx, kv.InternalImportInto)
+	_, err := conn.ExecuteInternal(ctx, "TRUNCATE TABLE %n.%n", dbName, tableName)
+	return err
+}
+
 func convert2JobInfo(row chunk.Row) (*JobInfo, error) {
 	// start_time, end_time, summary, error_message can be NULL, need to use row.IsNull() to check.
 	startTime, endTime := types.ZeroTime, types.ZeroTime
@@ -0,0 +1,168 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package importer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/pkg/lightning/checkpoints"
+	"github.com/pingcap/tidb/pkg/lightning/mydump"
+	"go.uber.org/zap"
+)
+
+// ErrMaxErrorsExceeded is returned when the rows which fail to encode exceed
+// max_errors.
+var ErrMaxErrorsExceeded = errors.Normalize("the number of rejected rows exceeds max_errors %d, %s",
+	errors.RFCCodeText("Importer:ErrMaxErrorsExceeded"))
+
+// rejectedRow is a line of the error file, which records a row that fails to
+// encode.
+type rejectedRow struct {
+	File   string `json:"file"`
+	Offset int64  `json:"offset"`
+	// Raw is the raw content of the row in the data file, it's only available
+	// for the uncompressed CSV, SQL and NDJSON files.
+	Raw string `json:"raw,omitempty"`
+	// Values is the parsed values of the row, it's used when Raw is not available.
+	Values []*string `json:"values,omitempty"`
+	Error  string    `json:"error"`
+}
+
+// rowRejecter skips the rows of a chunk which fail to encode, until the
+// rejected rows of the importer exceeds Plan.MaxErrors. The rejected rows are
+// written into a file under Plan.ErrorFile if it's set, which is only created
+// when there is a rejected row.
+type rowRejecter struct {
+	logger    *zap.Logger
+	maxErrors int64
+	rejected  *atomic.Int64
+
+	dataStore storage.ExternalStorage
+	fileMeta  *mydump.SourceFileMeta
+
+	errorStore storage.ExternalStorage
+	errorFile  string
+	writer     storage.ExternalFileWriter
+}
+
+func (ti *TableImporter) newRowRejecter(chunk *checkpoints.ChunkCheckpoint, logger *zap.Logger) *rowRejecter {
+	if ti.MaxErrors <= 0 {
+		return nil
+	}
+	return &rowRejecter{
+		logger:     logger,
+		maxErrors:  ti.MaxErrors,
+		rejected:   &ti.rejectedRows,
+		dataStore:  ti.dataStore,
+		fileMeta:   &chunk.FileMeta,
+		errorStore: ti.errorStore,
+		errorFile:  errorFileName(ti.id, chunk),
+	}
+}
+
+// errorFileName returns the name of the error file of a chunk.
+func errorFileName(id string, chunk *checkpoints.ChunkCheckpoint) string {
+	path := strings.ReplaceAll(strings.TrimLeft(chunk.FileMeta.Path, "/"), "/", "_")
+	return fmt.Sprintf("%s/%s.%d.jsonl", id, path, chunk.Chunk.Offset)
+}
+
+// reject records a row which fails to encode, it returns the encode error if
+// the rejected rows exceed the limit.
+func (r *rowRejecter) reject(ctx context.Context, data rowToEncode, encodeErr error) error {
+	if r.rejected.Add(1) > r.maxErrors {
+		return ErrMaxErrorsExceeded.GenWithStackByArgs(r.maxErrors, "the last error: "+encodeErr.Error())
+	}
+	r.logger.Warn("skip the row which fails to encode",
+		zap.Int64("offset", data.startPos), zap.Error(encodeErr))
+	if r.errorStore == nil {
+		return nil
+	}
+
+	row := rejectedRow{
+		File:   r.fileMeta.Path,
+		Offset: data.startPos,
+		Error:  encodeErr.Error(),
+	}
+	raw, err := r.readRaw(ctx, data)
+	if err != nil {
+		r.logger.Warn("failed to read the raw content of the rejected row", zap.Error(err))
+	}
+	if raw != nil {
+		row.Raw = string(raw)
+	} else {
+		row.Values = make([]*string, 0, len(data.row))
+		for _, d := range data.row {
+			if d.IsNull() {
+				row.Values = append(row.Values, nil)
+				continue
+			}
+			s, err := d.ToString()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			row.Values = append(row.Values, &s)
+		}
+	}
+	line, err := json.Marshal(&row)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	if r.writer == nil {
+		r.writer, err = r.errorStore.Create(ctx, r.errorFile, nil)
+		if err != nil {
+			return errors.Annotatef(err, "create error file %s", r.errorFile)
+		}
+	}
+	if _, err = r.writer.Write(ctx, append(line, '\n')); err != nil {
+		return errors.Annotatef(err, "write error file %s", r.errorFile)
+	}
+	return nil
+}
+
+// readRaw reads the raw content of a row from the data file, it returns nil
+// if the row doesn't map to a byte range of the file.
+func (r *rowRejecter) readRaw(ctx context.Context, data rowToEncode) ([]byte, error) {
+	if r.fileMeta.Compression != mydump.CompressionNone || data.startPos < 0 || data.endPos <= data.startPos {
+		return nil, nil
+	}
+	switch r.fileMeta.Type {
+	case mydump.SourceTypeCSV, mydump.SourceTypeSQL, mydump.SourceTypeNDJSON:
+	default:
+		return nil, nil
+	}
+	buf := make([]byte, data.endPos-data.startPos)
+	if _, err := storage.ReadDataInRange(ctx, r.dataStore, r.fileMeta.Path, data.startPos, buf); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf, "\r\n"), nil
+}
+
+// close completes the upload of the error file.
+func (r *rowRejecter) close(ctx context.Context) error {
+	if r.writer == nil {
+		return nil
+	}
+	err := r.writer.Close(ctx)
+	r.writer = nil
+	return errors.Annotatef(err, "close error file %s", r.errorFile)
+}
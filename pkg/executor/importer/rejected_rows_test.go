@@ -0,0 +1,120 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package importer
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/pkg/lightning/backend/kv"
+	"github.com/pingcap/tidb/pkg/lightning/checkpoints"
+	"github.com/pingcap/tidb/pkg/lightning/common"
+	"github.com/pingcap/tidb/pkg/lightning/mydump"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type badRowEncoder struct{}
+
+func (badRowEncoder) Encode(row []types.Datum, _ int64) (*kv.Pairs, error) {
+	if row[0].GetString() == "bad" {
+		return nil, errors.New("invalid value")
+	}
+	return &kv.Pairs{}, nil
+}
+
+func (badRowEncoder) Close() error {
+	return nil
+}
+
+func TestRowRejecter(t *testing.T) {
+	ctx := context.Background()
+	dataStore := storage.NewMemStorage()
+	errorStore, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+	content := "good\nbad\ngood\nbad\n"
+	require.NoError(t, dataStore.WriteFile(ctx, "/db/t.csv", []byte(content)))
+
+	// rows are at offset 0, 5, 9, 14
+	offsets := []int64{0, 5, 9, 14, int64(len(content))}
+	values := []string{"good", "bad", "good", "bad"}
+	newReader := func() encodeReaderFn {
+		i := 0
+		return func(context.Context) (rowToEncode, bool, error) {
+			if i >= len(values) {
+				return rowToEncode{}, true, nil
+			}
+			i++
+			return rowToEncode{
+				row:       []types.Datum{types.NewStringDatum(values[i-1])},
+				rowID:     int64(i),
+				endOffset: offsets[i],
+				startPos:  offsets[i-1],
+				endPos:    offsets[i],
+				resetFn:   func() {},
+			}, false, nil
+		}
+	}
+	sendFn := func(context.Context, *encodedKVGroupBatch) error { return nil }
+	chunk := &checkpoints.ChunkCheckpoint{
+		FileMeta: mydump.SourceFileMeta{Path: "/db/t.csv", Type: mydump.SourceTypeCSV},
+	}
+
+	var rejected atomic.Int64
+	rejecter := &rowRejecter{
+		logger:     zap.NewNop(),
+		maxErrors:  2,
+		rejected:   &rejected,
+		dataStore:  dataStore,
+		fileMeta:   &chunk.FileMeta,
+		errorStore: errorStore,
+		errorFile:  errorFileName("1", chunk),
+	}
+	require.Equal(t, "1/db_t.csv.0.jsonl", rejecter.errorFile)
+	enc := newChunkEncoder("t.csv:0", newReader(), 0, sendFn, zap.NewNop(), badRowEncoder{}, nil, rejecter)
+	require.NoError(t, enc.encodeLoop(ctx))
+	require.EqualValues(t, 2, rejected.Load())
+	data, err := errorStore.ReadFile(ctx, "1/db_t.csv.0.jsonl")
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		`{"file":"/db/t.csv","offset":5,"raw":"bad","error":"invalid value"}`,
+		`{"file":"/db/t.csv","offset":14,"raw":"bad","error":"invalid value"}`,
+	}, strings.Split(strings.TrimSpace(string(data)), "\n"))
+
+	// the values are recorded if the raw content is not available
+	chunk.FileMeta.Type = mydump.SourceTypeParquet
+	rejecter.maxErrors = 4
+	enc = newChunkEncoder("t.csv:0", newReader(), 0, sendFn, zap.NewNop(), badRowEncoder{}, nil, rejecter)
+	require.NoError(t, enc.encodeLoop(ctx))
+	data, err = errorStore.ReadFile(ctx, "1/db_t.csv.0.jsonl")
+	require.NoError(t, err)
+	require.Contains(t, string(data), `{"file":"/db/t.csv","offset":5,"values":["bad"],"error":"invalid value"}`)
+
+	// exceeds max_errors
+	enc = newChunkEncoder("t.csv:0", newReader(), 0, sendFn, zap.NewNop(), badRowEncoder{}, nil, rejecter)
+	err = enc.encodeLoop(ctx)
+	require.ErrorIs(t, err, common.ErrEncodeKV)
+	require.ErrorContains(t, err, "exceeds max_errors 4")
+	require.EqualValues(t, 5, rejected.Load())
+
+	// no rejecter
+	enc = newChunkEncoder("t.csv:0", newReader(), 0, sendFn, zap.NewNop(), badRowEncoder{}, nil, nil)
+	require.ErrorIs(t, enc.encodeLoop(ctx), common.ErrEncodeKV)
+}
@@ -24,6 +24,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 	"unicode/utf8"
 
@@ -242,6 +243,9 @@ type TableImporter struct {
 	regionSplitKeys int64
 	diskQuota       int64
 	diskQuotaLock   *syncutil.RWMutex
+	// rejectedRows is the number of rows which fail to encode and are skipped,
+	// see Plan.MaxErrors.
+	rejectedRows atomic.Int64
 
 	chunkCh chan QueryChunk
 }
@@ -287,6 +291,12 @@ func (ti *TableImporter) GetKeySpace() []byte {
 	return ti.keyspace
 }
 
+// RejectedRows returns the number of rows which fail to encode and are skipped
+// by this importer.
+func (ti *TableImporter) RejectedRows() int64 {
+	return ti.rejectedRows.Load()
+}
+
 func (ti *TableImporter) getParser(ctx context.Context, chunk *checkpoints.ChunkCheckpoint) (mydump.Parser, error) {
 	info := LoadDataReaderInfo{
 		Opener: func(ctx context.Context) (io.ReadSeekCloser, error) {
@@ -36,6 +36,7 @@ import (
 	"github.com/pingcap/tidb/pkg/domain"
 	"github.com/pingcap/tidb/pkg/domain/infosync"
 	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/eventscheduler"
 	"github.com/pingcap/tidb/pkg/executor/internal/exec"
 	"github.com/pingcap/tidb/pkg/executor/internal/pdhelper"
 	"github.com/pingcap/tidb/pkg/expression"
@@ -48,6 +49,7 @@ import (
 	"github.com/pingcap/tidb/pkg/parser"
 	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/parser/charset"
+	"github.com/pingcap/tidb/pkg/parser/format"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	plannercore "github.com/pingcap/tidb/pkg/planner/core"
 	"github.com/pingcap/tidb/pkg/planner/core/base"
@@ -163,6 +165,8 @@ func (e *memtableRetriever) retrieve(ctx context.Context, sctx sessionctx.Contex
 			err = e.setDataFromIndexes(ctx, sctx)
 		case infoschema.TableViews:
 			err = e.setDataFromViews(ctx, sctx)
+		case infoschema.TableEvents:
+			err = e.setDataFromEvents(ctx, sctx)
 		case infoschema.TableEngines:
 			e.setDataFromEngines()
 		case infoschema.TableCharacterSets:
@@ -1615,6 +1619,70 @@ func (e *memtableRetriever) setDataFromViews(ctx context.Context, sctx sessionct
 	return nil
 }
 
+func (e *memtableRetriever) setDataFromEvents(ctx context.Context, sctx sessionctx.Context) error {
+	checker := privilege.GetPrivilegeManager(sctx)
+	timers, err := getEventTimers(ctx, sctx, "")
+	if err != nil {
+		return err
+	}
+	rows := make([][]types.Datum, 0, len(timers))
+	for _, timer := range timers {
+		ev, err := eventscheduler.UnmarshalEvent(timer.Data)
+		if err != nil {
+			return err
+		}
+		if checker != nil && !checker.DBIsVisible(sctx.GetSessionVars().ActiveRoles, ev.Schema) {
+			continue
+		}
+		def, err := parseEventDefinition(sctx, ev)
+		if err != nil {
+			return err
+		}
+		var body strings.Builder
+		if err = def.Body.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &body)); err != nil {
+			return err
+		}
+		onCompletion := "NOT PRESERVE"
+		if ev.Preserve {
+			onCompletion = "PRESERVE"
+		}
+		attrs := eventRow(ev, timer)
+		loc := sctx.GetSessionVars().Location()
+		created := types.NewTime(types.FromGoTime(ev.Created.In(loc)), mysql.TypeDatetime, 0)
+		lastAltered := types.NewTime(types.FromGoTime(ev.LastAltered.In(loc)), mysql.TypeDatetime, 0)
+		record := types.MakeDatums(
+			infoschema.CatalogVal,  // EVENT_CATALOG
+			ev.Schema,              // EVENT_SCHEMA
+			ev.Name,                // EVENT_NAME
+			ev.Definer,             // DEFINER
+			ev.TimeZone,            // TIME_ZONE
+			"SQL",                  // EVENT_BODY
+			body.String(),          // EVENT_DEFINITION
+			attrs.tp,               // EVENT_TYPE
+			attrs.executeAt,        // EXECUTE_AT
+			attrs.intervalValue,    // INTERVAL_VALUE
+			attrs.intervalField,    // INTERVAL_FIELD
+			ev.SQLMode,             // SQL_MODE
+			attrs.starts,           // STARTS
+			attrs.ends,             // ENDS
+			attrs.status,           // STATUS
+			onCompletion,           // ON_COMPLETION
+			created,                // CREATED
+			lastAltered,            // LAST_ALTERED
+			attrs.lastExecuted,     // LAST_EXECUTED
+			ev.Comment,             // EVENT_COMMENT
+			0,                      // ORIGINATOR
+			ev.CharsetClient,       // CHARACTER_SET_CLIENT
+			ev.CollationConnection, // COLLATION_CONNECTION
+			ev.DatabaseCollation,   // DATABASE_COLLATION
+		)
+		rows = append(rows, record)
+		e.recordMemoryConsume(record)
+	}
+	e.rows = rows
+	return nil
+}
+
 func (e *memtableRetriever) dataForTiKVStoreStatus(ctx context.Context, sctx sessionctx.Context) (err error) {
 	tikvStore, ok := sctx.GetStore().(helper.Storage)
 	if !ok {
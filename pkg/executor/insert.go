@@ -520,7 +520,20 @@ func (e *InsertExec) doDupRowUpdate(
 	}
 
 	newData := e.row4Update[:len(oldRow)]
-	_, ignored, err := updateRecord(
+	numCols := len(e.Table.Cols())
+	if e.updateTriggers != nil {
+		changed, err := e.updateTriggers.fireBefore(ctx, oldRow[:numCols], newData[:numCols])
+		if err != nil {
+			return err
+		}
+		for i, ok := range changed {
+			if ok {
+				assignFlag[i] = true
+				e.evalBuffer4Dup.SetDatum(i, newData[i])
+			}
+		}
+	}
+	changed, ignored, err := updateRecord(
 		ctx, e.Ctx(),
 		handle, oldRow, newData,
 		0, generated, e.evalBuffer4Dup, errorHandler,
@@ -534,6 +547,16 @@ func (e *InsertExec) doDupRowUpdate(
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if changed && e.mlogs != nil {
+		if err = e.mlogs.onUpdate(ctx, oldRow, newData); err != nil {
+			return err
+		}
+	}
+	if e.updateTriggers != nil {
+		if err = e.updateTriggers.fireAfter(ctx, oldRow[:numCols], newData[:numCols]); err != nil {
+			return err
+		}
+	}
 
 	if autoColIdx >= 0 {
 		if e.Ctx().GetSessionVars().StmtCtx.AffectedRows() > 0 {
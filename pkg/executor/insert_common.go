@@ -95,6 +95,16 @@ type InsertValues struct {
 	// fkChecks contains the foreign key checkers.
 	fkChecks   []*FKCheckExec
 	fkCascades []*FKCascadeExec
+	// triggers fires the INSERT triggers of the table, it is nil if there is none.
+	triggers *triggerExec
+	// deleteTriggers fires the DELETE triggers for the rows deleted by REPLACE,
+	// and updateTriggers fires the UPDATE triggers for the rows updated by
+	// INSERT ... ON DUPLICATE KEY UPDATE. They are nil if there is none.
+	deleteTriggers *triggerExec
+	updateTriggers *triggerExec
+	// mlogs writes the row changes to the log tables of the materialized views,
+	// it is nil if there is none.
+	mlogs *mviewLogWriter
 
 	ignoreErr bool
 }
@@ -118,6 +128,17 @@ func (*InsertValues) exec(context.Context, [][]types.Datum) error {
 	panic("derived should overload exec function")
 }
 
+// getTriggerExecs implements withRowTrigger interface.
+func (e *InsertValues) getTriggerExecs() []*triggerExec {
+	var triggers []*triggerExec
+	for _, trigger := range []*triggerExec{e.triggers, e.deleteTriggers, e.updateTriggers} {
+		if trigger != nil {
+			triggers = append(triggers, trigger)
+		}
+	}
+	return triggers
+}
+
 // initInsertColumns sets the explicitly specified columns of an insert statement. There are three cases:
 // There are three types of insert statements:
 // 1 insert ... values(...)  --> name type column
@@ -692,6 +713,20 @@ func (e *InsertValues) fillRow(ctx context.Context, row []types.Datum, hasValue
 			}
 		}
 	}
+	if e.triggers != nil {
+		changed, err := e.triggers.fireBefore(ctx, nil, row[:len(e.Table.Cols())])
+		if err != nil {
+			return nil, err
+		}
+		for i, ok := range changed {
+			if !ok {
+				continue
+			}
+			if err = tCols[i].HandleBadNull(e.Ctx().GetSessionVars().StmtCtx.ErrCtx(), &row[i], rowCntInLoadData); err != nil {
+				return nil, err
+			}
+		}
+	}
 
 	// Handle exchange partition
 	tbl := e.Table.Meta()
@@ -1364,6 +1399,11 @@ func (e *InsertValues) removeRow(
 		return true, nil
 	}
 
+	if e.deleteTriggers != nil {
+		if _, err = e.deleteTriggers.fireBefore(ctx, oldRow[:len(e.Table.Cols())], nil); err != nil {
+			return false, err
+		}
+	}
 	if ph, ok := handle.(kv.PartitionHandle); ok {
 		err = e.Table.(table.PartitionedTable).GetPartition(ph.PartitionID).RemoveRecord(e.Ctx().GetTableCtx(), txn, ph.Handle, oldRow)
 	} else {
@@ -1376,12 +1416,19 @@ func (e *InsertValues) removeRow(
 	if err != nil {
 		return false, err
 	}
+	if e.mlogs != nil {
+		if err = e.mlogs.onDelete(ctx, oldRow); err != nil {
+			return false, err
+		}
+	}
 	if inReplace {
 		e.Ctx().GetSessionVars().StmtCtx.AddAffectedRows(1)
 	} else {
 		e.Ctx().GetSessionVars().StmtCtx.AddDeletedRows(1)
 	}
-
+	if e.deleteTriggers != nil {
+		return false, e.deleteTriggers.fireAfter(ctx, oldRow[:len(e.Table.Cols())], nil)
+	}
 	return false, nil
 }
 
@@ -1427,6 +1474,11 @@ func (e *InsertValues) addRecordWithAutoIDHint(
 	if e.lastInsertID != 0 {
 		vars.SetLastInsertID(e.lastInsertID)
 	}
+	if e.mlogs != nil {
+		if err = e.mlogs.onInsert(ctx, row); err != nil {
+			return err
+		}
+	}
 	if dupKeyCheck != table.DupKeyCheckSkip {
 		for _, fkc := range e.fkChecks {
 			err = fkc.insertRowNeedToCheck(vars.StmtCtx, row)
@@ -1441,6 +1493,9 @@ func (e *InsertValues) addRecordWithAutoIDHint(
 		vars.TxnCtx.InsertTTLRowsCount++
 	}
 
+	if e.triggers != nil {
+		return e.triggers.fireAfter(ctx, nil, row[:len(e.Table.Cols())])
+	}
 	return nil
 }
 
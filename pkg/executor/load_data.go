@@ -148,6 +148,8 @@ type LoadDataWorker struct {
 	planInfo   planInfo
 
 	table table.Table
+	// mlogs writes the row changes to the log tables of the materialized views.
+	mlogs *mviewLogWriter
 }
 
 func setNonRestrictiveFlags(stmtCtx *stmtctx.StatementContext) {
@@ -343,6 +345,7 @@ func createInsertValues(e *LoadDataWorker) (insertVal *InsertValues, err error)
 		insertColumns:  insertColumns,
 		rowLen:         len(insertColumns),
 		hasExtraHandle: hasExtraHandle,
+		mlogs:          e.mlogs,
 	}
 	if len(insertColumns) > 0 {
 		ret.initEvalBuffer()
@@ -0,0 +1,245 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"runtime/trace"
+
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/kv"
+	plannercore "github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/table"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/memory"
+)
+
+// MergeExec represents a merge executor.
+// It reads the left outer join of the source and the target table from its child, and applies
+// each row by the first WHEN clause it satisfies. The actions are done by the UpdateExec,
+// DeleteExec and InsertExec of the clauses, which never read from their own children.
+type MergeExec struct {
+	exec.BaseExecutor
+
+	tbl table.Table
+	// tblColPosInfo is the position of the target table in the rows of the child.
+	tblColPosInfo plannercore.TblColPosInfo
+	clauses       []*mergeClauseExec
+
+	// actedRowKeys records the handles of the target rows which are updated or deleted,
+	// a target row can't be updated or deleted more than once.
+	actedRowKeys *kv.MemAwareHandleMap[struct{}]
+	drained      bool
+	memTracker   *memory.Tracker
+}
+
+type mergeClauseExec struct {
+	notMatched bool
+	condition  expression.Expression
+
+	// Only one of update, delete and insert is set.
+	update *UpdateExec
+	delete *DeleteExec
+	insert *InsertExec
+	// values are evaluated on the row of the child to build the row to insert.
+	values []expression.Expression
+}
+
+// Open implements the Executor Open interface.
+func (e *MergeExec) Open(ctx context.Context) error {
+	e.memTracker = memory.NewTracker(e.ID(), -1)
+	e.memTracker.AttachTo(e.Ctx().GetSessionVars().StmtCtx.MemTracker)
+	e.actedRowKeys = kv.NewMemAwareHandleMap[struct{}]()
+
+	fields := exec.RetTypes(e.Children(0))
+	for _, clause := range e.clauses {
+		switch {
+		case clause.update != nil:
+			clause.update.memTracker = e.memTracker
+			clause.update.initUpdateRows(fields)
+		case clause.delete != nil:
+			clause.delete.memTracker = e.memTracker
+		case clause.insert != nil:
+			clause.insert.memTracker = e.memTracker
+		}
+	}
+	return exec.Open(ctx, e.Children(0))
+}
+
+// Close implements the Executor Close interface.
+func (e *MergeExec) Close() error {
+	defer e.memTracker.ReplaceBytesUsed(0)
+	return exec.Close(e.Children(0))
+}
+
+// Next implements the Executor Next interface.
+func (e *MergeExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	if e.drained {
+		return nil
+	}
+	e.drained = true
+	return e.mergeRows(ctx)
+}
+
+func (e *MergeExec) mergeRows(ctx context.Context) error {
+	defer trace.StartRegion(ctx, "MergeExec").End()
+	txn, err := e.Ctx().Txn(true)
+	if err != nil {
+		return err
+	}
+	dupKeyCheck := optimizeDupKeyCheckForUpdate(txn, false)
+	fields := exec.RetTypes(e.Children(0))
+	chk := exec.TryNewCacheChunk(e.Children(0))
+	rowIdx := 0
+	memUsageOfChk := int64(0)
+	for {
+		e.memTracker.Consume(-memUsageOfChk)
+		err := exec.Next(ctx, e.Children(0), chk)
+		if err != nil {
+			return err
+		}
+		if chk.NumRows() == 0 {
+			break
+		}
+		memUsageOfChk = chk.MemoryUsage()
+		e.memTracker.Consume(memUsageOfChk)
+		for i := range chk.NumRows() {
+			if err := e.mergeRow(ctx, rowIdx, chk.GetRow(i), fields, dupKeyCheck); err != nil {
+				return err
+			}
+			rowIdx++
+		}
+		chk = chunk.Renew(chk, e.MaxChunkSize())
+	}
+	return nil
+}
+
+// mergeRow applies the row by the first clause it satisfies, the row is skipped if there is none.
+func (e *MergeExec) mergeRow(ctx context.Context, rowIdx int, row chunk.Row, fields []*types.FieldType, dupKeyCheck table.DupKeyCheckMode) error {
+	evalCtx := e.Ctx().GetExprCtx().GetEvalCtx()
+	matched := !row.IsNull(e.tblColPosInfo.HandleCols.GetCol(0).Index)
+	var clause *mergeClauseExec
+	for _, c := range e.clauses {
+		if c.notMatched == matched {
+			continue
+		}
+		if c.condition != nil {
+			ok, _, err := expression.EvalBool(evalCtx, []expression.Expression{c.condition}, row)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+		}
+		clause = c
+		break
+	}
+	if clause == nil {
+		return nil
+	}
+
+	if clause.insert != nil {
+		vals := make([]types.Datum, 0, len(clause.values))
+		for _, expr := range clause.values {
+			val, err := expr.Eval(evalCtx, row)
+			if err != nil {
+				return err
+			}
+			vals = append(vals, val)
+		}
+		clause.insert.rowCount++
+		newRow, err := clause.insert.getRow(ctx, vals)
+		if err != nil {
+			return err
+		}
+		return clause.insert.exec(ctx, [][]types.Datum{newRow})
+	}
+
+	datumRow := row.GetDatumRow(fields)
+	handle, err := e.tblColPosInfo.HandleCols.BuildHandleByDatums(datumRow)
+	if err != nil {
+		return err
+	}
+	if _, ok := e.actedRowKeys.Get(handle); ok {
+		return exeerrors.ErrMergeCardinalityViolation.GenWithStackByArgs(e.tbl.Meta().Name.O)
+	}
+	e.memTracker.Consume(e.actedRowKeys.Set(handle, struct{}{}) + int64(handle.ExtraMemSize()))
+	e.Ctx().GetSessionVars().StmtCtx.AddRecordRows(1)
+	if clause.update != nil {
+		return clause.update.updateRow(ctx, rowIdx, datumRow, dupKeyCheck)
+	}
+	err = clause.delete.removeRow(ctx, e.tbl, handle, datumRow[e.tblColPosInfo.Start:e.tblColPosInfo.End], &e.tblColPosInfo)
+	if err != nil {
+		return err
+	}
+	if txn, _ := e.Ctx().Txn(false); txn != nil {
+		return txn.MayFlush()
+	}
+	return nil
+}
+
+// GetFKChecks implements WithForeignKeyTrigger interface.
+func (e *MergeExec) GetFKChecks() []*FKCheckExec {
+	var fkChecks []*FKCheckExec
+	for _, clause := range e.clauses {
+		fkChecks = append(fkChecks, clause.executor().GetFKChecks()...)
+	}
+	return fkChecks
+}
+
+// GetFKCascades implements WithForeignKeyTrigger interface.
+func (e *MergeExec) GetFKCascades() []*FKCascadeExec {
+	var fkCascades []*FKCascadeExec
+	for _, clause := range e.clauses {
+		fkCascades = append(fkCascades, clause.executor().GetFKCascades()...)
+	}
+	return fkCascades
+}
+
+// HasFKCascades implements WithForeignKeyTrigger interface.
+func (e *MergeExec) HasFKCascades() bool {
+	for _, clause := range e.clauses {
+		if clause.executor().HasFKCascades() {
+			return true
+		}
+	}
+	return false
+}
+
+// getTriggerExecs implements withRowTrigger interface.
+func (e *MergeExec) getTriggerExecs() []*triggerExec {
+	var triggers []*triggerExec
+	for _, clause := range e.clauses {
+		if w, ok := clause.executor().(withRowTrigger); ok {
+			triggers = append(triggers, w.getTriggerExecs()...)
+		}
+	}
+	return triggers
+}
+
+func (c *mergeClauseExec) executor() WithForeignKeyTrigger {
+	switch {
+	case c.update != nil:
+		return c.update
+	case c.delete != nil:
+		return c.delete
+	}
+	return c.insert
+}
@@ -0,0 +1,277 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/materializedview"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/planner/core/resolve"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/table"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/zap"
+)
+
+func (e *DDLExec) executeCreateMaterializedView(ctx context.Context, s *ast.CreateMaterializedViewStmt) error {
+	ret := &core.PreprocessorReturn{}
+	nodeW := resolve.NewNodeW(s.Select)
+	err := core.Preprocess(ctx, e.Ctx(), nodeW, core.WithPreprocessorReturn(ret))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if ret.IsStaleness {
+		return exeerrors.ErrViewInvalid.GenWithStackByArgs(s.ViewName.Schema.L, s.ViewName.Name.L)
+	}
+	if _, err = e.is.TableByName(ctx, s.ViewName.Schema, s.ViewName.Name); err == nil && s.IfNotExists {
+		// The DDL appends the note.
+		return e.ddlExecutor.CreateMaterializedView(e.Ctx(), s)
+	}
+
+	e.Ctx().GetSessionVars().ClearRelatedTableForMDL()
+	if err = e.ddlExecutor.CreateMaterializedView(e.Ctx(), s); err != nil {
+		return err
+	}
+	// The view is created empty, fill it after every TiDB logs the row changes
+	// of the base table.
+	is := domain.GetDomain(e.Ctx()).InfoSchema()
+	tbl, err := is.TableByName(ctx, s.ViewName.Schema, s.ViewName.Name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	se, err := e.GetSysSession()
+	if err != nil {
+		return err
+	}
+	internalCtx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnOthers)
+	defer e.ReleaseSysSession(internalCtx, se)
+	return refreshMaterializedView(internalCtx, se, is, s.ViewName.Schema, tbl.Meta(), ast.RefreshMethodComplete)
+}
+
+func (e *SimpleExec) executeRefreshMaterializedView(ctx context.Context, s *ast.RefreshMaterializedViewStmt) error {
+	is := e.Ctx().GetInfoSchema().(infoschema.InfoSchema)
+	tbl, err := is.TableByName(ctx, s.ViewName.Schema, s.ViewName.Name)
+	if err != nil {
+		return err
+	}
+	if !tbl.Meta().IsMaterializedView() {
+		return dbterror.ErrWrongObject.GenWithStackByArgs(s.ViewName.Schema.O, s.ViewName.Name.O, "MATERIALIZED VIEW")
+	}
+	se, err := e.GetSysSession()
+	if err != nil {
+		return err
+	}
+	internalCtx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnOthers)
+	defer e.ReleaseSysSession(internalCtx, se)
+	return refreshMaterializedView(internalCtx, se, is, s.ViewName.Schema, tbl.Meta(), s.Method)
+}
+
+// refreshMaterializedView refreshes the view in an optimistic transaction of
+// the internal session. All the statements read the snapshot of the start
+// timestamp, so the changes logged after it are kept for the next refresh.
+func refreshMaterializedView(ctx context.Context, se sessionctx.Context, is infoschema.InfoSchema,
+	schema ast.CIStr, tblInfo *model.TableInfo, method ast.RefreshMethod) (err error) {
+	mv := tblInfo.MaterializedView
+	view := ast.Ident{Schema: schema, Name: tblInfo.Name}
+	var log *ast.Ident
+	if mv.LogTableID != 0 {
+		if logTbl, ok := is.TableByID(ctx, mv.LogTableID); ok {
+			log = &ast.Ident{Schema: schema, Name: logTbl.Meta().Name}
+		}
+	}
+	cols := make([]ast.CIStr, 0, len(tblInfo.Columns))
+	for _, col := range tblInfo.Columns {
+		cols = append(cols, col.Name)
+	}
+	if method == ast.RefreshMethodDefault {
+		method = ast.RefreshMethodComplete
+		if log != nil {
+			method = ast.RefreshMethodFast
+		}
+	}
+
+	var sqls []string
+	switch method {
+	case ast.RefreshMethodComplete:
+		sqls = materializedview.CompleteRefreshSQLs(view, cols, mv.Definition, log)
+	case ast.RefreshMethodFast:
+		if log == nil {
+			return dbterror.ErrMViewNotFastRefreshable.GenWithStackByArgs(tblInfo.Name.O, "it has no log table")
+		}
+		stmt, err := parser.New().ParseOneStmt(mv.Definition, "", "")
+		if err != nil {
+			return errors.Trace(err)
+		}
+		d := materializedview.Analyze(stmt, cols)
+		if d == nil {
+			return dbterror.ErrMViewNotFastRefreshable.GenWithStackByArgs(tblInfo.Name.O, "it isn't an aggregate query of a single table")
+		}
+		if err = d.CheckFastRefresh(); err != nil {
+			return dbterror.ErrMViewNotFastRefreshable.GenWithStackByArgs(tblInfo.Name.O, err.Error())
+		}
+		sqls = d.FastRefreshSQLs(view, *log)
+	}
+
+	sqlExec := se.GetSQLExecutor()
+	if _, err = sqlExec.ExecuteInternal(ctx, "BEGIN OPTIMISTIC"); err != nil {
+		return err
+	}
+	defer func() {
+		if err == nil {
+			_, err = sqlExec.ExecuteInternal(ctx, "COMMIT")
+			return
+		}
+		if _, rollbackErr := sqlExec.ExecuteInternal(ctx, "ROLLBACK"); rollbackErr != nil {
+			logutil.BgLogger().Error("rollback error occur at refresh materialized view", zap.Error(rollbackErr))
+		}
+	}()
+	for _, sql := range sqls {
+		if _, err = sqlExec.ExecuteInternal(ctx, sql); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkMaterializedViewWrite returns an error if the table is a materialized
+// view or a log table, they are only written by the internal refresh statements.
+func checkMaterializedViewWrite(sctx sessionctx.Context, tblInfo *model.TableInfo, op string) error {
+	if (tblInfo.IsMaterializedView() || tblInfo.IsMaterializedViewLog()) && !sctx.GetSessionVars().InRestrictedSQL {
+		return dbterror.ErrOptOnMaterializedView.GenWithStackByArgs(op)
+	}
+	return nil
+}
+
+// mviewLogWriter writes the row changes of a base table to the log tables of
+// its materialized views. Every inserted row is logged with op 1, and every
+// deleted row with op -1, an updated row is logged as both.
+type mviewLogWriter struct {
+	sctx sessionctx.Context
+	logs []mviewLog
+}
+
+type mviewLog struct {
+	tbl table.Table
+	// offsets are the offsets of the logged columns in the rows of the base table.
+	offsets []int
+}
+
+func (w *mviewLogWriter) write(ctx context.Context, row []types.Datum, op int64) error {
+	txn, err := w.sctx.Txn(true)
+	if err != nil {
+		return err
+	}
+	for _, log := range w.logs {
+		logRow := make([]types.Datum, 0, len(log.offsets)+1)
+		for _, offset := range log.offsets {
+			logRow = append(logRow, row[offset])
+		}
+		logRow = append(logRow, types.NewIntDatum(op))
+		if _, err = log.tbl.AddRecord(w.sctx.GetTableCtx(), txn, logRow, table.WithCtx(ctx)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *mviewLogWriter) onInsert(ctx context.Context, row []types.Datum) error {
+	return w.write(ctx, row, 1)
+}
+
+func (w *mviewLogWriter) onDelete(ctx context.Context, row []types.Datum) error {
+	return w.write(ctx, row, -1)
+}
+
+func (w *mviewLogWriter) onUpdate(ctx context.Context, oldRow, newRow []types.Datum) error {
+	if err := w.onDelete(ctx, oldRow); err != nil {
+		return err
+	}
+	return w.onInsert(ctx, newRow)
+}
+
+// buildMViewLogWriter checks whether the table can be written by the statement,
+// and returns the writer of its log tables, or nil if there is none.
+func (b *executorBuilder) buildMViewLogWriter(tbl table.Table, op string) (*mviewLogWriter, error) {
+	tblInfo := tbl.Meta()
+	if err := checkMaterializedViewWrite(b.ctx, tblInfo, op); err != nil {
+		return nil, err
+	}
+	if !tblInfo.HasMaterializedViews() {
+		return nil, nil
+	}
+	cols := tbl.Cols()
+	var w *mviewLogWriter
+	for _, mvID := range tblInfo.MaterializedViewIDs {
+		// The view or its log table may be dropped in the same schema version.
+		mvTbl, ok := b.is.TableByID(context.Background(), mvID)
+		if !ok || !mvTbl.Meta().IsMaterializedView() || mvTbl.Meta().MaterializedView.LogTableID == 0 {
+			continue
+		}
+		logTbl, ok := b.is.TableByID(context.Background(), mvTbl.Meta().MaterializedView.LogTableID)
+		if !ok {
+			continue
+		}
+		logCols := logTbl.Cols()
+		offsets := make([]int, 0, len(logCols)-1)
+		for _, logCol := range logCols[:len(logCols)-1] {
+			offset := -1
+			for i, col := range cols {
+				if col.Name.L == logCol.Name.L {
+					offset = i
+					break
+				}
+			}
+			if offset < 0 {
+				return nil, errors.Errorf("can not find column %s of log table %s in table %s",
+					logCol.Name.O, logTbl.Meta().Name.O, tblInfo.Name.O)
+			}
+			offsets = append(offsets, offset)
+		}
+		if w == nil {
+			w = &mviewLogWriter{sctx: b.ctx}
+		}
+		w.logs = append(w.logs, mviewLog{tbl: logTbl, offsets: offsets})
+	}
+	return w, nil
+}
+
+func (b *executorBuilder) buildTblID2MViewLogWriters(tblID2Table map[int64]table.Table, op string) (map[int64]*mviewLogWriter, error) {
+	var writers map[int64]*mviewLogWriter
+	for id, tbl := range tblID2Table {
+		w, err := b.buildMViewLogWriter(tbl, op)
+		if err != nil {
+			return nil, err
+		}
+		if w == nil {
+			continue
+		}
+		if writers == nil {
+			writers = make(map[int64]*mviewLogWriter, len(tblID2Table))
+		}
+		writers[id] = w
+	}
+	return writers, nil
+}
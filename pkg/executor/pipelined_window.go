@@ -32,6 +32,13 @@ type dataInfo struct {
 	chk         *chunk.Chunk
 	remaining   uint64
 	accumulated uint64
+	// src is the child chunk that chk refers to.
+	src *chunk.Chunk
+	// spilledIdx is the index of src among the spilled chunks. It's only valid when chk is nil,
+	// which means src has been spilled before any row of chk got its result.
+	spilledIdx int
+	// memUsage is the memory usage of chk tracked by the executor.
+	memUsage int64
 }
 
 // PipelinedWindowExec is the executor for window functions.
@@ -75,10 +82,17 @@ type PipelinedWindowExec struct {
 	isRangeFrame             bool
 	emptyFrame               bool
 	initializedSlidingWindow bool
+
+	spill windowSpillHelper
+	// spilledRowCnt is the number of rows kept from rowStart in spill mode, the rows are read
+	// from disk instead of e.rows.
+	spilledRowCnt uint64
 }
 
 // Close implements the Executor Close interface.
 func (e *PipelinedWindowExec) Close() error {
+	e.rows, e.data = nil, nil
+	e.spill.close()
 	return errors.Trace(e.BaseExecutor.Close())
 }
 
@@ -88,15 +102,21 @@ func (e *PipelinedWindowExec) Open(ctx context.Context) (err error) {
 	e.dataIdx, e.curRowIdx, e.dropped, e.rowToConsume, e.accumulated = 0, 0, 0, 0, 0
 	e.lastStartRow, e.lastEndRow, e.stagedStartRow, e.stagedEndRow, e.rowStart, e.rowCnt = 0, 0, 0, 0, 0, 0
 	e.rows, e.data = make([]chunk.Row, 0), make([]dataInfo, 0)
-	return e.BaseExecutor.Open(ctx)
+	e.spilledRowCnt = 0
+	if err = e.BaseExecutor.Open(ctx); err != nil {
+		return err
+	}
+	e.spill.open(e.Ctx(), e.ID())
+	return nil
 }
 
 func (e *PipelinedWindowExec) firstResultChunkNotReady() bool {
 	if !e.done && len(e.data) == 0 {
 		return true
 	}
-	// chunk can't be ready unless, 1. all of the rows in the chunk is filled, 2. e.rows doesn't contain rows in the chunk
-	return len(e.data) > 0 && (e.data[0].remaining != 0 || e.data[0].accumulated > e.dropped)
+	// chunk can't be ready unless, 1. all of the rows in the chunk is filled, 2. e.rows doesn't contain rows in the chunk.
+	// In spill mode, the rows are read from disk, so the chunk is ready once all of its rows are filled.
+	return len(e.data) > 0 && (e.data[0].remaining != 0 || (!e.spill.inSpillMode() && e.data[0].accumulated > e.dropped))
 }
 
 // Next implements the Executor Next interface.
@@ -142,6 +162,12 @@ func (e *PipelinedWindowExec) Next(ctx context.Context, chk *chunk.Chunk) (err e
 
 		// e.p is ready to produce data
 		if len(e.data) > e.dataIdx && e.data[e.dataIdx].remaining != 0 {
+			if e.data[e.dataIdx].chk == nil {
+				err = e.spill.restoreResultChunk(&e.data[e.dataIdx], e.newResultChunk)
+				if err != nil {
+					return err
+				}
+			}
 			produced, err := e.produce(e.Ctx(), e.data[e.dataIdx].chk, e.data[e.dataIdx].remaining)
 			if err != nil {
 				return err
@@ -154,6 +180,7 @@ func (e *PipelinedWindowExec) Next(ctx context.Context, chk *chunk.Chunk) (err e
 	}
 	if len(e.data) > 0 {
 		chk.SwapColumns(e.data[0].chk)
+		e.spill.memTracker.Consume(-e.data[0].memUsage)
 		e.data = e.data[1:]
 		e.dataIdx--
 	}
@@ -162,7 +189,7 @@ func (e *PipelinedWindowExec) Next(ctx context.Context, chk *chunk.Chunk) (err e
 
 func (e *PipelinedWindowExec) getRowsInPartition(ctx context.Context) (err error) {
 	e.newPartition = true
-	if len(e.rows) == 0 {
+	if e.numRowsKept() == 0 {
 		// if getRowsInPartition is called for the first time, we ignore it as a new partition
 		e.newPartition = false
 	}
@@ -189,6 +216,10 @@ func (e *PipelinedWindowExec) getRowsInPartition(ctx context.Context) (err error
 	}
 	begin, end := e.groupChecker.GetNextGroup()
 	e.rowToConsume += uint64(end - begin)
+	if e.spill.inSpillMode() {
+		e.spilledRowCnt += uint64(end - begin)
+		return
+	}
 	for i := begin; i < end; i++ {
 		e.rows = append(e.rows, e.childResult.GetRow(i))
 	}
@@ -196,6 +227,11 @@ func (e *PipelinedWindowExec) getRowsInPartition(ctx context.Context) (err error
 }
 
 func (e *PipelinedWindowExec) fetchChild(ctx context.Context) (eof bool, err error) {
+	if e.spill.needSpill() {
+		if err = e.spillToDisk(); err != nil {
+			return false, err
+		}
+	}
 	// TODO: reuse chunks
 	childResult := exec.TryNewCacheChunk(e.Children(0))
 	err = exec.Next(ctx, e.Children(0), childResult)
@@ -208,19 +244,60 @@ func (e *PipelinedWindowExec) fetchChild(ctx context.Context) (eof bool, err err
 		return true, nil
 	}
 
-	// TODO: reuse chunks
-	resultChk := e.AllocPool.Alloc(e.RetFieldTypes(), 0, numRows)
-	err = e.copyChk(childResult, resultChk)
-	if err != nil {
-		return false, err
-	}
 	e.accumulated += uint64(numRows)
-	e.data = append(e.data, dataInfo{chk: resultChk, remaining: uint64(numRows), accumulated: e.accumulated})
+	d := dataInfo{src: childResult, remaining: uint64(numRows), accumulated: e.accumulated}
+	if e.spill.inSpillMode() {
+		if d.spilledIdx, err = e.spill.rowsInDisk.add(childResult); err != nil {
+			return false, err
+		}
+		d.src = nil
+	} else {
+		d.chk, err = e.newResultChunk(childResult)
+		if err != nil {
+			return false, err
+		}
+		d.memUsage = childResult.MemoryUsage()
+		e.spill.memTracker.Consume(d.memUsage)
+	}
+	e.data = append(e.data, d)
 
 	e.childResult = childResult
 	return false, nil
 }
 
+// spillToDisk switches the executor to spill mode. The child chunks which still have rows kept
+// in e.rows are spilled, and the kept rows are read from disk afterwards.
+func (e *PipelinedWindowExec) spillToDisk() error {
+	firstRow := e.accumulated
+	for _, d := range e.data {
+		if d.accumulated > e.dropped {
+			firstRow = d.accumulated - uint64(d.src.NumRows())
+			break
+		}
+	}
+	e.spill.startSpill(exec.RetTypes(e.Children(0)), firstRow)
+	for i := range e.data {
+		if e.data[i].accumulated <= e.dropped {
+			continue
+		}
+		if err := e.spill.spillResultChunk(&e.data[i]); err != nil {
+			return err
+		}
+	}
+	e.spilledRowCnt = uint64(len(e.rows))
+	e.rows = nil
+	return nil
+}
+
+func (e *PipelinedWindowExec) newResultChunk(src *chunk.Chunk) (*chunk.Chunk, error) {
+	// TODO: reuse chunks
+	resultChk := e.AllocPool.Alloc(e.RetFieldTypes(), 0, src.NumRows())
+	if err := e.copyChk(src, resultChk); err != nil {
+		return nil, err
+	}
+	return resultChk, nil
+}
+
 func (e *PipelinedWindowExec) copyChk(src, dst *chunk.Chunk) error {
 	columns := e.Schema().Columns[:len(e.Schema().Columns)-e.numWindowFuncs]
 	for i, col := range columns {
@@ -232,13 +309,37 @@ func (e *PipelinedWindowExec) copyChk(src, dst *chunk.Chunk) error {
 }
 
 func (e *PipelinedWindowExec) getRow(i uint64) chunk.Row {
+	if e.spill.inSpillMode() {
+		return e.spill.rowsInDisk.getRow(e.dropped + i - e.rowStart)
+	}
 	return e.rows[i-e.rowStart]
 }
 
 func (e *PipelinedWindowExec) getRows(start, end uint64) []chunk.Row {
+	if e.spill.inSpillMode() {
+		return e.spill.rowsInDisk.getRows(e.dropped+start-e.rowStart, e.dropped+end-e.rowStart)
+	}
 	return e.rows[start-e.rowStart : end-e.rowStart]
 }
 
+// numRowsKept returns the number of rows kept from e.rowStart.
+func (e *PipelinedWindowExec) numRowsKept() uint64 {
+	if e.spill.inSpillMode() {
+		return e.spilledRowCnt
+	}
+	return uint64(len(e.rows))
+}
+
+// dropRows drops the first numDrop rows kept from e.rowStart.
+func (e *PipelinedWindowExec) dropRows(numDrop uint64) {
+	e.dropped += numDrop
+	if e.spill.inSpillMode() {
+		e.spilledRowCnt -= numDrop
+		return
+	}
+	e.rows = e.rows[numDrop:]
+}
+
 // finish is called upon a whole partition is consumed
 func (e *PipelinedWindowExec) finish() {
 	e.whole = true
@@ -406,12 +507,10 @@ func (e *PipelinedWindowExec) produce(ctx sessionctx.Context, chk *chunk.Chunk,
 	}
 	extend := min(e.curRowIdx, e.lastEndRow, e.lastStartRow)
 	if extend > e.rowStart {
-		numDrop := extend - e.rowStart
-		e.dropped += numDrop
-		e.rows = e.rows[numDrop:]
+		e.dropRows(extend - e.rowStart)
 		e.rowStart = extend
 	}
-	return
+	return produced, e.spill.getErr()
 }
 
 func (e *PipelinedWindowExec) enoughToProduce(ctx sessionctx.Context) (enough bool, err error) {
@@ -429,7 +528,7 @@ func (e *PipelinedWindowExec) enoughToProduce(ctx sessionctx.Context) (enough bo
 	if err != nil {
 		return
 	}
-	return end < e.rowCnt && start < e.rowCnt, nil
+	return end < e.rowCnt && start < e.rowCnt, e.spill.getErr()
 }
 
 // reset resets the processor
@@ -441,9 +540,7 @@ func (e *PipelinedWindowExec) reset() {
 	e.emptyFrame = false
 	e.curRowIdx = 0
 	e.whole = false
-	numDrop := e.rowCnt - e.rowStart
-	e.dropped += numDrop
-	e.rows = e.rows[numDrop:]
+	e.dropRows(e.rowCnt - e.rowStart)
 	e.rowStart = 0
 	e.rowCnt = 0
 	e.initializedSlidingWindow = false
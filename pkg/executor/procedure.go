@@ -0,0 +1,1131 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/opcode"
+	"github.com/pingcap/tidb/pkg/parser/terror"
+	"github.com/pingcap/tidb/pkg/planner/core/resolve"
+	"github.com/pingcap/tidb/pkg/privilege"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/vardef"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+)
+
+const procedureRoutineType = "PROCEDURE"
+
+func (e *SimpleExec) executeCreateProcedure(ctx context.Context, s *ast.ProcedureInfo) error {
+	sessVars := e.Ctx().GetSessionVars()
+	is := e.Ctx().GetInfoSchema().(infoschema.InfoSchema)
+	dbInfo, ok := is.SchemaByName(s.ProcedureName.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(s.ProcedureName.Schema.O)
+	}
+	if err := checkProcedureInfo(s); err != nil {
+		return err
+	}
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	exec := e.Ctx().GetRestrictedSQLExecutor()
+	fullName := fmt.Sprintf("%s.%s", dbInfo.Name.O, s.ProcedureName.Name.O)
+	rows, _, err := exec.ExecRestrictedSQL(ctx, nil,
+		`SELECT 1 FROM mysql.routines WHERE routine_schema = %? AND routine_name = %? AND routine_type = %?`,
+		dbInfo.Name.L, s.ProcedureName.Name.O, procedureRoutineType)
+	if err != nil {
+		return err
+	}
+	if len(rows) > 0 {
+		err = exeerrors.ErrSpAlreadyExists.GenWithStackByArgs(procedureRoutineType, fullName)
+		if s.IfNotExists {
+			sessVars.StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+
+	definition := s.Text()
+	if definition == "" {
+		var sb strings.Builder
+		if err := s.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+			return err
+		}
+		definition = sb.String()
+	}
+	var definer string
+	if user := sessVars.User; user != nil {
+		definer = fmt.Sprintf("%s@%s", user.AuthUsername, user.AuthHostname)
+	}
+	sqlMode, _ := sessVars.GetSystemVar(vardef.SQLModeVar)
+	charsetClient, _ := sessVars.GetSystemVar(vardef.CharacterSetClient)
+	_, collationConnection := sessVars.GetCharsetInfo()
+	_, _, err = exec.ExecRestrictedSQL(ctx, nil,
+		`INSERT INTO mysql.routines (routine_schema, routine_name, routine_type, definition, definer, sql_mode,
+			character_set_client, collation_connection, database_collation, comment) VALUES (%?, %?, %?, %?, %?, %?, %?, %?, %?, '')`,
+		dbInfo.Name.L, s.ProcedureName.Name.O, procedureRoutineType, definition, definer, sqlMode,
+		charsetClient, collationConnection, dbInfo.Collate)
+	return err
+}
+
+func (e *SimpleExec) executeDropProcedure(ctx context.Context, s *ast.DropProcedureStmt) error {
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	exec := e.Ctx().GetRestrictedSQLExecutor()
+	rows, _, err := exec.ExecRestrictedSQL(ctx, nil,
+		`SELECT 1 FROM mysql.routines WHERE routine_schema = %? AND routine_name = %? AND routine_type = %?`,
+		s.ProcedureName.Schema.L, s.ProcedureName.Name.O, procedureRoutineType)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		err = exeerrors.ErrSpDoesNotExist.GenWithStackByArgs(procedureRoutineType,
+			fmt.Sprintf("%s.%s", s.ProcedureName.Schema.O, s.ProcedureName.Name.O))
+		if s.IfExists {
+			e.Ctx().GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+	_, _, err = exec.ExecRestrictedSQL(ctx, nil,
+		`DELETE FROM mysql.routines WHERE routine_schema = %? AND routine_name = %? AND routine_type = %?`,
+		s.ProcedureName.Schema.L, s.ProcedureName.Name.O, procedureRoutineType)
+	return err
+}
+
+// procedureChecker validates the body of a procedure when it is created, so
+// that errors like undeclared cursors or unknown labels are reported by
+// CREATE PROCEDURE instead of at the middle of a CALL.
+type procedureChecker struct {
+	// scopes holds the variable and cursor names of the enclosing blocks.
+	scopes []procedureCheckerScope
+	// labels holds the enclosing labels, loop labels can be used by ITERATE.
+	labels []procedureCheckerLabel
+	// trigger is set when the body of a trigger is checked.
+	trigger *triggerChecker
+}
+
+type procedureCheckerScope struct {
+	vars    map[string]struct{}
+	cursors map[string]struct{}
+}
+
+type procedureCheckerLabel struct {
+	name   string
+	isLoop bool
+}
+
+func checkProcedureInfo(s *ast.ProcedureInfo) error {
+	params := procedureCheckerScope{vars: make(map[string]struct{}), cursors: make(map[string]struct{})}
+	for _, param := range s.ProcedureParam {
+		name := strings.ToLower(param.ParamName)
+		if _, ok := params.vars[name]; ok {
+			return exeerrors.ErrSpDupParam.GenWithStackByArgs(param.ParamName)
+		}
+		params.vars[name] = struct{}{}
+	}
+	c := &procedureChecker{scopes: []procedureCheckerScope{params}}
+	return c.checkStmt(s.ProcedureBody)
+}
+
+func (c *procedureChecker) checkStmts(stmts []ast.StmtNode) error {
+	for _, stmt := range stmts {
+		if err := c.checkStmt(stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *procedureChecker) checkStmt(stmt ast.StmtNode) error {
+	switch x := stmt.(type) {
+	case *ast.ProcedureBlock:
+		return c.checkBlock(x)
+	case *ast.ProcedureLabelBlock:
+		return c.checkLabeled(x.LabelName, x.LabelEnd, x.LabelError, false, x.Block)
+	case *ast.ProcedureLabelLoop:
+		return c.checkLabeled(x.LabelName, x.LabelEnd, x.LabelError, true, x.Block)
+	case *ast.ProcedureIfInfo:
+		return c.checkIfBlock(x.IfBody)
+	case *ast.SimpleCaseStmt:
+		if err := c.checkRowRefs(x.Condition); err != nil {
+			return err
+		}
+		for _, when := range x.WhenCases {
+			if err := c.checkRowRefs(when.Expr); err != nil {
+				return err
+			}
+			if err := c.checkStmts(when.ProcedureStmts); err != nil {
+				return err
+			}
+		}
+		return c.checkStmts(x.ElseCases)
+	case *ast.SearchCaseStmt:
+		for _, when := range x.WhenCases {
+			if err := c.checkRowRefs(when.Expr); err != nil {
+				return err
+			}
+			if err := c.checkStmts(when.ProcedureStmts); err != nil {
+				return err
+			}
+		}
+		return c.checkStmts(x.ElseCases)
+	case *ast.ProcedureWhileStmt:
+		if err := c.checkRowRefs(x.Condition); err != nil {
+			return err
+		}
+		return c.checkStmts(x.Body)
+	case *ast.ProcedureRepeatStmt:
+		if err := c.checkRowRefs(x.Condition); err != nil {
+			return err
+		}
+		return c.checkStmts(x.Body)
+	case *ast.ProcedureOpenCur:
+		return c.checkCursor(x.CurName)
+	case *ast.ProcedureCloseCur:
+		return c.checkCursor(x.CurName)
+	case *ast.ProcedureFetchInto:
+		if err := c.checkCursor(x.CurName); err != nil {
+			return err
+		}
+		for _, name := range x.Variables {
+			if !c.hasVar(name) {
+				return exeerrors.ErrSpUndeclaredVar.GenWithStackByArgs(name)
+			}
+		}
+	case *ast.ProcedureJump:
+		return c.checkJump(x)
+	case *ast.UseStmt:
+		return exeerrors.ErrSpBadStatement.GenWithStackByArgs("USE")
+	default:
+		if c.trigger != nil {
+			return c.checkTriggerStmt(stmt)
+		}
+	}
+	return nil
+}
+
+func (c *procedureChecker) checkBlock(block *ast.ProcedureBlock) error {
+	scope := procedureCheckerScope{vars: make(map[string]struct{}), cursors: make(map[string]struct{})}
+	c.scopes = append(c.scopes, scope)
+	defer func() { c.scopes = c.scopes[:len(c.scopes)-1] }()
+	var handlers []*ast.ProcedureErrorControl
+	for _, decl := range block.ProcedureVars {
+		switch x := decl.(type) {
+		case *ast.ProcedureDecl:
+			for _, name := range x.DeclNames {
+				if _, ok := scope.vars[name]; ok {
+					return exeerrors.ErrSpDupVar.GenWithStackByArgs(name)
+				}
+				scope.vars[name] = struct{}{}
+			}
+			if err := c.checkRowRefs(x.DeclDefault); err != nil {
+				return err
+			}
+		case *ast.ProcedureCursor:
+			if _, ok := scope.cursors[x.CurName]; ok {
+				return exeerrors.ErrSpDupCurs.GenWithStackByArgs(x.CurName)
+			}
+			if err := c.checkRowRefs(x.Selectstring); err != nil {
+				return err
+			}
+			scope.cursors[x.CurName] = struct{}{}
+		case *ast.ProcedureErrorControl:
+			handlers = append(handlers, x)
+		}
+	}
+	for _, handler := range handlers {
+		if err := c.checkStmt(handler.Operate); err != nil {
+			return err
+		}
+	}
+	return c.checkStmts(block.ProcedureProcStmts)
+}
+
+func (c *procedureChecker) checkLabeled(name, end string, mismatch, isLoop bool, body ast.StmtNode) error {
+	if mismatch {
+		return exeerrors.ErrSpLabelMismatch.GenWithStackByArgs(end)
+	}
+	for _, label := range c.labels {
+		if strings.EqualFold(label.name, name) {
+			return exeerrors.ErrSpLabelRedefine.GenWithStackByArgs(name)
+		}
+	}
+	c.labels = append(c.labels, procedureCheckerLabel{name: name, isLoop: isLoop})
+	defer func() { c.labels = c.labels[:len(c.labels)-1] }()
+	return c.checkStmt(body)
+}
+
+func (c *procedureChecker) checkIfBlock(block *ast.ProcedureIfBlock) error {
+	if err := c.checkRowRefs(block.IfExpr); err != nil {
+		return err
+	}
+	if err := c.checkStmts(block.ProcedureIfStmts); err != nil {
+		return err
+	}
+	switch x := block.ProcedureElseStmt.(type) {
+	case *ast.ProcedureElseIfBlock:
+		return c.checkIfBlock(x.ProcedureIfStmt)
+	case *ast.ProcedureElseBlock:
+		return c.checkStmts(x.ProcedureIfStmts)
+	}
+	return nil
+}
+
+func (c *procedureChecker) checkCursor(name string) error {
+	for i := len(c.scopes) - 1; i >= 0; i-- {
+		if _, ok := c.scopes[i].cursors[name]; ok {
+			return nil
+		}
+	}
+	return exeerrors.ErrSpCursorMismatch.GenWithStackByArgs(name)
+}
+
+func (c *procedureChecker) hasVar(name string) bool {
+	for i := len(c.scopes) - 1; i >= 0; i-- {
+		if _, ok := c.scopes[i].vars[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *procedureChecker) checkJump(jump *ast.ProcedureJump) error {
+	for i := len(c.labels) - 1; i >= 0; i-- {
+		label := c.labels[i]
+		if strings.EqualFold(label.name, jump.Name) && (jump.IsLeave || label.isLoop) {
+			return nil
+		}
+	}
+	if jump.IsLeave {
+		return exeerrors.ErrSpLilabelMismatch.GenWithStackByArgs("LEAVE", jump.Name)
+	}
+	return exeerrors.ErrSpLilabelMismatch.GenWithStackByArgs("ITERATE", jump.Name)
+}
+
+// CallProcedure runs the stored procedure referenced by a CALL statement.
+// The statements of the procedure body are executed one by one through the
+// session of sctx, so they share the transaction of the caller. Like MySQL,
+// the body runs with the privileges of the definer unless the SQL SECURITY of
+// the procedure is INVOKER. The rows produced by the last statement that
+// returns a result set are returned.
+func CallProcedure(ctx context.Context, sctx sessionctx.Context, stmt *ast.CallStmt) (sqlexec.RecordSet, error) {
+	sessVars := sctx.GetSessionVars()
+	schema := stmt.Procedure.Schema
+	if schema.L == "" {
+		if sessVars.CurrentDB == "" {
+			return nil, plannererrors.ErrNoDB
+		}
+		schema = ast.NewCIStr(sessVars.CurrentDB)
+	}
+	fullName := fmt.Sprintf("%s.%s", schema.O, stmt.Procedure.FnName.O)
+	if pm := privilege.GetPrivilegeManager(sctx); pm != nil && sessVars.User != nil &&
+		!pm.RequestVerification(sessVars.ActiveRoles, schema.L, "", "", mysql.ExecutePriv) {
+		return nil, exeerrors.ErrDBaccessDenied.GenWithStackByArgs(sessVars.User.AuthUsername, sessVars.User.AuthHostname, schema.O)
+	}
+
+	internalCtx := kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	rows, _, err := sctx.GetRestrictedSQLExecutor().ExecRestrictedSQL(internalCtx, nil,
+		`SELECT definition, sql_mode, definer, security_type FROM mysql.routines WHERE routine_schema = %? AND routine_name = %? AND routine_type = %?`,
+		schema.L, stmt.Procedure.FnName.O, procedureRoutineType)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, exeerrors.ErrSpDoesNotExist.GenWithStackByArgs(procedureRoutineType, fullName)
+	}
+	// Like MySQL, a procedure can not call itself unless max_sp_recursion_depth
+	// allows it, otherwise a recursion without end overflows the stack.
+	callKey := strings.ToLower(fullName)
+	if sessVars.ProcedureCalls[callKey] > sessVars.MaxSpRecursionDepth {
+		return nil, exeerrors.ErrSpRecursionLimit.GenWithStackByArgs(sessVars.MaxSpRecursionDepth, stmt.Procedure.FnName.O)
+	}
+	if sessVars.ProcedureCalls == nil {
+		sessVars.ProcedureCalls = make(map[string]int)
+	}
+	sessVars.ProcedureCalls[callKey]++
+	defer func() {
+		if sessVars.ProcedureCalls[callKey]--; sessVars.ProcedureCalls[callKey] == 0 {
+			delete(sessVars.ProcedureCalls, callKey)
+		}
+	}()
+	sqlMode, err := mysql.GetSQLMode(rows[0].GetString(1))
+	if err != nil {
+		return nil, err
+	}
+	p := parser.New()
+	p.SetSQLMode(sqlMode)
+	p.SetParserConfig(sessVars.BuildParserConfig())
+	node, err := p.ParseOneStmt(rows[0].GetString(0), "", "")
+	if err != nil {
+		return nil, err
+	}
+	info, ok := node.(*ast.ProcedureInfo)
+	if !ok {
+		return nil, errors.Errorf("invalid definition of procedure %s", fullName)
+	}
+	if len(info.ProcedureParam) != len(stmt.Procedure.Args) {
+		return nil, exeerrors.ErrSpWrongNoOfArgs.GenWithStackByArgs(procedureRoutineType, fullName,
+			len(info.ProcedureParam), len(stmt.Procedure.Args))
+	}
+
+	e := &procedureExec{sctx: sctx, exec: sctx.GetSQLExecutor()}
+	// The arguments are evaluated in the context of the caller, before the
+	// parameters are visible.
+	params := newProcedureScope()
+	for i, param := range info.ProcedureParam {
+		v := &procedureVar{tp: procedureVarType(param.ParamType)}
+		arg := stmt.Procedure.Args[i]
+		if param.Paramstatus == ast.MODE_IN {
+			value, err := e.evalExpr(ctx, arg)
+			if err != nil {
+				return nil, err
+			}
+			if err := e.setVar(v, value); err != nil {
+				return nil, err
+			}
+		} else {
+			userVar, ok := arg.(*ast.VariableExpr)
+			if !ok || userVar.IsSystem {
+				return nil, exeerrors.ErrSpNotVarArg.GenWithStackByArgs(i+1, fullName)
+			}
+			if value, ok := sessVars.GetUserVarVal(strings.ToLower(userVar.Name)); ok && param.Paramstatus == ast.MODE_INOUT {
+				if err := e.setVar(v, value); err != nil {
+					return nil, err
+				}
+			}
+		}
+		params.vars[strings.ToLower(param.ParamName)] = v
+	}
+
+	if !strings.EqualFold(rows[0].GetEnum(3).String(), "INVOKER") {
+		restoreUser, err := switchToDefiner(ctx, sctx, rows[0].GetString(2))
+		if err != nil {
+			return nil, err
+		}
+		defer restoreUser()
+	}
+	// Like MySQL, the body runs with the database and the sql_mode which the
+	// procedure was created with.
+	defer func(originDB string, originMode mysql.SQLMode) {
+		sessVars.CurrentDB, sessVars.SQLMode = originDB, originMode
+	}(sessVars.CurrentDB, sessVars.SQLMode)
+	sessVars.CurrentDB, sessVars.SQLMode = schema.O, sqlMode
+	e.scopes = append(e.scopes, params)
+	_, err = e.execStmts(ctx, []ast.StmtNode{info.ProcedureBody})
+	e.scopes = e.scopes[:0]
+	if err != nil {
+		return nil, err
+	}
+
+	for i, param := range info.ProcedureParam {
+		if param.Paramstatus == ast.MODE_IN {
+			continue
+		}
+		name := strings.ToLower(stmt.Procedure.Args[i].(*ast.VariableExpr).Name)
+		v := params.vars[strings.ToLower(param.ParamName)]
+		if v.value.IsNull() {
+			sessVars.UnsetUserVar(name)
+			continue
+		}
+		sessVars.SetUserVarVal(name, v.value)
+		sessVars.SetUserVarType(name, v.tp)
+	}
+	return e.lastResult.toRecordSet(sessVars.MaxChunkSize), nil
+}
+
+// switchToDefiner makes the session of sctx use the privileges of definer,
+// which is stored like "user@host", and returns a function to switch back to
+// the current user.
+func switchToDefiner(ctx context.Context, sctx sessionctx.Context, definer string) (func(), error) {
+	sessVars := sctx.GetSessionVars()
+	origin, originRoles := sessVars.User, sessVars.ActiveRoles
+	pm := privilege.GetPrivilegeManager(sctx)
+	idx := strings.LastIndex(definer, "@")
+	if pm == nil || origin == nil || idx < 0 {
+		return func() {}, nil
+	}
+	name, host := definer[:idx], definer[idx+1:]
+	if name == origin.AuthUsername && host == origin.AuthHostname {
+		return func() {}, nil
+	}
+	if !pm.GetAuthWithoutVerification(name, host) {
+		return nil, exeerrors.ErrNoSuchDefiner.GenWithStackByArgs(name, host)
+	}
+	sessVars.User = &auth.UserIdentity{Username: name, Hostname: host, AuthUsername: name, AuthHostname: host}
+	sessVars.ActiveRoles = pm.GetDefaultRoles(ctx, name, host)
+	return func() {
+		pm.AuthSuccess(origin.AuthUsername, origin.AuthHostname)
+		sessVars.User, sessVars.ActiveRoles = origin, originRoles
+	}, nil
+}
+
+// procedureExec interprets the body of a stored procedure or a trigger.
+type procedureExec struct {
+	sctx sessionctx.Context
+	exec sqlexec.SQLExecutor
+	// run executes a statement of the body in place of exec. It is set for
+	// triggers, whose statements run inside the statement firing them.
+	run    func(ctx context.Context, stmt ast.StmtNode) (*procedureResult, error)
+	scopes []*procedureScope
+
+	lastResult *procedureResult
+}
+
+// procedureScope holds the objects declared by a BEGIN ... END block.
+type procedureScope struct {
+	vars     map[string]*procedureVar
+	cursors  map[string]*procedureCursor
+	handlers []*ast.ProcedureErrorControl
+	// handling is set while a handler of this scope is running, so the
+	// errors raised by the handler itself are not caught by its siblings.
+	handling bool
+}
+
+func newProcedureScope() *procedureScope {
+	return &procedureScope{
+		vars:    make(map[string]*procedureVar),
+		cursors: make(map[string]*procedureCursor),
+	}
+}
+
+type procedureVar struct {
+	tp    *types.FieldType
+	value types.Datum
+}
+
+type procedureCursor struct {
+	stmt   ast.StmtNode
+	isOpen bool
+	rows   [][]types.Datum
+	pos    int
+}
+
+// procedureSignal describes a transfer of control that is not sequential.
+type procedureSignal struct {
+	// label is the target of LEAVE and ITERATE.
+	label   string
+	iterate bool
+	// exit is set when an EXIT handler declared in this scope has run.
+	exit *procedureScope
+}
+
+// procedureResult holds the rows returned by a statement of the body.
+type procedureResult struct {
+	fields []*resolve.ResultField
+	rows   [][]types.Datum
+}
+
+func (r *procedureResult) toRecordSet(maxChunkSize int) sqlexec.RecordSet {
+	if r == nil {
+		return nil
+	}
+	rows := make([][]any, 0, len(r.rows))
+	for _, row := range r.rows {
+		values := make([]any, 0, len(row))
+		for _, d := range row {
+			values = append(values, d.GetValue())
+		}
+		rows = append(rows, values)
+	}
+	return &sqlexec.SimpleRecordSet{ResultFields: r.fields, Rows: rows, MaxChunkSize: maxChunkSize}
+}
+
+func (e *procedureExec) execStmts(ctx context.Context, stmts []ast.StmtNode) (*procedureSignal, error) {
+	for _, stmt := range stmts {
+		sig, err := e.execStmt(ctx, stmt)
+		if err != nil {
+			sig, err = e.handleError(ctx, err)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if sig != nil {
+			return sig, nil
+		}
+	}
+	return nil, nil
+}
+
+func (e *procedureExec) execStmt(ctx context.Context, stmt ast.StmtNode) (*procedureSignal, error) {
+	switch x := stmt.(type) {
+	case *ast.ProcedureBlock:
+		return e.execBlock(ctx, x)
+	case *ast.ProcedureLabelBlock:
+		sig, err := e.execBlock(ctx, x.Block)
+		if err != nil || sig == nil {
+			return nil, err
+		}
+		if !sig.iterate && strings.EqualFold(sig.label, x.LabelName) {
+			return nil, nil
+		}
+		return sig, nil
+	case *ast.ProcedureLabelLoop:
+		return e.execLoop(ctx, x.Block, x.LabelName)
+	case *ast.ProcedureWhileStmt, *ast.ProcedureRepeatStmt:
+		return e.execLoop(ctx, x, "")
+	case *ast.ProcedureIfInfo:
+		return e.execIf(ctx, x.IfBody)
+	case *ast.SimpleCaseStmt:
+		return e.execSimpleCase(ctx, x)
+	case *ast.SearchCaseStmt:
+		for _, when := range x.WhenCases {
+			ok, err := e.evalCondition(ctx, when.Expr)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				return e.execStmts(ctx, when.ProcedureStmts)
+			}
+		}
+		if x.ElseCases == nil {
+			return nil, exeerrors.ErrSpCaseNotFound.GenWithStackByArgs()
+		}
+		return e.execStmts(ctx, x.ElseCases)
+	case *ast.ProcedureOpenCur:
+		return nil, e.openCursor(ctx, x.CurName)
+	case *ast.ProcedureFetchInto:
+		return nil, e.fetchCursor(x)
+	case *ast.ProcedureCloseCur:
+		cur, err := e.lookupCursor(x.CurName)
+		if err != nil {
+			return nil, err
+		}
+		if !cur.isOpen {
+			return nil, exeerrors.ErrSpCursorNotOpen.GenWithStackByArgs()
+		}
+		cur.isOpen, cur.rows, cur.pos = false, nil, 0
+		return nil, nil
+	case *ast.ProcedureJump:
+		return &procedureSignal{label: x.Name, iterate: !x.IsLeave}, nil
+	case *ast.SetStmt:
+		return nil, e.execSet(ctx, x)
+	}
+	res, err := e.runStmt(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+	if res != nil {
+		e.lastResult = res
+	}
+	return nil, nil
+}
+
+func (e *procedureExec) execBlock(ctx context.Context, block *ast.ProcedureBlock) (*procedureSignal, error) {
+	scope := newProcedureScope()
+	e.scopes = append(e.scopes, scope)
+	defer func() { e.scopes = e.scopes[:len(e.scopes)-1] }()
+	for _, decl := range block.ProcedureVars {
+		switch x := decl.(type) {
+		case *ast.ProcedureDecl:
+			value := types.NewDatum(nil)
+			if x.DeclDefault != nil {
+				var err error
+				if value, err = e.evalExpr(ctx, x.DeclDefault); err != nil {
+					return nil, err
+				}
+			}
+			for _, name := range x.DeclNames {
+				v := &procedureVar{tp: procedureVarType(x.DeclType)}
+				if err := e.setVar(v, value); err != nil {
+					return nil, err
+				}
+				scope.vars[name] = v
+			}
+		case *ast.ProcedureCursor:
+			scope.cursors[x.CurName] = &procedureCursor{stmt: x.Selectstring}
+		case *ast.ProcedureErrorControl:
+			scope.handlers = append(scope.handlers, x)
+		}
+	}
+	sig, err := e.execStmts(ctx, block.ProcedureProcStmts)
+	if err != nil {
+		return nil, err
+	}
+	if sig != nil && sig.exit == scope {
+		return nil, nil
+	}
+	return sig, nil
+}
+
+func (e *procedureExec) execLoop(ctx context.Context, loop ast.StmtNode, label string) (*procedureSignal, error) {
+	for {
+		if err := e.sctx.GetSessionVars().SQLKiller.HandleSignal(); err != nil {
+			return nil, err
+		}
+		var (
+			sig  *procedureSignal
+			err  error
+			done bool
+		)
+		switch x := loop.(type) {
+		case *ast.ProcedureWhileStmt:
+			ok, err := e.evalCondition(ctx, x.Condition)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				return nil, nil
+			}
+			sig, err = e.execStmts(ctx, x.Body)
+			if err != nil {
+				return nil, err
+			}
+		case *ast.ProcedureRepeatStmt:
+			sig, err = e.execStmts(ctx, x.Body)
+			if err != nil {
+				return nil, err
+			}
+			if sig == nil {
+				if done, err = e.evalCondition(ctx, x.Condition); err != nil {
+					return nil, err
+				}
+			}
+		default:
+			return nil, errors.Errorf("unsupported loop statement %T", loop)
+		}
+		if sig != nil {
+			if label == "" || !strings.EqualFold(sig.label, label) {
+				return sig, nil
+			}
+			if !sig.iterate {
+				return nil, nil
+			}
+		}
+		if done {
+			return nil, nil
+		}
+	}
+}
+
+func (e *procedureExec) execIf(ctx context.Context, block *ast.ProcedureIfBlock) (*procedureSignal, error) {
+	ok, err := e.evalCondition(ctx, block.IfExpr)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return e.execStmts(ctx, block.ProcedureIfStmts)
+	}
+	switch x := block.ProcedureElseStmt.(type) {
+	case *ast.ProcedureElseIfBlock:
+		return e.execIf(ctx, x.ProcedureIfStmt)
+	case *ast.ProcedureElseBlock:
+		return e.execStmts(ctx, x.ProcedureIfStmts)
+	}
+	return nil, nil
+}
+
+func (e *procedureExec) execSimpleCase(ctx context.Context, s *ast.SimpleCaseStmt) (*procedureSignal, error) {
+	value, err := e.evalExpr(ctx, s.Condition)
+	if err != nil {
+		return nil, err
+	}
+	for _, when := range s.WhenCases {
+		cond := &ast.BinaryOperationExpr{Op: opcode.EQ, L: ast.NewValueExpr(value.GetValue(), "", ""), R: when.Expr}
+		ok, err := e.evalCondition(ctx, cond)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return e.execStmts(ctx, when.ProcedureStmts)
+		}
+	}
+	if s.ElseCases == nil {
+		return nil, exeerrors.ErrSpCaseNotFound.GenWithStackByArgs()
+	}
+	return e.execStmts(ctx, s.ElseCases)
+}
+
+// execSet assigns the local variables in a SET statement and forwards the
+// other assignments to the session, keeping the order of the assignments.
+func (e *procedureExec) execSet(ctx context.Context, s *ast.SetStmt) error {
+	hasLocal := false
+	for _, v := range s.Variables {
+		if e.localVarOfAssignment(v) != nil {
+			hasLocal = true
+			break
+		}
+	}
+	if !hasLocal {
+		_, err := e.runStmt(ctx, s)
+		return err
+	}
+	var pending []*ast.VariableAssignment
+	flush := func() error {
+		if len(pending) == 0 {
+			return nil
+		}
+		_, err := e.runStmt(ctx, &ast.SetStmt{Variables: pending})
+		pending = nil
+		return err
+	}
+	for _, v := range s.Variables {
+		local := e.localVarOfAssignment(v)
+		if local == nil {
+			pending = append(pending, v)
+			continue
+		}
+		if err := flush(); err != nil {
+			return err
+		}
+		value, err := e.evalExpr(ctx, v.Value)
+		if err != nil {
+			return err
+		}
+		if err := e.setVar(local, value); err != nil {
+			return err
+		}
+	}
+	return flush()
+}
+
+func (e *procedureExec) localVarOfAssignment(v *ast.VariableAssignment) *procedureVar {
+	if !v.IsSystem || v.IsGlobal {
+		return nil
+	}
+	return e.lookupVar(strings.ToLower(v.Name))
+}
+
+func (e *procedureExec) openCursor(ctx context.Context, name string) error {
+	cur, err := e.lookupCursor(name)
+	if err != nil {
+		return err
+	}
+	if cur.isOpen {
+		return exeerrors.ErrSpCursorAlreadyOpen.GenWithStackByArgs()
+	}
+	res, err := e.runStmt(ctx, cur.stmt)
+	if err != nil {
+		return err
+	}
+	cur.isOpen, cur.pos = true, 0
+	if res != nil {
+		cur.rows = res.rows
+	}
+	return nil
+}
+
+func (e *procedureExec) fetchCursor(s *ast.ProcedureFetchInto) error {
+	cur, err := e.lookupCursor(s.CurName)
+	if err != nil {
+		return err
+	}
+	if !cur.isOpen {
+		return exeerrors.ErrSpCursorNotOpen.GenWithStackByArgs()
+	}
+	if cur.pos >= len(cur.rows) {
+		return exeerrors.ErrSpFetchNoData.GenWithStackByArgs()
+	}
+	row := cur.rows[cur.pos]
+	if len(row) != len(s.Variables) {
+		return exeerrors.ErrSpWrongNoOfFetchArgs.GenWithStackByArgs()
+	}
+	cur.pos++
+	for i, name := range s.Variables {
+		v := e.lookupVar(name)
+		if v == nil {
+			return exeerrors.ErrSpUndeclaredVar.GenWithStackByArgs(name)
+		}
+		if err := e.setVar(v, row[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *procedureExec) lookupVar(name string) *procedureVar {
+	for i := len(e.scopes) - 1; i >= 0; i-- {
+		if v, ok := e.scopes[i].vars[name]; ok {
+			return v
+		}
+	}
+	return nil
+}
+
+func (e *procedureExec) lookupCursor(name string) (*procedureCursor, error) {
+	for i := len(e.scopes) - 1; i >= 0; i-- {
+		if cur, ok := e.scopes[i].cursors[name]; ok {
+			return cur, nil
+		}
+	}
+	return nil, exeerrors.ErrSpCursorMismatch.GenWithStackByArgs(name)
+}
+
+func (e *procedureExec) setVar(v *procedureVar, value types.Datum) error {
+	if value.IsNull() {
+		v.value.SetNull()
+		return nil
+	}
+	converted, err := value.ConvertTo(e.sctx.GetSessionVars().StmtCtx.TypeCtx(), v.tp)
+	if err != nil {
+		return err
+	}
+	v.value = converted
+	return nil
+}
+
+// handleError looks for the innermost handler which matches err. If there is
+// one, the handler is executed and the returned signal tells the caller where
+// to continue, otherwise err is returned as is.
+func (e *procedureExec) handleError(ctx context.Context, err error) (*procedureSignal, error) {
+	code, state, ok := procedureErrorCodeAndState(err)
+	if !ok {
+		return nil, err
+	}
+	for i := len(e.scopes) - 1; i >= 0; i-- {
+		scope := e.scopes[i]
+		if scope.handling {
+			continue
+		}
+		var (
+			handler  *ast.ProcedureErrorControl
+			priority int
+		)
+		for _, h := range scope.handlers {
+			for _, cond := range h.ErrorCon {
+				if p := procedureHandlerPriority(cond, code, state); p > priority {
+					handler, priority = h, p
+				}
+			}
+		}
+		if handler != nil {
+			return e.runHandler(ctx, i, handler)
+		}
+	}
+	return nil, err
+}
+
+func (e *procedureExec) runHandler(ctx context.Context, idx int, handler *ast.ProcedureErrorControl) (*procedureSignal, error) {
+	// The handler runs in the context of the block which declares it, so the
+	// inner blocks are hidden until it finishes.
+	scope := e.scopes[idx]
+	inner := append([]*procedureScope(nil), e.scopes[idx+1:]...)
+	e.scopes = e.scopes[:idx+1]
+	scope.handling = true
+	sig, err := e.execStmts(ctx, []ast.StmtNode{handler.Operate})
+	scope.handling = false
+	e.scopes = append(e.scopes, inner...)
+	if err != nil {
+		return nil, err
+	}
+	if sig == nil && handler.ControlHandle == ast.PROCEDUR_EXIT {
+		sig = &procedureSignal{exit: scope}
+	}
+	return sig, nil
+}
+
+// procedureErrorCodeAndState returns the MySQL error code and SQLSTATE of err.
+// It returns false for the errors that must not be caught by handlers, such
+// as a killed query.
+func procedureErrorCodeAndState(err error) (uint16, string, bool) {
+	if exeerrors.ErrQueryInterrupted.Equal(err) || exeerrors.ErrMaxExecTimeExceeded.Equal(err) ||
+		errors.Cause(err) == context.Canceled {
+		return 0, "", false
+	}
+	if te, ok := errors.Cause(err).(*terror.Error); ok {
+		sqlErr := terror.ToSQLError(te)
+		return sqlErr.Code, sqlErr.State, true
+	}
+	return mysql.ErrUnknown, mysql.DefaultMySQLState, true
+}
+
+// procedureHandlerPriority returns how specific cond matches the error, 0 means
+// it does not match. A handler for an error code is preferred to a handler for
+// a SQLSTATE, which is preferred to a handler for a class of conditions.
+func procedureHandlerPriority(cond ast.ErrNode, code uint16, state string) int {
+	switch x := cond.(type) {
+	case *ast.ProcedureErrorVal:
+		if x.ErrorNum == uint64(code) {
+			return 3
+		}
+	case *ast.ProcedureErrorState:
+		if x.CodeStatus == state {
+			return 2
+		}
+	case *ast.ProcedureErrorCon:
+		class := state[:min(2, len(state))]
+		switch x.ErrorCon {
+		case ast.PROCEDUR_SQLWARNING:
+			if class == "01" {
+				return 1
+			}
+		case ast.PROCEDUR_NOT_FOUND:
+			if class == "02" {
+				return 1
+			}
+		case ast.PROCEDUR_SQLEXCEPTION:
+			if class != "00" && class != "01" && class != "02" {
+				return 1
+			}
+		}
+	}
+	return 0
+}
+
+func (e *procedureExec) evalCondition(ctx context.Context, expr ast.ExprNode) (bool, error) {
+	value, err := e.evalExpr(ctx, expr)
+	if err != nil || value.IsNull() {
+		return false, err
+	}
+	b, err := value.ToBool(e.sctx.GetSessionVars().StmtCtx.TypeCtx())
+	return b != 0, err
+}
+
+// evalExpr evaluates expr by running `SELECT expr`, so subqueries and all the
+// builtin functions can be used in the conditions and the assignments.
+func (e *procedureExec) evalExpr(ctx context.Context, expr ast.ExprNode) (types.Datum, error) {
+	sel := &ast.SelectStmt{
+		SelectStmtOpts: &ast.SelectStmtOpts{SQLCache: true},
+		Kind:           ast.SelectStmtKindSelect,
+		Fields:         &ast.FieldList{Fields: []*ast.SelectField{{Expr: expr}}},
+	}
+	res, err := e.runStmt(ctx, sel)
+	if err != nil || res == nil || len(res.rows) == 0 {
+		return types.NewDatum(nil), err
+	}
+	return res.rows[0][0], nil
+}
+
+// runStmt executes stmt through the session after replacing the references to
+// local variables with their values. The rows of the result set, if any, are
+// read into memory.
+func (e *procedureExec) runStmt(ctx context.Context, stmt ast.StmtNode) (_ *procedureResult, err error) {
+	sub := &procedureVarSubstitutor{e: e, replaced: make(map[ast.Node]ast.Node)}
+	stmt.Accept(sub)
+	defer func() {
+		if len(sub.replaced) > 0 {
+			stmt.Accept(&procedureVarRestorer{replaced: sub.replaced})
+		}
+	}()
+	// The text is used by the slow log and the statement summary, keep the
+	// original one if the values can not be restored.
+	var sb strings.Builder
+	if err := stmt.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err == nil {
+		stmt.SetText(nil, sb.String())
+	}
+	if e.run != nil {
+		return e.run(ctx, stmt)
+	}
+
+	rs, err := e.exec.ExecuteStmt(ctx, stmt)
+	if err != nil || rs == nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := rs.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	rows, err := sqlexec.DrainRecordSet(ctx, rs, e.sctx.GetSessionVars().MaxChunkSize)
+	if err != nil {
+		return nil, err
+	}
+	fields := rs.Fields()
+	fieldTypes := make([]*types.FieldType, 0, len(fields))
+	for _, field := range fields {
+		fieldTypes = append(fieldTypes, &field.Column.FieldType)
+	}
+	res := &procedureResult{fields: fields, rows: make([][]types.Datum, 0, len(rows))}
+	for _, row := range rows {
+		res.rows = append(res.rows, row.GetDatumRow(fieldTypes))
+	}
+	return res, nil
+}
+
+// procedureVarSubstitutor replaces the unqualified column names which refer to
+// local variables with the values of the variables. Like MySQL, a local
+// variable takes precedence over a column with the same name. In a trigger,
+// the columns of the NEW and OLD rows are replaced too.
+type procedureVarSubstitutor struct {
+	e        *procedureExec
+	replaced map[ast.Node]ast.Node
+}
+
+// Enter implements ast.Visitor interface.
+func (*procedureVarSubstitutor) Enter(n ast.Node) (ast.Node, bool) {
+	// The column in VALUES(col) must stay a column name.
+	_, skip := n.(*ast.ValuesExpr)
+	return n, skip
+}
+
+// Leave implements ast.Visitor interface.
+func (s *procedureVarSubstitutor) Leave(n ast.Node) (ast.Node, bool) {
+	col, ok := n.(*ast.ColumnNameExpr)
+	if !ok || col.Name.Schema.L != "" {
+		return n, true
+	}
+	var v *procedureVar
+	switch col.Name.Table.L {
+	case "":
+		v = s.e.lookupVar(col.Name.Name.L)
+	case triggerRowNew, triggerRowOld:
+		v = s.e.lookupVar(col.Name.Table.L + "." + col.Name.Name.L)
+	}
+	if v == nil {
+		return n, true
+	}
+	value := ast.NewValueExpr(v.value.GetValue(), v.tp.GetCharset(), v.tp.GetCollate())
+	s.replaced[value] = col
+	return value, true
+}
+
+// procedureVarRestorer undoes procedureVarSubstitutor, so the statement can be
+// executed again with other values.
+type procedureVarRestorer struct {
+	replaced map[ast.Node]ast.Node
+}
+
+// Enter implements ast.Visitor interface.
+func (*procedureVarRestorer) Enter(n ast.Node) (ast.Node, bool) {
+	_, skip := n.(*ast.ValuesExpr)
+	return n, skip
+}
+
+// Leave implements ast.Visitor interface.
+func (r *procedureVarRestorer) Leave(n ast.Node) (ast.Node, bool) {
+	if origin, ok := r.replaced[n]; ok {
+		return origin, true
+	}
+	return n, true
+}
+
+// procedureVarType completes the type of a parameter or a local variable, so
+// the values can be converted to it.
+func procedureVarType(ft *types.FieldType) *types.FieldType {
+	tp := ft.Clone()
+	flen, decimal := mysql.GetDefaultFieldLengthAndDecimal(tp.GetType())
+	if tp.GetFlen() == types.UnspecifiedLength {
+		tp.SetFlen(flen)
+	}
+	if tp.GetDecimal() == types.UnspecifiedLength {
+		tp.SetDecimal(decimal)
+	}
+	if types.IsString(tp.GetType()) && tp.GetCharset() == "" {
+		tp.SetCharset(mysql.DefaultCharset)
+		tp.SetCollate(mysql.DefaultCollationName)
+	}
+	return tp
+}
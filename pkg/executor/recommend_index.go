@@ -55,7 +55,7 @@ func (e *RecommendIndexExec) Next(ctx context.Context, req *chunk.Chunk) error {
 		return e.showOptions(req)
 	}
 
-	if e.Action != "run" {
+	if e.Action != "run" && e.Action != "drop" {
 		return fmt.Errorf("unsupported action: %s", e.Action)
 	}
 
@@ -72,6 +72,9 @@ func (e *RecommendIndexExec) Next(ctx context.Context, req *chunk.Chunk) error {
 			return errors.New("empty SQLs")
 		}
 	}
+	if e.Action == "drop" {
+		return e.adviseDropIndexes(ctx, req, sqls)
+	}
 	results, err := indexadvisor.AdviseIndexes(ctx, e.Ctx(), sqls, e.Options)
 
 	for _, r := range results {
@@ -92,6 +95,27 @@ func (e *RecommendIndexExec) Next(ctx context.Context, req *chunk.Chunk) error {
 	return err
 }
 
+func (e *RecommendIndexExec) adviseDropIndexes(ctx context.Context, req *chunk.Chunk, sqls []string) error {
+	results, err := indexadvisor.AdviseDropIndexes(ctx, e.Ctx(), sqls, e.Options)
+
+	for _, r := range results {
+		req.AppendString(0, r.Database)
+		req.AppendString(1, r.Table)
+		req.AppendString(2, r.IndexName)
+		req.AppendString(3, strings.Join(r.IndexColumns, ","))
+		req.AppendString(4, fmt.Sprintf("%v", r.IndexSize))
+		req.AppendString(5, r.Reason)
+		req.AppendString(6, fmt.Sprintf("%v", r.WriteAmplificationSaved))
+		jData, err := json.Marshal(r.RegressedQueries)
+		if err != nil {
+			return err
+		}
+		req.AppendString(7, string(jData))
+		req.AppendString(8, fmt.Sprintf("DROP INDEX %s ON %s;", r.IndexName, r.Table))
+	}
+	return err
+}
+
 func (e *RecommendIndexExec) showOptions(req *chunk.Chunk) error {
 	vals, desc, err := indexadvisor.GetOptions(e.Ctx(), indexadvisor.AllOptions...)
 	if err != nil {
@@ -1057,6 +1057,10 @@ func ResetContextOfStmt(ctx sessionctx.Context, s ast.StmtNode) (err error) {
 	case *ast.DeleteStmt:
 		ResetDeleteStmtCtx(sc, stmt, vars)
 		errLevels = sc.ErrLevels()
+	case *ast.MergeStmt:
+		// MERGE has no IGNORE, its errors are handled like the ones of a plain UPDATE.
+		ResetUpdateStmtCtx(sc, &ast.UpdateStmt{}, vars)
+		errLevels = sc.ErrLevels()
 	case *ast.InsertStmt:
 		sc.InInsertStmt = true
 		// For insert statement (not for update statement), disabling the StrictSQLMode
@@ -36,6 +36,7 @@ import (
 	"github.com/pingcap/tidb/pkg/disttask/importinto"
 	"github.com/pingcap/tidb/pkg/domain"
 	"github.com/pingcap/tidb/pkg/domain/infosync"
+	"github.com/pingcap/tidb/pkg/eventscheduler"
 	"github.com/pingcap/tidb/pkg/executor/importer"
 	"github.com/pingcap/tidb/pkg/executor/internal/exec"
 	"github.com/pingcap/tidb/pkg/expression"
@@ -101,6 +102,8 @@ type ShowExec struct {
 	Column            *ast.ColumnName      // Used for `desc table column`.
 	IndexName         ast.CIStr            // Used for show table regions.
 	ResourceGroupName ast.CIStr            // Used for showing resource group
+	ProcedureName     ast.CIStr            // Used for showing create procedure
+	EventName         ast.CIStr            // Used for showing create event
 	Flag              int                  // Some flag parsed from sql, such as FULL.
 	Roles             []*auth.RoleIdentity // Used for show grants.
 	User              *auth.UserIdentity   // Used by show grants, show create user.
@@ -211,7 +214,11 @@ func (e *ShowExec) fetchAll(ctx context.Context) error {
 	case ast.ShowIndex:
 		return e.fetchShowIndex()
 	case ast.ShowProcedureStatus:
-		return e.fetchShowProcedureStatus()
+		return e.fetchShowProcedureStatus(ctx)
+	case ast.ShowCreateProcedure:
+		return e.fetchShowCreateProcedure(ctx)
+	case ast.ShowCreateEvent:
+		return e.fetchShowCreateEvent(ctx)
 	case ast.ShowStatus:
 		return e.fetchShowStatus()
 	case ast.ShowTables:
@@ -231,7 +238,7 @@ func (e *ShowExec) fetchAll(ctx context.Context) error {
 	case ast.ShowProcessList:
 		return e.fetchShowProcessList()
 	case ast.ShowEvents:
-		// empty result
+		return e.fetchShowEvents(ctx)
 	case ast.ShowStatsExtended:
 		return e.fetchShowStatsExtended(ctx)
 	case ast.ShowStatsMeta:
@@ -2012,7 +2019,112 @@ func (*ShowExec) fetchShowTriggers() error {
 	return nil
 }
 
-func (*ShowExec) fetchShowProcedureStatus() error {
+func (e *ShowExec) fetchShowProcedureStatus(ctx context.Context) error {
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	rows, _, err := e.Ctx().GetRestrictedSQLExecutor().ExecRestrictedSQL(ctx, nil,
+		`SELECT routine_schema, routine_name, routine_type, definer, last_altered, created, security_type, comment,
+			character_set_client, collation_connection, database_collation
+		FROM mysql.routines WHERE routine_type = %? ORDER BY routine_schema, routine_name`, procedureRoutineType)
+	if err != nil {
+		return err
+	}
+	checker := privilege.GetPrivilegeManager(e.Ctx())
+	for _, row := range rows {
+		db := row.GetString(0)
+		if checker != nil && e.Ctx().GetSessionVars().User != nil && !checker.DBIsVisible(e.Ctx().GetSessionVars().ActiveRoles, db) {
+			continue
+		}
+		e.appendRow([]any{db, row.GetString(1), row.GetEnum(2).String(), row.GetString(3), row.GetTime(4),
+			row.GetTime(5), row.GetEnum(6).String(), row.GetString(7), row.GetString(8), row.GetString(9), row.GetString(10)})
+	}
+	return nil
+}
+
+func (e *ShowExec) fetchShowCreateProcedure(ctx context.Context) error {
+	checker := privilege.GetPrivilegeManager(e.Ctx())
+	if checker != nil && e.Ctx().GetSessionVars().User != nil {
+		if !checker.DBIsVisible(e.Ctx().GetSessionVars().ActiveRoles, e.DBName.O) {
+			return e.dbAccessDenied()
+		}
+	}
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	rows, _, err := e.Ctx().GetRestrictedSQLExecutor().ExecRestrictedSQL(ctx, nil,
+		`SELECT routine_name, sql_mode, definition, character_set_client, collation_connection, database_collation
+		FROM mysql.routines WHERE routine_schema = %? AND routine_name = %? AND routine_type = %?`,
+		e.DBName.L, e.ProcedureName.O, procedureRoutineType)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return exeerrors.ErrSpDoesNotExist.GenWithStackByArgs(procedureRoutineType,
+			fmt.Sprintf("%s.%s", e.DBName.O, e.ProcedureName.O))
+	}
+	row := rows[0]
+	e.appendRow([]any{row.GetString(0), row.GetString(1), row.GetString(2), row.GetString(3), row.GetString(4), row.GetString(5)})
+	return nil
+}
+
+func (e *ShowExec) fetchShowEvents(ctx context.Context) error {
+	checker := privilege.GetPrivilegeManager(e.Ctx())
+	if checker != nil && e.Ctx().GetSessionVars().User != nil {
+		if !checker.DBIsVisible(e.Ctx().GetSessionVars().ActiveRoles, e.DBName.O) {
+			return e.dbAccessDenied()
+		}
+	}
+	timers, err := getEventTimers(ctx, e.Ctx(), e.DBName.L)
+	if err != nil {
+		return err
+	}
+	for _, timer := range timers {
+		ev, err := eventscheduler.UnmarshalEvent(timer.Data)
+		if err != nil {
+			return err
+		}
+		row := eventRow(ev, timer)
+		e.appendRow([]any{ev.Schema, ev.Name, ev.TimeZone, ev.Definer, row.tp, row.executeAt, row.intervalValue,
+			row.intervalField, row.starts, row.ends, row.status, int64(0), ev.CharsetClient, ev.CollationConnection,
+			ev.DatabaseCollation})
+	}
+	return nil
+}
+
+func (e *ShowExec) fetchShowCreateEvent(ctx context.Context) error {
+	checker := privilege.GetPrivilegeManager(e.Ctx())
+	if checker != nil && e.Ctx().GetSessionVars().User != nil {
+		if !checker.DBIsVisible(e.Ctx().GetSessionVars().ActiveRoles, e.DBName.O) {
+			return e.dbAccessDenied()
+		}
+	}
+	cli, err := eventTimerClient(e.Ctx())
+	if err != nil {
+		return err
+	}
+	timer, err := getEventTimer(ctx, cli, e.DBName.L, e.EventName.L)
+	if err != nil {
+		return err
+	}
+	if timer == nil {
+		return exeerrors.ErrEventDoesNotExist.GenWithStackByArgs(e.EventName.O)
+	}
+	ev, err := eventscheduler.UnmarshalEvent(timer.Data)
+	if err != nil {
+		return err
+	}
+	def, err := parseEventDefinition(e.Ctx(), ev)
+	if err != nil {
+		return err
+	}
+	// The event may be disabled by the scheduler after its last execution, so
+	// the status is always taken from the timer.
+	def.Status = ast.EventStatusEnable
+	if !timer.Enable {
+		def.Status = ast.EventStatusDisable
+	}
+	definition, err := restoreEventDefinition(def)
+	if err != nil {
+		return err
+	}
+	e.appendRow([]any{ev.Name, ev.SQLMode, ev.TimeZone, definition, ev.CharsetClient, ev.CollationConnection, ev.DatabaseCollation})
 	return nil
 }
 
@@ -2463,6 +2575,11 @@ func FillOneImportJobInfo(result *chunk.Chunk, info *importer.JobInfo, importedR
 		result.AppendTime(11, info.EndTime)
 	}
 	result.AppendString(12, info.CreatedBy)
+	if info.Summary != nil {
+		result.AppendUint64(13, info.Summary.RejectedRows)
+	} else {
+		result.AppendNull(13)
+	}
 }
 
 func handleImportJobInfo(ctx context.Context, info *importer.JobInfo, result *chunk.Chunk) error {
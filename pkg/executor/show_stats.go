@@ -85,18 +85,16 @@ func (e *ShowExec) appendTableForStatsExtended(dbName string, tbl *model.TableIn
 		}
 		sb.WriteString("]")
 		colNames := sb.String()
-		var statsType, statsVal string
+		var statsType string
 		switch item.Tp {
 		case ast.StatsTypeCorrelation:
 			statsType = "correlation"
-			statsVal = fmt.Sprintf("%f", item.ScalarVals)
 		case ast.StatsTypeDependency:
 			statsType = "dependency"
-			statsVal = item.StringVals
 		case ast.StatsTypeCardinality:
 			statsType = "cardinality"
-			statsVal = item.StringVals
 		}
+		statsVal := fmt.Sprintf("%f", item.ScalarVals)
 		e.appendRow([]any{
 			dbName,
 			tbl.Name.L,
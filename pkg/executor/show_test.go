@@ -32,7 +32,8 @@ import (
 func Test_fillOneImportJobInfo(t *testing.T) {
 	typeBytes := []byte{mysql.TypeLonglong, mysql.TypeString, mysql.TypeString, mysql.TypeLonglong,
 		mysql.TypeString, mysql.TypeString, mysql.TypeString, mysql.TypeLonglong,
-		mysql.TypeString, mysql.TypeTimestamp, mysql.TypeTimestamp, mysql.TypeTimestamp, mysql.TypeString}
+		mysql.TypeString, mysql.TypeTimestamp, mysql.TypeTimestamp, mysql.TypeTimestamp, mysql.TypeString,
+		mysql.TypeLonglong}
 	fieldTypes := make([]*types.FieldType, 0, len(typeBytes))
 	for _, tp := range typeBytes {
 		fieldType := types.NewFieldType(tp)
@@ -52,6 +53,7 @@ func Test_fillOneImportJobInfo(t *testing.T) {
 	require.True(t, c.GetRow(0).IsNull(7))
 	require.True(t, c.GetRow(0).IsNull(10))
 	require.True(t, c.GetRow(0).IsNull(11))
+	require.True(t, c.GetRow(0).IsNull(13))
 
 	executor.FillOneImportJobInfo(c, jobInfo, 0)
 	require.False(t, c.GetRow(1).IsNull(7))
@@ -59,7 +61,7 @@ func Test_fillOneImportJobInfo(t *testing.T) {
 	require.True(t, c.GetRow(1).IsNull(10))
 	require.True(t, c.GetRow(1).IsNull(11))
 
-	jobInfo.Summary = &importer.JobSummary{ImportedRows: 123}
+	jobInfo.Summary = &importer.JobSummary{ImportedRows: 123, RejectedRows: 2}
 	jobInfo.StartTime = types.NewTime(types.FromGoTime(time.Now()), mysql.TypeTimestamp, 0)
 	jobInfo.EndTime = types.NewTime(types.FromGoTime(time.Now()), mysql.TypeTimestamp, 0)
 	executor.FillOneImportJobInfo(c, jobInfo, 0)
@@ -67,6 +69,8 @@ func Test_fillOneImportJobInfo(t *testing.T) {
 	require.Equal(t, uint64(123), c.GetRow(2).GetUint64(7))
 	require.False(t, c.GetRow(2).IsNull(10))
 	require.False(t, c.GetRow(2).IsNull(11))
+	require.Equal(t, "", c.GetRow(2).GetString(8))
+	require.Equal(t, uint64(2), c.GetRow(2).GetUint64(13))
 }
 
 func TestShow(t *testing.T) {
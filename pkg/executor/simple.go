@@ -203,6 +203,18 @@ func (e *SimpleExec) Next(ctx context.Context, _ *chunk.Chunk) (err error) {
 		err = e.executeAlterRange(x)
 	case *ast.DropQueryWatchStmt:
 		err = e.executeDropQueryWatch(x)
+	case *ast.ProcedureInfo:
+		err = e.executeCreateProcedure(ctx, x)
+	case *ast.DropProcedureStmt:
+		err = e.executeDropProcedure(ctx, x)
+	case *ast.CreateEventStmt:
+		err = e.executeCreateEvent(ctx, x)
+	case *ast.AlterEventStmt:
+		err = e.executeAlterEvent(ctx, x)
+	case *ast.DropEventStmt:
+		err = e.executeDropEvent(ctx, x)
+	case *ast.RefreshMaterializedViewStmt:
+		err = e.executeRefreshMaterializedView(ctx, x)
 	}
 	e.done = true
 	return err
@@ -2859,7 +2871,11 @@ func (e *SimpleExec) autoNewTxn() bool {
 	// Data definition language (DDL) statements that define or modify database objects.
 	// (handled in DDL package)
 	// Statements that implicitly use or modify tables in the mysql database.
-	case *ast.CreateUserStmt, *ast.AlterUserStmt, *ast.DropUserStmt, *ast.RenameUserStmt, *ast.RevokeRoleStmt, *ast.GrantRoleStmt:
+	case *ast.CreateUserStmt, *ast.AlterUserStmt, *ast.DropUserStmt, *ast.RenameUserStmt, *ast.RevokeRoleStmt, *ast.GrantRoleStmt,
+		*ast.ProcedureInfo, *ast.DropProcedureStmt, *ast.CreateEventStmt, *ast.AlterEventStmt, *ast.DropEventStmt:
+		return true
+	// Like DDL, REFRESH MATERIALIZED VIEW writes the view in its own transaction.
+	case *ast.RefreshMaterializedViewStmt:
 		return true
 	// Transaction-control and locking statements.  BEGIN, LOCK TABLES, SET autocommit = 1 (if the value is not already 1), START TRANSACTION, UNLOCK TABLES.
 	// (handled in other place)
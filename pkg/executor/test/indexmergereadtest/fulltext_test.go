@@ -0,0 +1,94 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indexmergereadtest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchAgainstOnTiKV(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	// Without TiFlash, the FULLTEXT index is stored in TiKV.
+	tk.MustExec("create table t(id int primary key, title varchar(100), body text, fulltext index idx_body(body))")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  `title` varchar(100) DEFAULT NULL,\n" +
+		"  `body` text DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */,\n" +
+		"  FULLTEXT INDEX `idx_body`(`body`) WITH PARSER STANDARD\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	tk.MustExec(`insert into t values
+		(1, 'a', 'MySQL is a popular database'),
+		(2, 'b', 'TiDB is a distributed database, TiDB is compatible with MySQL'),
+		(3, 'c', 'Apple pie recipe'),
+		(4, 'd', null)`)
+
+	// The FULLTEXT index stored in TiKV can only be used by IndexMerge.
+	hasIndexMerge := func(sql string) bool {
+		for _, row := range tk.MustQuery("explain format = 'brief' " + sql).Rows() {
+			if strings.Contains(row[3].(string), "index:idx_body") {
+				return true
+			}
+		}
+		return false
+	}
+	sql := "select id from t where match(body) against('mysql tidb') order by id"
+	tk.MustQuery(sql).Check(testkit.Rows("1", "2"))
+	require.True(t, hasIndexMerge(sql))
+	sql = "select id from t where match(body) against('+database -tidb' in boolean mode)"
+	tk.MustQuery(sql).Check(testkit.Rows("1"))
+	require.True(t, hasIndexMerge(sql))
+	tk.MustQuery(`select id from t where match(body) against('"apple pie"' in boolean mode)`).Check(testkit.Rows("3"))
+	// A prefix word can't be looked up in the index, the MATCH is evaluated on every row.
+	sql = "select id from t where match(body) against('data*' in boolean mode) order by id"
+	tk.MustQuery(sql).Check(testkit.Rows("1", "2"))
+	require.False(t, hasIndexMerge(sql))
+	// Order by the relevance.
+	tk.MustQuery("select id, (match(body) against('tidb')) > 0, match(body) against('tidb mysql') as score from t order by score desc, id").Check(testkit.Rows(
+		"2 1 0.9521713170536843", "1 0 0.4472135954999579", "3 0 0", "4 0 0"))
+	tk.MustQuery("select id from t order by match(body) against('tidb mysql') desc, id").Check(testkit.Rows("2", "1", "3", "4"))
+
+	// The index is maintained by the writes.
+	tk.MustExec("update t set body = 'TiKV is a key value store' where id = 1")
+	tk.MustExec("delete from t where id = 3")
+	tk.MustExec("insert into t values (5, 'e', 'Apple and MySQL')")
+	tk.MustQuery("select id from t where match(body) against('mysql') order by id").Check(testkit.Rows("2", "5"))
+	tk.MustQuery("select id from t where match(body) against('apple tikv') order by id").Check(testkit.Rows("1", "5"))
+	tk.MustExec("admin check table t")
+
+	// Other predicates on the column don't use the FULLTEXT index.
+	tk.MustQuery("select id from t where body = 'Apple and MySQL' or id = 1 order by id").Check(testkit.Rows("1", "5"))
+	tk.MustQuery("select id from t where body = 'Apple and MySQL' and id > 1").Check(testkit.Rows("5"))
+
+	tk.MustGetErrCode("select * from t where match(title) against('a')", errno.ErrFtMatchingKeyNotFound)
+	tk.MustGetErrCode("select * from t where match(title, body) against('a')", errno.ErrFtMatchingKeyNotFound)
+	tk.MustGetErrCode("select * from t where match(body) against(title)", errno.ErrWrongArguments)
+	tk.MustGetErrCode("select * from t where match(body) against('a' with query expansion)", errno.ErrNotSupportedYet)
+
+	// Adding a FULLTEXT index backfills the existing rows.
+	tk.MustExec("alter table t add fulltext index idx_title(title) with parser multilingual")
+	tk.MustExec("insert into t values (6, '分布式数据库', 'x')")
+	tk.MustQuery("select id from t where match(title) against('数据库')").Check(testkit.Rows("6"))
+	tk.MustQuery("select id from t where match(title) against('+b +e' in boolean mode) or match(title) against('d')").Check(testkit.Rows("4"))
+	tk.MustQuery("select id from t where match(title) against('b e') order by id").Check(testkit.Rows("2", "5"))
+	tk.MustExec("admin check table t")
+}
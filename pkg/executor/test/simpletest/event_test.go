@@ -0,0 +1,128 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simpletest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAlterDropEvent(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("set @@time_zone = '+00:00'")
+	tk.MustExec("create table t (a int)")
+	tk.MustExec("create event e1 on schedule every 1 day starts '2035-01-01 00:00:00' comment 'daily' do insert into t values (1)")
+	tk.MustGetErrCode("create event e1 on schedule every 1 hour do insert into t values (1)", 1537)
+	tk.MustExec("create event if not exists e1 on schedule every 1 hour do insert into t values (1)")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1537 Event 'e1' already exists"))
+	tk.MustGetErrCode("create event nodb.e2 on schedule every 1 hour do insert into t values (1)", 1049)
+	tk.MustGetErrCode("create event e2 on schedule every 0 hour do insert into t values (1)", 1542)
+	tk.MustGetErrCode("create event e2 on schedule every 1 hour starts '2035-01-02' ends '2035-01-01' do insert into t values (1)", 1543)
+
+	tk.MustQuery("show events").Check(testkit.Rows(
+		"test e1 +00:00 root@% RECURRING <nil> 1 DAY 2035-01-01 00:00:00 <nil> ENABLED 0 utf8mb4 utf8mb4_bin utf8mb4_bin"))
+	tk.MustQuery("show events like 'x%'").Check(testkit.Rows())
+	tk.MustQuery("show create event e1").Check(testkit.Rows(
+		"e1 ONLY_FULL_GROUP_BY,STRICT_TRANS_TABLES,NO_ZERO_IN_DATE,NO_ZERO_DATE,ERROR_FOR_DIVISION_BY_ZERO,NO_AUTO_CREATE_USER,NO_ENGINE_SUBSTITUTION +00:00 " +
+			"CREATE DEFINER = `root`@`%` EVENT `e1` ON SCHEDULE EVERY 1 DAY STARTS _UTF8MB4'2035-01-01 00:00:00' ENABLE COMMENT 'daily' DO INSERT INTO `t` VALUES (1) utf8mb4 utf8mb4_bin utf8mb4_bin"))
+	tk.MustQuery("select event_schema, event_name, definer, event_type, interval_value, interval_field, starts, ends, status, on_completion, event_comment " +
+		"from information_schema.events").Check(testkit.Rows(
+		"test e1 root@% RECURRING 1 DAY 2035-01-01 00:00:00 <nil> ENABLED NOT PRESERVE daily"))
+
+	tk.MustExec("alter event e1 on schedule every 2 hour starts '2035-02-01 00:00:00' on completion preserve disable")
+	tk.MustQuery("select interval_value, interval_field, starts, status, on_completion, event_comment from information_schema.events").Check(testkit.Rows(
+		"2 HOUR 2035-02-01 00:00:00 DISABLED PRESERVE daily"))
+	tk.MustGetErrCode("alter event e1 rename to e1", 1551)
+	tk.MustGetErrCode("alter event e2 enable", 1539)
+	tk.MustExec("alter event e1 rename to e2 enable comment ''")
+	tk.MustQuery("select event_name, interval_value, starts, status, event_comment from information_schema.events").Check(testkit.Rows(
+		"e2 2 2035-02-01 00:00:00 ENABLED "))
+
+	// An expired event is dropped at once, unless ON COMPLETION PRESERVE is specified.
+	tk.MustExec("create event e3 on schedule at '2000-01-01 00:00:00' do insert into t values (1)")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1588 Event execution time is in the past and ON COMPLETION NOT PRESERVE is set. The event was dropped immediately after creation."))
+	tk.MustExec("create event e3 on schedule at '2000-01-01 00:00:00' on completion preserve do insert into t values (1)")
+	tk.MustQuery("select event_name, event_type, execute_at, status from information_schema.events where event_name = 'e3'").Check(testkit.Rows(
+		"e3 ONE TIME 2000-01-01 00:00:00 DISABLED"))
+
+	tk.MustExec("drop event e2")
+	tk.MustExec("drop event e3")
+	tk.MustGetErrCode("drop event e2", 1539)
+	tk.MustExec("drop event if exists e2")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1539 Unknown event 'e2'"))
+	tk.MustQuery("show events").Check(testkit.Rows())
+}
+
+func TestEventPrivilege(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("create user u1")
+	tk.MustExec("create database db1")
+	tk.MustExec("create database db2")
+	tk.MustExec("grant event on db1.* to u1")
+
+	tk1 := testkit.NewTestKit(t, store)
+	tk1.MustExec("set @@time_zone = '+00:00'")
+	require.NoError(t, tk1.Session().Auth(&auth.UserIdentity{Username: "u1", Hostname: "%"}, nil, nil, nil))
+	tk1.MustExec("create event db1.e on schedule every 1 day do select 1")
+	tk1.MustGetErrCode("create event db2.e on schedule every 1 day do select 1", 1044)
+	tk1.MustGetErrCode("create definer = 'root'@'%' event db1.e2 on schedule every 1 day do select 1", 1227)
+	tk1.MustQuery("select event_schema, event_name, definer from information_schema.events").Check(testkit.Rows("db1 e u1@%"))
+	tk1.MustExec("drop event db1.e")
+
+	// The user who alters an event becomes its definer, so the body can't be
+	// changed to run with the privileges of another user.
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("create event db1.e3 on schedule every 1 day disable do select 1")
+	tk.MustQuery("select definer from information_schema.events where event_name = 'e3'").Check(testkit.Rows("root@%"))
+	tk1.MustExec("alter event db1.e3 do update mysql.user set super_priv = 'Y' where user = 'u1'")
+	tk1.MustQuery("select definer from information_schema.events where event_name = 'e3'").Check(testkit.Rows("u1@%"))
+	tk1.MustQuery("show create event db1.e3").CheckContain("CREATE DEFINER = `u1`@`%` EVENT `e3`")
+	tk1.MustExec("drop event db1.e3")
+}
+
+func TestEventExecution(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, u varchar(64))")
+	tk.MustExec("set @@global.event_scheduler = on")
+	defer tk.MustExec("set @@global.event_scheduler = default")
+
+	tk.MustExec("create event e1 on schedule at current_timestamp do insert into t values (1, current_user())")
+	require.Eventually(t, func() bool {
+		return len(tk.MustQuery("select * from t").Rows()) == 1
+	}, 30*time.Second, 100*time.Millisecond)
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 root@%"))
+	// The one-time event is dropped after it runs.
+	require.Eventually(t, func() bool {
+		return len(tk.MustQuery("show events").Rows()) == 0
+	}, 30*time.Second, 100*time.Millisecond)
+
+	tk.MustExec("create event e2 on schedule at current_timestamp on completion preserve do insert into t values (2, database())")
+	require.Eventually(t, func() bool {
+		return len(tk.MustQuery("select status from information_schema.events where event_name = 'e2' and status = 'DISABLED'").Rows()) == 1
+	}, 30*time.Second, 100*time.Millisecond)
+	tk.MustQuery("select * from t order by a").Check(testkit.Rows("1 root@%", "2 test"))
+	require.Len(t, tk.MustQuery("select last_executed from information_schema.events where event_name = 'e2' and last_executed is not null").Rows(), 1)
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simpletest
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/testkit"
+)
+
+func TestCreateDropMaterializedView(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int)")
+	tk.MustExec("create table s (a int, c int)")
+	tk.MustExec("insert into t values (1, 1), (1, 2), (2, 3)")
+	tk.MustExec("insert into s values (1, 10)")
+
+	// A view which can't be fast refreshed has no log table.
+	tk.MustExec("create materialized view mv1 as select t.a, t.b, s.c from t join s on t.a = s.a")
+	tk.MustQuery("select * from mv1 order by b").Check(testkit.Rows("1 1 10", "1 2 10"))
+	tk.MustGetErrCode("create materialized view mv1 as select 1", 1050)
+	tk.MustExec("create materialized view if not exists mv1 as select 1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1050 Table 'test.mv1' already exists"))
+	tk.MustGetErrCode("refresh materialized view mv1 fast", 8272)
+	tk.MustExec("insert into s values (2, 20)")
+	tk.MustExec("refresh materialized view mv1")
+	tk.MustQuery("select * from mv1 order by b").Check(testkit.Rows("1 1 10", "1 2 10", "2 3 20"))
+
+	tk.MustExec("create materialized view mv2 (x, cnt) as select a, count(*) from t group by a")
+	tk.MustQuery("show tables like 'mlog%'").Check(testkit.Rows("mlog$_mv2"))
+	tk.MustQuery("select * from mv2 order by x").Check(testkit.Rows("1 2", "2 1"))
+	tk.MustGetErrCode("create materialized view mv3 (x) as select a, count(*) from t group by a", 1353)
+	tk.MustGetErrCode("refresh materialized view t", 1347)
+
+	// The view, its log table and its base table can't be changed directly.
+	tk.MustGetErrCode("insert into mv2 values (3, 1)", 8273)
+	tk.MustGetErrCode("delete from `mlog$_mv2`", 8273)
+	tk.MustGetErrCode("truncate table mv2", 8273)
+	tk.MustGetErrCode("drop table mv2", 8273)
+	tk.MustGetErrCode("alter table t add column c int", 8274)
+	tk.MustGetErrCode("drop table t", 8274)
+	tk.MustGetErrCode("rename table t to t1", 8274)
+	tk.MustGetErrCode("drop materialized view t", 1347)
+
+	tk.MustExec("drop materialized view mv2")
+	tk.MustQuery("show tables like 'mlog%'").Check(testkit.Rows())
+	tk.MustGetErrCode("drop materialized view mv2", 1051)
+	tk.MustExec("drop materialized view if exists mv2")
+	tk.MustExec("drop materialized view mv1")
+	tk.MustExec("alter table t add column c int")
+	tk.MustExec("drop table t")
+}
+
+func TestFastRefreshMaterializedView(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, a int, b int)")
+	tk.MustExec("insert into t values (1, 1, 1), (2, 1, 2), (3, 2, null)")
+	tk.MustExec("create materialized view mv (a, cnt, s, c) as select a, count(*), sum(b), count(b) from t where id < 100 group by a")
+	tk.MustQuery("select * from mv order by a").Check(testkit.Rows("1 2 3 2", "2 1 <nil> 0"))
+
+	tk.MustExec("insert into t values (4, 3, 5), (100, 3, 5)")
+	tk.MustExec("update t set b = 10 where id = 3")
+	tk.MustExec("delete from t where id = 1")
+	tk.MustExec("replace into t values (2, 4, 4)")
+	tk.MustExec("insert into t values (4, 3, 5) on duplicate key update b = b + 1")
+	tk.MustQuery("select count(*) from `mlog$_mv`").Check(testkit.Rows("9"))
+	// The view isn't changed until it's refreshed.
+	tk.MustQuery("select * from mv order by a").Check(testkit.Rows("1 2 3 2", "2 1 <nil> 0"))
+
+	tk.MustExec("refresh materialized view mv fast")
+	tk.MustQuery("select count(*) from `mlog$_mv`").Check(testkit.Rows("0"))
+	expected := tk.MustQuery("select a, count(*), sum(b), count(b) from t where id < 100 group by a order by a").Rows()
+	tk.MustQuery("select * from mv order by a").Check(expected)
+	tk.MustQuery("select * from mv order by a").Check(testkit.Rows("2 1 10 1", "3 1 6 1", "4 1 4 1"))
+
+	// Changes in a rolled back transaction aren't logged.
+	tk.MustExec("begin")
+	tk.MustExec("insert into t values (5, 2, 1)")
+	tk.MustExec("rollback")
+	tk.MustExec("begin")
+	tk.MustExec("delete from t where a = 2")
+	tk.MustExec("commit")
+	tk.MustExec("refresh materialized view mv")
+	tk.MustQuery("select * from mv order by a").Check(testkit.Rows("3 1 6 1", "4 1 4 1"))
+
+	tk.MustExec("insert into t values (6, 3, null)")
+	tk.MustExec("refresh materialized view mv complete")
+	tk.MustQuery("select count(*) from `mlog$_mv`").Check(testkit.Rows("0"))
+	tk.MustQuery("select * from mv order by a").Check(testkit.Rows("3 2 6 1", "4 1 4 1"))
+}
+
+func TestMaterializedViewRewrite(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int, c int)")
+	tk.MustExec("insert into t values (1, 1, 1), (1, 2, 2), (2, 1, 3)")
+	tk.MustExec("create materialized view mv (ga, gb, cnt, s, sc) as select a, b, count(*), sum(c), count(c) from t group by a, b")
+	tk.MustQuery("select @@tidb_enable_materialized_view_rewrite").Check(testkit.Rows("0"))
+	tk.MustExec("insert into t values (2, 1, 4)")
+
+	// The rewritten query reads the view, which isn't refreshed yet.
+	query := "select a, sum(c) from t group by a order by a"
+	tk.MustQuery(query).Check(testkit.Rows("1 3", "2 7"))
+	tk.MustExec("set @@tidb_enable_materialized_view_rewrite = 1")
+	tk.MustQuery(query).Check(testkit.Rows("1 3", "2 3"))
+	tk.MustQuery("explain format = 'brief' " + query).CheckContain("table:mv")
+	tk.MustExec("refresh materialized view mv")
+	tk.MustQuery(query).Check(testkit.Rows("1 3", "2 7"))
+	tk.MustQuery("select a, b, avg(c) from t group by a, b having count(*) > 1").Check(testkit.Rows("2 1 3.5000"))
+	tk.MustExec("insert into t values (3, 3, 3)")
+	// The query isn't rewritten if the WHERE clause is different.
+	tk.MustQuery("select count(*) from t where a > 2").Check(testkit.Rows("1"))
+	tk.MustQuery("explain format = 'brief' select count(*) from t where a > 2").CheckNotContain("table:mv")
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("4"))
+	tk.MustExec("set @@tidb_enable_materialized_view_rewrite = 0")
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("5"))
+}
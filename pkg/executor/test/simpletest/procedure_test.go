@@ -0,0 +1,298 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simpletest
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateDropProcedure(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create procedure p1() select 1")
+	tk.MustGetErrCode("create procedure p1() select 2", 1304)
+	tk.MustExec("create procedure if not exists p1() select 2")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1304 PROCEDURE test.p1 already exists"))
+	tk.MustQuery("select routine_schema, routine_name, routine_type from mysql.routines").Check(testkit.Rows("test p1 PROCEDURE"))
+	tk.MustQuery("show create procedure p1").CheckAt([]int{0, 2}, [][]any{{"p1", "create procedure p1() select 1"}})
+	tk.MustQuery("show procedure status like 'p%'").CheckAt([]int{0, 1, 2}, testkit.Rows("test p1 PROCEDURE"))
+	tk.MustQuery("show procedure status like 'q%'").Check(testkit.Rows())
+
+	tk.MustExec("drop procedure p1")
+	tk.MustGetErrCode("drop procedure p1", 1305)
+	tk.MustExec("drop procedure if exists p1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1305 PROCEDURE test.p1 does not exist"))
+	tk.MustGetErrCode("call p1()", 1305)
+	tk.MustGetErrCode("create procedure nodb.p1() select 1", 1049)
+
+	// Errors in the body are reported when the procedure is created.
+	tk.MustGetErrCode("create procedure p2(a int, a int) select 1", 1330)
+	tk.MustGetErrCode("create procedure p2() begin declare a int; declare a int; end", 1331)
+	tk.MustGetErrCode("create procedure p2() begin open c; end", 1324)
+	tk.MustGetErrCode("create procedure p2() begin declare c cursor for select 1; fetch c into a; end", 1327)
+	tk.MustGetErrCode("create procedure p2() begin leave l1; end", 1308)
+	tk.MustGetErrCode("create procedure p2() l1: begin iterate l1; end l1", 1308)
+	tk.MustGetErrCode("create procedure p2() begin use test; end", 1314)
+}
+
+func TestCallProcedure(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, v varchar(10))")
+	tk.MustExec("insert into t values (1, 'a'), (2, 'b'), (3, 'c')")
+
+	tk.MustExec("create procedure p_sel(in x int) select v from t where id > x order by id")
+	tk.MustQuery("call p_sel(1)").Check(testkit.Rows("b", "c"))
+	tk.MustGetErrCode("call p_sel()", 1318)
+
+	// IN, OUT and INOUT parameters.
+	tk.MustExec(`create procedure p_param(in a int, out b int, inout c int)
+begin
+	set b = a * 2;
+	set c = c + a;
+	set a = 0;
+end`)
+	tk.MustExec("set @c = 10")
+	tk.MustExec("call p_param(5, @b, @c)")
+	tk.MustQuery("select @b, @c").Check(testkit.Rows("10 15"))
+	tk.MustGetErrCode("call p_param(5, 1, @c)", 1414)
+
+	// Loops, labels and conditions.
+	tk.MustExec(`create procedure p_loop(out total int)
+begin
+	declare i int default 0;
+	set total = 0;
+	l1: while true do
+		set i = i + 1;
+		if i > 10 then
+			leave l1;
+		elseif i % 2 = 0 then
+			iterate l1;
+		end if;
+		set total = total + i;
+	end while l1;
+	while i > 0 do
+		set i = i - 5;
+	end while;
+	repeat
+		set i = i + 1;
+	until i >= 3 end repeat;
+	set total = total * 100 + i;
+end`)
+	tk.MustExec("call p_loop(@total)")
+	tk.MustQuery("select @total").Check(testkit.Rows("2503"))
+
+	tk.MustExec(`create procedure p_case(in x int, out y varchar(10))
+begin
+	case x
+		when 1 then set y = 'one';
+		when 2 then set y = 'two';
+	end case;
+end`)
+	tk.MustExec("call p_case(2, @y)")
+	tk.MustQuery("select @y").Check(testkit.Rows("two"))
+	tk.MustGetErrCode("call p_case(3, @y)", 1339)
+
+	// Local variables take precedence over the columns with the same name.
+	tk.MustExec(`create procedure p_var()
+begin
+	declare v varchar(10) default 'x';
+	insert into t values (4, v);
+	update t set v = concat(v, 'y') where id = 4;
+end`)
+	tk.MustExec("call p_var()")
+	tk.MustQuery("select v from t where id = 4").Check(testkit.Rows("xy"))
+	tk.MustQuery("call p_sel(3)").Check(testkit.Rows("xy"))
+}
+
+func TestProcedureCursorAndHandler(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, v int)")
+	tk.MustExec("insert into t values (1, 10), (2, 20), (3, 30)")
+
+	tk.MustExec(`create procedure p_cur(out s int)
+begin
+	declare done int default 0;
+	declare x int;
+	declare c cursor for select v from t order by id;
+	declare continue handler for not found set done = 1;
+	set s = 0;
+	open c;
+	read_loop: repeat
+		fetch c into x;
+		if done = 1 then
+			leave read_loop;
+		end if;
+		set s = s + x;
+	until false end repeat;
+	close c;
+end`)
+	tk.MustExec("call p_cur(@s)")
+	tk.MustQuery("select @s").Check(testkit.Rows("60"))
+
+	tk.MustExec(`create procedure p_exit(out r varchar(20))
+begin
+	declare exit handler for 1062 set r = 'duplicate';
+	set r = 'ok';
+	insert into t values (1, 0);
+	set r = 'unreachable';
+end`)
+	tk.MustExec("call p_exit(@r)")
+	tk.MustQuery("select @r").Check(testkit.Rows("duplicate"))
+
+	tk.MustExec(`create procedure p_continue(out r int)
+begin
+	declare continue handler for sqlexception set r = r + 1;
+	set r = 0;
+	insert into t values (1, 0);
+	insert into t values (2, 0);
+	insert into t values (4, 40);
+end`)
+	tk.MustExec("call p_continue(@r)")
+	tk.MustQuery("select @r").Check(testkit.Rows("2"))
+	tk.MustQuery("select v from t where id = 4").Check(testkit.Rows("40"))
+
+	// The errors without a handler are returned to the client.
+	tk.MustExec(`create procedure p_err()
+begin
+	declare c cursor for select v from t;
+	declare x int;
+	open c;
+	open c;
+end`)
+	tk.MustGetErrCode("call p_err()", 1325)
+	tk.MustExec(`create procedure p_nodata()
+begin
+	declare c cursor for select v from t where id < 0;
+	declare x int;
+	open c;
+	fetch c into x;
+end`)
+	err := tk.ExecToErr("call p_nodata()")
+	require.True(t, exeerrors.ErrSpFetchNoData.Equal(err))
+}
+
+func TestProcedurePrivileges(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create procedure p1() select 1")
+	tk.MustExec("create user 'u1'@'%'")
+
+	tk1 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk1.Session().Auth(&auth.UserIdentity{Username: "u1", Hostname: "%"}, nil, nil, nil))
+	tk1.MustGetErrCode("call test.p1()", 1044)
+	tk1.MustGetErrCode("create procedure test.p2() select 1", 1044)
+	tk1.MustGetErrCode("drop procedure test.p1", 1044)
+
+	tk.MustExec("grant execute, create routine, alter routine on test.* to 'u1'@'%'")
+	tk1.MustQuery("call test.p1()").Check(testkit.Rows("1"))
+	tk1.MustExec("create procedure test.p2() select 2")
+	tk1.MustExec("drop procedure test.p2")
+}
+
+func TestProcedureSQLSecurity(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create table secret (a int)")
+	tk.MustExec("insert into secret values (1), (2)")
+	tk.MustExec("create procedure p_read() select count(*), current_user() from secret")
+	tk.MustExec("create user 'u1'@'%'")
+	tk.MustExec("grant execute on test.* to 'u1'@'%'")
+
+	// The body runs with the privileges of the definer.
+	tk1 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk1.Session().Auth(&auth.UserIdentity{Username: "u1", Hostname: "%"}, nil, nil, nil))
+	tk1.MustQuery("call test.p_read()").Check(testkit.Rows("2 root@%"))
+	tk1.MustGetErrCode("select count(*) from test.secret", 1142)
+	tk1.MustQuery("select current_user()").Check(testkit.Rows("u1@%"))
+
+	// The session is restored even if the body fails.
+	tk.MustExec("create database db2")
+	tk.MustExec("create procedure db2.p_fail() select (select 1 union select 2)")
+	tk.MustExec("grant execute on db2.* to 'u1'@'%'")
+	tk1.MustExec("use test")
+	tk1.MustGetErrCode("call db2.p_fail()", 1242)
+	tk1.MustQuery("select current_user(), database()").Check(testkit.Rows("u1@% test"))
+
+	tk.MustExec("update mysql.routines set security_type = 'INVOKER' where routine_name = 'p_read'")
+	tk1.MustGetErrCode("call test.p_read()", 1142)
+
+	tk.MustExec("update mysql.routines set security_type = 'DEFINER', definer = 'nobody@%' where routine_name = 'p_read'")
+	tk1.MustGetErrCode("call test.p_read()", 1449)
+}
+
+func TestProcedureRecursion(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create procedure p_self() call p_self()")
+	tk.MustGetErrCode("call p_self()", 1456)
+
+	tk.MustExec(`create procedure p_count(in n int)
+begin
+	if n > 0 then
+		set @cnt = @cnt + 1;
+		call p_count(n - 1);
+	end if;
+end`)
+	tk.MustExec("set @cnt = 0")
+	tk.MustGetErrCode("call p_count(1)", 1456)
+	tk.MustExec("set max_sp_recursion_depth = 3")
+	tk.MustExec("set @cnt = 0")
+	tk.MustExec("call p_count(3)")
+	tk.MustQuery("select @cnt").Check(testkit.Rows("3"))
+	tk.MustGetErrCode("call p_count(4)", 1456)
+	tk.MustExec("set @cnt = 0")
+	tk.MustExec("call p_count(2)")
+	tk.MustQuery("select @cnt").Check(testkit.Rows("2"))
+}
+
+func TestCallStatementContext(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key auto_increment, v int)")
+	tk.MustExec(`create procedure p_ins()
+begin
+	insert into t (v) values (1), (2);
+	insert into t (v) values (3);
+	select 1 + 'x';
+end`)
+
+	tk.MustQuery("call p_ins()").Check(testkit.Rows("1"))
+	// The CALL is the current statement, with the warnings of the last statement.
+	sc := tk.Session().GetSessionVars().StmtCtx
+	require.Equal(t, "call p_ins()", sc.OriginalSQL)
+	require.Equal(t, "Call", sc.StmtType)
+	require.Len(t, sc.GetWarnings(), 1)
+	tk.MustQuery("select last_insert_id()").Check(testkit.Rows("3"))
+	tk.MustQuery("select exec_count from information_schema.statements_summary where digest_text = 'call `p_ins` ( )'").
+		Check(testkit.Rows("1"))
+
+	tk.MustGetErrCode("prepare s from 'call p_ins()'", 1295)
+}
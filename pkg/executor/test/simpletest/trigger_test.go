@@ -0,0 +1,204 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simpletest
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateDropTrigger(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int)")
+	tk.MustExec("create table log (v int)")
+	tk.MustExec("create trigger trg before insert on t for each row set new.b = new.a + 1")
+	tk.MustGetErrCode("create trigger trg after delete on log for each row insert into t values (1, 1)", 1359)
+	tk.MustExec("create trigger if not exists trg after delete on log for each row insert into t values (1, 1)")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1359 Trigger already exists"))
+	tk.MustGetErrCode("create trigger trg2 before insert on nodb.t for each row set new.b = 1", 1146)
+	tk.MustGetErrCode("create trigger trg2 before insert on t1 for each row set new.b = 1", 1146)
+	tk.MustGetErrCode("create trigger mysql.trg2 before insert on t for each row set new.b = 1", 1435)
+
+	tk.MustExec("create view v as select * from t")
+	tk.MustGetErrCode("create trigger trg2 before insert on v for each row set new.b = 1", 1361)
+	tk.MustExec("create temporary table tmp (a int)")
+	tk.MustGetErrCode("create trigger trg2 before insert on tmp for each row set new.a = 1", 1361)
+
+	// Errors in the body are reported when the trigger is created.
+	tk.MustGetErrCode("create trigger trg2 before insert on t for each row set new.c = 1", 1054)
+	tk.MustGetErrCode("create trigger trg2 before insert on t for each row insert into log values (old.a)", 1363)
+	tk.MustGetErrCode("create trigger trg2 before delete on t for each row set @x = new.a", 1363)
+	tk.MustGetErrCode("create trigger trg2 before update on t for each row set old.a = 1", 1362)
+	tk.MustGetErrCode("create trigger trg2 after update on t for each row set new.a = 1", 1362)
+	tk.MustGetErrCode("create trigger trg2 after update on t for each row select 1", 1415)
+	tk.MustGetErrCode("create trigger trg2 after update on t for each row begin commit; end", 1422)
+	tk.MustGetErrCode("create trigger trg2 after update on t for each row begin if old.c > 0 then set @x = 1; end if; end", 1054)
+
+	tk.MustExec("drop trigger trg")
+	tk.MustGetErrCode("drop trigger trg", 1360)
+	tk.MustExec("drop trigger if exists trg")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1360 Trigger does not exist"))
+	tk.MustExec("insert into t values (1, 1)")
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 1"))
+}
+
+func TestRowTriggers(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, v int, w int)")
+	tk.MustExec("create table log (event varchar(10), old_v int, new_v int)")
+	tk.MustExec("create table cnt (n int)")
+	tk.MustExec("insert into cnt values (0)")
+	tk.MustExec("create trigger t_bi before insert on t for each row begin " +
+		"if new.v is null then set new.v = 0; end if; set new.w = new.v * 10; update cnt set n = n + 1; end")
+	tk.MustExec("create trigger t_ai after insert on t for each row insert into log values ('insert', null, new.v)")
+	tk.MustExec("create trigger t_bu before update on t for each row set new.w = old.w + new.v")
+	tk.MustExec("create trigger t_au after update on t for each row insert into log values ('update', old.v, new.v)")
+	tk.MustExec("create trigger t_ad after delete on t for each row insert into log values ('delete', old.v, null)")
+
+	tk.MustExec("insert into t (id, v) values (1, 1), (2, null), (3, 3)")
+	checkT := func(rows ...string) {
+		tk.MustQuery("select * from t order by id").Check(testkit.Rows(rows...))
+	}
+	checkT("1 1 10", "2 0 0", "3 3 30")
+	// The rows written by the triggers are not counted.
+	tk.MustExec("update t set v = v + 1 where id < 3")
+	tk.MustQuery("select row_count()").Check(testkit.Rows("2"))
+	checkT("1 2 12", "2 1 1", "3 3 30")
+	tk.MustExec("delete from t where id = 3")
+	checkT("1 2 12", "2 1 1")
+	tk.MustQuery("select n from cnt").Check(testkit.Rows("3"))
+	tk.MustQuery("select * from log").Sort().Check(testkit.Rows(
+		"delete 3 <nil>",
+		"insert <nil> 0",
+		"insert <nil> 1",
+		"insert <nil> 3",
+		"update 0 1",
+		"update 1 2",
+	))
+
+	// A trigger can not modify the tables which are modified by the statement firing it.
+	tk.MustExec("create table t2 (id int)")
+	tk.MustExec("create trigger t2_ai after insert on t2 for each row delete from t where id = new.id")
+	tk.MustExec("insert into t2 values (1)")
+	checkT("2 1 1")
+	tk.MustExec("create trigger t_bd before delete on t for each row insert into t2 values (old.id)")
+	tk.MustGetErrCode("delete from t", 1442)
+	tk.MustGetErrCode("insert into t2 values (2)", 1442)
+	checkT("2 1 1")
+}
+
+func TestTriggersOfReplaceAndInsertOnDup(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, v int)")
+	tk.MustExec("create table log (event varchar(10), old_v int, new_v int)")
+	tk.MustExec("create trigger t_ai after insert on t for each row insert into log values ('insert', null, new.v)")
+	tk.MustExec("create trigger t_ad after delete on t for each row insert into log values ('delete', old.v, null)")
+	tk.MustExec("create trigger t_bu before update on t for each row set new.v = new.v * 10")
+	tk.MustExec("create trigger t_au after update on t for each row insert into log values ('update', old.v, new.v)")
+	tk.MustExec("insert into t values (1, 1), (2, 2)")
+	tk.MustExec("delete from log")
+
+	// REPLACE deletes the duplicated row and inserts the new one.
+	tk.MustExec("replace into t values (1, 5), (3, 3)")
+	tk.MustQuery("select * from log").Sort().Check(testkit.Rows(
+		"delete 1 <nil>",
+		"insert <nil> 3",
+		"insert <nil> 5",
+	))
+	tk.MustExec("delete from log")
+	// INSERT ... ON DUPLICATE KEY UPDATE updates the duplicated row.
+	tk.MustExec("insert into t values (2, 4), (4, 4) on duplicate key update v = values(v)")
+	tk.MustQuery("select * from log").Sort().Check(testkit.Rows(
+		"insert <nil> 4",
+		"update 2 40",
+	))
+	tk.MustQuery("select * from t order by id").Check(testkit.Rows("1 5", "2 40", "3 3", "4 4"))
+}
+
+func TestAfterTriggersFiredPerRow(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key)")
+	tk.MustExec("create table log (id int, cnt int)")
+	tk.MustExec("create trigger t_ai after insert on t for each row insert into log values (new.id, (select count(*) from t))")
+	tk.MustExec("create trigger t_ad after delete on t for each row insert into log values (old.id, (select count(*) from t))")
+
+	// The AFTER triggers of a row see the rows written before it, but not the rows after it.
+	tk.MustExec("insert into t values (1), (2), (3)")
+	tk.MustQuery("select * from log order by id").Check(testkit.Rows("1 1", "2 2", "3 3"))
+	tk.MustExec("delete from log")
+	tk.MustExec("delete from t order by id")
+	tk.MustQuery("select * from log order by id").Check(testkit.Rows("1 2", "2 1", "3 0"))
+}
+
+func TestTriggerDefiner(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int)")
+	tk.MustExec("create table log (id int, who varchar(64))")
+	tk.MustExec("create trigger t_ai after insert on t for each row insert into log values (new.id, current_user())")
+	tk.MustExec("create user 'u1'@'%'")
+	tk.MustExec("grant insert on test.t to 'u1'@'%'")
+
+	// The body runs with the privileges of the definer.
+	tk1 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk1.Session().Auth(&auth.UserIdentity{Username: "u1", Hostname: "%"}, nil, nil, nil))
+	tk1.MustExec("insert into test.t values (1)")
+	tk1.MustGetErrCode("insert into test.log values (2, 'u1')", 1142)
+	tk1.MustQuery("select current_user()").Check(testkit.Rows("u1@%"))
+	tk.MustQuery("select * from log").Check(testkit.Rows("1 root@%"))
+}
+
+func TestTriggerInTransaction(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key)")
+	tk.MustExec("create table log (id int primary key)")
+	tk.MustExec("create trigger t_ai after insert on t for each row insert into log values (new.id)")
+
+	tk.MustExec("begin")
+	tk.MustExec("insert into t values (1)")
+	tk.MustQuery("select * from log").Check(testkit.Rows("1"))
+	tk.MustExec("insert into log values (3)")
+	// The failed statement is rolled back with the changes of its triggers.
+	tk.MustGetErrCode("insert into t values (2), (3)", 1062)
+	tk.MustQuery("select * from t").Check(testkit.Rows("1"))
+	tk.MustQuery("select * from log").Check(testkit.Rows("1", "3"))
+	tk.MustExec("rollback")
+	tk.MustQuery("select * from t").Check(testkit.Rows())
+	tk.MustQuery("select * from log").Check(testkit.Rows())
+
+	tk.MustExec("create trigger t_bd before delete on t for each row insert into log values (old.id + 100)")
+	tk.MustExec("insert into t values (1), (2)")
+	tk.MustExec("begin")
+	tk.MustExec("delete from t")
+	tk.MustQuery("select * from log").Check(testkit.Rows("1", "2", "101", "102"))
+	tk.MustExec("commit")
+	tk.MustQuery("select * from t").Check(testkit.Rows())
+	tk.MustQuery("select * from log").Check(testkit.Rows("1", "2", "101", "102"))
+}
@@ -0,0 +1,71 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writetest
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerge(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, v int, c int, g int as (v * 2))")
+	tk.MustExec("create table s (id int, v int, op varchar(10))")
+	tk.MustExec("insert into t (id, v, c) values (1, 10, 0), (2, 20, 0), (3, 30, 0)")
+	tk.MustExec("insert into s values (1, 11, 'upsert'), (2, null, 'delete'), (4, 40, 'upsert'), (5, 50, 'skip')")
+
+	tk.MustExec(`merge into t using s on t.id = s.id
+		when matched and s.op = 'delete' then delete
+		when matched then update set v = s.v, c = c + 1
+		when not matched and s.op <> 'skip' then insert (id, v, c) values (s.id, s.v, default)`)
+	tk.MustQuery("select row_count()").Check(testkit.Rows("3"))
+	tk.MustQuery("select * from t order by id").Check(testkit.Rows(
+		"1 11 1 22",
+		"3 30 0 60",
+		"4 40 <nil> 80",
+	))
+
+	// The source can be a derived table and the target can be aliased.
+	tk.MustExec(`merge into t as dst using (select 3 as id, 33 as v union all select 6, 60) as src on dst.id = src.id
+		when matched then update set dst.v = src.v
+		when not matched then insert values (src.id, src.v, 0, default)`)
+	tk.MustQuery("select * from t order by id").Check(testkit.Rows(
+		"1 11 1 22",
+		"3 33 0 66",
+		"4 40 <nil> 80",
+		"6 60 0 120",
+	))
+
+	// A target row can't be updated or deleted by more than one source row.
+	tk.MustExec("insert into s values (1, 12, 'upsert')")
+	tk.MustGetErrCode("merge into t using s on t.id = s.id when matched then update set v = s.v", errno.ErrMergeCardinalityViolation)
+	tk.MustQuery("select v from t where id = 1").Check(testkit.Rows("11"))
+	// It's fine if the duplicated source rows don't act on the target row.
+	tk.MustExec("merge into t using s on t.id = s.id when matched and s.v = 12 then update set v = s.v")
+	tk.MustQuery("select v from t where id = 1").Check(testkit.Rows("12"))
+
+	tk.MustGetErrCode("merge into t using s on t.id = s.id when not matched then insert (id, g) values (s.id, 1)", errno.ErrBadGeneratedColumn)
+	tk.MustGetErrCode("merge into t using s on t.id = s.id when not matched then insert (id) values (s.id, 1)", errno.ErrWrongValueCountOnRow)
+	rows := tk.MustQuery("explain format = 'brief' merge into t using s on t.id = s.id when matched then delete").Rows()
+	require.Equal(t, "Merge", rows[0][0])
+
+	tk.MustExec("create view vt as select * from t")
+	tk.MustGetErrCode("merge into vt using s on vt.id = s.id when matched then delete", errno.ErrNonUpdatableTable)
+}
@@ -0,0 +1,477 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/terror"
+	"github.com/pingcap/tidb/pkg/planner"
+	plannercore "github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/planner/core/resolve"
+	"github.com/pingcap/tidb/pkg/table"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/collate"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
+)
+
+const (
+	triggerRowNew = "new"
+	triggerRowOld = "old"
+)
+
+func (e *DDLExec) executeCreateTrigger(s *ast.CreateTriggerStmt) error {
+	// The table is looked up in the session, so a local temporary table which
+	// hides a normal table is found.
+	tbl, err := e.is.TableByName(context.Background(), s.Table.Schema, s.Table.Name)
+	if err != nil {
+		return err
+	}
+	tblInfo := tbl.Meta()
+	if tblInfo.IsView() || tblInfo.IsSequence() || tblInfo.TempTableType != model.TempTableNone {
+		return dbterror.ErrTrgOnViewOrTempTable.GenWithStackByArgs(s.Table.Name.O)
+	}
+	if err := checkTriggerBody(s, tblInfo); err != nil {
+		return err
+	}
+	return e.ddlExecutor.CreateTrigger(e.Ctx(), s)
+}
+
+func (e *DDLExec) executeDropTrigger(s *ast.DropTriggerStmt) error {
+	return e.ddlExecutor.DropTrigger(e.Ctx(), s)
+}
+
+// triggerChecker holds what procedureChecker needs to know about a trigger.
+type triggerChecker struct {
+	timing ast.TriggerTiming
+	event  ast.TriggerEvent
+	cols   map[string]struct{}
+}
+
+func checkTriggerBody(s *ast.CreateTriggerStmt, tblInfo *model.TableInfo) error {
+	cols := make(map[string]struct{}, len(tblInfo.Columns))
+	for _, col := range tblInfo.Cols() {
+		cols[col.Name.L] = struct{}{}
+	}
+	c := &procedureChecker{trigger: &triggerChecker{timing: s.Timing, event: s.Event, cols: cols}}
+	return c.checkStmt(s.Body)
+}
+
+// checkRow checks a reference to a column of the NEW or the OLD row.
+func (c *triggerChecker) checkRow(row, col string, isAssign bool) error {
+	switch {
+	case row == triggerRowOld && c.event == ast.TriggerEventInsert:
+		return exeerrors.ErrTrgNoSuchRowInTrg.GenWithStackByArgs("OLD", c.event.String())
+	case row == triggerRowNew && c.event == ast.TriggerEventDelete:
+		return exeerrors.ErrTrgNoSuchRowInTrg.GenWithStackByArgs("NEW", c.event.String())
+	}
+	if _, ok := c.cols[col]; !ok {
+		return plannererrors.ErrUnknownColumn.GenWithStackByArgs(col, strings.ToUpper(row))
+	}
+	if isAssign {
+		if row == triggerRowOld {
+			return exeerrors.ErrTrgCantChangeRow.GenWithStackByArgs("OLD", "")
+		}
+		if c.timing == ast.TriggerTimingAfter {
+			return exeerrors.ErrTrgCantChangeRow.GenWithStackByArgs("NEW", "after ")
+		}
+	}
+	return nil
+}
+
+// checkTriggerStmt checks a statement of the trigger body which is not a
+// compound statement. Only the statements which neither return a result set
+// nor commit the transaction can be used.
+func (c *procedureChecker) checkTriggerStmt(stmt ast.StmtNode) error {
+	switch x := stmt.(type) {
+	case *ast.InsertStmt, *ast.UpdateStmt, *ast.DeleteStmt, *ast.DoStmt:
+	case *ast.SetStmt:
+		for _, v := range x.Variables {
+			name := strings.ToLower(v.Name)
+			if !v.IsSystem || v.IsGlobal || c.hasVar(name) {
+				continue
+			}
+			if row, col, ok := strings.Cut(name, "."); ok && (row == triggerRowNew || row == triggerRowOld) {
+				if err := c.trigger.checkRow(row, col, true); err != nil {
+					return err
+				}
+			}
+		}
+	case *ast.SelectStmt, *ast.SetOprStmt, *ast.ShowStmt, *ast.ExplainStmt:
+		return exeerrors.ErrSpNoRetset.GenWithStackByArgs("trigger")
+	case ast.DDLNode, *ast.BeginStmt, *ast.CommitStmt, *ast.RollbackStmt:
+		return exeerrors.ErrCommitNotAllowedInSfOrTrg.GenWithStackByArgs()
+	default:
+		return plannererrors.ErrNotSupportedYet.GenWithStackByArgs(ast.GetStmtLabel(stmt) + " in trigger")
+	}
+	return c.checkRowRefs(stmt)
+}
+
+// checkRowRefs checks the references to the NEW and OLD rows in node. It does
+// nothing for the body of a procedure.
+func (c *procedureChecker) checkRowRefs(node ast.Node) error {
+	if c.trigger == nil || node == nil {
+		return nil
+	}
+	v := &triggerRowRefChecker{c: c.trigger}
+	node.Accept(v)
+	return v.err
+}
+
+type triggerRowRefChecker struct {
+	c   *triggerChecker
+	err error
+}
+
+// Enter implements ast.Visitor interface.
+func (v *triggerRowRefChecker) Enter(n ast.Node) (ast.Node, bool) {
+	if v.err != nil {
+		return n, true
+	}
+	col, ok := n.(*ast.ColumnNameExpr)
+	if !ok || col.Name.Schema.L != "" {
+		return n, false
+	}
+	if row := col.Name.Table.L; row == triggerRowNew || row == triggerRowOld {
+		v.err = v.c.checkRow(row, col.Name.Name.L, false)
+	}
+	return n, true
+}
+
+// Leave implements ast.Visitor interface.
+func (*triggerRowRefChecker) Leave(n ast.Node) (ast.Node, bool) {
+	return n, true
+}
+
+// withRowTrigger indicates the executor fires row triggers.
+type withRowTrigger interface {
+	getTriggerExecs() []*triggerExec
+}
+
+// triggerExec fires the row triggers of a table for an event. The statements
+// of the trigger bodies are executed inside the statement which fires them,
+// so they share its transaction and are rolled back together with it.
+//
+// The BEFORE triggers are fired for each row before it is written, and can
+// change the NEW row. The AFTER triggers are fired for each row after it is
+// written, but before the foreign key cascades of the statement, which are
+// done when the statement finishes. REPLACE fires the DELETE triggers for the
+// rows it deletes, and INSERT ... ON DUPLICATE KEY UPDATE fires the UPDATE
+// triggers for the rows it updates. The LOAD DATA and IMPORT INTO statements
+// and the foreign key cascades don't fire triggers. The trigger bodies are
+// executed with the privileges of the definer of the trigger.
+type triggerExec struct {
+	b      *executorBuilder
+	tbl    table.Table
+	schema ast.CIStr
+
+	before []*triggerBody
+	after  []*triggerBody
+	// usedTables holds the tables modified by the statement which fires the
+	// triggers, and by the statements whose triggers are running. They can
+	// not be modified by the trigger bodies.
+	usedTables map[int64]struct{}
+}
+
+type triggerBody struct {
+	info *model.TriggerInfo
+	stmt ast.StmtNode
+}
+
+// buildTriggerExec returns nil if tbl has no trigger for event.
+func (b *executorBuilder) buildTriggerExec(tbl table.Table, event ast.TriggerEvent, usedTables map[int64]struct{}) (*triggerExec, error) {
+	tblInfo := tbl.Meta()
+	// Like MySQL, the foreign key cascades don't fire triggers.
+	if len(tblInfo.Triggers) == 0 || b.ctx.GetSessionVars().StmtCtx.InHandleForeignKeyTrigger {
+		return nil, nil
+	}
+	before := tblInfo.TriggersOf(ast.TriggerTimingBefore, event)
+	after := tblInfo.TriggersOf(ast.TriggerTimingAfter, event)
+	if len(before) == 0 && len(after) == 0 {
+		return nil, nil
+	}
+	dbInfo, ok := infoschema.SchemaByTable(b.is, tblInfo)
+	if !ok {
+		return nil, errors.Errorf("can not find the schema of table %s", tblInfo.Name.O)
+	}
+	e := &triggerExec{b: b, tbl: tbl, schema: dbInfo.Name, usedTables: usedTables}
+	var err error
+	if e.before, err = b.buildTriggerBodies(before); err != nil {
+		return nil, err
+	}
+	if e.after, err = b.buildTriggerBodies(after); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (b *executorBuilder) buildTblID2TriggerExecs(tblID2Table map[int64]table.Table, event ast.TriggerEvent) (map[int64]*triggerExec, error) {
+	usedTables := make(map[int64]struct{}, len(tblID2Table))
+	for id := range tblID2Table {
+		usedTables[id] = struct{}{}
+	}
+	var triggers map[int64]*triggerExec
+	for id, tbl := range tblID2Table {
+		e, err := b.buildTriggerExec(tbl, event, usedTables)
+		if err != nil {
+			return nil, err
+		}
+		if e == nil {
+			continue
+		}
+		if triggers == nil {
+			triggers = make(map[int64]*triggerExec)
+		}
+		triggers[id] = e
+	}
+	return triggers, nil
+}
+
+func (b *executorBuilder) buildTriggerBodies(triggers []*model.TriggerInfo) ([]*triggerBody, error) {
+	bodies := make([]*triggerBody, 0, len(triggers))
+	for _, trigger := range triggers {
+		p := parser.New()
+		p.SetSQLMode(trigger.SQLMode)
+		p.SetParserConfig(b.ctx.GetSessionVars().BuildParserConfig())
+		node, err := p.ParseOneStmt(trigger.Definition, trigger.CharsetClient, trigger.CollationConnection)
+		if err != nil {
+			return nil, err
+		}
+		s, ok := node.(*ast.CreateTriggerStmt)
+		if !ok {
+			return nil, errors.Errorf("invalid definition of trigger %s", trigger.Name.O)
+		}
+		// The body isn't traversed by CreateTriggerStmt.Accept, so its flags
+		// like the aggregate functions are not set by the parser.
+		ast.SetFlag(s.Body)
+		bodies = append(bodies, &triggerBody{info: trigger, stmt: s.Body})
+	}
+	return bodies, nil
+}
+
+// fireBefore fires the BEFORE triggers for a row. The values assigned to the
+// NEW row are written back to newRow, and the returned flags tell which
+// columns are changed.
+func (e *triggerExec) fireBefore(ctx context.Context, oldRow, newRow []types.Datum) ([]bool, error) {
+	if len(e.before) == 0 {
+		return nil, nil
+	}
+	scope := e.newRowScope(oldRow, newRow)
+	for _, body := range e.before {
+		if err := e.fire(ctx, body, scope); err != nil {
+			return nil, err
+		}
+	}
+	if newRow == nil {
+		return nil, nil
+	}
+	sctx := e.b.ctx
+	tc := sctx.GetSessionVars().StmtCtx.TypeCtx()
+	var changed []bool
+	for i, col := range e.tbl.Cols() {
+		v := scope.vars[triggerRowNew+"."+col.Name.L]
+		cmp, err := v.value.Compare(tc, &newRow[i], collate.GetBinaryCollator())
+		if err != nil {
+			return nil, err
+		}
+		if cmp == 0 {
+			continue
+		}
+		if newRow[i], err = table.CastValue(sctx, v.value, col.ToInfo(), false, false); err != nil {
+			return nil, err
+		}
+		if changed == nil {
+			changed = make([]bool, len(newRow))
+		}
+		changed[i] = true
+	}
+	return changed, nil
+}
+
+// fireAfter fires the AFTER triggers for a written row.
+func (e *triggerExec) fireAfter(ctx context.Context, oldRow, newRow []types.Datum) error {
+	if len(e.after) == 0 {
+		return nil
+	}
+	// Flush the written row to the txn mem-buffer, so it can be read by the
+	// statements of the trigger bodies.
+	e.b.ctx.StmtCommit(ctx)
+	scope := e.newRowScope(oldRow, newRow)
+	for _, body := range e.after {
+		if err := e.fire(ctx, body, scope); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newRowScope returns the scope holding the columns of the NEW and OLD rows,
+// which are named like "new.col" so they are not hidden by local variables.
+func (e *triggerExec) newRowScope(oldRow, newRow []types.Datum) *procedureScope {
+	scope := newProcedureScope()
+	for i, col := range e.tbl.Cols() {
+		if oldRow != nil {
+			scope.vars[triggerRowOld+"."+col.Name.L] = &procedureVar{tp: &col.FieldType, value: oldRow[i]}
+		}
+		if newRow != nil {
+			scope.vars[triggerRowNew+"."+col.Name.L] = &procedureVar{tp: &col.FieldType, value: newRow[i]}
+		}
+	}
+	return scope
+}
+
+func (e *triggerExec) fire(ctx context.Context, body *triggerBody, scope *procedureScope) error {
+	if definer := body.info.Definer; definer != nil {
+		restoreUser, err := switchToDefiner(ctx, e.b.ctx, definer.Username+"@"+definer.Hostname)
+		if err != nil {
+			return err
+		}
+		defer restoreUser()
+	}
+	sessVars := e.b.ctx.GetSessionVars()
+	// Like a procedure, the body runs with the database of the table and the
+	// sql_mode which the trigger was created with.
+	originDB, originMode, originInTrigger := sessVars.CurrentDB, sessVars.SQLMode, sessVars.StmtCtx.InHandleTrigger
+	sessVars.CurrentDB, sessVars.SQLMode, sessVars.StmtCtx.InHandleTrigger = e.schema.O, body.info.SQLMode, true
+	defer func() {
+		sessVars.CurrentDB, sessVars.SQLMode, sessVars.StmtCtx.InHandleTrigger = originDB, originMode, originInTrigger
+	}()
+	pe := &procedureExec{sctx: e.b.ctx, run: e.runStmt, scopes: []*procedureScope{scope}}
+	_, err := pe.execStmts(ctx, []ast.StmtNode{body.stmt})
+	return err
+}
+
+// runStmt executes a statement of a trigger body, the changes are flushed to
+// the transaction memory buffer so they can be read by the statements after
+// it, like what is done for the foreign key cascades.
+func (e *triggerExec) runStmt(ctx context.Context, stmt ast.StmtNode) (_ *procedureResult, err error) {
+	sctx := e.b.ctx
+	nodeW := resolve.NewNodeW(stmt)
+	if err := plannercore.Preprocess(ctx, sctx, nodeW); err != nil {
+		return nil, err
+	}
+	p, err := planner.OptimizeForTrigger(ctx, sctx.GetPlanCtx(), nodeW, e.b.is)
+	if err != nil {
+		return nil, err
+	}
+	stmtExec := e.b.build(p)
+	if err := e.b.err; err != nil {
+		// The error may be handled by the trigger body, reset it so the
+		// builder can still be used.
+		e.b.err = nil
+		return nil, err
+	}
+	for _, id := range modifiedTableIDs(stmtExec) {
+		if _, ok := e.usedTables[id]; ok {
+			name := e.tbl.Meta().Name.O
+			if tbl, ok := e.b.is.TableByID(ctx, id); ok {
+				name = tbl.Meta().Name.O
+			}
+			return nil, exeerrors.ErrCantUpdateUsedTableInSfOrTrg.GenWithStackByArgs(name)
+		}
+	}
+	if w, ok := stmtExec.(withRowTrigger); ok {
+		for _, nested := range w.getTriggerExecs() {
+			for id := range e.usedTables {
+				nested.usedTables[id] = struct{}{}
+			}
+		}
+	}
+
+	if err := exec.Open(ctx, stmtExec); err != nil {
+		terror.Log(exec.Close(stmtExec))
+		return nil, err
+	}
+	var res *procedureResult
+	if stmtExec.Schema().Len() == 0 {
+		err = exec.Next(ctx, stmtExec, exec.NewFirstChunk(stmtExec))
+	} else {
+		res, err = drainTriggerStmt(ctx, stmtExec)
+	}
+	if closeErr := exec.Close(stmtExec); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	sctx.StmtCommit(ctx)
+	return res, (&ExecStmt{Ctx: sctx}).handleForeignKeyTrigger(ctx, stmtExec, 1)
+}
+
+func drainTriggerStmt(ctx context.Context, e exec.Executor) (*procedureResult, error) {
+	fieldTypes := exec.RetTypes(e)
+	res := &procedureResult{}
+	for {
+		chk := exec.NewFirstChunk(e)
+		if err := exec.Next(ctx, e, chk); err != nil {
+			return nil, err
+		}
+		if chk.NumRows() == 0 {
+			return res, nil
+		}
+		for i := range chk.NumRows() {
+			res.rows = append(res.rows, chk.GetRow(i).GetDatumRow(fieldTypes))
+		}
+	}
+}
+
+// modifiedTableIDs returns the IDs of the tables written by a DML executor.
+func modifiedTableIDs(e exec.Executor) []int64 {
+	switch x := e.(type) {
+	case *InsertExec:
+		return []int64{x.Table.Meta().ID}
+	case *ReplaceExec:
+		return []int64{x.Table.Meta().ID}
+	case *UpdateExec:
+		ids := make([]int64, 0, len(x.tblColPosInfos))
+		for _, content := range x.tblColPosInfos {
+			if slices.ContainsFunc(x.assignFlag[content.Start:content.End], func(flag int) bool { return flag >= 0 }) {
+				ids = append(ids, content.TblID)
+			}
+		}
+		return ids
+	case *MergeExec:
+		return []int64{x.tbl.Meta().ID}
+	case *DeleteExec:
+		ids := make([]int64, 0, len(x.tblID2Table))
+		for id := range x.tblID2Table {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	return nil
+}
+
+func sortedTriggerExecs(tblID2Triggers map[int64]*triggerExec) []*triggerExec {
+	triggers := make([]*triggerExec, 0, len(tblID2Triggers))
+	for _, trigger := range tblID2Triggers {
+		triggers = append(triggers, trigger)
+	}
+	slices.SortFunc(triggers, func(a, b *triggerExec) int {
+		return cmp.Compare(a.tbl.Meta().ID, b.tbl.Meta().ID)
+	})
+	return triggers
+}
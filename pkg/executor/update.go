@@ -60,6 +60,7 @@ type UpdateExec struct {
 	// the columns ordinals is present in ordinal range format, @see plannercore.TblColPosInfos
 	tblColPosInfos            plannercore.TblColPosInfoSlice
 	assignFlag                []int
+	colsInfo                  []*table.Column
 	evalBuffer                chunk.MutRow
 	allAssignmentsAreConstant bool
 	virtualAssignmentsOffset  int
@@ -76,6 +77,10 @@ type UpdateExec struct {
 	fkChecks map[int64][]*FKCheckExec
 	// fkCascades contains the foreign key cascade. the map is tableID -> []*FKCascadeExec
 	fkCascades map[int64][]*FKCascadeExec
+	// triggers contains the UPDATE triggers. the map is tableID -> *triggerExec
+	triggers map[int64]*triggerExec
+	// mlogs contains the log writers of the materialized views. the map is tableID -> *mviewLogWriter
+	mlogs map[int64]*mviewLogWriter
 
 	IgnoreError bool
 }
@@ -243,7 +248,6 @@ func (e *UpdateExec) mergeGenerated(row, newData []types.Datum, i int, beforeEva
 
 func (e *UpdateExec) exec(
 	ctx context.Context,
-	_ *expression.Schema,
 	rowIdx int, row, newData []types.Datum,
 	dupKeyCheck table.DupKeyCheckMode,
 ) error {
@@ -292,6 +296,24 @@ func (e *UpdateExec) exec(
 			return errors.Trace(err)
 		}
 
+		trigger := e.triggers[content.TblID]
+		if trigger != nil {
+			numCols := len(tbl.Cols())
+			changed, err := trigger.fireBefore(ctx, oldData[:numCols], newTableData[:numCols])
+			if err != nil {
+				return err
+			}
+			for j, ok := range changed {
+				if !ok {
+					continue
+				}
+				flags[j] = true
+				if chunk.Row(e.evalBuffer).Chunk() != nil {
+					e.evalBuffer.SetDatum(content.Start+j, newTableData[j])
+				}
+			}
+		}
+
 		// Update row
 		changed, ignored, err := updateRecord(
 			ctx, e.Ctx(),
@@ -312,6 +334,16 @@ func (e *UpdateExec) exec(
 		}
 
 		if err == nil {
+			if mlogs := e.mlogs[content.TblID]; changed && mlogs != nil {
+				if err = mlogs.onUpdate(ctx, oldData, newTableData); err != nil {
+					return err
+				}
+			}
+			if trigger != nil {
+				if err = trigger.fireAfter(ctx, oldData[:len(tbl.Cols())], newTableData[:len(tbl.Cols())]); err != nil {
+					return err
+				}
+			}
 			_, exist := e.updatedRowKeys[content.Start].Get(handle)
 			memDelta := e.updatedRowKeys[content.Start].Set(handle, changed)
 			if !exist {
@@ -364,26 +396,12 @@ func (e *UpdateExec) Next(ctx context.Context, req *chunk.Chunk) error {
 	return nil
 }
 
-func (e *UpdateExec) updateRows(ctx context.Context) (int, error) {
-	fields := exec.RetTypes(e.Children(0))
-	colsInfo := plannercore.GetUpdateColumnsInfo(e.tblID2table, e.tblColPosInfos, len(fields))
-	globalRowIdx := 0
-	chk := exec.TryNewCacheChunk(e.Children(0))
+// initUpdateRows initializes the states used by updateRow, fields are the types of the rows to update.
+func (e *UpdateExec) initUpdateRows(fields []*types.FieldType) {
+	e.colsInfo = plannercore.GetUpdateColumnsInfo(e.tblID2table, e.tblColPosInfos, len(fields))
 	if !e.allAssignmentsAreConstant {
 		e.evalBuffer = chunk.MutRowFromTypes(fields)
 	}
-	composeFunc := e.fastComposeNewRow
-	if !e.allAssignmentsAreConstant {
-		composeFunc = e.composeNewRow
-	}
-	memUsageOfChk := int64(0)
-	totalNumRows := 0
-
-	txn, err := e.Ctx().Txn(true)
-	if err != nil {
-		return 0, err
-	}
-
 	if e.virtualAssignmentsOffset < len(e.OrderedList) {
 		e.assignmentsPerTable = make(map[int][]*expression.Assignment, 0)
 		for _, assign := range e.OrderedList[e.virtualAssignmentsOffset:] {
@@ -397,6 +415,48 @@ func (e *UpdateExec) updateRows(ctx context.Context) (int, error) {
 			e.assignmentsPerTable[tblIdx] = append(e.assignmentsPerTable[tblIdx], assign)
 		}
 	}
+}
+
+// updateRow updates the tables by the rowIdx-th row read from the child.
+func (e *UpdateExec) updateRow(ctx context.Context, rowIdx int, datumRow []types.Datum, dupKeyCheck table.DupKeyCheckMode) error {
+	// precomputes handles
+	if err := e.prepare(datumRow); err != nil {
+		return err
+	}
+	// compose non-generated columns
+	var newRow []types.Datum
+	var err error
+	if e.allAssignmentsAreConstant {
+		newRow, err = e.fastComposeNewRow(rowIdx, datumRow, e.colsInfo)
+	} else {
+		newRow, err = e.composeNewRow(rowIdx, datumRow, e.colsInfo)
+	}
+	if err != nil {
+		return err
+	}
+	// merge non-generated columns
+	if err := e.mergeNonGenerated(datumRow, newRow); err != nil {
+		return err
+	}
+
+	if e.virtualAssignmentsOffset < len(e.OrderedList) {
+		e.evalBuffer.SetDatums(newRow...)
+	}
+	return e.exec(ctx, rowIdx, datumRow, newRow, dupKeyCheck)
+}
+
+func (e *UpdateExec) updateRows(ctx context.Context) (int, error) {
+	fields := exec.RetTypes(e.Children(0))
+	e.initUpdateRows(fields)
+	globalRowIdx := 0
+	chk := exec.TryNewCacheChunk(e.Children(0))
+	memUsageOfChk := int64(0)
+	totalNumRows := 0
+
+	txn, err := e.Ctx().Txn(true)
+	if err != nil {
+		return 0, err
+	}
 
 	dupKeyCheck := optimizeDupKeyCheckForUpdate(txn, e.IgnoreError)
 	for {
@@ -428,27 +488,7 @@ func (e *UpdateExec) updateRows(ctx context.Context) (int, error) {
 		for rowIdx := range chk.NumRows() {
 			chunkRow := chk.GetRow(rowIdx)
 			datumRow := chunkRow.GetDatumRow(fields)
-			// precomputes handles
-			if err := e.prepare(datumRow); err != nil {
-				return 0, err
-			}
-			// compose non-generated columns
-			newRow, err := composeFunc(globalRowIdx, datumRow, colsInfo)
-			if err != nil {
-				return 0, err
-			}
-			// merge non-generated columns
-			if err := e.mergeNonGenerated(datumRow, newRow); err != nil {
-				return 0, err
-			}
-
-			if e.virtualAssignmentsOffset < len(e.OrderedList) {
-				e.evalBuffer.SetDatums(newRow...)
-			}
-
-			if err := e.exec(
-				ctx, e.Children(0).Schema(),
-				globalRowIdx, datumRow, newRow, dupKeyCheck); err != nil {
+			if err := e.updateRow(ctx, globalRowIdx, datumRow, dupKeyCheck); err != nil {
 				return 0, err
 			}
 			globalRowIdx++
@@ -675,6 +715,11 @@ func (e *UpdateExec) HasFKCascades() bool {
 	return len(e.fkCascades) > 0
 }
 
+// getTriggerExecs implements withRowTrigger interface.
+func (e *UpdateExec) getTriggerExecs() []*triggerExec {
+	return sortedTriggerExecs(e.triggers)
+}
+
 // optimizeDupKeyCheckForUpdate trys to optimize the DupKeyCheckMode for an update statement.
 // If the DupKeyCheckMode of the current statement can be optimized, it will return `DupKeyCheckLazy` to avoid the
 // redundant requests to TiKV, otherwise, `DupKeyCheckInPlace` will be returned.
@@ -37,45 +37,74 @@ type WindowExec struct {
 	childResult *chunk.Chunk
 	// executed indicates the child executor is drained or something unexpected happened.
 	executed bool
-	// resultChunks stores the chunks to return
-	resultChunks []*chunk.Chunk
-	// remainingRowsInChunk indicates how many rows the resultChunks[i] is not prepared.
-	remainingRowsInChunk []int
+	// resultChunks stores the chunks to return, and how many rows of each chunk are not prepared.
+	resultChunks []dataInfo
+	// accumulated is the number of rows fetched from the child executor.
+	accumulated uint64
+	// groupRows stores the rows of the current partition when the executor is not in spill mode.
+	groupRows []chunk.Row
+	// groupStart and groupRowCnt locate the rows of the current partition in the child's output
+	// when the executor is in spill mode.
+	groupStart  uint64
+	groupRowCnt uint64
+	// remainingRowsInGroup is the number of rows in the current spilled partition whose results
+	// are not appended yet. In spill mode, the results are appended one chunk at a time, so that
+	// the restored chunks can be returned before the whole partition is evaluated.
+	remainingRowsInGroup uint64
 
 	numWindowFuncs int
 	processor      windowProcessor
+	spill          windowSpillHelper
+}
+
+// Open implements the Executor Open interface.
+func (e *WindowExec) Open(ctx context.Context) error {
+	if err := e.BaseExecutor.Open(ctx); err != nil {
+		return err
+	}
+	e.accumulated, e.groupStart, e.groupRowCnt, e.remainingRowsInGroup = 0, 0, 0, 0
+	e.spill.open(e.Ctx(), e.ID())
+	return nil
 }
 
 // Close implements the Executor Close interface.
 func (e *WindowExec) Close() error {
+	e.resultChunks, e.groupRows = nil, nil
+	e.spill.close()
 	return errors.Trace(e.BaseExecutor.Close())
 }
 
 // Next implements the Executor Next interface.
 func (e *WindowExec) Next(ctx context.Context, chk *chunk.Chunk) error {
 	chk.Reset()
-	for !e.executed && !e.preparedChunkAvailable() {
-		err := e.consumeOneGroup(ctx)
+	for !e.preparedChunkAvailable() {
+		var err error
+		if e.remainingRowsInGroup > 0 {
+			err = e.appendSpilledGroupResult()
+		} else if e.executed {
+			break
+		} else {
+			err = e.consumeOneGroup(ctx)
+		}
 		if err != nil {
 			e.executed = true
 			return err
 		}
 	}
 	if len(e.resultChunks) > 0 {
-		chk.SwapColumns(e.resultChunks[0])
-		e.resultChunks[0] = nil // GC it. TODO: Reuse it.
+		chk.SwapColumns(e.resultChunks[0].chk)
+		e.spill.memTracker.Consume(-e.resultChunks[0].memUsage)
+		e.resultChunks[0] = dataInfo{} // GC it. TODO: Reuse it.
 		e.resultChunks = e.resultChunks[1:]
-		e.remainingRowsInChunk = e.remainingRowsInChunk[1:]
 	}
 	return nil
 }
 
 func (e *WindowExec) preparedChunkAvailable() bool {
-	return len(e.resultChunks) > 0 && e.remainingRowsInChunk[0] == 0
+	return len(e.resultChunks) > 0 && e.resultChunks[0].remaining == 0
 }
 
 func (e *WindowExec) consumeOneGroup(ctx context.Context) error {
-	var groupRows []chunk.Row
 	if e.groupChecker.IsExhausted() {
 		eof, err := e.fetchChild(ctx)
 		if err != nil {
@@ -83,7 +112,7 @@ func (e *WindowExec) consumeOneGroup(ctx context.Context) error {
 		}
 		if eof {
 			e.executed = true
-			return e.consumeGroupRows(groupRows)
+			return e.consumeGroupRows()
 		}
 		_, err = e.groupChecker.SplitIntoGroups(e.childResult)
 		if err != nil {
@@ -91,9 +120,7 @@ func (e *WindowExec) consumeOneGroup(ctx context.Context) error {
 		}
 	}
 	begin, end := e.groupChecker.GetNextGroup()
-	for i := begin; i < end; i++ {
-		groupRows = append(groupRows, e.childResult.GetRow(i))
-	}
+	e.appendGroupRows(begin, end)
 
 	for meetLastGroup := end == e.childResult.NumRows(); meetLastGroup; {
 		meetLastGroup = false
@@ -103,7 +130,7 @@ func (e *WindowExec) consumeOneGroup(ctx context.Context) error {
 		}
 		if eof {
 			e.executed = true
-			return e.consumeGroupRows(groupRows)
+			return e.consumeGroupRows()
 		}
 
 		isFirstGroupSameAsPrev, err := e.groupChecker.SplitIntoGroups(e.childResult)
@@ -113,45 +140,140 @@ func (e *WindowExec) consumeOneGroup(ctx context.Context) error {
 
 		if isFirstGroupSameAsPrev {
 			begin, end = e.groupChecker.GetNextGroup()
-			for i := begin; i < end; i++ {
-				groupRows = append(groupRows, e.childResult.GetRow(i))
-			}
+			e.appendGroupRows(begin, end)
 			meetLastGroup = end == e.childResult.NumRows()
 		}
 	}
-	return e.consumeGroupRows(groupRows)
+	return e.consumeGroupRows()
 }
 
-func (e *WindowExec) consumeGroupRows(groupRows []chunk.Row) (err error) {
-	remainingRowsInGroup := len(groupRows)
+// appendGroupRows appends the rows in [begin, end) of e.childResult to the current partition.
+func (e *WindowExec) appendGroupRows(begin, end int) {
+	if e.spill.inSpillMode() {
+		if e.groupRowCnt == 0 {
+			e.groupStart = e.accumulated - uint64(e.childResult.NumRows()) + uint64(begin)
+		}
+		e.groupRowCnt += uint64(end - begin)
+		return
+	}
+	for i := begin; i < end; i++ {
+		e.groupRows = append(e.groupRows, e.childResult.GetRow(i))
+	}
+}
+
+func (e *WindowExec) consumeGroupRows() (err error) {
+	if e.spill.inSpillMode() {
+		return e.consumeSpilledGroupRows()
+	}
+	groupRows := inMemWindowRows(e.groupRows)
+	e.groupRows = nil
+	remainingRowsInGroup := uint64(len(groupRows))
 	if remainingRowsInGroup == 0 {
 		return nil
 	}
+	err = e.processor.consumeGroupRows(e.Ctx(), groupRows)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	for i := range e.resultChunks {
-		remained := min(e.remainingRowsInChunk[i], remainingRowsInGroup)
-		e.remainingRowsInChunk[i] -= remained
+		remained := min(e.resultChunks[i].remaining, remainingRowsInGroup)
+		e.resultChunks[i].remaining -= remained
 		remainingRowsInGroup -= remained
 
-		// TODO: Combine these three methods.
-		// The old implementation needs the processor has these three methods
-		// but now it does not have to.
-		groupRows, err = e.processor.consumeGroupRows(e.Ctx(), groupRows)
+		err = e.processor.appendResult2Chunk(e.Ctx(), groupRows, e.resultChunks[i].chk, int(remained))
 		if err != nil {
 			return errors.Trace(err)
 		}
-		_, err = e.processor.appendResult2Chunk(e.Ctx(), groupRows, e.resultChunks[i], remained)
+		if remainingRowsInGroup == 0 {
+			e.processor.resetPartialResult()
+			break
+		}
+	}
+	return nil
+}
+
+func (e *WindowExec) spilledGroupRows() spilledWindowRows {
+	return spilledWindowRows{rowsInDisk: e.spill.rowsInDisk, start: e.groupStart, n: e.groupRowCnt}
+}
+
+// consumeSpilledGroupRows consumes the rows of the current partition in spill mode. The results
+// are appended later by appendSpilledGroupResult.
+func (e *WindowExec) consumeSpilledGroupRows() error {
+	if e.groupRowCnt == 0 {
+		return nil
+	}
+	e.remainingRowsInGroup = e.groupRowCnt
+	err := e.processor.consumeGroupRows(e.Ctx(), e.spilledGroupRows())
+	if err == nil {
+		err = e.spill.getErr()
+	}
+	return errors.Trace(err)
+}
+
+// appendSpilledGroupResult appends the results of the current spilled partition to the first
+// unprepared result chunk, which is restored from disk if necessary.
+func (e *WindowExec) appendSpilledGroupResult() error {
+	for i := range e.resultChunks {
+		d := &e.resultChunks[i]
+		if d.remaining == 0 {
+			continue
+		}
+		if d.chk == nil {
+			if err := e.spill.restoreResultChunk(d, e.newResultChunk); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		remained := min(d.remaining, e.remainingRowsInGroup)
+		err := e.processor.appendResult2Chunk(e.Ctx(), e.spilledGroupRows(), d.chk, int(remained))
+		if err == nil {
+			err = e.spill.getErr()
+		}
 		if err != nil {
 			return errors.Trace(err)
 		}
-		if remainingRowsInGroup == 0 {
+		d.remaining -= remained
+		e.remainingRowsInGroup -= remained
+		if e.remainingRowsInGroup == 0 {
 			e.processor.resetPartialResult()
+			e.groupRowCnt = 0
+		}
+		return nil
+	}
+	return nil
+}
+
+// spillToDisk switches the executor to spill mode. The child chunks referred by the unprepared
+// result chunks are spilled, and the current partition is read from disk afterwards.
+func (e *WindowExec) spillToDisk() error {
+	firstRow := e.accumulated
+	for _, d := range e.resultChunks {
+		if d.remaining > 0 {
+			// The current partition starts from the first unprepared row.
+			firstRow = d.accumulated - uint64(d.src.NumRows())
+			e.groupStart = d.accumulated - d.remaining
 			break
 		}
 	}
+	e.spill.startSpill(exec.RetTypes(e.Children(0)), firstRow)
+	for i := range e.resultChunks {
+		if e.resultChunks[i].remaining == 0 {
+			continue
+		}
+		if err := e.spill.spillResultChunk(&e.resultChunks[i]); err != nil {
+			return err
+		}
+	}
+	e.groupRowCnt = uint64(len(e.groupRows))
+	e.groupRows = nil
 	return nil
 }
 
 func (e *WindowExec) fetchChild(ctx context.Context) (eof bool, err error) {
+	if e.spill.needSpill() {
+		if err = e.spillToDisk(); err != nil {
+			return false, err
+		}
+	}
 	childResult := exec.TryNewCacheChunk(e.Children(0))
 	err = exec.Next(ctx, e.Children(0), childResult)
 	if err != nil {
@@ -163,18 +285,35 @@ func (e *WindowExec) fetchChild(ctx context.Context) (eof bool, err error) {
 		return true, nil
 	}
 
-	resultChk := e.AllocPool.Alloc(e.RetFieldTypes(), 0, numRows)
-	err = e.copyChk(childResult, resultChk)
-	if err != nil {
-		return false, err
+	e.accumulated += uint64(numRows)
+	d := dataInfo{src: childResult, remaining: uint64(numRows), accumulated: e.accumulated}
+	if e.spill.inSpillMode() {
+		if d.spilledIdx, err = e.spill.rowsInDisk.add(childResult); err != nil {
+			return false, err
+		}
+		d.src = nil
+	} else {
+		d.chk, err = e.newResultChunk(childResult)
+		if err != nil {
+			return false, err
+		}
+		d.memUsage = childResult.MemoryUsage()
+		e.spill.memTracker.Consume(d.memUsage)
 	}
-	e.resultChunks = append(e.resultChunks, resultChk)
-	e.remainingRowsInChunk = append(e.remainingRowsInChunk, numRows)
+	e.resultChunks = append(e.resultChunks, d)
 
 	e.childResult = childResult
 	return false, nil
 }
 
+func (e *WindowExec) newResultChunk(src *chunk.Chunk) (*chunk.Chunk, error) {
+	resultChk := e.AllocPool.Alloc(e.RetFieldTypes(), 0, src.NumRows())
+	if err := e.copyChk(src, resultChk); err != nil {
+		return nil, err
+	}
+	return resultChk, nil
+}
+
 func (e *WindowExec) copyChk(src, dst *chunk.Chunk) error {
 	columns := e.Schema().Columns[:len(e.Schema().Columns)-e.numWindowFuncs]
 	for i, col := range columns {
@@ -189,10 +328,10 @@ func (e *WindowExec) copyChk(src, dst *chunk.Chunk) error {
 type windowProcessor interface {
 	// consumeGroupRows updates the result for an window function using the input rows
 	// which belong to the same partition.
-	consumeGroupRows(ctx sessionctx.Context, rows []chunk.Row) ([]chunk.Row, error)
+	consumeGroupRows(ctx sessionctx.Context, rows windowRows) error
 	// appendResult2Chunk appends the final results to chunk.
 	// It is called when there are no more rows in current partition.
-	appendResult2Chunk(ctx sessionctx.Context, rows []chunk.Row, chk *chunk.Chunk, remained int) ([]chunk.Row, error)
+	appendResult2Chunk(ctx sessionctx.Context, rows windowRows, chk *chunk.Chunk, remained int) error
 	// resetPartialResult resets the partial result to the original state for a specific window function.
 	resetPartialResult()
 }
@@ -202,30 +341,33 @@ type aggWindowProcessor struct {
 	partialResults []aggfuncs.PartialResult
 }
 
-func (p *aggWindowProcessor) consumeGroupRows(ctx sessionctx.Context, rows []chunk.Row) ([]chunk.Row, error) {
-	for i, windowFunc := range p.windowFuncs {
-		// @todo Add memory trace
-		_, err := windowFunc.UpdatePartialResult(ctx.GetExprCtx().GetEvalCtx(), rows, p.partialResults[i])
-		if err != nil {
-			return nil, err
+func (p *aggWindowProcessor) consumeGroupRows(ctx sessionctx.Context, rows windowRows) error {
+	numRows := rows.numRows()
+	for start := uint64(0); start < numRows; start += windowRowsBatchSize {
+		batch := rows.getRows(start, min(start+windowRowsBatchSize, numRows))
+		for i, windowFunc := range p.windowFuncs {
+			// @todo Add memory trace
+			_, err := windowFunc.UpdatePartialResult(ctx.GetExprCtx().GetEvalCtx(), batch, p.partialResults[i])
+			if err != nil {
+				return err
+			}
 		}
 	}
-	rows = rows[:0]
-	return rows, nil
+	return nil
 }
 
-func (p *aggWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows []chunk.Row, chk *chunk.Chunk, remained int) ([]chunk.Row, error) {
+func (p *aggWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, _ windowRows, chk *chunk.Chunk, remained int) error {
 	for remained > 0 {
 		for i, windowFunc := range p.windowFuncs {
 			// TODO: We can extend the agg func interface to avoid the `for` loop  here.
 			err := windowFunc.AppendFinalResult2Chunk(ctx.GetExprCtx().GetEvalCtx(), p.partialResults[i], chk)
 			if err != nil {
-				return nil, err
+				return err
 			}
 		}
 		remained--
 	}
-	return rows, nil
+	return nil
 }
 
 func (p *aggWindowProcessor) resetPartialResult() {
@@ -288,12 +430,12 @@ func (p *rowFrameWindowProcessor) getEndOffset(numRows uint64) uint64 {
 	return 0
 }
 
-func (*rowFrameWindowProcessor) consumeGroupRows(_ sessionctx.Context, rows []chunk.Row) ([]chunk.Row, error) {
-	return rows, nil
+func (*rowFrameWindowProcessor) consumeGroupRows(sessionctx.Context, windowRows) error {
+	return nil
 }
 
-func (p *rowFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows []chunk.Row, chk *chunk.Chunk, remained int) ([]chunk.Row, error) {
-	numRows := uint64(len(rows))
+func (p *rowFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows windowRows, chk *chunk.Chunk, remained int) error {
+	numRows := rows.numRows()
 	var (
 		err                      error
 		initializedSlidingWindow bool
@@ -321,16 +463,14 @@ func (p *rowFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, row
 			for i, windowFunc := range p.windowFuncs {
 				slidingWindowAggFunc := slidingWindowAggFuncs[i]
 				if slidingWindowAggFunc != nil && initializedSlidingWindow {
-					err = slidingWindowAggFunc.Slide(ctx.GetExprCtx().GetEvalCtx(), func(u uint64) chunk.Row {
-						return rows[u]
-					}, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
+					err = slidingWindowAggFunc.Slide(ctx.GetExprCtx().GetEvalCtx(), rows.getRow, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
 					if err != nil {
-						return nil, err
+						return err
 					}
 				}
 				err = windowFunc.AppendFinalResult2Chunk(ctx.GetExprCtx().GetEvalCtx(), p.partialResults[i], chk)
 				if err != nil {
-					return nil, err
+					return err
 				}
 			}
 			continue
@@ -339,9 +479,7 @@ func (p *rowFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, row
 		for i, windowFunc := range p.windowFuncs {
 			slidingWindowAggFunc := slidingWindowAggFuncs[i]
 			if slidingWindowAggFunc != nil && initializedSlidingWindow {
-				err = slidingWindowAggFunc.Slide(ctx.GetExprCtx().GetEvalCtx(), func(u uint64) chunk.Row {
-					return rows[u]
-				}, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
+				err = slidingWindowAggFunc.Slide(ctx.GetExprCtx().GetEvalCtx(), rows.getRow, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
 			} else {
 				// For MinMaxSlidingWindowAggFuncs, it needs the absolute value of each start of window, to compare
 				// whether elements inside deque are out of current window.
@@ -349,14 +487,14 @@ func (p *rowFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, row
 					// Store start inside MaxMinSlidingWindowAggFunc.windowInfo
 					minMaxSlidingWindowAggFunc.SetWindowStart(start)
 				}
-				_, err = windowFunc.UpdatePartialResult(ctx.GetExprCtx().GetEvalCtx(), rows[start:end], p.partialResults[i])
+				_, err = windowFunc.UpdatePartialResult(ctx.GetExprCtx().GetEvalCtx(), rows.getRows(start, end), p.partialResults[i])
 			}
 			if err != nil {
-				return nil, err
+				return err
 			}
 			err = windowFunc.AppendFinalResult2Chunk(ctx.GetExprCtx().GetEvalCtx(), p.partialResults[i], chk)
 			if err != nil {
-				return nil, err
+				return err
 			}
 			if slidingWindowAggFunc == nil {
 				windowFunc.ResetPartialResult(p.partialResults[i])
@@ -369,7 +507,7 @@ func (p *rowFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, row
 	for i, windowFunc := range p.windowFuncs {
 		windowFunc.ResetPartialResult(p.partialResults[i])
 	}
-	return rows, nil
+	return nil
 }
 
 func (p *rowFrameWindowProcessor) resetPartialResult() {
@@ -389,16 +527,16 @@ type rangeFrameWindowProcessor struct {
 	expectedCmpResult int64
 }
 
-func (p *rangeFrameWindowProcessor) getStartOffset(ctx sessionctx.Context, rows []chunk.Row) (uint64, error) {
+func (p *rangeFrameWindowProcessor) getStartOffset(ctx sessionctx.Context, rows windowRows) (uint64, error) {
 	if p.start.UnBounded {
 		return 0, nil
 	}
-	numRows := uint64(len(rows))
+	numRows := rows.numRows()
 	for ; p.lastStartOffset < numRows; p.lastStartOffset++ {
 		var res int64
 		var err error
 		for i := range p.orderByCols {
-			res, _, err = p.start.CmpFuncs[i](ctx.GetExprCtx().GetEvalCtx(), p.start.CompareCols[i], p.start.CalcFuncs[i], rows[p.lastStartOffset], rows[p.curRowIdx])
+			res, _, err = p.start.CmpFuncs[i](ctx.GetExprCtx().GetEvalCtx(), p.start.CompareCols[i], p.start.CalcFuncs[i], rows.getRow(p.lastStartOffset), rows.getRow(p.curRowIdx))
 			if err != nil {
 				return 0, err
 			}
@@ -415,8 +553,8 @@ func (p *rangeFrameWindowProcessor) getStartOffset(ctx sessionctx.Context, rows
 	return p.lastStartOffset, nil
 }
 
-func (p *rangeFrameWindowProcessor) getEndOffset(ctx sessionctx.Context, rows []chunk.Row) (uint64, error) {
-	numRows := uint64(len(rows))
+func (p *rangeFrameWindowProcessor) getEndOffset(ctx sessionctx.Context, rows windowRows) (uint64, error) {
+	numRows := rows.numRows()
 	if p.end.UnBounded {
 		return numRows, nil
 	}
@@ -424,7 +562,7 @@ func (p *rangeFrameWindowProcessor) getEndOffset(ctx sessionctx.Context, rows []
 		var res int64
 		var err error
 		for i := range p.orderByCols {
-			res, _, err = p.end.CmpFuncs[i](ctx.GetExprCtx().GetEvalCtx(), p.end.CalcFuncs[i], p.end.CompareCols[i], rows[p.curRowIdx], rows[p.lastEndOffset])
+			res, _, err = p.end.CmpFuncs[i](ctx.GetExprCtx().GetEvalCtx(), p.end.CalcFuncs[i], p.end.CompareCols[i], rows.getRow(p.curRowIdx), rows.getRow(p.lastEndOffset))
 			if err != nil {
 				return 0, err
 			}
@@ -441,7 +579,7 @@ func (p *rangeFrameWindowProcessor) getEndOffset(ctx sessionctx.Context, rows []
 	return p.lastEndOffset, nil
 }
 
-func (p *rangeFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows []chunk.Row, chk *chunk.Chunk, remained int) ([]chunk.Row, error) {
+func (p *rangeFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows windowRows, chk *chunk.Chunk, remained int) error {
 	var (
 		err                      error
 		initializedSlidingWindow bool
@@ -461,11 +599,11 @@ func (p *rangeFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, r
 	for ; remained > 0; lastStart, lastEnd = start, end {
 		start, err = p.getStartOffset(ctx, rows)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		end, err = p.getEndOffset(ctx, rows)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		p.curRowIdx++
 		remained--
@@ -475,16 +613,14 @@ func (p *rangeFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, r
 			for i, windowFunc := range p.windowFuncs {
 				slidingWindowAggFunc := slidingWindowAggFuncs[i]
 				if slidingWindowAggFunc != nil && initializedSlidingWindow {
-					err = slidingWindowAggFunc.Slide(ctx.GetExprCtx().GetEvalCtx(), func(u uint64) chunk.Row {
-						return rows[u]
-					}, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
+					err = slidingWindowAggFunc.Slide(ctx.GetExprCtx().GetEvalCtx(), rows.getRow, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
 					if err != nil {
-						return nil, err
+						return err
 					}
 				}
 				err = windowFunc.AppendFinalResult2Chunk(ctx.GetExprCtx().GetEvalCtx(), p.partialResults[i], chk)
 				if err != nil {
-					return nil, err
+					return err
 				}
 			}
 			continue
@@ -493,21 +629,19 @@ func (p *rangeFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, r
 		for i, windowFunc := range p.windowFuncs {
 			slidingWindowAggFunc := slidingWindowAggFuncs[i]
 			if slidingWindowAggFunc != nil && initializedSlidingWindow {
-				err = slidingWindowAggFunc.Slide(ctx.GetExprCtx().GetEvalCtx(), func(u uint64) chunk.Row {
-					return rows[u]
-				}, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
+				err = slidingWindowAggFunc.Slide(ctx.GetExprCtx().GetEvalCtx(), rows.getRow, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
 			} else {
 				if minMaxSlidingWindowAggFunc, ok := windowFunc.(aggfuncs.MaxMinSlidingWindowAggFunc); ok {
 					minMaxSlidingWindowAggFunc.SetWindowStart(start)
 				}
-				_, err = windowFunc.UpdatePartialResult(ctx.GetExprCtx().GetEvalCtx(), rows[start:end], p.partialResults[i])
+				_, err = windowFunc.UpdatePartialResult(ctx.GetExprCtx().GetEvalCtx(), rows.getRows(start, end), p.partialResults[i])
 			}
 			if err != nil {
-				return nil, err
+				return err
 			}
 			err = windowFunc.AppendFinalResult2Chunk(ctx.GetExprCtx().GetEvalCtx(), p.partialResults[i], chk)
 			if err != nil {
-				return nil, err
+				return err
 			}
 			if slidingWindowAggFunc == nil {
 				windowFunc.ResetPartialResult(p.partialResults[i])
@@ -520,11 +654,11 @@ func (p *rangeFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, r
 	for i, windowFunc := range p.windowFuncs {
 		windowFunc.ResetPartialResult(p.partialResults[i])
 	}
-	return rows, nil
+	return nil
 }
 
-func (*rangeFrameWindowProcessor) consumeGroupRows(_ sessionctx.Context, rows []chunk.Row) ([]chunk.Row, error) {
-	return rows, nil
+func (*rangeFrameWindowProcessor) consumeGroupRows(sessionctx.Context, windowRows) error {
+	return nil
 }
 
 func (p *rangeFrameWindowProcessor) resetPartialResult() {
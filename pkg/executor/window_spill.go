@@ -0,0 +1,357 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"slices"
+	"sync/atomic"
+
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/vardef"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/disk"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/memory"
+	"go.uber.org/zap"
+)
+
+const (
+	windowSpillLogInfo = "memory exceeds quota, set window mode to spill-mode"
+
+	// windowRowsCacheSize is the number of restored chunks kept in memory for frame evaluation.
+	// A frame reads rows around its start, its end and the current row, so a few chunks are enough.
+	windowRowsCacheSize = 4
+	// windowRowsBatchSize is the number of rows passed to a window function at a time when a
+	// whole partition is consumed.
+	windowRowsBatchSize = 1024
+)
+
+// windowRows provides the rows of a partition to window processors.
+type windowRows interface {
+	numRows() uint64
+	getRow(i uint64) chunk.Row
+	getRows(start, end uint64) []chunk.Row
+}
+
+// inMemWindowRows is the rows of a partition held in memory.
+type inMemWindowRows []chunk.Row
+
+func (r inMemWindowRows) numRows() uint64 {
+	return uint64(len(r))
+}
+
+func (r inMemWindowRows) getRow(i uint64) chunk.Row {
+	return r[i]
+}
+
+func (r inMemWindowRows) getRows(start, end uint64) []chunk.Row {
+	return r[start:end]
+}
+
+// spilledWindowRows is the rows of a partition spilled to disk. The i-th row of the
+// partition is the (start+i)-th row of the child's output.
+type spilledWindowRows struct {
+	rowsInDisk *windowRowsInDisk
+	start      uint64
+	n          uint64
+}
+
+func (r spilledWindowRows) numRows() uint64 {
+	return r.n
+}
+
+func (r spilledWindowRows) getRow(i uint64) chunk.Row {
+	return r.rowsInDisk.getRow(r.start + i)
+}
+
+func (r spilledWindowRows) getRows(start, end uint64) []chunk.Row {
+	return r.rowsInDisk.getRows(r.start+start, r.start+end)
+}
+
+type restoredWindowChunk struct {
+	idx int
+	chk *chunk.Chunk
+}
+
+// windowRowsInDisk stores the child chunks of a window executor in spill mode, and serves
+// the spilled rows by their positions in the child's output.
+type windowRowsInDisk struct {
+	inDisk     *chunk.DataInDiskByChunks
+	memTracker *memory.Tracker
+
+	// firstRow is the position of the first spilled row in the child's output.
+	firstRow uint64
+	// chunkEnds[i] is the position following the last row of the i-th spilled chunk.
+	chunkEnds []uint64
+	// cache keeps the recently restored chunks, the most recently used one is at the end.
+	cache []restoredWindowChunk
+
+	// err is the first error met when restoring chunks. Window functions read rows through
+	// a getter which can't return errors, so the error is recorded here and the rows of
+	// placeholder are returned instead. Callers must check it after reading rows.
+	err         error
+	placeholder *chunk.Chunk
+}
+
+func newWindowRowsInDisk(fieldTypes []*types.FieldType, firstRow uint64, memTracker *memory.Tracker, diskTracker *disk.Tracker) *windowRowsInDisk {
+	r := &windowRowsInDisk{
+		inDisk:      chunk.NewDataInDiskByChunks(fieldTypes),
+		memTracker:  memTracker,
+		firstRow:    firstRow,
+		placeholder: chunk.NewChunkWithCapacity(fieldTypes, 1),
+	}
+	for i := range fieldTypes {
+		r.placeholder.AppendNull(i)
+	}
+	if diskTracker != nil {
+		r.inDisk.GetDiskTracker().AttachTo(diskTracker)
+	}
+	return r
+}
+
+// add spills chk to disk and returns its index among the spilled chunks.
+func (r *windowRowsInDisk) add(chk *chunk.Chunk) (int, error) {
+	if err := r.inDisk.Add(chk); err != nil {
+		return 0, err
+	}
+	r.chunkEnds = append(r.chunkEnds, r.endRow()+uint64(chk.NumRows()))
+	return len(r.chunkEnds) - 1, nil
+}
+
+// endRow returns the position following the last spilled row.
+func (r *windowRowsInDisk) endRow() uint64 {
+	if len(r.chunkEnds) == 0 {
+		return r.firstRow
+	}
+	return r.chunkEnds[len(r.chunkEnds)-1]
+}
+
+func (r *windowRowsInDisk) locate(pos uint64) (chkIdx, rowIdx int) {
+	chkIdx, _ = slices.BinarySearch(r.chunkEnds, pos+1)
+	begin := r.firstRow
+	if chkIdx > 0 {
+		begin = r.chunkEnds[chkIdx-1]
+	}
+	return chkIdx, int(pos - begin)
+}
+
+func (r *windowRowsInDisk) getCachedChunk(idx int) *chunk.Chunk {
+	if r.err != nil {
+		return nil
+	}
+	for i := len(r.cache) - 1; i >= 0; i-- {
+		if r.cache[i].idx == idx {
+			restored := r.cache[i]
+			copy(r.cache[i:], r.cache[i+1:])
+			r.cache[len(r.cache)-1] = restored
+			return restored.chk
+		}
+	}
+	chk, err := r.inDisk.GetChunk(idx)
+	if err != nil {
+		r.err = err
+		return nil
+	}
+	if len(r.cache) == windowRowsCacheSize {
+		r.memTracker.Consume(-r.cache[0].chk.MemoryUsage())
+		r.cache = append(r.cache[:0], r.cache[1:]...)
+	}
+	r.cache = append(r.cache, restoredWindowChunk{idx: idx, chk: chk})
+	r.memTracker.Consume(chk.MemoryUsage())
+	return chk
+}
+
+// getRow returns the row at pos of the child's output.
+func (r *windowRowsInDisk) getRow(pos uint64) chunk.Row {
+	chkIdx, rowIdx := r.locate(pos)
+	chk := r.getCachedChunk(chkIdx)
+	if chk == nil {
+		return r.placeholder.GetRow(0)
+	}
+	return chk.GetRow(rowIdx)
+}
+
+// getRows returns the rows in [start, end) of the child's output.
+func (r *windowRowsInDisk) getRows(start, end uint64) []chunk.Row {
+	rows := make([]chunk.Row, 0, end-start)
+	for pos := start; pos < end; {
+		chkIdx, rowIdx := r.locate(pos)
+		chk := r.getCachedChunk(chkIdx)
+		if chk == nil {
+			for ; pos < end; pos++ {
+				rows = append(rows, r.placeholder.GetRow(0))
+			}
+			break
+		}
+		n := min(chk.NumRows()-rowIdx, int(end-pos))
+		for i := range n {
+			rows = append(rows, chk.GetRow(rowIdx+i))
+		}
+		pos += uint64(n)
+	}
+	return rows
+}
+
+// restoreChunk reads the idx-th spilled chunk from disk. Unlike the cached chunks, the returned
+// chunk is owned by the caller.
+func (r *windowRowsInDisk) restoreChunk(idx int) (*chunk.Chunk, error) {
+	return r.inDisk.GetChunk(idx)
+}
+
+func (r *windowRowsInDisk) close() {
+	for _, restored := range r.cache {
+		r.memTracker.Consume(-restored.chk.MemoryUsage())
+	}
+	r.cache = nil
+	r.inDisk.Close()
+}
+
+// windowSpillHelper holds the states shared by window executors to spill rows to disk.
+type windowSpillHelper struct {
+	memTracker  *memory.Tracker
+	diskTracker *disk.Tracker
+	spillAction *windowSpillDiskAction
+
+	// spillTriggered is set by spillAction when the memory quota is exceeded. The executor
+	// checks it before fetching the next child chunk, and then moves its rows to disk.
+	spillTriggered atomic.Bool
+	// rowsInDisk is nil until the executor enters spill mode. Once in spill mode, all the
+	// following child chunks are spilled to it.
+	rowsInDisk *windowRowsInDisk
+}
+
+func (h *windowSpillHelper) open(sctx sessionctx.Context, id int) {
+	vars := sctx.GetSessionVars()
+	if h.memTracker == nil {
+		h.memTracker = memory.NewTracker(id, -1)
+	} else {
+		h.memTracker.Reset()
+	}
+	h.memTracker.AttachTo(vars.StmtCtx.MemTracker)
+	h.spillTriggered.Store(false)
+	h.rowsInDisk = nil
+	if !vardef.EnableTmpStorageOnOOM.Load() {
+		return
+	}
+	if h.diskTracker == nil {
+		h.diskTracker = disk.NewTracker(id, -1)
+	} else {
+		h.diskTracker.Reset()
+	}
+	h.diskTracker.AttachTo(vars.StmtCtx.DiskTracker)
+	h.spillAction = &windowSpillDiskAction{helper: h}
+	vars.MemTracker.FallbackOldAndSetNewAction(h.spillAction)
+}
+
+func (h *windowSpillHelper) inSpillMode() bool {
+	return h.rowsInDisk != nil
+}
+
+func (h *windowSpillHelper) needSpill() bool {
+	return h.rowsInDisk == nil && h.spillTriggered.Load()
+}
+
+// startSpill switches to spill mode. firstRow is the position of the first row to be spilled
+// in the child's output.
+func (h *windowSpillHelper) startSpill(fieldTypes []*types.FieldType, firstRow uint64) {
+	h.rowsInDisk = newWindowRowsInDisk(fieldTypes, firstRow, h.memTracker, h.diskTracker)
+}
+
+// spillResultChunk spills the child chunk of d, and drops d.chk if none of its rows has got
+// the results. The dropped chunk is restored by restoreResultChunk when its results are appended.
+func (h *windowSpillHelper) spillResultChunk(d *dataInfo) error {
+	idx, err := h.rowsInDisk.add(d.src)
+	if err != nil {
+		return err
+	}
+	if d.remaining == uint64(d.src.NumRows()) {
+		h.memTracker.Consume(-d.memUsage)
+		d.chk, d.memUsage, d.spilledIdx = nil, 0, idx
+	}
+	d.src = nil
+	return nil
+}
+
+// restoreResultChunk restores d.chk from the spilled child chunk with newResultChunk.
+func (h *windowSpillHelper) restoreResultChunk(d *dataInfo, newResultChunk func(src *chunk.Chunk) (*chunk.Chunk, error)) error {
+	src, err := h.rowsInDisk.restoreChunk(d.spilledIdx)
+	if err != nil {
+		return err
+	}
+	d.chk, err = newResultChunk(src)
+	if err != nil {
+		return err
+	}
+	d.memUsage = src.MemoryUsage()
+	h.memTracker.Consume(d.memUsage)
+	return nil
+}
+
+// getErr returns the error met when reading the spilled rows.
+func (h *windowSpillHelper) getErr() error {
+	if h.rowsInDisk == nil {
+		return nil
+	}
+	return h.rowsInDisk.err
+}
+
+func (h *windowSpillHelper) close() {
+	if h.rowsInDisk != nil {
+		h.rowsInDisk.close()
+		h.rowsInDisk = nil
+	}
+	if h.memTracker != nil {
+		h.memTracker.ReplaceBytesUsed(0)
+	}
+	if h.spillAction != nil {
+		h.spillAction.SetFinished()
+		h.spillAction = nil
+	}
+}
+
+// hasEnoughDataToSpill guarantees that the window executor holds at least 20% of the quota,
+// to avoid spilling when most of the memory is consumed by other executors.
+func (h *windowSpillHelper) hasEnoughDataToSpill(t *memory.Tracker) bool {
+	return h.memTracker.BytesConsumed() >= t.GetBytesLimit()/5
+}
+
+// windowSpillDiskAction implements memory.ActionOnExceed for window executors.
+// If the memory quota of a query is exceeded, windowSpillDiskAction.Action is
+// triggered.
+type windowSpillDiskAction struct {
+	memory.BaseOOMAction
+	helper *windowSpillHelper
+}
+
+// Action sets the window executor to spill mode.
+func (a *windowSpillDiskAction) Action(t *memory.Tracker) {
+	if !a.helper.spillTriggered.Load() && a.helper.hasEnoughDataToSpill(t) {
+		logutil.BgLogger().Info(windowSpillLogInfo,
+			zap.Int64("consumed", t.BytesConsumed()),
+			zap.Int64("quota", t.GetBytesLimit()))
+		a.helper.spillTriggered.Store(true)
+		memory.QueryForceDisk.Add(1)
+		return
+	}
+	if fallback := a.GetFallback(); fallback != nil {
+		fallback.Action(t)
+	}
+}
+
+// GetPriority get the priority of the Action
+func (*windowSpillDiskAction) GetPriority() int64 {
+	return memory.DefSpillPriority
+}
@@ -17,10 +17,13 @@ package executor_test
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/pingcap/tidb/pkg/config"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
 )
 
 func TestWindowFunctions(t *testing.T) {
@@ -479,3 +482,60 @@ func TestVarSampAsAWindowFunction(t *testing.T) {
 	tk.MustExec("select var_samp(c1) from t1")
 	tk.MustExec("select c1, var_samp(c1) over (partition by c1) from t1")
 }
+
+func TestWindowFunctionsSpillToDisk(t *testing.T) {
+	defer config.RestoreFunc()()
+	config.UpdateGlobal(func(conf *config.Config) {
+		conf.TempStoragePath = t.TempDir()
+	})
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	defer tk.MustExec("SET GLOBAL tidb_mem_oom_action = DEFAULT")
+	tk.MustExec("SET GLOBAL tidb_mem_oom_action='LOG'")
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int, c int)")
+	var buf strings.Builder
+	buf.WriteString("insert into t values ")
+	for i := range 1000 {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		// Most of the rows are in the same partition.
+		a := 1
+		if i%10 == 0 {
+			a = i % 7
+		}
+		buf.WriteString(fmt.Sprintf("(%d, %d, %d)", a, i, i%13))
+	}
+	tk.MustExec(buf.String())
+	tk.MustExec("set @@tidb_window_concurrency = 1")
+	tk.MustExec("set @@tidb_max_chunk_size = 32")
+
+	queries := []string{
+		"select a, b, row_number() over (partition by a order by b) from t",
+		"select a, b, sum(c) over (partition by a) from t",
+		"select a, b, sum(c) over (partition by a order by b) from t",
+		"select a, b, count(c) over (partition by a order by b rows between 40 preceding and 3 following) from t",
+		"select a, b, max(c) over (partition by a order by b range between 50 preceding and 50 following) from t",
+		"select a, b, lead(c, 40) over (partition by a order by b), nth_value(c, 100) over (partition by a order by b) from t",
+	}
+	for _, pipelined := range []string{"0", "1"} {
+		tk.MustExec("set @@tidb_enable_pipelined_window_function = " + pipelined)
+		for _, query := range queries {
+			tk.MustExec("set @@tidb_mem_quota_query = default")
+			expected := tk.MustQuery(query).Sort().Rows()
+			tk.MustExec("set @@tidb_mem_quota_query = 1")
+			tk.MustQuery(query).Sort().Check(expected)
+			require.Equal(t, int64(0), tk.Session().GetSessionVars().StmtCtx.MemTracker.BytesConsumed())
+			require.Equal(t, int64(0), tk.Session().GetSessionVars().StmtCtx.DiskTracker.BytesConsumed())
+
+			spilled := false
+			for _, row := range tk.MustQuery("explain analyze " + query).Rows() {
+				if strings.Contains(row[0].(string), "Window") {
+					spilled = row[len(row)-1].(string) != "N/A"
+				}
+			}
+			require.True(t, spilled, query)
+		}
+	}
+}
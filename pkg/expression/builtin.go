@@ -978,8 +978,21 @@ var funcs = map[string]functionClass{
 	ast.VecFromText:             &vecFromTextFunctionClass{baseFunctionClass{ast.VecFromText, 1, 1}},
 	ast.VecAsText:               &vecAsTextFunctionClass{baseFunctionClass{ast.VecAsText, 1, 1}},
 
+	// spatial functions
+	ast.STGeomFromText:     &stGeomFromTextFunctionClass{baseFunctionClass{ast.STGeomFromText, 1, 2}},
+	ast.STGeometryFromText: &stGeomFromTextFunctionClass{baseFunctionClass{ast.STGeometryFromText, 1, 2}},
+	ast.STAsText:           &stAsTextFunctionClass{baseFunctionClass{ast.STAsText, 1, 1}},
+	ast.STAsWKT:            &stAsTextFunctionClass{baseFunctionClass{ast.STAsWKT, 1, 1}},
+	ast.STDistance:         &stDistanceFunctionClass{baseFunctionClass{ast.STDistance, 2, 2}},
+	ast.STContains:         &stRelationFunctionClass{baseFunctionClass{ast.STContains, 2, 2}},
+	ast.STWithin:           &stRelationFunctionClass{baseFunctionClass{ast.STWithin, 2, 2}},
+	ast.STIntersects:       &stRelationFunctionClass{baseFunctionClass{ast.STIntersects, 2, 2}},
+	ast.STX:                &stCoordFunctionClass{baseFunctionClass{ast.STX, 1, 1}},
+	ast.STY:                &stCoordFunctionClass{baseFunctionClass{ast.STY, 1, 1}},
+
 	// fts functions
-	ast.FTSMatchWord: &ftsMatchWordFunctionClass{baseFunctionClass{ast.FTSMatchWord, 2, 2}},
+	ast.FTSMatchWord:    &ftsMatchWordFunctionClass{baseFunctionClass{ast.FTSMatchWord, 2, 2}},
+	ast.FTSMatchAgainst: &ftsMatchAgainstFunctionClass{baseFunctionClass{ast.FTSMatchAgainst, 4, 4}},
 
 	// TiDB internal function.
 	ast.TiDBDecodeKey:       &tidbDecodeKeyFunctionClass{baseFunctionClass{ast.TiDBDecodeKey, 1, 1}},
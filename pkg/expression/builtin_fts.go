@@ -16,17 +16,22 @@ package expression
 
 import (
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/fulltext"
 	"github.com/pingcap/tipb/go-tipb"
 )
 
 var (
 	_ functionClass = &ftsMatchWordFunctionClass{}
+	_ functionClass = &ftsMatchAgainstFunctionClass{}
 )
 
 var (
 	_ builtinFunc = &builtinFtsMatchWordSig{}
+	_ builtinFunc = &builtinFtsMatchAgainstSig{}
 )
 
 type ftsMatchWordFunctionClass struct {
@@ -81,3 +86,88 @@ func (b *builtinFtsMatchWordSig) evalReal(ctx EvalContext, row chunk.Row) (float
 	// Reject executing match against in TiDB side.
 	return 0, false, errors.Errorf("cannot use 'FTS_MATCH_WORD()' outside of fulltext index")
 }
+
+// ftsMatchAgainstFunctionClass is the function class of `MATCH (col) AGAINST (expr [modifier])`
+// on a FULLTEXT index stored in TiKV. The arguments are the search string, whether it's in
+// boolean mode, the SQL name of the parser and the column.
+type ftsMatchAgainstFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *ftsMatchAgainstFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	modeArg, ok := args[1].(*Constant)
+	if !ok {
+		return nil, ErrNotSupportedYet.GenWithStackByArgs("match against with a non-constant modifier")
+	}
+	parserArg, ok := args[2].(*Constant)
+	if !ok {
+		return nil, ErrNotSupportedYet.GenWithStackByArgs("match against with a non-constant parser")
+	}
+	parser := model.GetFullTextParserTypeBySQLName(parserArg.Value.GetString())
+	if parser == model.FullTextParserTypeInvalid {
+		return nil, ErrNotSupportedYet.GenWithStackByArgs("match against with an unknown parser")
+	}
+
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, types.ETString, types.ETInt, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.AddFlag(mysql.NotNullFlag)
+	sig := &builtinFtsMatchAgainstSig{
+		baseBuiltinFunc: bf,
+		parser:          parser,
+		booleanMode:     modeArg.Value.GetInt64() != 0,
+	}
+	return sig, nil
+}
+
+type builtinFtsMatchAgainstSig struct {
+	baseBuiltinFunc
+	parser      model.FullTextParserType
+	booleanMode bool
+
+	queryCache builtinFuncCache[*fulltext.Query]
+}
+
+func (b *builtinFtsMatchAgainstSig) Clone() builtinFunc {
+	newSig := &builtinFtsMatchAgainstSig{parser: b.parser, booleanMode: b.booleanMode}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalReal evals the relevance of `MATCH (col) AGAINST (expr [modifier])`, it's 0 if the row doesn't match.
+func (b *builtinFtsMatchAgainstSig) evalReal(ctx EvalContext, row chunk.Row) (float64, bool, error) {
+	text, isNull, err := b.args[3].EvalString(ctx, row)
+	if isNull || err != nil {
+		return 0, false, err
+	}
+	query, isNull, err := b.getQuery(ctx, row)
+	if isNull || err != nil {
+		return 0, false, err
+	}
+	return query.Relevance(fulltext.NewDocument(b.parser, text)), false, nil
+}
+
+func (b *builtinFtsMatchAgainstSig) getQuery(ctx EvalContext, row chunk.Row) (*fulltext.Query, bool, error) {
+	parse := func() (*fulltext.Query, error) {
+		against, isNull, err := b.args[0].EvalString(ctx, row)
+		if isNull || err != nil {
+			return nil, err
+		}
+		return fulltext.ParseQuery(b.parser, against, b.booleanMode), nil
+	}
+	var (
+		query *fulltext.Query
+		err   error
+	)
+	// Parse the search string only once if it's constant. Notice that the cache is only valid when the context ids are the same.
+	if b.args[0].ConstLevel() >= ConstOnlyInContext {
+		query, err = b.queryCache.getOrInitCache(ctx, parse)
+	} else {
+		query, err = parse()
+	}
+	return query, query == nil, err
+}
@@ -0,0 +1,460 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"math"
+
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	parsertypes "github.com/pingcap/tidb/pkg/parser/types"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/hack"
+)
+
+var (
+	_ functionClass = &stGeomFromTextFunctionClass{}
+	_ functionClass = &stAsTextFunctionClass{}
+	_ functionClass = &stDistanceFunctionClass{}
+	_ functionClass = &stRelationFunctionClass{}
+	_ functionClass = &stCoordFunctionClass{}
+)
+
+var (
+	_ builtinFunc = &builtinSTGeomFromTextSig{}
+	_ builtinFunc = &builtinSTAsTextSig{}
+	_ builtinFunc = &builtinSTDistanceSig{}
+	_ builtinFunc = &builtinSTRelationSig{}
+	_ builtinFunc = &builtinSTCoordSig{}
+)
+
+type stGeomFromTextFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stGeomFromTextFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString}
+	if len(args) > 1 {
+		argTps = append(argTps, types.ETInt)
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.SetType(mysql.TypeGeometry)
+	bf.tp.SetFlen(mysql.MaxLongBlobWidth)
+	types.SetBinChsClnFlag(bf.tp)
+	sig := &builtinSTGeomFromTextSig{bf, c.funcName}
+	return sig, nil
+}
+
+type builtinSTGeomFromTextSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinSTGeomFromTextSig) Clone() builtinFunc {
+	newSig := &builtinSTGeomFromTextSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinSTGeomFromTextSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	wkt, isNull, err := b.args[0].EvalString(ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	srid := int64(types.SRIDCartesian)
+	if len(b.args) > 1 {
+		srid, isNull, err = b.args[1].EvalInt(ctx, row)
+		if isNull || err != nil {
+			return "", isNull, err
+		}
+		if srid < 0 || srid > math.MaxUint32 {
+			return "", false, types.ErrSRSNotFound.GenWithStackByArgs(srid)
+		}
+	}
+	g, err := types.ParseGeometryFromWKT(b.funcName, wkt, uint32(srid))
+	if err != nil {
+		return "", false, err
+	}
+	return string(g.Encode()), false, nil
+}
+
+type stAsTextFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stAsTextFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.SetFlen(mysql.MaxLongBlobWidth)
+	sig := &builtinSTAsTextSig{bf, c.funcName}
+	return sig, nil
+}
+
+type builtinSTAsTextSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinSTAsTextSig) Clone() builtinFunc {
+	newSig := &builtinSTAsTextSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinSTAsTextSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	s, isNull, err := b.args[0].EvalString(ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	g, err := types.DecodeGeometry(b.funcName, hack.Slice(s))
+	if err != nil {
+		return "", false, err
+	}
+	return g.WKT(), false, nil
+}
+
+// spatialConstArg is a decoded constant geometry argument.
+type spatialConstArg struct {
+	geom   *types.Geometry
+	mbr    types.GeometryMBR
+	isNull bool
+}
+
+// spatialBinaryFuncSig is the base of the spatial functions with two geometry
+// arguments. It decodes a constant argument only once.
+type spatialBinaryFuncSig struct {
+	baseBuiltinFunc
+	funcName  string
+	constArgs [2]builtinFuncCache[spatialConstArg]
+}
+
+func (b *spatialBinaryFuncSig) cloneFrom(from *spatialBinaryFuncSig) {
+	b.baseBuiltinFunc.cloneFrom(&from.baseBuiltinFunc)
+	b.funcName = from.funcName
+}
+
+func (b *spatialBinaryFuncSig) isConstArg(idx int) bool {
+	return b.args[idx].ConstLevel() >= ConstOnlyInContext
+}
+
+// evalConstArg evaluates and decodes a constant geometry argument. Notice that
+// the cache is only valid when the context ids are the same.
+func (b *spatialBinaryFuncSig) evalConstArg(ctx EvalContext, row chunk.Row, idx int) (spatialConstArg, error) {
+	return b.constArgs[idx].getOrInitCache(ctx, func() (ret spatialConstArg, err error) {
+		s, isNull, err := b.args[idx].EvalString(ctx, row)
+		if isNull || err != nil {
+			ret.isNull = isNull
+			return ret, err
+		}
+		if ret.geom, err = types.DecodeGeometry(b.funcName, hack.Slice(s)); err != nil {
+			return ret, err
+		}
+		mbr, ok := ret.geom.MBR()
+		ret.mbr, ret.isNull = mbr, !ok
+		return ret, nil
+	})
+}
+
+// evalArgs evaluates the geometry arguments. It returns null if any of them is
+// null or empty. If one of the arguments is constant and match isn't nil, the
+// other argument is skipped without being decoded when match returns false on
+// the bounding rectangles of them, and the returned geometries are nil.
+func (b *spatialBinaryFuncSig) evalArgs(ctx EvalContext, row chunk.Row, match func(mbr1, mbr2 types.GeometryMBR) bool) (g1, g2 *types.Geometry, isNull bool, err error) {
+	var (
+		geoms    [2]*types.Geometry
+		constArg spatialConstArg
+	)
+	for i := range geoms {
+		if !b.isConstArg(i) {
+			continue
+		}
+		if constArg, err = b.evalConstArg(ctx, row, i); constArg.isNull || err != nil {
+			return nil, nil, constArg.isNull, err
+		}
+		geoms[i] = constArg.geom
+	}
+	for i := range geoms {
+		if geoms[i] != nil {
+			continue
+		}
+		s, isNull, err := b.args[i].EvalString(ctx, row)
+		if isNull || err != nil {
+			return nil, nil, isNull, err
+		}
+		if other := geoms[1-i]; match != nil && b.isConstArg(1-i) {
+			srid, mbr, ok, err := types.DecodeGeometryMBR(b.funcName, hack.Slice(s))
+			if !ok || err != nil {
+				return nil, nil, !ok, err
+			}
+			if srid != other.SRID {
+				srids := [2]uint32{other.SRID, other.SRID}
+				srids[i] = srid
+				return nil, nil, false, types.ErrGISDifferentSRIDs.GenWithStackByArgs(b.funcName, srids[0], srids[1])
+			}
+			mbrs := [2]types.GeometryMBR{constArg.mbr, constArg.mbr}
+			mbrs[i] = mbr
+			if !match(mbrs[0], mbrs[1]) {
+				return nil, nil, false, nil
+			}
+		}
+		if geoms[i], err = types.DecodeGeometry(b.funcName, hack.Slice(s)); err != nil {
+			return nil, nil, false, err
+		}
+		if geoms[i].IsEmpty() {
+			return nil, nil, true, nil
+		}
+	}
+	if err = types.CheckGeometrySRIDs(b.funcName, geoms[0], geoms[1]); err != nil {
+		return nil, nil, false, err
+	}
+	return geoms[0], geoms[1], false, nil
+}
+
+type stDistanceFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stDistanceFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinSTDistanceSig{spatialBinaryFuncSig{baseBuiltinFunc: bf, funcName: c.funcName}}
+	return sig, nil
+}
+
+type builtinSTDistanceSig struct {
+	spatialBinaryFuncSig
+}
+
+func (b *builtinSTDistanceSig) Clone() builtinFunc {
+	newSig := &builtinSTDistanceSig{}
+	newSig.cloneFrom(&b.spatialBinaryFuncSig)
+	return newSig
+}
+
+func (b *builtinSTDistanceSig) evalReal(ctx EvalContext, row chunk.Row) (float64, bool, error) {
+	g1, g2, isNull, err := b.evalArgs(ctx, row, nil)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	d, err := types.GeometryDistance(b.funcName, g1, g2)
+	return d, false, err
+}
+
+// stRelationFunctionClass is the function class of ST_Contains, ST_Within and
+// ST_Intersects.
+type stRelationFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stRelationFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.SetFlen(1)
+	sig := &builtinSTRelationSig{spatialBinaryFuncSig{baseBuiltinFunc: bf, funcName: c.funcName}}
+	return sig, nil
+}
+
+// builtinSTRelationSig checks a spatial relation between two geometries. When
+// one of the geometries is constant, the other one is compared by the minimum
+// bounding rectangle first, so most of the rows can be filtered out without
+// computing the exact relation.
+type builtinSTRelationSig struct {
+	spatialBinaryFuncSig
+}
+
+func (b *builtinSTRelationSig) Clone() builtinFunc {
+	newSig := &builtinSTRelationSig{}
+	newSig.cloneFrom(&b.spatialBinaryFuncSig)
+	return newSig
+}
+
+func (b *builtinSTRelationSig) evalInt(ctx EvalContext, row chunk.Row) (int64, bool, error) {
+	var (
+		match    func(mbr1, mbr2 types.GeometryMBR) bool
+		relation func(g1, g2 *types.Geometry) bool
+	)
+	switch b.funcName {
+	case ast.STContains:
+		match = types.GeometryMBR.Contains
+		relation = types.GeometryContains
+	case ast.STWithin:
+		match = func(mbr1, mbr2 types.GeometryMBR) bool { return mbr2.Contains(mbr1) }
+		relation = func(g1, g2 *types.Geometry) bool { return types.GeometryContains(g2, g1) }
+	default:
+		match = types.GeometryMBR.Intersects
+		relation = types.GeometryIntersects
+	}
+	g1, g2, isNull, err := b.evalArgs(ctx, row, match)
+	if isNull || err != nil || g1 == nil {
+		return 0, isNull, err
+	}
+	return boolToInt64(relation(g1, g2)), false, nil
+}
+
+type stCoordFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stCoordFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinSTCoordSig{bf, c.funcName, stCoordAxis(c.funcName)}
+	return sig, nil
+}
+
+// builtinSTCoordSig returns a coordinate of a point, which is ST_X or ST_Y.
+type builtinSTCoordSig struct {
+	baseBuiltinFunc
+	funcName string
+	axis     int
+}
+
+func (b *builtinSTCoordSig) Clone() builtinFunc {
+	newSig := &builtinSTCoordSig{funcName: b.funcName, axis: b.axis}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinSTCoordSig) evalReal(ctx EvalContext, row chunk.Row) (float64, bool, error) {
+	s, isNull, err := b.args[0].EvalString(ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	g, err := types.DecodeGeometry(b.funcName, hack.Slice(s))
+	if err != nil {
+		return 0, false, err
+	}
+	if g.Type != parsertypes.GeometryTypePoint || len(g.Points) != 1 {
+		return 0, false, types.ErrGISUnsupportedArgument.GenWithStackByArgs(b.funcName)
+	}
+	return stCoord(g.Points[0], g.IsGeographic(), b.axis), false, nil
+}
+
+func stCoordAxis(funcName string) int {
+	if funcName == ast.STY {
+		return 1
+	}
+	return 0
+}
+
+// stCoord returns the coordinate of p on axis, which is 0 for ST_X and 1 for
+// ST_Y. The axes are in the order of the spatial reference system, so ST_X
+// returns the latitude of a geographic point.
+func stCoord(p types.GeometryPoint, geographic bool, axis int) float64 {
+	if geographic == (axis == 0) {
+		return p.Y
+	}
+	return p.X
+}
+
+// DeriveSpatialMBRFilters derives the filters on ST_X and ST_Y of a point
+// column from ST_Contains, ST_Within or ST_Intersects between the column and a
+// constant geometry: the point must be inside the minimum bounding rectangle of
+// the constant. The derived filters are a superset of cond, and they can be
+// converted to index ranges when ST_X or ST_Y of the column has an expression
+// index. It returns nil if nothing can be derived.
+func DeriveSpatialMBRFilters(ctx BuildContext, cond Expression) []Expression {
+	sf, ok := cond.(*ScalarFunction)
+	if !ok {
+		return nil
+	}
+	switch sf.FuncName.L {
+	case ast.STContains, ast.STWithin, ast.STIntersects:
+	default:
+		return nil
+	}
+	// A point intersects, contains or is within a geometry only if it's inside
+	// the bounding rectangle of the geometry, so the relation doesn't matter.
+	var (
+		col  *Column
+		geom Expression
+	)
+	args := sf.GetArgs()
+	for i, arg := range args {
+		c, ok := arg.(*Column)
+		if ok && c.RetType.GetType() == mysql.TypeGeometry && c.RetType.GetGeometryType() == parsertypes.GeometryTypePoint &&
+			args[1-i].ConstLevel() == ConstStrict {
+			col, geom = c, args[1-i]
+			break
+		}
+	}
+	if col == nil {
+		return nil
+	}
+	s, isNull, err := geom.EvalString(ctx.GetEvalCtx(), chunk.Row{})
+	if isNull || err != nil {
+		return nil
+	}
+	g, err := types.DecodeGeometry(sf.FuncName.L, hack.Slice(s))
+	if err != nil {
+		return nil
+	}
+	mbr, ok := g.MBR()
+	if !ok {
+		return nil
+	}
+	lower := types.GeometryPoint{X: mbr.MinX, Y: mbr.MinY}
+	upper := types.GeometryPoint{X: mbr.MaxX, Y: mbr.MaxY}
+	filters := make([]Expression, 0, 4)
+	for _, funcName := range []string{ast.STX, ast.STY} {
+		axis := stCoordAxis(funcName)
+		for _, bound := range []struct {
+			op    string
+			value float64
+		}{
+			{ast.GE, stCoord(lower, g.IsGeographic(), axis)},
+			{ast.LE, stCoord(upper, g.IsGeographic(), axis)},
+		} {
+			coord, err := NewFunction(ctx, funcName, types.NewFieldType(mysql.TypeDouble), col.Clone())
+			if err != nil {
+				return nil
+			}
+			value := &Constant{Value: types.NewFloat64Datum(bound.value), RetType: types.NewFieldType(mysql.TypeDouble)}
+			filter, err := NewFunction(ctx, bound.op, types.NewFieldType(mysql.TypeTiny), coord, value)
+			if err != nil {
+				return nil
+			}
+			filters = append(filters, filter)
+		}
+	}
+	return filters
+}
@@ -109,6 +109,7 @@ func TestIllegalFunctions4GeneratedColumns(t *testing.T) {
 		"from_base64",
 		"from_days",
 		"from_unixtime",
+		"fts_match_against",
 		"fts_match_word",
 		"ge",
 		"get_format",
@@ -238,6 +239,16 @@ func TestIllegalFunctions4GeneratedColumns(t *testing.T) {
 		"sm3", // TiDB specific?
 		"space",
 		"sqrt",
+		"st_astext",
+		"st_aswkt",
+		"st_contains",
+		"st_distance",
+		"st_geometryfromtext",
+		"st_geomfromtext",
+		"st_intersects",
+		"st_within",
+		"st_x",
+		"st_y",
 		"str_to_date",
 		"strcmp",
 		"subdate",
@@ -207,7 +207,8 @@ func TestFTSSyntax(t *testing.T) {
 	// tk.MustContainErrMsg("select * from t where (fts_match_word('hello', title)) > 0", "Currently 'FTS_MATCH_WORD()' must be used alone")
 	// tk.MustContainErrMsg("select (fts_match_word('hello', title)) AS score from t where fts_match_word('hello', title)", "Currently 'FTS_MATCH_WORD()' cannot be used in SELECT fields")
 	tk.MustContainErrMsg("select * from t where match() against ('hello')", `You have an error in your SQL syntax`)
-	tk.MustContainErrMsg("select * from t where match(title) against ('hello' in boolean mode)", `UnknownType: *ast.MatchAgainst`)
+	tk.MustQuery("select * from t where match(title) against ('hello' in boolean mode)").Check(testkit.Rows())
+	tk.MustContainErrMsg("select * from t where match(body) against ('hello')", `Can't find FULLTEXT index matching the column list`)
 	tk.MustContainErrMsg("select * from t where fts_match_word(title, body)", `match against a non-constant string`)
 	tk.MustContainErrMsg("select * from t where fts_match_word(45.67, body)", `match against a non-constant string`)
 	tk.MustContainErrMsg("select * from t where fts_match_word('hello', title, body)", `Incorrect parameter count in the call to native function`)
@@ -253,7 +254,12 @@ func TestFTSIndexSyntax(t *testing.T) {
 
 	tk.MustExec("drop table t1, t2, t3, t4, t5, t6")
 	tk.MustExec("create table t1(title TEXT, body TEXT)")
-	tk.MustContainErrMsg("alter table t1 add FULLTEXT INDEX (body)", "columnar replica must exist to create")
+	// Without columnar replica, the FULLTEXT index is stored in TiKV.
+	tk.MustExec("alter table t1 add FULLTEXT INDEX (body)")
+	tbl, err := domain.GetDomain(tk.Session()).InfoSchema().TableByName(context.Background(), ast.NewCIStr("test"), ast.NewCIStr("t1"))
+	require.NoError(t, err)
+	require.True(t, tbl.Meta().Indices[0].IsRowStoreFullTextIndex())
+	tk.MustExec("alter table t1 drop index body")
 	tk.MustExec("alter table t1 set tiflash replica 1")
 	tk.MustExec("alter table t1 add FULLTEXT INDEX (body)")
 	tk.MustQuery("show create table t1").Check(testkit.Rows("t1 CREATE TABLE `t1` (\n  `title` text DEFAULT NULL,\n  `body` text DEFAULT NULL,\n  FULLTEXT INDEX `body`(`body`) WITH PARSER STANDARD\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
@@ -4425,3 +4431,67 @@ func TestIssue57608(t *testing.T) {
 		))
 	}
 }
+
+func TestSpatialFunctions(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(id int primary key, g geometry, p point)")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  `g` geometry DEFAULT NULL,\n" +
+		"  `p` point DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	tk.MustExec("insert into t values " +
+		"(1, st_geomfromtext('POLYGON((0 0,4 0,4 4,0 4,0 0))'), st_geomfromtext('POINT(1 1)'))," +
+		"(2, st_geomfromtext('LINESTRING(10 10,20 20)'), st_geomfromtext('POINT(15 15)'))," +
+		"(3, st_geometryfromtext('MULTIPOINT(1 2,3 4)'), null)," +
+		"(4, null, st_geomfromtext('POINT(-3 0)'))")
+	tk.MustGetErrCode("insert into t values (5, 'abc', null)", errno.ErrCantCreateGeometryObject)
+	tk.MustGetErrCode("insert into t values (5, null, st_geomfromtext('LINESTRING(0 0,1 1)'))", errno.ErrCantCreateGeometryObject)
+	tk.MustGetErrCode("insert into t values (5, st_geomfromtext('POINT(1)'), null)", errno.ErrGISInvalidData)
+
+	tk.MustQuery("select id, st_astext(g), st_aswkt(p) from t order by id").Check(testkit.Rows(
+		"1 POLYGON((0 0,4 0,4 4,0 4,0 0)) POINT(1 1)",
+		"2 LINESTRING(10 10,20 20) POINT(15 15)",
+		"3 MULTIPOINT((1 2),(3 4)) <nil>",
+		"4 <nil> POINT(-3 0)",
+	))
+	tk.MustQuery("select id from t where st_contains(st_geomfromtext('POLYGON((0 0,5 0,5 5,0 5,0 0))'), g) order by id").Check(testkit.Rows("1", "3"))
+	tk.MustQuery("select id from t where st_within(p, st_geomfromtext('POLYGON((0 0,5 0,5 5,0 5,0 0))')) order by id").Check(testkit.Rows("1"))
+	tk.MustQuery("select id from t where st_intersects(g, st_geomfromtext('LINESTRING(0 20,20 0)')) order by id").Check(testkit.Rows("2"))
+	tk.MustQuery("select id, st_contains(g, p), st_intersects(g, p) from t order by id").Check(testkit.Rows(
+		"1 1 1",
+		"2 1 1",
+		"3 <nil> <nil>",
+		"4 <nil> <nil>",
+	))
+	tk.MustQuery("select id, st_distance(p, st_geomfromtext('POINT(0 0)')) from t order by id").Check(testkit.Rows(
+		"1 1.4142135623730951",
+		"2 21.213203435596427",
+		"3 <nil>",
+		"4 3",
+	))
+
+	tk.MustQuery("select st_astext(st_geomfromtext('POINT(30 120)', 4326))").Check(testkit.Rows("POINT(30 120)"))
+	tk.MustQuery("select id, st_x(p), st_y(p) from t order by id").Check(testkit.Rows(
+		"1 1 1",
+		"2 15 15",
+		"3 <nil> <nil>",
+		"4 -3 0",
+	))
+	tk.MustQuery("select st_x(st_geomfromtext('POINT(30 120)', 4326)), st_y(st_geomfromtext('POINT(30 120)', 4326))").Check(testkit.Rows("30 120"))
+	err := tk.QueryToErr("select st_x(g) from t where id = 1")
+	require.True(t, types.ErrGISUnsupportedArgument.Equal(err))
+	tk.MustQuery("select round(st_distance(st_geomfromtext('POINT(0 0)', 4326), st_geomfromtext('POINT(0 1)', 4326)), 3)").Check(testkit.Rows("111319.491"))
+	err = tk.QueryToErr("select st_contains(g, st_geomfromtext('POINT(1 1)', 4326)) from t")
+	require.True(t, types.ErrGISDifferentSRIDs.Equal(err))
+	err = tk.QueryToErr("select st_geomfromtext('POINT(1 1)', 1234)")
+	require.True(t, types.ErrSRSNotFound.Equal(err))
+	err = tk.QueryToErr("select st_geomfromtext('POINT(100 0)', 4326)")
+	require.True(t, types.ErrLatitudeOutOfRange.Equal(err))
+
+	tk.MustGetErrCode("create index idx on t(g)", errno.ErrBlobKeyWithoutLength)
+	tk.MustGetErrCode("create table t1(g geometry default 'abc')", errno.ErrBlobCantHaveDefault)
+}
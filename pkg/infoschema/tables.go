@@ -104,10 +104,11 @@ const (
 	// TableEngines is the string constant of infoschema table.
 	TableEngines = "ENGINES"
 	// TableViews is the string constant of infoschema table.
-	TableViews          = "VIEWS"
-	tableRoutines       = "ROUTINES"
-	tableParameters     = "PARAMETERS"
-	tableEvents         = "EVENTS"
+	TableViews      = "VIEWS"
+	tableRoutines   = "ROUTINES"
+	tableParameters = "PARAMETERS"
+	// TableEvents is the string constant of infoschema table.
+	TableEvents         = "EVENTS"
 	tableOptimizerTrace = "OPTIMIZER_TRACE"
 	tableTableSpaces    = "TABLESPACES"
 	// TableCollationCharacterSetApplicability is the string constant of infoschema memory table.
@@ -272,7 +273,7 @@ var tableIDMap = map[string]int64{
 	TableViews:            autoid.InformationSchemaDBID + 23,
 	tableRoutines:         autoid.InformationSchemaDBID + 24,
 	tableParameters:       autoid.InformationSchemaDBID + 25,
-	tableEvents:           autoid.InformationSchemaDBID + 26,
+	TableEvents:           autoid.InformationSchemaDBID + 26,
 	// Removed, see https://github.com/pingcap/tidb/issues/9154
 	// tableGlobalStatus:                    autoid.InformationSchemaDBID + 27,
 	// tableGlobalVariables:                 autoid.InformationSchemaDBID + 28,
@@ -2448,7 +2449,7 @@ var tableNameToColumns = map[string][]columnInfo{
 	TableViews:                              tableViewsCols,
 	tableRoutines:                           tableRoutinesCols,
 	tableParameters:                         tableParametersCols,
-	tableEvents:                             tableEventsCols,
+	TableEvents:                             tableEventsCols,
 	tableOptimizerTrace:                     tableOptimizerTraceCols,
 	tableTableSpaces:                        tableTableSpacesCols,
 	TableCollationCharacterSetApplicability: tableCollationCharacterSetApplicabilityCols,
@@ -104,6 +104,29 @@ const (
 	defaultCSVDataInvalidCharReplace = utf8.RuneError
 
 	DefaultSwitchTiKVModeInterval = 5 * time.Minute
+
+	// SourceConsistencyAuto reads the source database under a TiDB snapshot if
+	// it's TiDB, otherwise under the transactions started with FLUSH TABLES WITH
+	// READ LOCK.
+	SourceConsistencyAuto = "auto"
+	// SourceConsistencySnapshot reads the source TiDB under `tidb_snapshot`.
+	SourceConsistencySnapshot = "snapshot"
+	// SourceConsistencyFlush reads the source MySQL under the transactions with
+	// consistent snapshot, which are started with FLUSH TABLES WITH READ LOCK.
+	SourceConsistencyFlush = "flush"
+	// SourceConsistencyNone reads the source database without a consistent snapshot.
+	SourceConsistencyNone = "none"
+
+	// UnknownColumnsError reports an error if a CSV header column doesn't exist in the table.
+	UnknownColumnsError = "error"
+	// UnknownColumnsIgnore ignores the CSV header columns which don't exist in the table.
+	UnknownColumnsIgnore = "ignore"
+	// UnknownColumnsOverflow stores the CSV header columns which don't exist in the table
+	// into the JSON overflow column, as an object of the header names and the values.
+	UnknownColumnsOverflow = "overflow"
+
+	defaultSourceDBPort         = 3306
+	defaultSourceDBRowsPerChunk = 200000
 )
 
 var (
@@ -893,6 +916,9 @@ type MydumperRuntime struct {
 	StrictFormat     bool             `toml:"strict-format" json:"strict-format"`
 	DefaultFileRules bool             `toml:"default-file-rules" json:"default-file-rules"`
 	IgnoreColumns    AllIgnoreColumns `toml:"ignore-data-columns" json:"ignore-data-columns"`
+	// FieldPaths maps the column names to the paths of the fields in NDJSON and avro files, for example,
+	// `city = "address.city"`. If it's set, only these columns are imported from NDJSON and avro files.
+	FieldPaths map[string]string `toml:"field-paths" json:"field-paths"`
 	// DataCharacterSet is the character set of the source file. Only CSV files are supported now. The following options are supported.
 	//   - utf8mb4
 	//   - GB18030
@@ -904,12 +930,164 @@ type MydumperRuntime struct {
 	// DataInvalidCharReplace is the replacement characters for non-compatible characters, which shouldn't duplicate with the separators or line breaks.
 	// Changing the default value will result in increased parsing time. Non-compatible characters do not cause an increase in error.
 	DataInvalidCharReplace string `toml:"data-invalid-char-replace" json:"data-invalid-char-replace"`
+	// SourceDB is the MySQL compatible database to import the tables from directly, it's exclusive with SourceDir.
+	SourceDB SourceDB `toml:"source-db" json:"source-db"`
+	// ColumnMapping maps the header columns of the CSV files to the table columns.
+	ColumnMapping ColumnMapping `toml:"column-mapping" json:"column-mapping"`
+}
+
+// ColumnMapping is the config to map the header columns of the CSV files to
+// the columns of the target tables by names, so the files whose headers drift
+// from the table schemas can be imported without `mydumper.files` rules. The
+// header names are matched case-insensitively, and the table columns missing
+// from the headers are filled with the default values.
+type ColumnMapping struct {
+	Enable bool `toml:"enable" json:"enable"`
+	// Aliases maps the header names to the column names, an alias is applied
+	// to a table if the table has the column but not a column named the alias.
+	Aliases map[string]string `toml:"aliases" json:"aliases"`
+	// UnknownColumns is one of UnknownColumnsError, UnknownColumnsIgnore and
+	// UnknownColumnsOverflow.
+	UnknownColumns string `toml:"unknown-columns" json:"unknown-columns"`
+	// OverflowColumn is the JSON column of the tables to store the unknown
+	// columns, the tables without the column fail if they have unknown columns.
+	OverflowColumn string `toml:"overflow-column" json:"overflow-column"`
+}
+
+func (c *ColumnMapping) adjust() error {
+	if !c.Enable {
+		return nil
+	}
+	switch c.UnknownColumns {
+	case "":
+		c.UnknownColumns = UnknownColumnsError
+	case UnknownColumnsError, UnknownColumnsIgnore, UnknownColumnsOverflow:
+	default:
+		return common.ErrInvalidConfig.GenWithStack(
+			"unsupported `mydumper.column-mapping.unknown-columns` (%s), supported values are %s, %s and %s",
+			c.UnknownColumns, UnknownColumnsError, UnknownColumnsIgnore, UnknownColumnsOverflow)
+	}
+	if (c.UnknownColumns == UnknownColumnsOverflow) != (len(c.OverflowColumn) > 0) {
+		return common.ErrInvalidConfig.GenWithStack(
+			"`mydumper.column-mapping.overflow-column` must be set if and only if `mydumper.column-mapping.unknown-columns` is %s",
+			UnknownColumnsOverflow)
+	}
+	c.OverflowColumn = strings.ToLower(c.OverflowColumn)
+
+	// lower case the names, because the CSV header names and Name.L are compared
+	aliases := make(map[string]string, len(c.Aliases))
+	for alias, col := range c.Aliases {
+		alias = strings.ToLower(alias)
+		if _, ok := aliases[alias]; ok {
+			return common.ErrInvalidConfig.GenWithStack(
+				"duplicated alias %s in `mydumper.column-mapping.aliases`", alias)
+		}
+		aliases[alias] = strings.ToLower(col)
+	}
+	c.Aliases = aliases
+	return nil
+}
+
+// SourceDB is the upstream MySQL compatible database, the tables are read in
+// parallel by the ranges of the primary keys and imported without dumping to
+// intermediate files.
+type SourceDB struct {
+	Host     string    `toml:"host" json:"host"`
+	Port     int       `toml:"port" json:"port"`
+	User     string    `toml:"user" json:"user"`
+	Psw      string    `toml:"password" json:"-"`
+	Security *Security `toml:"security" json:"security"`
+	// Consistency is one of SourceConsistencyAuto, SourceConsistencySnapshot,
+	// SourceConsistencyFlush and SourceConsistencyNone.
+	Consistency string `toml:"consistency" json:"consistency"`
+	// Snapshot is the TSO or the time of the TiDB snapshot to read, it's the
+	// current TSO if it's empty. It's resolved to the TSO on loading the source,
+	// which is kept from GC during the import and saved in the checkpoints to
+	// be reused on resuming.
+	Snapshot string `toml:"snapshot" json:"snapshot"`
+	// RowsPerChunk is the approximate rows of the chunks, the tables with an
+	// integer primary key (or `_tidb_rowid` in TiDB) are split into chunks by
+	// the ranges of the key.
+	RowsPerChunk int64 `toml:"rows-per-chunk" json:"rows-per-chunk"`
+	// Vars is the session variables of the connections to the source database.
+	// The TIMESTAMP values are read in the `time_zone` of the session, which
+	// should be the same as the target.
+	Vars map[string]string `toml:"session-vars" json:"session-vars"`
+}
+
+// IsEnabled returns whether the tables are imported from the source database.
+func (s *SourceDB) IsEnabled() bool {
+	return len(s.Host) > 0
+}
+
+// URL returns the URL of the source database, which is used as the data
+// source dir to identify the source in the checkpoints. The snapshot is
+// included, so that the checkpoints are resumed under the same snapshot, see
+// SourceDBSnapshotFromURL.
+func (s *SourceDB) URL() string {
+	u := s.hostURL()
+	if len(s.Snapshot) > 0 {
+		u += "?snapshot=" + url.QueryEscape(s.Snapshot)
+	}
+	return u
+}
+
+func (s *SourceDB) hostURL() string {
+	return fmt.Sprintf("mysql://%s", net.JoinHostPort(s.Host, strconv.Itoa(s.Port)))
+}
+
+// SourceDBSnapshotFromURL returns the snapshot in the URL returned by
+// SourceDB.URL, it's empty if there is no snapshot.
+func SourceDBSnapshotFromURL(sourceURL string) string {
+	u, err := url.Parse(sourceURL)
+	if err != nil || u.Scheme != "mysql" {
+		return ""
+	}
+	return u.Query().Get("snapshot")
+}
+
+func (s *SourceDB) adjust() error {
+	if s.Port == 0 {
+		s.Port = defaultSourceDBPort
+	}
+	if s.RowsPerChunk <= 0 {
+		s.RowsPerChunk = defaultSourceDBRowsPerChunk
+	}
+	switch s.Consistency {
+	case "":
+		s.Consistency = SourceConsistencyAuto
+	case SourceConsistencyAuto, SourceConsistencySnapshot, SourceConsistencyFlush, SourceConsistencyNone:
+	default:
+		return common.ErrInvalidConfig.GenWithStack(
+			"unsupported `mydumper.source-db.consistency` (%s), supported values are %s, %s, %s and %s", s.Consistency,
+			SourceConsistencyAuto, SourceConsistencySnapshot, SourceConsistencyFlush, SourceConsistencyNone)
+	}
+	if len(s.Snapshot) > 0 && s.Consistency != SourceConsistencyAuto && s.Consistency != SourceConsistencySnapshot {
+		return common.ErrInvalidConfig.GenWithStack(
+			"`mydumper.source-db.snapshot` can only be used with `mydumper.source-db.consistency` %s or %s",
+			SourceConsistencyAuto, SourceConsistencySnapshot)
+	}
+	return errors.Trace(s.Security.BuildTLSConfig())
 }
 
 func (m *MydumperRuntime) adjust() error {
 	if err := m.CSV.adjust(); err != nil {
 		return err
 	}
+	if m.SourceDB.IsEnabled() {
+		if err := m.SourceDB.adjust(); err != nil {
+			return err
+		}
+		// SourceDir is set to the URL of the source database by the previous
+		// adjust, the snapshot in it may have been resolved since then.
+		if dir, _, _ := strings.Cut(m.SourceDir, "?"); len(m.SourceDir) > 0 && dir != m.SourceDB.hostURL() {
+			return common.ErrInvalidConfig.GenWithStack(
+				"`mydumper.data-source-dir` and `mydumper.source-db` can't be set at the same time")
+		}
+		if len(m.FileRouters) > 0 {
+			return common.ErrInvalidConfig.GenWithStack("`mydumper.files` can't be used with `mydumper.source-db`")
+		}
+	}
 	if m.StrictFormat && len(m.CSV.LinesTerminatedBy) == 0 {
 		return common.ErrInvalidConfig.GenWithStack(
 			`mydumper.strict-format can not be used with empty mydumper.csv.terminator. Please set mydumper.csv.terminator to a non-empty value like "\r\n"`)
@@ -931,6 +1109,13 @@ func (m *MydumperRuntime) adjust() error {
 		}
 	}
 
+	for col, path := range m.FieldPaths {
+		if len(col) == 0 || slices.Contains(strings.Split(path, "."), "") {
+			return common.ErrInvalidConfig.GenWithStack(
+				"invalid `mydumper.field-paths`, path '%s' of column '%s' is not valid", path, col)
+		}
+	}
+
 	// enable default file route rule if no rules are set
 	if len(m.FileRouters) == 0 {
 		m.DefaultFileRules = true
@@ -969,6 +1154,18 @@ func (m *MydumperRuntime) adjust() error {
 			ig.Columns = cols
 		}
 	}
+	if err := m.ColumnMapping.adjust(); err != nil {
+		return err
+	}
+	if m.ColumnMapping.Enable && (!m.CSV.Header || !m.CSV.HeaderSchemaMatch) {
+		return common.ErrInvalidConfig.GenWithStack(
+			"`mydumper.column-mapping` requires `mydumper.csv.header` and `mydumper.csv.header-schema-match`")
+	}
+
+	if m.SourceDB.IsEnabled() {
+		m.SourceDir = m.SourceDB.URL()
+		return nil
+	}
 	return m.adjustFilePath()
 }
 
@@ -1433,6 +1433,99 @@ func TestAdjustBlockSize(t *testing.T) {
 	require.EqualValues(t, 16384, cfg.TikvImporter.BlockSize)
 }
 
+func TestAdjustSourceDB(t *testing.T) {
+	newConfig := func() *Config {
+		cfg := NewConfig()
+		assignMinimalLegalValue(cfg)
+		cfg.Mydumper.SourceDir = ""
+		cfg.Mydumper.SourceDB.Host = "127.0.0.1"
+		return cfg
+	}
+	ctx := context.Background()
+
+	cfg := newConfig()
+	require.NoError(t, cfg.Adjust(ctx))
+	require.Equal(t, 3306, cfg.Mydumper.SourceDB.Port)
+	require.Equal(t, SourceConsistencyAuto, cfg.Mydumper.SourceDB.Consistency)
+	require.EqualValues(t, 200000, cfg.Mydumper.SourceDB.RowsPerChunk)
+	require.Equal(t, "mysql://127.0.0.1:3306", cfg.Mydumper.SourceDir)
+	// adjust again
+	require.NoError(t, cfg.Adjust(ctx))
+
+	cfg = newConfig()
+	cfg.Mydumper.SourceDB.Snapshot = "2026-01-01 00:00:00"
+	require.NoError(t, cfg.Adjust(ctx))
+	require.Equal(t, "mysql://127.0.0.1:3306?snapshot=2026-01-01+00%3A00%3A00", cfg.Mydumper.SourceDir)
+	require.Equal(t, "2026-01-01 00:00:00", SourceDBSnapshotFromURL(cfg.Mydumper.SourceDir))
+	// the snapshot is resolved to the TSO by the source
+	cfg.Mydumper.SourceDB.Snapshot = "464371523522494465"
+	require.NoError(t, cfg.Adjust(ctx))
+	require.Equal(t, "464371523522494465", SourceDBSnapshotFromURL(cfg.Mydumper.SourceDir))
+	require.Empty(t, SourceDBSnapshotFromURL("mysql://127.0.0.1:3306"))
+	require.Empty(t, SourceDBSnapshotFromURL("s3://bucket/prefix?snapshot=1"))
+
+	cfg = newConfig()
+	cfg.Mydumper.SourceDir = "file://."
+	require.ErrorContains(t, cfg.Adjust(ctx), "`mydumper.data-source-dir` and `mydumper.source-db` can't be set at the same time")
+
+	cfg = newConfig()
+	cfg.Mydumper.SourceDB.Consistency = "lock"
+	require.ErrorContains(t, cfg.Adjust(ctx), "unsupported `mydumper.source-db.consistency` (lock)")
+
+	cfg = newConfig()
+	cfg.Mydumper.SourceDB.Consistency = SourceConsistencyFlush
+	cfg.Mydumper.SourceDB.Snapshot = "2026-01-01 00:00:00"
+	require.ErrorContains(t, cfg.Adjust(ctx), "`mydumper.source-db.snapshot` can only be used with")
+
+	cfg = newConfig()
+	cfg.Mydumper.FileRouters = []*FileRouteRule{{Pattern: `.*`, Schema: "a", Table: "b", Type: "csv"}}
+	require.ErrorContains(t, cfg.Adjust(ctx), "`mydumper.files` can't be used with `mydumper.source-db`")
+}
+
+func TestAdjustColumnMapping(t *testing.T) {
+	newConfig := func() *Config {
+		cfg := NewConfig()
+		assignMinimalLegalValue(cfg)
+		cfg.Mydumper.CSV.Header = true
+		cfg.Mydumper.CSV.HeaderSchemaMatch = true
+		cfg.Mydumper.ColumnMapping.Enable = true
+		return cfg
+	}
+	ctx := context.Background()
+
+	cfg := newConfig()
+	cfg.Mydumper.ColumnMapping.Aliases = map[string]string{"Full_Name": "NAME"}
+	require.NoError(t, cfg.Adjust(ctx))
+	require.Equal(t, UnknownColumnsError, cfg.Mydumper.ColumnMapping.UnknownColumns)
+	require.Equal(t, map[string]string{"full_name": "name"}, cfg.Mydumper.ColumnMapping.Aliases)
+
+	cfg = newConfig()
+	cfg.Mydumper.ColumnMapping.UnknownColumns = UnknownColumnsOverflow
+	cfg.Mydumper.ColumnMapping.OverflowColumn = "Extra"
+	require.NoError(t, cfg.Adjust(ctx))
+	require.Equal(t, "extra", cfg.Mydumper.ColumnMapping.OverflowColumn)
+
+	cfg = newConfig()
+	cfg.Mydumper.ColumnMapping.UnknownColumns = UnknownColumnsOverflow
+	require.ErrorContains(t, cfg.Adjust(ctx), "`mydumper.column-mapping.overflow-column` must be set")
+
+	cfg = newConfig()
+	cfg.Mydumper.ColumnMapping.OverflowColumn = "extra"
+	require.ErrorContains(t, cfg.Adjust(ctx), "`mydumper.column-mapping.overflow-column` must be set")
+
+	cfg = newConfig()
+	cfg.Mydumper.ColumnMapping.UnknownColumns = "drop"
+	require.ErrorContains(t, cfg.Adjust(ctx), "unsupported `mydumper.column-mapping.unknown-columns` (drop)")
+
+	cfg = newConfig()
+	cfg.Mydumper.ColumnMapping.Aliases = map[string]string{"a": "x", "A": "y"}
+	require.ErrorContains(t, cfg.Adjust(ctx), "duplicated alias a")
+
+	cfg = newConfig()
+	cfg.Mydumper.CSV.HeaderSchemaMatch = false
+	require.ErrorContains(t, cfg.Adjust(ctx), "`mydumper.column-mapping` requires `mydumper.csv.header`")
+}
+
 func TestRedactConfig(t *testing.T) {
 	tests := []struct {
 		origin string
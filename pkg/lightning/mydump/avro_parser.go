@@ -0,0 +1,829 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mydump
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"hash/crc32"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/pkg/lightning/log"
+)
+
+// See https://avro.apache.org/docs/1.11.1/specification/#object-container-files
+const (
+	avroMagic    = "Obj\x01"
+	avroSyncSize = 16
+
+	avroSchemaKey = "avro.schema"
+	avroCodecKey  = "avro.codec"
+
+	avroCodecNull    = "null"
+	avroCodecDeflate = "deflate"
+	avroCodecSnappy  = "snappy"
+	avroCodecZstd    = "zstandard"
+)
+
+// avroMaxZeroWidthItems limits the items of an array whose items are encoded
+// in zero bytes, such as nulls, the block counts of such arrays can't be
+// checked against the remaining data.
+const avroMaxZeroWidthItems = 1 << 20
+
+var errAvroTruncated = errors.New("avro data is truncated")
+
+// avroSchema is the parsed avro schema, named types are resolved to the
+// schemas they reference.
+type avroSchema struct {
+	// one of the primitive types, "record", "enum", "array", "map", "fixed"
+	// or "union".
+	typ         string
+	logicalType string
+	// scale of the decimal logical type.
+	scale int
+	// size of the fixed type.
+	size     int
+	fields   []avroField
+	symbols  []string
+	items    *avroSchema
+	values   *avroSchema
+	branches []*avroSchema
+
+	// minSize is the minimum number of bytes a value is encoded in, it's
+	// computed by encodedSize on demand.
+	minSize      int
+	minSizeKnown bool
+}
+
+// encodedSize returns the minimum number of bytes a value of the schema is
+// encoded in.
+func (s *avroSchema) encodedSize() int {
+	if s.minSizeKnown {
+		return s.minSize
+	}
+	// a recursive reference to the schema is counted as zero bytes.
+	s.minSizeKnown = true
+	var n int
+	switch s.typ {
+	case "null":
+	case "float":
+		n = 4
+	case "double":
+		n = 8
+	case "fixed":
+		n = min(max(s.size, 0), math.MaxInt32)
+	case "record":
+		for _, f := range s.fields {
+			n = min(n+f.schema.encodedSize(), math.MaxInt32)
+		}
+	default:
+		// booleans, varints, and the lengths, counts or indexes which the
+		// other types start with take at least one byte.
+		n = 1
+	}
+	s.minSize = n
+	return n
+}
+
+type avroField struct {
+	name   string
+	schema *avroSchema
+}
+
+func parseAvroSchema(data []byte) (*avroSchema, error) {
+	var raw any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, errors.Annotate(err, "invalid avro schema")
+	}
+	p := avroSchemaParser{named: make(map[string]*avroSchema)}
+	return p.parse(raw, "")
+}
+
+type avroSchemaParser struct {
+	// named maps the full names and the short names to the named types.
+	named map[string]*avroSchema
+}
+
+func (p *avroSchemaParser) parse(raw any, namespace string) (*avroSchema, error) {
+	switch v := raw.(type) {
+	case string:
+		switch v {
+		case "null", "boolean", "int", "long", "float", "double", "bytes", "string":
+			return &avroSchema{typ: v}, nil
+		}
+		if s, ok := p.named[namespace+"."+v]; ok {
+			return s, nil
+		}
+		if s, ok := p.named[v]; ok {
+			return s, nil
+		}
+		return nil, errors.Errorf("unknown avro type '%s'", v)
+	case []any:
+		s := &avroSchema{typ: "union", branches: make([]*avroSchema, 0, len(v))}
+		for _, b := range v {
+			branch, err := p.parse(b, namespace)
+			if err != nil {
+				return nil, err
+			}
+			s.branches = append(s.branches, branch)
+		}
+		return s, nil
+	case map[string]any:
+		typ, ok := v["type"].(string)
+		if !ok {
+			// the type is a complex type, like {"type": {"type": "array", ...}}
+			return p.parse(v["type"], namespace)
+		}
+		var (
+			s   = &avroSchema{typ: typ}
+			err error
+		)
+		switch typ {
+		case "error":
+			s.typ = "record"
+			namespace = p.register(v, namespace, s)
+		case "record", "enum", "fixed":
+			namespace = p.register(v, namespace, s)
+		}
+		switch typ {
+		case "record", "error":
+			fields, _ := v["fields"].([]any)
+			for _, f := range fields {
+				field, _ := f.(map[string]any)
+				name, _ := field["name"].(string)
+				if len(name) == 0 {
+					return nil, errors.New("avro record field must have a name")
+				}
+				fieldSchema, err := p.parse(field["type"], namespace)
+				if err != nil {
+					return nil, err
+				}
+				s.fields = append(s.fields, avroField{name: name, schema: fieldSchema})
+			}
+		case "enum":
+			symbols, _ := v["symbols"].([]any)
+			for _, symbol := range symbols {
+				str, _ := symbol.(string)
+				s.symbols = append(s.symbols, str)
+			}
+		case "fixed":
+			size, _ := v["size"].(float64)
+			s.size = int(size)
+		case "array":
+			if s.items, err = p.parse(v["items"], namespace); err != nil {
+				return nil, err
+			}
+		case "map":
+			if s.values, err = p.parse(v["values"], namespace); err != nil {
+				return nil, err
+			}
+		case "null", "boolean", "int", "long", "float", "double", "bytes", "string":
+		default:
+			return p.parse(typ, namespace)
+		}
+		s.logicalType, _ = v["logicalType"].(string)
+		scale, _ := v["scale"].(float64)
+		s.scale = int(scale)
+		return s, nil
+	default:
+		return nil, errors.Errorf("invalid avro schema %v", raw)
+	}
+}
+
+// register registers the named type, and returns the namespace of it.
+func (p *avroSchemaParser) register(v map[string]any, namespace string, s *avroSchema) string {
+	name, _ := v["name"].(string)
+	if ns, ok := v["namespace"].(string); ok {
+		namespace = ns
+	}
+	if idx := strings.LastIndexByte(name, '.'); idx >= 0 {
+		namespace, name = name[:idx], name[idx+1:]
+	}
+	p.named[namespace+"."+name] = s
+	p.named[name] = s
+	return namespace
+}
+
+// avroDecoder decodes the avro binary encoding.
+type avroDecoder struct {
+	buf []byte
+	off int
+}
+
+func (d *avroDecoder) readLong() (int64, error) {
+	var (
+		u     uint64
+		shift uint
+	)
+	for {
+		if d.off >= len(d.buf) {
+			return 0, errAvroTruncated
+		}
+		b := d.buf[d.off]
+		d.off++
+		u |= uint64(b&0x7f) << shift
+		if b&0x80 == 0 {
+			break
+		}
+		if shift += 7; shift >= 64 {
+			return 0, errors.New("avro varint overflows a 64-bit integer")
+		}
+	}
+	// zig-zag decoding
+	return int64(u>>1) ^ -int64(u&1), nil
+}
+
+func (d *avroDecoder) readFixed(n int) ([]byte, error) {
+	if n < 0 || n > len(d.buf)-d.off {
+		return nil, errAvroTruncated
+	}
+	b := d.buf[d.off : d.off+n]
+	d.off += n
+	return b, nil
+}
+
+func (d *avroDecoder) readBytes() ([]byte, error) {
+	n, err := d.readLong()
+	if err != nil {
+		return nil, err
+	}
+	if n > math.MaxInt32 {
+		return nil, errAvroTruncated
+	}
+	return d.readFixed(int(n))
+}
+
+// readBlockCount reads the item count of a block of arrays and maps.
+func (d *avroDecoder) readBlockCount() (int64, error) {
+	n, err := d.readLong()
+	if err != nil || n >= 0 {
+		return n, err
+	}
+	if n == math.MinInt64 {
+		return 0, errors.Errorf("invalid avro block count %d", n)
+	}
+	// a negative count is followed by the byte size of the block.
+	_, err = d.readLong()
+	return -n, err
+}
+
+// checkBlockCount checks the item count of a block against the remaining
+// data, so that a corrupted count can't make the decoder run for long or
+// allocate without bound. decoded is the number of items decoded by the
+// previous blocks.
+func (d *avroDecoder) checkBlockCount(n int64, itemSize, decoded int) error {
+	if itemSize > 0 {
+		if n > int64((len(d.buf)-d.off)/itemSize) {
+			return errAvroTruncated
+		}
+		return nil
+	}
+	if n > int64(avroMaxZeroWidthItems-decoded) {
+		return errors.Errorf("avro array has more than %d items of zero-width type", avroMaxZeroWidthItems)
+	}
+	return nil
+}
+
+// decode decodes a value of the schema. Records and maps are decoded as
+// map[string]any, arrays are decoded as []any, bytes and strings are decoded
+// as string, values of logical types are decoded as their text.
+func (d *avroDecoder) decode(s *avroSchema) (any, error) {
+	switch s.typ {
+	case "null":
+		return nil, nil
+	case "boolean":
+		b, err := d.readFixed(1)
+		if err != nil {
+			return nil, err
+		}
+		return b[0] != 0, nil
+	case "int", "long":
+		v, err := d.readLong()
+		if err != nil {
+			return nil, err
+		}
+		return avroLongValue(v, s.logicalType), nil
+	case "float":
+		b, err := d.readFixed(4)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float32frombits(binary.LittleEndian.Uint32(b)), nil
+	case "double":
+		b, err := d.readFixed(8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(binary.LittleEndian.Uint64(b)), nil
+	case "bytes", "string", "fixed":
+		var (
+			b   []byte
+			err error
+		)
+		if s.typ == "fixed" {
+			b, err = d.readFixed(s.size)
+		} else {
+			b, err = d.readBytes()
+		}
+		if err != nil {
+			return nil, err
+		}
+		if s.logicalType == "decimal" {
+			if len(b) == 0 {
+				return "0", nil
+			}
+			// binaryToDecimalStr modifies the bytes
+			return binaryToDecimalStr(bytes.Clone(b), s.scale), nil
+		}
+		return string(b), nil
+	case "enum":
+		idx, err := d.readLong()
+		if err != nil {
+			return nil, err
+		}
+		if idx < 0 || idx >= int64(len(s.symbols)) {
+			return nil, errors.Errorf("avro enum index %d out of range", idx)
+		}
+		return s.symbols[idx], nil
+	case "array":
+		var arr []any
+		for {
+			n, err := d.readBlockCount()
+			if err != nil {
+				return nil, err
+			}
+			if n == 0 {
+				return arr, nil
+			}
+			if err := d.checkBlockCount(n, s.items.encodedSize(), len(arr)); err != nil {
+				return nil, err
+			}
+			for range n {
+				item, err := d.decode(s.items)
+				if err != nil {
+					return nil, err
+				}
+				arr = append(arr, item)
+			}
+		}
+	case "map":
+		m := make(map[string]any)
+		for {
+			n, err := d.readBlockCount()
+			if err != nil {
+				return nil, err
+			}
+			if n == 0 {
+				return m, nil
+			}
+			// every entry starts with the length of its key.
+			if err := d.checkBlockCount(n, 1+s.values.encodedSize(), len(m)); err != nil {
+				return nil, err
+			}
+			for range n {
+				key, err := d.readBytes()
+				if err != nil {
+					return nil, err
+				}
+				if m[string(key)], err = d.decode(s.values); err != nil {
+					return nil, err
+				}
+			}
+		}
+	case "record":
+		m := make(map[string]any, len(s.fields))
+		for _, f := range s.fields {
+			v, err := d.decode(f.schema)
+			if err != nil {
+				return nil, errors.Annotatef(err, "field '%s'", f.name)
+			}
+			m[f.name] = v
+		}
+		return m, nil
+	case "union":
+		idx, err := d.readLong()
+		if err != nil {
+			return nil, err
+		}
+		if idx < 0 || idx >= int64(len(s.branches)) {
+			return nil, errors.Errorf("avro union index %d out of range", idx)
+		}
+		return d.decode(s.branches[idx])
+	default:
+		return nil, errors.Errorf("unknown avro type '%s'", s.typ)
+	}
+}
+
+// avroLongValue converts the int and long values of the date and time logical
+// types to their text, the timestamps are in UTC.
+func avroLongValue(v int64, logicalType string) any {
+	switch logicalType {
+	case "date":
+		return time.Unix(v*secPerDay, 0).UTC().Format(time.DateOnly)
+	case "time-millis":
+		return time.UnixMilli(v).UTC().Format("15:04:05.999")
+	case "time-micros":
+		return time.UnixMicro(v).UTC().Format("15:04:05.999999")
+	case "timestamp-millis":
+		return time.UnixMilli(v).UTC().Format(utcTimeLayout)
+	case "timestamp-micros":
+		return time.UnixMicro(v).UTC().Format(utcTimeLayout)
+	case "local-timestamp-millis":
+		return time.UnixMilli(v).UTC().Format(timeLayout)
+	case "local-timestamp-micros":
+		return time.UnixMicro(v).UTC().Format(timeLayout)
+	default:
+		return v
+	}
+}
+
+func readAvroLong(r io.ByteReader) (int64, error) {
+	u, err := binary.ReadUvarint(r)
+	if err != nil {
+		return 0, err
+	}
+	return int64(u>>1) ^ -int64(u&1), nil
+}
+
+// AvroParser parses an avro object container file for import. The rows are
+// the records of the file, which are mapped to columns by field name, see
+// fieldProjector. If the columns are neither set by field paths nor
+// SetColumns, they are the fields of the top-level record.
+//
+// Like ParquetParser, the position of AvroParser is the row number.
+type AvroParser struct {
+	reader    ReadSeekCloser
+	br        *bufio.Reader
+	codec     string
+	sync      [avroSyncSize]byte
+	schema    *avroSchema
+	projector *fieldProjector
+
+	// block holds the decompressed data of current block.
+	block avroDecoder
+	// blockRows is the number of unread rows in current block.
+	blockRows   int64
+	blockBuf    []byte
+	zstdDecoder *zstd.Decoder
+
+	pos     int64
+	lastRow Row
+	logger  log.Logger
+}
+
+// NewAvroParser creates an avro parser. fieldPaths maps the column names to
+// the paths of the nested fields, like `address.city`.
+func NewAvroParser(
+	ctx context.Context,
+	fieldPaths map[string]string,
+	reader ReadSeekCloser,
+) (*AvroParser, error) {
+	projector, err := newFieldProjector(fieldPaths)
+	if err != nil {
+		return nil, err
+	}
+	parser := &AvroParser{
+		reader:    reader,
+		br:        bufio.NewReader(reader),
+		projector: projector,
+		logger:    log.FromContext(ctx),
+	}
+	if err = parser.readHeader(); err != nil {
+		return nil, err
+	}
+	if parser.schema.typ != "record" {
+		return nil, errors.Errorf("the schema of avro file must be a record, got '%s'", parser.schema.typ)
+	}
+	if len(fieldPaths) == 0 {
+		columns := make([]string, 0, len(parser.schema.fields))
+		for _, f := range parser.schema.fields {
+			columns = append(columns, f.name)
+		}
+		projector.setColumns(columns)
+	}
+	return parser, nil
+}
+
+func (p *AvroParser) readHeader() error {
+	magic := make([]byte, len(avroMagic))
+	if _, err := io.ReadFull(p.br, magic); err != nil {
+		return errors.Annotate(err, "read avro header")
+	}
+	if string(magic) != avroMagic {
+		return errors.New("not an avro object container file")
+	}
+	meta := make(map[string][]byte)
+	for {
+		n, err := readAvroLong(p.br)
+		if err != nil {
+			return errors.Annotate(err, "read avro header")
+		}
+		if n == 0 {
+			break
+		}
+		if n < 0 {
+			n = -n
+			if _, err = readAvroLong(p.br); err != nil {
+				return errors.Annotate(err, "read avro header")
+			}
+		}
+		for range n {
+			key, err := p.readHeaderBytes()
+			if err != nil {
+				return err
+			}
+			if meta[string(key)], err = p.readHeaderBytes(); err != nil {
+				return err
+			}
+		}
+	}
+	if _, err := io.ReadFull(p.br, p.sync[:]); err != nil {
+		return errors.Annotate(err, "read avro header")
+	}
+
+	schema, err := parseAvroSchema(meta[avroSchemaKey])
+	if err != nil {
+		return err
+	}
+	p.schema = schema
+	p.codec = string(meta[avroCodecKey])
+	switch p.codec {
+	case "", avroCodecNull, avroCodecDeflate, avroCodecSnappy:
+	case avroCodecZstd:
+		if p.zstdDecoder, err = zstd.NewReader(nil, zstd.WithDecoderConcurrency(1)); err != nil {
+			return errors.Trace(err)
+		}
+	default:
+		return errors.Errorf("unsupported avro codec '%s'", p.codec)
+	}
+	return nil
+}
+
+func (p *AvroParser) readHeaderBytes() ([]byte, error) {
+	n, err := readAvroLong(p.br)
+	if err != nil {
+		return nil, errors.Annotate(err, "read avro header")
+	}
+	if n < 0 || n > math.MaxInt32 {
+		return nil, errors.New("invalid avro header")
+	}
+	b := make([]byte, n)
+	if _, err = io.ReadFull(p.br, b); err != nil {
+		return nil, errors.Annotate(err, "read avro header")
+	}
+	return b, nil
+}
+
+// readBlockHeader reads the row count and the byte size of next block, it
+// returns io.EOF if there are no more blocks.
+func (p *AvroParser) readBlockHeader() (count, size int64, err error) {
+	if count, err = readAvroLong(p.br); err != nil {
+		if err == io.EOF {
+			return 0, 0, io.EOF
+		}
+		return 0, 0, errors.Annotate(err, "read avro block")
+	}
+	if size, err = readAvroLong(p.br); err != nil {
+		return 0, 0, errors.Annotate(err, "read avro block")
+	}
+	if count < 0 || size < 0 || size > math.MaxInt32 {
+		return 0, 0, errors.Errorf("invalid avro block, count: %d, size: %d", count, size)
+	}
+	return count, size, nil
+}
+
+// readBlockData reads and decompresses the data of a block.
+func (p *AvroParser) readBlockData(size int64) error {
+	if int64(cap(p.blockBuf)) < size+avroSyncSize {
+		p.blockBuf = make([]byte, size+avroSyncSize)
+	}
+	buf := p.blockBuf[:size+avroSyncSize]
+	if _, err := io.ReadFull(p.br, buf); err != nil {
+		return errors.Annotate(err, "read avro block")
+	}
+	data, sync := buf[:size], buf[size:]
+	if !bytes.Equal(sync, p.sync[:]) {
+		return errors.New("avro block sync marker mismatch")
+	}
+
+	var err error
+	switch p.codec {
+	case avroCodecDeflate:
+		data, err = io.ReadAll(flate.NewReader(bytes.NewReader(data)))
+	case avroCodecSnappy:
+		// the snappy compressed data is followed by the CRC32 checksum of the
+		// uncompressed data.
+		if len(data) < 4 {
+			return errAvroTruncated
+		}
+		checksum := binary.BigEndian.Uint32(data[len(data)-4:])
+		if data, err = snappy.Decode(nil, data[:len(data)-4]); err == nil && crc32.ChecksumIEEE(data) != checksum {
+			err = errors.New("avro block checksum mismatch")
+		}
+	case avroCodecZstd:
+		data, err = p.zstdDecoder.DecodeAll(data, nil)
+	}
+	if err != nil {
+		return errors.Annotate(err, "decompress avro block")
+	}
+	p.block = avroDecoder{buf: data}
+	return nil
+}
+
+// skipBlockData skips the data of a block without reading it if possible.
+func (p *AvroParser) skipBlockData(size int64) error {
+	n := size + avroSyncSize
+	if buffered := int64(p.br.Buffered()); n > buffered {
+		if _, err := p.reader.Seek(n-buffered, io.SeekCurrent); err != nil {
+			return errors.Trace(err)
+		}
+		p.br.Reset(p.reader)
+		return nil
+	}
+	_, err := p.br.Discard(int(n))
+	return errors.Trace(err)
+}
+
+// nextBlock moves to next non-empty block if all rows of current block are read.
+func (p *AvroParser) nextBlock() error {
+	for p.blockRows == 0 {
+		count, size, err := p.readBlockHeader()
+		if err != nil {
+			return err
+		}
+		if err = p.readBlockData(size); err != nil {
+			return err
+		}
+		p.blockRows = count
+	}
+	return nil
+}
+
+// Pos returns the currently row number of the avro file
+func (p *AvroParser) Pos() (pos int64, rowID int64) {
+	return p.pos, p.lastRow.RowID
+}
+
+// SetPos sets the position in an avro file.
+// It implements the Parser interface.
+func (p *AvroParser) SetPos(pos int64, rowID int64) error {
+	if pos < p.pos {
+		return errors.Errorf("avro parser can't seek back, current: %d, required: %d", p.pos, pos)
+	}
+	for p.pos < pos {
+		if p.blockRows == 0 {
+			count, size, err := p.readBlockHeader()
+			if err != nil {
+				return err
+			}
+			if p.pos+count <= pos {
+				if err = p.skipBlockData(size); err != nil {
+					return err
+				}
+				p.pos += count
+				continue
+			}
+			if err = p.readBlockData(size); err != nil {
+				return err
+			}
+			p.blockRows = count
+		}
+		if _, err := p.block.decode(p.schema); err != nil {
+			return errors.Trace(err)
+		}
+		p.blockRows--
+		p.pos++
+	}
+	p.lastRow.RowID = rowID
+	return nil
+}
+
+// ScannedPos implements the Parser interface.
+// For avro it's avro file's reader current position.
+func (p *AvroParser) ScannedPos() (int64, error) {
+	return p.reader.Seek(0, io.SeekCurrent)
+}
+
+// Close closes the avro file of the parser.
+// It implements the Parser interface.
+func (p *AvroParser) Close() error {
+	if p.zstdDecoder != nil {
+		p.zstdDecoder.Close()
+	}
+	return p.reader.Close()
+}
+
+// ReadRow reads a row in the avro file by the parser.
+// It implements the Parser interface.
+func (p *AvroParser) ReadRow() error {
+	if err := p.nextBlock(); err != nil {
+		return err
+	}
+	start := p.block.off
+	record, err := p.block.decode(p.schema)
+	if err != nil {
+		return errors.Annotatef(err, "decode avro row %d", p.pos)
+	}
+	p.blockRows--
+	p.pos++
+
+	row := &p.lastRow
+	row.RowID++
+	row.Length = p.block.off - start
+	// the top-level schema is checked to be a record
+	fields, _ := record.(map[string]any)
+	row.Row, err = p.projector.project(row.Row, fields)
+	return errors.Trace(err)
+}
+
+// LastRow gets the last row parsed by the parser.
+// It implements the Parser interface.
+func (p *AvroParser) LastRow() Row {
+	return p.lastRow
+}
+
+// RecycleRow implements the Parser interface.
+func (*AvroParser) RecycleRow(_ Row) {
+}
+
+// Columns returns the _lower-case_ column names corresponding to values in
+// the LastRow.
+func (p *AvroParser) Columns() []string {
+	return p.projector.columns
+}
+
+// SetColumns sets the columns to project the rows to, the fields which are not
+// mapped to these columns are ignored.
+func (p *AvroParser) SetColumns(columns []string) {
+	p.projector.setColumns(columns)
+}
+
+// SetLogger sets the logger used in the parser.
+// It implements the Parser interface.
+func (p *AvroParser) SetLogger(l log.Logger) {
+	p.logger = l
+}
+
+// SetRowID sets the rowID in an avro file when we start a compressed file.
+// It implements the Parser interface.
+func (p *AvroParser) SetRowID(rowID int64) {
+	p.lastRow.RowID = rowID
+}
+
+// ReadAvroFileRowCount reads the row count of an avro file by the headers of
+// the blocks, the data of the blocks are skipped.
+func ReadAvroFileRowCount(
+	ctx context.Context,
+	store storage.ExternalStorage,
+	fileMeta SourceFileMeta,
+) (int64, error) {
+	r, err := store.Open(ctx, fileMeta.Path, nil)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	parser, err := NewAvroParser(ctx, nil, r)
+	if err != nil {
+		_ = r.Close()
+		return 0, err
+	}
+	//nolint: errcheck
+	defer parser.Close()
+
+	var rows int64
+	for {
+		count, size, err := parser.readBlockHeader()
+		if err != nil {
+			if err == io.EOF {
+				return rows, nil
+			}
+			return 0, err
+		}
+		if err = parser.skipBlockData(size); err != nil {
+			return 0, err
+		}
+		rows += count
+	}
+}
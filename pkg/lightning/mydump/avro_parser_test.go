@@ -0,0 +1,266 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mydump
+
+import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"math"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+const testAvroSchema = `{
+	"type": "record", "name": "Event", "namespace": "com.example",
+	"fields": [
+		{"name": "id", "type": "long"},
+		{"name": "Name", "type": ["null", "string"]},
+		{"name": "amount", "type": {"type": "bytes", "logicalType": "decimal", "precision": 10, "scale": 2}},
+		{"name": "ts", "type": {"type": "long", "logicalType": "timestamp-micros"}},
+		{"name": "day", "type": {"type": "int", "logicalType": "date"}},
+		{"name": "ok", "type": "boolean"},
+		{"name": "ratio", "type": "double"},
+		{"name": "kind", "type": {"type": "enum", "name": "Kind", "symbols": ["A", "B"]}},
+		{"name": "address", "type": {"type": "record", "name": "Address", "fields": [
+			{"name": "city", "type": "string"},
+			{"name": "zip", "type": "int"}
+		]}},
+		{"name": "tags", "type": {"type": "array", "items": "string"}},
+		{"name": "attrs", "type": {"type": "map", "values": "long"}},
+		{"name": "prev", "type": ["null", "com.example.Address"]}
+	]
+}`
+
+func appendAvroLong(b []byte, v int64) []byte {
+	return binary.AppendUvarint(b, uint64((v<<1)^(v>>63)))
+}
+
+func appendAvroBytes(b []byte, data []byte) []byte {
+	return append(appendAvroLong(b, int64(len(data))), data...)
+}
+
+// encodeTestAvroRow encodes the row i of testAvroSchema.
+func encodeTestAvroRow(b []byte, i int64) []byte {
+	b = appendAvroLong(b, i)
+	if i%2 == 0 {
+		b = appendAvroBytes(appendAvroLong(b, 1), []byte("n"+strconv.FormatInt(i, 10)))
+	} else {
+		b = appendAvroLong(b, 0)
+	}
+	// amount: 123.45 or -0.05
+	if i%2 == 0 {
+		b = appendAvroBytes(b, []byte{0x30, 0x39})
+	} else {
+		b = appendAvroBytes(b, []byte{0xfb})
+	}
+	b = appendAvroLong(b, 1_700_000_000_000_000+i)
+	b = appendAvroLong(b, 19000)
+	b = append(b, 1)
+	b = binary.LittleEndian.AppendUint64(b, math.Float64bits(0.5))
+	b = appendAvroLong(b, 1)
+	// address
+	b = appendAvroBytes(b, []byte("city"+strconv.FormatInt(i, 10)))
+	b = appendAvroLong(b, 1000+i)
+	// tags
+	b = appendAvroLong(b, 2)
+	b = appendAvroBytes(b, []byte("x"))
+	b = appendAvroBytes(b, []byte("y"))
+	b = appendAvroLong(b, 0)
+	// attrs, a block with negative count is followed by its size
+	entry := appendAvroLong(appendAvroBytes(nil, []byte("k")), 7)
+	b = appendAvroLong(b, -1)
+	b = appendAvroLong(b, int64(len(entry)))
+	b = append(b, entry...)
+	b = appendAvroLong(b, 0)
+	// prev
+	b = appendAvroLong(b, 1)
+	b = appendAvroBytes(b, []byte("prev"))
+	return appendAvroLong(b, 1)
+}
+
+// writeTestAvroFile writes an avro file, the rows are split into blocks by blockRows.
+func writeTestAvroFile(t *testing.T, codec string, blockRows []int) []byte {
+	sync := []byte("0123456789abcdef")
+	b := []byte(avroMagic)
+	b = appendAvroLong(b, 2)
+	b = appendAvroBytes(b, []byte(avroSchemaKey))
+	b = appendAvroBytes(b, []byte(testAvroSchema))
+	b = appendAvroBytes(b, []byte(avroCodecKey))
+	b = appendAvroBytes(b, []byte(codec))
+	b = appendAvroLong(b, 0)
+	b = append(b, sync...)
+
+	var id int64
+	for _, rows := range blockRows {
+		var data []byte
+		for range rows {
+			data = encodeTestAvroRow(data, id)
+			id++
+		}
+		switch codec {
+		case avroCodecDeflate:
+			var buf bytes.Buffer
+			w, err := flate.NewWriter(&buf, flate.DefaultCompression)
+			require.NoError(t, err)
+			_, err = w.Write(data)
+			require.NoError(t, err)
+			require.NoError(t, w.Close())
+			data = buf.Bytes()
+		case avroCodecSnappy:
+			checksum := crc32.ChecksumIEEE(data)
+			data = binary.BigEndian.AppendUint32(snappy.Encode(nil, data), checksum)
+		case avroCodecZstd:
+			enc, err := zstd.NewWriter(nil)
+			require.NoError(t, err)
+			data = enc.EncodeAll(data, nil)
+			require.NoError(t, enc.Close())
+		}
+		b = appendAvroLong(b, int64(rows))
+		b = appendAvroBytes(b, data)
+		b = append(b, sync...)
+	}
+	return b
+}
+
+func TestAvroParser(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store, err := storage.NewLocalStorage(dir)
+	require.NoError(t, err)
+
+	ts := time.UnixMicro(1_700_000_000_000_000).UTC().Format(utcTimeLayout)
+	day := time.Unix(19000*secPerDay, 0).UTC().Format(time.DateOnly)
+	for _, codec := range []string{avroCodecNull, avroCodecDeflate, avroCodecSnappy, avroCodecZstd} {
+		name := codec + ".avro"
+		// the second block is empty
+		require.NoError(t, store.WriteFile(ctx, name, writeTestAvroFile(t, codec, []int{3, 0, 2})))
+
+		rows, err := ReadAvroFileRowCount(ctx, store, SourceFileMeta{Path: name})
+		require.NoError(t, err)
+		require.Equal(t, int64(5), rows, codec)
+
+		r, err := store.Open(ctx, name, nil)
+		require.NoError(t, err)
+		parser, err := NewAvroParser(ctx, nil, r)
+		require.NoError(t, err)
+		require.Equal(t, []string{"id", "name", "amount", "ts", "day", "ok", "ratio", "kind", "address", "tags", "attrs", "prev"},
+			parser.Columns())
+
+		require.NoError(t, parser.ReadRow())
+		require.Equal(t, []types.Datum{
+			types.NewIntDatum(0),
+			types.NewCollationStringDatum("n0", "utf8mb4_bin"),
+			types.NewCollationStringDatum("123.45", "utf8mb4_bin"),
+			types.NewCollationStringDatum(ts, "utf8mb4_bin"),
+			types.NewCollationStringDatum(day, "utf8mb4_bin"),
+			types.NewUintDatum(1),
+			types.NewFloat64Datum(0.5),
+			types.NewCollationStringDatum("B", "utf8mb4_bin"),
+			types.NewCollationStringDatum(`{"city":"city0","zip":1000}`, "utf8mb4_bin"),
+			types.NewCollationStringDatum(`["x","y"]`, "utf8mb4_bin"),
+			types.NewCollationStringDatum(`{"k":7}`, "utf8mb4_bin"),
+			types.NewCollationStringDatum(`{"city":"prev","zip":1}`, "utf8mb4_bin"),
+		}, parser.LastRow().Row, codec)
+		require.NoError(t, parser.ReadRow())
+		require.True(t, parser.LastRow().Row[1].IsNull())
+		require.Equal(t, "-0.05", parser.LastRow().Row[2].GetString())
+		pos, rowID := parser.Pos()
+		require.Equal(t, int64(2), pos)
+		require.Equal(t, int64(2), rowID)
+		require.NoError(t, parser.Close())
+
+		// seek to the row in the last block, and project the nested fields.
+		r, err = store.Open(ctx, name, nil)
+		require.NoError(t, err)
+		parser, err = NewAvroParser(ctx, map[string]string{
+			"id":       "id",
+			"city":     "address.city",
+			"tag":      "tags.1",
+			"k":        "attrs.k",
+			"prev_zip": "prev.zip",
+		}, r)
+		require.NoError(t, err)
+		require.Equal(t, []string{"city", "id", "k", "prev_zip", "tag"}, parser.Columns())
+		require.NoError(t, parser.SetPos(4, 10))
+		require.NoError(t, parser.ReadRow())
+		require.Equal(t, []types.Datum{
+			types.NewCollationStringDatum("city4", "utf8mb4_bin"),
+			types.NewIntDatum(4),
+			types.NewIntDatum(7),
+			types.NewIntDatum(1),
+			types.NewCollationStringDatum("y", "utf8mb4_bin"),
+		}, parser.LastRow().Row, codec)
+		pos, rowID = parser.Pos()
+		require.Equal(t, int64(5), pos)
+		require.Equal(t, int64(11), rowID)
+		require.ErrorIs(t, errors.Cause(parser.ReadRow()), io.EOF)
+		require.Error(t, parser.SetPos(1, 0))
+		require.NoError(t, parser.Close())
+	}
+
+	_, err = NewAvroParser(ctx, nil, NewStringReader("not an avro file"))
+	require.ErrorContains(t, err, "not an avro object container file")
+}
+
+func TestAvroDecodeCorruptedBlockCount(t *testing.T) {
+	decode := func(schema string, data []byte) (any, error) {
+		s, err := parseAvroSchema([]byte(schema))
+		require.NoError(t, err)
+		d := avroDecoder{buf: data}
+		return d.decode(s)
+	}
+
+	// the counts exceed the remaining bytes.
+	_, err := decode(`{"type": "array", "items": "long"}`, appendAvroLong(nil, math.MaxInt64))
+	require.ErrorIs(t, err, errAvroTruncated)
+	_, err = decode(`{"type": "array", "items": "double"}`, append(appendAvroLong(nil, 2), make([]byte, 15)...))
+	require.ErrorIs(t, err, errAvroTruncated)
+	_, err = decode(`{"type": "map", "values": "null"}`, appendAvroLong(nil, 1<<40))
+	require.ErrorIs(t, err, errAvroTruncated)
+	_, err = decode(`{"type": "array", "items": "long"}`, appendAvroLong(nil, math.MinInt64))
+	require.ErrorContains(t, err, "invalid avro block count")
+
+	// the counts of zero-width items are capped.
+	data := appendAvroLong(nil, 3)
+	data = appendAvroLong(data, 0)
+	v, err := decode(`{"type": "array", "items": "null"}`, data)
+	require.NoError(t, err)
+	require.Equal(t, []any{nil, nil, nil}, v)
+	data = appendAvroLong(nil, avroMaxZeroWidthItems)
+	data = appendAvroLong(data, 1)
+	_, err = decode(`{"type": "array", "items": {"type": "record", "name": "R", "fields": []}}`, data)
+	require.ErrorContains(t, err, "zero-width")
+
+	// the fields of a record are counted.
+	data = appendAvroLong(nil, 2)
+	data = appendAvroLong(data, 1)
+	data = appendAvroBytes(data, []byte("a"))
+	_, err = decode(`{"type": "array", "items": {"type": "record", "name": "R", "fields": [
+		{"name": "id", "type": "long"}, {"name": "name", "type": "string"}
+	]}}`, data)
+	require.ErrorIs(t, err, errAvroTruncated)
+}
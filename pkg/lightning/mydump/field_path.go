@@ -0,0 +1,159 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mydump
+
+import (
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/types"
+)
+
+// fieldProjector maps the fields of structured rows, such as NDJSON and avro
+// rows, to columns by field name. A column can be mapped to a nested field by
+// a dot-separated path like `address.city`, a segment of the path which is an
+// integer indexes into an array, like `tags.0`. The field names are matched
+// case-insensitively.
+type fieldProjector struct {
+	// paths maps the lower-case column name to the path of the field.
+	paths map[string][]string
+	// columns are the lower-case column names of the projected rows. if they
+	// are empty, the columns are the top-level fields of the first row.
+	columns []string
+}
+
+func newFieldProjector(fieldPaths map[string]string) (*fieldProjector, error) {
+	p := &fieldProjector{
+		paths:   make(map[string][]string, len(fieldPaths)),
+		columns: make([]string, 0, len(fieldPaths)),
+	}
+	for col, path := range fieldPaths {
+		segments := strings.Split(path, ".")
+		if len(col) == 0 || slices.Contains(segments, "") {
+			return nil, errors.Errorf("invalid field path '%s' of column '%s'", path, col)
+		}
+		col = strings.ToLower(col)
+		p.paths[col] = segments
+		p.columns = append(p.columns, col)
+	}
+	slices.Sort(p.columns)
+	return p, nil
+}
+
+func (p *fieldProjector) setColumns(columns []string) {
+	p.columns = make([]string, 0, len(columns))
+	for _, col := range columns {
+		p.columns = append(p.columns, strings.ToLower(col))
+	}
+}
+
+// project fills the row with the values of the columns in the record, the
+// value of a missing field is NULL.
+func (p *fieldProjector) project(row []types.Datum, record map[string]any) ([]types.Datum, error) {
+	if cap(row) < len(p.columns) {
+		row = make([]types.Datum, len(p.columns))
+	}
+	row = row[:len(p.columns)]
+	for i, col := range p.columns {
+		path, ok := p.paths[col]
+		if !ok {
+			path = []string{col}
+		}
+		v, _ := lookupFieldPath(record, path)
+		// the row may be reused, reset the datum to avoid keeping the old value.
+		row[i] = types.Datum{}
+		if err := setDatumByFieldValue(&row[i], v); err != nil {
+			return nil, errors.Annotatef(err, "column '%s'", col)
+		}
+	}
+	return row, nil
+}
+
+func lookupFieldPath(v any, path []string) (any, bool) {
+	for _, segment := range path {
+		switch vv := v.(type) {
+		case map[string]any:
+			field, ok := vv[segment]
+			if !ok {
+				for key, value := range vv {
+					if strings.EqualFold(key, segment) {
+						field, ok = value, true
+						break
+					}
+				}
+			}
+			if !ok {
+				return nil, false
+			}
+			v = field
+		case []any:
+			idx, err := strconv.Atoi(segment)
+			if err != nil || idx < 0 || idx >= len(vv) {
+				return nil, false
+			}
+			v = vv[idx]
+		default:
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+// setDatumByFieldValue converts a value decoded from NDJSON or avro files to
+// Datum, objects and arrays are converted to JSON text.
+func setDatumByFieldValue(d *types.Datum, v any) error {
+	switch vv := v.(type) {
+	case nil:
+		d.SetNull()
+	case bool:
+		if vv {
+			d.SetUint64(1)
+		} else {
+			d.SetUint64(0)
+		}
+	case int32:
+		d.SetInt64(int64(vv))
+	case int64:
+		d.SetInt64(vv)
+	case float32:
+		d.SetFloat32(vv)
+	case float64:
+		d.SetFloat64(vv)
+	case json.Number:
+		s := vv.String()
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			d.SetInt64(i)
+		} else if u, err := strconv.ParseUint(s, 10, 64); err == nil {
+			d.SetUint64(u)
+		} else {
+			// keep the text of decimals and floats to avoid losing precision
+			d.SetString(s, "utf8mb4_bin")
+		}
+	case string:
+		d.SetString(vv, "utf8mb4_bin")
+	case map[string]any, []any:
+		bs, err := json.Marshal(vv)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		d.SetString(string(bs), "utf8mb4_bin")
+	default:
+		return errors.Errorf("unknown value: %v", v)
+	}
+	return nil
+}
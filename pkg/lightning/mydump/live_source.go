@@ -0,0 +1,1140 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mydump
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/pkg/lightning/common"
+	"github.com/pingcap/tidb/pkg/lightning/config"
+	"github.com/pingcap/tidb/pkg/lightning/log"
+	"github.com/pingcap/tidb/pkg/types"
+	filter "github.com/pingcap/tidb/pkg/util/table-filter"
+	"github.com/tikv/client-go/v2/oracle"
+	pd "github.com/tikv/pd/client"
+	"github.com/tikv/pd/client/pkg/caller"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	liveFileExt = ".live"
+	// liveExtraHandleName is the name of the hidden row ID column of the TiDB
+	// tables without a clustered index.
+	liveExtraHandleName = "_tidb_rowid"
+	// liveDefaultRowSize is the estimated size of a row if the source database
+	// doesn't know the average row length of a table.
+	liveDefaultRowSize = 128
+	// liveServiceSafePointTTL is the TTL in seconds of the service GC safe
+	// point which keeps the snapshot of the source TiDB, it's updated every
+	// 1/3 of the TTL.
+	liveServiceSafePointTTL = 10 * 60
+	// liveGCSafePointFormat is the format of `tikv_gc_safe_point` in `mysql.tidb`.
+	liveGCSafePointFormat = "20060102-15:04:05.999 -0700"
+)
+
+// liveFileRouteRules routes the files generated by LiveSource, the schema
+// files are routed by the default rules.
+var liveFileRouteRules = []*config.FileRouteRule{
+	// range file pattern, matches files like '{schema}.{table}.00001.live'
+	{Pattern: `(?i)^(?:[^/]*/)*([^/.]+)\.(.*?)\.([0-9]+)\.live$`,
+		Schema: "$1", Table: "$2", Type: TypeLive, Key: "$3", Unescape: true},
+}
+
+// liveTableRange is a range of a table in the source database, it's stored as
+// the content of a `.live` file in LiveSource.
+type liveTableRange struct {
+	DB      string   `json:"db"`
+	Table   string   `json:"table"`
+	Columns []string `json:"columns"`
+	// OrderBy is the columns to sort the rows, so that the rows read before
+	// can be skipped when resuming from a checkpoint.
+	OrderBy []string `json:"order-by,omitempty"`
+	// SplitColumn is the integer column of the range, the range is
+	// [Lower, Upper) and the nil bounds are unlimited.
+	SplitColumn string `json:"split-column,omitempty"`
+	Lower       *int64 `json:"lower,omitempty"`
+	Upper       *int64 `json:"upper,omitempty"`
+	// Rows is the exact row count of the range under the snapshot.
+	Rows int64 `json:"rows"`
+	// Size is the estimated data size of the range.
+	Size int64 `json:"size"`
+}
+
+// writeWhere writes the condition of the range, the rows whose keys are not
+// greater than `after` are excluded if it's not nil.
+func (r *liveTableRange) writeWhere(sb *strings.Builder, after *int64, args []any) []any {
+	sep := " WHERE "
+	writeCond := func(op string, v int64) {
+		sb.WriteString(sep)
+		common.WriteMySQLIdentifier(sb, r.SplitColumn)
+		sb.WriteString(op)
+		args = append(args, v)
+		sep = " AND "
+	}
+	if after != nil {
+		writeCond(" > ?", *after)
+	} else if r.Lower != nil {
+		writeCond(" >= ?", *r.Lower)
+	}
+	if r.Upper != nil {
+		writeCond(" < ?", *r.Upper)
+	}
+	return args
+}
+
+func (r *liveTableRange) writeOrderBy(sb *strings.Builder) {
+	if len(r.OrderBy) == 0 {
+		return
+	}
+	sb.WriteString(" ORDER BY ")
+	for i, col := range r.OrderBy {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		common.WriteMySQLIdentifier(sb, col)
+	}
+}
+
+// countQuery builds the query to count the rows of the range.
+func (r *liveTableRange) countQuery() (string, []any) {
+	var sb strings.Builder
+	sb.WriteString("SELECT COUNT(*) FROM ")
+	sb.WriteString(common.UniqueTable(r.DB, r.Table))
+	args := r.writeWhere(&sb, nil, nil)
+	return sb.String(), args
+}
+
+// keyQuery builds the query to read the split column of the row at `offset`
+// of the range, only the split column is read to skip the rows before it.
+func (r *liveTableRange) keyQuery(offset int64) (string, []any) {
+	var sb strings.Builder
+	sb.WriteString("SELECT ")
+	common.WriteMySQLIdentifier(&sb, r.SplitColumn)
+	sb.WriteString(" FROM ")
+	sb.WriteString(common.UniqueTable(r.DB, r.Table))
+	args := r.writeWhere(&sb, nil, nil)
+	r.writeOrderBy(&sb)
+	sb.WriteString(" LIMIT ?, 1")
+	return sb.String(), append(args, offset)
+}
+
+// selectQuery builds the query to read the columns of the rows of the range.
+// The rows whose split column are not greater than `after` are skipped if it's
+// not nil, otherwise the first `offset` rows are skipped.
+func (r *liveTableRange) selectQuery(columns []string, after *int64, offset int64) (string, []any) {
+	var sb strings.Builder
+	sb.WriteString("SELECT ")
+	for i, col := range columns {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		common.WriteMySQLIdentifier(&sb, col)
+	}
+	sb.WriteString(" FROM ")
+	sb.WriteString(common.UniqueTable(r.DB, r.Table))
+	args := r.writeWhere(&sb, after, nil)
+	r.writeOrderBy(&sb)
+	if after == nil && offset > 0 {
+		sb.WriteString(" LIMIT ?, 18446744073709551615")
+		args = append(args, offset)
+	}
+	return sb.String(), args
+}
+
+// liveTable is the information of a table in the source database.
+type liveTable struct {
+	db           string
+	name         string
+	rows         int64
+	avgRowLength int64
+	// pkType is the TIDB_PK_TYPE of the table if the source is TiDB.
+	pkType     string
+	columns    []string
+	dataTypes  []string
+	primaryKey []string
+}
+
+func (t *liveTable) dataType(column string) string {
+	for i, col := range t.columns {
+		if strings.EqualFold(col, column) {
+			return t.dataTypes[i]
+		}
+	}
+	return ""
+}
+
+func isLiveIntegerType(dataType string) bool {
+	switch strings.ToLower(dataType) {
+	case "tinyint", "smallint", "mediumint", "int", "integer", "bigint":
+		return true
+	}
+	return false
+}
+
+// escapeLiveName escapes the name of a database or table to a part of a file
+// name, which is unescaped by the file routers.
+func escapeLiveName(name string) string {
+	return strings.ReplaceAll(url.PathEscape(name), ".", "%2E")
+}
+
+// LiveSource is a storage of the tables in an upstream MySQL compatible
+// database. On creation, it reads the schemas and splits the tables into
+// ranges under a consistent snapshot, which are presented as the files of a
+// dumpling export, so that they're loaded by MDLoader as usual. The data of
+// the ranges are read by LiveParser from the database directly.
+type LiveSource struct {
+	*storage.MemStorage
+
+	cfg    *config.SourceDB
+	db     *sql.DB
+	isTiDB bool
+	// allConns are the connections which read the same snapshot, conns holds
+	// the idle ones.
+	allConns []*sql.Conn
+	conns    chan *sql.Conn
+	// stopGC stops keeping the snapshot of the source TiDB from GC, it's nil if
+	// the snapshot isn't kept.
+	stopGC func()
+
+	mu    sync.Mutex
+	sizes map[string]int64
+}
+
+// NewLiveSource connects to the source database of the config and creates a
+// LiveSource of it.
+func NewLiveSource(ctx context.Context, cfg *config.Config) (*LiveSource, error) {
+	src := &cfg.Mydumper.SourceDB
+	param := common.MySQLConnectParam{
+		Host:     src.Host,
+		Port:     src.Port,
+		User:     src.User,
+		Password: src.Psw,
+		SQLMode:  "''",
+		Vars:     src.Vars,
+	}
+	if src.Security != nil {
+		param.TLSConfig = src.Security.TLSConfig
+		param.AllowFallbackToPlaintext = src.Security.AllowFallbackToPlaintext
+	}
+	db, err := param.Connect()
+	if err != nil {
+		return nil, errors.Annotate(err, "connect to the source database")
+	}
+	s, err := newLiveSource(ctx, db, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// newLiveSource creates a LiveSource of the connected source database, the db
+// is closed when the LiveSource is closed or on error. The snapshot of the
+// source TiDB is resolved to a TSO in the config, and the data source dir of the
+// config is set to the URL with the snapshot, so that it's saved in the
+// checkpoints and reused on resuming.
+func newLiveSource(ctx context.Context, db *sql.DB, cfg *config.Config) (*LiveSource, error) {
+	s := &LiveSource{
+		MemStorage: storage.NewMemStorage(),
+		cfg:        &cfg.Mydumper.SourceDB,
+		db:         db,
+		sizes:      make(map[string]int64),
+	}
+	var version string
+	if err := db.QueryRowContext(ctx, "SELECT VERSION()").Scan(&version); err != nil {
+		s.Close()
+		return nil, errors.Annotate(err, "get the version of the source database")
+	}
+	s.isTiDB = strings.Contains(strings.ToLower(version), "tidb")
+
+	if err := s.openConns(ctx, max(cfg.App.RegionConcurrency, 1)); err != nil {
+		s.Close()
+		return nil, err
+	}
+	cfg.Mydumper.SourceDir = s.cfg.URL()
+	if err := s.dump(ctx, cfg); err != nil {
+		s.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// queryMasterStatus returns the first row of SHOW MASTER STATUS by the column
+// names, it's empty if the binlog is disabled.
+func queryMasterStatus(ctx context.Context, conn *sql.Conn) (map[string]string, error) {
+	rows, err := conn.QueryContext(ctx, "SHOW MASTER STATUS")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	//nolint: errcheck
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	status := make(map[string]string, len(columns))
+	if rows.Next() {
+		values := make([]sql.NullString, len(columns))
+		dest := make([]any, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err = rows.Scan(dest...); err != nil {
+			return nil, errors.Trace(err)
+		}
+		for i, col := range columns {
+			status[col] = values[i].String
+		}
+	}
+	return status, errors.Trace(rows.Err())
+}
+
+// openConns opens n connections which read the same snapshot of the source
+// database.
+func (s *LiveSource) openConns(ctx context.Context, n int) error {
+	logger := log.FromContext(ctx)
+	consistency := s.cfg.Consistency
+	if consistency == config.SourceConsistencyAuto {
+		consistency = config.SourceConsistencyFlush
+		if s.isTiDB {
+			consistency = config.SourceConsistencySnapshot
+		}
+	}
+
+	var initStmts []string
+	switch consistency {
+	case config.SourceConsistencySnapshot:
+		if !s.isTiDB {
+			return common.ErrInvalidConfig.GenWithStack(
+				"`mydumper.source-db.consistency` %s is only supported by TiDB", config.SourceConsistencySnapshot)
+		}
+		conn, err := s.db.Conn(ctx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		snapshotTS, err := resolveLiveSnapshot(ctx, conn, s.cfg.Snapshot)
+		if err == nil {
+			err = s.keepSnapshot(ctx, conn, snapshotTS)
+		}
+		_ = conn.Close()
+		if err != nil {
+			return err
+		}
+		s.cfg.Snapshot = strconv.FormatUint(snapshotTS, 10)
+		logger.Info("read the source TiDB under a snapshot", zap.String("snapshot", s.cfg.Snapshot))
+		initStmts = []string{"SET SESSION tidb_snapshot = " + s.cfg.Snapshot}
+	case config.SourceConsistencyFlush:
+		lockConn, err := s.db.Conn(ctx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		//nolint: errcheck
+		defer lockConn.Close()
+		if _, err = lockConn.ExecContext(ctx, "FLUSH TABLES WITH READ LOCK"); err != nil {
+			return errors.Annotatef(err, "lock the tables of the source database, set `mydumper.source-db.consistency` to %s "+
+				"if the user has no RELOAD privilege", config.SourceConsistencyNone)
+		}
+		defer func() {
+			if _, err := lockConn.ExecContext(context.Background(), "UNLOCK TABLES"); err != nil {
+				logger.Warn("failed to unlock the tables of the source database", zap.Error(err))
+			}
+		}()
+		if status, err := queryMasterStatus(ctx, lockConn); err != nil {
+			logger.Warn("failed to get the binlog position of the source database", zap.Error(err))
+		} else {
+			logger.Info("read the source database at a binlog position",
+				zap.String("file", status["File"]), zap.String("position", status["Position"]),
+				zap.String("gtid", status["Executed_Gtid_Set"]))
+		}
+		initStmts = []string{
+			"SET SESSION TRANSACTION ISOLATION LEVEL REPEATABLE READ",
+			"START TRANSACTION /*!40108 WITH CONSISTENT SNAPSHOT */",
+		}
+	default:
+		logger.Warn("read the source database without a consistent snapshot")
+	}
+
+	s.conns = make(chan *sql.Conn, n)
+	for range n {
+		conn, err := s.db.Conn(ctx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		s.allConns = append(s.allConns, conn)
+		for _, stmt := range initStmts {
+			if _, err = conn.ExecContext(ctx, stmt); err != nil {
+				return errors.Annotatef(err, "init the connection to the source database by %q", stmt)
+			}
+		}
+		s.conns <- conn
+	}
+	return nil
+}
+
+// resolveLiveSnapshot returns the TSO of the snapshot, which is the TSO or the
+// time of the snapshot, or empty for the current TSO.
+func resolveLiveSnapshot(ctx context.Context, conn *sql.Conn, snapshot string) (uint64, error) {
+	if len(snapshot) == 0 {
+		status, err := queryMasterStatus(ctx, conn)
+		if err != nil {
+			return 0, errors.Annotate(err, "get the current TSO of the source TiDB")
+		}
+		snapshot = status["Position"]
+		if len(snapshot) == 0 {
+			return 0, errors.New("failed to get the current TSO of the source TiDB")
+		}
+	}
+	if ts, err := strconv.ParseUint(snapshot, 10, 64); err == nil {
+		return ts, nil
+	}
+	// the time is parsed in the time zone of the session like `tidb_snapshot`
+	var unixTime sql.NullString
+	if err := conn.QueryRowContext(ctx, "SELECT UNIX_TIMESTAMP(?)", snapshot).Scan(&unixTime); err != nil {
+		return 0, errors.Annotatef(err, "parse the snapshot %s of the source TiDB", snapshot)
+	}
+	sec, err := strconv.ParseFloat(unixTime.String, 64)
+	if !unixTime.Valid || err != nil {
+		return 0, common.ErrInvalidConfig.GenWithStack(
+			"invalid `mydumper.source-db.snapshot` %s, please use a TSO or a time like '2006-01-02 15:04:05'", snapshot)
+	}
+	return oracle.GoTimeToTS(time.UnixMilli(int64(sec * 1000))), nil
+}
+
+// keepSnapshot checks the snapshot of the source TiDB isn't garbage collected,
+// and keeps it from GC by a service GC safe point in the PD of the source until
+// the LiveSource is closed. The snapshot isn't kept if the PD can't be
+// connected, which is logged as a warning.
+func (s *LiveSource) keepSnapshot(ctx context.Context, conn *sql.Conn, snapshotTS uint64) error {
+	logger := log.FromContext(ctx).With(zap.Uint64("snapshot", snapshotTS))
+	var safePoint string
+	err := conn.QueryRowContext(ctx,
+		"SELECT VARIABLE_VALUE FROM mysql.tidb WHERE VARIABLE_NAME = 'tikv_gc_safe_point'").Scan(&safePoint)
+	switch {
+	case err == sql.ErrNoRows:
+	case err != nil:
+		logger.Warn("failed to get the GC safe point of the source TiDB", zap.Error(err))
+	default:
+		t, err := time.Parse(liveGCSafePointFormat, safePoint)
+		if err != nil {
+			logger.Warn("failed to parse the GC safe point of the source TiDB",
+				zap.String("safePoint", safePoint), zap.Error(err))
+		} else if oracle.GoTimeToTS(t) > snapshotTS {
+			return errors.Errorf("the snapshot %d of the source TiDB is garbage collected, the GC safe point is %s",
+				snapshotTS, safePoint)
+		}
+	}
+
+	var pdAddrs []string
+	err = queryLiveRows(ctx, conn, "SELECT STATUS_ADDRESS FROM information_schema.CLUSTER_INFO WHERE TYPE = 'pd'",
+		func(rows *sql.Rows) error {
+			var addr string
+			if err := rows.Scan(&addr); err != nil {
+				return err
+			}
+			pdAddrs = append(pdAddrs, addr)
+			return nil
+		})
+	if err == nil && len(pdAddrs) == 0 {
+		err = errors.New("no PD is found in the cluster info")
+	}
+	var pdCli pd.Client
+	if err == nil {
+		var secOpt pd.SecurityOption
+		if sec := s.cfg.Security; sec != nil {
+			secOpt = pd.SecurityOption{CAPath: sec.CAPath, CertPath: sec.CertPath, KeyPath: sec.KeyPath}
+		}
+		pdCli, err = pd.NewClientWithContext(ctx, caller.Component("lightning-source-gc"), pdAddrs, secOpt)
+	}
+	if err != nil {
+		logger.Warn("failed to connect to the PD of the source TiDB to keep the snapshot from GC, "+
+			"please make sure `tidb_gc_life_time` of the source TiDB is longer than the import", zap.Error(err))
+		return nil
+	}
+
+	serviceID := fmt.Sprintf("lightning-source-%s", uuid.New())
+	minSafePoint, err := pdCli.UpdateServiceGCSafePoint(ctx, serviceID, liveServiceSafePointTTL, snapshotTS)
+	if err != nil {
+		pdCli.Close()
+		return errors.Annotate(err, "set the service GC safe point of the source TiDB")
+	}
+	if minSafePoint > snapshotTS {
+		_, _ = pdCli.UpdateServiceGCSafePoint(ctx, serviceID, 0, snapshotTS)
+		pdCli.Close()
+		return errors.Errorf("the snapshot %d of the source TiDB is garbage collected, the GC safe point is %d",
+			snapshotTS, minSafePoint)
+	}
+	logger.Info("keep the snapshot of the source TiDB from GC", zap.String("serviceID", serviceID))
+
+	gcCtx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(liveServiceSafePointTTL * time.Second / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-gcCtx.Done():
+				return
+			case <-ticker.C:
+			}
+			if _, err := pdCli.UpdateServiceGCSafePoint(gcCtx, serviceID, liveServiceSafePointTTL, snapshotTS); err != nil {
+				logger.Warn("failed to update the service GC safe point of the source TiDB", zap.Error(err))
+			}
+		}
+	}()
+	s.stopGC = func() {
+		cancel()
+		wg.Wait()
+		// the service GC safe point is removed if the TTL isn't positive
+		if _, err := pdCli.UpdateServiceGCSafePoint(context.Background(), serviceID, 0, snapshotTS); err != nil {
+			logger.Warn("failed to remove the service GC safe point of the source TiDB", zap.Error(err))
+		}
+		pdCli.Close()
+	}
+	return nil
+}
+
+func (s *LiveSource) acquireConn(ctx context.Context) (*sql.Conn, error) {
+	select {
+	case conn := <-s.conns:
+		return conn, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (s *LiveSource) releaseConn(conn *sql.Conn) {
+	s.conns <- conn
+}
+
+// dump writes the schema files and the range files of the tables which match
+// the filter.
+func (s *LiveSource) dump(ctx context.Context, cfg *config.Config) error {
+	f, err := filter.Parse(cfg.Mydumper.Filter)
+	if err != nil {
+		return common.ErrInvalidConfig.Wrap(err).GenWithStack("parse filter failed")
+	}
+	if !cfg.Mydumper.CaseSensitive {
+		f = filter.CaseInsensitive(f)
+	}
+
+	conn, err := s.acquireConn(ctx)
+	if err != nil {
+		return err
+	}
+	tables, err := s.loadTables(ctx, conn, f)
+	s.releaseConn(conn)
+	if err != nil {
+		return err
+	}
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.SetLimit(cap(s.conns))
+	for _, t := range tables {
+		eg.Go(func() error {
+			return s.dumpTable(egCtx, t, s.cfg.RowsPerChunk)
+		})
+	}
+	return eg.Wait()
+}
+
+// loadTables writes the schema files of the databases and returns the
+// information of the tables.
+func (s *LiveSource) loadTables(ctx context.Context, conn *sql.Conn, f filter.Filter) ([]*liveTable, error) {
+	logger := log.FromContext(ctx)
+
+	var dbs []string
+	err := queryLiveRows(ctx, conn, "SELECT SCHEMA_NAME FROM information_schema.SCHEMATA", func(rows *sql.Rows) error {
+		var db string
+		if err := rows.Scan(&db); err != nil {
+			return err
+		}
+		if f.MatchSchema(db) {
+			dbs = append(dbs, db)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "list the databases of the source database")
+	}
+	for _, db := range dbs {
+		var name, createSQL string
+		if err = conn.QueryRowContext(ctx, "SHOW CREATE DATABASE "+common.EscapeIdentifier(db)).
+			Scan(&name, &createSQL); err != nil {
+			return nil, errors.Annotatef(err, "show create database %s", db)
+		}
+		if err = s.WriteFile(ctx, fmt.Sprintf("/%s-schema-create.sql", escapeLiveName(db)),
+			[]byte(createSQL+";\n")); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	query := "SELECT TABLE_SCHEMA, TABLE_NAME, TABLE_TYPE, IFNULL(TABLE_ROWS, 0), IFNULL(AVG_ROW_LENGTH, 0), "
+	if s.isTiDB {
+		query += "IFNULL(TIDB_PK_TYPE, '') FROM information_schema.TABLES"
+	} else {
+		query += "'' FROM information_schema.TABLES"
+	}
+	var tables []*liveTable
+	tableMap := make(map[filter.Table]*liveTable)
+	err = queryLiveRows(ctx, conn, query, func(rows *sql.Rows) error {
+		t := &liveTable{}
+		var tableType string
+		if err := rows.Scan(&t.db, &t.name, &tableType, &t.rows, &t.avgRowLength, &t.pkType); err != nil {
+			return err
+		}
+		if !f.MatchTable(t.db, t.name) {
+			return nil
+		}
+		if tableType != "BASE TABLE" {
+			logger.Warn("skip the non-table object of the source database",
+				zap.String("schema", t.db), zap.String("table", t.name), zap.String("type", tableType))
+			return nil
+		}
+		tables = append(tables, t)
+		tableMap[filter.Table{Schema: t.db, Name: t.name}] = t
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "list the tables of the source database")
+	}
+
+	err = queryLiveRows(ctx, conn, "SELECT TABLE_SCHEMA, TABLE_NAME, COLUMN_NAME, DATA_TYPE, EXTRA "+
+		"FROM information_schema.COLUMNS ORDER BY TABLE_SCHEMA, TABLE_NAME, ORDINAL_POSITION", func(rows *sql.Rows) error {
+		var db, table, column, dataType, extra string
+		if err := rows.Scan(&db, &table, &column, &dataType, &extra); err != nil {
+			return err
+		}
+		t, ok := tableMap[filter.Table{Schema: db, Name: table}]
+		if !ok {
+			return nil
+		}
+		// the generated columns are computed by the target
+		extra = strings.ToUpper(extra)
+		if strings.Contains(extra, "VIRTUAL GENERATED") || strings.Contains(extra, "STORED GENERATED") {
+			return nil
+		}
+		t.columns = append(t.columns, column)
+		t.dataTypes = append(t.dataTypes, dataType)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "list the columns of the source database")
+	}
+
+	err = queryLiveRows(ctx, conn, "SELECT TABLE_SCHEMA, TABLE_NAME, COLUMN_NAME FROM information_schema.KEY_COLUMN_USAGE "+
+		"WHERE CONSTRAINT_NAME = 'PRIMARY' ORDER BY TABLE_SCHEMA, TABLE_NAME, ORDINAL_POSITION", func(rows *sql.Rows) error {
+		var db, table, column string
+		if err := rows.Scan(&db, &table, &column); err != nil {
+			return err
+		}
+		if t, ok := tableMap[filter.Table{Schema: db, Name: table}]; ok {
+			t.primaryKey = append(t.primaryKey, column)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "list the primary keys of the source database")
+	}
+	return tables, nil
+}
+
+func queryLiveRows(ctx context.Context, conn *sql.Conn, query string, fn func(*sql.Rows) error) error {
+	rows, err := conn.QueryContext(ctx, query)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	//nolint: errcheck
+	defer rows.Close()
+	for rows.Next() {
+		if err = fn(rows); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return errors.Trace(rows.Err())
+}
+
+// dumpTable writes the schema file and the range files of a table.
+func (s *LiveSource) dumpTable(ctx context.Context, t *liveTable, rowsPerChunk int64) error {
+	conn, err := s.acquireConn(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.releaseConn(conn)
+
+	tableName := common.UniqueTable(t.db, t.name)
+	var name, createSQL string
+	if err = conn.QueryRowContext(ctx, "SHOW CREATE TABLE "+tableName).Scan(&name, &createSQL); err != nil {
+		return errors.Annotatef(err, "show create table %s", tableName)
+	}
+	prefix := fmt.Sprintf("/%s.%s", escapeLiveName(t.db), escapeLiveName(t.name))
+	if err = s.WriteFile(ctx, prefix+"-schema.sql", []byte(createSQL+";\n")); err != nil {
+		return errors.Trace(err)
+	}
+
+	ranges, err := splitLiveTable(ctx, conn, t, s.isTiDB, rowsPerChunk)
+	if err != nil {
+		return errors.Annotatef(err, "split table %s", tableName)
+	}
+	for i, r := range ranges {
+		data, err := json.Marshal(r)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		name := fmt.Sprintf("%s.%05d%s", prefix, i+1, liveFileExt)
+		if err = s.WriteFile(ctx, name, data); err != nil {
+			return errors.Trace(err)
+		}
+		s.mu.Lock()
+		s.sizes[name] = r.Size
+		s.mu.Unlock()
+	}
+	log.FromContext(ctx).Info("split table of the source database",
+		zap.String("table", tableName), zap.Int("ranges", len(ranges)))
+	return nil
+}
+
+// splitLiveTable splits a table into the ranges of about rowsPerChunk rows by
+// an integer primary key, or `_tidb_rowid` of TiDB. The empty ranges are
+// skipped.
+func splitLiveTable(
+	ctx context.Context,
+	conn *sql.Conn,
+	t *liveTable,
+	isTiDB bool,
+	rowsPerChunk int64,
+) ([]*liveTableRange, error) {
+	rowSize := t.avgRowLength
+	if rowSize <= 0 {
+		rowSize = liveDefaultRowSize
+	}
+	newRange := func(splitColumn string, orderBy []string) *liveTableRange {
+		return &liveTableRange{
+			DB:          t.db,
+			Table:       t.name,
+			Columns:     t.columns,
+			OrderBy:     orderBy,
+			SplitColumn: splitColumn,
+		}
+	}
+	count := func(r *liveTableRange) error {
+		query, args := r.countQuery()
+		if err := conn.QueryRowContext(ctx, query, args...).Scan(&r.Rows); err != nil {
+			return errors.Trace(err)
+		}
+		r.Size = r.Rows * rowSize
+		return nil
+	}
+
+	var splitColumn string
+	switch {
+	case len(t.primaryKey) == 1 && isLiveIntegerType(t.dataType(t.primaryKey[0])):
+		splitColumn = t.primaryKey[0]
+	case isTiDB && t.pkType != "CLUSTERED":
+		splitColumn = liveExtraHandleName
+	}
+
+	var (
+		bounds []int64
+		total  int64
+	)
+	if len(splitColumn) > 0 {
+		var minVal, maxVal sql.NullString
+		query := common.SprintfWithIdentifiers("SELECT MIN(%s), MAX(%s), COUNT(*) FROM %s.%s",
+			splitColumn, splitColumn, t.db, t.name)
+		if err := conn.QueryRowContext(ctx, query).Scan(&minVal, &maxVal, &total); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if total == 0 {
+			return nil, nil
+		}
+		lower, err1 := strconv.ParseInt(minVal.String, 10, 64)
+		upper, err2 := strconv.ParseInt(maxVal.String, 10, 64)
+		if err1 == nil && err2 == nil {
+			bounds = splitLiveKeyRange(lower, upper, (total+rowsPerChunk-1)/rowsPerChunk)
+		} else {
+			// the unsigned BIGINT values which overflow int64 are not split
+			splitColumn = ""
+		}
+	}
+
+	if len(splitColumn) == 0 {
+		r := newRange("", t.primaryKey)
+		if err := count(r); err != nil {
+			return nil, err
+		}
+		if r.Rows == 0 {
+			return nil, nil
+		}
+		return []*liveTableRange{r}, nil
+	}
+
+	if len(bounds) == 0 {
+		r := newRange(splitColumn, []string{splitColumn})
+		r.Rows = total
+		r.Size = total * rowSize
+		return []*liveTableRange{r}, nil
+	}
+	ranges := make([]*liveTableRange, 0, len(bounds)+1)
+	for i := 0; i <= len(bounds); i++ {
+		r := newRange(splitColumn, []string{splitColumn})
+		if i > 0 {
+			r.Lower = &bounds[i-1]
+		}
+		if i < len(bounds) {
+			r.Upper = &bounds[i]
+		}
+		if err := count(r); err != nil {
+			return nil, err
+		}
+		if r.Rows > 0 {
+			ranges = append(ranges, r)
+		}
+	}
+	return ranges, nil
+}
+
+// splitLiveKeyRange returns the n-1 bounds which split [lower, upper] into n
+// ranges of the same length, duplicated bounds are removed.
+func splitLiveKeyRange(lower, upper int64, n int64) []int64 {
+	if n <= 1 || upper <= lower {
+		return nil
+	}
+	span := uint64(upper) - uint64(lower)
+	step := span / uint64(n)
+	if step == 0 {
+		step = 1
+	}
+	bounds := make([]int64, 0, n-1)
+	for i := uint64(1); i < uint64(n); i++ {
+		offset := i * step
+		if offset > span {
+			break
+		}
+		bounds = append(bounds, int64(uint64(lower)+offset))
+	}
+	return bounds
+}
+
+// WalkDir traverses the files of the source, the sizes of the range files are
+// the estimated data sizes of the ranges.
+// It implements the `ExternalStorage` interface.
+func (s *LiveSource) WalkDir(ctx context.Context, opt *storage.WalkOption, fn func(string, int64) error) error {
+	return s.MemStorage.WalkDir(ctx, opt, func(name string, size int64) error {
+		s.mu.Lock()
+		rangeSize, ok := s.sizes[name]
+		s.mu.Unlock()
+		if ok {
+			size = rangeSize
+		}
+		return fn(name, size)
+	})
+}
+
+// URI returns the URL of the source database.
+// It implements the `ExternalStorage` interface.
+func (s *LiveSource) URI() string {
+	return s.cfg.URL()
+}
+
+// Close closes the connections to the source database.
+// It implements the `ExternalStorage` interface.
+func (s *LiveSource) Close() {
+	if s.stopGC != nil {
+		s.stopGC()
+		s.stopGC = nil
+	}
+	for _, conn := range s.allConns {
+		_ = conn.Close()
+	}
+	_ = s.db.Close()
+	s.MemStorage.Close()
+}
+
+func (s *LiveSource) readRange(ctx context.Context, name string) (*liveTableRange, error) {
+	data, err := s.ReadFile(ctx, name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	r := &liveTableRange{}
+	if err = json.Unmarshal(data, r); err != nil {
+		return nil, errors.Annotatef(err, "invalid range file %s", name)
+	}
+	return r, nil
+}
+
+// ReadLiveFileRowCount returns the row count of a range of the source
+// database.
+func ReadLiveFileRowCount(
+	ctx context.Context,
+	store storage.ExternalStorage,
+	fileMeta SourceFileMeta,
+) (int64, error) {
+	reader, err := openLiveReader(ctx, store, &fileMeta)
+	if err != nil {
+		return 0, err
+	}
+	return reader.rng.Rows, nil
+}
+
+// liveTableReader is the reader returned by OpenReader for a range of the
+// source database, the rows are read by LiveParser rather than the reader.
+type liveTableReader struct {
+	source *LiveSource
+	rng    *liveTableRange
+}
+
+func openLiveReader(ctx context.Context, store storage.ExternalStorage, fileMeta *SourceFileMeta) (*liveTableReader, error) {
+	source, ok := store.(*LiveSource)
+	if !ok {
+		return nil, errors.Errorf("can't read the range %s of the source database from %s", fileMeta.Path, store.URI())
+	}
+	rng, err := source.readRange(ctx, fileMeta.Path)
+	if err != nil {
+		return nil, err
+	}
+	return &liveTableReader{source: source, rng: rng}, nil
+}
+
+// Read implements io.Reader.
+func (*liveTableReader) Read([]byte) (int, error) {
+	return 0, errors.New("the range of the source database can only be read by LiveParser")
+}
+
+// Seek implements io.Seeker.
+func (*liveTableReader) Seek(int64, int) (int64, error) {
+	return 0, errors.New("the range of the source database can only be read by LiveParser")
+}
+
+// Close implements io.Closer.
+func (*liveTableReader) Close() error {
+	return nil
+}
+
+// LiveParser reads the rows of a range of a table in the source database. The
+// connection is acquired on the first ReadRow and released on Close, so the
+// parsers waiting for the workers don't hold the connections.
+//
+// Like AvroParser, the position of LiveParser is the row number. The rows are
+// sorted by the primary key if any. When resuming from a checkpoint under the
+// same snapshot, the key of the last read row is located by reading the split
+// column only, and the rows after the key are read, so that the rows before the
+// position aren't read again. The ranges without a split column skip the rows
+// by the offset.
+type LiveParser struct {
+	// ctx is used to cancel the query, ReadRow has no context argument.
+	ctx     context.Context
+	source  *LiveSource
+	rng     *liveTableRange
+	columns []string
+
+	conn   *sql.Conn
+	rows   *sql.Rows
+	binary []bool
+	values []sql.RawBytes
+	dest   []any
+
+	pos     int64
+	scanned int64
+	lastRow Row
+	logger  log.Logger
+}
+
+// NewLiveParser creates a parser of the reader returned by OpenReader for a
+// range of the source database.
+func NewLiveParser(ctx context.Context, reader ReadSeekCloser) (*LiveParser, error) {
+	r, ok := reader.(*liveTableReader)
+	if !ok {
+		return nil, errors.New("not a range of the source database")
+	}
+	parser := &LiveParser{
+		ctx:    ctx,
+		source: r.source,
+		rng:    r.rng,
+		logger: log.FromContext(ctx),
+	}
+	parser.SetColumns(r.rng.Columns)
+	return parser, nil
+}
+
+func (p *LiveParser) query() error {
+	conn, err := p.source.acquireConn(p.ctx)
+	if err != nil {
+		return err
+	}
+	p.conn = conn
+	var after *int64
+	if p.pos > 0 && len(p.rng.SplitColumn) > 0 {
+		query, args := p.rng.keyQuery(p.pos - 1)
+		var key int64
+		if err = conn.QueryRowContext(p.ctx, query, args...).Scan(&key); err != nil {
+			return errors.Annotatef(err, "locate the row %d of table %s", p.pos, common.UniqueTable(p.rng.DB, p.rng.Table))
+		}
+		after = &key
+	}
+	query, args := p.rng.selectQuery(p.columns, after, p.pos)
+	rows, err := conn.QueryContext(p.ctx, query, args...)
+	if err != nil {
+		return errors.Annotatef(err, "read table %s", common.UniqueTable(p.rng.DB, p.rng.Table))
+	}
+	p.rows = rows
+	colTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	p.binary = make([]bool, len(colTypes))
+	for i, tp := range colTypes {
+		switch tp.DatabaseTypeName() {
+		case "BIT", "BINARY", "VARBINARY", "TINYBLOB", "BLOB", "MEDIUMBLOB", "LONGBLOB", "GEOMETRY":
+			p.binary[i] = true
+		}
+	}
+	p.values = make([]sql.RawBytes, len(colTypes))
+	p.dest = make([]any, len(colTypes))
+	for i := range p.values {
+		p.dest[i] = &p.values[i]
+	}
+	return nil
+}
+
+// Pos returns the row number of the parser.
+// It implements the Parser interface.
+func (p *LiveParser) Pos() (pos int64, rowID int64) {
+	return p.pos, p.lastRow.RowID
+}
+
+// SetPos sets the row number to read from, the rows before it are skipped by
+// the query.
+// It implements the Parser interface.
+func (p *LiveParser) SetPos(pos int64, rowID int64) error {
+	if p.rows != nil {
+		return errors.New("live parser can't seek after reading")
+	}
+	p.pos = pos
+	p.lastRow.RowID = rowID
+	return nil
+}
+
+// ScannedPos returns the size of the data read by the parser.
+// It implements the Parser interface.
+func (p *LiveParser) ScannedPos() (int64, error) {
+	return p.scanned, nil
+}
+
+// Close releases the connection of the parser.
+// It implements the Parser interface.
+func (p *LiveParser) Close() error {
+	var err error
+	if p.rows != nil {
+		err = p.rows.Close()
+		p.rows = nil
+	}
+	if p.conn != nil {
+		p.source.releaseConn(p.conn)
+		p.conn = nil
+	}
+	return errors.Trace(err)
+}
+
+// ReadRow reads a row of the range by the parser.
+// It implements the Parser interface.
+func (p *LiveParser) ReadRow() error {
+	if p.conn == nil {
+		if err := p.query(); err != nil {
+			return err
+		}
+	}
+	if !p.rows.Next() {
+		if err := p.rows.Err(); err != nil {
+			return errors.Trace(err)
+		}
+		return io.EOF
+	}
+	if err := p.rows.Scan(p.dest...); err != nil {
+		return errors.Trace(err)
+	}
+	p.pos++
+
+	row := &p.lastRow
+	row.RowID++
+	row.Length = 0
+	if cap(row.Row) < len(p.values) {
+		row.Row = make([]types.Datum, len(p.values))
+	}
+	row.Row = row.Row[:len(p.values)]
+	for i, v := range p.values {
+		row.Length += len(v)
+		d := &row.Row[i]
+		switch {
+		case v == nil:
+			d.SetNull()
+		case p.binary[i]:
+			d.SetBytes(append([]byte(nil), v...))
+		default:
+			d.SetString(string(v), "utf8mb4_bin")
+		}
+	}
+	p.scanned += int64(row.Length)
+	return nil
+}
+
+// LastRow gets the last row parsed by the parser.
+// It implements the Parser interface.
+func (p *LiveParser) LastRow() Row {
+	return p.lastRow
+}
+
+// RecycleRow implements the Parser interface.
+func (*LiveParser) RecycleRow(_ Row) {
+}
+
+// Columns returns the _lower-case_ column names corresponding to values in
+// the LastRow.
+func (p *LiveParser) Columns() []string {
+	return p.columns
+}
+
+// SetColumns sets the columns to read.
+func (p *LiveParser) SetColumns(columns []string) {
+	p.columns = make([]string, len(columns))
+	for i, col := range columns {
+		p.columns[i] = strings.ToLower(col)
+	}
+}
+
+// SetLogger sets the logger used in the parser.
+// It implements the Parser interface.
+func (p *LiveParser) SetLogger(l log.Logger) {
+	p.logger = l
+}
+
+// SetRowID sets the rowID of the parser.
+// It implements the Parser interface.
+func (p *LiveParser) SetRowID(rowID int64) {
+	p.lastRow.RowID = rowID
+}
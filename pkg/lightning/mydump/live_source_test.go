@@ -0,0 +1,211 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mydump
+
+import (
+	"context"
+	"io"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/pkg/lightning/config"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitLiveKeyRange(t *testing.T) {
+	require.Nil(t, splitLiveKeyRange(1, 100, 1))
+	require.Nil(t, splitLiveKeyRange(5, 5, 3))
+	require.Equal(t, []int64{34, 67}, splitLiveKeyRange(1, 100, 3))
+	require.Equal(t, []int64{2, 3}, splitLiveKeyRange(1, 3, 10))
+	require.Equal(t, []int64{-1}, splitLiveKeyRange(-9223372036854775808, 9223372036854775807, 2))
+}
+
+func TestLiveSource(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	cfg := config.NewConfig()
+	cfg.App.RegionConcurrency = 1
+	cfg.Mydumper.SourceDB = config.SourceDB{
+		Host:         "127.0.0.1",
+		Port:         4000,
+		Consistency:  config.SourceConsistencySnapshot,
+		Snapshot:     "2026-02-18 17:46:21",
+		RowsPerChunk: 2,
+	}
+
+	mock.ExpectQuery("SELECT VERSION()").
+		WillReturnRows(sqlmock.NewRows([]string{"VERSION()"}).AddRow("8.0.11-TiDB-v8.5.0"))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT UNIX_TIMESTAMP(?)")).WithArgs("2026-02-18 17:46:21").
+		WillReturnRows(sqlmock.NewRows([]string{"UNIX_TIMESTAMP"}).AddRow("1771436781.000"))
+	mock.ExpectQuery("tikv_gc_safe_point").
+		WillReturnRows(sqlmock.NewRows([]string{"VARIABLE_VALUE"}).AddRow("20260101-00:00:00.000 +0000"))
+	// the snapshot isn't kept if the PD is unknown
+	mock.ExpectQuery("information_schema.CLUSTER_INFO").
+		WillReturnRows(sqlmock.NewRows([]string{"STATUS_ADDRESS"}))
+	mock.ExpectExec("SET SESSION tidb_snapshot = 464371523518464000").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("information_schema.SCHEMATA").
+		WillReturnRows(sqlmock.NewRows([]string{"SCHEMA_NAME"}).AddRow("mysql").AddRow("db.1"))
+	mock.ExpectQuery(regexp.QuoteMeta("SHOW CREATE DATABASE `db.1`")).
+		WillReturnRows(sqlmock.NewRows([]string{"Database", "Create Database"}).
+			AddRow("db.1", "CREATE DATABASE `db.1`"))
+	mock.ExpectQuery("information_schema.TABLES").
+		WillReturnRows(sqlmock.NewRows([]string{"TABLE_SCHEMA", "TABLE_NAME", "TABLE_TYPE", "TABLE_ROWS", "AVG_ROW_LENGTH", "TIDB_PK_TYPE"}).
+			AddRow("mysql", "user", "BASE TABLE", 1, 100, "NONCLUSTERED").
+			AddRow("db.1", "t1", "BASE TABLE", 5, 20, "CLUSTERED").
+			AddRow("db.1", "v1", "VIEW", 0, 0, ""))
+	mock.ExpectQuery("information_schema.COLUMNS").
+		WillReturnRows(sqlmock.NewRows([]string{"TABLE_SCHEMA", "TABLE_NAME", "COLUMN_NAME", "DATA_TYPE", "EXTRA"}).
+			AddRow("db.1", "t1", "ID", "bigint", "").
+			AddRow("db.1", "t1", "b", "varbinary", "").
+			AddRow("db.1", "t1", "g", "int", "VIRTUAL GENERATED").
+			AddRow("db.1", "v1", "ID", "bigint", ""))
+	mock.ExpectQuery("information_schema.KEY_COLUMN_USAGE").
+		WillReturnRows(sqlmock.NewRows([]string{"TABLE_SCHEMA", "TABLE_NAME", "COLUMN_NAME"}).
+			AddRow("db.1", "t1", "ID"))
+	mock.ExpectQuery(regexp.QuoteMeta("SHOW CREATE TABLE `db.1`.`t1`")).
+		WillReturnRows(sqlmock.NewRows([]string{"Table", "Create Table"}).
+			AddRow("t1", "CREATE TABLE `t1` (`ID` bigint PRIMARY KEY, `b` varbinary(10), `g` int AS (`ID` + 1))"))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT MIN(`ID`), MAX(`ID`), COUNT(*) FROM `db.1`.`t1`")).
+		WillReturnRows(sqlmock.NewRows([]string{"MIN", "MAX", "COUNT"}).AddRow("1", "100", 5))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM `db.1`.`t1` WHERE `ID` < ?")).WithArgs(34).
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT"}).AddRow(2))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM `db.1`.`t1` WHERE `ID` >= ? AND `ID` < ?")).WithArgs(34, 67).
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT"}).AddRow(0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM `db.1`.`t1` WHERE `ID` >= ?")).WithArgs(67).
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT"}).AddRow(3))
+
+	src, err := newLiveSource(ctx, db, cfg)
+	require.NoError(t, err)
+	defer src.Close()
+	require.NoError(t, mock.ExpectationsWereMet())
+	require.Equal(t, "464371523518464000", cfg.Mydumper.SourceDB.Snapshot)
+	require.Equal(t, "mysql://127.0.0.1:4000?snapshot=464371523518464000", src.URI())
+	require.Equal(t, src.URI(), cfg.Mydumper.SourceDir)
+
+	files := make(map[string]int64)
+	require.NoError(t, src.WalkDir(ctx, &storage.WalkOption{}, func(name string, size int64) error {
+		files[name] = size
+		return nil
+	}))
+	require.Len(t, files, 4)
+	require.Contains(t, files, "/db%2E1-schema-create.sql")
+	require.Contains(t, files, "/db%2E1.t1-schema.sql")
+	require.EqualValues(t, 40, files["/db%2E1.t1.00001.live"])
+	require.EqualValues(t, 60, files["/db%2E1.t1.00002.live"])
+
+	loader, err := NewLoaderWithStore(ctx, NewLoaderCfg(cfg), src)
+	require.NoError(t, err)
+	dbMetas := loader.GetDatabases()
+	require.Len(t, dbMetas, 1)
+	require.Equal(t, "db.1", dbMetas[0].Name)
+	require.Len(t, dbMetas[0].Tables, 1)
+	tableMeta := dbMetas[0].Tables[0]
+	require.Equal(t, "t1", tableMeta.Name)
+	require.Len(t, tableMeta.DataFiles, 2)
+	fileMeta := tableMeta.DataFiles[0].FileMeta
+	require.Equal(t, SourceTypeLive, fileMeta.Type)
+	require.EqualValues(t, 2, fileMeta.Rows)
+	require.EqualValues(t, 3, tableMeta.DataFiles[1].FileMeta.Rows)
+
+	regions, err := MakeTableRegions(ctx, &DataDivideConfig{
+		ColumnCnt: 2,
+		Store:     src,
+		TableMeta: tableMeta,
+	})
+	require.NoError(t, err)
+	require.Len(t, regions, 2)
+	require.Equal(t, Chunk{EndOffset: 2, RowIDMax: 2}, regions[0].Chunk)
+	require.Equal(t, Chunk{EndOffset: 3, PrevRowIDMax: 2, RowIDMax: 5}, regions[1].Chunk)
+
+	// resume the first range from the second row, which is after the key of the first row
+	reader, err := OpenReader(ctx, &fileMeta, src, storage.DecompressConfig{})
+	require.NoError(t, err)
+	parser, err := NewLiveParser(ctx, reader)
+	require.NoError(t, err)
+	require.Equal(t, []string{"id", "b"}, parser.Columns())
+	require.NoError(t, parser.SetPos(1, 1))
+
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT `ID` FROM `db.1`.`t1` WHERE `ID` < ? ORDER BY `ID` LIMIT ?, 1")).
+		WithArgs(34, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"ID"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT `id`, `b` FROM `db.1`.`t1` WHERE `ID` > ? AND `ID` < ? ORDER BY `ID`")).
+		WithArgs(1, 34).
+		WillReturnRows(sqlmock.NewRowsWithColumnDefinition(
+			sqlmock.NewColumn("id").OfType("BIGINT", int64(0)),
+			sqlmock.NewColumn("b").OfType("VARBINARY", []byte{}),
+		).AddRow("2", []byte("\x00\x01")))
+	require.NoError(t, parser.ReadRow())
+	row := parser.LastRow()
+	require.EqualValues(t, 2, row.RowID)
+	require.Equal(t, 3, row.Length)
+	require.Equal(t, types.NewStringDatum("2"), row.Row[0])
+	require.Equal(t, types.NewBytesDatum([]byte("\x00\x01")), row.Row[1])
+	pos, rowID := parser.Pos()
+	require.EqualValues(t, 2, pos)
+	require.EqualValues(t, 2, rowID)
+	scanned, err := parser.ScannedPos()
+	require.NoError(t, err)
+	require.EqualValues(t, 3, scanned)
+	require.ErrorIs(t, parser.ReadRow(), io.EOF)
+	require.NoError(t, parser.Close())
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// the connection is released on close
+	conn, err := src.acquireConn(ctx)
+	require.NoError(t, err)
+	src.releaseConn(conn)
+}
+
+func TestLiveSourceSnapshotGC(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	cfg := config.NewConfig()
+	cfg.App.RegionConcurrency = 1
+	cfg.Mydumper.SourceDB = config.SourceDB{
+		Host:        "127.0.0.1",
+		Port:        4000,
+		Consistency: config.SourceConsistencyAuto,
+	}
+
+	// the snapshot in the checkpoints is garbage collected
+	cfg.Mydumper.SourceDB.Snapshot = "464371523518464000"
+	mock.ExpectQuery("SELECT VERSION()").
+		WillReturnRows(sqlmock.NewRows([]string{"VERSION()"}).AddRow("8.0.11-TiDB-v8.5.0"))
+	mock.ExpectQuery("tikv_gc_safe_point").
+		WillReturnRows(sqlmock.NewRows([]string{"VARIABLE_VALUE"}).AddRow("20260301-00:00:00.000 +0000"))
+	_, err = newLiveSource(ctx, db, cfg)
+	require.ErrorContains(t, err, "the snapshot 464371523518464000 of the source TiDB is garbage collected")
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	db, mock, err = sqlmock.New()
+	require.NoError(t, err)
+	cfg.Mydumper.SourceDB.Snapshot = "yesterday"
+	mock.ExpectQuery("SELECT VERSION()").
+		WillReturnRows(sqlmock.NewRows([]string{"VERSION()"}).AddRow("8.0.11-TiDB-v8.5.0"))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT UNIX_TIMESTAMP(?)")).WithArgs("yesterday").
+		WillReturnRows(sqlmock.NewRows([]string{"UNIX_TIMESTAMP"}).AddRow(nil))
+	_, err = newLiveSource(ctx, db, cfg)
+	require.ErrorContains(t, err, "invalid `mydumper.source-db.snapshot` yesterday")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
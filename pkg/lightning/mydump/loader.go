@@ -228,13 +228,17 @@ type LoaderConfig struct {
 
 // NewLoaderCfg creates loader config from lightning config.
 func NewLoaderCfg(cfg *config.Config) LoaderConfig {
+	fileRouters := cfg.Mydumper.FileRouters
+	if cfg.Mydumper.SourceDB.IsEnabled() {
+		fileRouters = liveFileRouteRules
+	}
 	return LoaderConfig{
 		SourceID:         cfg.Mydumper.SourceID,
 		SourceURL:        cfg.Mydumper.SourceDir,
 		Routes:           cfg.Routes,
 		CharacterSet:     cfg.Mydumper.CharacterSet,
 		Filter:           cfg.Mydumper.Filter,
-		FileRouters:      cfg.Mydumper.FileRouters,
+		FileRouters:      fileRouters,
 		CaseSensitive:    cfg.Mydumper.CaseSensitive,
 		DefaultFileRules: cfg.Mydumper.DefaultFileRules,
 	}
@@ -499,7 +503,7 @@ func (s *mdLoaderSetup) setup(ctx context.Context) error {
 			s.tableSchemas = append(s.tableSchemas, *info)
 		case SourceTypeViewSchema:
 			s.viewSchemas = append(s.viewSchemas, *info)
-		case SourceTypeSQL, SourceTypeCSV, SourceTypeParquet:
+		case SourceTypeSQL, SourceTypeCSV, SourceTypeParquet, SourceTypeNDJSON, SourceTypeAvro, SourceTypeLive:
 			s.tableDatas = append(s.tableDatas, *info)
 		}
 	}
@@ -620,7 +624,7 @@ func (s *mdLoaderSetup) constructFileInfo(ctx context.Context, f RawFile) (*File
 	}
 
 	switch res.Type {
-	case SourceTypeSQL, SourceTypeCSV:
+	case SourceTypeSQL, SourceTypeCSV, SourceTypeNDJSON:
 		info.FileMeta.RealSize = EstimateRealSizeForFile(ctx, info.FileMeta, s.loader.GetStore())
 	case SourceTypeParquet:
 		var (
@@ -654,6 +658,24 @@ func (s *mdLoaderSetup) constructFileInfo(ctx context.Context, f RawFile) (*File
 		if m, ok := metric.FromContext(ctx); ok {
 			m.RowsCounter.WithLabelValues(metric.StateTotalRestore, tableName).Add(float64(totalRowCount))
 		}
+	case SourceTypeAvro, SourceTypeLive:
+		var totalRowCount int64
+		if res.Type == SourceTypeAvro {
+			totalRowCount, err = ReadAvroFileRowCount(ctx, s.loader.GetStore(), info.FileMeta)
+		} else {
+			totalRowCount, err = ReadLiveFileRowCount(ctx, s.loader.GetStore(), info.FileMeta)
+		}
+		if err != nil {
+			logger.Error("fail to get file total row count", zap.String("category", "loader"),
+				zap.String("schema", res.Schema), zap.String("table", res.Name),
+				zap.Stringer("type", res.Type), zap.Error(err))
+			return nil, errors.Trace(err)
+		}
+
+		info.FileMeta.Rows = totalRowCount
+		if m, ok := metric.FromContext(ctx); ok {
+			m.RowsCounter.WithLabelValues(metric.StateTotalRestore, info.TableName.String()).Add(float64(totalRowCount))
+		}
 	}
 
 	logger.Debug("file route result", zap.String("schema", res.Schema),
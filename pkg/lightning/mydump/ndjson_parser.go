@@ -0,0 +1,222 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mydump
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/lightning/log"
+	"github.com/pingcap/tidb/pkg/lightning/metric"
+	"github.com/pingcap/tidb/pkg/lightning/worker"
+	"go.uber.org/zap"
+)
+
+// NDJSONParser is a parser for newline-delimited JSON files, each non-empty
+// line of the file is a JSON object which is a row. Since a newline can't
+// appear inside a JSON value, a large file can be split at any newline.
+//
+// The values are mapped to columns by field name, see fieldProjector. If the
+// columns are neither set by field paths nor SetColumns, they are the
+// top-level fields of the first row, and the following rows can't contain
+// other fields.
+type NDJSONParser struct {
+	blockParser
+
+	projector *fieldProjector
+	// inferColumns is true if the columns are the fields of the first row.
+	inferColumns bool
+	columnSet    map[string]struct{}
+	// holds the line which crosses blocks.
+	lineBuf []byte
+}
+
+// NewNDJSONParser creates a NDJSON parser. fieldPaths maps the column names to
+// the paths of the nested fields, like `address.city`.
+func NewNDJSONParser(
+	ctx context.Context,
+	fieldPaths map[string]string,
+	reader ReadSeekCloser,
+	blockBufSize int64,
+	ioWorkers *worker.Pool,
+) (*NDJSONParser, error) {
+	projector, err := newFieldProjector(fieldPaths)
+	if err != nil {
+		return nil, err
+	}
+	metrics, _ := metric.FromContext(ctx)
+	return &NDJSONParser{
+		blockParser:  makeBlockParser(reader, blockBufSize, ioWorkers, metrics, log.FromContext(ctx)),
+		projector:    projector,
+		inferColumns: len(fieldPaths) == 0,
+	}, nil
+}
+
+// Columns returns the _lower-case_ column names corresponding to values in
+// the LastRow.
+func (parser *NDJSONParser) Columns() []string {
+	return parser.projector.columns
+}
+
+// SetColumns sets the columns to project the rows to, the fields which are not
+// mapped to these columns are ignored.
+func (parser *NDJSONParser) SetColumns(columns []string) {
+	parser.projector.setColumns(columns)
+	parser.inferColumns = false
+}
+
+// readLine reads a line without the trailing newline. The returned slice is
+// only valid before the next read.
+func (parser *NDJSONParser) readLine() ([]byte, error) {
+	if index := bytes.IndexByte(parser.buf, '\n'); index >= 0 {
+		line := parser.buf[:index]
+		parser.buf = parser.buf[index+1:]
+		parser.pos += int64(index + 1)
+		return line, nil
+	}
+
+	// not found in parser.buf, need allocate and loop.
+	parser.lineBuf = parser.lineBuf[:0]
+	for {
+		parser.lineBuf = append(parser.lineBuf, parser.buf...)
+		parser.pos += int64(len(parser.buf))
+		parser.buf = nil
+		if len(parser.lineBuf) > LargestEntryLimit {
+			return nil, errors.New("size of row cannot exceed the max value of txn-entry-size-limit")
+		}
+		if err := parser.readBlock(); err != nil || len(parser.buf) == 0 {
+			if err == nil {
+				err = io.EOF
+			}
+			// the last line may have no newline.
+			if errors.Cause(err) == io.EOF && len(parser.lineBuf) > 0 {
+				return parser.lineBuf, nil
+			}
+			return nil, errors.Trace(err)
+		}
+		if index := bytes.IndexByte(parser.buf, '\n'); index >= 0 {
+			parser.lineBuf = append(parser.lineBuf, parser.buf[:index]...)
+			parser.buf = parser.buf[index+1:]
+			parser.pos += int64(index + 1)
+			return parser.lineBuf, nil
+		}
+	}
+}
+
+// ReadUntilNewLine skips the content until the next newline, and returns the
+// file offset beyond the newline.
+func (parser *NDJSONParser) ReadUntilNewLine() (int64, error) {
+	_, err := parser.readLine()
+	return parser.pos, err
+}
+
+// ReadRow reads a row from the datafile.
+func (parser *NDJSONParser) ReadRow() error {
+	var (
+		line     []byte
+		startPos int64
+		err      error
+	)
+	// skip empty lines
+	for len(line) == 0 {
+		startPos = parser.pos
+		if line, err = parser.readLine(); err != nil {
+			return err
+		}
+		line = bytes.TrimSpace(line)
+	}
+
+	keys, record, err := decodeNDJSONRow(line)
+	if err != nil {
+		content := line
+		if len(content) > 256 {
+			content = content[:256]
+		}
+		parser.Logger.Error("syntax error", zap.Int64("pos", startPos), zap.ByteString("content", content), log.ShortError(err))
+		return errors.Annotatef(err, "syntax error at offset %d", startPos)
+	}
+	if parser.inferColumns {
+		if err = parser.checkOrInferColumns(keys, startPos); err != nil {
+			return err
+		}
+	}
+
+	row := &parser.lastRow
+	row.RowID++
+	row.Length = len(line)
+	row.Row, err = parser.projector.project(parser.acquireDatumSlice(), record)
+	return errors.Trace(err)
+}
+
+func (parser *NDJSONParser) checkOrInferColumns(keys []string, pos int64) error {
+	if parser.columnSet == nil {
+		parser.projector.setColumns(keys)
+		parser.columnSet = make(map[string]struct{}, len(keys))
+		for _, col := range parser.projector.columns {
+			parser.columnSet[col] = struct{}{}
+		}
+		return nil
+	}
+	for _, key := range keys {
+		if _, ok := parser.columnSet[strings.ToLower(key)]; !ok {
+			return errors.Errorf("field '%s' at offset %d doesn't exist in the first row, "+
+				"please specify the columns by field paths", key, pos)
+		}
+	}
+	return nil
+}
+
+// decodeNDJSONRow decodes a JSON object, and returns the keys in the order of
+// appearance and the decoded object. Numbers are decoded as json.Number.
+func decodeNDJSONRow(line []byte) ([]string, map[string]any, error) {
+	dec := json.NewDecoder(bytes.NewReader(line))
+	dec.UseNumber()
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return nil, nil, errors.New("a row must be a JSON object")
+	}
+	var keys []string
+	record := make(map[string]any)
+	for dec.More() {
+		if tok, err = dec.Token(); err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		// the token of an object key is always a string.
+		key, _ := tok.(string)
+		var v any
+		if err = dec.Decode(&v); err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		if _, ok := record[key]; !ok {
+			keys = append(keys, key)
+		}
+		record[key] = v
+	}
+	// the closing '}'
+	if _, err = dec.Token(); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if rest := bytes.TrimSpace(line[dec.InputOffset():]); len(rest) > 0 {
+		return nil, nil, errors.Errorf("unexpected content after the JSON object: %q", rest)
+	}
+	return keys, record, nil
+}
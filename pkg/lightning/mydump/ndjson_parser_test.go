@@ -0,0 +1,133 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mydump_test
+
+import (
+	"context"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/lightning/mydump"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func newNDJSONParser(t *testing.T, input string, fieldPaths map[string]string) *mydump.NDJSONParser {
+	// use a small block size to read lines crossing blocks.
+	parser, err := mydump.NewNDJSONParser(context.Background(), fieldPaths, mydump.NewStringReader(input), 4, ioWorkersForCSV)
+	require.NoError(t, err)
+	return parser
+}
+
+func TestNDJSONParser(t *testing.T) {
+	line1 := `{"id": 1, "Name": "a", "info": {"city": "x", "tags": [1, 2]}}`
+	line2 := ` {"name": "b", "id": 18446744073709551615, "info": null}`
+	line3 := `{"id": 3.25}`
+	input := line1 + "\n\n" + line2 + "\r\n" + line3
+	parser := newNDJSONParser(t, input, nil)
+
+	require.NoError(t, parser.ReadRow())
+	require.Equal(t, []string{"id", "name", "info"}, parser.Columns())
+	require.Equal(t, mydump.Row{
+		RowID: 1,
+		Row: []types.Datum{
+			types.NewIntDatum(1),
+			types.NewCollationStringDatum("a", "utf8mb4_bin"),
+			types.NewCollationStringDatum(`{"city":"x","tags":[1,2]}`, "utf8mb4_bin"),
+		},
+		Length: len(line1),
+	}, parser.LastRow())
+	pos, rowID := parser.Pos()
+	require.Equal(t, int64(len(line1)+1), pos)
+	require.Equal(t, int64(1), rowID)
+	parser.RecycleRow(parser.LastRow())
+
+	require.NoError(t, parser.ReadRow())
+	require.Equal(t, []types.Datum{
+		types.NewUintDatum(math.MaxUint64),
+		types.NewCollationStringDatum("b", "utf8mb4_bin"),
+		types.NewDatum(nil),
+	}, parser.LastRow().Row)
+	pos, _ = parser.Pos()
+	require.Equal(t, int64(len(line1)+len(line2)+4), pos)
+	parser.RecycleRow(parser.LastRow())
+
+	// the last line has no newline, and the value of missing fields are NULL.
+	require.NoError(t, parser.ReadRow())
+	require.Equal(t, []types.Datum{
+		types.NewCollationStringDatum("3.25", "utf8mb4_bin"),
+		types.NewDatum(nil),
+		types.NewDatum(nil),
+	}, parser.LastRow().Row)
+	pos, rowID = parser.Pos()
+	require.Equal(t, int64(len(input)), pos)
+	require.Equal(t, int64(3), rowID)
+
+	require.ErrorIs(t, errors.Cause(parser.ReadRow()), io.EOF)
+	require.NoError(t, parser.Close())
+
+	// the fields which are not in the first row are rejected if the columns are inferred.
+	parser = newNDJSONParser(t, "{\"a\": 1}\n{\"a\": 2, \"b\": 3}\n", nil)
+	require.NoError(t, parser.ReadRow())
+	require.ErrorContains(t, parser.ReadRow(), "field 'b' at offset 9 doesn't exist in the first row")
+	require.NoError(t, parser.Close())
+
+	// but they are ignored if the columns are set.
+	parser = newNDJSONParser(t, "{\"a\": 1}\n{\"a\": 2, \"B\": true}\n", nil)
+	parser.SetColumns([]string{"B", "a"})
+	require.NoError(t, parser.ReadRow())
+	require.Equal(t, []types.Datum{types.NewDatum(nil), types.NewIntDatum(1)}, parser.LastRow().Row)
+	require.NoError(t, parser.ReadRow())
+	require.Equal(t, []types.Datum{types.NewUintDatum(1), types.NewIntDatum(2)}, parser.LastRow().Row)
+	require.Equal(t, []string{"b", "a"}, parser.Columns())
+	require.NoError(t, parser.Close())
+
+	for _, input := range []string{`{"a": 1`, `[1, 2]`, `{"a": 1} {"a": 2}`} {
+		parser = newNDJSONParser(t, input, nil)
+		require.Error(t, parser.ReadRow(), input)
+		require.NoError(t, parser.Close())
+	}
+}
+
+func TestNDJSONParserFieldPaths(t *testing.T) {
+	input := `{"id": 1, "info": {"City": "x", "tags": ["t1", "t2"]}, "other": 1}` + "\n" +
+		`{"id": 2, "info": {"tags": []}}` + "\n"
+	parser := newNDJSONParser(t, input, map[string]string{
+		"ID":   "id",
+		"city": "info.city",
+		"tag":  "info.tags.1",
+	})
+	require.Equal(t, []string{"city", "id", "tag"}, parser.Columns())
+
+	require.NoError(t, parser.ReadRow())
+	require.Equal(t, []types.Datum{
+		types.NewCollationStringDatum("x", "utf8mb4_bin"),
+		types.NewIntDatum(1),
+		types.NewCollationStringDatum("t2", "utf8mb4_bin"),
+	}, parser.LastRow().Row)
+	require.NoError(t, parser.ReadRow())
+	require.Equal(t, []types.Datum{
+		types.NewDatum(nil),
+		types.NewIntDatum(2),
+		types.NewDatum(nil),
+	}, parser.LastRow().Row)
+	require.ErrorIs(t, errors.Cause(parser.ReadRow()), io.EOF)
+	require.NoError(t, parser.Close())
+
+	_, err := mydump.NewNDJSONParser(context.Background(), map[string]string{"a": "b..c"}, mydump.NewStringReader(""), 4, nil)
+	require.ErrorContains(t, err, "invalid field path 'b..c' of column 'a'")
+}
@@ -680,6 +680,8 @@ func OpenReader(
 	switch {
 	case fileMeta.Type == SourceTypeParquet:
 		reader, err = OpenParquetReader(ctx, store, fileMeta.Path, fileMeta.FileSize)
+	case fileMeta.Type == SourceTypeLive:
+		reader, err = openLiveReader(ctx, store, fileMeta)
 	case fileMeta.Compression != CompressionNone:
 		compressType, err2 := ToStorageCompressType(fileMeta.Compression)
 		if err2 != nil {
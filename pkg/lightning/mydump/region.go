@@ -239,6 +239,14 @@ func MakeTableRegions(
 			dataFileSize := info.FileMeta.FileSize
 			if info.FileMeta.Type == SourceTypeParquet {
 				regions, sizes, err = makeParquetFileRegion(egCtx, cfg, info)
+			} else if info.FileMeta.Type == SourceTypeAvro || info.FileMeta.Type == SourceTypeLive {
+				regions, sizes, err = makeAvroFileRegion(egCtx, cfg, info)
+			} else if info.FileMeta.Type == SourceTypeNDJSON &&
+				info.FileMeta.Compression == CompressionNone &&
+				dataFileSize > cfg.MaxChunkSize+cfg.MaxChunkSize/largeCSVLowerThresholdRation {
+				// A newline can't appear inside a JSON value, so unlike csv files, NDJSON
+				// files can always be split at newlines.
+				regions, sizes, err = SplitLargeNDJSON(egCtx, cfg, info)
 			} else if info.FileMeta.Type == SourceTypeCSV && cfg.StrictFormat &&
 				info.FileMeta.Compression == CompressionNone &&
 				dataFileSize > cfg.MaxChunkSize+cfg.MaxChunkSize/largeCSVLowerThresholdRation {
@@ -390,6 +398,108 @@ func makeParquetFileRegion(
 	return []*TableRegion{region}, []float64{float64(dataFile.FileMeta.FileSize)}, nil
 }
 
+// avro files are split into blocks which can be compressed, so we can't know the offset of a row
+// without reading the blocks before it. like parquet files, the offset is read row number.
+// The ranges of the source database are the same, see LiveSource.
+func makeAvroFileRegion(
+	ctx context.Context,
+	cfg *DataDivideConfig,
+	dataFile FileInfo,
+) ([]*TableRegion, []float64, error) {
+	numberRows := dataFile.FileMeta.Rows
+	var err error
+	// for safety
+	if numberRows <= 0 {
+		if dataFile.FileMeta.Type == SourceTypeLive {
+			numberRows, err = ReadLiveFileRowCount(ctx, cfg.Store, dataFile.FileMeta)
+		} else {
+			numberRows, err = ReadAvroFileRowCount(ctx, cfg.Store, dataFile.FileMeta)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	region := &TableRegion{
+		DB:       cfg.TableMeta.DB,
+		Table:    cfg.TableMeta.Name,
+		FileMeta: dataFile.FileMeta,
+		Chunk: Chunk{
+			Offset:       0,
+			EndOffset:    numberRows,
+			RealOffset:   0,
+			PrevRowIDMax: 0,
+			RowIDMax:     numberRows,
+		},
+	}
+	return []*TableRegion{region}, []float64{float64(dataFile.FileMeta.FileSize)}, nil
+}
+
+// SplitLargeNDJSON splits a large NDJSON file into multiple regions, the size
+// of each regions is specified by `config.MaxRegionSize`. The regions end at
+// newlines.
+func SplitLargeNDJSON(
+	ctx context.Context,
+	cfg *DataDivideConfig,
+	dataFile FileInfo,
+) (regions []*TableRegion, dataFileSizes []float64, err error) {
+	maxRegionSize := cfg.MaxChunkSize
+	dataFileSizes = make([]float64, 0, dataFile.FileMeta.FileSize/maxRegionSize+1)
+	startOffset, endOffset := int64(0), maxRegionSize
+	var prevRowIdxMax int64
+	divisor := int64(cfg.ColumnCnt) + 2
+	for {
+		curRowsCnt := (endOffset - startOffset) / divisor
+		rowIDMax := prevRowIdxMax + curRowsCnt
+		if endOffset != dataFile.FileMeta.FileSize {
+			r, err := cfg.Store.Open(ctx, dataFile.FileMeta.Path, nil)
+			if err != nil {
+				return nil, nil, err
+			}
+			parser, err := NewNDJSONParser(ctx, nil, r, cfg.ReadBlockSize, cfg.IOWorkers)
+			if err != nil {
+				_ = r.Close()
+				return nil, nil, err
+			}
+			if err = parser.SetPos(endOffset, 0); err != nil {
+				_ = parser.Close()
+				return nil, nil, err
+			}
+			pos, err := parser.ReadUntilNewLine()
+			if err != nil {
+				if !errors.ErrorEqual(err, io.EOF) {
+					_ = parser.Close()
+					return nil, nil, err
+				}
+				pos = dataFile.FileMeta.FileSize
+			}
+			endOffset = pos
+			_ = parser.Close()
+		}
+		regions = append(regions,
+			&TableRegion{
+				DB:       cfg.TableMeta.DB,
+				Table:    cfg.TableMeta.Name,
+				FileMeta: dataFile.FileMeta,
+				Chunk: Chunk{
+					Offset:       startOffset,
+					EndOffset:    endOffset,
+					PrevRowIDMax: prevRowIdxMax,
+					RowIDMax:     rowIDMax,
+				},
+			})
+		dataFileSizes = append(dataFileSizes, float64(endOffset-startOffset))
+		prevRowIdxMax = rowIDMax
+		if endOffset == dataFile.FileMeta.FileSize {
+			break
+		}
+		startOffset = endOffset
+		if endOffset += maxRegionSize; endOffset > dataFile.FileMeta.FileSize {
+			endOffset = dataFile.FileMeta.FileSize
+		}
+	}
+	return regions, dataFileSizes, nil
+}
+
 // SplitLargeCSV splits a large csv file into multiple regions, the size of
 // each regions is specified by `config.MaxRegionSize`.
 // Note: We split the file coarsely, thus the format of csv file is needed to be
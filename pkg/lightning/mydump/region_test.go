@@ -18,6 +18,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/pingcap/tidb/br/pkg/storage"
@@ -582,3 +583,42 @@ func TestSplitLargeFileSeekInsideCRLF(t *testing.T) {
 	}
 	require.NoError(t, parser.Close())
 }
+
+func TestSplitLargeNDJSON(t *testing.T) {
+	meta := &MDTableMeta{
+		DB:   "ndjson",
+		Name: "large_ndjson_file",
+	}
+	dir := t.TempDir()
+	fileName := "test.ndjson"
+	content := []byte(strings.Repeat("{\"a\":1}\n", 5))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, fileName), content, 0o644))
+	fileInfo := FileInfo{FileMeta: SourceFileMeta{Path: fileName, Type: SourceTypeNDJSON, FileSize: int64(len(content))}}
+	store, err := storage.NewLocalStorage(dir)
+	require.NoError(t, err)
+	ioWorker := worker.NewPool(context.Background(), 4, "io")
+
+	for _, tc := range []struct {
+		maxRegionSize config.ByteSize
+		offsets       [][]int64
+	}{
+		{10, [][]int64{{0, 16}, {16, 32}, {32, 40}}},
+		{16, [][]int64{{0, 24}, {24, 40}}},
+	} {
+		cfg := &config.Config{
+			Mydumper: config.MydumperRuntime{
+				ReadBlockSize: config.ReadBlockSize,
+				MaxRegionSize: tc.maxRegionSize,
+			},
+		}
+		divideConfig := NewDataDivideConfig(cfg, 1, ioWorker, store, meta)
+		regions, sizes, err := SplitLargeNDJSON(context.Background(), divideConfig, fileInfo)
+		require.NoError(t, err)
+		require.Len(t, regions, len(tc.offsets))
+		require.Len(t, sizes, len(tc.offsets))
+		for i := range tc.offsets {
+			require.Equal(t, tc.offsets[i][0], regions[i].Chunk.Offset)
+			require.Equal(t, tc.offsets[i][1], regions[i].Chunk.EndOffset)
+		}
+	}
+}
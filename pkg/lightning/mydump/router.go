@@ -48,6 +48,12 @@ const (
 	SourceTypeParquet
 	// SourceTypeViewSchema means this source file is a schema file for the view.
 	SourceTypeViewSchema
+	// SourceTypeNDJSON means this source file is a newline-delimited JSON data file.
+	SourceTypeNDJSON
+	// SourceTypeAvro means this source file is an avro object container data file.
+	SourceTypeAvro
+	// SourceTypeLive means this source file is a range of a table in the source database, see LiveSource.
+	SourceTypeLive
 )
 
 const (
@@ -63,6 +69,14 @@ const (
 	TypeCSV = "csv"
 	// TypeParquet is the source type value for parquet data file.
 	TypeParquet = "parquet"
+	// TypeNDJSON is the source type value for newline-delimited JSON data file.
+	TypeNDJSON = "ndjson"
+	// TypeJSONLines is the alias of TypeNDJSON, it's the extension of JSON Lines files.
+	TypeJSONLines = "jsonl"
+	// TypeAvro is the source type value for avro data file.
+	TypeAvro = "avro"
+	// TypeLive is the source type value for a range of a table in the source database.
+	TypeLive = "live"
 	// TypeIgnore is the source type value for a ignored data file.
 	TypeIgnore = "ignore"
 )
@@ -116,6 +130,12 @@ func parseSourceType(t string) (SourceType, error) {
 		return SourceTypeCSV, nil
 	case TypeParquet:
 		return SourceTypeParquet, nil
+	case TypeNDJSON, TypeJSONLines:
+		return SourceTypeNDJSON, nil
+	case TypeAvro:
+		return SourceTypeAvro, nil
+	case TypeLive:
+		return SourceTypeLive, nil
 	case TypeIgnore:
 		return SourceTypeIgnore, nil
 	case ViewSchema:
@@ -137,6 +157,12 @@ func (s SourceType) String() string {
 		return TypeSQL
 	case SourceTypeParquet:
 		return TypeParquet
+	case SourceTypeNDJSON:
+		return TypeNDJSON
+	case SourceTypeAvro:
+		return TypeAvro
+	case SourceTypeLive:
+		return TypeLive
 	case SourceTypeViewSchema:
 		return ViewSchema
 	default:
@@ -144,6 +170,14 @@ func (s SourceType) String() string {
 	}
 }
 
+// IsRowCountPos returns whether the positions of the parser and the offsets of
+// the chunks are row counts instead of file offsets, such as parquet and avro
+// files which can't be split by file offsets, and the tables read from the
+// source database.
+func (s SourceType) IsRowCountPos() bool {
+	return s == SourceTypeParquet || s == SourceTypeAvro || s == SourceTypeLive
+}
+
 // ParseCompressionOnFileExtension parses the compression type from the file extension.
 func ParseCompressionOnFileExtension(filename string) Compression {
 	fileExt := strings.ToLower(filepath.Ext(filename))
@@ -185,7 +219,7 @@ var defaultFileRouteRules = []*config.FileRouteRule{
 	// ignore *-schema-trigger.sql, *-schema-post.sql files
 	{Pattern: `(?i).*(-schema-trigger|-schema-post)\.sql(?:\.(\w*?))?$`, Type: "ignore"},
 	// ignore backup files
-	{Pattern: `(?i).*\.(sql|csv|parquet)(\.(\w+))?\.(bak|BAK)$`, Type: "ignore"},
+	{Pattern: `(?i).*\.(sql|csv|parquet|ndjson|jsonl|avro)(\.(\w+))?\.(bak|BAK)$`, Type: "ignore"},
 	// db schema create file pattern, matches files like '{schema}-schema-create.sql[.{compress}]'
 	{Pattern: `(?i)^(?:[^/]*/)*([^/.]+)-schema-create\.sql(?:\.(\w*?))?$`,
 		Schema: "$1", Table: "", Type: SchemaSchema, Compression: "$2", Unescape: true},
@@ -195,8 +229,8 @@ var defaultFileRouteRules = []*config.FileRouteRule{
 	// view schema create file pattern, matches files like '{schema}.{table}-schema-view.sql[.{compress}]'
 	{Pattern: `(?i)^(?:[^/]*/)*([^/.]+)\.(.*?)-schema-view\.sql(?:\.(\w*?))?$`,
 		Schema: "$1", Table: "$2", Type: ViewSchema, Compression: "$3", Unescape: true},
-	// source file pattern, matches files like '{schema}.{table}.0001.{sql|csv}[.{compress}]'
-	{Pattern: `(?i)^(?:[^/]*/)*([^/.]+)\.(.*?)(?:\.([0-9]+))?\.(sql|csv|parquet)(?:\.(\w+))?$`,
+	// source file pattern, matches files like '{schema}.{table}.0001.{sql|csv|parquet|ndjson|jsonl|avro}[.{compress}]'
+	{Pattern: `(?i)^(?:[^/]*/)*([^/.]+)\.(.*?)(?:\.([0-9]+))?\.(sql|csv|parquet|ndjson|jsonl|avro)(?:\.(\w+))?$`,
 		Schema: "$1", Table: "$2", Type: "$4", Key: "$3", Compression: "$5", Unescape: true},
 }
 
@@ -361,6 +395,9 @@ func (p regexRouterParser) Parse(r *config.FileRouteRule, logger log.Logger) (*R
 			if result.Type == SourceTypeParquet && compression != CompressionNone {
 				return errors.Errorf("can't support whole compressed parquet file, should compress parquet files by choosing correct parquet compress writer, path: %s", r.Path)
 			}
+			if result.Type == SourceTypeAvro && compression != CompressionNone {
+				return errors.Errorf("can't support whole compressed avro file, should compress avro files by choosing correct avro codec, path: %s", r.Path)
+			}
 			result.Compression = compression
 			return nil
 		})
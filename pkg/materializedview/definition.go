@@ -0,0 +1,286 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package materializedview
+
+import (
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/format"
+)
+
+const (
+	// LogOpColumn is the column of the log table which records the kind of a
+	// row change, 1 for an inserted row and -1 for a deleted row. An updated
+	// row is recorded as a deleted row and an inserted row.
+	LogOpColumn = "_tidb_mlog_op"
+	// logTablePrefix is the name prefix of the log tables.
+	logTablePrefix = "mlog$_"
+)
+
+// keyRestoreFlags are used to restore an expression to its key. The table
+// names are ignored since the query has only one table.
+const keyRestoreFlags = format.RestoreStringSingleQuotes | format.RestoreKeyWordLowercase |
+	format.RestoreNameLowercase | format.RestoreNameBackQuotes |
+	format.RestoreWithoutSchemaName | format.RestoreWithoutTableName
+
+// sqlRestoreFlags are used to restore an expression to SQL which reads the
+// log table or the materialized view.
+const sqlRestoreFlags = format.DefaultRestoreFlags | format.RestoreWithoutSchemaName | format.RestoreWithoutTableName
+
+// LogTableName returns the name of the log table of a materialized view.
+func LogTableName(view string) string {
+	name := logTablePrefix + view
+	if len(name) > 64 {
+		name = name[:64]
+	}
+	return name
+}
+
+// FieldKind is the kind of a field in the SELECT list of a materialized view.
+type FieldKind int
+
+// FieldKind types.
+const (
+	// FieldGroupBy is a column in the GROUP BY clause.
+	FieldGroupBy FieldKind = iota
+	// FieldCountAll is COUNT(*), the COUNT of a non-NULL constant is also
+	// regarded as COUNT(*).
+	FieldCountAll
+	FieldCount
+	FieldSum
+	FieldMin
+	FieldMax
+)
+
+// Field is a field in the SELECT list of a materialized view.
+type Field struct {
+	Kind FieldKind
+	// Column is the column of the materialized view which stores the field.
+	Column ast.CIStr
+	// Arg is the argument of the aggregate function, or the column of a
+	// FieldGroupBy field. It is nil for a FieldCountAll field.
+	Arg ast.ExprNode
+	// Key identifies Arg, the expressions with the same key are regarded as
+	// the same expression.
+	Key string
+}
+
+// Definition is the analyzed definition of a materialized view which
+// aggregates a single table.
+type Definition struct {
+	Table    *ast.TableName
+	Where    ast.ExprNode
+	WhereKey string
+	Fields   []*Field
+	// GroupBy are the indexes of the FieldGroupBy fields in Fields.
+	GroupBy []int
+}
+
+// Analyze analyzes the SELECT statement of a materialized view. cols are the
+// columns of the view, in the order of the fields. It returns nil if the
+// statement isn't an aggregate query of a single table, the view can still be
+// completely refreshed, but it can't be fast refreshed or used to rewrite a
+// query.
+func Analyze(stmt ast.StmtNode, cols []ast.CIStr) *Definition {
+	sel, ok := stmt.(*ast.SelectStmt)
+	if !ok || sel.Kind != ast.SelectStmtKindSelect || sel.Distinct || sel.With != nil ||
+		sel.Having != nil || sel.OrderBy != nil || sel.Limit != nil || len(sel.WindowSpecs) > 0 ||
+		sel.LockInfo != nil || sel.SelectIntoOpt != nil || sel.Fields == nil || len(sel.Fields.Fields) != len(cols) {
+		return nil
+	}
+	tn := singleTable(sel)
+	if tn == nil {
+		return nil
+	}
+	d := &Definition{Table: tn, Where: sel.Where}
+	if sel.Where != nil {
+		if !isSimpleExpr(sel.Where) {
+			return nil
+		}
+		d.WhereKey = exprKey(sel.Where)
+	}
+
+	groupBy := make(map[string]bool)
+	if sel.GroupBy != nil {
+		if sel.GroupBy.Rollup {
+			return nil
+		}
+		for _, item := range sel.GroupBy.Items {
+			col, ok := item.Expr.(*ast.ColumnNameExpr)
+			if !ok {
+				return nil
+			}
+			groupBy[exprKey(col)] = false
+		}
+	}
+	hasAgg := false
+	for i, f := range sel.Fields.Fields {
+		if f.WildCard != nil {
+			return nil
+		}
+		field := &Field{Column: cols[i]}
+		switch x := f.Expr.(type) {
+		case *ast.ColumnNameExpr:
+			key := exprKey(x)
+			selected, ok := groupBy[key]
+			if !ok || selected {
+				return nil
+			}
+			groupBy[key] = true
+			field.Kind, field.Arg, field.Key = FieldGroupBy, x, key
+			d.GroupBy = append(d.GroupBy, i)
+		case *ast.AggregateFuncExpr:
+			kind, arg, ok := aggregateField(x)
+			if !ok {
+				return nil
+			}
+			field.Kind, field.Arg = kind, arg
+			if arg != nil {
+				field.Key = exprKey(arg)
+			}
+			hasAgg = true
+		default:
+			return nil
+		}
+		d.Fields = append(d.Fields, field)
+	}
+	// All the GROUP BY columns must be selected to merge the changes of a group.
+	for _, selected := range groupBy {
+		if !selected {
+			return nil
+		}
+	}
+	if !hasAgg {
+		return nil
+	}
+	return d
+}
+
+// CheckFastRefresh checks whether the view can be fast refreshed.
+func (d *Definition) CheckFastRefresh() error {
+	var hasCountAll bool
+	counts := make(map[string]bool)
+	for _, f := range d.Fields {
+		switch f.Kind {
+		case FieldCountAll:
+			hasCountAll = true
+		case FieldCount:
+			counts[f.Key] = true
+		case FieldMin, FieldMax:
+			return errors.New("MIN and MAX are not supported")
+		}
+	}
+	if !hasCountAll {
+		return errors.New("COUNT(*) is required")
+	}
+	for _, f := range d.Fields {
+		if f.Kind == FieldSum && !counts[f.Key] {
+			return errors.Errorf("COUNT(%s) is required for SUM(%s)", f.Key, f.Key)
+		}
+	}
+	return nil
+}
+
+// countField returns the index of the field which counts the non-NULL values
+// of key, or counts all the rows if key is empty. It returns -1 if there is
+// no such field.
+func (d *Definition) countField(key string) int {
+	for i, f := range d.Fields {
+		if key == "" && f.Kind == FieldCountAll || key != "" && f.Kind == FieldCount && f.Key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// singleTable returns the table if the FROM clause has only one table.
+func singleTable(sel *ast.SelectStmt) *ast.TableName {
+	if sel.From == nil || sel.From.TableRefs == nil || sel.From.TableRefs.Right != nil {
+		return nil
+	}
+	ts, ok := sel.From.TableRefs.Left.(*ast.TableSource)
+	if !ok {
+		return nil
+	}
+	tn, ok := ts.Source.(*ast.TableName)
+	if !ok || tn.AsOf != nil || len(tn.PartitionNames) > 0 {
+		return nil
+	}
+	return tn
+}
+
+func aggregateField(agg *ast.AggregateFuncExpr) (FieldKind, ast.ExprNode, bool) {
+	if agg.Distinct || len(agg.Args) != 1 || !isSimpleExpr(agg.Args[0]) {
+		return 0, nil, false
+	}
+	arg := agg.Args[0]
+	switch strings.ToLower(agg.F) {
+	case ast.AggFuncCount:
+		if v, ok := arg.(ast.ValueExpr); ok && v.GetValue() != nil {
+			return FieldCountAll, nil, true
+		}
+		return FieldCount, arg, true
+	case ast.AggFuncSum:
+		return FieldSum, arg, true
+	case ast.AggFuncMin:
+		return FieldMin, arg, true
+	case ast.AggFuncMax:
+		return FieldMax, arg, true
+	}
+	return 0, nil, false
+}
+
+// isSimpleExpr checks whether the expression only reads the columns of the
+// current row, so it can be evaluated on the rows of the log table.
+func isSimpleExpr(expr ast.ExprNode) bool {
+	checker := &simpleExprChecker{simple: true}
+	expr.Accept(checker)
+	return checker.simple
+}
+
+type simpleExprChecker struct {
+	simple bool
+}
+
+func (c *simpleExprChecker) Enter(in ast.Node) (ast.Node, bool) {
+	switch in.(type) {
+	case *ast.SubqueryExpr, *ast.ExistsSubqueryExpr, *ast.AggregateFuncExpr, *ast.WindowFuncExpr,
+		*ast.VariableExpr, *ast.DefaultExpr, *ast.PositionExpr, ast.ParamMarkerExpr:
+		c.simple = false
+		return in, true
+	}
+	return in, false
+}
+
+func (c *simpleExprChecker) Leave(in ast.Node) (ast.Node, bool) {
+	return in, c.simple
+}
+
+func exprKey(expr ast.ExprNode) string {
+	return restoreExpr(expr, keyRestoreFlags)
+}
+
+func exprSQL(expr ast.ExprNode) string {
+	return restoreExpr(expr, sqlRestoreFlags)
+}
+
+func restoreExpr(expr ast.ExprNode, flags format.RestoreFlags) string {
+	var sb strings.Builder
+	// The expressions are parsed from SQL, so they can always be restored.
+	_ = expr.Restore(format.NewRestoreCtx(flags, &sb))
+	return sb.String()
+}
@@ -0,0 +1,180 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package materializedview
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	_ "github.com/pingcap/tidb/pkg/types/parser_driver"
+	"github.com/stretchr/testify/require"
+)
+
+func analyze(t *testing.T, sql string, cols ...string) *Definition {
+	stmt, err := parser.New().ParseOneStmt(sql, "", "")
+	require.NoError(t, err)
+	names := make([]ast.CIStr, 0, len(cols))
+	for _, col := range cols {
+		names = append(names, ast.NewCIStr(col))
+	}
+	return Analyze(stmt, names)
+}
+
+func TestLogTableName(t *testing.T) {
+	require.Equal(t, "mlog$_mv", LogTableName("mv"))
+	require.Len(t, LogTableName(strings.Repeat("a", 64)), 64)
+}
+
+func TestAnalyze(t *testing.T) {
+	d := analyze(t, "select a, count(*), sum(b + 1), count(b + 1), min(c) from test.t where c > 1 group by a",
+		"a", "cnt", "s", "c", "m")
+	require.NotNil(t, d)
+	require.Equal(t, "t", d.Table.Name.L)
+	require.Equal(t, "`c`>1", d.WhereKey)
+	require.Equal(t, []int{0}, d.GroupBy)
+	kinds := make([]FieldKind, 0, len(d.Fields))
+	for _, f := range d.Fields {
+		kinds = append(kinds, f.Kind)
+	}
+	require.Equal(t, []FieldKind{FieldGroupBy, FieldCountAll, FieldSum, FieldCount, FieldMin}, kinds)
+	require.Equal(t, "`b`+1", d.Fields[2].Key)
+	require.EqualError(t, d.CheckFastRefresh(), "MIN and MAX are not supported")
+
+	d = analyze(t, "select count(1), sum(a) from t", "cnt", "s")
+	require.NotNil(t, d)
+	require.Equal(t, FieldCountAll, d.Fields[0].Kind)
+	require.EqualError(t, d.CheckFastRefresh(), "COUNT(`a`) is required for SUM(`a`)")
+	d = analyze(t, "select sum(a), count(a) from t", "s", "c")
+	require.EqualError(t, d.CheckFastRefresh(), "COUNT(*) is required")
+	d = analyze(t, "select t.a, count(*), sum(a), count(a) from t group by a", "a", "cnt", "s", "c")
+	require.NoError(t, d.CheckFastRefresh())
+
+	for _, sql := range []string{
+		"select a from t group by a",
+		"select a, count(*) from t",
+		"select count(*) from t group by a",
+		"select a + 1, count(*) from t group by a",
+		"select distinct a, count(*) from t group by a",
+		"select a, count(*) from t group by a having count(*) > 1",
+		"select a, count(*) from t group by a order by a",
+		"select a, count(*) from t group by a limit 1",
+		"select a, count(*) from t group by a with rollup",
+		"select a, count(distinct b) from t group by a",
+		"select a, count(*) from t, s group by a",
+		"select a, count(*) from t where b in (select b from s) group by a",
+		"select a, count(*) from t where b > @x group by a",
+		"select a, count(*) from t as of timestamp now() group by a",
+		"select a, group_concat(b) from t group by a",
+	} {
+		stmt, err := parser.New().ParseOneStmt(sql, "", "")
+		require.NoError(t, err, sql)
+		sel := stmt.(*ast.SelectStmt)
+		cols := make([]ast.CIStr, len(sel.Fields.Fields))
+		require.Nil(t, Analyze(stmt, cols), sql)
+	}
+}
+
+func TestRefreshSQLs(t *testing.T) {
+	view := ast.Ident{Schema: ast.NewCIStr("test"), Name: ast.NewCIStr("mv")}
+	log := ast.Ident{Schema: ast.NewCIStr("test"), Name: ast.NewCIStr("mlog$_mv")}
+	require.Equal(t, []string{
+		"DELETE FROM `test`.`mv`",
+		"INSERT INTO `test`.`mv` (`a`, `cnt`) SELECT 1",
+		"DELETE FROM `test`.`mlog$_mv`",
+	}, CompleteRefreshSQLs(view, []ast.CIStr{ast.NewCIStr("a"), ast.NewCIStr("cnt")}, "SELECT 1", &log))
+
+	d := analyze(t, "select a, count(*), sum(b), count(b) from t where c > 1 group by a", "a", "cnt", "s", "c")
+	require.NoError(t, d.CheckFastRefresh())
+	delta := "SELECT `a` AS `c0`, SUM(`_tidb_mlog_op`) AS `c1`, SUM((`b`) * `_tidb_mlog_op`) AS `c2`, " +
+		"SUM(IF((`b`) IS NULL, 0, `_tidb_mlog_op`)) AS `c3` FROM `test`.`mlog$_mv` WHERE `c`>1 GROUP BY `a`"
+	require.Equal(t, []string{
+		"UPDATE `test`.`mv` AS `m`, (" + delta + ") AS `d` SET " +
+			"`m`.`s` = IF(`m`.`c` + IFNULL(`d`.`c3`, 0) = 0, NULL, IFNULL(`m`.`s`, 0) + IFNULL(`d`.`c2`, 0)), " +
+			"`m`.`c` = `m`.`c` + IFNULL(`d`.`c3`, 0), `m`.`cnt` = `m`.`cnt` + IFNULL(`d`.`c1`, 0) " +
+			"WHERE `m`.`a` <=> `d`.`c0`",
+		"INSERT INTO `test`.`mv` (`a`, `cnt`, `s`, `c`) SELECT `d`.`c0`, `d`.`c1`, IF(`d`.`c3` = 0, NULL, `d`.`c2`), `d`.`c3` " +
+			"FROM (" + delta + ") AS `d` WHERE NOT EXISTS (SELECT 1 FROM `test`.`mv` AS `m` WHERE `m`.`a` <=> `d`.`c0`)",
+		"DELETE FROM `test`.`mv` WHERE `cnt` = 0",
+		"DELETE FROM `test`.`mlog$_mv`",
+	}, d.FastRefreshSQLs(view, log))
+
+	d = analyze(t, "select count(*) from t", "cnt")
+	require.Equal(t, []string{
+		"UPDATE `test`.`mv` AS `m`, (SELECT SUM(`_tidb_mlog_op`) AS `c0` FROM `test`.`mlog$_mv`) AS `d` SET " +
+			"`m`.`cnt` = `m`.`cnt` + IFNULL(`d`.`c0`, 0)",
+		"DELETE FROM `test`.`mlog$_mv`",
+	}, d.FastRefreshSQLs(view, log))
+}
+
+func TestRewrite(t *testing.T) {
+	view := ast.Ident{Schema: ast.NewCIStr("test"), Name: ast.NewCIStr("mv")}
+	d := analyze(t, "select a, b, count(*), sum(c), count(c), max(c) from t where d = 1 group by a, b",
+		"ga", "gb", "cnt", "s", "sc", "mx")
+	require.NotNil(t, d)
+
+	for _, c := range []struct {
+		sql      string
+		expected string
+	}{
+		{
+			"select a, b, count(*) from t where d = 1 group by b, a",
+			"SELECT `ga` AS `a`,`gb` AS `b`,`cnt` AS `count(*)` FROM `test`.`mv`",
+		},
+		{
+			"select b as x, avg(c), max(c) from t where t.d = 1 group by a, b having count(c) > 1 order by x, sum(c) desc limit 2",
+			"SELECT `gb` AS `x`,`s`/`sc` AS `avg(c)`,`mx` AS `max(c)` FROM `test`.`mv` WHERE `sc`>1 ORDER BY `x`,`s` DESC LIMIT 2",
+		},
+		{
+			"select a, count(*) c1, count(c), sum(c), avg(c) from t where d = 1 group by a having c1 > 1 order by a",
+			"SELECT `ga` AS `a`,CAST(IFNULL(SUM(`cnt`), 0) AS SIGNED) AS `c1`,CAST(IFNULL(SUM(`sc`), 0) AS SIGNED) AS `count(c)`," +
+				"SUM(`s`) AS `sum(c)`,SUM(`s`)/SUM(`sc`) AS `avg(c)` FROM `test`.`mv` GROUP BY `ga` HAVING `c1`>1 ORDER BY `ga`",
+		},
+		{
+			"select max(c) + 1 from t where d = 1",
+			"SELECT MAX(`mx`)+1 AS `max(c) + 1` FROM `test`.`mv`",
+		},
+	} {
+		stmt, err := parser.New().ParseOneStmt(c.sql, "", "")
+		require.NoError(t, err, c.sql)
+		sel, ok := d.Rewrite(stmt.(*ast.SelectStmt), view)
+		require.True(t, ok, c.sql)
+		var sb strings.Builder
+		require.NoError(t, sel.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)))
+		require.Equal(t, c.expected, sb.String(), c.sql)
+	}
+
+	for _, sql := range []string{
+		"select a, count(*) from t group by a",
+		"select a, count(*) from t where d = 2 group by a",
+		"select c, count(*) from t where d = 1 group by c",
+		"select a, min(c) from t where d = 1 group by a",
+		"select a, count(distinct c) from t where d = 1 group by a",
+		"select a, count(*) from t where d = 1 group by a having sum(d) > 1",
+		"select a, count(*) from t where d = 1 group by a order by c",
+		"select a, count(*) from t where d = 1 group by a having count(*) > (select 1)",
+		"select a, b, count(*) c1 from t where d = 1 group by a, b having c1 > 1",
+		"select a, count(*) from t where d = 1 group by a for update",
+		"select a from t where d = 1",
+		"select * from t where d = 1 group by a",
+	} {
+		stmt, err := parser.New().ParseOneStmt(sql, "", "")
+		require.NoError(t, err, sql)
+		_, ok := d.Rewrite(stmt.(*ast.SelectStmt), view)
+		require.False(t, ok, sql)
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package materializedview
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/util/sqlescape"
+)
+
+// CompleteRefreshSQLs returns the statements to completely refresh the view.
+// They should be executed in an optimistic transaction, so the log table only
+// loses the changes which are read by the definition.
+func CompleteRefreshSQLs(view ast.Ident, cols []ast.CIStr, definition string, log *ast.Ident) []string {
+	sqls := make([]string, 0, 3)
+	sqls = append(sqls, sqlescape.MustEscapeSQL("DELETE FROM %n.%n", view.Schema.O, view.Name.O))
+	var sb strings.Builder
+	sqlescape.MustFormatSQL(&sb, "INSERT INTO %n.%n (", view.Schema.O, view.Name.O)
+	for i, col := range cols {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sqlescape.MustFormatSQL(&sb, "%n", col.O)
+	}
+	sb.WriteString(") ")
+	sb.WriteString(definition)
+	sqls = append(sqls, sb.String())
+	if log != nil {
+		sqls = append(sqls, sqlescape.MustEscapeSQL("DELETE FROM %n.%n", log.Schema.O, log.Name.O))
+	}
+	return sqls
+}
+
+// FastRefreshSQLs returns the statements to merge the row changes recorded in
+// the log table into the view, and then clear the log table. Like
+// CompleteRefreshSQLs, they should be executed in an optimistic transaction.
+// The caller should make sure the view can be fast refreshed.
+func (d *Definition) FastRefreshSQLs(view, log ast.Ident) []string {
+	delta := d.deltaSQL(log)
+	sqls := make([]string, 0, 4)
+
+	// Update the existing groups. The SUM fields are assigned before the COUNT
+	// fields, so they always read the old counts.
+	var sb strings.Builder
+	sqlescape.MustFormatSQL(&sb, "UPDATE %n.%n AS `m`, (", view.Schema.O, view.Name.O)
+	sb.WriteString(delta)
+	sb.WriteString(") AS `d` SET ")
+	first := true
+	for _, kind := range []FieldKind{FieldSum, FieldCount, FieldCountAll} {
+		for i, f := range d.Fields {
+			if f.Kind != kind {
+				continue
+			}
+			if !first {
+				sb.WriteString(", ")
+			}
+			first = false
+			col, dcol := f.Column.O, deltaColumn(i)
+			if kind == FieldSum {
+				cnt := d.countField(f.Key)
+				sqlescape.MustFormatSQL(&sb, "`m`.%n = IF(`m`.%n + IFNULL(`d`.%n, 0) = 0, NULL, IFNULL(`m`.%n, 0) + IFNULL(`d`.%n, 0))",
+					col, d.Fields[cnt].Column.O, deltaColumn(cnt), col, dcol)
+			} else {
+				sqlescape.MustFormatSQL(&sb, "`m`.%n = `m`.%n + IFNULL(`d`.%n, 0)", col, col, dcol)
+			}
+		}
+	}
+	if len(d.GroupBy) > 0 {
+		sb.WriteString(" WHERE ")
+		d.writeGroupMatch(&sb)
+	}
+	sqls = append(sqls, sb.String())
+
+	if len(d.GroupBy) > 0 {
+		// Insert the new groups, and then delete the groups which have no rows.
+		sb.Reset()
+		sqlescape.MustFormatSQL(&sb, "INSERT INTO %n.%n (", view.Schema.O, view.Name.O)
+		for i, f := range d.Fields {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sqlescape.MustFormatSQL(&sb, "%n", f.Column.O)
+		}
+		sb.WriteString(") SELECT ")
+		for i, f := range d.Fields {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			if f.Kind == FieldSum {
+				sqlescape.MustFormatSQL(&sb, "IF(`d`.%n = 0, NULL, `d`.%n)", deltaColumn(d.countField(f.Key)), deltaColumn(i))
+			} else {
+				sqlescape.MustFormatSQL(&sb, "`d`.%n", deltaColumn(i))
+			}
+		}
+		sb.WriteString(" FROM (")
+		sb.WriteString(delta)
+		sqlescape.MustFormatSQL(&sb, ") AS `d` WHERE NOT EXISTS (SELECT 1 FROM %n.%n AS `m` WHERE ", view.Schema.O, view.Name.O)
+		d.writeGroupMatch(&sb)
+		sb.WriteString(")")
+		sqls = append(sqls, sb.String())
+		sqls = append(sqls, sqlescape.MustEscapeSQL("DELETE FROM %n.%n WHERE %n = 0",
+			view.Schema.O, view.Name.O, d.Fields[d.countField("")].Column.O))
+	}
+	sqls = append(sqls, sqlescape.MustEscapeSQL("DELETE FROM %n.%n", log.Schema.O, log.Name.O))
+	return sqls
+}
+
+// deltaSQL returns the query which aggregates the row changes in the log
+// table, its fields are named by deltaColumn.
+func (d *Definition) deltaSQL(log ast.Ident) string {
+	var sb strings.Builder
+	sb.WriteString("SELECT ")
+	for i, f := range d.Fields {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		switch f.Kind {
+		case FieldGroupBy:
+			sb.WriteString(exprSQL(f.Arg))
+		case FieldCountAll:
+			sqlescape.MustFormatSQL(&sb, "SUM(%n)", LogOpColumn)
+		case FieldCount:
+			sb.WriteString("SUM(IF((")
+			sb.WriteString(exprSQL(f.Arg))
+			sqlescape.MustFormatSQL(&sb, ") IS NULL, 0, %n))", LogOpColumn)
+		case FieldSum:
+			sb.WriteString("SUM((")
+			sb.WriteString(exprSQL(f.Arg))
+			sqlescape.MustFormatSQL(&sb, ") * %n)", LogOpColumn)
+		}
+		sqlescape.MustFormatSQL(&sb, " AS %n", deltaColumn(i))
+	}
+	sqlescape.MustFormatSQL(&sb, " FROM %n.%n", log.Schema.O, log.Name.O)
+	if d.Where != nil {
+		sb.WriteString(" WHERE ")
+		sb.WriteString(exprSQL(d.Where))
+	}
+	if len(d.GroupBy) > 0 {
+		sb.WriteString(" GROUP BY ")
+		for i, idx := range d.GroupBy {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(exprSQL(d.Fields[idx].Arg))
+		}
+	}
+	return sb.String()
+}
+
+// writeGroupMatch writes the condition which matches the groups of the view
+// `m` and the delta `d`. The NULL values are in the same group.
+func (d *Definition) writeGroupMatch(sb *strings.Builder) {
+	for i, idx := range d.GroupBy {
+		if i > 0 {
+			sb.WriteString(" AND ")
+		}
+		sqlescape.MustFormatSQL(sb, "`m`.%n <=> `d`.%n", d.Fields[idx].Column.O, deltaColumn(idx))
+	}
+}
+
+func deltaColumn(i int) string {
+	return fmt.Sprintf("c%d", i)
+}
@@ -0,0 +1,288 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package materializedview
+
+import (
+	"strings"
+
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/util/sqlescape"
+)
+
+// Rewrite rewrites an aggregate query of the base table to read the view. The
+// query must have the same WHERE clause as the definition, and group by a
+// subset of the GROUP BY columns of the definition. If it groups by all of
+// them, every group of the query is a row of the view, otherwise the rows of
+// the view are aggregated again.
+//
+// The query isn't modified, the rewritten query is a new statement. It returns
+// false if the query can't be rewritten.
+func (d *Definition) Rewrite(sel *ast.SelectStmt, view ast.Ident) (*ast.SelectStmt, bool) {
+	if sel.Kind != ast.SelectStmtKindSelect || sel.Distinct || sel.With != nil || len(sel.WindowSpecs) > 0 ||
+		sel.LockInfo != nil || sel.SelectIntoOpt != nil || sel.Fields == nil || singleTable(sel) == nil {
+		return nil, false
+	}
+	whereKey := ""
+	if sel.Where != nil {
+		whereKey = exprKey(sel.Where)
+	}
+	if whereKey != d.WhereKey {
+		return nil, false
+	}
+
+	// Copy the query by restoring and parsing it again.
+	var sb strings.Builder
+	if err := sel.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+		return nil, false
+	}
+	stmt, err := parser.New().ParseOneStmt(sb.String(), "", "")
+	if err != nil {
+		return nil, false
+	}
+	cp, ok := stmt.(*ast.SelectStmt)
+	if !ok || len(cp.Fields.Fields) != len(sel.Fields.Fields) {
+		return nil, false
+	}
+
+	r := &rewriter{d: d, aliases: make(map[string]bool)}
+	if cp.GroupBy != nil {
+		if cp.GroupBy.Rollup {
+			return nil, false
+		}
+		groups := make(map[string]bool)
+		for _, item := range cp.GroupBy.Items {
+			col, ok := item.Expr.(*ast.ColumnNameExpr)
+			if !ok || d.groupField(exprKey(col)) < 0 {
+				return nil, false
+			}
+			groups[exprKey(col)] = true
+		}
+		r.exact = len(groups) == len(d.GroupBy)
+	} else {
+		r.exact = len(d.GroupBy) == 0
+	}
+
+	for i, f := range cp.Fields.Fields {
+		if f.WildCard != nil {
+			return nil, false
+		}
+		// Keep the names of the fields.
+		if f.AsName.L == "" {
+			f.AsName = ast.NewCIStr(fieldName(sel.Fields.Fields[i]))
+		}
+		r.aliases[f.AsName.L] = true
+		if f.Expr = r.rewrite(f.Expr, false); f.Expr == nil {
+			return nil, false
+		}
+	}
+	if cp.GroupBy != nil {
+		for _, item := range cp.GroupBy.Items {
+			if item.Expr = r.rewrite(item.Expr, false); item.Expr == nil {
+				return nil, false
+			}
+		}
+	}
+	if cp.Having != nil {
+		// If every group is a row of the view, the HAVING clause is evaluated
+		// on the rows, where the aliases of the fields can't be referenced.
+		if cp.Having.Expr = r.rewrite(cp.Having.Expr, !r.exact); cp.Having.Expr == nil {
+			return nil, false
+		}
+	}
+	if cp.OrderBy != nil {
+		for _, item := range cp.OrderBy.Items {
+			if item.Expr = r.rewrite(item.Expr, true); item.Expr == nil {
+				return nil, false
+			}
+		}
+	}
+	if !r.hasAgg && cp.GroupBy == nil {
+		return nil, false
+	}
+
+	cp.From = &ast.TableRefsClause{TableRefs: &ast.Join{Left: &ast.TableSource{
+		Source: &ast.TableName{Schema: view.Schema, Name: view.Name},
+	}}}
+	cp.Where = nil
+	cp.TableHints = nil
+	if r.exact {
+		cp.GroupBy = nil
+		if cp.Having != nil {
+			cp.Where = cp.Having.Expr
+			cp.Having = nil
+		}
+	}
+	return cp, true
+}
+
+// groupField returns the index of the FieldGroupBy field with the key, or -1
+// if there is no such field.
+func (d *Definition) groupField(key string) int {
+	for _, idx := range d.GroupBy {
+		if d.Fields[idx].Key == key {
+			return idx
+		}
+	}
+	return -1
+}
+
+// aggField returns the index of the aggregate field, or -1 if there is no such
+// field.
+func (d *Definition) aggField(kind FieldKind, key string) int {
+	if kind == FieldCountAll || kind == FieldCount {
+		return d.countField(key)
+	}
+	for i, f := range d.Fields {
+		if f.Kind == kind && f.Key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// fieldName returns the name of a field without an alias, it's the same as
+// the name given by the planner.
+func fieldName(f *ast.SelectField) string {
+	if col, ok := f.Expr.(*ast.ColumnNameExpr); ok {
+		return col.Name.Name.O
+	}
+	if text := f.Text(); text != "" {
+		return text
+	}
+	return restoreExpr(f.Expr, format.DefaultRestoreFlags)
+}
+
+// rewriter rewrites the expressions of the query to read the view.
+type rewriter struct {
+	d     *Definition
+	exact bool
+	// aliases are the lowercase names of the fields.
+	aliases    map[string]bool
+	allowAlias bool
+	hasAgg     bool
+	failed     bool
+}
+
+// rewrite returns nil if the expression can't be rewritten.
+func (r *rewriter) rewrite(expr ast.ExprNode, allowAlias bool) ast.ExprNode {
+	r.allowAlias = allowAlias
+	node, ok := expr.Accept(r)
+	if !ok || r.failed {
+		return nil
+	}
+	return node.(ast.ExprNode)
+}
+
+func (r *rewriter) Enter(in ast.Node) (ast.Node, bool) {
+	switch x := in.(type) {
+	case *ast.AggregateFuncExpr:
+		// The arguments are rewritten with the function.
+		return in, true
+	case *ast.SubqueryExpr, *ast.ExistsSubqueryExpr, *ast.WindowFuncExpr, *ast.DefaultExpr, ast.ParamMarkerExpr:
+		r.failed = true
+		return in, true
+	case *ast.VariableExpr:
+		if x.Value != nil {
+			r.failed = true
+			return in, true
+		}
+	}
+	return in, false
+}
+
+func (r *rewriter) Leave(in ast.Node) (ast.Node, bool) {
+	if r.failed {
+		return in, false
+	}
+	switch x := in.(type) {
+	case *ast.AggregateFuncExpr:
+		r.hasAgg = true
+		if expr := r.aggregate(x); expr != nil {
+			return expr, true
+		}
+		r.failed = true
+		return in, false
+	case *ast.ColumnNameExpr:
+		if idx := r.d.groupField(exprKey(x)); idx >= 0 {
+			return &ast.ColumnNameExpr{Name: &ast.ColumnName{Name: r.d.Fields[idx].Column}}, true
+		}
+		if r.allowAlias && x.Name.Schema.L == "" && x.Name.Table.L == "" && r.aliases[x.Name.Name.L] {
+			return in, true
+		}
+		r.failed = true
+		return in, false
+	}
+	return in, true
+}
+
+// aggregate returns the expression which reads the aggregate function from the
+// view, or nil if the function isn't stored by the view.
+func (r *rewriter) aggregate(agg *ast.AggregateFuncExpr) ast.ExprNode {
+	if strings.ToLower(agg.F) == ast.AggFuncAvg {
+		if agg.Distinct || len(agg.Args) != 1 {
+			return nil
+		}
+		key := exprKey(agg.Args[0])
+		sum, cnt := r.d.aggField(FieldSum, key), r.d.aggField(FieldCount, key)
+		if sum < 0 || cnt < 0 {
+			return nil
+		}
+		sumCol, cntCol := r.d.Fields[sum].Column.O, r.d.Fields[cnt].Column.O
+		if r.exact {
+			return parseExpr(sqlescape.MustEscapeSQL("%n / %n", sumCol, cntCol))
+		}
+		return parseExpr(sqlescape.MustEscapeSQL("SUM(%n) / SUM(%n)", sumCol, cntCol))
+	}
+
+	kind, arg, ok := aggregateField(agg)
+	if !ok {
+		return nil
+	}
+	key := ""
+	if arg != nil {
+		key = exprKey(arg)
+	}
+	idx := r.d.aggField(kind, key)
+	if idx < 0 {
+		return nil
+	}
+	col := r.d.Fields[idx].Column.O
+	if r.exact {
+		return &ast.ColumnNameExpr{Name: &ast.ColumnName{Name: r.d.Fields[idx].Column}}
+	}
+	switch kind {
+	case FieldCountAll, FieldCount:
+		return parseExpr(sqlescape.MustEscapeSQL("CAST(IFNULL(SUM(%n), 0) AS SIGNED)", col))
+	case FieldSum:
+		return parseExpr(sqlescape.MustEscapeSQL("SUM(%n)", col))
+	case FieldMin:
+		return parseExpr(sqlescape.MustEscapeSQL("MIN(%n)", col))
+	case FieldMax:
+		return parseExpr(sqlescape.MustEscapeSQL("MAX(%n)", col))
+	}
+	return nil
+}
+
+// parseExpr parses an expression built by the rewriter, it returns nil if the
+// expression can't be parsed.
+func parseExpr(expr string) ast.ExprNode {
+	stmt, err := parser.New().ParseOneStmt("SELECT "+expr, "", "")
+	if err != nil {
+		return nil
+	}
+	return stmt.(*ast.SelectStmt).Fields.Fields[0].Expr
+}
@@ -105,6 +105,10 @@ var BDRActionMap = map[DDLBDRType][]ActionType{
 		ActionModifyEngineAttribute,
 		ActionAlterTableMode,
 		ActionRefreshMeta,
+		ActionCreateTrigger,
+		ActionDropTrigger,
+		ActionCreateMaterializedView,
+		ActionDropMaterializedView,
 	},
 	UnmanagementDDL: {
 		ActionCreatePlacementPolicy,
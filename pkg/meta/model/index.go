@@ -282,7 +282,13 @@ func (index *IndexInfo) IsPublic() bool {
 // IsColumnarIndex checks whether the index is a columnar index.
 // Columnar index only exists in TiFlash, no actual index data need to be written to KV layer.
 func (index *IndexInfo) IsColumnarIndex() bool {
-	return index.VectorInfo != nil || index.InvertedInfo != nil || index.FullTextInfo != nil
+	return index.VectorInfo != nil || index.InvertedInfo != nil || (index.FullTextInfo != nil && !index.MVIndex)
+}
+
+// IsRowStoreFullTextIndex checks whether the index is a FULLTEXT index stored in TiKV.
+// It's a multi-valued index, every row has an index entry for each distinct token of the column.
+func (index *IndexInfo) IsRowStoreFullTextIndex() bool {
+	return index.FullTextInfo != nil && index.MVIndex
 }
 
 // GetColumnarIndexType returns the type of columnar index.
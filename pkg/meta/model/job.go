@@ -115,6 +115,10 @@ const (
 	ActionModifyEngineAttribute  ActionType = 74
 	ActionAlterTableMode         ActionType = 75
 	ActionRefreshMeta            ActionType = 76
+	ActionCreateTrigger          ActionType = 77
+	ActionDropTrigger            ActionType = 78
+	ActionCreateMaterializedView ActionType = 79
+	ActionDropMaterializedView   ActionType = 80
 )
 
 // ActionMap is the map of DDL ActionType to string.
@@ -190,6 +194,10 @@ var ActionMap = map[ActionType]string{
 	ActionModifyEngineAttribute:         "modify engine attribute",
 	ActionAlterTableMode:                "alter table mode",
 	ActionRefreshMeta:                   "refresh meta",
+	ActionCreateTrigger:                 "create trigger",
+	ActionDropTrigger:                   "drop trigger",
+	ActionCreateMaterializedView:        "create materialized view",
+	ActionDropMaterializedView:          "drop materialized view",
 
 	// `ActionAlterTableAlterPartition` is removed and will never be used.
 	// Just left a tombstone here for compatibility.
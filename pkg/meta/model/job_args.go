@@ -1004,6 +1004,96 @@ func GetAddCheckConstraintArgs(job *Job) (*AddCheckConstraintArgs, error) {
 	return getOrDecodeArgs[*AddCheckConstraintArgs](&AddCheckConstraintArgs{}, job)
 }
 
+// CreateTriggerArgs is the arguments for create trigger job.
+type CreateTriggerArgs struct {
+	Trigger *TriggerInfo `json:"trigger_info"`
+}
+
+func (a *CreateTriggerArgs) getArgsV1(*Job) []any {
+	return []any{a.Trigger}
+}
+
+func (a *CreateTriggerArgs) decodeV1(job *Job) error {
+	a.Trigger = &TriggerInfo{}
+	return errors.Trace(job.decodeArgs(&a.Trigger))
+}
+
+// GetCreateTriggerArgs gets the create trigger args.
+func GetCreateTriggerArgs(job *Job) (*CreateTriggerArgs, error) {
+	return getOrDecodeArgs[*CreateTriggerArgs](&CreateTriggerArgs{}, job)
+}
+
+// DropTriggerArgs is the arguments for drop trigger job.
+type DropTriggerArgs struct {
+	TriggerName ast.CIStr `json:"trigger_name"`
+}
+
+func (a *DropTriggerArgs) getArgsV1(*Job) []any {
+	return []any{a.TriggerName}
+}
+
+func (a *DropTriggerArgs) decodeV1(job *Job) error {
+	return errors.Trace(job.decodeArgs(&a.TriggerName))
+}
+
+// GetDropTriggerArgs gets the drop trigger args.
+func GetDropTriggerArgs(job *Job) (*DropTriggerArgs, error) {
+	return getOrDecodeArgs[*DropTriggerArgs](&DropTriggerArgs{}, job)
+}
+
+// CreateMaterializedViewArgs is the arguments for create materialized view job.
+type CreateMaterializedViewArgs struct {
+	TableInfo *TableInfo `json:"table_info"`
+	// LogTableInfo is nil if the view can't be fast refreshed.
+	LogTableInfo *TableInfo `json:"log_table_info,omitempty"`
+}
+
+func (a *CreateMaterializedViewArgs) getArgsV1(*Job) []any {
+	return []any{a.TableInfo, a.LogTableInfo}
+}
+
+func (a *CreateMaterializedViewArgs) decodeV1(job *Job) error {
+	return errors.Trace(job.decodeArgs(&a.TableInfo, &a.LogTableInfo))
+}
+
+// GetCreateMaterializedViewArgs gets the create materialized view args.
+func GetCreateMaterializedViewArgs(job *Job) (*CreateMaterializedViewArgs, error) {
+	return getOrDecodeArgs[*CreateMaterializedViewArgs](&CreateMaterializedViewArgs{}, job)
+}
+
+// DropMaterializedViewArgs is the arguments for drop materialized view job.
+// The job has no in args.
+type DropMaterializedViewArgs struct {
+	// below field is finished job args, it's the IDs of the view and its log
+	// table.
+	OldTableIDs []int64 `json:"old_table_ids,omitempty"`
+}
+
+func (*DropMaterializedViewArgs) getArgsV1(*Job) []any {
+	return nil
+}
+
+func (a *DropMaterializedViewArgs) getFinishedArgsV1(*Job) []any {
+	return []any{a.OldTableIDs}
+}
+
+func (*DropMaterializedViewArgs) decodeV1(*Job) error {
+	return nil
+}
+
+// GetFinishedDropMaterializedViewArgs gets the drop materialized view args
+// after the job is finished.
+func GetFinishedDropMaterializedViewArgs(job *Job) (*DropMaterializedViewArgs, error) {
+	if job.Version == JobVersion1 {
+		var oldTableIDs []int64
+		if err := job.decodeArgs(&oldTableIDs); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return &DropMaterializedViewArgs{OldTableIDs: oldTableIDs}, nil
+	}
+	return getOrDecodeArgsV2[*DropMaterializedViewArgs](job)
+}
+
 // AlterTablePlacementArgs is the arguments for alter table placements ddl job.
 type AlterTablePlacementArgs struct {
 	PlacementPolicyRef *PolicyRefInfo `json:"placement_policy_ref,omitempty"`
@@ -1353,7 +1443,8 @@ type IndexArg struct {
 
 	// ColumnarIndexType is used to distinguish different columnar index types.
 	// Note: 1. when you want to read it, always calling `GetColumnarIndexType`` rather than using it directly.
-	//       2. when you set it, make sure IsColumnar = ColumnarIndexType != ColumnarIndexTypeNA.
+	//       2. when you set it, make sure IsColumnar = ColumnarIndexType != ColumnarIndexTypeNA,
+	//          except for a FULLTEXT index stored in TiKV, whose IsColumnar is false.
 	ColumnarIndexType ColumnarIndexType `json:"columnar_index_type,omitempty"`
 
 	// For PK
@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/auth"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/stretchr/testify/require"
 	pdhttp "github.com/tikv/pd/client/http"
@@ -693,6 +694,83 @@ func TestCheckConstraintArgs(t *testing.T) {
 	}
 }
 
+func TestCreateTriggerArgs(t *testing.T) {
+	inArgs := &CreateTriggerArgs{
+		Trigger: &TriggerInfo{
+			Name:       ast.NewCIStr("tr1"),
+			Timing:     ast.TriggerTimingAfter,
+			Event:      ast.TriggerEventUpdate,
+			Definition: "create trigger tr1 after update on t for each row set @a = 1",
+			SQLMode:    mysql.ModeStrictTransTables,
+			Definer:    &auth.UserIdentity{Username: "root", Hostname: "%"},
+		},
+	}
+	for _, v := range []JobVersion{JobVersion1, JobVersion2} {
+		j2 := &Job{}
+		require.NoError(t, j2.Decode(getJobBytes(t, inArgs, v, ActionCreateTrigger)))
+		args, err := GetCreateTriggerArgs(j2)
+		require.NoError(t, err)
+		require.Equal(t, inArgs.Trigger, args.Trigger)
+	}
+}
+
+func TestDropTriggerArgs(t *testing.T) {
+	inArgs := &DropTriggerArgs{
+		TriggerName: ast.NewCIStr("tr1"),
+	}
+	for _, v := range []JobVersion{JobVersion1, JobVersion2} {
+		j2 := &Job{}
+		require.NoError(t, j2.Decode(getJobBytes(t, inArgs, v, ActionDropTrigger)))
+		args, err := GetDropTriggerArgs(j2)
+		require.NoError(t, err)
+		require.Equal(t, "tr1", args.TriggerName.O)
+	}
+}
+
+func TestCreateMaterializedViewArgs(t *testing.T) {
+	inArgs := &CreateMaterializedViewArgs{
+		TableInfo: &TableInfo{
+			ID:               100,
+			Name:             ast.NewCIStr("mv"),
+			MaterializedView: &MaterializedViewInfo{Definition: "SELECT COUNT(1) FROM `test`.`t`", BaseTableID: 1, LogTableID: 101},
+		},
+		LogTableInfo: &TableInfo{
+			ID:                  101,
+			Name:                ast.NewCIStr("mlog$_mv"),
+			MaterializedViewLog: &MaterializedViewLogInfo{MViewID: 100, BaseTableID: 1},
+		},
+	}
+	for _, v := range []JobVersion{JobVersion1, JobVersion2} {
+		j2 := &Job{}
+		require.NoError(t, j2.Decode(getJobBytes(t, inArgs, v, ActionCreateMaterializedView)))
+		args, err := GetCreateMaterializedViewArgs(j2)
+		require.NoError(t, err)
+		require.Equal(t, inArgs, args)
+	}
+
+	inArgs.LogTableInfo = nil
+	for _, v := range []JobVersion{JobVersion1, JobVersion2} {
+		j2 := &Job{}
+		require.NoError(t, j2.Decode(getJobBytes(t, inArgs, v, ActionCreateMaterializedView)))
+		args, err := GetCreateMaterializedViewArgs(j2)
+		require.NoError(t, err)
+		require.Nil(t, args.LogTableInfo)
+	}
+}
+
+func TestDropMaterializedViewArgs(t *testing.T) {
+	inArgs := &DropMaterializedViewArgs{
+		OldTableIDs: []int64{100, 101},
+	}
+	for _, v := range []JobVersion{JobVersion1, JobVersion2} {
+		j2 := &Job{}
+		require.NoError(t, j2.Decode(getFinishedJobBytes(t, inArgs, v, ActionDropMaterializedView)))
+		args, err := GetFinishedDropMaterializedViewArgs(j2)
+		require.NoError(t, err)
+		require.Equal(t, []int64{100, 101}, args.OldTableIDs)
+	}
+}
+
 func TestGetAlterTablePlacementArgs(t *testing.T) {
 	inArgs := &AlterTablePlacementArgs{
 		PlacementPolicyRef: &PolicyRefInfo{
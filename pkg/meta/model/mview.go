@@ -0,0 +1,76 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import "slices"
+
+// MaterializedViewInfo provides meta data describing a materialized view. The
+// rows of the view are stored in the table.
+type MaterializedViewInfo struct {
+	// Definition is the SELECT statement of the view, the table names in it are
+	// qualified by the schema names.
+	Definition string `json:"definition"`
+	// BaseTableID is the ID of the table aggregated by the view. It's 0 if the
+	// view isn't an aggregate query of a single table in the same schema.
+	BaseTableID int64 `json:"base_table_id,omitempty"`
+	// LogTableID is the ID of the table which records the row changes of the
+	// base table. It's 0 if the view can't be fast refreshed.
+	LogTableID int64 `json:"log_table_id,omitempty"`
+}
+
+// Clone clones MaterializedViewInfo.
+func (m *MaterializedViewInfo) Clone() *MaterializedViewInfo {
+	nm := *m
+	return &nm
+}
+
+// MaterializedViewLogInfo provides meta data describing the log table of a
+// materialized view.
+type MaterializedViewLogInfo struct {
+	MViewID     int64 `json:"mview_id"`
+	BaseTableID int64 `json:"base_table_id"`
+}
+
+// Clone clones MaterializedViewLogInfo.
+func (m *MaterializedViewLogInfo) Clone() *MaterializedViewLogInfo {
+	nm := *m
+	return &nm
+}
+
+// IsMaterializedView checks if TableInfo is a materialized view.
+func (t *TableInfo) IsMaterializedView() bool {
+	return t.MaterializedView != nil
+}
+
+// IsMaterializedViewLog checks if TableInfo is the log table of a materialized view.
+func (t *TableInfo) IsMaterializedViewLog() bool {
+	return t.MaterializedViewLog != nil
+}
+
+// HasMaterializedViews checks whether the table is the base table of any
+// materialized view.
+func (t *TableInfo) HasMaterializedViews() bool {
+	return len(t.MaterializedViewIDs) > 0
+}
+
+// RemoveMaterializedView removes the materialized view from the base table.
+func (t *TableInfo) RemoveMaterializedView(id int64) {
+	t.MaterializedViewIDs = slices.DeleteFunc(t.MaterializedViewIDs, func(mvID int64) bool {
+		return mvID == id
+	})
+	if len(t.MaterializedViewIDs) == 0 {
+		t.MaterializedViewIDs = nil
+	}
+}
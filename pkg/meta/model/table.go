@@ -204,6 +204,16 @@ type TableInfo struct {
 	DBID int64 `json:"-"`
 
 	Mode TableMode `json:"mode,omitempty"`
+
+	// Triggers are the row triggers of the table, in the order of creation.
+	Triggers []*TriggerInfo `json:"triggers,omitempty"`
+
+	// MaterializedView is set if the table stores the rows of a materialized view.
+	MaterializedView *MaterializedViewInfo `json:"materialized_view,omitempty"`
+	// MaterializedViewLog is set if the table is the log table of a materialized view.
+	MaterializedViewLog *MaterializedViewLogInfo `json:"materialized_view_log,omitempty"`
+	// MaterializedViewIDs are the IDs of the materialized views which aggregate the table.
+	MaterializedViewIDs []int64 `json:"materialized_view_ids,omitempty"`
 }
 
 // Hash64 implement HashEquals interface.
@@ -273,6 +283,19 @@ func (t *TableInfo) Clone() *TableInfo {
 	if t.TTLInfo != nil {
 		nt.TTLInfo = t.TTLInfo.Clone()
 	}
+	if len(t.Triggers) > 0 {
+		nt.Triggers = make([]*TriggerInfo, len(t.Triggers))
+		for i := range t.Triggers {
+			nt.Triggers[i] = t.Triggers[i].Clone()
+		}
+	}
+	if t.MaterializedView != nil {
+		nt.MaterializedView = t.MaterializedView.Clone()
+	}
+	if t.MaterializedViewLog != nil {
+		nt.MaterializedViewLog = t.MaterializedViewLog.Clone()
+	}
+	nt.MaterializedViewIDs = slices.Clone(t.MaterializedViewIDs)
 
 	return &nt
 }
@@ -0,0 +1,79 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"strings"
+
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+)
+
+// TriggerInfo provides meta data describing a row trigger of a table.
+type TriggerInfo struct {
+	Name   ast.CIStr         `json:"name"`
+	Timing ast.TriggerTiming `json:"timing"`
+	Event  ast.TriggerEvent  `json:"event"`
+	// Definition is the original text of the CREATE TRIGGER statement, the body
+	// is parsed from it every time the trigger is loaded.
+	Definition          string             `json:"definition"`
+	SQLMode             mysql.SQLMode      `json:"sql_mode"`
+	Definer             *auth.UserIdentity `json:"definer"`
+	CharsetClient       string             `json:"charset_client"`
+	CollationConnection string             `json:"collation_connection"`
+}
+
+// Clone clones TriggerInfo.
+func (t *TriggerInfo) Clone() *TriggerInfo {
+	nt := *t
+	if t.Definer != nil {
+		definer := *t.Definer
+		nt.Definer = &definer
+	}
+	return &nt
+}
+
+// FindTrigger finds the trigger by name.
+func (t *TableInfo) FindTrigger(name string) *TriggerInfo {
+	lowName := strings.ToLower(name)
+	for _, trigger := range t.Triggers {
+		if trigger.Name.L == lowName {
+			return trigger
+		}
+	}
+	return nil
+}
+
+// TriggersOf returns the triggers with the action time and event, in the order of creation.
+func (t *TableInfo) TriggersOf(timing ast.TriggerTiming, event ast.TriggerEvent) []*TriggerInfo {
+	var triggers []*TriggerInfo
+	for _, trigger := range t.Triggers {
+		if trigger.Timing == timing && trigger.Event == event {
+			triggers = append(triggers, trigger)
+		}
+	}
+	return triggers
+}
+
+// HasTriggerOn checks whether the table has any trigger for the event.
+func (t *TableInfo) HasTriggerOn(event ast.TriggerEvent) bool {
+	for _, trigger := range t.Triggers {
+		if trigger.Event == event {
+			return true
+		}
+	}
+	return false
+}
@@ -57,6 +57,9 @@ var (
 
 	// NetworkTransmissionStats records the network transmission for queries
 	NetworkTransmissionStats *prometheus.CounterVec
+
+	// SpillCompressionBytes records the bytes of the spilled data before and after compression.
+	SpillCompressionBytes *prometheus.CounterVec
 )
 
 // InitExecutorMetrics initializes excutor metrics.
@@ -140,4 +143,12 @@ func InitExecutorMetrics() {
 			Name:      "network_transmission",
 			Help:      "Counter of network transmission bytes.",
 		}, []string{LblType})
+
+	SpillCompressionBytes = NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "tidb",
+			Subsystem: "executor",
+			Name:      "spill_compression_bytes",
+			Help:      "Counter of the spilled bytes before (raw) and after (compressed) compression.",
+		}, []string{LblType})
 }
@@ -304,6 +304,7 @@ func RegisterMetrics() {
 	prometheus.MustRegister(ActiveUser)
 
 	prometheus.MustRegister(NetworkTransmissionStats)
+	prometheus.MustRegister(SpillCompressionBytes)
 
 	prometheus.MustRegister(RestoreTableCreatedCount)
 	prometheus.MustRegister(RestoreImportFileSeconds)
@@ -158,6 +158,8 @@ func GetStmtLabel(stmtNode StmtNode) string {
 		return "AnalyzeTable"
 	case *BeginStmt:
 		return "Begin"
+	case *CallStmt:
+		return "Call"
 	case *ChangeStmt:
 		return "Change"
 	case *CommitStmt:
@@ -202,6 +204,8 @@ func GetStmtLabel(stmtNode StmtNode) string {
 		return "ImportInto"
 	case *LoadDataStmt:
 		return "LoadData"
+	case *MergeStmt:
+		return "Merge"
 	case *RollbackStmt:
 		return "Rollback"
 	case *SelectStmt:
@@ -533,6 +533,10 @@ type TableSource struct {
 
 	// AsName is the alias name of the table source.
 	AsName CIStr
+
+	// Lateral indicates the source is a LATERAL derived table, which can refer to
+	// the columns of the tables preceding it in the FROM clause.
+	Lateral bool
 }
 
 func (*TableSource) resultSet() {}
@@ -578,6 +582,9 @@ func (n *TableSource) Restore(ctx *format.RestoreCtx) error {
 			ctx.WritePlain(")")
 		}
 	} else {
+		if n.Lateral {
+			ctx.WriteKeyWord("LATERAL ")
+		}
 		if needParen {
 			ctx.WritePlain("(")
 		}
@@ -2936,6 +2943,202 @@ func (n *UpdateStmt) TableRefsJoin() (*Join, bool) {
 	return n.TableRefs.TableRefs, true
 }
 
+// MergeActionType is the type of the action in a WHEN clause of the MERGE statement.
+type MergeActionType int
+
+// MergeActionType types.
+const (
+	MergeActionUpdate MergeActionType = iota
+	MergeActionDelete
+	MergeActionInsert
+)
+
+// MergeWhenClause is a `WHEN [NOT] MATCHED [AND condition] THEN action` clause of the MERGE statement.
+type MergeWhenClause struct {
+	node
+
+	NotMatched bool
+	// Condition is the optional search condition after AND.
+	Condition ExprNode
+	Action    MergeActionType
+	// Assignments is the SET list of UPDATE.
+	Assignments []*Assignment
+	// Columns and Values are the column list and the value list of INSERT.
+	Columns []*ColumnName
+	Values  []ExprNode
+}
+
+// Restore implements Node interface.
+func (n *MergeWhenClause) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("WHEN ")
+	if n.NotMatched {
+		ctx.WriteKeyWord("NOT ")
+	}
+	ctx.WriteKeyWord("MATCHED")
+	if n.Condition != nil {
+		ctx.WriteKeyWord(" AND ")
+		if err := n.Condition.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore MergeWhenClause.Condition")
+		}
+	}
+	ctx.WriteKeyWord(" THEN ")
+	switch n.Action {
+	case MergeActionUpdate:
+		ctx.WriteKeyWord("UPDATE SET ")
+		for i, assignment := range n.Assignments {
+			if i != 0 {
+				ctx.WritePlain(", ")
+			}
+			if err := assignment.Restore(ctx); err != nil {
+				return errors.Annotatef(err, "An error occurred while restore MergeWhenClause.Assignments[%d]", i)
+			}
+		}
+	case MergeActionDelete:
+		ctx.WriteKeyWord("DELETE")
+	case MergeActionInsert:
+		ctx.WriteKeyWord("INSERT ")
+		if len(n.Columns) > 0 {
+			ctx.WritePlain("(")
+			for i, col := range n.Columns {
+				if i != 0 {
+					ctx.WritePlain(",")
+				}
+				if err := col.Restore(ctx); err != nil {
+					return errors.Annotatef(err, "An error occurred while restore MergeWhenClause.Columns[%d]", i)
+				}
+			}
+			ctx.WritePlain(") ")
+		}
+		ctx.WriteKeyWord("VALUES ")
+		ctx.WritePlain("(")
+		for i, val := range n.Values {
+			if i != 0 {
+				ctx.WritePlain(",")
+			}
+			if err := val.Restore(ctx); err != nil {
+				return errors.Annotatef(err, "An error occurred while restore MergeWhenClause.Values[%d]", i)
+			}
+		}
+		ctx.WritePlain(")")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *MergeWhenClause) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*MergeWhenClause)
+	if n.Condition != nil {
+		node, ok := n.Condition.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Condition = node.(ExprNode)
+	}
+	for i, val := range n.Assignments {
+		node, ok := val.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Assignments[i] = node.(*Assignment)
+	}
+	for i, val := range n.Columns {
+		node, ok := val.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Columns[i] = node.(*ColumnName)
+	}
+	for i, val := range n.Values {
+		node, ok := val.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Values[i] = node.(ExprNode)
+	}
+	return v.Leave(n)
+}
+
+// MergeStmt is a statement to update, delete or insert the rows of the target table
+// according to whether they are matched by the rows of the source.
+// MERGE INTO target USING source ON condition WHEN [NOT] MATCHED [AND condition] THEN action ...
+type MergeStmt struct {
+	dmlNode
+
+	// TableRefs is `source LEFT JOIN target ON condition`, the target table is
+	// always the right side of the join.
+	TableRefs  *TableRefsClause
+	Clauses    []*MergeWhenClause
+	TableHints []*TableOptimizerHint
+}
+
+// Target returns the target table of the MERGE statement.
+func (n *MergeStmt) Target() *TableSource {
+	return n.TableRefs.TableRefs.Right.(*TableSource)
+}
+
+// Restore implements Node interface.
+func (n *MergeStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("MERGE ")
+	if len(n.TableHints) != 0 {
+		ctx.WritePlain("/*+ ")
+		for i, tableHint := range n.TableHints {
+			if i != 0 {
+				ctx.WritePlain(" ")
+			}
+			if err := tableHint.Restore(ctx); err != nil {
+				return errors.Annotatef(err, "An error occurred while restore MergeStmt.TableHints[%d]", i)
+			}
+		}
+		ctx.WritePlain("*/ ")
+	}
+	join := n.TableRefs.TableRefs
+	ctx.WriteKeyWord("INTO ")
+	if err := join.Right.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore MergeStmt.Target")
+	}
+	ctx.WriteKeyWord(" USING ")
+	if err := join.Left.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore MergeStmt.Source")
+	}
+	ctx.WriteKeyWord(" ON ")
+	if err := join.On.Expr.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore MergeStmt.On")
+	}
+	for i, clause := range n.Clauses {
+		ctx.WritePlain(" ")
+		if err := clause.Restore(ctx); err != nil {
+			return errors.Annotatef(err, "An error occurred while restore MergeStmt.Clauses[%d]", i)
+		}
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *MergeStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*MergeStmt)
+	node, ok := n.TableRefs.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.TableRefs = node.(*TableRefsClause)
+	for i, val := range n.Clauses {
+		node, ok = val.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Clauses[i] = node.(*MergeWhenClause)
+	}
+	return v.Leave(n)
+}
+
 // Limit is the limit clause.
 type Limit struct {
 	node
@@ -3054,6 +3257,7 @@ const (
 	ShowDistributions
 	ShowPlanForSQL
 	ShowDistributionJobs
+	ShowCreateEvent
 )
 
 const (
@@ -3079,6 +3283,7 @@ type ShowStmt struct {
 	Table  *TableName // Used for showing columns.
 	// Procedure's naming method is consistent with the table name
 	Procedure         *TableName
+	Event             *TableName  // Used for showing create event.
 	Partition         CIStr       // Used for showing partition.
 	Column            *ColumnName // Used for `desc table column`.
 	IndexName         CIStr
@@ -3158,6 +3363,11 @@ func (n *ShowStmt) Restore(ctx *format.RestoreCtx) error {
 		if err := n.Procedure.Restore(ctx); err != nil {
 			return errors.Annotate(err, "An error occurred while restore ShowStmt.Procedure")
 		}
+	case ShowCreateEvent:
+		ctx.WriteKeyWord("CREATE EVENT ")
+		if err := n.Event.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore ShowStmt.Event")
+		}
 	case ShowCreateView:
 		ctx.WriteKeyWord("CREATE VIEW ")
 		if err := n.Table.Restore(ctx); err != nil {
@@ -0,0 +1,304 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ast
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/parser/format"
+)
+
+var (
+	_ StmtNode = &CreateEventStmt{}
+	_ StmtNode = &AlterEventStmt{}
+	_ StmtNode = &DropEventStmt{}
+
+	_ Node = &EventSchedule{}
+)
+
+// EventCompletion is the ON COMPLETION clause of an event.
+type EventCompletion int
+
+// EventCompletion types.
+const (
+	EventCompletionUnspecified EventCompletion = iota
+	EventCompletionNotPreserve
+	EventCompletionPreserve
+)
+
+// Restore writes the ON COMPLETION clause, nothing is written if it is unspecified.
+func (c EventCompletion) Restore(ctx *format.RestoreCtx) {
+	switch c {
+	case EventCompletionNotPreserve:
+		ctx.WriteKeyWord(" ON COMPLETION NOT PRESERVE")
+	case EventCompletionPreserve:
+		ctx.WriteKeyWord(" ON COMPLETION PRESERVE")
+	}
+}
+
+// EventStatus is the status of an event.
+type EventStatus int
+
+// EventStatus types.
+const (
+	EventStatusUnspecified EventStatus = iota
+	EventStatusEnable
+	EventStatusDisable
+)
+
+// Restore writes the status, nothing is written if it is unspecified.
+func (s EventStatus) Restore(ctx *format.RestoreCtx) {
+	switch s {
+	case EventStatusEnable:
+		ctx.WriteKeyWord(" ENABLE")
+	case EventStatusDisable:
+		ctx.WriteKeyWord(" DISABLE")
+	}
+}
+
+// EventSchedule is the schedule of an event.
+// See https://dev.mysql.com/doc/refman/8.0/en/create-event.html
+type EventSchedule struct {
+	node
+
+	// At is the time to run a one-time event, it is nil for a recurring event.
+	At ExprNode
+	// Every and Unit are the interval of a recurring event.
+	Every ExprNode
+	Unit  TimeUnitType
+	// Starts and Ends are the optional period of a recurring event.
+	Starts ExprNode
+	Ends   ExprNode
+}
+
+// Restore implements Node interface.
+func (n *EventSchedule) Restore(ctx *format.RestoreCtx) error {
+	if n.At != nil {
+		ctx.WriteKeyWord("AT ")
+		if err := n.At.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore EventSchedule.At")
+		}
+		return nil
+	}
+	ctx.WriteKeyWord("EVERY ")
+	if err := n.Every.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore EventSchedule.Every")
+	}
+	ctx.WritePlain(" ")
+	ctx.WriteKeyWord(n.Unit.String())
+	if n.Starts != nil {
+		ctx.WriteKeyWord(" STARTS ")
+		if err := n.Starts.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore EventSchedule.Starts")
+		}
+	}
+	if n.Ends != nil {
+		ctx.WriteKeyWord(" ENDS ")
+		if err := n.Ends.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore EventSchedule.Ends")
+		}
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *EventSchedule) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*EventSchedule)
+	for _, expr := range []*ExprNode{&n.At, &n.Every, &n.Starts, &n.Ends} {
+		if *expr == nil {
+			continue
+		}
+		node, ok := (*expr).Accept(v)
+		if !ok {
+			return n, false
+		}
+		*expr = node.(ExprNode)
+	}
+	return v.Leave(n)
+}
+
+// CreateEventStmt is a statement to create an event.
+// See https://dev.mysql.com/doc/refman/8.0/en/create-event.html
+type CreateEventStmt struct {
+	stmtNode
+
+	IfNotExists bool
+	Definer     *auth.UserIdentity
+	EventName   *TableName
+	Schedule    *EventSchedule
+	Completion  EventCompletion
+	Status      EventStatus
+	Comment     string
+	Body        StmtNode
+}
+
+// Restore implements Node interface.
+func (n *CreateEventStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("CREATE")
+	if n.Definer != nil {
+		ctx.WriteKeyWord(" DEFINER")
+		ctx.WritePlain(" = ")
+		if n.Definer.CurrentUser {
+			ctx.WriteKeyWord("current_user")
+		} else {
+			ctx.WriteName(n.Definer.Username)
+			if n.Definer.Hostname != "" {
+				ctx.WritePlain("@")
+				ctx.WriteName(n.Definer.Hostname)
+			}
+		}
+	}
+	ctx.WriteKeyWord(" EVENT ")
+	if n.IfNotExists {
+		ctx.WriteKeyWord("IF NOT EXISTS ")
+	}
+	if err := n.EventName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateEventStmt.EventName")
+	}
+	ctx.WriteKeyWord(" ON SCHEDULE ")
+	if err := n.Schedule.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateEventStmt.Schedule")
+	}
+	n.Completion.Restore(ctx)
+	n.Status.Restore(ctx)
+	if n.Comment != "" {
+		ctx.WriteKeyWord(" COMMENT ")
+		ctx.WriteString(n.Comment)
+	}
+	ctx.WriteKeyWord(" DO ")
+	if err := n.Body.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateEventStmt.Body")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *CreateEventStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*CreateEventStmt)
+	node, ok := n.Schedule.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Schedule = node.(*EventSchedule)
+	// Like stored procedures, the body is checked when the event runs, so don't traverse it.
+	return v.Leave(n)
+}
+
+// AlterEventStmt is a statement to change an event.
+// See https://dev.mysql.com/doc/refman/8.0/en/alter-event.html
+type AlterEventStmt struct {
+	stmtNode
+
+	EventName *TableName
+	// Schedule is nil if the schedule is not changed.
+	Schedule   *EventSchedule
+	Completion EventCompletion
+	// NewName is nil if the event is not renamed.
+	NewName *TableName
+	Status  EventStatus
+	// Comment is nil if the comment is not changed.
+	Comment *string
+	// Body is nil if the body is not changed.
+	Body StmtNode
+}
+
+// Restore implements Node interface.
+func (n *AlterEventStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("ALTER EVENT ")
+	if err := n.EventName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore AlterEventStmt.EventName")
+	}
+	if n.Schedule != nil {
+		ctx.WriteKeyWord(" ON SCHEDULE ")
+		if err := n.Schedule.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore AlterEventStmt.Schedule")
+		}
+	}
+	n.Completion.Restore(ctx)
+	if n.NewName != nil {
+		ctx.WriteKeyWord(" RENAME TO ")
+		if err := n.NewName.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore AlterEventStmt.NewName")
+		}
+	}
+	n.Status.Restore(ctx)
+	if n.Comment != nil {
+		ctx.WriteKeyWord(" COMMENT ")
+		ctx.WriteString(*n.Comment)
+	}
+	if n.Body != nil {
+		ctx.WriteKeyWord(" DO ")
+		if err := n.Body.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore AlterEventStmt.Body")
+		}
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *AlterEventStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*AlterEventStmt)
+	if n.Schedule != nil {
+		node, ok := n.Schedule.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Schedule = node.(*EventSchedule)
+	}
+	return v.Leave(n)
+}
+
+// DropEventStmt is a statement to drop an event.
+// See https://dev.mysql.com/doc/refman/8.0/en/drop-event.html
+type DropEventStmt struct {
+	stmtNode
+
+	IfExists  bool
+	EventName *TableName
+}
+
+// Restore implements Node interface.
+func (n *DropEventStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("DROP EVENT ")
+	if n.IfExists {
+		ctx.WriteKeyWord("IF EXISTS ")
+	}
+	if err := n.EventName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore DropEventStmt.EventName")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *DropEventStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*DropEventStmt)
+	return v.Leave(n)
+}
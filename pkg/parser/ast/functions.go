@@ -361,8 +361,21 @@ const (
 	VecFromText             = "vec_from_text"
 	VecAsText               = "vec_as_text"
 
+	// spatial functions
+	STGeomFromText     = "st_geomfromtext"
+	STGeometryFromText = "st_geometryfromtext"
+	STAsText           = "st_astext"
+	STAsWKT            = "st_aswkt"
+	STDistance         = "st_distance"
+	STContains         = "st_contains"
+	STWithin           = "st_within"
+	STIntersects       = "st_intersects"
+	STX                = "st_x"
+	STY                = "st_y"
+
 	// FTS functions (tidb extension)
-	FTSMatchWord = "fts_match_word"
+	FTSMatchWord    = "fts_match_word"
+	FTSMatchAgainst = "fts_match_against"
 
 	// TiDB internal function.
 	TiDBDecodeKey       = "tidb_decode_key"
@@ -2116,8 +2116,8 @@ type RecommendIndexStmt struct {
 func (n *RecommendIndexStmt) Restore(ctx *format.RestoreCtx) error {
 	ctx.WriteKeyWord("RECOMMEND INDEX")
 	switch n.Action {
-	case "run":
-		ctx.WriteKeyWord(" RUN")
+	case "run", "drop":
+		ctx.WriteKeyWord(" " + strings.ToUpper(n.Action))
 		if n.SQL != "" {
 			ctx.WriteKeyWord(" FOR ")
 			ctx.WriteString(n.SQL)
@@ -268,6 +268,7 @@ var Keywords = []KeywordsType{
 	{"ANY", false, "unreserved"},
 	{"APPLY", false, "unreserved"},
 	{"ASCII", false, "unreserved"},
+	{"AT", false, "unreserved"},
 	{"ATTRIBUTE", false, "unreserved"},
 	{"ATTRIBUTES", false, "unreserved"},
 	{"AUTO_ID_CACHE", false, "unreserved"},
@@ -280,6 +281,7 @@ var Keywords = []KeywordsType{
 	{"BACKUP", false, "unreserved"},
 	{"BACKUPS", false, "unreserved"},
 	{"BDR", false, "unreserved"},
+	{"BEFORE", false, "unreserved"},
 	{"BEGIN", false, "unreserved"},
 	{"BERNOULLI", false, "unreserved"},
 	{"BINDING", false, "unreserved"},
@@ -318,6 +320,8 @@ var Keywords = []KeywordsType{
 	{"COMMIT", false, "unreserved"},
 	{"COMMITTED", false, "unreserved"},
 	{"COMPACT", false, "unreserved"},
+	{"COMPLETE", false, "unreserved"},
+	{"COMPLETION", false, "unreserved"},
 	{"COMPRESSED", false, "unreserved"},
 	{"COMPRESSION", false, "unreserved"},
 	{"COMPRESSION_LEVEL", false, "unreserved"},
@@ -355,12 +359,14 @@ var Keywords = []KeywordsType{
 	{"DO", false, "unreserved"},
 	{"DUPLICATE", false, "unreserved"},
 	{"DYNAMIC", false, "unreserved"},
+	{"EACH", false, "unreserved"},
 	{"ENABLE", false, "unreserved"},
 	{"ENABLED", false, "unreserved"},
 	{"ENCRYPTION", false, "unreserved"},
 	{"ENCRYPTION_KEYFILE", false, "unreserved"},
 	{"ENCRYPTION_METHOD", false, "unreserved"},
 	{"END", false, "unreserved"},
+	{"ENDS", false, "unreserved"},
 	{"ENFORCED", false, "unreserved"},
 	{"ENGINE", false, "unreserved"},
 	{"ENGINES", false, "unreserved"},
@@ -371,6 +377,7 @@ var Keywords = []KeywordsType{
 	{"ESCAPE", false, "unreserved"},
 	{"EVENT", false, "unreserved"},
 	{"EVENTS", false, "unreserved"},
+	{"EVERY", false, "unreserved"},
 	{"EVOLVE", false, "unreserved"},
 	{"EXCHANGE", false, "unreserved"},
 	{"EXCLUSIVE", false, "unreserved"},
@@ -380,6 +387,7 @@ var Keywords = []KeywordsType{
 	{"EXPLORE", false, "unreserved"},
 	{"EXTENDED", false, "unreserved"},
 	{"FAILED_LOGIN_ATTEMPTS", false, "unreserved"},
+	{"FAST", false, "unreserved"},
 	{"FAULTS", false, "unreserved"},
 	{"FIELDS", false, "unreserved"},
 	{"FILE", false, "unreserved"},
@@ -392,6 +400,9 @@ var Keywords = []KeywordsType{
 	{"FULL", false, "unreserved"},
 	{"FUNCTION", false, "unreserved"},
 	{"GENERAL", false, "unreserved"},
+	{"GEOMCOLLECTION", false, "unreserved"},
+	{"GEOMETRY", false, "unreserved"},
+	{"GEOMETRYCOLLECTION", false, "unreserved"},
 	{"GLOBAL", false, "unreserved"},
 	{"GRANTS", false, "unreserved"},
 	{"HANDLER", false, "unreserved"},
@@ -424,8 +435,10 @@ var Keywords = []KeywordsType{
 	{"LAST", false, "unreserved"},
 	{"LASTVAL", false, "unreserved"},
 	{"LAST_BACKUP", false, "unreserved"},
+	{"LATERAL", false, "unreserved"},
 	{"LESS", false, "unreserved"},
 	{"LEVEL", false, "unreserved"},
+	{"LINESTRING", false, "unreserved"},
 	{"LIST", false, "unreserved"},
 	{"LOAD_STATS", false, "unreserved"},
 	{"LOCAL", false, "unreserved"},
@@ -433,6 +446,8 @@ var Keywords = []KeywordsType{
 	{"LOCKED", false, "unreserved"},
 	{"LOGS", false, "unreserved"},
 	{"MASTER", false, "unreserved"},
+	{"MATCHED", false, "unreserved"},
+	{"MATERIALIZED", false, "unreserved"},
 	{"MAX_CONNECTIONS_PER_HOUR", false, "unreserved"},
 	{"MAX_IDXNUM", false, "unreserved"},
 	{"MAX_MINUTES", false, "unreserved"},
@@ -451,6 +466,9 @@ var Keywords = []KeywordsType{
 	{"MODE", false, "unreserved"},
 	{"MODIFY", false, "unreserved"},
 	{"MONTH", false, "unreserved"},
+	{"MULTILINESTRING", false, "unreserved"},
+	{"MULTIPOINT", false, "unreserved"},
+	{"MULTIPOLYGON", false, "unreserved"},
 	{"NAMES", false, "unreserved"},
 	{"NATIONAL", false, "unreserved"},
 	{"NCHAR", false, "unreserved"},
@@ -493,6 +511,7 @@ var Keywords = []KeywordsType{
 	{"PLUGINS", false, "unreserved"},
 	{"POINT", false, "unreserved"},
 	{"POLICY", false, "unreserved"},
+	{"POLYGON", false, "unreserved"},
 	{"PRECEDING", false, "unreserved"},
 	{"PREPARE", false, "unreserved"},
 	{"PRESERVE", false, "unreserved"},
@@ -513,6 +532,7 @@ var Keywords = []KeywordsType{
 	{"RECOMMEND", false, "unreserved"},
 	{"RECOVER", false, "unreserved"},
 	{"REDUNDANT", false, "unreserved"},
+	{"REFRESH", false, "unreserved"},
 	{"RELOAD", false, "unreserved"},
 	{"REMOVE", false, "unreserved"},
 	{"REORGANIZE", false, "unreserved"},
@@ -579,6 +599,7 @@ var Keywords = []KeywordsType{
 	{"SQL_TSI_WEEK", false, "unreserved"},
 	{"SQL_TSI_YEAR", false, "unreserved"},
 	{"START", false, "unreserved"},
+	{"STARTS", false, "unreserved"},
 	{"STATS_AUTO_RECALC", false, "unreserved"},
 	{"STATS_COL_CHOICE", false, "unreserved"},
 	{"STATS_COL_LIST", false, "unreserved"},
@@ -36,7 +36,7 @@ func TestKeywords(t *testing.T) {
 }
 
 func TestKeywordsLength(t *testing.T) {
-	require.Equal(t, 662, len(parser.Keywords))
+	require.Equal(t, 683, len(parser.Keywords))
 
 	reservedNr := 0
 	for _, kw := range parser.Keywords {
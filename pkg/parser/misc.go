@@ -178,6 +178,7 @@ var tokenMap = map[string]int{
 	"AS":                         as,
 	"ASC":                        asc,
 	"ASCII":                      ascii,
+	"AT":                         at,
 	"APPLY":                      apply,
 	"ATTRIBUTE":                  attribute,
 	"ATTRIBUTES":                 attributes,
@@ -185,6 +186,7 @@ var tokenMap = map[string]int{
 	"BACKGROUND":                 background,
 	"STATS_OPTIONS":              statsOptions,
 	"STATS_SAMPLE_RATE":          statsSampleRate,
+	"STARTS":                     starts,
 	"STATS_COL_CHOICE":           statsColChoice,
 	"STATS_COL_LIST":             statsColList,
 	"AUTO_ID_CACHE":              autoIdCache,
@@ -197,6 +199,7 @@ var tokenMap = map[string]int{
 	"BACKUP":                     backup,
 	"BACKUPS":                    backups,
 	"BDR":                        bdr,
+	"BEFORE":                     before,
 	"BEGIN":                      begin,
 	"BETWEEN":                    between,
 	"BERNOULLI":                  bernoulli,
@@ -263,6 +266,8 @@ var tokenMap = map[string]int{
 	"COMMIT":                     commit,
 	"COMMITTED":                  committed,
 	"COMPACT":                    compact,
+	"COMPLETE":                   complete,
+	"COMPLETION":                 completion,
 	"COMPRESS":                   compress,
 	"COMPRESSED":                 compressed,
 	"COMPRESSION":                compression,
@@ -358,6 +363,7 @@ var tokenMap = map[string]int{
 	"DUPLICATE":                  duplicate,
 	"DURATION":                   timeDuration,
 	"DYNAMIC":                    dynamic,
+	"EACH":                       each,
 	"ELSE":                       elseKwd,
 	"ELSEIF":                     elseIfKwd,
 	"ENABLE":                     enable,
@@ -365,6 +371,7 @@ var tokenMap = map[string]int{
 	"ENCLOSED":                   enclosed,
 	"ENCRYPTION":                 encryption,
 	"END":                        end,
+	"ENDS":                       ends,
 	"END_TIME":                   endTime,
 	"ENFORCED":                   enforced,
 	"ENGINE":                     engine,
@@ -378,6 +385,7 @@ var tokenMap = map[string]int{
 	"ESCAPED":                    escaped,
 	"EVENT":                      event,
 	"EVENTS":                     events,
+	"EVERY":                      every,
 	"EVOLVE":                     evolve,
 	"EXACT":                      exact,
 	"EXEC_ELAPSED":               execElapsed,
@@ -422,6 +430,9 @@ var tokenMap = map[string]int{
 	"FUNCTION":                   function,
 	"GC_TTL":                     gcTTL,
 	"GENERAL":                    general,
+	"GEOMCOLLECTION":             geomCollection,
+	"GEOMETRY":                   geometry,
+	"GEOMETRYCOLLECTION":         geometryCollection,
 	"GENERATED":                  generated,
 	"GET_FORMAT":                 getFormat,
 	"GLOBAL":                     global,
@@ -503,6 +514,7 @@ var tokenMap = map[string]int{
 	"LAST_BACKUP":                lastBackup,
 	"LAST":                       last,
 	"LASTVAL":                    lastval,
+	"LATERAL":                    lateral,
 	"LEADER":                     leader,
 	"LEADER_CONSTRAINTS":         leaderConstraints,
 	"LEADING":                    leading,
@@ -517,6 +529,7 @@ var tokenMap = map[string]int{
 	"LIMIT":                      limit,
 	"LINEAR":                     linear,
 	"LINES":                      lines,
+	"LINESTRING":                 lineString,
 	"LIST":                       list,
 	"LOAD":                       load,
 	"LOCAL":                      local,
@@ -532,6 +545,8 @@ var tokenMap = map[string]int{
 	"LONGTEXT":                   longtextType,
 	"LOW_PRIORITY":               lowPriority,
 	"MASTER":                     master,
+	"MATCHED":                    matched,
+	"MATERIALIZED":               materialized,
 	"MATCH":                      match,
 	"MAX_CONNECTIONS_PER_HOUR":   maxConnectionsPerHour,
 	"MAX_IDXNUM":                 max_idxnum,
@@ -562,6 +577,9 @@ var tokenMap = map[string]int{
 	"MODE":                       mode,
 	"MODIFY":                     modify,
 	"MONTH":                      month,
+	"MULTILINESTRING":            multiLineString,
+	"MULTIPOINT":                 multiPoint,
+	"MULTIPOLYGON":               multiPolygon,
 	"NAMES":                      names,
 	"NATIONAL":                   national,
 	"NATURAL":                    natural,
@@ -630,6 +648,7 @@ var tokenMap = map[string]int{
 	"PLUGINS":                    plugins,
 	"POINT":                      point,
 	"POLICY":                     policy,
+	"POLYGON":                    polygon,
 	"POSITION":                   position,
 	"PRE_SPLIT_REGIONS":          preSplitRegions,
 	"PRECEDING":                  preceding,
@@ -664,6 +683,7 @@ var tokenMap = map[string]int{
 	"RECOVER":                    recover,
 	"RECURSIVE":                  recursive,
 	"REDUNDANT":                  redundant,
+	"REFRESH":                    refresh,
 	"REFERENCES":                 references,
 	"REGEXP":                     regexpKwd,
 	"REGION":                     region,
@@ -935,6 +955,7 @@ var tokenMap = map[string]int{
 	"ZEROFILL":                   zerofill,
 	"WAIT":                       wait,
 	"FAILED_LOGIN_ATTEMPTS":      failedLoginAttempts,
+	"FAST":                       fast,
 	"PASSWORD_LOCK_TIME":         passwordLockTime,
 	"REUSE":                      reuse,
 }
@@ -1004,7 +1025,8 @@ var aliases = map[string]string{
 // hintedTokens is a set of tokens which recognizes a hint.
 // According to https://dev.mysql.com/doc/refman/8.0/en/optimizer-hints.html,
 // only SELECT, INSERT, REPLACE, UPDATE and DELETE accept optimizer hints.
-// additionally we support CREATE and PARTITION for hints at table creation.
+// additionally we support CREATE and PARTITION for hints at table creation,
+// and MERGE for hints of the MERGE statement.
 var hintedTokens = map[int]struct{}{
 	selectKwd: {},
 	insert:    {},
@@ -1013,6 +1035,7 @@ var hintedTokens = map[int]struct{}{
 	deleteKwd: {},
 	create:    {},
 	partition: {},
+	merge:     {},
 }
 
 var hintTokenMap = map[string]int{
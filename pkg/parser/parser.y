@@ -320,6 +320,7 @@ import (
 	any                      "ANY"
 	apply                    "APPLY"
 	ascii                    "ASCII"
+	at                       "AT"
 	attribute                "ATTRIBUTE"
 	attributes               "ATTRIBUTES"
 	autoIdCache              "AUTO_ID_CACHE"
@@ -332,6 +333,7 @@ import (
 	backup                   "BACKUP"
 	backups                  "BACKUPS"
 	bdr                      "BDR"
+	before                   "BEFORE"
 	begin                    "BEGIN"
 	bernoulli                "BERNOULLI"
 	binding                  "BINDING"
@@ -370,6 +372,8 @@ import (
 	commit                   "COMMIT"
 	committed                "COMMITTED"
 	compact                  "COMPACT"
+	complete                 "COMPLETE"
+	completion               "COMPLETION"
 	compressed               "COMPRESSED"
 	compression              "COMPRESSION"
 	compressionLevel         "COMPRESSION_LEVEL"
@@ -407,12 +411,14 @@ import (
 	do                       "DO"
 	duplicate                "DUPLICATE"
 	dynamic                  "DYNAMIC"
+	each                     "EACH"
 	enable                   "ENABLE"
 	enabled                  "ENABLED"
 	encryption               "ENCRYPTION"
 	encryptionKeyFile        "ENCRYPTION_KEYFILE"
 	encryptionMethod         "ENCRYPTION_METHOD"
 	end                      "END"
+	ends                     "ENDS"
 	enforced                 "ENFORCED"
 	engine                   "ENGINE"
 	engines                  "ENGINES"
@@ -423,6 +429,7 @@ import (
 	escape                   "ESCAPE"
 	event                    "EVENT"
 	events                   "EVENTS"
+	every                    "EVERY"
 	evolve                   "EVOLVE"
 	exchange                 "EXCHANGE"
 	exclusive                "EXCLUSIVE"
@@ -432,6 +439,7 @@ import (
 	explore                  "EXPLORE"
 	extended                 "EXTENDED"
 	failedLoginAttempts      "FAILED_LOGIN_ATTEMPTS"
+	fast                     "FAST"
 	faultsSym                "FAULTS"
 	fields                   "FIELDS"
 	file                     "FILE"
@@ -444,6 +452,9 @@ import (
 	full                     "FULL"
 	function                 "FUNCTION"
 	general                  "GENERAL"
+	geomCollection           "GEOMCOLLECTION"
+	geometry                 "GEOMETRY"
+	geometryCollection       "GEOMETRYCOLLECTION"
 	global                   "GLOBAL"
 	grants                   "GRANTS"
 	handler                  "HANDLER"
@@ -476,8 +487,10 @@ import (
 	last                     "LAST"
 	lastval                  "LASTVAL"
 	lastBackup               "LAST_BACKUP"
+	lateral                  "LATERAL"
 	less                     "LESS"
 	level                    "LEVEL"
+	lineString               "LINESTRING"
 	list                     "LIST"
 	loadStats                "LOAD_STATS"
 	local                    "LOCAL"
@@ -485,6 +498,8 @@ import (
 	locked                   "LOCKED"
 	logs                     "LOGS"
 	master                   "MASTER"
+	matched                  "MATCHED"
+	materialized             "MATERIALIZED"
 	maxConnectionsPerHour    "MAX_CONNECTIONS_PER_HOUR"
 	max_idxnum               "MAX_IDXNUM"
 	max_minutes              "MAX_MINUTES"
@@ -503,6 +518,9 @@ import (
 	mode                     "MODE"
 	modify                   "MODIFY"
 	month                    "MONTH"
+	multiLineString          "MULTILINESTRING"
+	multiPoint               "MULTIPOINT"
+	multiPolygon             "MULTIPOLYGON"
 	names                    "NAMES"
 	national                 "NATIONAL"
 	ncharType                "NCHAR"
@@ -546,6 +564,7 @@ import (
 	plugins                  "PLUGINS"
 	point                    "POINT"
 	policy                   "POLICY"
+	polygon                  "POLYGON"
 	preceding                "PRECEDING"
 	prepare                  "PREPARE"
 	preserve                 "PRESERVE"
@@ -566,6 +585,7 @@ import (
 	recommend                "RECOMMEND"
 	recover                  "RECOVER"
 	redundant                "REDUNDANT"
+	refresh                  "REFRESH"
 	reload                   "RELOAD"
 	remove                   "REMOVE"
 	reorganize               "REORGANIZE"
@@ -632,6 +652,7 @@ import (
 	sqlTsiWeek               "SQL_TSI_WEEK"
 	sqlTsiYear               "SQL_TSI_YEAR"
 	start                    "START"
+	starts                   "STARTS"
 	statsAutoRecalc          "STATS_AUTO_RECALC"
 	statsColChoice           "STATS_COL_CHOICE"
 	statsColList             "STATS_COL_LIST"
@@ -970,6 +991,7 @@ import (
 %type	<statement>
 	AdminStmt                  "Check table statement or show ddl statement"
 	AlterDatabaseStmt          "Alter database statement"
+	AlterEventStmt             "ALTER EVENT statement"
 	AlterTableStmt             "Alter table statement"
 	AlterUserStmt              "Alter user statement"
 	AlterInstanceStmt          "Alter instance statement"
@@ -988,7 +1010,9 @@ import (
 	CreateUserStmt             "CREATE User statement"
 	CreateRoleStmt             "CREATE Role statement"
 	CreateDatabaseStmt         "Create Database Statement"
+	CreateEventStmt            "CREATE EVENT statement"
 	CreateIndexStmt            "CREATE INDEX statement"
+	CreateMaterializedViewStmt "CREATE MATERIALIZED VIEW statement"
 	CreateBindingStmt          "CREATE BINDING statement"
 	CreatePolicyStmt           "CREATE PLACEMENT POLICY statement"
 	CreateProcedureStmt        "CREATE PROCEDURE statement"
@@ -996,14 +1020,18 @@ import (
 	CreateResourceGroupStmt    "CREATE RESOURCE GROUP statement"
 	CreateSequenceStmt         "CREATE SEQUENCE statement"
 	CreateStatisticsStmt       "CREATE STATISTICS statement"
+	CreateTriggerStmt          "CREATE TRIGGER statement"
 	DoStmt                     "Do statement"
 	DropDatabaseStmt           "DROP DATABASE statement"
+	DropEventStmt              "DROP EVENT statement"
 	DropIndexStmt              "DROP INDEX statement"
+	DropMaterializedViewStmt   "DROP MATERIALIZED VIEW statement"
 	DropProcedureStmt          "DROP PROCEDURE statement"
 	DropQueryWatchStmt         "DROP QUERY WATCH statement"
 	DropResourceGroupStmt      "DROP RESOURCE GROUP statement"
 	DropStatisticsStmt         "DROP STATISTICS statement"
 	DropStatsStmt              "DROP STATS statement"
+	DropTriggerStmt            "DROP TRIGGER statement"
 	DropTableStmt              "DROP TABLE statement"
 	DropSequenceStmt           "DROP SEQUENCE statement"
 	DropUserStmt               "DROP USER"
@@ -1037,10 +1065,13 @@ import (
 	LockStatsStmt              "Lock statistic statement"
 	UnlockStatsStmt            "Unlock statistic statement"
 	LockTablesStmt             "Lock tables statement"
+	MergeStmt                  "MERGE statement"
 	NonTransactionalDMLStmt    "Non-transactional DML statement"
 	OptimizeTableStmt          "OPTIMIZE statement"
 	PlanReplayerStmt           "Plan replayer statement"
 	PreparedStmt               "PreparedStmt"
+	RefreshMViewStmt           "REFRESH MATERIALIZED VIEW statement"
+	EventBodyOpt               "Event body optional"
 	ProcedureProcStmt          "The entrance of procedure statements which contains all kinds of statements in procedure"
 	ProcedureStatementStmt     "The normal statements in procedure, such as dml, select, set ..."
 	SelectStmt                 "SELECT statement"
@@ -1372,6 +1403,18 @@ import (
 	TableLock                              "Table name and lock type"
 	TableLockList                          "Table lock list"
 	TableName                              "Table name"
+	TriggerEvent                           "Trigger event"
+	AlterEventOnOpt                        "ALTER EVENT schedule and completion optional"
+	EventCommentOpt                        "Event comment optional"
+	EventCompletion                        "Event ON COMPLETION clause"
+	EventCompletionOpt                     "Event ON COMPLETION clause optional"
+	EventEndsOpt                           "Event ENDS optional"
+	EventRenameOpt                         "Event RENAME TO optional"
+	EventSchedule                          "Event schedule"
+	EventStartsOpt                         "Event STARTS optional"
+	EventStatusOpt                         "Event status optional"
+	TriggerTiming                          "Trigger action time"
+	RefreshMethodOpt                       "Materialized view refresh method optional"
 	TableNameOptWild                       "Table name with optional wildcard"
 	TableNameList                          "Table name list"
 	TableNameListOpt                       "Table name list opt"
@@ -1424,6 +1467,10 @@ import (
 	WhereClauseOptional                    "Optional WHERE clause"
 	WhenClause                             "When clause"
 	WhenClauseList                         "When clause list"
+	MergeWhenClause                        "WHEN clause of MERGE"
+	MergeWhenClauseList                    "WHEN clause list of MERGE"
+	MergeWhenCondOpt                       "Optional search condition of MERGE WHEN clause"
+	MergeInsertColumnListOpt               "Optional column list of MERGE INSERT action"
 	WithClustered                          "With Clustered Index Enabled"
 	WithClause                             "With Clause"
 	WithList                               "With list"
@@ -1474,6 +1521,8 @@ import (
 	BlobType                               "Blob types"
 	TextType                               "Text types"
 	DateAndTimeType                        "Date and Time types"
+	SpatialType                            "Spatial types"
+	GeometryType                           "Geometry types"
 	OptFieldLen                            "Field length or empty"
 	FieldLen                               "Field length"
 	FieldOpts                              "Field type definition option list"
@@ -6850,6 +6899,7 @@ UnReservedKeyword:
 |	"ASCII"
 |	"APPLY"
 |	"ATTRIBUTE"
+|	"AT"
 |	"ATTRIBUTES"
 |	"BINDING_CACHE"
 |	"STATS_OPTIONS"
@@ -6862,6 +6912,7 @@ UnReservedKeyword:
 |	"ALWAYS"
 |	"AVG"
 |	"BDR"
+|	"BEFORE"
 |	"BEGIN"
 |	"BIT"
 |	"BOOL"
@@ -6879,6 +6930,8 @@ UnReservedKeyword:
 |	"SAN"
 |	"COMMIT"
 |	"COMPACT"
+|	"COMPLETE"
+|	"COMPLETION"
 |	"COMPRESSED"
 |	"CONSISTENCY"
 |	"CONSISTENT"
@@ -6891,6 +6944,7 @@ UnReservedKeyword:
 |	"DO"
 |	"DUPLICATE"
 |	"DYNAMIC"
+|	"EACH"
 |	"ENCRYPTION"
 |	"END"
 |	"ENFORCED"
@@ -6902,6 +6956,8 @@ UnReservedKeyword:
 |	"ERROR"
 |	"ERRORS"
 |	"ESCAPE"
+|	"ENDS"
+|	"EVERY"
 |	"EVOLVE"
 |	"EXECUTE"
 |	"EXPLORE"
@@ -6936,6 +6992,7 @@ UnReservedKeyword:
 |	"REBUILD"
 |	"RECOMMEND"
 |	"REDUNDANT"
+|	"REFRESH"
 |	"REORGANIZE"
 |	"RESOURCE"
 |	"RESTART"
@@ -6950,8 +7007,17 @@ UnReservedKeyword:
 |	"SNAPSHOT"
 |	"START"
 |	"STATUS"
+|	"STARTS"
 |	"OPEN"
 |	"POINT"
+|	"GEOMETRY"
+|	"LINESTRING"
+|	"POLYGON"
+|	"MULTIPOINT"
+|	"MULTILINESTRING"
+|	"MULTIPOLYGON"
+|	"GEOMETRYCOLLECTION"
+|	"GEOMCOLLECTION"
 |	"SUBPARTITIONS"
 |	"SUBPARTITION"
 |	"TABLES"
@@ -6985,6 +7051,8 @@ UnReservedKeyword:
 |	"COMPRESSION"
 |	"KEY_BLOCK_SIZE"
 |	"MASTER"
+|	"MATCHED"
+|	"MATERIALIZED"
 |	"MAX_ROWS"
 |	"MIN_ROWS"
 |	"NATIONAL"
@@ -7128,6 +7196,7 @@ UnReservedKeyword:
 |	"SQL_TSI_QUARTER"
 |	"SQL_TSI_SECOND"
 |	"LANGUAGE"
+|	"LATERAL"
 |	"SQL_TSI_WEEK"
 |	"SQL_TSI_YEAR"
 |	"INVISIBLE"
@@ -7207,6 +7276,7 @@ UnReservedKeyword:
 |	"TTL_ENABLE"
 |	"TTL_JOB_INTERVAL"
 |	"FAILED_LOGIN_ATTEMPTS"
+|	"FAST"
 |	"PASSWORD_LOCK_TIME"
 |	"DIGEST"
 |	"REUSE" %prec lowerThanEq
@@ -10004,6 +10074,11 @@ TableFactor:
 		resultNode := $1.(*ast.SubqueryExpr).Query
 		$$ = &ast.TableSource{Source: resultNode, AsName: $2.(ast.CIStr)}
 	}
+|	"LATERAL" SubSelect TableAsNameOpt
+	{
+		resultNode := $2.(*ast.SubqueryExpr).Query
+		$$ = &ast.TableSource{Source: resultNode, AsName: $3.(ast.CIStr), Lateral: true}
+	}
 |	'(' TableRefs ')'
 	{
 		j := $2.(*ast.Join)
@@ -11801,6 +11876,13 @@ ShowStmt:
 			Procedure: $4.(*ast.TableName),
 		}
 	}
+|	"SHOW" "CREATE" "EVENT" TableName
+	{
+		$$ = &ast.ShowStmt{
+			Tp:    ast.ShowCreateEvent,
+			Event: $4.(*ast.TableName),
+		}
+	}
 |	"SHOW" "TABLE" TableName PartitionNameListOpt "DISTRIBUTIONS" WhereClauseOptional
 	{
 		stmt := &ast.ShowStmt{
@@ -12354,6 +12436,7 @@ Statement:
 	EmptyStmt
 |	AdminStmt
 |	AlterDatabaseStmt
+|	AlterEventStmt
 |	AlterTableStmt
 |	AlterUserStmt
 |	AlterInstanceStmt
@@ -12372,7 +12455,9 @@ Statement:
 |	ExplainStmt
 |	CalibrateResourceStmt
 |	CreateDatabaseStmt
+|	CreateEventStmt
 |	CreateIndexStmt
+|	CreateMaterializedViewStmt
 |	CreateTableStmt
 |	CreateViewStmt
 |	CreateUserStmt
@@ -12384,10 +12469,13 @@ Statement:
 |	AddQueryWatchStmt
 |	CreateSequenceStmt
 |	CreateStatisticsStmt
+|	CreateTriggerStmt
 |	DistributeTableStmt
 |	DoStmt
 |	DropDatabaseStmt
+|	DropEventStmt
 |	DropIndexStmt
+|	DropMaterializedViewStmt
 |	DropTableStmt
 |	DropProcedureStmt
 |	DropPolicyStmt
@@ -12398,6 +12486,7 @@ Statement:
 |	DropQueryWatchStmt
 |	DropRoleStmt
 |	DropStatisticsStmt
+|	DropTriggerStmt
 |	DropStatsStmt
 |	DropBindingStmt
 |	FlushStmt
@@ -12414,6 +12503,7 @@ Statement:
 |	LoadDataStmt
 |	LoadStatsStmt
 |	LockStatsStmt
+|	MergeStmt
 |	UnlockStatsStmt
 |	PlanReplayerStmt
 |	PreparedStmt
@@ -12422,6 +12512,7 @@ Statement:
 |	RenameUserStmt
 |	ReplaceIntoStmt
 |	RecoverTableStmt
+|	RefreshMViewStmt
 |	ReleaseSavepointStmt
 |	RevokeStmt
 |	RevokeRoleStmt
@@ -12468,6 +12559,7 @@ TraceableStmt:
 |	UpdateStmt
 |	InsertIntoStmt
 |	ReplaceIntoStmt
+|	MergeStmt
 |	SetOprStmt
 |	SelectStmt
 |	SelectStmtWithClause
@@ -12498,6 +12590,7 @@ ExplainableStmt:
 |	UpdateStmt
 |	InsertIntoStmt
 |	ReplaceIntoStmt
+|	MergeStmt
 |	SetOprStmt
 |	SelectStmt
 |	SelectStmtWithClause
@@ -12976,6 +13069,7 @@ Type:
 	NumericType
 |	StringType
 |	DateAndTimeType
+|	SpatialType
 
 NumericType:
 	IntegerType OptFieldLen FieldOpts
@@ -13473,6 +13567,55 @@ OptCharsetWithOptBinary:
 		}
 	}
 
+SpatialType:
+	GeometryType
+	{
+		tp := types.NewFieldType(mysql.TypeGeometry)
+		tp.SetGeometryType($1.(types.GeometryType))
+		tp.SetCharset(charset.CharsetBin)
+		tp.SetCollate(charset.CollationBin)
+		tp.AddFlag(mysql.BinaryFlag)
+		$$ = tp
+	}
+
+GeometryType:
+	"GEOMETRY"
+	{
+		$$ = types.GeometryTypeGeometry
+	}
+|	"POINT"
+	{
+		$$ = types.GeometryTypePoint
+	}
+|	"LINESTRING"
+	{
+		$$ = types.GeometryTypeLineString
+	}
+|	"POLYGON"
+	{
+		$$ = types.GeometryTypePolygon
+	}
+|	"MULTIPOINT"
+	{
+		$$ = types.GeometryTypeMultiPoint
+	}
+|	"MULTILINESTRING"
+	{
+		$$ = types.GeometryTypeMultiLineString
+	}
+|	"MULTIPOLYGON"
+	{
+		$$ = types.GeometryTypeMultiPolygon
+	}
+|	"GEOMETRYCOLLECTION"
+	{
+		$$ = types.GeometryTypeGeometryCollection
+	}
+|	"GEOMCOLLECTION"
+	{
+		$$ = types.GeometryTypeGeometryCollection
+	}
+
 DateAndTimeType:
 	"DATE"
 	{
@@ -13746,6 +13889,92 @@ UpdateStmtNoWith:
 		$$ = st
 	}
 
+/***********************************************************************************
+ * Merge Statement
+ * MERGE INTO target USING source ON condition
+ *     WHEN MATCHED [AND condition] THEN UPDATE SET assignment_list
+ *     WHEN MATCHED [AND condition] THEN DELETE
+ *     WHEN NOT MATCHED [AND condition] THEN INSERT [(column_list)] VALUES (value_list)
+ ***********************************************************************************/
+MergeStmt:
+	"MERGE" TableOptimizerHintsOpt "INTO" TableName TableAsNameOpt "USING" TableFactor "ON" Expression MergeWhenClauseList
+	{
+		target := &ast.TableSource{Source: $4.(*ast.TableName), AsName: $5.(ast.CIStr)}
+		join := &ast.Join{
+			Left:  $7.(ast.ResultSetNode),
+			Right: target,
+			Tp:    ast.LeftJoin,
+			On:    &ast.OnCondition{Expr: $9.(ast.ExprNode)},
+		}
+		st := &ast.MergeStmt{
+			TableRefs: &ast.TableRefsClause{TableRefs: join},
+			Clauses:   $10.([]*ast.MergeWhenClause),
+		}
+		if $2 != nil {
+			st.TableHints = $2.([]*ast.TableOptimizerHint)
+		}
+		$$ = st
+	}
+
+MergeWhenClauseList:
+	MergeWhenClause
+	{
+		$$ = []*ast.MergeWhenClause{$1.(*ast.MergeWhenClause)}
+	}
+|	MergeWhenClauseList MergeWhenClause
+	{
+		$$ = append($1.([]*ast.MergeWhenClause), $2.(*ast.MergeWhenClause))
+	}
+
+MergeWhenClause:
+	"WHEN" "MATCHED" MergeWhenCondOpt "THEN" "UPDATE" "SET" AssignmentList
+	{
+		clause := &ast.MergeWhenClause{Action: ast.MergeActionUpdate, Assignments: $7.([]*ast.Assignment)}
+		if $3 != nil {
+			clause.Condition = $3.(ast.ExprNode)
+		}
+		$$ = clause
+	}
+|	"WHEN" "MATCHED" MergeWhenCondOpt "THEN" "DELETE"
+	{
+		clause := &ast.MergeWhenClause{Action: ast.MergeActionDelete}
+		if $3 != nil {
+			clause.Condition = $3.(ast.ExprNode)
+		}
+		$$ = clause
+	}
+|	"WHEN" "NOT" "MATCHED" MergeWhenCondOpt "THEN" "INSERT" MergeInsertColumnListOpt ValueSym RowValue
+	{
+		clause := &ast.MergeWhenClause{
+			NotMatched: true,
+			Action:     ast.MergeActionInsert,
+			Columns:    $7.([]*ast.ColumnName),
+			Values:     $9.([]ast.ExprNode),
+		}
+		if $4 != nil {
+			clause.Condition = $4.(ast.ExprNode)
+		}
+		$$ = clause
+	}
+
+MergeWhenCondOpt:
+	{
+		$$ = nil
+	}
+|	"AND" Expression
+	{
+		$$ = $2
+	}
+
+MergeInsertColumnListOpt:
+	{
+		$$ = []*ast.ColumnName{}
+	}
+|	'(' ColumnNameListOpt ')'
+	{
+		$$ = $2
+	}
+
 UseStmt:
 	"USE" DBName
 	{
@@ -14449,6 +14678,25 @@ RecommendIndexStmt:
 			Options: $4.([]ast.RecommendIndexOption),
 		}
 
+		$$ = x
+	}
+|	"RECOMMEND" "INDEX" "DROP" "FOR" stringLit RecommendIndexOptionListOpt
+	{
+		x := &ast.RecommendIndexStmt{
+			Action:  "drop",
+			SQL:     $5,
+			Options: $6.([]ast.RecommendIndexOption),
+		}
+
+		$$ = x
+	}
+|	"RECOMMEND" "INDEX" "DROP" RecommendIndexOptionListOpt
+	{
+		x := &ast.RecommendIndexStmt{
+			Action:  "drop",
+			Options: $4.([]ast.RecommendIndexOption),
+		}
+
 		$$ = x
 	}
 |	"RECOMMEND" "INDEX" "SHOW" "OPTION"
@@ -16115,6 +16363,7 @@ ProcedureStatementStmt:
 |	DeleteFromStmt
 |	AnalyzeTableStmt
 |	TruncateTableStmt
+|	CallStmt
 
 ProcedureCursorSelectStmt:
 	SelectStmt
@@ -16627,6 +16876,333 @@ DropProcedureStmt:
 		}
 	}
 
+/********************************************************************************************
+ *
+ *  Create Event Statement
+ *
+ *  Example:
+ *  CREATE
+ *  [DEFINER = user]
+ *  EVENT [IF NOT EXISTS] event_name
+ *  ON SCHEDULE schedule
+ *  [ON COMPLETION [NOT] PRESERVE]
+ *  [ENABLE | DISABLE]
+ *  [COMMENT 'string']
+ *  DO event_body
+ *  schedule: {
+ *  AT timestamp [+ INTERVAL interval] ...
+ *  | EVERY interval
+ *  [STARTS timestamp [+ INTERVAL interval] ...]
+ *  [ENDS timestamp [+ INTERVAL interval] ...]
+ *  }
+ ********************************************************************************************/
+CreateEventStmt:
+	"CREATE" OrReplace ViewAlgorithm ViewDefiner "EVENT" IfNotExists TableName "ON" "SCHEDULE" EventSchedule EventCompletionOpt EventStatusOpt EventCommentOpt "DO" ProcedureProcStmt
+	{
+		// OrReplace and ViewAlgorithm are only accepted here to share the prefix with CREATE VIEW.
+		if $2.(bool) || $3.(ast.ViewAlgorithm) != ast.AlgorithmUndefined {
+			yylex.AppendError(yylex.Errorf("OR REPLACE and ALGORITHM are not supported by CREATE EVENT"))
+			return 1
+		}
+		x := &ast.CreateEventStmt{
+			Definer:     $4.(*auth.UserIdentity),
+			IfNotExists: $6.(bool),
+			EventName:   $7.(*ast.TableName),
+			Schedule:    $10.(*ast.EventSchedule),
+			Completion:  $11.(ast.EventCompletion),
+			Status:      $12.(ast.EventStatus),
+			Body:        $15,
+		}
+		if $13 != nil {
+			x.Comment = *$13.(*string)
+		}
+		$$ = x
+	}
+
+EventSchedule:
+	"AT" Expression
+	{
+		$$ = &ast.EventSchedule{At: $2}
+	}
+|	"EVERY" Expression TimeUnit EventStartsOpt EventEndsOpt
+	{
+		x := &ast.EventSchedule{
+			Every: $2,
+			Unit:  $3.(ast.TimeUnitType),
+		}
+		if $4 != nil {
+			x.Starts = $4.(ast.ExprNode)
+		}
+		if $5 != nil {
+			x.Ends = $5.(ast.ExprNode)
+		}
+		$$ = x
+	}
+
+EventStartsOpt:
+	{
+		$$ = nil
+	}
+|	"STARTS" Expression
+	{
+		$$ = $2
+	}
+
+EventEndsOpt:
+	{
+		$$ = nil
+	}
+|	"ENDS" Expression
+	{
+		$$ = $2
+	}
+
+EventCompletion:
+	"ON" "COMPLETION" "PRESERVE"
+	{
+		$$ = ast.EventCompletionPreserve
+	}
+|	"ON" "COMPLETION" "NOT" "PRESERVE"
+	{
+		$$ = ast.EventCompletionNotPreserve
+	}
+
+EventCompletionOpt:
+	{
+		$$ = ast.EventCompletionUnspecified
+	}
+|	EventCompletion
+
+EventStatusOpt:
+	{
+		$$ = ast.EventStatusUnspecified
+	}
+|	"ENABLE"
+	{
+		$$ = ast.EventStatusEnable
+	}
+|	"DISABLE"
+	{
+		$$ = ast.EventStatusDisable
+	}
+
+EventCommentOpt:
+	{
+		$$ = nil
+	}
+|	"COMMENT" stringLit
+	{
+		comment := $2
+		$$ = &comment
+	}
+
+/********************************************************************************************
+ *
+ *  Alter Event Statement
+ *
+ *  Example:
+ *  ALTER EVENT event_name
+ *  [ON SCHEDULE schedule]
+ *  [ON COMPLETION [NOT] PRESERVE]
+ *  [RENAME TO new_event_name]
+ *  [ENABLE | DISABLE]
+ *  [COMMENT 'string']
+ *  [DO event_body]
+ ********************************************************************************************/
+AlterEventStmt:
+	"ALTER" "EVENT" TableName AlterEventOnOpt EventRenameOpt EventStatusOpt EventCommentOpt EventBodyOpt
+	{
+		x := &ast.AlterEventStmt{
+			EventName: $3.(*ast.TableName),
+			Status:    $6.(ast.EventStatus),
+			Body:      $8,
+		}
+		if $4 != nil {
+			on := $4.(*ast.AlterEventStmt)
+			x.Schedule, x.Completion = on.Schedule, on.Completion
+		}
+		if $5 != nil {
+			x.NewName = $5.(*ast.TableName)
+		}
+		if $7 != nil {
+			x.Comment = $7.(*string)
+		}
+		if x.Schedule == nil && x.Completion == ast.EventCompletionUnspecified && x.NewName == nil &&
+			x.Status == ast.EventStatusUnspecified && x.Comment == nil && x.Body == nil {
+			yylex.AppendError(yylex.Errorf("ALTER EVENT requires at least one option"))
+			return 1
+		}
+		$$ = x
+	}
+
+AlterEventOnOpt:
+	{
+		$$ = nil
+	}
+|	"ON" "SCHEDULE" EventSchedule EventCompletionOpt
+	{
+		$$ = &ast.AlterEventStmt{
+			Schedule:   $3.(*ast.EventSchedule),
+			Completion: $4.(ast.EventCompletion),
+		}
+	}
+|	EventCompletion
+	{
+		$$ = &ast.AlterEventStmt{Completion: $1.(ast.EventCompletion)}
+	}
+
+EventRenameOpt:
+	{
+		$$ = nil
+	}
+|	"RENAME" "TO" TableName
+	{
+		$$ = $3
+	}
+
+EventBodyOpt:
+	{
+		$$ = nil
+	}
+|	"DO" ProcedureProcStmt
+	{
+		$$ = $2
+	}
+
+/********************************************************************************************
+*  DROP EVENT [IF EXISTS] event_name
+********************************************************************************************/
+DropEventStmt:
+	"DROP" "EVENT" IfExists TableName
+	{
+		$$ = &ast.DropEventStmt{
+			IfExists:  $3.(bool),
+			EventName: $4.(*ast.TableName),
+		}
+	}
+
+/********************************************************************************************
+ *
+ *  Create Trigger Statement
+ *
+ *  Example:
+ *  CREATE
+ *  TRIGGER [IF NOT EXISTS] trigger_name
+ *  trigger_time trigger_event
+ *  ON tbl_name FOR EACH ROW
+ *  trigger_body
+ *  trigger_time: { BEFORE | AFTER }
+ *  trigger_event: { INSERT | UPDATE | DELETE }
+ ********************************************************************************************/
+CreateTriggerStmt:
+	"CREATE" "TRIGGER" IfNotExists TableName TriggerTiming TriggerEvent "ON" TableName "FOR" "EACH" "ROW" ProcedureProcStmt
+	{
+		$$ = &ast.CreateTriggerStmt{
+			IfNotExists: $3.(bool),
+			TriggerName: $4.(*ast.TableName),
+			Timing:      $5.(ast.TriggerTiming),
+			Event:       $6.(ast.TriggerEvent),
+			Table:       $8.(*ast.TableName),
+			Body:        $12,
+		}
+	}
+
+TriggerTiming:
+	"BEFORE"
+	{
+		$$ = ast.TriggerTimingBefore
+	}
+|	"AFTER"
+	{
+		$$ = ast.TriggerTimingAfter
+	}
+
+TriggerEvent:
+	"INSERT"
+	{
+		$$ = ast.TriggerEventInsert
+	}
+|	"UPDATE"
+	{
+		$$ = ast.TriggerEventUpdate
+	}
+|	"DELETE"
+	{
+		$$ = ast.TriggerEventDelete
+	}
+
+/********************************************************************************************
+*  DROP TRIGGER [IF EXISTS] [schema_name.]trigger_name
+********************************************************************************************/
+DropTriggerStmt:
+	"DROP" "TRIGGER" IfExists TableName
+	{
+		$$ = &ast.DropTriggerStmt{
+			IfExists:    $3.(bool),
+			TriggerName: $4.(*ast.TableName),
+		}
+	}
+
+/*******************************************************************
+ *
+ *  Create Materialized View Statement
+ *
+ *  Example:
+ *      CREATE MATERIALIZED VIEW IF NOT EXISTS mv (a, cnt)
+ *          AS SELECT a, COUNT(*) FROM t GROUP BY a
+ *******************************************************************/
+CreateMaterializedViewStmt:
+	"CREATE" "MATERIALIZED" "VIEW" IfNotExists ViewName ViewFieldList "AS" CreateViewSelectOpt
+	{
+		x := &ast.CreateMaterializedViewStmt{
+			IfNotExists: $4.(bool),
+			ViewName:    $5.(*ast.TableName),
+			Select:      $8.(ast.StmtNode),
+		}
+		if $6 != nil {
+			x.Cols = $6.([]ast.CIStr)
+		}
+		$$ = x
+	}
+
+/*******************************************************************
+ *  DROP MATERIALIZED VIEW [IF EXISTS] [schema_name.]view_name
+ *******************************************************************/
+DropMaterializedViewStmt:
+	"DROP" "MATERIALIZED" "VIEW" IfExists TableName
+	{
+		$$ = &ast.DropMaterializedViewStmt{
+			IfExists: $4.(bool),
+			ViewName: $5.(*ast.TableName),
+		}
+	}
+
+/*******************************************************************
+ *  REFRESH MATERIALIZED VIEW [schema_name.]view_name [COMPLETE | FAST]
+ *******************************************************************/
+RefreshMViewStmt:
+	"REFRESH" "MATERIALIZED" "VIEW" TableName RefreshMethodOpt
+	{
+		$$ = &ast.RefreshMaterializedViewStmt{
+			ViewName: $4.(*ast.TableName),
+			Method:   $5.(ast.RefreshMethod),
+		}
+	}
+
+RefreshMethodOpt:
+	/* EMPTY */
+	{
+		$$ = ast.RefreshMethodDefault
+	}
+|	"COMPLETE"
+	{
+		$$ = ast.RefreshMethodComplete
+	}
+|	"FAST"
+	{
+		$$ = ast.RefreshMethodFast
+	}
+
 /********************************************************************
  *
  * Calibrate Resource Statement
@@ -474,6 +474,13 @@ func TestRecommendIndex(t *testing.T) {
 			"RECOMMEND INDEX RUN FOR 'select * from t where a=1' WITH A = 1"},
 		{"recommend index run for 'select * from t where a=1' with A = 1, B = 2", true,
 			"RECOMMEND INDEX RUN FOR 'select * from t where a=1' WITH A = 1, B = 2"},
+		{"recommend index drop", true, "RECOMMEND INDEX DROP"},
+		{"recommend index drop with A = 1", true, "RECOMMEND INDEX DROP WITH A = 1"},
+		{"recommend index drop for 'select * from t where a=1'", true,
+			"RECOMMEND INDEX DROP FOR 'select * from t where a=1'"},
+		{"recommend index drop for 'select * from t where a=1' with A = 1, B = 2", true,
+			"RECOMMEND INDEX DROP FOR 'select * from t where a=1' WITH A = 1, B = 2"},
+		{"recommend index drop 1", false, ""},
 		{"recommend index show option", true, "RECOMMEND INDEX SHOW OPTION"},
 		{"recommend index apply 1", true, "RECOMMEND INDEX APPLY 1"},
 		{"recommend index ignore 1", true, "RECOMMEND INDEX IGNORE 1"},
@@ -972,6 +979,23 @@ func TestDMLStmt(t *testing.T) {
 		{"select min(b) b from (select min(t.b) b from t where t.a = '');", true, "SELECT MIN(`b`) AS `b` FROM (SELECT MIN(`t`.`b`) AS `b` FROM `t` WHERE `t`.`a`=_UTF8MB4'')"},
 		{"select min(b) b from (select min(t.b) b from t where t.a = '') as t1;", true, "SELECT MIN(`b`) AS `b` FROM (SELECT MIN(`t`.`b`) AS `b` FROM `t` WHERE `t`.`a`=_UTF8MB4'') AS `t1`"},
 
+		// for lateral derived tables
+		{"select * from t, lateral (select * from t1 where t1.a = t.a limit 3) as dt", true, "SELECT * FROM (`t`) JOIN LATERAL (SELECT * FROM `t1` WHERE `t1`.`a`=`t`.`a` LIMIT 3) AS `dt`"},
+		{"select * from t left join lateral (select max(b) m from t1 where t1.a = t.a) dt on true", true, "SELECT * FROM `t` LEFT JOIN LATERAL (SELECT MAX(`b`) AS `m` FROM `t1` WHERE `t1`.`a`=`t`.`a`) AS `dt` ON TRUE"},
+		{"select * from t cross join lateral (select 1 union select t.a) dt", true, "SELECT * FROM `t` JOIN LATERAL (SELECT 1 UNION SELECT `t`.`a`) AS `dt`"},
+		{"select * from lateral (select 1) as dt", true, "SELECT * FROM LATERAL (SELECT 1) AS `dt`"},
+		{"select lateral from lateral", true, "SELECT `lateral` FROM `lateral`"},
+		{"select * from t, lateral t1", true, "SELECT * FROM (`t`) JOIN `lateral` AS `t1`"},
+
+		// for merge statement
+		{"merge into t using s on t.a = s.a when matched then update set t.b = s.b when not matched then insert (a, b) values (s.a, s.b)", true, "MERGE INTO `t` USING `s` ON `t`.`a`=`s`.`a` WHEN MATCHED THEN UPDATE SET `t`.`b`=`s`.`b` WHEN NOT MATCHED THEN INSERT (`a`,`b`) VALUES (`s`.`a`,`s`.`b`)"},
+		{"merge into t as x using (select * from s) as y on x.a = y.a when matched and y.op = 'D' then delete when matched then update set b = y.b", true, "MERGE INTO `t` AS `x` USING (SELECT * FROM `s`) AS `y` ON `x`.`a`=`y`.`a` WHEN MATCHED AND `y`.`op`=_UTF8MB4'D' THEN DELETE WHEN MATCHED THEN UPDATE SET `b`=`y`.`b`"},
+		{"merge /*+ HASH_JOIN(t) */ into t using s on t.a = s.a when not matched and s.a > 0 then insert values (s.a, default)", true, "MERGE /*+ HASH_JOIN(`t`)*/ INTO `t` USING `s` ON `t`.`a`=`s`.`a` WHEN NOT MATCHED AND `s`.`a`>0 THEN INSERT VALUES (`s`.`a`,DEFAULT)"},
+		{"merge into t using s on t.a = s.a", false, ""},
+		{"merge into t using s on t.a = s.a when not matched then update set b = 1", false, ""},
+		{"merge into t using s on t.a = s.a when matched then insert values (1)", false, ""},
+		{"select matched from matched", true, "SELECT `matched` FROM `matched`"},
+
 		// for https://github.com/pingcap/tidb/issues/1050
 		{`SELECT /*!40001 SQL_NO_CACHE */ * FROM test WHERE 1 limit 0, 2000;`, true, "SELECT SQL_NO_CACHE * FROM `test` WHERE 1 LIMIT 0,2000"},
 
@@ -3847,6 +3871,52 @@ func TestDDL(t *testing.T) {
 		{"drop sequence seq seq2", false, ""},
 		{"drop sequence seq, seq2", true, "DROP SEQUENCE `seq`, `seq2`"},
 
+		// for trigger
+		{"create trigger tr before insert on t for each row set new.a = 1", true, "CREATE TRIGGER `tr` BEFORE INSERT ON `t` FOR EACH ROW SET @@SESSION.`new.a`=1"},
+		{"create trigger if not exists test.tr after update on test.t for each row insert into log values (old.a, new.a)", true, "CREATE TRIGGER IF NOT EXISTS `test`.`tr` AFTER UPDATE ON `test`.`t` FOR EACH ROW INSERT INTO `log` VALUES (`old`.`a`,`new`.`a`)"},
+		{"create trigger tr after delete on t for each row begin delete from t2 where id = old.id; end", true, "CREATE TRIGGER `tr` AFTER DELETE ON `t` FOR EACH ROW BEGIN DELETE FROM `t2` WHERE `id`=`old`.`id`; END"},
+		{"create trigger tr before replace on t for each row set new.a = 1", false, ""},
+		{"create trigger tr on t for each row set new.a = 1", false, ""},
+		{"create trigger tr before insert on t set new.a = 1", false, ""},
+		{"drop trigger tr", true, "DROP TRIGGER `tr`"},
+		{"drop trigger if exists test.tr", true, "DROP TRIGGER IF EXISTS `test`.`tr`"},
+		{"create table before (each int)", true, "CREATE TABLE `before` (`each` INT)"},
+
+		// for event
+		{"create event e on schedule at '2025-01-01 00:00:00' do insert into t values (1)", true, "CREATE DEFINER = CURRENT_USER EVENT `e` ON SCHEDULE AT _UTF8MB4'2025-01-01 00:00:00' DO INSERT INTO `t` VALUES (1)"},
+		{"create definer = 'root'@'%' event if not exists test.e on schedule at current_timestamp + interval 1 hour on completion preserve disable comment 'c' do delete from t", true, "CREATE DEFINER = `root`@`%` EVENT IF NOT EXISTS `test`.`e` ON SCHEDULE AT DATE_ADD(CURRENT_TIMESTAMP(), INTERVAL 1 HOUR) ON COMPLETION PRESERVE DISABLE COMMENT 'c' DO DELETE FROM `t`"},
+		{"create event e on schedule every 2 day starts '2025-01-01' ends '2025-02-01' on completion not preserve enable do begin insert into t values (1); delete from t; end", true, "CREATE DEFINER = CURRENT_USER EVENT `e` ON SCHEDULE EVERY 2 DAY STARTS _UTF8MB4'2025-01-01' ENDS _UTF8MB4'2025-02-01' ON COMPLETION NOT PRESERVE ENABLE DO BEGIN INSERT INTO `t` VALUES (1);DELETE FROM `t`; END"},
+		{"create event e on schedule every '1:30' hour_minute do delete from t", true, "CREATE DEFINER = CURRENT_USER EVENT `e` ON SCHEDULE EVERY _UTF8MB4'1:30' HOUR_MINUTE DO DELETE FROM `t`"},
+		{"create or replace event e on schedule every 1 day do delete from t", false, ""},
+		{"create event e on schedule every 1 day", false, ""},
+		{"create event e do delete from t", false, ""},
+		{"alter event e on schedule every 1 hour", true, "ALTER EVENT `e` ON SCHEDULE EVERY 1 HOUR"},
+		{"alter event test.e on schedule at '2025-01-01' on completion preserve rename to e2 disable comment 'c' do delete from t", true, "ALTER EVENT `test`.`e` ON SCHEDULE AT _UTF8MB4'2025-01-01' ON COMPLETION PRESERVE RENAME TO `e2` DISABLE COMMENT 'c' DO DELETE FROM `t`"},
+		{"alter event e on completion not preserve", true, "ALTER EVENT `e` ON COMPLETION NOT PRESERVE"},
+		{"alter event e enable", true, "ALTER EVENT `e` ENABLE"},
+		{"alter event e comment ''", true, "ALTER EVENT `e` COMMENT ''"},
+		{"alter event e do delete from t", true, "ALTER EVENT `e` DO DELETE FROM `t`"},
+		{"alter event e", false, ""},
+		{"drop event e", true, "DROP EVENT `e`"},
+		{"drop event if exists test.e", true, "DROP EVENT IF EXISTS `test`.`e`"},
+		{"show create event test.e", true, "SHOW CREATE EVENT `test`.`e`"},
+		{"show events from test like 'e%'", true, "SHOW EVENTS IN `test` LIKE _UTF8MB4'e%'"},
+		{"create table at (every int, starts int, ends int, completion int)", true, "CREATE TABLE `at` (`every` INT,`starts` INT,`ends` INT,`completion` INT)"},
+
+		// for materialized view
+		{"create materialized view mv as select a, count(*) from t group by a", true, "CREATE MATERIALIZED VIEW `mv` AS SELECT `a`,COUNT(1) FROM `t` GROUP BY `a`"},
+		{"create materialized view if not exists test.mv (a, cnt) as select a, count(*) from t where b > 1 group by a", true, "CREATE MATERIALIZED VIEW IF NOT EXISTS `test`.`mv` (`a`,`cnt`) AS SELECT `a`,COUNT(1) FROM `t` WHERE `b`>1 GROUP BY `a`"},
+		{"create materialized view mv as (select a from t union select b from t)", true, "CREATE MATERIALIZED VIEW `mv` AS (SELECT `a` FROM `t` UNION SELECT `b` FROM `t`)"},
+		{"create or replace materialized view mv as select * from t", false, ""},
+		{"drop materialized view mv", true, "DROP MATERIALIZED VIEW `mv`"},
+		{"drop materialized view if exists test.mv", true, "DROP MATERIALIZED VIEW IF EXISTS `test`.`mv`"},
+		{"drop materialized view mv, mv2", false, ""},
+		{"refresh materialized view mv", true, "REFRESH MATERIALIZED VIEW `mv`"},
+		{"refresh materialized view test.mv complete", true, "REFRESH MATERIALIZED VIEW `test`.`mv` COMPLETE"},
+		{"refresh materialized view mv fast", true, "REFRESH MATERIALIZED VIEW `mv` FAST"},
+		{"refresh materialized view mv force", false, ""},
+		{"create table materialized (refresh int, complete int, fast int)", true, "CREATE TABLE `materialized` (`refresh` INT,`complete` INT,`fast` INT)"},
+
 		// for auto_random
 		{"create table t (a bigint auto_random(3) primary key, b varchar(255))", true, "CREATE TABLE `t` (`a` BIGINT AUTO_RANDOM(3) PRIMARY KEY,`b` VARCHAR(255))"},
 		{"create table t (a bigint auto_random primary key, b varchar(255))", true, "CREATE TABLE `t` (`a` BIGINT AUTO_RANDOM PRIMARY KEY,`b` VARCHAR(255))"},
@@ -5060,6 +5130,12 @@ func TestType(t *testing.T) {
 
 		// for json type
 		{`create table t (a JSON);`, true, "CREATE TABLE `t` (`a` JSON)"},
+
+		// for spatial types
+		{`create table t (a geometry, b point not null, c linestring, d polygon);`, true, "CREATE TABLE `t` (`a` GEOMETRY,`b` POINT NOT NULL,`c` LINESTRING,`d` POLYGON)"},
+		{`create table t (a multipoint, b multilinestring, c multipolygon, d geometrycollection, e geomcollection);`, true, "CREATE TABLE `t` (`a` MULTIPOINT,`b` MULTILINESTRING,`c` MULTIPOLYGON,`d` GEOMCOLLECTION,`e` GEOMCOLLECTION)"},
+		{`create table geometry (point int, polygon int);`, true, "CREATE TABLE `geometry` (`point` INT,`polygon` INT)"},
+		{`create table t (a geometry(10));`, false, ""},
 	}
 	RunTest(t, table, false)
 }
@@ -7016,6 +7092,20 @@ func (checker *nodeTextCleaner) Enter(in ast.Node) (out ast.Node, skipChildren b
 				}
 			}
 		}
+	case *ast.CreateTriggerStmt:
+		// The trigger body is not traversed by Accept.
+		node.Body.Accept(checker)
+	case *ast.CreateEventStmt:
+		// The event body is not traversed by Accept.
+		node.Body.Accept(checker)
+	case *ast.AlterEventStmt:
+		if node.Body != nil {
+			node.Body.Accept(checker)
+		}
+	case *ast.ProcedureBlock:
+		for _, stmt := range node.ProcedureProcStmts {
+			stmt.Accept(checker)
+		}
 	case *ast.DeleteStmt:
 		for _, tableHint := range node.TableHints {
 			tableHint.HintName.O = ""
@@ -72,6 +72,8 @@ type FieldType struct {
 	elems            []string
 	elemsIsBinaryLit []bool
 	array            bool
+	// geometryType is the subtype of the geometry type.
+	geometryType GeometryType
 	// Please keep in mind that jsonFieldType should be updated if you add a new field here.
 }
 
@@ -92,6 +94,7 @@ func (ft *FieldType) Hash64(h IHasher) {
 		h.HashBool(elem)
 	}
 	h.HashBool(ft.array)
+	h.HashByte(byte(ft.geometryType))
 }
 
 // Equals implements the cascades/base.Hasher.<1th> interface.
@@ -112,7 +115,8 @@ func (ft *FieldType) Equals(other any) bool {
 		ft.decimal == ft2.decimal &&
 		ft.charset == ft2.charset &&
 		ft.collate == ft2.collate &&
-		ft.array == ft2.array
+		ft.array == ft2.array &&
+		ft.geometryType == ft2.geometryType
 	if !ok {
 		return false
 	}
@@ -205,6 +209,9 @@ func (ft *FieldType) GetElems() []string {
 func (ft *FieldType) SetType(tp byte) {
 	ft.tp = tp
 	ft.array = false
+	if tp != mysql.TypeGeometry {
+		ft.geometryType = GeometryTypeGeometry
+	}
 }
 
 // SetFlag sets the flag of the FieldType.
@@ -303,6 +310,16 @@ func (ft *FieldType) SetArray(array bool) {
 	ft.array = array
 }
 
+// GetGeometryType returns the subtype of the geometry type.
+func (ft *FieldType) GetGeometryType() GeometryType {
+	return ft.geometryType
+}
+
+// SetGeometryType sets the subtype of the geometry type.
+func (ft *FieldType) SetGeometryType(tp GeometryType) {
+	ft.geometryType = tp
+}
+
 // IsArray return true if the filed type is array.
 func (ft *FieldType) IsArray() bool {
 	return ft.array
@@ -371,7 +388,8 @@ func (ft *FieldType) Equal(other *FieldType) bool {
 		ft.charset == other.charset &&
 		ft.collate == other.collate &&
 		flenEqual &&
-		mysql.HasUnsignedFlag(ft.flag) == mysql.HasUnsignedFlag(other.flag)
+		mysql.HasUnsignedFlag(ft.flag) == mysql.HasUnsignedFlag(other.flag) &&
+		ft.geometryType == other.geometryType
 	if !partialEqual {
 		return false
 	}
@@ -442,6 +460,9 @@ func (ft *FieldType) Init(tp byte) {
 // This is used for showing column type in infoschema.
 func (ft *FieldType) CompactStr() string {
 	ts := TypeToStr(ft.GetType(), ft.charset)
+	if ft.GetType() == mysql.TypeGeometry {
+		ts = ft.geometryType.String()
+	}
 	suffix := ""
 
 	defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimal(ft.GetType())
@@ -549,6 +570,10 @@ func (ft *FieldType) String() string {
 
 // Restore implements Node interface.
 func (ft *FieldType) Restore(ctx *format.RestoreCtx) error {
+	if ft.GetType() == mysql.TypeGeometry {
+		ctx.WriteKeyWord(ft.geometryType.String())
+		return nil
+	}
 	ctx.WriteKeyWord(TypeToStr(ft.GetType(), ft.charset))
 
 	precision := UnspecifiedLength
@@ -722,6 +747,7 @@ type jsonFieldType struct {
 	Elems            []string
 	ElemsIsBinaryLit []bool
 	Array            bool
+	GeometryType     GeometryType `json:",omitempty"`
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
@@ -738,6 +764,7 @@ func (ft *FieldType) UnmarshalJSON(data []byte) error {
 		ft.elems = r.Elems
 		ft.elemsIsBinaryLit = r.ElemsIsBinaryLit
 		ft.array = r.Array
+		ft.geometryType = r.GeometryType
 	}
 	return err
 }
@@ -754,6 +781,7 @@ func (ft *FieldType) MarshalJSON() ([]byte, error) {
 	r.Elems = ft.elems
 	r.ElemsIsBinaryLit = ft.elemsIsBinaryLit
 	r.Array = ft.array
+	r.GeometryType = ft.geometryType
 	return json.Marshal(r)
 }
 
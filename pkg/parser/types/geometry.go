@@ -0,0 +1,57 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// GeometryType is the subtype of a spatial column. The values are the same as
+// the geometry type codes in WKB, except that GeometryTypeGeometry means any
+// geometry is accepted.
+type GeometryType byte
+
+// Geometry types.
+const (
+	GeometryTypeGeometry GeometryType = iota
+	GeometryTypePoint
+	GeometryTypeLineString
+	GeometryTypePolygon
+	GeometryTypeMultiPoint
+	GeometryTypeMultiLineString
+	GeometryTypeMultiPolygon
+	GeometryTypeGeometryCollection
+)
+
+var geometryTypeStr = [...]string{
+	GeometryTypeGeometry:           "geometry",
+	GeometryTypePoint:              "point",
+	GeometryTypeLineString:         "linestring",
+	GeometryTypePolygon:            "polygon",
+	GeometryTypeMultiPoint:         "multipoint",
+	GeometryTypeMultiLineString:    "multilinestring",
+	GeometryTypeMultiPolygon:       "multipolygon",
+	GeometryTypeGeometryCollection: "geomcollection",
+}
+
+// String implements the fmt.Stringer interface.
+func (t GeometryType) String() string {
+	if int(t) < len(geometryTypeStr) {
+		return geometryTypeStr[t]
+	}
+	return geometryTypeStr[GeometryTypeGeometry]
+}
+
+// Accepts checks whether a column of geometry type t can store a geometry of
+// type v.
+func (t GeometryType) Accepts(v GeometryType) bool {
+	return t == GeometryTypeGeometry || t == v
+}
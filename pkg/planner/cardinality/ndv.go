@@ -16,8 +16,10 @@ package cardinality
 
 import (
 	"math"
+	"slices"
 
 	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/planner/property"
 	"github.com/pingcap/tidb/pkg/statistics"
 	"github.com/pingcap/tidb/pkg/util/logutil"
@@ -44,6 +46,23 @@ func EstimateColumnNDV(tbl *statistics.Table, colID int64) (ndv float64) {
 	return ndv
 }
 
+// EstimateColGroupNDVByExtendedStats returns the NDV of a column group from the cardinality extended stats built on
+// exactly the same columns. colIDs are the column IDs in the metadata.
+func EstimateColGroupNDVByExtendedStats(coll *statistics.HistColl, colIDs []int64) (float64, bool) {
+	if coll == nil || coll.ExtStats == nil || len(colIDs) < 2 {
+		return 0, false
+	}
+	for _, item := range coll.ExtStats.Stats {
+		if item.Tp != ast.StatsTypeCardinality || item.ScalarVals <= 0 || len(item.ColIDs) != len(colIDs) {
+			continue
+		}
+		if !slices.ContainsFunc(item.ColIDs, func(id int64) bool { return !slices.Contains(colIDs, id) }) {
+			return min(item.ScalarVals, float64(max(coll.RealtimeCount, 1))), true
+		}
+	}
+	return 0, false
+}
+
 // getTotalRowCount returns the total row count, which is obtained when collecting colHist.
 func getTotalRowCount(statsTbl *statistics.Table, colHist *statistics.Column) int64 {
 	if colHist.IsFullLoad() {
@@ -16,6 +16,7 @@ package cardinality
 
 import (
 	"cmp"
+	"maps"
 	"math"
 	"math/bits"
 	"slices"
@@ -261,6 +262,12 @@ func Selectivity(
 			)
 		}
 	}
+	if factor := correlatedColsSelectivityFactor(ctx, coll, usedSets); factor != 1 {
+		ret *= factor
+		if sc.EnableOptimizerDebugTrace {
+			debugtrace.RecordAnyValuesWithNames(ctx, "Extended Stats Adjustment Factor", factor)
+		}
+	}
 
 	notCoveredConstants := make(map[int]*expression.Constant)
 	notCoveredDNF := make(map[int]*expression.ScalarFunction)
@@ -458,6 +465,82 @@ OUTER:
 	return ret, nodes, nil
 }
 
+// correlatedColsSelectivityFactor uses the extended stats to adjust the selectivity of the point conditions on
+// correlated columns, e.g, `city = 'x' and zip = 'y'`, which is estimated under the independence assumption by
+// multiplying the selectivity of each column. It returns the factor to be multiplied with the combined selectivity.
+//   - For the cardinality stats whose columns are all covered by point conditions, the combined selectivity of them is
+//     estimated as 1/NDV of the column group, bounded by the independent estimation and the minimal one of them.
+//   - For the dependency stats X -> Y with degree d, sel(X, Y) = sel(X) * (d + (1-d) * sel(Y)).
+func correlatedColsSelectivityFactor(ctx planctx.PlanContext, coll *statistics.HistColl, usedSets []*StatsNode) float64 {
+	if coll.ExtStats == nil || len(coll.ExtStats.Stats) == 0 {
+		return 1
+	}
+	tc := ctx.GetSessionVars().StmtCtx.TypeCtx()
+	// column ID in the metadata -> selectivity of the point conditions on this column
+	pointSels := make(map[int64]float64, len(usedSets))
+	for _, set := range usedSets {
+		if set.partCover || len(set.Ranges) == 0 || set.Selectivity <= 0 {
+			continue
+		}
+		uniqueID := set.ID
+		if set.Tp == IndexType {
+			if set.numCols != 1 || len(coll.Idx2ColUniqueIDs[set.ID]) == 0 {
+				continue
+			}
+			uniqueID = coll.Idx2ColUniqueIDs[set.ID][0]
+		}
+		colID, ok := coll.UniqueID2colInfoID[uniqueID]
+		if !ok {
+			continue
+		}
+		if slices.ContainsFunc(set.Ranges, func(ran *ranger.Range) bool { return !ran.IsPointNullable(tc) }) {
+			continue
+		}
+		pointSels[colID] = set.Selectivity
+	}
+	if len(pointSels) < 2 {
+		return 1
+	}
+	names := slices.Sorted(maps.Keys(coll.ExtStats.Stats))
+	factor := 1.0
+	adjusted := make(map[int64]struct{}, len(pointSels))
+	isCandidate := func(colID int64) bool {
+		_, isPoint := pointSels[colID]
+		_, isAdjusted := adjusted[colID]
+		return isPoint && !isAdjusted
+	}
+	for _, name := range names {
+		item := coll.ExtStats.Stats[name]
+		if item.Tp != ast.StatsTypeCardinality || item.ScalarVals < 1 || len(item.ColIDs) < 2 ||
+			slices.ContainsFunc(item.ColIDs, func(id int64) bool { return !isCandidate(id) }) {
+			continue
+		}
+		independentSel, minSel := 1.0, 1.0
+		for _, id := range item.ColIDs {
+			independentSel *= pointSels[id]
+			minSel = min(minSel, pointSels[id])
+			adjusted[id] = struct{}{}
+		}
+		groupSel := mathutil.Clamp(1/item.ScalarVals, independentSel, minSel)
+		factor *= groupSel / independentSel
+	}
+	for _, name := range names {
+		item := coll.ExtStats.Stats[name]
+		if item.Tp != ast.StatsTypeDependency || len(item.ColIDs) != 2 {
+			continue
+		}
+		colX, colY := item.ColIDs[0], item.ColIDs[1]
+		if _, ok := pointSels[colX]; !ok || !isCandidate(colY) {
+			continue
+		}
+		degree := mathutil.Clamp(item.ScalarVals, 0, 1)
+		selY := pointSels[colY]
+		factor *= (degree + (1-degree)*selY) / selY
+		adjusted[colY] = struct{}{}
+	}
+	return factor
+}
+
 // CalcTotalSelectivityForMVIdxPath calculates the total selectivity for the given partial paths of an MV index merge path.
 // It corresponds with the meaning of AccessPath.CountAfterAccess, as used in buildPartialPathUp4MVIndex.
 // It uses the independence assumption to estimate the selectivity.
@@ -1528,3 +1528,55 @@ func TestRiskEqSkewRatio(t *testing.T) {
 	require.Equal(t, float64(2), count)
 	testKit.MustExec("set @@session.tidb_opt_risk_eq_skew_ratio = 0")
 }
+
+func TestSelectivityWithExtendedStats(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	h := dom.StatsHandle()
+	tk.MustExec("use test")
+	tk.MustExec("create table t(zip int, city int, state int)")
+	// Each zip has 5 rows, a zip belongs to one city, and all the cities except city 2 belong to one state.
+	values := make([]string, 0, 100)
+	for zip := range 20 {
+		for range 5 {
+			values = append(values, fmt.Sprintf("(%d,%d,%d)", zip, zip/4, zip/10))
+		}
+	}
+	tk.MustExec("insert into t values " + strings.Join(values, ","))
+	require.NoError(t, h.DumpStatsDeltaToKV(true))
+	tk.MustExec("set session tidb_enable_extended_stats = on")
+	analyze := func() {
+		tk.MustExec("analyze table t all columns")
+		require.NoError(t, h.Update(context.Background(), dom.InfoSchema()))
+	}
+	estRows := func(sql string) string {
+		return tk.MustQuery("explain format='brief' " + sql).Rows()[0][1].(string)
+	}
+	const filterSQL = "select * from t where city = 1 and state = 0"
+	const groupBySQL = "select city, state from t group by city, state"
+	analyze()
+	// sel(city = 1) = 0.2, sel(state = 0) = 0.5, and the independence assumption is used.
+	require.Equal(t, "10.00", estRows(filterSQL))
+	// max(NDV(city), NDV(state)) is used.
+	require.Equal(t, "5.00", estRows(groupBySQL))
+
+	// sel(city = 1 and state = 0) = sel(city = 1) * (0.8 + 0.2 * sel(state = 0))
+	tk.MustExec("alter table t add stats_extended s1 dependency(city,state)")
+	analyze()
+	require.Equal(t, "18.00", estRows(filterSQL))
+	// The dependency doesn't help when the determinant column is not filtered by a point condition.
+	require.Equal(t, "20.00", estRows("select * from t where city > 2 and state = 0"))
+
+	// sel(city = 1 and state = 0) = 1 / NDV(city, state)
+	tk.MustExec("alter table t add stats_extended s2 cardinality(city,state)")
+	analyze()
+	require.Equal(t, "16.67", estRows(filterSQL))
+	require.Equal(t, "6.00", estRows(groupBySQL))
+	tk.MustExec("alter table t add stats_extended s3 cardinality(zip,city,state)")
+	analyze()
+	require.Equal(t, "20.00", estRows("select zip, city, state from t group by zip, city, state"))
+
+	tk.MustExec("set session tidb_enable_extended_stats = off")
+	require.Equal(t, "10.00", estRows(filterSQL))
+	require.Equal(t, "5.00", estRows(groupBySQL))
+}
@@ -536,3 +536,36 @@ func TestIssue56915(t *testing.T) {
 		"└─TableRowIDScan(Probe) 1.00 cop[tikv] table:t keep order:false, stats:partial[j:unInitialized]",
 	))
 }
+
+func TestSpatialMBRIndexRange(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec(`use test`)
+	tk.MustExec(`create table t(id int primary key, p point, index ix((st_x(p))), index iy((st_y(p))))`)
+	tk.MustExec(`insert into t values (1, st_geomfromtext('POINT(1 1)')), (2, st_geomfromtext('POINT(3 8)')),
+		(3, st_geomfromtext('POINT(9 2)')), (4, st_geomfromtext('POINT(5 5)')), (5, null)`)
+	polygon := `st_geomfromtext('POLYGON((0 0,6 0,6 6,0 6,0 0))')`
+	for _, cond := range []string{
+		"st_within(p, " + polygon + ")",
+		"st_contains(" + polygon + ", p)",
+		"st_intersects(p, " + polygon + ")",
+	} {
+		tk.MustQuery("select id from t use index (ix) where " + cond + " order by id").Check(testkit.Rows("1", "4"))
+		tk.MustQuery("explain format = brief select id from t use index (ix) where " + cond).CheckContain("range:[0,6]")
+		tk.MustQuery("select id from t use index (iy) where " + cond + " order by id").Check(testkit.Rows("1", "4"))
+		tk.MustQuery("explain format = brief select id from t use index (iy) where " + cond).CheckContain("range:[0,6]")
+	}
+
+	// the axes of geographic points are in latitude-longitude order
+	tk.MustExec(`create table g(id int primary key, p point, index ix((st_x(p))))`)
+	tk.MustExec(`insert into g values (1, st_geomfromtext('POINT(30 120)', 4326)), (2, st_geomfromtext('POINT(60 120)', 4326))`)
+	cond := "st_within(p, st_geomfromtext('POLYGON((20 110,40 110,40 130,20 130,20 110))', 4326))"
+	tk.MustQuery("select id, st_x(p), st_y(p) from g where " + cond).Check(testkit.Rows("1 30 120"))
+	tk.MustQuery("explain format = brief select id from g use index (ix) where " + cond).CheckContain("range:[20,40]")
+
+	// nothing is derived for a geometry column or a table without indexed virtual columns
+	tk.MustExec(`create table t2(id int primary key, g geometry, index ix((st_x(g))))`)
+	tk.MustQuery("explain format = brief select id from t2 where st_within(g, " + polygon + ")").CheckNotContain("st_x")
+	tk.MustExec(`create table t3(id int primary key, p point)`)
+	tk.MustQuery("explain format = brief select id from t3 where st_within(p, " + polygon + ")").CheckNotContain("st_x")
+}
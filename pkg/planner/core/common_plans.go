@@ -568,6 +568,64 @@ func (p *Delete) MemoryUsage() (sum int64) {
 	return
 }
 
+// Merge represents a merge plan.
+// The SelectPlan is the left outer join of the source and the target table, each of its
+// rows is applied by the first WHEN clause whose match state and condition it satisfies.
+type Merge struct {
+	baseSchemaProducer
+
+	SelectPlan base.PhysicalPlan
+
+	// Table is the target table.
+	Table table.Table `plan-cache-clone:"shallow"`
+	// TblColPosInfo records the columns and the handle of the target table in the output of SelectPlan,
+	// the handle is NULL if the source row matches no target row.
+	TblColPosInfo TblColPosInfo `plan-cache-clone:"shallow"`
+
+	Clauses []*MergeClause
+}
+
+// MergeClause is a WHEN clause of the merge plan.
+type MergeClause struct {
+	NotMatched bool
+	// Condition is the optional search condition, which is evaluated on the joined row.
+	Condition expression.Expression
+	Action    ast.MergeActionType
+
+	// Update is the plan of the UPDATE action. Its SelectPlan is nil, the
+	// assignments are evaluated on the joined row.
+	Update *Update
+	// Delete is the plan of the DELETE action. Its SelectPlan is nil.
+	Delete *Delete
+	// Insert is the plan of the INSERT action. Its SelectPlan is nil, the row to
+	// insert is evaluated from Values on the joined row.
+	Insert *Insert
+	Values []expression.Expression
+}
+
+// MemoryUsage return the memory usage of Merge
+func (p *Merge) MemoryUsage() (sum int64) {
+	if p == nil {
+		return
+	}
+
+	sum = p.baseSchemaProducer.MemoryUsage() + size.SizeOfInterface*2 + size.SizeOfSlice + p.TblColPosInfo.MemoryUsage()
+	if p.SelectPlan != nil {
+		sum += p.SelectPlan.MemoryUsage()
+	}
+	for _, clause := range p.Clauses {
+		sum += size.SizeOfPointer + size.SizeOfBool + size.SizeOfInt + size.SizeOfInterface + size.SizeOfSlice +
+			clause.Update.MemoryUsage() + clause.Delete.MemoryUsage() + clause.Insert.MemoryUsage()
+		if clause.Condition != nil {
+			sum += clause.Condition.MemoryUsage()
+		}
+		for _, expr := range clause.Values {
+			sum += expr.MemoryUsage()
+		}
+	}
+	return
+}
+
 // AnalyzeInfo is used to store the database name, table name and partition name of analyze task.
 type AnalyzeInfo struct {
 	DBName        string
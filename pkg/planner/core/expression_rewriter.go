@@ -1545,6 +1545,10 @@ func (er *expressionRewriter) Leave(originInNode ast.Node) (retNode ast.Node, ok
 		}, "")
 	case *ast.IsNullExpr:
 		er.isNullToExpression(v)
+	case *ast.MatchAgainst:
+		withPlanCtx(func(planCtx *exprRewriterPlanCtx) {
+			er.matchAgainstToScalarFunc(planCtx, v)
+		}, "MATCH ... AGAINST requires plan context")
 	case *ast.IsTruthExpr:
 		er.isTrueToScalarFunc(v)
 	case *ast.DefaultExpr:
@@ -1866,6 +1870,70 @@ func (er *expressionRewriter) positionToScalarFunc(planCtx *exprRewriterPlanCtx,
 	}
 }
 
+// matchAgainstToScalarFunc rewrites `MATCH (col) AGAINST (expr [modifier])` to the function fts_match_against.
+// The column must have a FULLTEXT index, whose parser is used to tokenize the column and the search string.
+func (er *expressionRewriter) matchAgainstToScalarFunc(planCtx *exprRewriterPlanCtx, v *ast.MatchAgainst) {
+	intest.AssertNotNil(planCtx)
+	if v.Modifier.WithQueryExpansion() {
+		er.err = plannererrors.ErrNotSupportedYet.GenWithStackByArgs("MATCH ... AGAINST with query expansion")
+		return
+	}
+	stkLen := len(er.ctxStack)
+	argCnt := len(v.ColumnNames) + 1
+	against := er.ctxStack[stkLen-1]
+	if _, ok := against.(*expression.Constant); !ok {
+		er.err = plannererrors.ErrWrongArguments.GenWithStackByArgs("AGAINST")
+		return
+	}
+	// A FULLTEXT index has only one column, so only one column can be matched.
+	col, ok := er.ctxStack[stkLen-argCnt].(*expression.Column)
+	name := er.ctxNameStk[stkLen-argCnt]
+	if argCnt != 2 || !ok || name.OrigTblName.L == "" {
+		er.err = plannererrors.ErrFtMatchingKeyNotFound
+		return
+	}
+	dbName := name.DBName
+	if dbName.L == "" {
+		dbName = ast.NewCIStr(planCtx.builder.ctx.GetSessionVars().CurrentDB)
+	}
+	tbl, err := planCtx.builder.is.TableByName(context.Background(), dbName, name.OrigTblName)
+	if err != nil {
+		er.err = err
+		return
+	}
+	var ftIndex *model.IndexInfo
+	for _, idx := range tbl.Meta().Indices {
+		if idx.FullTextInfo == nil || idx.State != model.StatePublic || idx.Columns[0].Name.L != name.OrigColName.L {
+			continue
+		}
+		// Prefer the index stored in TiKV, which can be used to find the rows.
+		if ftIndex == nil || idx.IsRowStoreFullTextIndex() {
+			ftIndex = idx
+		}
+	}
+	if ftIndex == nil {
+		er.err = plannererrors.ErrFtMatchingKeyNotFound
+		return
+	}
+
+	var mode int64
+	if v.Modifier.IsBooleanMode() {
+		mode = 1
+	}
+	modeArg := &expression.Constant{Value: types.NewIntDatum(mode), RetType: types.NewFieldType(mysql.TypeTiny)}
+	parserArg := &expression.Constant{
+		Value:   types.NewStringDatum(ftIndex.FullTextInfo.ParserType.SQLName()),
+		RetType: types.NewFieldType(mysql.TypeVarchar),
+	}
+	function, err := er.newFunction(ast.FTSMatchAgainst, types.NewFieldType(mysql.TypeDouble), against, modeArg, parserArg, col)
+	if err != nil {
+		er.err = err
+		return
+	}
+	er.ctxStackPop(argCnt)
+	er.ctxStackAppend(function, types.EmptyName)
+}
+
 func (er *expressionRewriter) isTrueToScalarFunc(v *ast.IsTruthExpr) {
 	stkLen := len(er.ctxStack)
 	op := ast.IsTruthWithoutNull
@@ -343,7 +343,7 @@ func buildOnUpdateChildFKChecks(ctx base.PlanContext, is infoschema.InfoSchema,
 }
 
 func (updt *Update) buildTbl2UpdateColumns() map[int64]map[string]struct{} {
-	colsInfo := GetUpdateColumnsInfo(updt.tblID2Table, updt.TblColPosInfos, len(updt.SelectPlan.Schema().Columns))
+	colsInfo := GetUpdateColumnsInfo(updt.tblID2Table, updt.TblColPosInfos, len(updt.names))
 	tblID2UpdateColumns := make(map[int64]map[string]struct{})
 	for _, assign := range updt.OrderedList {
 		col := colsInfo[assign.Col.Index]
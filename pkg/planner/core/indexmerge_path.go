@@ -38,6 +38,7 @@ import (
 	"github.com/pingcap/tidb/pkg/statistics"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/fulltext"
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"github.com/pingcap/tidb/pkg/util/ranger"
 	"go.uber.org/zap"
@@ -81,7 +82,7 @@ func generateIndexMergePath(ds *logicalop.DataSource) error {
 
 	regularPathCount := len(ds.PossibleAccessPaths)
 
-	// Now we have 3 entry functions to generate IndexMerge paths:
+	// Now we have 4 entry functions to generate IndexMerge paths:
 	// 1. Generate AND type IndexMerge for non-MV indexes and all OR type IndexMerge.
 	var err error
 	if warningMsg, err = generateOtherIndexMerge(ds, regularPathCount, indexMergeConds); err != nil {
@@ -91,8 +92,12 @@ func generateIndexMergePath(ds *logicalop.DataSource) error {
 	if err := generateANDIndexMerge4MVIndex(ds, regularPathCount, indexMergeConds); err != nil {
 		return err
 	}
+	// 3. Generate IndexMerge for FULLTEXT indexes stored in TiKV. It can only use one index in an IndexMerge path.
+	if err := generateIndexMerge4FullTextIndex(ds, regularPathCount, indexMergeConds); err != nil {
+		return err
+	}
 	oldIndexMergeCount := len(ds.PossibleAccessPaths)
-	// 3. Generate AND type IndexMerge for MV indexes. It can use multiple MV and non-MV indexes in an IndexMerge path.
+	// 4. Generate AND type IndexMerge for MV indexes. It can use multiple MV and non-MV indexes in an IndexMerge path.
 	if err := generateANDIndexMerge4ComposedIndex(ds, regularPathCount, indexMergeConds); err != nil {
 		return err
 	}
@@ -797,6 +802,106 @@ func generateANDIndexMerge4MVIndex(ds *logicalop.DataSource, normalPathCnt int,
 	return nil
 }
 
+// generateIndexMerge4FullTextIndex generates IndexMerge paths for the FULLTEXT indexes stored in TiKV,
+// which are multi-valued indexes on the tokens of the column. For example:
+/*
+	select * from t where match(body) against('+tidb +mysql' in boolean mode)
+		IndexMerge(AND)
+			IndexRangeScan(body, ['mysql','mysql'])
+			IndexRangeScan(body, ['tidb','tidb'])
+			TableRowIdScan(t)
+*/
+// The index only finds the rows which may match, so all the filters are kept as table filters.
+func generateIndexMerge4FullTextIndex(ds *logicalop.DataSource, normalPathCnt int, filters []expression.Expression) error {
+	for idx := 0; idx < normalPathCnt; idx++ {
+		path := ds.PossibleAccessPaths[idx]
+		if path.IsTablePath() || path.Index == nil || !path.Index.IsRowStoreFullTextIndex() {
+			continue
+		}
+		if !isInIndexMergeHints(ds, path.Index.Name.L) {
+			continue
+		}
+		idxCols, ok := PrepareIdxColsAndUnwrapArrayType(ds.Table.Meta(), path.Index, ds.TblCols, false)
+		if !ok {
+			continue
+		}
+		tokens, isIntersection, ok := collectTokens4FullTextIndex(ds.SCtx(), filters, path.Index, idxCols[0])
+		if !ok {
+			continue
+		}
+
+		tokenType := idxCols[0].RetType.Clone()
+		tokenType.SetType(mysql.TypeVarString)
+		tokenType.SetFlen(types.UnspecifiedLength)
+		partialPaths := make([]*util.AccessPath, 0, len(tokens))
+		for _, token := range tokens {
+			eq, err := expression.NewFunction(ds.SCtx().GetExprCtx(), ast.EQ, types.NewFieldType(mysql.TypeTiny), idxCols[0],
+				&expression.Constant{Value: types.NewCollationStringDatum(token, tokenType.GetCollate()), RetType: tokenType})
+			if err != nil {
+				return err
+			}
+			partialPath, ok, err := buildPartialPath4MVIndex(ds.SCtx(), []expression.Expression{eq}, idxCols, path.Index, ds.TableStats.HistColl)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				break
+			}
+			partialPaths = append(partialPaths, partialPath)
+		}
+		if len(partialPaths) != len(tokens) {
+			continue
+		}
+		ds.PossibleAccessPaths = append(ds.PossibleAccessPaths, buildPartialPathUp4MVIndex(
+			partialPaths,
+			isIntersection,
+			util.CloneExprs(filters),
+			ds.TableStats.HistColl,
+		))
+	}
+	return nil
+}
+
+// collectTokens4FullTextIndex finds the MATCH ... AGAINST filter on the column of the FULLTEXT index,
+// and returns the tokens to look up in the index.
+func collectTokens4FullTextIndex(
+	sctx planctx.PlanContext,
+	filters []expression.Expression,
+	ftIndex *model.IndexInfo,
+	col *expression.Column,
+) (tokens []string, isIntersection bool, ok bool) {
+	for _, filter := range filters {
+		sf, ok := filter.(*expression.ScalarFunction)
+		if !ok || sf.FuncName.L != ast.FTSMatchAgainst {
+			continue
+		}
+		args := sf.GetArgs()
+		if argCol, ok := args[3].(*expression.Column); !ok || !argCol.EqualColumn(col) {
+			continue
+		}
+		if model.GetFullTextParserTypeBySQLName(args[2].(*expression.Constant).Value.GetString()) != ftIndex.FullTextInfo.ParserType {
+			continue
+		}
+		if expression.MaybeOverOptimized4PlanCache(sctx.GetExprCtx(), []expression.Expression{args[0]}) {
+			// skip plan cache and try to generate the best plan in this case.
+			sctx.GetExprCtx().SetSkipPlanCache("MATCH ... AGAINST with immutable parameters can affect index selection")
+		}
+		if !expression.IsImmutableFunc(args[0]) {
+			continue
+		}
+		against, isNull, err := args[0].EvalString(sctx.GetExprCtx().GetEvalCtx(), chunk.Row{})
+		if isNull || err != nil {
+			continue
+		}
+		booleanMode := args[1].(*expression.Constant).Value.GetInt64() != 0
+		query := fulltext.ParseQuery(ftIndex.FullTextInfo.ParserType, against, booleanMode)
+		if tokens, isIntersection, ok = query.IndexTokens(); ok {
+			return tokens, isIntersection, true
+		}
+	}
+	return nil, false, false
+}
+
 // buildPartialPathUp4MVIndex builds these partial paths up to a complete index merge path.
 func buildPartialPathUp4MVIndex(
 	partialPaths []*util.AccessPath,
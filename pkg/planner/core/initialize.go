@@ -139,6 +139,12 @@ func (p Insert) Init(ctx base.PlanContext) *Insert {
 	return &p
 }
 
+// Init initializes Merge.
+func (p Merge) Init(ctx base.PlanContext) *Merge {
+	p.Plan = baseimpl.NewBasePlan(ctx, plancodec.TypeMerge, 0)
+	return &p
+}
+
 // Init initializes LoadData.
 func (p LoadData) Init(ctx base.PlanContext) *LoadData {
 	p.Plan = baseimpl.NewBasePlan(ctx, plancodec.TypeLoadData, 0)
@@ -2579,3 +2579,33 @@ func TestAggregationInWindowFunctionPushDownToTiFlash(t *testing.T) {
 	}
 	tk.MustQuery("explain select sum(v) over w as res1, count(v) over w as res2, avg(v) over w as res3, min(v) over w as res4, max(v) over w as res5 from t window w as (partition by p order by o);").CheckAt([]int{0, 2, 4}, rows)
 }
+
+func TestLateralDerivedTable(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t1 (a int primary key, b int)")
+	tk.MustExec("create table t2 (a int, b int, key(a))")
+	tk.MustExec("insert into t1 values (1, 10), (2, 20), (3, 30)")
+	tk.MustExec("insert into t2 values (1, 1), (1, 2), (1, 3), (2, 4), (2, 5)")
+
+	// Top-N per group can not be decorrelated and is executed by an Apply.
+	sql := "select t1.a, dt.b from t1, lateral (select b from t2 where t2.a = t1.a order by b desc limit 2) dt"
+	tk.MustHavePlan(sql, "Apply")
+	tk.MustQuery(sql).Sort().Check(testkit.Rows("1 2", "1 3", "2 4", "2 5"))
+	sql = "select t1.a, dt.b from t1 join lateral (select b from t2 where t2.a = t1.a order by b limit 1) as dt on dt.b > 1"
+	tk.MustQuery(sql).Sort().Check(testkit.Rows("2 4"))
+	sql = "select t1.a, dt.b from t1 left join lateral (select b from t2 where t2.a = t1.a order by b limit 1) as dt on true"
+	tk.MustQuery(sql).Sort().Check(testkit.Rows("1 1", "2 4", "3 <nil>"))
+
+	// The aggregation can be decorrelated into a join.
+	sql = "select t1.a, dt.s from t1, lateral (select sum(b) s from t2 where t2.a = t1.a) dt"
+	tk.MustNotHavePlan(sql, "Apply")
+	tk.MustQuery(sql).Sort().Check(testkit.Rows("1 6", "2 9", "3 <nil>"))
+	sql = "select * from t1, lateral (select t1.b + 1 c) dt1, lateral (select dt1.c * 2 d) dt2 where t1.a = 1"
+	tk.MustQuery(sql).Check(testkit.Rows("1 10 11 22"))
+
+	// A derived table without LATERAL can not refer to the preceding tables.
+	tk.MustGetErrCode("select * from t1, (select b from t2 where t2.a = t1.a) dt", mysql.ErrBadField)
+	tk.MustGetErrCode("select * from t1 right join lateral (select b from t2 where t2.a = t1.a) dt on true", mysql.ErrBadField)
+}
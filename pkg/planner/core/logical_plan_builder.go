@@ -568,7 +568,21 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (base.L
 		return nil, err
 	}
 
+	// A LATERAL derived table on the right side can refer to the columns of the
+	// left side, so we build it with the left side as its outer schema and join
+	// them with an Apply. The columns of the left side are invisible to it in a
+	// RIGHT JOIN, the same as MySQL.
+	isLateral := false
+	if ts, ok := joinNode.Right.(*ast.TableSource); ok && ts.Lateral && joinNode.Tp != ast.RightJoin {
+		isLateral = true
+		b.outerSchemas = append(b.outerSchemas, leftPlan.Schema())
+		b.outerNames = append(b.outerNames, leftPlan.OutputNames())
+	}
 	rightPlan, err := b.buildResultSetNode(ctx, joinNode.Right, false)
+	if isLateral {
+		b.outerSchemas = b.outerSchemas[0 : len(b.outerSchemas)-1]
+		b.outerNames = b.outerNames[0 : len(b.outerNames)-1]
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -682,19 +696,38 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (base.L
 		// possible decorrelate optimizations. The ON clause is actually treated as a WHERE clause now.
 		if joinPlan.JoinType == logicalop.InnerJoin {
 			sel := logicalop.LogicalSelection{Conditions: onCondition}.Init(b.ctx, b.getSelectOffset())
-			sel.SetChildren(joinPlan)
+			if isLateral {
+				sel.SetChildren(b.buildLateralApply(joinPlan))
+			} else {
+				sel.SetChildren(joinPlan)
+			}
 			return sel, nil
 		}
 		joinPlan.AttachOnConds(onCondition)
-	} else if joinPlan.JoinType == logicalop.InnerJoin {
+	} else if joinPlan.JoinType == logicalop.InnerJoin && !isLateral {
 		// If a inner join without "ON" or "USING" clause, it's a cartesian
 		// product over the join tables.
 		joinPlan.CartesianJoin = true
 	}
 
+	if isLateral {
+		return b.buildLateralApply(joinPlan), nil
+	}
 	return joinPlan, nil
 }
 
+// buildLateralApply converts the join of a LATERAL derived table into an Apply,
+// which evaluates the derived table for every row from the left side. The
+// decorrelate rule will turn it back into a join if possible.
+func (b *PlanBuilder) buildLateralApply(joinPlan *logicalop.LogicalJoin) *logicalop.LogicalApply {
+	b.optFlag = b.optFlag | rule.FlagBuildKeyInfo | rule.FlagDecorrelate
+	ap := &logicalop.LogicalApply{LogicalJoin: *joinPlan}
+	ap.SetTP(plancodec.TypeApply)
+	ap.SetSelf(ap)
+	setIsInApplyForCTE(ap.Children()[1], ap.Schema())
+	return ap
+}
+
 // buildUsingClause eliminate the redundant columns and ordering columns based
 // on the "USING" clause.
 //
@@ -970,6 +1003,15 @@ func (b *PlanBuilder) buildSelection(ctx context.Context, p base.LogicalPlan, wh
 	if len(cnfExpres) == 0 {
 		return p, nil
 	}
+	// The filters derived from the spatial predicates are only useful when they
+	// can be substituted by the indexed virtual columns of ST_X and ST_Y.
+	if b.optFlag&rule.FlagGcSubstitute != 0 {
+		var derived []expression.Expression
+		for _, cond := range cnfExpres {
+			derived = append(derived, expression.DeriveSpatialMBRFilters(b.ctx.GetExprCtx(), cond)...)
+		}
+		cnfExpres = append(derived, cnfExpres...)
+	}
 	evalCtx := b.ctx.GetExprCtx().GetEvalCtx()
 	// check expr field types.
 	for i, expr := range cnfExpres {
@@ -2311,6 +2353,16 @@ func (a *havingWindowAndOrderbyExprResolver) Leave(n ast.Node) (node ast.Node, o
 		}
 	case *ast.WindowSpec:
 		a.inWindowSpec = false
+	case *ast.MatchAgainst:
+		// The columns of MATCH are not ColumnNameExpr, append them to the select fields
+		// if they are not selected, so the order by items can be rewritten.
+		if a.curClause == orderByClause {
+			for _, name := range v.ColumnNames {
+				if _, a.err = a.resolveFromPlan(&ast.ColumnNameExpr{Name: name}, a.p, true); a.err != nil {
+					return node, false
+				}
+			}
+		}
 	case *ast.PartitionByClause:
 		a.popCurClause()
 	case *ast.OrderByClause:
@@ -5373,7 +5425,10 @@ func pruneAndBuildColPositionInfoForDelete(
 		tblInfo := tbl.Meta()
 		// If it's partitioned table, or has foreign keys, or is point get plan, we can't prune the columns, currently.
 		// nonPrunedSet will be nil if it's a point get or has foreign keys.
-		if tblInfo.GetPartitionInfo() != nil || hasFK || nonPruned == nil {
+		// The DELETE triggers and the log tables of the materialized views need the whole OLD row,
+		// so the columns are not pruned either.
+		if tblInfo.GetPartitionInfo() != nil || hasFK || nonPruned == nil || tblInfo.HasTriggerOn(ast.TriggerEventDelete) ||
+			tblInfo.HasMaterializedViews() {
 			err = buildSingleTableColPosInfoForDelete(tbl, cols2PosInfo)
 			if err != nil {
 				return nil, nil, err
@@ -6122,6 +6177,236 @@ func (b *PlanBuilder) buildDelete(ctx context.Context, ds *ast.DeleteStmt) (base
 	return del, err
 }
 
+func (b *PlanBuilder) buildMerge(ctx context.Context, merge *ast.MergeStmt) (base.Plan, error) {
+	b.pushSelectOffset(0)
+	b.pushTableHints(merge.TableHints, 0)
+	defer func() {
+		b.popSelectOffset()
+		// table hints are only visible in the current MERGE statement.
+		b.popTableHints()
+	}()
+
+	b.inUpdateStmt = true
+	b.isForUpdateRead = true
+
+	target := merge.Target()
+	tn, ok := target.Source.(*ast.TableName)
+	if !ok {
+		return nil, plannererrors.ErrNonUpdatableTable.GenWithStackByArgs(target.AsName.O, "MERGE")
+	}
+	tnW := b.resolveCtx.GetTableName(tn)
+	if isCTE(tnW) || tnW.TableInfo.IsView() || tnW.TableInfo.IsSequence() {
+		return nil, plannererrors.ErrNonUpdatableTable.GenWithStackByArgs(tn.Name.O, "MERGE")
+	}
+	tbl, found := b.is.TableByID(ctx, tnW.TableInfo.ID)
+	if !found {
+		return nil, infoschema.ErrTableNotExists.FastGenByArgs(tnW.DBInfo.Name.O, tnW.TableInfo.Name.O)
+	}
+
+	// The source is left joined with the target, so every source row is seen once
+	// with the target columns filled by NULL if it matches no target row.
+	p, err := b.buildResultSetNode(ctx, merge.TableRefs.TableRefs, false)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeW := resolve.NewNodeWWithCtx(merge.TableRefs.TableRefs, b.resolveCtx)
+	tableList := ExtractTableList(nodeW, false)
+	for _, t := range tableList {
+		dbName := t.Schema.L
+		if dbName == "" {
+			dbName = b.ctx.GetSessionVars().CurrentDB
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SelectPriv, dbName, t.Name.L, "", nil)
+	}
+
+	// Add project to freeze the order of output columns.
+	oldSchemaLen := p.Schema().Len()
+	proj := logicalop.LogicalProjection{Exprs: expression.Column2Exprs(p.Schema().Columns)}.Init(b.ctx, b.getSelectOffset())
+	proj.SetSchema(expression.NewSchema(make([]*expression.Column, oldSchemaLen)...))
+	proj.SetOutputNames(make(types.NameSlice, len(p.OutputNames())))
+	copy(proj.OutputNames(), p.OutputNames())
+	copy(proj.Schema().Columns, p.Schema().Columns)
+	proj.SetChildren(p)
+	p = proj
+
+	utlr := &updatableTableListResolver{
+		resolveCtx: b.resolveCtx,
+	}
+	target.Accept(utlr)
+	targetName := utlr.updatableTableList[0]
+
+	var authErr error
+	sessionVars := b.ctx.GetSessionVars()
+	dbName := tnW.DBInfo.Name.L
+	mergePlan := Merge{Table: tbl}.Init(b.ctx)
+	for _, clause := range merge.Clauses {
+		mergeClause := &MergeClause{NotMatched: clause.NotMatched, Action: clause.Action}
+		if clause.Condition != nil {
+			b.curClause = whereClause
+			mergeClause.Condition, p, err = b.rewrite(ctx, clause.Condition, p, nil, true)
+			if err != nil {
+				return nil, err
+			}
+		}
+		switch clause.Action {
+		case ast.MergeActionUpdate:
+			// The columns to update always belong to the target table.
+			assignments := make([]*ast.Assignment, 0, len(clause.Assignments))
+			for _, assign := range clause.Assignments {
+				if assign.Column.Table.L == "" {
+					assign = &ast.Assignment{
+						Column: &ast.ColumnName{Schema: targetName.Schema, Table: targetName.Name, Name: assign.Column.Name},
+						Expr:   assign.Expr,
+					}
+				}
+				assignments = append(assignments, assign)
+			}
+			orderedList, np, allAssignmentsAreConstant, err := b.buildUpdateLists(ctx, []*ast.TableName{targetName}, assignments, p)
+			if err != nil {
+				return nil, err
+			}
+			p = np
+			mergeClause.Update = Update{
+				OrderedList:               orderedList,
+				AllAssignmentsAreConstant: allAssignmentsAreConstant,
+				VirtualAssignmentsOffset:  len(assignments),
+			}.Init(b.ctx)
+		case ast.MergeActionDelete:
+			if sessionVars.User != nil {
+				authErr = plannererrors.ErrTableaccessDenied.FastGenByArgs("DELETE", sessionVars.User.AuthUsername, sessionVars.User.AuthHostname, tn.Name.L)
+			}
+			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.DeletePriv, dbName, tn.Name.L, "", authErr)
+			mergeClause.Delete = Delete{}.Init(b.ctx)
+		case ast.MergeActionInsert:
+			mergeClause.Insert, mergeClause.Values, p, err = b.buildMergeInsert(ctx, tnW, tbl, clause, p)
+			if err != nil {
+				return nil, err
+			}
+		}
+		mergePlan.Clauses = append(mergePlan.Clauses, mergeClause)
+	}
+
+	mergePlan.names = p.OutputNames()
+	// We cannot apply projection elimination when building the subplan, because
+	// columns in the clauses cannot be resolved.
+	mergePlan.SelectPlan, _, err = DoOptimize(ctx, b.ctx, b.optFlag&^rule.FlagEliminateProjection, p)
+	if err != nil {
+		return nil, err
+	}
+	err = mergePlan.ResolveIndices()
+	if err != nil {
+		return nil, err
+	}
+	tblID := tbl.Meta().ID
+	tblID2Handle, err := resolveIndicesForTblID2Handle(b.handleHelper.tailMap(), mergePlan.SelectPlan.Schema())
+	if err != nil {
+		return nil, err
+	}
+	tblID2table := map[int64]table.Table{tblID: tbl}
+	colPosInfos, err := buildColumns2HandleWithWrtiableColumns(mergePlan.OutputNames(), map[int64][]util.HandleCols{tblID: tblID2Handle[tblID]}, tblID2table)
+	if err != nil {
+		return nil, err
+	}
+	if len(colPosInfos) == 0 {
+		return nil, plannererrors.ErrNonUpdatableTable.GenWithStackByArgs(tn.Name.O, "MERGE")
+	}
+	// The target table is the right side of the join, its columns are the last ones
+	// in case that the source reads the same table.
+	mergePlan.TblColPosInfo = colPosInfos[len(colPosInfos)-1]
+	for _, clause := range mergePlan.Clauses {
+		switch clause.Action {
+		case ast.MergeActionUpdate:
+			clause.Update.names = mergePlan.names
+			clause.Update.TblColPosInfos = TblColPosInfoSlice{mergePlan.TblColPosInfo}
+			clause.Update.tblID2Table = tblID2table
+			err = clause.Update.buildOnUpdateFKTriggers(b.ctx, b.is, tblID2table)
+		case ast.MergeActionDelete:
+			clause.Delete.names = mergePlan.names
+			clause.Delete.TblColPosInfos = TblColPosInfoSlice{mergePlan.TblColPosInfo}
+			err = clause.Delete.buildOnDeleteFKTriggers(b.ctx, b.is, tblID2table)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return mergePlan, nil
+}
+
+// buildMergeInsert builds the INSERT action of a `WHEN NOT MATCHED` clause. The values
+// are rewritten on the joined plan p, and the returned plan replaces p.
+func (b *PlanBuilder) buildMergeInsert(ctx context.Context, tnW *resolve.TableNameW, tbl table.Table, clause *ast.MergeWhenClause, p base.LogicalPlan) (*Insert, []expression.Expression, base.LogicalPlan, error) {
+	tableInfo := tnW.TableInfo
+	schema, names, err := expression.TableInfo2SchemaAndNames(b.ctx.GetExprCtx(), tnW.DBInfo.Name, tableInfo)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	insertPlan := Insert{
+		Table:         tbl,
+		Columns:       clause.Columns,
+		tableSchema:   schema,
+		tableColNames: names,
+	}.Init(b.ctx)
+
+	var authErr error
+	if user := b.ctx.GetSessionVars().User; user != nil {
+		authErr = plannererrors.ErrTableaccessDenied.FastGenByArgs("INSERT", user.AuthUsername, user.AuthHostname, tableInfo.Name.L)
+	}
+	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.InsertPriv, tnW.DBInfo.Name.L, tableInfo.Name.L, "", authErr)
+
+	affectedValuesCols, err := b.getAffectCols(&ast.InsertStmt{Columns: clause.Columns}, insertPlan)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(clause.Values) != len(affectedValuesCols) {
+		return nil, nil, nil, plannererrors.ErrWrongValueCountOnRow.GenWithStackByArgs(1)
+	}
+	values := make([]expression.Expression, 0, len(clause.Values))
+	for i, col := range affectedValuesCols {
+		if col.Hidden {
+			return nil, nil, nil, plannererrors.ErrUnknownColumn.GenWithStackByArgs(col.Name, clauseMsg[fieldList])
+		}
+		if expr := extractDefaultExpr(clause.Values[i]); expr != nil {
+			// Only DEFAULT is permitted for the generated columns, they are calculated from the inserted row.
+			if col.IsGenerated() {
+				continue
+			}
+			value, err := b.getDefaultValueForInsert(col)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			values = append(values, value)
+			continue
+		}
+		if col.IsGenerated() {
+			return nil, nil, nil, plannererrors.ErrBadGeneratedColumn.GenWithStackByArgs(col.Name.O, tableInfo.Name.O)
+		}
+		b.curClause = fieldList
+		value, np, err := b.rewrite(ctx, clause.Values[i], p, nil, true)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		p = np
+		values = append(values, value)
+	}
+	insertPlan.RowLen = len(values)
+
+	// Calculate generated columns.
+	mockTablePlan := logicalop.LogicalTableDual{}.Init(b.ctx, b.getSelectOffset())
+	mockTablePlan.SetSchema(insertPlan.tableSchema)
+	mockTablePlan.SetOutputNames(insertPlan.tableColNames)
+	insertPlan.GenCols, err = b.resolveGeneratedColumns(ctx, tbl.Cols(), nil, mockTablePlan)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	err = insertPlan.ResolveIndices()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	err = insertPlan.buildOnInsertFKTriggers(b.ctx, b.is, tnW.DBInfo.Name.L)
+	return insertPlan, values, p, err
+}
+
 func resolveIndicesForTblID2Handle(tblID2Handle map[int64][]util.HandleCols, schema *expression.Schema) (map[int64][]util.HandleCols, error) {
 	newMap := make(map[int64][]util.HandleCols, len(tblID2Handle))
 	for i, cols := range tblID2Handle {
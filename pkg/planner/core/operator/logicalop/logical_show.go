@@ -46,6 +46,8 @@ type ShowContents struct {
 	Column            *ast.ColumnName     // Used for `desc table column`.
 	IndexName         ast.CIStr
 	ResourceGroupName string               // Used for showing resource group
+	ProcedureName     string               // Used for showing create procedure
+	EventName         string               // Used for showing create event
 	Flag              int                  // Some flag parsed from sql, such as FULL.
 	User              *auth.UserIdentity   // Used for show grants.
 	Roles             []*auth.RoleIdentity // Used for show grants.
@@ -71,7 +73,7 @@ func (s *ShowContents) MemoryUsage() (sum int64) {
 		return
 	}
 
-	sum = emptyShowContentsSize + int64(len(s.DBName)) + s.Partition.MemoryUsage() + s.IndexName.MemoryUsage() +
+	sum = emptyShowContentsSize + int64(len(s.DBName)) + int64(len(s.ProcedureName)) + int64(len(s.EventName)) + s.Partition.MemoryUsage() + s.IndexName.MemoryUsage() +
 		int64(cap(s.Roles))*size.SizeOfPointer
 	return
 }
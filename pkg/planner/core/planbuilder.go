@@ -527,6 +527,8 @@ func (b *PlanBuilder) Build(ctx context.Context, node *resolve.NodeW) (base.Plan
 		return b.buildLoadStats(x), nil
 	case *ast.LockStatsStmt:
 		return b.buildLockStats(x), nil
+	case *ast.MergeStmt:
+		return b.buildMerge(ctx, x)
 	case *ast.UnlockStatsStmt:
 		return b.buildUnlockStats(x), nil
 	case *ast.PlanReplayerStmt:
@@ -559,7 +561,9 @@ func (b *PlanBuilder) Build(ctx context.Context, node *resolve.NodeW) (base.Plan
 		*ast.GrantStmt, *ast.DropUserStmt, *ast.AlterUserStmt, *ast.AlterRangeStmt, *ast.RevokeStmt, *ast.KillStmt, *ast.DropStatsStmt,
 		*ast.GrantRoleStmt, *ast.RevokeRoleStmt, *ast.SetRoleStmt, *ast.SetDefaultRoleStmt, *ast.ShutdownStmt,
 		*ast.RenameUserStmt, *ast.NonTransactionalDMLStmt, *ast.SetSessionStatesStmt, *ast.SetResourceGroupStmt,
-		*ast.ImportIntoActionStmt, *ast.CalibrateResourceStmt, *ast.AddQueryWatchStmt, *ast.DropQueryWatchStmt, *ast.DropProcedureStmt:
+		*ast.ImportIntoActionStmt, *ast.CalibrateResourceStmt, *ast.AddQueryWatchStmt, *ast.DropQueryWatchStmt,
+		*ast.ProcedureInfo, *ast.DropProcedureStmt, *ast.CallStmt, *ast.CreateEventStmt, *ast.AlterEventStmt, *ast.DropEventStmt,
+		*ast.RefreshMaterializedViewStmt:
 		return b.buildSimple(ctx, node.Node.(ast.StmtNode))
 	case ast.DDLNode:
 		return b.buildDDL(ctx, x)
@@ -1192,6 +1196,10 @@ func getPossibleAccessPaths(ctx base.PlanContext, tableHints *hint.PlanHints, in
 	}
 
 	optimizerUseInvisibleIndexes := ctx.GetSessionVars().OptimizerUseInvisibleIndexes
+	var hypoDroppedIndexes map[string]struct{}
+	if ctx.GetSessionVars().StmtCtx.InExplainStmt && ctx.GetSessionVars().HypoDroppedIndexes != nil {
+		hypoDroppedIndexes = ctx.GetSessionVars().HypoDroppedIndexes[dbName.L][tblInfo.Name.L]
+	}
 
 	check = check || ctx.GetSessionVars().IsIsolation(ast.ReadCommitted)
 	check = check && ctx.GetSessionVars().ConnectionID > 0
@@ -1204,6 +1212,10 @@ func getPossibleAccessPaths(ctx base.PlanContext, tableHints *hint.PlanHints, in
 			if !optimizerUseInvisibleIndexes && index.Invisible {
 				continue
 			}
+			// Filter out hypo-dropped index, which is considered as dropped by the index advisor
+			if _, ok := hypoDroppedIndexes[index.Name.L]; ok {
+				continue
+			}
 			if tblInfo.IsCommonHandle && index.Primary {
 				continue
 			}
@@ -3520,6 +3532,22 @@ func (b *PlanBuilder) buildShow(ctx context.Context, show *ast.ShowStmt) (base.P
 				b.visitInfo = appendVisitInfo(b.visitInfo, mysql.AllPrivMask&(^mysql.CreateTMPTablePriv), show.Table.Schema.L, show.Table.Name.L, "", err)
 			}
 		}
+	case ast.ShowCreateProcedure:
+		if err := b.fillProcedureSchema(show.Procedure); err != nil {
+			return nil, err
+		}
+		p.DBName = show.Procedure.Schema.O
+		p.ProcedureName = show.Procedure.Name.O
+	case ast.ShowCreateEvent:
+		if err := b.fillProcedureSchema(show.Event); err != nil {
+			return nil, err
+		}
+		p.DBName = show.Event.Schema.O
+		p.EventName = show.Event.Name.O
+	case ast.ShowEvents:
+		if p.DBName == "" {
+			return nil, plannererrors.ErrNoDB
+		}
 	case ast.ShowConfig:
 		privErr := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("CONFIG")
 		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.ConfigPriv, "", "", "", privErr)
@@ -3594,8 +3622,13 @@ func (b *PlanBuilder) buildShow(ctx context.Context, show *ast.ShowStmt) (base.P
 	np = p
 	// If we have ShowPredicateExtractor, we do not buildSelection with Pattern
 	if show.Pattern != nil && buildPattern {
+		patternCol := p.OutputNames()[0].ColName
+		if show.Tp == ast.ShowProcedureStatus || show.Tp == ast.ShowFunctionStatus || show.Tp == ast.ShowEvents {
+			// The pattern matches the routine name instead of the database.
+			patternCol = p.OutputNames()[1].ColName
+		}
 		show.Pattern.Expr = &ast.ColumnNameExpr{
-			Name: &ast.ColumnName{Name: p.OutputNames()[0].ColName},
+			Name: &ast.ColumnName{Name: patternCol},
 		}
 		np, err = b.buildSelection(ctx, np, show.Pattern, nil)
 		if err != nil {
@@ -3763,17 +3796,78 @@ func (b *PlanBuilder) buildSimple(ctx context.Context, node ast.StmtNode) (base.
 			err := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER or RESOURCE_GROUP_ADMIN or RESOURCE_GROUP_USER")
 			b.visitInfo = appendDynamicVisitInfo(b.visitInfo, []string{"RESOURCE_GROUP_ADMIN", "RESOURCE_GROUP_USER"}, false, err)
 		}
+	case *ast.ProcedureInfo:
+		if err := b.fillProcedureSchema(raw.ProcedureName); err != nil {
+			return nil, err
+		}
+		b.visitInfo = b.appendProcedureVisitInfo(b.visitInfo, mysql.CreateRoutinePriv, raw.ProcedureName.Schema.L)
 	case *ast.DropProcedureStmt:
-		procName := fmt.Sprintf("%s.%s", ast.NewCIStr(b.ctx.GetSessionVars().CurrentDB).O, raw.ProcedureName.Name.O)
-		err := plannererrors.ErrSpDoesNotExist.GenWithStackByArgs("PROCEDURE", procName)
-		if !raw.IfExists {
+		if err := b.fillProcedureSchema(raw.ProcedureName); err != nil {
+			return nil, err
+		}
+		b.visitInfo = b.appendProcedureVisitInfo(b.visitInfo, mysql.AlterRoutinePriv, raw.ProcedureName.Schema.L)
+	case *ast.CreateEventStmt:
+		if err := b.fillProcedureSchema(raw.EventName); err != nil {
+			return nil, err
+		}
+		b.visitInfo = b.appendProcedureVisitInfo(b.visitInfo, mysql.EventPriv, raw.EventName.Schema.L)
+		if user := b.ctx.GetSessionVars().User; user != nil && raw.Definer != nil && !raw.Definer.CurrentUser &&
+			raw.Definer.String() != user.String() {
+			err := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER")
+			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "", "", "", err)
+		}
+	case *ast.AlterEventStmt:
+		if err := b.fillProcedureSchema(raw.EventName); err != nil {
+			return nil, err
+		}
+		b.visitInfo = b.appendProcedureVisitInfo(b.visitInfo, mysql.EventPriv, raw.EventName.Schema.L)
+		if raw.NewName != nil {
+			if err := b.fillProcedureSchema(raw.NewName); err != nil {
+				return nil, err
+			}
+			b.visitInfo = b.appendProcedureVisitInfo(b.visitInfo, mysql.EventPriv, raw.NewName.Schema.L)
+		}
+	case *ast.DropEventStmt:
+		if err := b.fillProcedureSchema(raw.EventName); err != nil {
 			return nil, err
 		}
-		b.ctx.GetSessionVars().StmtCtx.AppendNote(err)
+		b.visitInfo = b.appendProcedureVisitInfo(b.visitInfo, mysql.EventPriv, raw.EventName.Schema.L)
+	case *ast.RefreshMaterializedViewStmt:
+		// A refresh deletes the rows of the view and inserts the new rows.
+		var insertErr, deleteErr error
+		if user := b.ctx.GetSessionVars().User; user != nil {
+			insertErr = plannererrors.ErrTableaccessDenied.GenWithStackByArgs("INSERT", user.AuthUsername,
+				user.AuthHostname, raw.ViewName.Name.L)
+			deleteErr = plannererrors.ErrTableaccessDenied.GenWithStackByArgs("DELETE", user.AuthUsername,
+				user.AuthHostname, raw.ViewName.Name.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.InsertPriv, raw.ViewName.Schema.L, raw.ViewName.Name.L, "", insertErr)
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.DeletePriv, raw.ViewName.Schema.L, raw.ViewName.Name.L, "", deleteErr)
 	}
 	return p, nil
 }
 
+// fillProcedureSchema qualifies an unqualified routine name with the current database.
+func (b *PlanBuilder) fillProcedureSchema(name *ast.TableName) error {
+	if name.Schema.L != "" {
+		return nil
+	}
+	currentDB := b.ctx.GetSessionVars().CurrentDB
+	if currentDB == "" {
+		return plannererrors.ErrNoDB
+	}
+	name.Schema = ast.NewCIStr(currentDB)
+	return nil
+}
+
+func (b *PlanBuilder) appendProcedureVisitInfo(vi []visitInfo, priv mysql.PrivilegeType, db string) []visitInfo {
+	var err error
+	if user := b.ctx.GetSessionVars().User; user != nil {
+		err = plannererrors.ErrDBaccessDenied.GenWithStackByArgs(user.AuthUsername, user.AuthHostname, db)
+	}
+	return appendVisitInfo(vi, priv, db, "", "", err)
+}
+
 func collectVisitInfoFromRevokeStmt(ctx context.Context, sctx base.PlanContext, vi []visitInfo, stmt *ast.RevokeStmt) ([]visitInfo, error) {
 	// To use REVOKE, you must have the GRANT OPTION privilege,
 	// and you must have the privileges that you are granting.
@@ -4507,10 +4601,11 @@ func (b *PlanBuilder) buildLoadData(ctx context.Context, ld *ast.LoadDataStmt) (
 var (
 	importIntoSchemaNames = []string{"Job_ID", "Data_Source", "Target_Table", "Table_ID",
 		"Phase", "Status", "Source_File_Size", "Imported_Rows",
-		"Result_Message", "Create_Time", "Start_Time", "End_Time", "Created_By"}
+		"Result_Message", "Create_Time", "Start_Time", "End_Time", "Created_By", "Rejected_Rows"}
 	importIntoSchemaFTypes = []byte{mysql.TypeLonglong, mysql.TypeString, mysql.TypeString, mysql.TypeLonglong,
 		mysql.TypeString, mysql.TypeString, mysql.TypeString, mysql.TypeLonglong,
-		mysql.TypeString, mysql.TypeTimestamp, mysql.TypeTimestamp, mysql.TypeTimestamp, mysql.TypeString}
+		mysql.TypeString, mysql.TypeTimestamp, mysql.TypeTimestamp, mysql.TypeTimestamp, mysql.TypeString,
+		mysql.TypeLonglong}
 
 	// ImportIntoDataSource used inplannererrors.ErrLoadDataInvalidURI.
 	ImportIntoDataSource = "data source"
@@ -4669,6 +4764,10 @@ func (b *PlanBuilder) buildImportInto(ctx context.Context, ld *ast.ImportIntoStm
 		return nil, errors.Errorf("IMPORT INTO does not support temporary table")
 	} else if tnW.TableInfo.TableCacheStatusType != model.TableCacheStatusDisable {
 		return nil, errors.Errorf("IMPORT INTO does not support cached table")
+	} else if tnW.TableInfo.IsMaterializedView() || tnW.TableInfo.IsMaterializedViewLog() {
+		return nil, dbterror.ErrOptOnMaterializedView.GenWithStackByArgs("IMPORT INTO")
+	} else if tnW.TableInfo.HasMaterializedViews() {
+		return nil, dbterror.ErrOptOnMViewBaseTable.GenWithStackByArgs("IMPORT INTO", tnW.TableInfo.Name.O)
 	}
 	p := ImportInto{
 		Path:               ld.Path,
@@ -5329,6 +5428,65 @@ func (b *PlanBuilder) buildDDL(ctx context.Context, node ast.DDLNode) (base.Plan
 			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.DropPriv, sequence.Schema.L,
 				sequence.Name.L, "", authErr)
 		}
+	case *ast.CreateTriggerStmt:
+		if b.ctx.GetSessionVars().User != nil {
+			authErr = plannererrors.ErrTableaccessDenied.GenWithStackByArgs("TRIGGER", b.ctx.GetSessionVars().User.AuthUsername,
+				b.ctx.GetSessionVars().User.AuthHostname, v.Table.Name.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.TriggerPriv, v.Table.Schema.L,
+			v.Table.Name.L, "", authErr)
+	case *ast.DropTriggerStmt:
+		if b.ctx.GetSessionVars().User != nil {
+			authErr = plannererrors.ErrDBaccessDenied.GenWithStackByArgs(b.ctx.GetSessionVars().User.AuthUsername,
+				b.ctx.GetSessionVars().User.AuthHostname, v.TriggerName.Schema.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.TriggerPriv, v.TriggerName.Schema.L, "", "", authErr)
+	case *ast.CreateMaterializedViewStmt:
+		err := checkForUserVariables(v.Select)
+		if err != nil {
+			return nil, err
+		}
+		b.isCreateView = true
+		b.capFlag |= canExpandAST
+		defer func() {
+			b.capFlag &= ^canExpandAST
+			b.isCreateView = false
+		}()
+
+		nodeW := resolve.NewNodeWWithCtx(v.Select, b.resolveCtx)
+		plan, err := b.Build(ctx, nodeW)
+		if err != nil {
+			return nil, err
+		}
+		schema := plan.Schema()
+		names := plan.OutputNames()
+		if v.Cols == nil {
+			adjustOverlongViewColname(plan.(base.LogicalPlan))
+			v.Cols = make([]ast.CIStr, len(schema.Columns))
+			for i, name := range names {
+				v.Cols[i] = name.ColName
+			}
+		}
+		if len(v.Cols) != schema.Len() {
+			return nil, dbterror.ErrViewWrongList
+		}
+		v.ColTypes = make([]*types.FieldType, 0, schema.Len())
+		for _, col := range schema.Columns {
+			v.ColTypes = append(v.ColTypes, col.RetType)
+		}
+		if b.ctx.GetSessionVars().User != nil {
+			authErr = plannererrors.ErrTableaccessDenied.GenWithStackByArgs("CREATE", b.ctx.GetSessionVars().User.AuthUsername,
+				b.ctx.GetSessionVars().User.AuthHostname, v.ViewName.Name.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreatePriv, v.ViewName.Schema.L,
+			v.ViewName.Name.L, "", authErr)
+	case *ast.DropMaterializedViewStmt:
+		if b.ctx.GetSessionVars().User != nil {
+			authErr = plannererrors.ErrTableaccessDenied.GenWithStackByArgs("DROP", b.ctx.GetSessionVars().User.AuthUsername,
+				b.ctx.GetSessionVars().User.AuthHostname, v.ViewName.Name.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.DropPriv, v.ViewName.Schema.L,
+			v.ViewName.Name.L, "", authErr)
 	case *ast.TruncateTableStmt:
 		if b.ctx.GetSessionVars().User != nil {
 			authErr = plannererrors.ErrTableaccessDenied.GenWithStackByArgs("DROP", b.ctx.GetSessionVars().User.AuthUsername,
@@ -5751,6 +5909,10 @@ func buildShowSchema(s *ast.ShowStmt, isView bool, isSequence bool) (schema *exp
 		names = []string{"View", "Create View", "character_set_client", "collation_connection"}
 	case ast.ShowCreateDatabase:
 		names = []string{"Database", "Create Database"}
+	case ast.ShowCreateProcedure:
+		names = []string{"Procedure", "sql_mode", "Create Procedure", "character_set_client", "collation_connection", "Database Collation"}
+	case ast.ShowCreateEvent:
+		names = []string{"Event", "sql_mode", "time_zone", "Create Event", "character_set_client", "collation_connection", "Database Collation"}
 	case ast.ShowGrants:
 		if s.User != nil {
 			names = []string{fmt.Sprintf("Grants for %s", s.User)}
@@ -6042,6 +6204,18 @@ func (*PlanBuilder) buildRecommendIndex(v *ast.RecommendIndexStmt) (base.Plan, e
 		schema.Append(buildColumnWithName("", "top_impacted_query", mysql.TypeBlob, -1))
 		schema.Append(buildColumnWithName("", "create_index_statement", mysql.TypeBlob, -1))
 		p.setSchemaAndNames(schema.col2Schema(), schema.names)
+	case "drop":
+		schema := newColumnsWithNames(9)
+		schema.Append(buildColumnWithName("", "database", mysql.TypeVarchar, 64))
+		schema.Append(buildColumnWithName("", "table", mysql.TypeVarchar, 64))
+		schema.Append(buildColumnWithName("", "index_name", mysql.TypeVarchar, 64))
+		schema.Append(buildColumnWithName("", "index_columns", mysql.TypeVarchar, 256))
+		schema.Append(buildColumnWithName("", "est_index_size", mysql.TypeVarchar, 256))
+		schema.Append(buildColumnWithName("", "reason", mysql.TypeVarchar, 256))
+		schema.Append(buildColumnWithName("", "est_write_amplification_saved", mysql.TypeVarchar, 64))
+		schema.Append(buildColumnWithName("", "regressed_queries", mysql.TypeBlob, -1))
+		schema.Append(buildColumnWithName("", "drop_index_statement", mysql.TypeBlob, -1))
+		p.setSchemaAndNames(schema.col2Schema(), schema.names)
 	case "set":
 		if len(p.Options) == 0 {
 			return nil, fmt.Errorf("option is empty")
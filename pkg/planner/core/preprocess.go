@@ -287,6 +287,13 @@ func (p *preprocessor) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
 		p.flag |= inCreateOrDropTable
 		p.checkCreateViewGrammar(node)
 		p.checkCreateViewWithSelectGrammar(node)
+	case *ast.CreateMaterializedViewStmt:
+		p.stmtTp = TypeCreate
+		p.flag |= inCreateOrDropTable
+		p.checkCreateMViewGrammar(node)
+	case *ast.DropMaterializedViewStmt:
+		p.stmtTp = TypeDrop
+		p.flag |= inCreateOrDropTable
 	case *ast.DropTableStmt:
 		p.flag |= inCreateOrDropTable
 		p.stmtTp = TypeDrop
@@ -351,6 +358,10 @@ func (p *preprocessor) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
 			}
 		}
 		return in, true
+	case *ast.CallStmt:
+		// The procedure is looked up when it is called, and the arguments are
+		// evaluated like the statements of the procedure body.
+		return in, true
 	case *ast.RecoverTableStmt:
 		// The specified table in recover table statement maybe already been dropped.
 		// So skip check table name here, otherwise, recover table [table_name] syntax will return
@@ -382,6 +393,12 @@ func (p *preprocessor) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
 		p.stmtTp = TypeDrop
 		p.flag |= inCreateOrDropTable
 		p.checkDropSequenceGrammar(node)
+	case *ast.CreateTriggerStmt:
+		p.stmtTp = TypeCreate
+		p.resolveCreateTriggerStmt(node)
+	case *ast.DropTriggerStmt:
+		p.stmtTp = TypeDrop
+		p.resolveDropTriggerStmt(node)
 	case *ast.FuncCastExpr:
 		p.checkFuncCastExpr(node)
 	case *ast.FuncCallExpr:
@@ -618,7 +635,7 @@ func (p *preprocessor) Leave(in ast.Node) (out ast.Node, ok bool) {
 		p.flag &= ^inCreateOrDropTable
 		p.checkAutoIncrement(x)
 		p.checkContainDotColumn(x)
-	case *ast.CreateViewStmt:
+	case *ast.CreateViewStmt, *ast.CreateMaterializedViewStmt, *ast.DropMaterializedViewStmt:
 		p.flag &= ^inCreateOrDropTable
 	case *ast.DropTableStmt, *ast.AlterTableStmt, *ast.RenameTableStmt:
 		p.flag &= ^inCreateOrDropTable
@@ -1017,6 +1034,31 @@ func (p *preprocessor) checkCreateViewGrammar(stmt *ast.CreateViewStmt) {
 	}
 }
 
+func (p *preprocessor) checkCreateMViewGrammar(stmt *ast.CreateMaterializedViewStmt) {
+	vName := stmt.ViewName.Name.String()
+	if util.IsInCorrectIdentifierName(vName) {
+		p.err = dbterror.ErrWrongTableName.GenWithStackByArgs(vName)
+		return
+	}
+	for _, col := range stmt.Cols {
+		if util.IsInCorrectIdentifierName(col.String()) {
+			p.err = dbterror.ErrWrongColumnName.GenWithStackByArgs(col)
+			return
+		}
+	}
+	switch sel := stmt.Select.(type) {
+	case *ast.SelectStmt:
+		p.checkCreateViewWithSelect(sel)
+	case *ast.SetOprStmt:
+		for _, selectStmt := range sel.SelectList.Selects {
+			p.checkCreateViewWithSelect(selectStmt)
+			if p.err != nil {
+				return
+			}
+		}
+	}
+}
+
 func (p *preprocessor) checkCreateViewWithSelect(stmt ast.Node) {
 	switch s := stmt.(type) {
 	case *ast.SelectStmt:
@@ -1887,6 +1929,33 @@ func (p *preprocessor) resolveCreateSequenceStmt(stmt *ast.CreateSequenceStmt) {
 	}
 }
 
+// resolveCreateTriggerStmt fills the schema of the subject table, a trigger
+// without schema belongs to the schema of its table.
+func (p *preprocessor) resolveCreateTriggerStmt(stmt *ast.CreateTriggerStmt) {
+	if stmt.Table.Schema.L == "" {
+		currentDB := p.sctx.GetSessionVars().CurrentDB
+		if currentDB == "" {
+			p.err = errors.Trace(plannererrors.ErrNoDB)
+			return
+		}
+		stmt.Table.Schema = ast.NewCIStr(currentDB)
+	}
+	if stmt.TriggerName.Schema.L == "" {
+		stmt.TriggerName.Schema = stmt.Table.Schema
+	}
+}
+
+func (p *preprocessor) resolveDropTriggerStmt(stmt *ast.DropTriggerStmt) {
+	if stmt.TriggerName.Schema.L == "" {
+		currentDB := p.sctx.GetSessionVars().CurrentDB
+		if currentDB == "" {
+			p.err = errors.Trace(plannererrors.ErrNoDB)
+			return
+		}
+		stmt.TriggerName.Schema = ast.NewCIStr(currentDB)
+	}
+}
+
 func (p *preprocessor) checkFuncCastExpr(node *ast.FuncCastExpr) {
 	if node.Tp.EvalType() == types.ETDecimal {
 		if node.Tp.GetFlen() >= node.Tp.GetDecimal() && node.Tp.GetFlen() <= mysql.MaxDecimalWidth && node.Tp.GetDecimal() <= mysql.MaxDecimalScale {
@@ -858,6 +858,43 @@ func (p *Update) ResolveIndices() (err error) {
 	return
 }
 
+// ResolveIndices implements Plan interface.
+func (p *Merge) ResolveIndices() (err error) {
+	err = p.baseSchemaProducer.ResolveIndices()
+	if err != nil {
+		return err
+	}
+	schema := p.SelectPlan.Schema()
+	for _, clause := range p.Clauses {
+		if clause.Condition != nil {
+			clause.Condition, err = clause.Condition.ResolveIndices(schema)
+			if err != nil {
+				return err
+			}
+		}
+		if clause.Update != nil {
+			for _, assign := range clause.Update.OrderedList {
+				newCol, err := assign.Col.ResolveIndices(schema)
+				if err != nil {
+					return err
+				}
+				assign.Col = newCol.(*expression.Column)
+				assign.Expr, err = assign.Expr.ResolveIndices(schema)
+				if err != nil {
+					return err
+				}
+			}
+		}
+		for i, expr := range clause.Values {
+			clause.Values[i], err = expr.ResolveIndices(schema)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return
+}
+
 // ResolveIndices implements Plan interface.
 func (p *PhysicalLock) ResolveIndices() (err error) {
 	err = p.BasePhysicalPlan.ResolveIndices()
@@ -483,6 +483,21 @@ func getGroupNDVs(ds *logicalop.DataSource) []property.GroupNDV {
 		}
 		return false
 	})
+	// Use the cardinality extended stats for the column groups which are not covered by any index.
+	for _, g := range colGroups {
+		uniqueIDs := make([]int64, 0, len(g))
+		colIDs := make([]int64, 0, len(g))
+		for _, col := range g {
+			uniqueIDs = append(uniqueIDs, col.UniqueID)
+			colIDs = append(colIDs, col.ID)
+		}
+		if slices.ContainsFunc(ndvs, func(ndv property.GroupNDV) bool { return slices.Equal(ndv.Cols, uniqueIDs) }) {
+			continue
+		}
+		if ndv, ok := cardinality.EstimateColGroupNDVByExtendedStats(tbl, colIDs); ok {
+			ndvs = append(ndvs, property.GroupNDV{Cols: uniqueIDs, NDV: ndv})
+		}
+	}
 	return ndvs
 }
 
@@ -521,6 +536,9 @@ func initStats(ds *logicalop.DataSource) {
 	if ds.StatisticTable.Pseudo {
 		tableStats.StatsVersion = statistics.PseudoVersion
 	}
+	if ds.SCtx().GetSessionVars().EnableExtendedStats {
+		tableStats.HistColl.ExtStats = ds.StatisticTable.ExtendedStats
+	}
 
 	statsRecord := ds.SCtx().GetSessionVars().StmtCtx.GetUsedStatsInfo(true)
 	name, tblInfo := getTblInfoForUsedStatsByPhysicalID(ds.SCtx(), ds.PhysicalTableID)
@@ -0,0 +1,442 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indexadvisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/util/intest"
+	s "github.com/pingcap/tidb/pkg/util/set"
+	"go.uber.org/zap"
+)
+
+const (
+	dropReasonRedundant = "redundant"
+	dropReasonUnused    = "unused"
+	dropReasonDominated = "dominated"
+)
+
+// AdviseDropIndexes is the entry point for recommending existing indexes to drop.
+// An index is recommended to be dropped if it is redundant (a prefix of another index), never used
+// according to the index usage and the statement history, or dominated in cost by other access paths
+// for all queries in the workload.
+func AdviseDropIndexes(ctx context.Context, sctx sessionctx.Context, userSQLs []string,
+	userOptions []ast.RecommendIndexOption) (results []*DropRecommendation, err error) {
+	advisorLogger().Info("fill index advisor option")
+	option := &Option{SpecifiedSQLs: userSQLs}
+	if err := fillOption(sctx, option, userOptions); err != nil {
+		advisorLogger().Error("fill index advisor option failed", zap.Error(err))
+		return nil, err
+	}
+
+	return adviseDropIndexesWithOption(ctx, sctx, option)
+}
+
+func adviseDropIndexesWithOption(ctx context.Context, sctx sessionctx.Context,
+	option *Option) (results []*DropRecommendation, err error) {
+	if ctx == nil || sctx == nil || option == nil {
+		return nil, errors.New("nil input")
+	}
+
+	advisorLogger().Info("index advisor option filled and start to advise dropping indexes", zap.Any("option", option))
+	defer func() {
+		if r := recover(); r != nil {
+			advisorLogger().Error("panic in AdviseDropIndexesWithOption", zap.Any("recover", r))
+			err = fmt.Errorf("panic in AdviseDropIndexesWithOption: %v", r)
+		}
+	}()
+
+	opt := NewOptimizer(sctx)
+	defaultDB := sctx.GetSessionVars().CurrentDB
+	querySet, err := prepareQuerySet(ctx, sctx, defaultDB, opt, option)
+	if err != nil {
+		advisorLogger().Error("prepare workload failed", zap.Error(err))
+		return nil, err
+	}
+
+	unusedIndexes, err := prepareUnusedIndexes(ctx, sctx)
+	if err != nil {
+		advisorLogger().Error("prepare unused indexes failed", zap.Error(err))
+		return nil, err
+	}
+
+	da := &dropAdvisor{
+		optimizer: opt,
+		option:    option,
+		startAt:   time.Now(),
+		queries:   querySet.ToList(),
+	}
+	if err := da.prepareQueryTables(); err != nil {
+		return nil, err
+	}
+
+	numIndexes := 0
+	for _, table := range candidateTables(da.queryTables, unusedIndexes) {
+		if err := da.timeout(); err != nil {
+			return nil, err
+		}
+		schema, tableName, _ := strings.Cut(table, ".")
+		indexes, err := opt.TableIndexes(schema, tableName)
+		if err != nil { // the table might be a view or has been dropped
+			advisorLogger().Info("skip table when advising dropping indexes", zap.String("table", table), zap.Error(err))
+			continue
+		}
+		numIndexes += len(indexes)
+		tableResults, err := da.adviseTable(table, indexes, unusedIndexes)
+		if err != nil {
+			advisorLogger().Error("advise dropping indexes failed", zap.String("table", table), zap.Error(err))
+			return nil, err
+		}
+		results = append(results, tableResults...)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Database != results[j].Database {
+			return results[i].Database < results[j].Database
+		}
+		if results[i].Table != results[j].Table {
+			return results[i].Table < results[j].Table
+		}
+		return results[i].IndexName < results[j].IndexName
+	})
+
+	if len(results) == 0 {
+		sctx.GetSessionVars().StmtCtx.AppendWarning(fmt.Errorf(
+			" Considered %v existing indexes on %v queries, no redundant, unused or dominated indexes were found.",
+			numIndexes, querySet.Size()))
+	}
+	return results, nil
+}
+
+// prepareUnusedIndexes returns the indexes that are never used, in the format of "schema.table.index".
+// Indexes in sys.schema_unused_indexes might be used before the index usage is collected, so indexes
+// appearing in the statement history are excluded.
+func prepareUnusedIndexes(ctx context.Context, sctx sessionctx.Context) (s.StringSet, error) {
+	if intest.InTest && ctx.Value(TestKey("unused_indexes")) != nil {
+		return ctx.Value(TestKey("unused_indexes")).(s.StringSet), nil
+	}
+
+	rows, err := exec(sctx, `SELECT object_schema, object_name, index_name FROM sys.schema_unused_indexes`)
+	if err != nil {
+		return nil, err
+	}
+	unusedIndexes := s.NewStringSet()
+	for _, r := range rows {
+		unusedIndexes.Insert(strings.ToLower(fmt.Sprintf("%v.%v.%v", r.GetString(0), r.GetString(1), r.GetString(2))))
+	}
+	if len(unusedIndexes) == 0 {
+		return unusedIndexes, nil
+	}
+
+	// index_names in statements summary are in the format of "table:index,table:index", without the schema name.
+	rows, err = exec(sctx, `SELECT DISTINCT index_names
+			FROM information_schema.statements_summary_history
+			WHERE summary_begin_time >= date_sub(now(), interval 1 day) AND
+				index_names IS NOT NULL AND index_names != ""`)
+	if err != nil {
+		return nil, err
+	}
+	usedIndexes := s.NewStringSet()
+	for _, r := range rows {
+		for _, tableIndex := range strings.Split(r.GetString(0), ",") {
+			usedIndexes.Insert(strings.ToLower(strings.TrimSpace(tableIndex)))
+		}
+	}
+	for key := range unusedIndexes {
+		parts := strings.SplitN(key, ".", 3)
+		if usedIndexes.Exist(parts[1] + ":" + parts[2]) {
+			delete(unusedIndexes, key)
+		}
+	}
+	return unusedIndexes, nil
+}
+
+// candidateTables returns the tables referenced by the workload and the tables holding unused indexes,
+// in the format of "schema.table".
+func candidateTables(queryTables map[string]s.StringSet, unusedIndexes s.StringSet) []string {
+	tables := s.NewStringSet()
+	for _, names := range queryTables {
+		for name := range names {
+			tables.Insert(name)
+		}
+	}
+	for key := range unusedIndexes {
+		parts := strings.SplitN(key, ".", 3)
+		tables.Insert(parts[0] + "." + parts[1])
+	}
+	list := make([]string, 0, len(tables))
+	for table := range tables {
+		list = append(list, table)
+	}
+	sort.Strings(list)
+	return list
+}
+
+type dropAdvisor struct {
+	optimizer Optimizer
+	option    *Option
+	startAt   time.Time
+
+	queries []Query
+	// queryTables caches the tables referenced by each query, in the format of "schema.table".
+	queryTables map[string]s.StringSet
+}
+
+func (da *dropAdvisor) prepareQueryTables() error {
+	da.queryTables = make(map[string]s.StringSet, len(da.queries))
+	for _, q := range da.queries {
+		names, err := CollectTableNamesFromQuery(q.SchemaName, q.Text)
+		if err != nil {
+			return err
+		}
+		tables := s.NewStringSet()
+		for _, name := range names {
+			tables.Insert(strings.ToLower(name))
+		}
+		da.queryTables[q.Key()] = tables
+	}
+	return nil
+}
+
+// adviseTable returns the indexes of the table which are recommended to be dropped.
+// The redundant and unused indexes are evaluated first, then the other non-unique indexes are
+// evaluated one by one on top of the indexes already recommended to be dropped, so that only one
+// of the indexes that can replace each other is recommended.
+func (da *dropAdvisor) adviseTable(table string, indexes []Index,
+	unusedIndexes s.StringSet) ([]*DropRecommendation, error) {
+	reasons := make([]string, len(indexes))
+	candidates := make([]int, 0, len(indexes))
+	for i, idx := range indexes {
+		reasons[i] = redundantReason(i, indexes)
+		if reasons[i] == "" && idx.Unique {
+			continue // dropping a unique index changes the constraints of the table
+		}
+		if reasons[i] == "" && unusedIndexes.Exist(fmt.Sprintf("%v.%v", table, idx.IndexName)) {
+			reasons[i] = fmt.Sprintf("%v: index %v is never used according to the index usage and the statement history",
+				dropReasonUnused, idx.IndexName)
+		}
+		if reasons[i] != "" {
+			candidates = append(candidates, i)
+		}
+	}
+	referenced := da.referencedByWorkload(table)
+	for i, idx := range indexes {
+		if reasons[i] == "" && !idx.Unique && referenced {
+			candidates = append(candidates, i)
+		}
+	}
+
+	// queryCosts are the plan costs of the workload queries after dropping the recommended indexes.
+	queryCosts := make(map[string]float64)
+	dropped := make([]Index, 0, len(candidates))
+	results := make([]*DropRecommendation, 0, len(candidates))
+	for _, i := range candidates {
+		idx := indexes[i]
+		if requiredByForeignKeys(idx, indexes, dropped) {
+			continue
+		}
+		regressed, costs, err := da.regressedQueries(table, queryCosts, dropped, idx)
+		if err != nil {
+			return nil, err
+		}
+		reason := reasons[i]
+		if reason == "" && len(regressed) == 0 {
+			reason = fmt.Sprintf("%v: the plan cost of the workload queries does not increase without index %v",
+				dropReasonDominated, idx.IndexName)
+		}
+		if reason == "" {
+			continue
+		}
+		dropped = append(dropped, idx)
+		queryCosts = costs
+
+		cols := make([]string, 0, len(idx.Columns))
+		for _, col := range idx.Columns {
+			cols = append(cols, col.ColumnName)
+		}
+		indexSize, err := da.optimizer.EstIndexSize(idx.SchemaName, idx.TableName, cols...)
+		if err != nil {
+			return nil, err
+		}
+		writeAmplificationSaved, err := da.writeAmplificationSaved(idx, indexes, indexSize)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, &DropRecommendation{
+			Database:                idx.SchemaName,
+			Table:                   idx.TableName,
+			IndexName:               idx.IndexName,
+			IndexColumns:            cols,
+			IndexSize:               uint64(indexSize),
+			Reason:                  reason,
+			WriteAmplificationSaved: writeAmplificationSaved,
+			RegressedQueries:        regressed,
+		})
+	}
+	return results, nil
+}
+
+// requiredByForeignKeys returns whether a foreign key can't use any index after dropping the index
+// in addition to the dropped indexes.
+func requiredByForeignKeys(idx Index, indexes, dropped []Index) bool {
+	for _, cols := range idx.ForeignKeys {
+		covered := false
+		for _, other := range indexes {
+			if other.IndexName == idx.IndexName || !other.prefixCover(cols) ||
+				slices.ContainsFunc(dropped, func(d Index) bool { return d.IndexName == other.IndexName }) {
+				continue
+			}
+			covered = true
+			break
+		}
+		if !covered {
+			return true
+		}
+	}
+	return false
+}
+
+// redundantReason returns why indexes[i] is redundant, or an empty string if it isn't.
+// An index is redundant if its columns are a prefix of another index's columns, and its
+// uniqueness constraint is enforced by the other index as well.
+func redundantReason(i int, indexes []Index) string {
+	idx := indexes[i]
+	for j, other := range indexes {
+		if i == j || !other.PrefixContain(idx) {
+			continue
+		}
+		sameCols := len(other.Columns) == len(idx.Columns)
+		if idx.Unique && !(other.Unique && sameCols) {
+			continue
+		}
+		if sameCols && idx.Unique == other.Unique && i < j {
+			continue // keep the first one of the duplicated indexes
+		}
+		if sameCols {
+			return fmt.Sprintf("%v: index %v is a duplicate of index %v", dropReasonRedundant, idx.IndexName, other.IndexName)
+		}
+		return fmt.Sprintf("%v: index %v is a prefix of index %v", dropReasonRedundant, idx.IndexName, other.IndexName)
+	}
+	return ""
+}
+
+func (da *dropAdvisor) referencedByWorkload(table string) bool {
+	for _, q := range da.queries {
+		if da.queryTables[q.Key()].Exist(table) {
+			return true
+		}
+	}
+	return false
+}
+
+// regressedQueries returns the top queries whose plan cost would increase after dropping the index in
+// addition to the dropped indexes, and the plan costs of the queries after dropping them all.
+// queryCosts are the plan costs of the queries after dropping the dropped indexes, which are filled lazily.
+func (da *dropAdvisor) regressedQueries(table string, queryCosts map[string]float64, dropped []Index,
+	idx Index) ([]*ImpactedQuery, map[string]float64, error) {
+	impacts := make([]*ImpactedQuery, 0)
+	costs := make(map[string]float64, len(queryCosts))
+	droppedWithIdx := append(append(make([]Index, 0, len(dropped)+1), dropped...), idx)
+	for _, q := range da.queries {
+		if !da.queryTables[q.Key()].Exist(table) {
+			continue
+		}
+		if err := da.timeout(); err != nil {
+			return nil, nil, err
+		}
+		costBefore, ok := queryCosts[q.Key()]
+		if !ok {
+			var err error
+			if costBefore, err = da.optimizer.QueryPlanCostWithoutIndexes(q.Text, dropped...); err != nil {
+				return nil, nil, err
+			}
+			queryCosts[q.Key()] = costBefore
+		}
+		costAfter, err := da.optimizer.QueryPlanCostWithoutIndexes(q.Text, droppedWithIdx...)
+		if err != nil {
+			return nil, nil, err
+		}
+		costs[q.Key()] = costAfter
+		if costBefore == 0 { // avoid NaN
+			costBefore += 0.1
+			costAfter += 0.1
+		}
+		regression := round((costAfter-costBefore)/costBefore, 6)
+		if regression < 0.0001 {
+			continue // this query doesn't regress
+		}
+		impacts = append(impacts, &ImpactedQuery{
+			Query:       q.Text,
+			Improvement: -regression,
+		})
+	}
+
+	sort.Slice(impacts, func(i, j int) bool {
+		return impacts[i].Improvement < impacts[j].Improvement
+	})
+	topN := 3
+	if topN > len(impacts) {
+		topN = len(impacts)
+	}
+	return impacts[:topN], costs, nil
+}
+
+// writeAmplificationSaved estimates the ratio of the writes saved by dropping the index, every write to
+// the table has to update the row data and all its indexes, so the ratio is estimated by the size of the
+// index divided by the total size of the row data and all indexes.
+func (da *dropAdvisor) writeAmplificationSaved(idx Index, indexes []Index, indexSize float64) (float64, error) {
+	tableCols, err := da.optimizer.TableColumns(idx.SchemaName, idx.TableName)
+	if err != nil {
+		return 0, err
+	}
+	colNames := make([]string, 0, len(tableCols))
+	for _, col := range tableCols {
+		colNames = append(colNames, col.ColumnName)
+	}
+	totalSize, err := da.optimizer.EstIndexSize(idx.SchemaName, idx.TableName, colNames...)
+	if err != nil {
+		return 0, err
+	}
+	for _, other := range indexes {
+		cols := make([]string, 0, len(other.Columns))
+		for _, col := range other.Columns {
+			cols = append(cols, col.ColumnName)
+		}
+		otherSize, err := da.optimizer.EstIndexSize(other.SchemaName, other.TableName, cols...)
+		if err != nil {
+			return 0, err
+		}
+		totalSize += otherSize
+	}
+	if indexSize == 0 || totalSize == 0 { // no stats, assume all indexes and the row data are of the same size
+		return round(1/float64(1+len(indexes)), 6), nil
+	}
+	return round(indexSize/totalSize, 6), nil
+}
+
+func (da *dropAdvisor) timeout() error {
+	if time.Since(da.startAt) > da.option.Timeout {
+		return fmt.Errorf("index advisor timeout after %v", da.option.Timeout)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indexadvisor_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/planner/indexadvisor"
+	"github.com/pingcap/tidb/pkg/testkit"
+	s "github.com/pingcap/tidb/pkg/util/set"
+	"github.com/stretchr/testify/require"
+)
+
+func dropIndexCtx(unusedIndexes []string, queries ...string) context.Context {
+	querySet := s.NewSet[indexadvisor.Query]()
+	for _, q := range queries {
+		querySet.Add(indexadvisor.Query{SchemaName: "test", Text: q, Frequency: 1})
+	}
+	ctx := context.WithValue(context.Background(), indexadvisor.TestKey("query_set"), querySet)
+	return context.WithValue(ctx, indexadvisor.TestKey("unused_indexes"), s.NewStringSet(unusedIndexes...))
+}
+
+func checkDrop(ctx context.Context, t *testing.T, tk *testkit.TestKit, expected string) []*indexadvisor.DropRecommendation {
+	r, err := indexadvisor.AdviseDropIndexes(ctx, tk.Session(), nil, nil)
+	require.NoError(t, err)
+	indexes := make([]string, 0, len(r))
+	for _, result := range r {
+		reason, _, _ := strings.Cut(result.Reason, ":")
+		indexes = append(indexes, fmt.Sprintf("%v.%v.%v(%v):%v", result.Database, result.Table,
+			result.IndexName, strings.Join(result.IndexColumns, ","), reason))
+	}
+	require.Equal(t, expected, strings.Join(indexes, "|"))
+	return r
+}
+
+func TestAdviseDropRedundantIndexes(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec(`use test`)
+	tk.MustExec(`create table t (a int, b int, c int, d int, key ka(a), key kab(a, b), key kab2(a, b),
+		unique key uc(c), key kc(c), unique key ud(d), unique key ud2(d), unique key uab(a, b))`)
+
+	ctx := dropIndexCtx(nil, "select a, b from t where a=1 and b=1", "select c from t where c=1",
+		"select d from t where d=1")
+	r := checkDrop(ctx, t, tk, "test.t.ka(a):redundant|test.t.kab(a,b):redundant|test.t.kab2(a,b):redundant|"+
+		"test.t.kc(c):redundant|test.t.ud2(d):redundant")
+	for _, result := range r {
+		require.Empty(t, result.RegressedQueries)
+		require.Greater(t, result.WriteAmplificationSaved, 0.0)
+		require.Less(t, result.WriteAmplificationSaved, 1.0)
+	}
+	require.Equal(t, "redundant: index ka is a prefix of index kab", r[0].Reason)
+	require.Equal(t, "redundant: index kab is a duplicate of index uab", r[1].Reason)
+	require.Equal(t, "redundant: index ud2 is a duplicate of index ud", r[4].Reason)
+}
+
+func TestAdviseDropUnusedIndexes(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec(`use test`)
+	tk.MustExec(`create table t1 (a int, b int, c int, key ka(a), key kb(b), unique key uc(c))`)
+	tk.MustExec(`create table t2 (a int, b int, key ka(a), key kb(b))`)
+
+	// t2 is not referenced by the workload, but its unused indexes are still recommended.
+	ctx := dropIndexCtx([]string{"test.t1.ka", "test.t1.uc", "test.t2.kb"},
+		"select * from t1 where a=1", "select * from t1 where b=1")
+	r := checkDrop(ctx, t, tk, "test.t1.ka(a):unused|test.t2.kb(b):unused")
+	// the usage of ka is not collected, dropping it regresses the query
+	require.Len(t, r[0].RegressedQueries, 1)
+	require.Equal(t, "SELECT * FROM `test`.`t1` WHERE `a` = 1", r[0].RegressedQueries[0].Query)
+	require.Less(t, r[0].RegressedQueries[0].Improvement, 0.0)
+	require.Empty(t, r[1].RegressedQueries)
+}
+
+func TestAdviseDropDominatedIndexes(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec(`use test`)
+	tk.MustExec(`create table t (a int, b int, c int, d int, key kab(a, b), key kac(a, c), key kd(d))`)
+
+	// kab and kac can replace each other for the first query, only one of them is recommended.
+	ctx := dropIndexCtx(nil, "select a from t where a=1", "select * from t where d=1")
+	r := checkDrop(ctx, t, tk, "test.t.kab(a,b):dominated")
+	require.Empty(t, r[0].RegressedQueries)
+
+	ctx = dropIndexCtx(nil, "select a, b from t where a=1 and b=1", "select a, c from t where a=1 and c=1",
+		"select * from t where d=1")
+	r, err := indexadvisor.AdviseDropIndexes(ctx, tk.Session(), nil, nil)
+	require.NoError(t, err)
+	require.Len(t, r, 0)
+	tk.MustQuery(`show warnings`).Check(testkit.Rows(
+		"Warning 1105  Considered 3 existing indexes on 3 queries, no redundant, unused or dominated indexes were found."))
+}
+
+func TestAdviseDropIndexesForForeignKey(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec(`use test`)
+	tk.MustExec(`create table t1 (a int primary key)`)
+	tk.MustExec(`create table t2 (a int, b int, key ka(a), key kab(a, b), foreign key fk_a(a) references t1(a))`)
+
+	// ka is a prefix of kab, and kab is unused, but one of them must be kept for fk_a.
+	ctx := dropIndexCtx([]string{"test.t2.kab"}, "select * from t2 where b=1")
+	checkDrop(ctx, t, tk, "test.t2.ka(a):redundant")
+}
+
+func TestAdviseDropIndexesForSQL(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec(`use test`)
+	tk.MustExec(`create table t (a int, b int, key ka(a), key kab(a, b))`)
+
+	ctx := context.WithValue(context.Background(), indexadvisor.TestKey("unused_indexes"), s.NewStringSet())
+	rows := tk.MustQueryWithContext(ctx, `recommend index drop for 'select * from t where a=1 and b=1'`).Rows()
+	require.Len(t, rows, 1)
+	require.Equal(t, []any{"test", "t", "ka", "a"}, rows[0][:4])
+	require.Equal(t, "redundant: index ka is a prefix of index kab", rows[0][5])
+	require.Equal(t, "[]", rows[0][7])
+	require.Equal(t, "DROP INDEX ka ON t;", rows[0][8])
+
+	require.Error(t, tk.QueryToErr(`recommend index drop for 'xxx'`))
+}
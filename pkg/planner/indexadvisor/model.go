@@ -17,6 +17,7 @@ package indexadvisor
 import (
 	"fmt"
 	"math"
+	"slices"
 	"strings"
 )
 
@@ -66,6 +67,10 @@ type Index struct {
 	TableName  string
 	IndexName  string
 	Columns    []Column
+	Unique     bool // only used for existing indexes
+	// ForeignKeys are the columns of the foreign keys which can use this index, and can only use
+	// the other indexes that may be dropped as well, only used for existing indexes.
+	ForeignKeys [][]string
 }
 
 // NewIndex creates a new index.
@@ -105,6 +110,19 @@ func (i Index) PrefixContain(j Index) bool {
 	return true
 }
 
+// prefixCover returns whether the columns are the prefix of the index in any order.
+func (i Index) prefixCover(cols []string) bool {
+	if len(i.Columns) < len(cols) {
+		return false
+	}
+	for _, col := range cols {
+		if !slices.ContainsFunc(i.Columns[:len(cols)], func(c Column) bool { return c.ColumnName == col }) {
+			return false
+		}
+	}
+	return true
+}
+
 // IndexSetCost is the cost of a index configuration.
 type IndexSetCost struct {
 	TotalWorkloadQueryCost    float64
@@ -162,3 +180,19 @@ type Recommendation struct {
 	WorkloadImpact     *WorkloadImpact
 	TopImpactedQueries []*ImpactedQuery
 }
+
+// DropRecommendation represents an existing index which is recommended to be dropped.
+type DropRecommendation struct {
+	Database     string
+	Table        string
+	IndexName    string
+	IndexColumns []string
+	IndexSize    uint64 // byte
+	Reason       string // why recommend dropping this index
+
+	// WriteAmplificationSaved is the estimated ratio of the index writes saved for the table,
+	// which is the size of this index divided by the total size of the table data and indexes.
+	WriteAmplificationSaved float64
+	// RegressedQueries are the queries whose plan cost would increase after dropping this index.
+	RegressedQueries []*ImpactedQuery
+}
@@ -56,6 +56,12 @@ type Optimizer interface {
 
 	// QueryPlanCost return the cost of the query plan.
 	QueryPlanCost(sql string, hypoIndexes ...Index) (cost float64, err error)
+
+	// TableIndexes returns the existing secondary indexes of the specified table which can be dropped.
+	TableIndexes(schema, table string) ([]Index, error)
+
+	// QueryPlanCostWithoutIndexes return the cost of the query plan as if the specified indexes were dropped.
+	QueryPlanCostWithoutIndexes(sql string, droppedIndexes ...Index) (cost float64, err error)
 }
 
 // optimizerImpl is the implementation of Optimizer.
@@ -243,6 +249,120 @@ func (opt *optimizerImpl) QueryPlanCost(sql string, hypoIndexes ...Index) (cost
 	return QueryPlanCostHook(opt.sctx, stmt)
 }
 
+// TableIndexes returns the existing secondary indexes of the specified table which can be dropped.
+// Indexes that the advisor can't evaluate (prefix, expression, multi-valued and columnar indexes)
+// and indexes required by foreign keys are skipped.
+func (opt *optimizerImpl) TableIndexes(schema, table string) ([]Index, error) {
+	tbl, err := opt.is().TableByName(context.Background(), ast.NewCIStr(schema), ast.NewCIStr(table))
+	if err != nil {
+		return nil, err
+	}
+	tblInfo := tbl.Meta()
+	fkCols := make([][]ast.CIStr, 0, len(tblInfo.ForeignKeys))
+	for _, fk := range tblInfo.ForeignKeys {
+		fkCols = append(fkCols, fk.Cols)
+	}
+	for _, referredFK := range opt.is().GetTableReferredForeignKeys(strings.ToLower(schema), strings.ToLower(table)) {
+		fkCols = append(fkCols, referredFK.Cols)
+	}
+
+	indexes := make([]Index, 0, len(tblInfo.Indices))
+	for _, idxInfo := range tblInfo.Indices {
+		if !droppableIndex(tblInfo, idxInfo) {
+			continue
+		}
+		required, sharedFKCols := requiredByForeignKey(tblInfo, idxInfo, fkCols)
+		if required {
+			continue
+		}
+		cols := make([]string, 0, len(idxInfo.Columns))
+		for _, col := range idxInfo.Columns {
+			cols = append(cols, col.Name.L)
+		}
+		idx := NewIndex(schema, table, idxInfo.Name.L, cols...)
+		idx.Unique = idxInfo.Unique
+		idx.ForeignKeys = sharedFKCols
+		indexes = append(indexes, idx)
+	}
+	return indexes, nil
+}
+
+func droppableIndex(tblInfo *model.TableInfo, idxInfo *model.IndexInfo) bool {
+	if idxInfo.State != model.StatePublic || idxInfo.Primary || idxInfo.MVIndex ||
+		idxInfo.IsColumnarIndex() || idxInfo.IsRowStoreFullTextIndex() {
+		return false
+	}
+	for _, col := range idxInfo.Columns {
+		if col.Length != types.UnspecifiedLength || col.Offset >= len(tblInfo.Columns) ||
+			tblInfo.Columns[col.Offset].Hidden {
+			return false
+		}
+	}
+	return true
+}
+
+// requiredByForeignKey returns whether the index is the only one that can be used by a foreign key,
+// and the columns of the foreign keys which can use the index and other droppable indexes only,
+// one of these indexes must be kept for each of such foreign keys, see dropAdvisor.adviseTable.
+func requiredByForeignKey(tblInfo *model.TableInfo, idxInfo *model.IndexInfo,
+	fkCols [][]ast.CIStr) (required bool, sharedFKCols [][]string) {
+	for _, cols := range fkCols {
+		if !model.IsIndexPrefixCovered(tblInfo, idxInfo, cols...) {
+			continue
+		}
+		if tblInfo.PKIsHandle && len(cols) == 1 {
+			if pkCol := tblInfo.GetPkColInfo(); pkCol != nil && pkCol.Name.L == cols[0].L {
+				continue
+			}
+		}
+		coveredByKept, coveredByDroppable := false, false
+		for _, other := range tblInfo.Indices {
+			if other.ID == idxInfo.ID || other.State != model.StatePublic ||
+				!model.IsIndexPrefixCovered(tblInfo, other, cols...) {
+				continue
+			}
+			if !droppableIndex(tblInfo, other) {
+				coveredByKept = true
+				break
+			}
+			coveredByDroppable = true
+		}
+		switch {
+		case coveredByKept:
+		case coveredByDroppable:
+			names := make([]string, 0, len(cols))
+			for _, col := range cols {
+				names = append(names, col.L)
+			}
+			sharedFKCols = append(sharedFKCols, names)
+		default:
+			return true, nil
+		}
+	}
+	return false, sharedFKCols
+}
+
+// QueryPlanCostWithoutIndexes return the cost of the query plan as if the specified indexes were dropped.
+func (opt *optimizerImpl) QueryPlanCostWithoutIndexes(sql string, droppedIndexes ...Index) (cost float64, err error) {
+	originalHypoDroppedIndexes := opt.sctx.GetSessionVars().HypoDroppedIndexes
+	defer func() {
+		opt.sctx.GetSessionVars().HypoDroppedIndexes = originalHypoDroppedIndexes
+	}()
+
+	hypoDroppedIndexes := make(map[string]map[string]map[string]struct{})
+	for _, idx := range droppedIndexes {
+		if hypoDroppedIndexes[idx.SchemaName] == nil {
+			hypoDroppedIndexes[idx.SchemaName] = make(map[string]map[string]struct{})
+		}
+		if hypoDroppedIndexes[idx.SchemaName][idx.TableName] == nil {
+			hypoDroppedIndexes[idx.SchemaName][idx.TableName] = make(map[string]struct{})
+		}
+		hypoDroppedIndexes[idx.SchemaName][idx.TableName][idx.IndexName] = struct{}{}
+	}
+	opt.sctx.GetSessionVars().HypoDroppedIndexes = hypoDroppedIndexes
+	return opt.QueryPlanCost(sql)
+}
+
 // EstIndexSize return the estimated index size of the specified table and columns
 func (opt *optimizerImpl) EstIndexSize(db, table string, cols ...string) (indexSize float64, err error) {
 	tbl, err := opt.is().TableByName(context.Background(), ast.NewCIStr(db), ast.NewCIStr(table))
@@ -262,3 +262,60 @@ func TestOptimizerQueryPlanCost(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, cost3 < cost2)
 }
+
+func TestOptimizerTableIndexes(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec(`use test`)
+	tk.MustExec(`create table t1 (a int primary key, b int, c varchar(32), d int, unique key ub(b), key kbc(b, c),
+		key kc(c(8)), key kexpr((d+1)))`)
+	tk.MustExec(`create table t2 (a int, b int, c int, key ka(a), key kab(a, b), key kc(c),
+		foreign key fk_a(a) references t1(a), foreign key fk_c(c) references t1(a))`)
+
+	opt := indexadvisor.NewOptimizer(tk.Session())
+	indexes, err := opt.TableIndexes("test", "t1")
+	require.NoError(t, err)
+	require.Equal(t, []indexadvisor.Index{
+		{SchemaName: "test", TableName: "t1", IndexName: "ub", Unique: true,
+			Columns: indexadvisor.NewColumns("test", "t1", "b")},
+		indexadvisor.NewIndex("test", "t1", "kbc", "b", "c"),
+	}, indexes)
+
+	// kc is the only index for the foreign key fk_c, while ka and kab can both be used by fk_a.
+	indexes, err = opt.TableIndexes("test", "t2")
+	require.NoError(t, err)
+	ka := indexadvisor.NewIndex("test", "t2", "ka", "a")
+	ka.ForeignKeys = [][]string{{"a"}}
+	kab := indexadvisor.NewIndex("test", "t2", "kab", "a", "b")
+	kab.ForeignKeys = [][]string{{"a"}}
+	require.Equal(t, []indexadvisor.Index{ka, kab}, indexes)
+
+	_, err = opt.TableIndexes("test", "t3")
+	require.Error(t, err)
+}
+
+func TestOptimizerQueryPlanCostWithoutIndexes(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec(`use test`)
+	tk.MustExec(`create table t0 (a int, b int, c int, key ka(a), key kab(a, b))`)
+
+	opt := indexadvisor.NewOptimizer(tk.Session())
+	sql := "select a, b from t0 where a=1 and b=1"
+	cost1, err := opt.QueryPlanCost(sql)
+	require.NoError(t, err)
+	cost2, err := opt.QueryPlanCostWithoutIndexes(sql, indexadvisor.NewIndex("test", "t0", "ka", "a"))
+	require.NoError(t, err)
+	require.Equal(t, cost1, cost2)
+	cost3, err := opt.QueryPlanCostWithoutIndexes(sql, indexadvisor.NewIndex("test", "t0", "kab", "a", "b"))
+	require.NoError(t, err)
+	require.True(t, cost3 > cost1)
+	cost4, err := opt.QueryPlanCostWithoutIndexes(sql, indexadvisor.NewIndex("test", "t0", "ka", "a"),
+		indexadvisor.NewIndex("test", "t0", "kab", "a", "b"))
+	require.NoError(t, err)
+	require.True(t, cost4 > cost3)
+
+	// the dropped indexes only take effect when calculating the cost
+	require.Nil(t, tk.Session().GetSessionVars().HypoDroppedIndexes)
+	tk.MustUseIndex(sql, "kab")
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package planner
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/materializedview"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/planner/core/resolve"
+	"github.com/pingcap/tidb/pkg/privilege"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+)
+
+// rewriteByMaterializedView rewrites an aggregate query of a single table to
+// read one of the materialized views of the table. The view is read as it is,
+// so the result reflects the base table at the last refresh of the view. It
+// returns nil if the query isn't rewritten.
+func rewriteByMaterializedView(ctx context.Context, sctx sessionctx.Context, node *resolve.NodeW, is infoschema.InfoSchema) (*resolve.NodeW, error) {
+	var sel *ast.SelectStmt
+	explain, isExplain := node.Node.(*ast.ExplainStmt)
+	if isExplain {
+		sel, _ = explain.Stmt.(*ast.SelectStmt)
+	} else {
+		sel, _ = node.Node.(*ast.SelectStmt)
+	}
+	if sel == nil || sel.From == nil {
+		return nil, nil
+	}
+	join, ok := sel.From.TableRefs.Left.(*ast.TableSource)
+	if !ok || sel.From.TableRefs.Right != nil {
+		return nil, nil
+	}
+	tn, ok := join.Source.(*ast.TableName)
+	if !ok || tn.AsOf != nil {
+		return nil, nil
+	}
+	tnW := node.GetResolveContext().GetTableName(tn)
+	if tnW == nil || tnW.DBInfo == nil || !tnW.TableInfo.HasMaterializedViews() {
+		return nil, nil
+	}
+
+	checker := privilege.GetPrivilegeManager(sctx)
+	for _, mvID := range tnW.TableInfo.MaterializedViewIDs {
+		mvTbl, ok := is.TableByID(ctx, mvID)
+		if !ok || !mvTbl.Meta().IsMaterializedView() {
+			continue
+		}
+		mvInfo := mvTbl.Meta()
+		if checker != nil && !checker.RequestVerification(sctx.GetSessionVars().ActiveRoles,
+			tnW.DBInfo.Name.L, mvInfo.Name.L, "", mysql.SelectPriv) {
+			continue
+		}
+		stmt, err := parser.New().ParseOneStmt(mvInfo.MaterializedView.Definition, "", "")
+		if err != nil {
+			continue
+		}
+		cols := make([]ast.CIStr, 0, len(mvInfo.Columns))
+		for _, col := range mvInfo.Columns {
+			cols = append(cols, col.Name)
+		}
+		d := materializedview.Analyze(stmt, cols)
+		if d == nil {
+			continue
+		}
+		newSel, ok := d.Rewrite(sel, ast.Ident{Schema: tnW.DBInfo.Name, Name: mvInfo.Name})
+		if !ok {
+			continue
+		}
+		if err = core.Preprocess(ctx, sctx, node.CloneWithNewNode(newSel)); err != nil {
+			return nil, err
+		}
+		if isExplain {
+			explain.Stmt = newSel
+			return node, nil
+		}
+		return node.CloneWithNewNode(newSel), nil
+	}
+	return nil, nil
+}
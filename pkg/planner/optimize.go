@@ -265,6 +265,19 @@ func Optimize(ctx context.Context, sctx sessionctx.Context, node *resolve.NodeW,
 		}
 	}
 
+	if sessVars.EnableMaterializedViewRewrite && !sessVars.InRestrictedSQL && !sessVars.StmtCtx.IsStaleness {
+		rewritten, err := rewriteByMaterializedView(ctx, sctx, node, is)
+		if err != nil {
+			return nil, nil, err
+		}
+		if rewritten != nil {
+			// The plan of the rewritten statement can't be shared by the statements
+			// with the same digest.
+			node = rewritten
+			skipNonPreparedCache = true
+		}
+	}
+
 	enableUseBinding := sessVars.UsePlanBaselines
 	stmtNode, isStmtNode := node.Node.(ast.StmtNode)
 	binding, match, _ := bindinfo.MatchSQLBinding(sctx, stmtNode)
@@ -420,6 +433,36 @@ func OptimizeForForeignKeyCascade(ctx context.Context, sctx planctx.PlanContext,
 	return p, nil
 }
 
+// OptimizeForTrigger does optimization and creates a Plan for a statement in a trigger body.
+// Like OptimizeForForeignKeyCascade, it doesn't consider plan cache and plan binding,
+// but the privileges of the session user, which is switched to the definer of the trigger, are checked.
+func OptimizeForTrigger(ctx context.Context, sctx planctx.PlanContext, node *resolve.NodeW, is infoschema.InfoSchema) (base.Plan, error) {
+	builder := planBuilderPool.Get().(*core.PlanBuilder)
+	defer planBuilderPool.Put(builder.ResetForReuse())
+	hintProcessor := hint.NewQBHintHandler(sctx.GetSessionVars().StmtCtx)
+	builder.Init(sctx, is, hintProcessor)
+	p, err := builder.Build(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+	if pm := privilege.GetPrivilegeManager(sctx); pm != nil {
+		visitInfo := core.VisitInfo4PrivCheck(ctx, is, node.Node, builder.GetVisitInfo())
+		if err := core.CheckPrivilege(sctx.GetSessionVars().ActiveRoles, pm, visitInfo); err != nil {
+			return nil, err
+		}
+	}
+	if err := core.CheckTableLock(sctx, is, builder.GetVisitInfo()); err != nil {
+		return nil, err
+	}
+	logic, isLogicalPlan := p.(base.LogicalPlan)
+	if !isLogicalPlan {
+		return p, nil
+	}
+	core.RecheckCTE(logic)
+	finalPlan, _, err := core.DoOptimize(ctx, sctx, builder.GetOptFlag(), logic)
+	return finalPlan, err
+}
+
 func allowInReadOnlyMode(sctx planctx.PlanContext, node ast.Node) (bool, error) {
 	pm := privilege.GetPrivilegeManager(sctx)
 	if pm == nil {
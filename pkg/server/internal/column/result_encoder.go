@@ -136,7 +136,7 @@ func isStringColumnType(tp byte) bool {
 	switch tp {
 	case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar, mysql.TypeBit,
 		mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob,
-		mysql.TypeEnum, mysql.TypeSet, mysql.TypeJSON:
+		mysql.TypeEnum, mysql.TypeSet, mysql.TypeJSON, mysql.TypeGeometry:
 		return true
 	case mysql.TypeTiDBVectorFloat32:
 		// When passing Vector column to the SQL Client, pretend to be a non-binary String.
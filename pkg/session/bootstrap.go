@@ -788,6 +788,23 @@ const (
 		value json NOT NULL,
 		index idx_version_category_type (version, category, type),
 		index idx_table_id (table_id));`
+
+	// CreateRoutinesTable is a table to store the definitions of stored routines.
+	CreateRoutinesTable = `CREATE TABLE IF NOT EXISTS mysql.routines (
+		routine_schema varchar(64) COLLATE utf8mb4_general_ci NOT NULL,
+		routine_name varchar(64) COLLATE utf8mb4_general_ci NOT NULL,
+		routine_type enum('FUNCTION','PROCEDURE') NOT NULL,
+		definition longtext NOT NULL,
+		definer varchar(288) NOT NULL,
+		sql_mode varchar(1024) NOT NULL DEFAULT '',
+		security_type enum('DEFINER','INVOKER') NOT NULL DEFAULT 'DEFINER',
+		character_set_client varchar(32) NOT NULL,
+		collation_connection varchar(32) NOT NULL,
+		database_collation varchar(32) NOT NULL,
+		comment text,
+		created timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		last_altered timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (routine_schema, routine_name, routine_type));`
 )
 
 // CreateTimers is a table to store all timers for tidb
@@ -1284,11 +1301,15 @@ const (
 	// version 247
 	// Add last_stats_histograms_version to mysql.stats_meta.
 	version247 = 247
+
+	// version 248
+	//   create `mysql.routines` table
+	version248 = 248
 )
 
 // currentBootstrapVersion is defined as a variable, so we can modify its value for testing.
 // please make sure this is the largest version
-var currentBootstrapVersion int64 = version247
+var currentBootstrapVersion int64 = version248
 
 // DDL owner key's expired time is ManagerSessionTTL seconds, we should wait the time and give more time to have a chance to finish it.
 var internalSQLTimeout = owner.ManagerSessionTTL + 15
@@ -1471,6 +1492,7 @@ var (
 		upgradeToVer245,
 		upgradeToVer246,
 		upgradeToVer247,
+		upgradeToVer248,
 	}
 )
 
@@ -3477,6 +3499,13 @@ func upgradeToVer247(s sessiontypes.Session, ver int64) {
 	doReentrantDDL(s, "ALTER TABLE mysql.stats_meta ADD COLUMN last_stats_histograms_version bigint unsigned DEFAULT NULL", infoschema.ErrColumnExists)
 }
 
+func upgradeToVer248(s sessiontypes.Session, ver int64) {
+	if ver >= version248 {
+		return
+	}
+	mustExecute(s, CreateRoutinesTable)
+}
+
 // initGlobalVariableIfNotExists initialize a global variable with specific val if it does not exist.
 func initGlobalVariableIfNotExists(s sessiontypes.Session, name string, val any) {
 	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnBootstrap)
@@ -3633,6 +3662,8 @@ func doDDLWorks(s sessiontypes.Session) {
 	mustExecute(s, CreateKernelOptionsTable)
 	// create mysql.tidb_workload_values
 	mustExecute(s, CreateTiDBWorkloadValuesTable)
+	// create mysql.routines
+	mustExecute(s, CreateRoutinesTable)
 }
 
 // doBootstrapSQLFile executes SQL commands in a file as the last stage of bootstrap.
@@ -239,6 +239,8 @@ func TestBootstrapWithError(t *testing.T) {
 	MustExec(t, se, "SELECT * from mysql.tidb_ttl_table_status")
 	// Check mysql.tidb_workload_values table
 	MustExec(t, se, "SELECT * from mysql.tidb_workload_values")
+	// Check mysql.routines table
+	MustExec(t, se, "SELECT * from mysql.routines")
 }
 
 func TestDDLTableCreateBackfillTable(t *testing.T) {
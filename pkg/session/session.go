@@ -52,6 +52,7 @@ import (
 	"github.com/pingcap/tidb/pkg/domain"
 	"github.com/pingcap/tidb/pkg/domain/infosync"
 	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/eventscheduler"
 	"github.com/pingcap/tidb/pkg/executor"
 	"github.com/pingcap/tidb/pkg/executor/staticrecordset"
 	"github.com/pingcap/tidb/pkg/expression"
@@ -2052,7 +2053,6 @@ func (s *session) ExecuteStmt(ctx context.Context, stmtNode ast.StmtNode) (sqlex
 	if err := s.loadCommonGlobalVariablesIfNeeded(); err != nil {
 		return nil, err
 	}
-
 	sessVars := s.sessionVars
 	sessVars.StartTime = time.Now()
 
@@ -2187,6 +2187,8 @@ func (s *session) ExecuteStmt(ctx context.Context, stmtNode ast.StmtNode) (sqlex
 		recordSet, err = stmt.PointGet(ctx)
 		s.setLastTxnInfoBeforeTxnEnd()
 		s.txn.changeToInvalid()
+	} else if callStmt, ok := stmtNode.(*ast.CallStmt); ok {
+		recordSet, err = runCallStmt(ctx, s, stmt, callStmt)
 	} else {
 		recordSet, err = runStmt(ctx, s, stmt)
 	}
@@ -2370,6 +2372,38 @@ func runStmt(ctx context.Context, se *session, s sqlexec.Statement) (rs sqlexec.
 	return nil, err
 }
 
+// runCallStmt runs a CALL statement. The statements of the procedure body are
+// executed one by one through the session, and each of them replaces the
+// statement context, so the context of the CALL is restored after the body
+// to record its slow log and statement summary. Like MySQL, the warnings and
+// the affected rows of the CALL are the ones of the last statement.
+func runCallStmt(ctx context.Context, se *session, s *executor.ExecStmt, stmt *ast.CallStmt) (sqlexec.RecordSet, error) {
+	sessVars := se.sessionVars
+	origTxnCtx := sessVars.TxnCtx
+	sc, startTime := sessVars.StmtCtx, sessVars.StartTime
+	// The cached statement contexts are not reused while they are referenced,
+	// so the statements of the body don't reset the context of the CALL.
+	refHeld := sessVars.RefCountOfStmtCtx.TryIncrease()
+	sc.StmtType = ast.GetStmtLabel(stmt)
+	rs, err := executor.CallProcedure(ctx, se, stmt)
+	if refHeld {
+		sessVars.RefCountOfStmtCtx.Decrease()
+	}
+	if last := sessVars.StmtCtx; last != sc {
+		sc.SetWarnings(last.GetWarnings())
+		sc.SetAffectedRows(last.AffectedRows())
+		sc.PrevLastInsertID, sc.LastInsertID, sc.LastInsertIDSet = last.PrevLastInsertID, last.LastInsertID, last.LastInsertIDSet
+		sessVars.StmtCtx, sessVars.StartTime = sc, startTime
+	}
+	se.currentPlan = s.Plan
+	sc.DetachMemDiskTracker()
+	if se.txn.pending() {
+		se.txn.changeToInvalid()
+	}
+	s.FinishExecuteStmt(origTxnCtx.StartTS, err, false)
+	return rs, err
+}
+
 // ExecStmtVarKeyType is a dummy type to avoid naming collision in context.
 type ExecStmtVarKeyType int
 
@@ -3679,6 +3713,7 @@ func bootstrapSessionImpl(ctx context.Context, store kv.Storage, createSessionsI
 		return s
 	}
 	dom.StartTTLJobManager()
+	dom.StartEventScheduler(eventRunner(store))
 
 	dom.LoadSigningCertLoop(cfg.Security.SessionTokenSigningCert, cfg.Security.SessionTokenSigningKey)
 
@@ -3802,6 +3837,37 @@ func createSessionsImpl(store kv.Storage, cnt int, createSessionImpl func(kv.Sto
 	return ses, nil
 }
 
+// eventRunner returns the runner of the event scheduler. The body of an event
+// runs in a new session which is authenticated as the definer, with the
+// environment saved when the event was created.
+func eventRunner(store kv.Storage) eventscheduler.Runner {
+	return func(ctx context.Context, ev *eventscheduler.Event) error {
+		se, err := CreateSession(store)
+		if err != nil {
+			return err
+		}
+		defer se.Close()
+
+		username, hostname, _ := strings.Cut(ev.Definer, "@")
+		if !se.AuthWithoutVerification(ctx, &auth.UserIdentity{Username: username, Hostname: hostname}) {
+			return errors.Errorf("the definer '%s' of event %s does not exist", ev.Definer, ev.FullName())
+		}
+		sessVars := se.GetSessionVars()
+		sessVars.CurrentDB = ev.Schema
+		for name, val := range map[string]string{
+			vardef.SQLModeVar:          ev.SQLMode,
+			vardef.TimeZone:            ev.TimeZone,
+			vardef.CharacterSetClient:  ev.CharsetClient,
+			vardef.CollationConnection: ev.CollationConnection,
+		} {
+			if err = sessVars.SetSystemVar(name, val); err != nil {
+				return err
+			}
+		}
+		return executor.ExecuteEventBody(ctx, se, ev)
+	}
+}
+
 // createSession creates a new session.
 // Please note that such a session is not tracked by the internal session list.
 // This means the min ts reporter is not aware of it and may report a wrong min start ts.
@@ -441,6 +441,8 @@ type StatementContext struct {
 
 	// InHandleForeignKeyTrigger indicates currently are handling foreign key trigger.
 	InHandleForeignKeyTrigger bool
+	// InHandleTrigger indicates currently are executing the body of a row trigger.
+	InHandleTrigger bool
 
 	// ForeignKeyTriggerCtx is the contain information for foreign key cascade execution.
 	ForeignKeyTriggerCtx struct {
@@ -864,8 +866,8 @@ type TableEntry struct {
 
 // AddAffectedRows adds affected rows.
 func (sc *StatementContext) AddAffectedRows(rows uint64) {
-	if sc.InHandleForeignKeyTrigger {
-		// For compatibility with MySQL, not add the affected row cause by the foreign key trigger.
+	if sc.InHandleForeignKeyTrigger || sc.InHandleTrigger {
+		// For compatibility with MySQL, not add the affected row cause by the foreign key trigger and the row trigger.
 		return
 	}
 	sc.affectedRows.Add(rows)
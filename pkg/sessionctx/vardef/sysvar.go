@@ -104,6 +104,8 @@ const (
 	OldPasswords = "old_passwords"
 	// MaxConnections is the name for 'max_connections' system variable.
 	MaxConnections = "max_connections"
+	// EventScheduler is the name for 'event_scheduler' system variable.
+	EventScheduler = "event_scheduler"
 	// SkipNameResolve is the name for 'skip_name_resolve' system variable.
 	SkipNameResolve = "skip_name_resolve"
 	// ForeignKeyChecks is the name for 'foreign_key_checks' system variable.
@@ -588,6 +588,9 @@ const (
 	// TiDBEnablePipelinedWindowFunction is used to control whether to use pipelined window function, it only works when tidb_enable_window_function = true.
 	TiDBEnablePipelinedWindowFunction = "tidb_enable_pipelined_window_function"
 
+	// TiDBEnableMaterializedViewRewrite is used to control whether to rewrite the queries to read the materialized views.
+	TiDBEnableMaterializedViewRewrite = "tidb_enable_materialized_view_rewrite"
+
 	// TiDBEnableStrictDoubleTypeCheck is used to control table field double type syntax check.
 	TiDBEnableStrictDoubleTypeCheck = "tidb_enable_strict_double_type_check"
 
@@ -653,6 +656,10 @@ const (
 	// TiDBOptEnableFuzzyBinding indicates whether to enable the universal binding.
 	TiDBOptEnableFuzzyBinding = "tidb_opt_enable_fuzzy_binding"
 
+	// TiDBOptExploreTrialBudget indicates the time budget to trial-execute the plans generated by `EXPLAIN EXPLORE`.
+	// Zero means the generated plans are not executed.
+	TiDBOptExploreTrialBudget = "tidb_opt_explore_trial_budget"
+
 	// TiDBEnableExtendedStats indicates whether the extended statistics feature is enabled.
 	TiDBEnableExtendedStats = "tidb_enable_extended_stats"
 
@@ -1102,6 +1109,9 @@ const (
 	// TiDBEnableTmpStorageOnOOM controls whether to enable the temporary storage for some operators
 	// when a single SQL statement exceeds the memory quota specified by the memory quota.
 	TiDBEnableTmpStorageOnOOM = "tidb_enable_tmp_storage_on_oom"
+	// TiDBSpillCompression indicates the algorithm used to compress the data spilled to the temporary storage.
+	// The possible values are "none", "lz4" and "zstd".
+	TiDBSpillCompression = "tidb_spill_compression"
 	// TiDBDDLEnableFastReorg indicates whether to use lighting backfill process for adding index.
 	TiDBDDLEnableFastReorg = "tidb_ddl_enable_fast_reorg"
 	// TiDBDDLDiskQuota used to set disk quota for lightning add index.
@@ -1414,6 +1424,7 @@ const (
 	DefTiDBForcePriority                    = mysql.NoPriority
 	DefEnableWindowFunction                 = true
 	DefEnablePipelinedWindowFunction        = true
+	DefEnableMaterializedViewRewrite        = false
 	DefEnableStrictDoubleTypeCheck          = true
 	DefEnableVectorizedExpression           = true
 	DefTiDBOptJoinReorderThreshold          = 0
@@ -1433,7 +1444,9 @@ const (
 	DefTiDBEvolvePlanTaskMaxTime            = 600 // 600s
 	DefTiDBEvolvePlanTaskStartTime          = "00:00 +0000"
 	DefTiDBEvolvePlanTaskEndTime            = "23:59 +0000"
+	DefTiDBOptExploreTrialBudget            = time.Duration(0)
 	DefInnodbLockWaitTimeout                = 50 // 50s
+	DefEventScheduler                       = false
 	DefTiDBStoreLimit                       = 0
 	DefTiDBMetricSchemaStep                 = 60 // 60s
 	DefTiDBMetricSchemaRangeDuration        = 60 // 60s
@@ -1460,6 +1473,7 @@ const (
 	DefTiDBEnableIndexMergeJoin                       = false
 	DefTiDBTrackAggregateMemoryUsage                  = true
 	DefCTEMaxRecursionDepth                           = 1000
+	DefMaxSpRecursionDepth                            = 0
 	DefTiDBTmpTableMaxSize                            = 64 << 20 // 64MB.
 	DefTiDBEnableLocalTxn                             = false
 	DefTiDBTSOClientBatchMaxWaitTime                  = 0.0 // 0ms
@@ -1538,6 +1552,7 @@ const (
 	DefEnableTiDBGCAwareMemoryTrack                   = false
 	DefTiDBDefaultStrMatchSelectivity                 = 0.8
 	DefTiDBEnableTmpStorageOnOOM                      = true
+	DefTiDBSpillCompression                           = "none"
 	DefTiDBEnableMDL                                  = true
 	DefTiFlashFastScan                                = false
 	DefMemoryUsageAlarmRatio                          = 0.7
@@ -1674,6 +1689,7 @@ var (
 	EnablePProfSQLCPU             = atomic.NewBool(false)
 	EnableBatchDML                = atomic.NewBool(false)
 	EnableTmpStorageOnOOM         = atomic.NewBool(DefTiDBEnableTmpStorageOnOOM)
+	SpillCompression              = atomic.NewString(DefTiDBSpillCompression)
 	DDLReorgWorkerCounter   int32 = DefTiDBDDLReorgWorkerCount
 	DDLReorgBatchSize       int32 = DefTiDBDDLReorgBatchSize
 	DDLFlashbackConcurrency int32 = DefTiDBDDLFlashbackConcurrency
@@ -1739,6 +1755,7 @@ var (
 	PasswordValidtaionNumberCount      = atomic.NewInt32(1)
 	PasswordValidationSpecialCharCount = atomic.NewInt32(1)
 	EnableTTLJob                       = atomic.NewBool(DefTiDBTTLJobEnable)
+	EnableEventScheduler               = atomic.NewBool(DefEventScheduler)
 	TTLScanBatchSize                   = atomic.NewInt64(DefTiDBTTLScanBatchSize)
 	TTLDeleteBatchSize                 = atomic.NewInt64(DefTiDBTTLDeleteBatchSize)
 	TTLDeleteRateLimit                 = atomic.NewInt64(DefTiDBTTLDeleteRateLimit)
@@ -1762,6 +1779,7 @@ var (
 	MaxUserConnectionsValue         = atomic.NewUint32(DefMaxUserConnections)
 	MaxPreparedStmtCountValue       = atomic.NewInt64(DefMaxPreparedStmtCount)
 	HistoricalStatsDuration         = atomic.NewDuration(DefTiDBHistoricalStatsDuration)
+	OptExploreTrialBudget           = atomic.NewDuration(DefTiDBOptExploreTrialBudget)
 	EnableHistoricalStatsForCapture = atomic.NewBool(DefTiDBEnableHistoricalStatsForCapture)
 	TTLRunningTasks                 = atomic.NewInt32(DefTiDBTTLRunningTasks)
 	// always set the default value to false because the resource control in kv-client is not inited
@@ -1122,6 +1122,9 @@ type SessionVars struct {
 	// EnablePipelinedWindowExec enables executing window functions in a pipelined manner.
 	EnablePipelinedWindowExec bool
 
+	// EnableMaterializedViewRewrite enables rewriting the queries to read the materialized views.
+	EnableMaterializedViewRewrite bool
+
 	// AllowProjectionPushDown enables pushdown projection on TiKV.
 	AllowProjectionPushDown bool
 
@@ -1378,6 +1381,14 @@ type SessionVars struct {
 	// see https://dev.mysql.com/doc/refman/8.0/en/server-system-variables.html#sysvar_cte_max_recursion_depth
 	CTEMaxRecursionDepth int
 
+	// MaxSpRecursionDepth indicates the maximum number of times a stored procedure can be called recursively.
+	// see https://dev.mysql.com/doc/refman/8.0/en/server-system-variables.html#sysvar_max_sp_recursion_depth
+	MaxSpRecursionDepth int
+
+	// ProcedureCalls counts the running calls of each stored procedure, which is used to limit the
+	// recursion of the procedures by MaxSpRecursionDepth.
+	ProcedureCalls map[string]int
+
 	// The temporary table size threshold, which is different from MySQL. See https://github.com/pingcap/tidb/issues/28691.
 	TMPTableSize int64
 
@@ -1661,6 +1672,10 @@ type SessionVars struct {
 	// HypoIndexes are for the Index Advisor.
 	HypoIndexes map[string]map[string]map[string]*model.IndexInfo // dbName -> tblName -> idxName -> idxInfo
 
+	// HypoDroppedIndexes are for the Index Advisor, these existing indexes are ignored by the optimizer
+	// in explain-statements as if they were dropped.
+	HypoDroppedIndexes map[string]map[string]map[string]struct{} // dbName -> tblName -> idxName
+
 	// TiFlashReplicaRead indicates the policy of TiFlash node selection when the query needs the TiFlash engine.
 	TiFlashReplicaRead tiflash.ReplicaRead
 
@@ -2259,6 +2274,7 @@ func NewSessionVars(hctx HookContext) *SessionVars {
 		EnableIndexMergeJoin:          vardef.DefTiDBEnableIndexMergeJoin,
 		AllowFallbackToTiKV:           make(map[kv.StoreType]struct{}),
 		CTEMaxRecursionDepth:          vardef.DefCTEMaxRecursionDepth,
+		MaxSpRecursionDepth:           vardef.DefMaxSpRecursionDepth,
 		TMPTableSize:                  vardef.DefTiDBTmpTableMaxSize,
 		MPPStoreFailTTL:               vardef.DefTiDBMPPStoreFailTTL,
 		Rng:                           mathutil.NewWithTime(),
@@ -1535,6 +1535,12 @@ var defaultSysVars = []*SysVar{
 	}, GetGlobal: func(_ context.Context, s *SessionVars) (string, error) {
 		return BoolToOnOff(vardef.EnableTmpStorageOnOOM.Load()), nil
 	}},
+	{Scope: vardef.ScopeGlobal, Name: vardef.TiDBSpillCompression, Value: vardef.DefTiDBSpillCompression, Type: vardef.TypeEnum, PossibleValues: []string{"none", "lz4", "zstd"}, SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+		vardef.SpillCompression.Store(val)
+		return nil
+	}, GetGlobal: func(_ context.Context, s *SessionVars) (string, error) {
+		return vardef.SpillCompression.Load(), nil
+	}},
 	{Scope: vardef.ScopeGlobal, Name: vardef.TiDBAutoBuildStatsConcurrency, Value: strconv.Itoa(vardef.DefTiDBAutoBuildStatsConcurrency), Type: vardef.TypeInt, MinValue: 1, MaxValue: vardef.MaxConfigurableConcurrency},
 	{Scope: vardef.ScopeGlobal, Name: vardef.TiDBSysProcScanConcurrency, Value: strconv.Itoa(vardef.DefTiDBSysProcScanConcurrency), Type: vardef.TypeInt, MinValue: 0, MaxValue: math.MaxInt32},
 	{Scope: vardef.ScopeGlobal, Name: vardef.TiDBMemoryUsageAlarmRatio, Value: strconv.FormatFloat(vardef.DefMemoryUsageAlarmRatio, 'f', -1, 64), Type: vardef.TypeFloat, MinValue: 0.0, MaxValue: 1.0, SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
@@ -2330,6 +2336,10 @@ var defaultSysVars = []*SysVar{
 		s.EnableWindowFunction = TiDBOptOn(val)
 		return nil
 	}},
+	{Scope: vardef.ScopeGlobal | vardef.ScopeSession, Name: vardef.TiDBEnableMaterializedViewRewrite, Value: BoolToOnOff(vardef.DefEnableMaterializedViewRewrite), Type: vardef.TypeBool, SetSession: func(s *SessionVars, val string) error {
+		s.EnableMaterializedViewRewrite = TiDBOptOn(val)
+		return nil
+	}},
 	{Scope: vardef.ScopeGlobal | vardef.ScopeSession, Name: vardef.TiDBEnablePipelinedWindowFunction, Value: BoolToOnOff(vardef.DefEnablePipelinedWindowFunction), Type: vardef.TypeBool, SetSession: func(s *SessionVars, val string) error {
 		s.EnablePipelinedWindowExec = TiDBOptOn(val)
 		return nil
@@ -2461,6 +2471,17 @@ var defaultSysVars = []*SysVar{
 		s.EvolvePlanBaselines = TiDBOptOn(val)
 		return nil
 	}},
+	{Scope: vardef.ScopeGlobal, Name: vardef.TiDBOptExploreTrialBudget, Value: vardef.DefTiDBOptExploreTrialBudget.String(), Type: vardef.TypeDuration, MaxValue: uint64(time.Hour),
+		GetGlobal: func(_ context.Context, _ *SessionVars) (string, error) {
+			return vardef.OptExploreTrialBudget.Load().String(), nil
+		}, SetGlobal: func(_ context.Context, _ *SessionVars, val string) error {
+			d, err := time.ParseDuration(val)
+			if err != nil {
+				return err
+			}
+			vardef.OptExploreTrialBudget.Store(d)
+			return nil
+		}},
 	{Scope: vardef.ScopeGlobal | vardef.ScopeSession, Name: vardef.TiDBEnableExtendedStats, Value: BoolToOnOff(false), Hidden: true, Type: vardef.TypeBool, SetSession: func(s *SessionVars, val string) error {
 		s.EnableExtendedStats = TiDBOptOn(val)
 		return nil
@@ -2469,6 +2490,10 @@ var defaultSysVars = []*SysVar{
 		s.CTEMaxRecursionDepth = TidbOptInt(val, vardef.DefCTEMaxRecursionDepth)
 		return nil
 	}},
+	{Scope: vardef.ScopeGlobal | vardef.ScopeSession, Name: vardef.MaxSpRecursionDepth, Value: strconv.Itoa(vardef.DefMaxSpRecursionDepth), Type: vardef.TypeUnsigned, MinValue: 0, MaxValue: 255, SetSession: func(s *SessionVars, val string) error {
+		s.MaxSpRecursionDepth = TidbOptInt(val, vardef.DefMaxSpRecursionDepth)
+		return nil
+	}},
 	{Scope: vardef.ScopeGlobal | vardef.ScopeSession, Name: vardef.TiDBAllowAutoRandExplicitInsert, Value: BoolToOnOff(vardef.DefTiDBAllowAutoRandExplicitInsert), Type: vardef.TypeBool, SetSession: func(s *SessionVars, val string) error {
 		s.AllowAutoRandExplicitInsert = TiDBOptOn(val)
 		return nil
@@ -2895,6 +2920,12 @@ var defaultSysVars = []*SysVar{
 	}, GetGlobal: func(ctx context.Context, vars *SessionVars) (string, error) {
 		return BoolToOnOff(vardef.EnableTTLJob.Load()), nil
 	}},
+	{Scope: vardef.ScopeGlobal, Name: vardef.EventScheduler, Value: BoolToOnOff(vardef.DefEventScheduler), Type: vardef.TypeBool, SetGlobal: func(ctx context.Context, vars *SessionVars, s string) error {
+		vardef.EnableEventScheduler.Store(TiDBOptOn(s))
+		return nil
+	}, GetGlobal: func(ctx context.Context, vars *SessionVars) (string, error) {
+		return BoolToOnOff(vardef.EnableEventScheduler.Load()), nil
+	}},
 	{Scope: vardef.ScopeGlobal, Name: vardef.TiDBTTLScanBatchSize, Value: strconv.Itoa(vardef.DefTiDBTTLScanBatchSize), Type: vardef.TypeInt, MinValue: vardef.DefTiDBTTLScanBatchMinSize, MaxValue: vardef.DefTiDBTTLScanBatchMaxSize, SetGlobal: func(ctx context.Context, vars *SessionVars, s string) error {
 		val, err := strconv.ParseInt(s, 10, 64)
 		if err != nil {
@@ -1819,6 +1819,25 @@ func TestTiDBHashJoinVersion(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestTiDBSpillCompression(t *testing.T) {
+	vars := NewSessionVars(nil)
+	mock := NewMockGlobalAccessor4Tests()
+	mock.SessionVars = vars
+	vars.GlobalVarsAccessor = mock
+	defer vardef.SpillCompression.Store(vardef.DefTiDBSpillCompression)
+
+	val, err := mock.GetGlobalSysVar(vardef.TiDBSpillCompression)
+	require.NoError(t, err)
+	require.Equal(t, "none", val)
+
+	require.NoError(t, mock.SetGlobalSysVar(context.Background(), vardef.TiDBSpillCompression, "ZSTD"))
+	require.Equal(t, "zstd", vardef.SpillCompression.Load())
+	require.NoError(t, mock.SetGlobalSysVar(context.Background(), vardef.TiDBSpillCompression, "lz4"))
+	require.Equal(t, "lz4", vardef.SpillCompression.Load())
+	require.Error(t, mock.SetGlobalSysVar(context.Background(), vardef.TiDBSpillCompression, "snappy"))
+	require.Equal(t, "lz4", vardef.SpillCompression.Load())
+}
+
 func TestTiDBAutoAnalyzeConcurrencyValidation(t *testing.T) {
 	vars := NewSessionVars(nil)
 
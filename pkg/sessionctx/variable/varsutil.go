@@ -485,6 +485,9 @@ var GAFunction4ExpressionIndex = map[string]struct{}{
 	ast.JSONDepth:         {},
 	ast.JSONKeys:          {},
 	ast.JSONLength:        {},
+	// Spatial functions.
+	ast.STX: {},
+	ast.STY: {},
 }
 
 var analyzeSkipAllowedTypes = map[string]struct{}{
@@ -23,6 +23,9 @@ import (
 	"github.com/pingcap/tidb/pkg/meta/model"
 	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/codec"
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"go.uber.org/zap"
 )
@@ -63,16 +66,19 @@ func BuildExtendedStats(sctx sessionctx.Context,
 
 func fillExtendedStatsItemVals(sctx sessionctx.Context, item *ExtendedStatsItem, cols []*model.ColumnInfo, collectors []*SampleCollector) *ExtendedStatsItem {
 	switch item.Tp {
-	case ast.StatsTypeCardinality, ast.StatsTypeDependency:
-		return nil
+	case ast.StatsTypeCardinality:
+		return fillExtStatsCardinalityVals(sctx, item, cols, collectors)
+	case ast.StatsTypeDependency:
+		return fillExtStatsDependencyVals(sctx, item, cols, collectors)
 	case ast.StatsTypeCorrelation:
 		return fillExtStatsCorrVals(sctx, item, cols, collectors)
 	}
 	return nil
 }
 
-func fillExtStatsCorrVals(sctx sessionctx.Context, item *ExtendedStatsItem, cols []*model.ColumnInfo, collectors []*SampleCollector) *ExtendedStatsItem {
-	colOffsets := make([]int, 0, 2)
+// extStatsColOffsets returns the offsets in cols of the columns involved in the extended stats item.
+func extStatsColOffsets(item *ExtendedStatsItem, cols []*model.ColumnInfo) []int {
+	colOffsets := make([]int, 0, len(item.ColIDs))
 	for _, id := range item.ColIDs {
 		for i, col := range cols {
 			if col.ID == id {
@@ -81,6 +87,124 @@ func fillExtStatsCorrVals(sctx sessionctx.Context, item *ExtendedStatsItem, cols
 			}
 		}
 	}
+	return colOffsets
+}
+
+// encodeSampleRows encodes the sampled values of the given columns row by row, i.e, the sampled values sharing the
+// same SampleItem.Ordinal are encoded into one key. NULL values are not collected into the samples, so a value missing
+// from one of the columns is encoded as NULL, and a row is only returned if it has a non-NULL value in any column.
+func encodeSampleRows(sc *stmtctx.StatementContext, collectors []*SampleCollector, colOffsets []int) (map[int]string, error) {
+	rows := make(map[int][]types.Datum)
+	for i, offset := range colOffsets {
+		for _, sample := range collectors[offset].Samples {
+			row, ok := rows[sample.Ordinal]
+			if !ok {
+				row = make([]types.Datum, len(colOffsets))
+				rows[sample.Ordinal] = row
+			}
+			row[i] = sample.Value
+		}
+	}
+	keys := make(map[int]string, len(rows))
+	for ordinal, row := range rows {
+		key, err := codec.EncodeKey(sc.TimeZone(), nil, row...)
+		if err != nil {
+			return nil, err
+		}
+		keys[ordinal] = string(key)
+	}
+	return keys, nil
+}
+
+// fillExtStatsCardinalityVals estimates the number of distinct value combinations of the columns, which is used to
+// estimate the NDV of column groups, e.g, `GROUP BY a, b`, and the selectivity of equal conditions on all the columns.
+func fillExtStatsCardinalityVals(sctx sessionctx.Context, item *ExtendedStatsItem, cols []*model.ColumnInfo, collectors []*SampleCollector) *ExtendedStatsItem {
+	colOffsets := extStatsColOffsets(item, cols)
+	if len(colOffsets) < 2 || len(colOffsets) != len(item.ColIDs) {
+		return nil
+	}
+	keys, err := encodeSampleRows(sctx.GetSessionVars().StmtCtx, collectors, colOffsets)
+	if err != nil {
+		return nil
+	}
+	if len(keys) == 0 {
+		item.ScalarVals = 0
+		return item
+	}
+	counts := make(map[string]uint64, len(keys))
+	for _, key := range keys {
+		counts[key]++
+	}
+	var onlyOnceItems uint64
+	for _, cnt := range counts {
+		if cnt == 1 {
+			onlyOnceItems++
+		}
+	}
+	sampleSize := uint64(len(keys))
+	// Count of SampleCollector excludes NULL values, and all the collectors are built from the same sampled rows.
+	rowCount := max(uint64(collectors[colOffsets[0]].Count+collectors[colOffsets[0]].NullCount), sampleSize)
+	item.ScalarVals = float64(estimateNDVBySample(sampleSize, uint64(len(counts)), onlyOnceItems, rowCount))
+	return item
+}
+
+// fillExtStatsDependencyVals computes the degree of the functional dependency from the first column to the second one,
+// i.e, the fraction of rows whose value of the first column determines the value of the second one. A group of rows
+// with the same value of the first column supports the dependency only if all of them have the same value of the
+// second column. Rows having NULL value on either column are ignored.
+func fillExtStatsDependencyVals(sctx sessionctx.Context, item *ExtendedStatsItem, cols []*model.ColumnInfo, collectors []*SampleCollector) *ExtendedStatsItem {
+	colOffsets := extStatsColOffsets(item, cols)
+	if len(colOffsets) != 2 {
+		return nil
+	}
+	sc := sctx.GetSessionVars().StmtCtx
+	keysX, err := encodeSampleRows(sc, collectors, colOffsets[:1])
+	if err != nil {
+		return nil
+	}
+	keysY, err := encodeSampleRows(sc, collectors, colOffsets[1:])
+	if err != nil {
+		return nil
+	}
+	type dependencyGroup struct {
+		valY       string
+		count      int
+		consistent bool
+	}
+	groups := make(map[string]*dependencyGroup, len(keysX))
+	total := 0
+	for ordinal, keyX := range keysX {
+		keyY, ok := keysY[ordinal]
+		if !ok {
+			continue
+		}
+		total++
+		group, ok := groups[keyX]
+		if !ok {
+			groups[keyX] = &dependencyGroup{valY: keyY, count: 1, consistent: true}
+			continue
+		}
+		group.count++
+		if group.valY != keyY {
+			group.consistent = false
+		}
+	}
+	if total == 0 {
+		item.ScalarVals = 0
+		return item
+	}
+	supported := 0
+	for _, group := range groups {
+		if group.consistent {
+			supported += group.count
+		}
+	}
+	item.ScalarVals = float64(supported) / float64(total)
+	return item
+}
+
+func fillExtStatsCorrVals(sctx sessionctx.Context, item *ExtendedStatsItem, cols []*model.ColumnInfo, collectors []*SampleCollector) *ExtendedStatsItem {
+	colOffsets := extStatsColOffsets(item, cols)
 	if len(colOffsets) != 2 {
 		return nil
 	}
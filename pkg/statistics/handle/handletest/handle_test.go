@@ -647,6 +647,57 @@ func TestCorrelationStatsCompute(t *testing.T) {
 	))
 }
 
+func TestCardinalityAndDependencyStatsCompute(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("set session tidb_enable_extended_stats = on")
+	tk.MustExec("use test")
+	tk.MustExec("create table t(zip int, city int, state int)")
+	// Each zip has 5 rows, a zip belongs to one city, and all the cities except city 2 belong to one state.
+	values := make([]string, 0, 100)
+	for zip := range 20 {
+		for range 5 {
+			values = append(values, fmt.Sprintf("(%d,%d,%d)", zip, zip/4, zip/10))
+		}
+	}
+	tk.MustExec("insert into t values " + strings.Join(values, ","))
+	err := tk.ExecToErr("alter table t add stats_extended s1 cardinality(zip)")
+	require.Equal(t, "Only support Cardinality statistics type on at least 2 columns", err.Error())
+	err = tk.ExecToErr("alter table t add stats_extended s1 dependency(zip,city,state)")
+	require.Equal(t, "Only support Correlation and Dependency statistics types on 2 columns", err.Error())
+	tk.MustExec("alter table t add stats_extended s1 cardinality(city,state)")
+	tk.MustExec("alter table t add stats_extended s2 cardinality(zip,city,state)")
+	tk.MustExec("alter table t add stats_extended s3 dependency(zip,city)")
+	tk.MustExec("alter table t add stats_extended s4 dependency(city,state)")
+	tk.MustExec("alter table t add stats_extended s5 dependency(state,city)")
+	for _, ver := range []int{1, 2} {
+		tk.MustExec(fmt.Sprintf("set @@session.tidb_analyze_version=%d", ver))
+		tk.MustExec("analyze table t")
+		tk.MustQuery("select name, type, column_ids, stats, status from mysql.stats_extended").Sort().Check(testkit.Rows(
+			"s1 0 [2,3] 6.000000 1",
+			"s2 0 [1,2,3] 20.000000 1",
+			"s3 1 [1,2] 1.000000 1",
+			"s4 1 [2,3] 0.800000 1",
+			"s5 1 [3,2] 0.000000 1",
+		))
+	}
+	is := dom.InfoSchema()
+	require.NoError(t, dom.StatsHandle().Update(context.Background(), is))
+	rows := tk.MustQuery("show stats_extended where db_name = 'test' and table_name = 't' and stats_name in ('s1', 's4')").Sort().Rows()
+	require.Len(t, rows, 2)
+	require.Equal(t, []any{"s1", "[city,state]", "cardinality", "6.000000"}, rows[0][2:6])
+	require.Equal(t, []any{"s4", "[city,state]", "dependency", "0.800000"}, rows[1][2:6])
+
+	// Rows with NULL values are ignored by the dependency stats.
+	tk.MustExec("insert into t values (100, null, 1), (101, 2, null)")
+	tk.MustExec("analyze table t")
+	tk.MustQuery("select name, stats from mysql.stats_extended where name in ('s1', 's3', 's4')").Sort().Check(testkit.Rows(
+		"s1 8.000000",
+		"s3 1.000000",
+		"s4 0.800000",
+	))
+}
+
 func TestSyncStatsExtendedRemoval(t *testing.T) {
 	store, dom := testkit.CreateMockStoreAndDomain(t)
 	tk := testkit.NewTestKit(t, store)
@@ -26,7 +26,6 @@ import (
 	"github.com/pingcap/tidb/pkg/infoschema"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/meta/model"
-	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/statistics"
@@ -280,16 +279,12 @@ func ExtendedStatsFromStorage(sctx sessionctx.Context, table *statistics.Table,
 				return nil, err
 			}
 			statsStr := row.GetString(4)
-			if item.Tp == ast.StatsTypeCardinality || item.Tp == ast.StatsTypeCorrelation {
-				if statsStr != "" {
-					item.ScalarVals, err = strconv.ParseFloat(statsStr, 64)
-					if err != nil {
-						statslogutil.StatsLogger().Error("parse scalar stats failed", zap.String("stats", statsStr), zap.Error(err))
-						return nil, err
-					}
+			if statsStr != "" {
+				item.ScalarVals, err = strconv.ParseFloat(statsStr, 64)
+				if err != nil {
+					statslogutil.StatsLogger().Error("parse scalar stats failed", zap.String("stats", statsStr), zap.Error(err))
+					return nil, err
 				}
-			} else {
-				item.StringVals = statsStr
 			}
 			table.ExtendedStats.Stats[name] = item
 		}
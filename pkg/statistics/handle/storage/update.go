@@ -165,7 +165,10 @@ func UpdateStatsMeta(
 func InsertExtendedStats(sctx sessionctx.Context,
 	statsCache types.StatsCache,
 	statsName string, colIDs []int64, tp int, tableID int64, ifNotExists bool) (statsVer uint64, err error) {
-	slices.Sort(colIDs)
+	// The order of columns matters for dependency stats, i.e, the first column determines the second one.
+	if tp != int(ast.StatsTypeDependency) {
+		slices.Sort(colIDs)
+	}
 	bytes, err := json.Marshal(colIDs)
 	if err != nil {
 		return 0, errors.Trace(err)
@@ -234,13 +237,7 @@ func SaveExtendedStatsToStorage(sctx sessionctx.Context,
 			return 0, errors.Trace(err)
 		}
 		strColIDs := string(bytes)
-		var statsStr string
-		switch item.Tp {
-		case ast.StatsTypeCardinality, ast.StatsTypeCorrelation:
-			statsStr = fmt.Sprintf("%f", item.ScalarVals)
-		case ast.StatsTypeDependency:
-			statsStr = item.StringVals
-		}
+		statsStr := fmt.Sprintf("%f", item.ScalarVals)
 		// If isLoad is true, it's INSERT; otherwise, it's UPDATE.
 		if _, err := statsutil.Exec(sctx, "replace into mysql.stats_extended values (%?, %?, %?, %?, %?, %?, %?)", name, item.Tp, tableID, strColIDs, statsStr, version, statistics.ExtendedStatsAnalyzed); err != nil {
 			return 0, err
@@ -266,6 +266,9 @@ type HistColl struct {
 	// For normal index, the column id is enough, as we already have in Idx2ColUniqueIDs. But currently, mv index needs more
 	// information to match the filter against the mv index columns, and we need this map to provide this information.
 	MVIdx2Columns map[int64][]*expression.Column
+	// ExtStats is the extended stats of the table, note that the ColIDs of its items are the column IDs in the metadata.
+	// It's only set when tidb_enable_extended_stats is ON.
+	ExtStats *ExtendedStatsColl
 }
 
 // NewHistColl creates a new HistColl.
@@ -28,6 +28,7 @@ import (
 	"github.com/pingcap/tidb/pkg/tablecodec"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util"
+	"github.com/pingcap/tidb/pkg/util/fulltext"
 	"github.com/pingcap/tidb/pkg/util/intest"
 	"github.com/pingcap/tidb/pkg/util/rowcodec"
 	"github.com/pingcap/tidb/pkg/util/tracing"
@@ -109,10 +110,14 @@ func (c *index) GenIndexValue(ec errctx.Context, loc *time.Location, distinct bo
 // 2. (i1, [m1,m2], i2, ...) ==> [(i1, m1, i2, ...), (i1, m2, i2, ...)]
 // 3. (i1, null, i2, ...) ==> [(i1, null, i2, ...)]
 // 4. (i1, [], i2, ...) ==> nothing.
+// 5. For FULLTEXT index, ('a b a') ==> [('a'), ('b')], and a NULL value has no entry.
 func (c *index) getIndexedValue(indexedValues []types.Datum) [][]types.Datum {
 	if !c.idxInfo.MVIndex {
 		return [][]types.Datum{indexedValues}
 	}
+	if c.idxInfo.FullTextInfo != nil {
+		return c.getFullTextIndexedValue(indexedValues[0])
+	}
 
 	vals := make([][]types.Datum, 0, 16)
 	jsonIdx := 0
@@ -156,6 +161,19 @@ out:
 	return vals
 }
 
+func (c *index) getFullTextIndexedValue(text types.Datum) [][]types.Datum {
+	if text.IsNull() {
+		return nil
+	}
+	collation := c.tblInfo.Columns[c.idxInfo.Columns[0].Offset].GetCollate()
+	tokens := fulltext.DistinctTokens(c.idxInfo.FullTextInfo.ParserType, text.GetString())
+	vals := make([][]types.Datum, 0, len(tokens))
+	for _, token := range tokens {
+		vals = append(vals, []types.Datum{types.NewCollationStringDatum(token, collation)})
+	}
+	return vals
+}
+
 // Create creates a new entry in the kvIndex data.
 // If the index is unique and there is an existing entry with the same key,
 // Create will return the existing entry's handle as the first return value, ErrKeyExists as the second return value.
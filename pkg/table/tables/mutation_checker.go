@@ -29,6 +29,7 @@ import (
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/collate"
 	"github.com/pingcap/tidb/pkg/util/dbterror"
+	"github.com/pingcap/tidb/pkg/util/fulltext"
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"github.com/pingcap/tidb/pkg/util/rowcodec"
 	"go.uber.org/zap"
@@ -388,9 +389,16 @@ func compareIndexData(
 			cols[offsetInTable].ColumnInfo,
 		)
 
-		comparison, err := CompareIndexAndVal(tc, expectedDatum, decodedMutationDatum,
-			collate.GetCollator(decodedMutationDatum.Collation()),
-			cols[offsetInTable].ColumnInfo.FieldType.IsArray() && expectedDatum.Kind() == types.KindMysqlJSON)
+		var comparison int
+		var err error
+		if indexInfo.IsRowStoreFullTextIndex() {
+			comparison = CompareFullTextIndexAndVal(expectedDatum, decodedMutationDatum, indexInfo,
+				collate.GetCollator(decodedMutationDatum.Collation()))
+		} else {
+			comparison, err = CompareIndexAndVal(tc, expectedDatum, decodedMutationDatum,
+				collate.GetCollator(decodedMutationDatum.Collation()),
+				cols[offsetInTable].ColumnInfo.FieldType.IsArray() && expectedDatum.Kind() == types.KindMysqlJSON)
+		}
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -431,6 +439,20 @@ func CompareIndexAndVal(tc types.Context, rowVal types.Datum, idxVal types.Datum
 	return cmpRes, err
 }
 
+// CompareFullTextIndexAndVal compares the row value and the value of a FULLTEXT index stored in TiKV.
+// It returns 0 if the indexed value is a token of the row value.
+func CompareFullTextIndexAndVal(rowVal types.Datum, idxVal types.Datum, indexInfo *model.IndexInfo, collator collate.Collator) int {
+	if rowVal.IsNull() {
+		return 1
+	}
+	for _, token := range fulltext.DistinctTokens(indexInfo.FullTextInfo.ParserType, rowVal.GetString()) {
+		if collator.Compare(token, idxVal.GetString()) == 0 {
+			return 0
+		}
+	}
+	return 1
+}
+
 // getColumnMaps tries to get the columnMaps from transaction options. If there isn't one, it builds one and stores it.
 // It saves redundant computations of the map.
 func getColumnMaps(txn kv.Transaction, t *TableCommon) columnMaps {
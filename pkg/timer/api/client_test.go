@@ -148,6 +148,14 @@ func TestUpdateTimerOption(t *testing.T) {
 	require.True(t, ok)
 	require.Equal(t, "UTC", tz)
 	require.Equal(t, []string{"Tags", "Enable", "TimeZone", "SchedPolicyType", "SchedPolicyExpr", "Watermark", "SummaryData"}, update.FieldsSet())
+
+	// test 'Data' field
+	require.False(t, update.Data.Present())
+	WithSetData([]byte("data1"))(&update)
+	data, ok := update.Data.Get()
+	require.True(t, ok)
+	require.Equal(t, []byte("data1"), data)
+	require.Equal(t, []string{"Tags", "Data", "Enable", "TimeZone", "SchedPolicyType", "SchedPolicyExpr", "Watermark", "SummaryData"}, update.FieldsSet())
 }
 
 func TestDefaultClient(t *testing.T) {
@@ -207,10 +215,11 @@ func TestDefaultClient(t *testing.T) {
 	require.Equal(t, 0, len(tms))
 
 	// update
-	err = cli.UpdateTimer(ctx, timer.ID, WithSetSchedExpr(SchedEventInterval, "3h"))
+	err = cli.UpdateTimer(ctx, timer.ID, WithSetSchedExpr(SchedEventInterval, "3h"), WithSetData([]byte("data2")))
 	require.NoError(t, err)
 	timer.SchedPolicyType = SchedEventInterval
 	timer.SchedPolicyExpr = "3h"
+	timer.Data = []byte("data2")
 	got, err = cli.GetTimerByID(ctx, timer.ID)
 	require.NoError(t, err)
 	require.Greater(t, got.Version, timer.Version)
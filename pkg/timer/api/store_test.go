@@ -256,6 +256,7 @@ func TestTimerUpdate(t *testing.T) {
 		EventData:       NewOptionalVal([]byte("eventdata1")),
 		EventStart:      NewOptionalVal(now.Add(time.Second)),
 		Tags:            NewOptionalVal([]string{"l1", "l2"}),
+		Data:            NewOptionalVal([]byte("data1")),
 		ManualRequest: NewOptionalVal(ManualRequest{
 			ManualRequestID:   "req1",
 			ManualRequestTime: time.Unix(123, 0),
@@ -284,6 +285,7 @@ func TestTimerUpdate(t *testing.T) {
 	require.Equal(t, []byte("eventdata1"), record.EventData)
 	require.Equal(t, now.Add(time.Second), record.EventStart)
 	require.Equal(t, []string{"l1", "l2"}, record.Tags)
+	require.Equal(t, []byte("data1"), record.Data)
 	require.Equal(t, ManualRequest{
 		ManualRequestID:   "req1",
 		ManualRequestTime: time.Unix(123, 0),
@@ -392,6 +392,11 @@ func (o *eventExtObj) ToEventExtra() (e api.EventExtra) {
 
 func buildUpdateCriteria(update *api.TimerUpdate, args []any) (string, []any, error) {
 	updateFields := make([]string, 0, cap(args)-len(args))
+	if val, ok := update.Data.Get(); ok {
+		updateFields = append(updateFields, "TIMER_DATA = %?")
+		args = append(args, val)
+	}
+
 	if val, ok := update.Enable.Get(); ok {
 		updateFields = append(updateFields, "ENABLE = %?")
 		args = append(args, val)
@@ -416,6 +416,14 @@ func TestBuildUpdateCriteria(t *testing.T) {
 			criteria: "ENABLE = %?, VERSION = VERSION + 1",
 			args:     []any{true},
 		},
+		{
+			update: &api.TimerUpdate{
+				Enable: api.NewOptionalVal(true),
+				Data:   api.NewOptionalVal([]byte("data1")),
+			},
+			criteria: "TIMER_DATA = %?, ENABLE = %?, VERSION = VERSION + 1",
+			args:     []any{[]byte("data1"), true},
+		},
 		{
 			update: &api.TimerUpdate{
 				Enable:          api.NewOptionalVal(false),
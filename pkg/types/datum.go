@@ -1060,6 +1060,8 @@ func (d *Datum) ConvertTo(ctx Context, target *FieldType) (Datum, error) {
 		return d.convertToMysqlJSON(target)
 	case mysql.TypeTiDBVectorFloat32:
 		return d.convertToVectorFloat32(ctx, target)
+	case mysql.TypeGeometry:
+		return d.convertToGeometry(target)
 	case mysql.TypeNull:
 		return Datum{}, nil
 	default:
@@ -1877,6 +1879,20 @@ func (d *Datum) convertToVectorFloat32(_ Context, target *FieldType) (ret Datum,
 	return ret, errors.Trace(err)
 }
 
+func (d *Datum) convertToGeometry(target *FieldType) (ret Datum, err error) {
+	switch d.k {
+	case KindString, KindBytes:
+		g, err := decodeGeometry(d.GetBytes())
+		if err != nil || !target.GetGeometryType().Accepts(g.Type) {
+			return ret, ErrCantCreateGeometryObject
+		}
+		ret.SetBytes(d.GetBytes())
+	default:
+		return ret, ErrCantCreateGeometryObject
+	}
+	return ret, nil
+}
+
 // ToBool converts to a bool.
 // We will use 1 for true, and 0 for false.
 func (d *Datum) ToBool(ctx Context) (int64, error) {
@@ -98,4 +98,20 @@ var (
 	ErrJSONBadOneOrAllArg = dbterror.ClassTypes.NewStd(mysql.ErrJSONBadOneOrAllArg)
 	// ErrJSONVacuousPath is returned for path expressions that are not allowed in that context.
 	ErrJSONVacuousPath = dbterror.ClassTypes.NewStd(mysql.ErrJSONVacuousPath)
+	// ErrCantCreateGeometryObject is returned when the value stored in a spatial column isn't a geometry of the column type.
+	ErrCantCreateGeometryObject = dbterror.ClassTypes.NewStd(mysql.ErrCantCreateGeometryObject)
+	// ErrGISInvalidData is returned when a spatial function gets an invalid geometry.
+	ErrGISInvalidData = dbterror.ClassTypes.NewStd(mysql.ErrGISInvalidData)
+	// ErrGISUnsupportedArgument is returned when a spatial function gets a geometry of an unsupported type.
+	ErrGISUnsupportedArgument = dbterror.ClassTypes.NewStd(mysql.ErrGISUnsupportedArgument)
+	// ErrGISDifferentSRIDs is returned when a spatial function gets geometries in different spatial reference systems.
+	ErrGISDifferentSRIDs = dbterror.ClassTypes.NewStd(mysql.ErrGISDifferentSRIDs)
+	// ErrSRSNotFound is returned when the spatial reference system of a geometry isn't supported.
+	ErrSRSNotFound = dbterror.ClassTypes.NewStd(mysql.ErrSRSNotFound)
+	// ErrLongitudeOutOfRange is returned when the longitude of a geographic geometry is out of range.
+	ErrLongitudeOutOfRange = dbterror.ClassTypes.NewStd(mysql.ErrLongitudeOutOfRange)
+	// ErrLatitudeOutOfRange is returned when the latitude of a geographic geometry is out of range.
+	ErrLatitudeOutOfRange = dbterror.ClassTypes.NewStd(mysql.ErrLatitudeOutOfRange)
+	// ErrNotImplementedForGeographicSRS is returned when a spatial function doesn't support geographic geometries.
+	ErrNotImplementedForGeographicSRS = dbterror.ClassTypes.NewStd(mysql.ErrNotImplementedForGeographicSRS)
 )
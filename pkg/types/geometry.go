@@ -0,0 +1,729 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/binary"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/parser/types"
+)
+
+// Spatial reference systems supported by the spatial types.
+const (
+	// SRIDCartesian is the SRID of the cartesian plane.
+	SRIDCartesian uint32 = 0
+	// SRIDWGS84 is the SRID of the WGS 84 geographic spatial reference system.
+	SRIDWGS84 uint32 = 4326
+)
+
+// GeometryPoint is a coordinate in a geometry. For geographic geometries, X is
+// the longitude and Y is the latitude, both in degrees.
+type GeometryPoint struct {
+	X, Y float64
+}
+
+// Geometry is a value of the spatial types.
+//
+// Storage Format (the same as the internal format of MySQL):
+// 4 byte       - SRID in little endian
+// N byte       - WKB of the geometry in little endian
+//
+// For geographic geometries, the coordinates are stored in longitude-latitude
+// order, while WKT and WKB use the latitude-longitude order of the spatial
+// reference system.
+type Geometry struct {
+	// SRID is the spatial reference system of the geometry. It's only set on
+	// the outermost geometry.
+	SRID uint32
+	// Type is the type of the geometry. It's never GeometryTypeGeometry.
+	Type types.GeometryType
+	// Points are the coordinates of a Point or a LineString.
+	Points []GeometryPoint
+	// Rings are the rings of a Polygon. The first ring is the exterior ring,
+	// and the others are holes.
+	Rings [][]GeometryPoint
+	// Geoms are the members of a MultiPoint, MultiLineString, MultiPolygon or
+	// GeometryCollection.
+	Geoms []*Geometry
+}
+
+var errInvalidGeometry = errors.New("invalid geometry")
+
+// geometryTypeName is the name of the geometry types in WKT.
+var geometryTypeName = [...]string{
+	types.GeometryTypePoint:              "POINT",
+	types.GeometryTypeLineString:         "LINESTRING",
+	types.GeometryTypePolygon:            "POLYGON",
+	types.GeometryTypeMultiPoint:         "MULTIPOINT",
+	types.GeometryTypeMultiLineString:    "MULTILINESTRING",
+	types.GeometryTypeMultiPolygon:       "MULTIPOLYGON",
+	types.GeometryTypeGeometryCollection: "GEOMETRYCOLLECTION",
+}
+
+// TypeName returns the name of the geometry type used in error messages.
+func (g *Geometry) TypeName() string {
+	return strings.ToUpper(g.Type.String())
+}
+
+// IsGeographic checks whether the geometry is in a geographic spatial
+// reference system.
+func (g *Geometry) IsGeographic() bool {
+	return g.SRID == SRIDWGS84
+}
+
+// IsEmpty checks whether the geometry has no points, which can only be a
+// geometry collection with no members or only empty members.
+func (g *Geometry) IsEmpty() bool {
+	if g.Type != types.GeometryTypeGeometryCollection {
+		return false
+	}
+	for _, m := range g.Geoms {
+		if !m.IsEmpty() {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckGeometrySRID checks whether the spatial reference system is supported.
+func CheckGeometrySRID(srid uint32) error {
+	if srid != SRIDCartesian && srid != SRIDWGS84 {
+		return ErrSRSNotFound.GenWithStackByArgs(srid)
+	}
+	return nil
+}
+
+// checkGeographicRange checks whether the coordinates of a geographic geometry
+// are in range.
+func (g *Geometry) checkGeographicRange(fn string) error {
+	var err error
+	g.walkPoints(func(p GeometryPoint) bool {
+		if p.X <= -180 || p.X > 180 {
+			err = ErrLongitudeOutOfRange.GenWithStackByArgs(p.X, fn, -180.0, 180.0)
+		} else if p.Y < -90 || p.Y > 90 {
+			err = ErrLatitudeOutOfRange.GenWithStackByArgs(p.Y, fn, -90.0, 90.0)
+		}
+		return err == nil
+	})
+	return err
+}
+
+// walkPoints calls f on every coordinate of the geometry until f returns false.
+func (g *Geometry) walkPoints(f func(GeometryPoint) bool) bool {
+	for _, p := range g.Points {
+		if !f(p) {
+			return false
+		}
+	}
+	for _, ring := range g.Rings {
+		for _, p := range ring {
+			if !f(p) {
+				return false
+			}
+		}
+	}
+	for _, m := range g.Geoms {
+		if !m.walkPoints(f) {
+			return false
+		}
+	}
+	return true
+}
+
+// swapAxes swaps the X and Y of every coordinate of the geometry.
+func (g *Geometry) swapAxes() {
+	swap := func(points []GeometryPoint) {
+		for i := range points {
+			points[i].X, points[i].Y = points[i].Y, points[i].X
+		}
+	}
+	swap(g.Points)
+	for _, ring := range g.Rings {
+		swap(ring)
+	}
+	for _, m := range g.Geoms {
+		m.swapAxes()
+	}
+}
+
+// validate checks the structure of a geometry: a LineString has at least two
+// points, the rings of a Polygon are closed and have at least four points, the
+// multi geometries have at least one member and all coordinates are finite.
+func (g *Geometry) validate() bool {
+	for _, p := range g.Points {
+		if math.IsNaN(p.X) || math.IsInf(p.X, 0) || math.IsNaN(p.Y) || math.IsInf(p.Y, 0) {
+			return false
+		}
+	}
+	switch g.Type {
+	case types.GeometryTypePoint:
+		return len(g.Points) == 1
+	case types.GeometryTypeLineString:
+		return len(g.Points) >= 2
+	case types.GeometryTypePolygon:
+		if len(g.Rings) == 0 {
+			return false
+		}
+		for _, ring := range g.Rings {
+			if len(ring) < 4 || ring[0] != ring[len(ring)-1] {
+				return false
+			}
+			for _, p := range ring {
+				if math.IsNaN(p.X) || math.IsInf(p.X, 0) || math.IsNaN(p.Y) || math.IsInf(p.Y, 0) {
+					return false
+				}
+			}
+		}
+		return true
+	case types.GeometryTypeMultiPoint, types.GeometryTypeMultiLineString, types.GeometryTypeMultiPolygon:
+		if len(g.Geoms) == 0 {
+			return false
+		}
+		memberType := g.Type - types.GeometryTypeMultiPoint + types.GeometryTypePoint
+		for _, m := range g.Geoms {
+			if m.Type != memberType || !m.validate() {
+				return false
+			}
+		}
+		return true
+	case types.GeometryTypeGeometryCollection:
+		for _, m := range g.Geoms {
+			if !m.validate() {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// ParseGeometryFromWKT parses a geometry from its WKT in the spatial reference
+// system srid. fn is the name of the function used in the error messages.
+func ParseGeometryFromWKT(fn string, wkt string, srid uint32) (*Geometry, error) {
+	if err := CheckGeometrySRID(srid); err != nil {
+		return nil, err
+	}
+	p := wktParser{s: wkt}
+	g, err := p.parseGeometry()
+	if err == nil {
+		p.skipSpaces()
+		if p.pos != len(p.s) || !g.validate() {
+			err = errInvalidGeometry
+		}
+	}
+	if err != nil {
+		return nil, ErrGISInvalidData.GenWithStackByArgs(fn)
+	}
+	g.SRID = srid
+	if g.IsGeographic() {
+		g.swapAxes()
+		if err = g.checkGeographicRange(fn); err != nil {
+			return nil, err
+		}
+	}
+	return g, nil
+}
+
+type wktParser struct {
+	s   string
+	pos int
+}
+
+func (p *wktParser) skipSpaces() {
+	for p.pos < len(p.s) && strings.IndexByte(" \t\r\n", p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+func (p *wktParser) tryConsume(c byte) bool {
+	p.skipSpaces()
+	if p.pos < len(p.s) && p.s[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *wktParser) consume(c byte) error {
+	if !p.tryConsume(c) {
+		return errInvalidGeometry
+	}
+	return nil
+}
+
+func (p *wktParser) word() string {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') {
+			break
+		}
+		p.pos++
+	}
+	return strings.ToUpper(p.s[start:p.pos])
+}
+
+func (p *wktParser) number() (float64, error) {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.s) && strings.IndexByte("+-.0123456789eE", p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+	return strconv.ParseFloat(p.s[start:p.pos], 64)
+}
+
+func (p *wktParser) point() (pt GeometryPoint, err error) {
+	if pt.X, err = p.number(); err != nil {
+		return pt, errInvalidGeometry
+	}
+	if pt.Y, err = p.number(); err != nil {
+		return pt, errInvalidGeometry
+	}
+	return pt, nil
+}
+
+// pointList parses '(' x y {, x y} ')'.
+func (p *wktParser) pointList() ([]GeometryPoint, error) {
+	if err := p.consume('('); err != nil {
+		return nil, err
+	}
+	var points []GeometryPoint
+	for {
+		pt, err := p.point()
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, pt)
+		if !p.tryConsume(',') {
+			break
+		}
+	}
+	return points, p.consume(')')
+}
+
+// list parses '(' elem {, elem} ')'.
+func (p *wktParser) list(elem func() error) error {
+	if err := p.consume('('); err != nil {
+		return err
+	}
+	for {
+		if err := elem(); err != nil {
+			return err
+		}
+		if !p.tryConsume(',') {
+			break
+		}
+	}
+	return p.consume(')')
+}
+
+func (p *wktParser) body(tp types.GeometryType) (*Geometry, error) {
+	g := &Geometry{Type: tp}
+	var err error
+	switch tp {
+	case types.GeometryTypePoint, types.GeometryTypeLineString:
+		g.Points, err = p.pointList()
+	case types.GeometryTypePolygon:
+		err = p.list(func() error {
+			ring, err := p.pointList()
+			g.Rings = append(g.Rings, ring)
+			return err
+		})
+	case types.GeometryTypeMultiPoint:
+		err = p.list(func() error {
+			// Both MULTIPOINT(1 1, 2 2) and MULTIPOINT((1 1), (2 2)) are accepted.
+			var pt GeometryPoint
+			var err error
+			if p.tryConsume('(') {
+				if pt, err = p.point(); err == nil {
+					err = p.consume(')')
+				}
+			} else {
+				pt, err = p.point()
+			}
+			g.Geoms = append(g.Geoms, &Geometry{Type: types.GeometryTypePoint, Points: []GeometryPoint{pt}})
+			return err
+		})
+	case types.GeometryTypeMultiLineString, types.GeometryTypeMultiPolygon:
+		memberType := tp - types.GeometryTypeMultiPoint + types.GeometryTypePoint
+		err = p.list(func() error {
+			m, err := p.body(memberType)
+			g.Geoms = append(g.Geoms, m)
+			return err
+		})
+	case types.GeometryTypeGeometryCollection:
+		if p.word() == "EMPTY" {
+			return g, nil
+		}
+		if err = p.consume('('); err != nil {
+			return nil, err
+		}
+		if p.tryConsume(')') {
+			return g, nil
+		}
+		for {
+			m, err := p.parseGeometry()
+			if err != nil {
+				return nil, err
+			}
+			g.Geoms = append(g.Geoms, m)
+			if !p.tryConsume(',') {
+				break
+			}
+		}
+		err = p.consume(')')
+	}
+	return g, err
+}
+
+func (p *wktParser) parseGeometry() (*Geometry, error) {
+	name := p.word()
+	if name == "GEOMCOLLECTION" {
+		return p.body(types.GeometryTypeGeometryCollection)
+	}
+	for tp, n := range geometryTypeName {
+		if n != "" && n == name {
+			return p.body(types.GeometryType(tp))
+		}
+	}
+	return nil, errInvalidGeometry
+}
+
+// WKT returns the WKT of the geometry.
+func (g *Geometry) WKT() string {
+	var sb strings.Builder
+	g.writeWKT(&sb, g.IsGeographic(), true)
+	return sb.String()
+}
+
+func writeWKTFloat(sb *strings.Builder, f float64) {
+	sb.WriteString(strings.Replace(strconv.FormatFloat(f, 'g', -1, 64), "e+", "e", 1))
+}
+
+func writeWKTPoints(sb *strings.Builder, points []GeometryPoint, swap bool) {
+	sb.WriteByte('(')
+	for i, p := range points {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		x, y := p.X, p.Y
+		if swap {
+			x, y = y, x
+		}
+		writeWKTFloat(sb, x)
+		sb.WriteByte(' ')
+		writeWKTFloat(sb, y)
+	}
+	sb.WriteByte(')')
+}
+
+func (g *Geometry) writeWKT(sb *strings.Builder, swap bool, withName bool) {
+	if withName {
+		sb.WriteString(geometryTypeName[g.Type])
+	}
+	switch g.Type {
+	case types.GeometryTypePoint, types.GeometryTypeLineString:
+		writeWKTPoints(sb, g.Points, swap)
+	case types.GeometryTypePolygon:
+		sb.WriteByte('(')
+		for i, ring := range g.Rings {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			writeWKTPoints(sb, ring, swap)
+		}
+		sb.WriteByte(')')
+	default:
+		if len(g.Geoms) == 0 {
+			sb.WriteString(" EMPTY")
+			return
+		}
+		// Members of multi geometries are written without their names.
+		collection := g.Type == types.GeometryTypeGeometryCollection
+		sb.WriteByte('(')
+		for i, m := range g.Geoms {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			m.writeWKT(sb, swap, collection)
+		}
+		sb.WriteByte(')')
+	}
+}
+
+const (
+	wkbBigEndian    = 0
+	wkbLittleEndian = 1
+)
+
+func appendWKBPoints(buf []byte, points []GeometryPoint, swap bool, withLen bool) []byte {
+	if withLen {
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(points)))
+	}
+	for _, p := range points {
+		x, y := p.X, p.Y
+		if swap {
+			x, y = y, x
+		}
+		buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(x))
+		buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(y))
+	}
+	return buf
+}
+
+func (g *Geometry) appendWKB(buf []byte, swap bool) []byte {
+	buf = append(buf, wkbLittleEndian)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(g.Type))
+	switch g.Type {
+	case types.GeometryTypePoint:
+		buf = appendWKBPoints(buf, g.Points, swap, false)
+	case types.GeometryTypeLineString:
+		buf = appendWKBPoints(buf, g.Points, swap, true)
+	case types.GeometryTypePolygon:
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(g.Rings)))
+		for _, ring := range g.Rings {
+			buf = appendWKBPoints(buf, ring, swap, true)
+		}
+	default:
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(g.Geoms)))
+		for _, m := range g.Geoms {
+			buf = m.appendWKB(buf, swap)
+		}
+	}
+	return buf
+}
+
+type wkbReader struct {
+	b     []byte
+	order binary.ByteOrder
+}
+
+func (r *wkbReader) uint32() (uint32, error) {
+	if len(r.b) < 4 {
+		return 0, errInvalidGeometry
+	}
+	v := r.order.Uint32(r.b)
+	r.b = r.b[4:]
+	return v, nil
+}
+
+// count reads the number of elements which take at least size bytes each.
+func (r *wkbReader) count(size int) (int, error) {
+	n, err := r.uint32()
+	if err != nil || uint64(n)*uint64(size) > uint64(len(r.b)) {
+		return 0, errInvalidGeometry
+	}
+	return int(n), nil
+}
+
+func (r *wkbReader) points(n int) ([]GeometryPoint, error) {
+	if len(r.b) < n*16 {
+		return nil, errInvalidGeometry
+	}
+	points := make([]GeometryPoint, n)
+	for i := range points {
+		points[i].X = math.Float64frombits(r.order.Uint64(r.b))
+		points[i].Y = math.Float64frombits(r.order.Uint64(r.b[8:]))
+		r.b = r.b[16:]
+	}
+	return points, nil
+}
+
+// header reads the byte order and the type of a geometry.
+func (r *wkbReader) header() (types.GeometryType, error) {
+	if len(r.b) < 5 {
+		return 0, errInvalidGeometry
+	}
+	switch r.b[0] {
+	case wkbBigEndian:
+		r.order = binary.BigEndian
+	case wkbLittleEndian:
+		r.order = binary.LittleEndian
+	default:
+		return 0, errInvalidGeometry
+	}
+	r.b = r.b[1:]
+	tp, _ := r.uint32()
+	if tp < uint32(types.GeometryTypePoint) || tp > uint32(types.GeometryTypeGeometryCollection) {
+		return 0, errInvalidGeometry
+	}
+	return types.GeometryType(tp), nil
+}
+
+func (r *wkbReader) geometry() (*Geometry, error) {
+	tp, err := r.header()
+	if err != nil {
+		return nil, err
+	}
+	g := &Geometry{Type: tp}
+	switch g.Type {
+	case types.GeometryTypePoint:
+		g.Points, err = r.points(1)
+	case types.GeometryTypeLineString:
+		var n int
+		if n, err = r.count(16); err == nil {
+			g.Points, err = r.points(n)
+		}
+	case types.GeometryTypePolygon:
+		var n int
+		if n, err = r.count(4); err != nil {
+			return nil, err
+		}
+		g.Rings = make([][]GeometryPoint, n)
+		for i := range g.Rings {
+			var m int
+			if m, err = r.count(16); err != nil {
+				return nil, err
+			}
+			if g.Rings[i], err = r.points(m); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		var n int
+		if n, err = r.count(5); err != nil {
+			return nil, err
+		}
+		g.Geoms = make([]*Geometry, n)
+		for i := range g.Geoms {
+			if g.Geoms[i], err = r.geometry(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return g, err
+}
+
+// extendMBR extends mbr by the next n points.
+func (r *wkbReader) extendMBR(mbr *GeometryMBR, n int) error {
+	if len(r.b) < n*16 {
+		return errInvalidGeometry
+	}
+	for range n {
+		x := math.Float64frombits(r.order.Uint64(r.b))
+		y := math.Float64frombits(r.order.Uint64(r.b[8:]))
+		mbr.MinX, mbr.MaxX = math.Min(mbr.MinX, x), math.Max(mbr.MaxX, x)
+		mbr.MinY, mbr.MaxY = math.Min(mbr.MinY, y), math.Max(mbr.MaxY, y)
+		r.b = r.b[16:]
+	}
+	return nil
+}
+
+// mbr extends mbr by the next geometry without decoding it.
+func (r *wkbReader) mbr(mbr *GeometryMBR) error {
+	tp, err := r.header()
+	if err != nil {
+		return err
+	}
+	switch tp {
+	case types.GeometryTypePoint:
+		return r.extendMBR(mbr, 1)
+	case types.GeometryTypeLineString:
+		n, err := r.count(16)
+		if err != nil {
+			return err
+		}
+		return r.extendMBR(mbr, n)
+	case types.GeometryTypePolygon:
+		n, err := r.count(4)
+		if err != nil {
+			return err
+		}
+		for range n {
+			m, err := r.count(16)
+			if err != nil {
+				return err
+			}
+			if err = r.extendMBR(mbr, m); err != nil {
+				return err
+			}
+		}
+	default:
+		n, err := r.count(5)
+		if err != nil {
+			return err
+		}
+		for range n {
+			if err = r.mbr(mbr); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func parseWKB(wkb []byte) (*Geometry, error) {
+	r := wkbReader{b: wkb}
+	g, err := r.geometry()
+	if err != nil || len(r.b) != 0 || !g.validate() {
+		return nil, errInvalidGeometry
+	}
+	return g, nil
+}
+
+// Encode encodes the geometry into the storage format.
+func (g *Geometry) Encode() []byte {
+	buf := binary.LittleEndian.AppendUint32(make([]byte, 0, 64), g.SRID)
+	return g.appendWKB(buf, false)
+}
+
+// decodeGeometry decodes a geometry from the storage format.
+func decodeGeometry(b []byte) (*Geometry, error) {
+	if len(b) < 4 {
+		return nil, errInvalidGeometry
+	}
+	srid := binary.LittleEndian.Uint32(b)
+	if CheckGeometrySRID(srid) != nil {
+		return nil, errInvalidGeometry
+	}
+	g, err := parseWKB(b[4:])
+	if err != nil {
+		return nil, err
+	}
+	g.SRID = srid
+	return g, nil
+}
+
+// DecodeGeometry decodes a geometry from the storage format. fn is the name of
+// the function used in the error messages.
+func DecodeGeometry(fn string, b []byte) (*Geometry, error) {
+	g, err := decodeGeometry(b)
+	if err != nil {
+		return nil, ErrGISInvalidData.GenWithStackByArgs(fn)
+	}
+	return g, nil
+}
+
+// DecodeGeometryMBR returns the SRID and the minimum bounding rectangle of a
+// geometry in the storage format. It's much cheaper than DecodeGeometry, so
+// it's used to skip geometries before computing the exact spatial relations.
+// It returns false if the geometry is empty. fn is the name of the function
+// used in the error messages.
+func DecodeGeometryMBR(fn string, b []byte) (srid uint32, mbr GeometryMBR, ok bool, err error) {
+	if len(b) < 4 {
+		return 0, mbr, false, ErrGISInvalidData.GenWithStackByArgs(fn)
+	}
+	mbr = GeometryMBR{MinX: math.Inf(1), MinY: math.Inf(1), MaxX: math.Inf(-1), MaxY: math.Inf(-1)}
+	r := wkbReader{b: b[4:]}
+	if r.mbr(&mbr) != nil || len(r.b) != 0 {
+		return 0, mbr, false, ErrGISInvalidData.GenWithStackByArgs(fn)
+	}
+	return binary.LittleEndian.Uint32(b), mbr, mbr.MinX <= mbr.MaxX, nil
+}
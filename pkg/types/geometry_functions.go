@@ -0,0 +1,480 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"math"
+	"slices"
+
+	"github.com/pingcap/tidb/pkg/parser/types"
+)
+
+// The spatial relations are computed in the plane. For geographic geometries,
+// the plane is the longitude-latitude plane, which is exact for points and a
+// good approximation for small shapes. Only the distance between geographic
+// points is computed on the ellipsoid.
+
+// GeometryMBR is the minimum bounding rectangle of a geometry.
+type GeometryMBR struct {
+	MinX, MinY, MaxX, MaxY float64
+}
+
+// MBR returns the minimum bounding rectangle of the geometry. It returns false
+// if the geometry is empty.
+func (g *Geometry) MBR() (GeometryMBR, bool) {
+	mbr := GeometryMBR{MinX: math.Inf(1), MinY: math.Inf(1), MaxX: math.Inf(-1), MaxY: math.Inf(-1)}
+	g.walkPoints(func(p GeometryPoint) bool {
+		mbr.MinX = math.Min(mbr.MinX, p.X)
+		mbr.MinY = math.Min(mbr.MinY, p.Y)
+		mbr.MaxX = math.Max(mbr.MaxX, p.X)
+		mbr.MaxY = math.Max(mbr.MaxY, p.Y)
+		return true
+	})
+	return mbr, mbr.MinX <= mbr.MaxX
+}
+
+// Intersects checks whether two rectangles share at least one point.
+func (m GeometryMBR) Intersects(o GeometryMBR) bool {
+	return m.MinX <= o.MaxX && o.MinX <= m.MaxX && m.MinY <= o.MaxY && o.MinY <= m.MaxY
+}
+
+// Contains checks whether o is inside m.
+func (m GeometryMBR) Contains(o GeometryMBR) bool {
+	return m.MinX <= o.MinX && o.MaxX <= m.MaxX && m.MinY <= o.MinY && o.MaxY <= m.MaxY
+}
+
+// CheckGeometrySRIDs checks whether the two geometries of a binary spatial
+// function are in the same spatial reference system.
+func CheckGeometrySRIDs(fn string, g1, g2 *Geometry) error {
+	if g1.SRID != g2.SRID {
+		return ErrGISDifferentSRIDs.GenWithStackByArgs(fn, g1.SRID, g2.SRID)
+	}
+	return nil
+}
+
+const (
+	locExterior = iota
+	locBoundary
+	locInterior
+)
+
+// geometryComponents are the basic shapes of a geometry.
+type geometryComponents struct {
+	points   []GeometryPoint
+	lines    [][]GeometryPoint
+	polygons [][][]GeometryPoint
+}
+
+func (c *geometryComponents) collect(g *Geometry) *geometryComponents {
+	switch g.Type {
+	case types.GeometryTypePoint:
+		c.points = append(c.points, g.Points...)
+	case types.GeometryTypeLineString:
+		c.lines = append(c.lines, g.Points)
+	case types.GeometryTypePolygon:
+		c.polygons = append(c.polygons, g.Rings)
+	default:
+		for _, m := range g.Geoms {
+			c.collect(m)
+		}
+	}
+	return c
+}
+
+// segments calls f on every segment of the lines and the polygon rings until f
+// returns false.
+func (c *geometryComponents) segments(f func(a, b GeometryPoint) bool) bool {
+	walk := func(points []GeometryPoint) bool {
+		for i := 1; i < len(points); i++ {
+			if !f(points[i-1], points[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	for _, line := range c.lines {
+		if !walk(line) {
+			return false
+		}
+	}
+	for _, polygon := range c.polygons {
+		for _, ring := range polygon {
+			if !walk(ring) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// vertices returns the points, and the first point of every line and every
+// polygon. Any shape that intersects a geometry without crossing its segments
+// must have one of these points inside the geometry.
+func (c *geometryComponents) vertices() []GeometryPoint {
+	vertices := slices.Clone(c.points)
+	for _, line := range c.lines {
+		vertices = append(vertices, line[0])
+	}
+	for _, polygon := range c.polygons {
+		vertices = append(vertices, polygon[0][0])
+	}
+	return vertices
+}
+
+// locate returns where p is relative to the geometry.
+func (c *geometryComponents) locate(p GeometryPoint) int {
+	loc := locExterior
+	for _, q := range c.points {
+		if p == q {
+			return locInterior
+		}
+	}
+	for _, line := range c.lines {
+		for i := 1; i < len(line); i++ {
+			if !onSegment(p, line[i-1], line[i]) {
+				continue
+			}
+			closed := line[0] == line[len(line)-1]
+			if !closed && (p == line[0] || p == line[len(line)-1]) {
+				loc = locBoundary
+			} else {
+				return locInterior
+			}
+		}
+	}
+	for _, polygon := range c.polygons {
+		switch locateInPolygon(p, polygon) {
+		case locInterior:
+			return locInterior
+		case locBoundary:
+			loc = locBoundary
+		}
+	}
+	return loc
+}
+
+func orientation(a, b, c GeometryPoint) float64 {
+	return (b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)
+}
+
+func onSegment(p, a, b GeometryPoint) bool {
+	return orientation(a, b, p) == 0 &&
+		math.Min(a.X, b.X) <= p.X && p.X <= math.Max(a.X, b.X) &&
+		math.Min(a.Y, b.Y) <= p.Y && p.Y <= math.Max(a.Y, b.Y)
+}
+
+func sign(f float64) int {
+	if f > 0 {
+		return 1
+	} else if f < 0 {
+		return -1
+	}
+	return 0
+}
+
+// segmentsIntersect checks whether segments ab and cd share at least one point.
+func segmentsIntersect(a, b, c, d GeometryPoint) bool {
+	o1, o2 := sign(orientation(a, b, c)), sign(orientation(a, b, d))
+	o3, o4 := sign(orientation(c, d, a)), sign(orientation(c, d, b))
+	if o1*o2 < 0 && o3*o4 < 0 {
+		return true
+	}
+	return onSegment(c, a, b) || onSegment(d, a, b) || onSegment(a, c, d) || onSegment(b, c, d)
+}
+
+// locateInRing returns where p is relative to the area enclosed by the ring.
+func locateInRing(p GeometryPoint, ring []GeometryPoint) int {
+	inside := false
+	for i := 1; i < len(ring); i++ {
+		a, b := ring[i-1], ring[i]
+		if onSegment(p, a, b) {
+			return locBoundary
+		}
+		if (a.Y > p.Y) != (b.Y > p.Y) && p.X < (b.X-a.X)*(p.Y-a.Y)/(b.Y-a.Y)+a.X {
+			inside = !inside
+		}
+	}
+	if inside {
+		return locInterior
+	}
+	return locExterior
+}
+
+func locateInPolygon(p GeometryPoint, rings [][]GeometryPoint) int {
+	loc := locateInRing(p, rings[0])
+	if loc != locInterior {
+		return loc
+	}
+	for _, hole := range rings[1:] {
+		switch locateInRing(p, hole) {
+		case locInterior:
+			return locExterior
+		case locBoundary:
+			return locBoundary
+		}
+	}
+	return locInterior
+}
+
+// GeometryIntersects checks whether two geometries share at least one point.
+// Both geometries must be in the same spatial reference system.
+func GeometryIntersects(g1, g2 *Geometry) bool {
+	mbr1, ok1 := g1.MBR()
+	mbr2, ok2 := g2.MBR()
+	if !ok1 || !ok2 || !mbr1.Intersects(mbr2) {
+		return false
+	}
+	c1 := new(geometryComponents).collect(g1)
+	c2 := new(geometryComponents).collect(g2)
+	return componentsIntersect(c1, c2)
+}
+
+func componentsIntersect(c1, c2 *geometryComponents) bool {
+	for _, p := range c2.vertices() {
+		if c1.locate(p) != locExterior {
+			return true
+		}
+	}
+	for _, p := range c1.vertices() {
+		if c2.locate(p) != locExterior {
+			return true
+		}
+	}
+	intersects := false
+	c1.segments(func(a, b GeometryPoint) bool {
+		c2.segments(func(c, d GeometryPoint) bool {
+			intersects = segmentsIntersect(a, b, c, d)
+			return !intersects
+		})
+		return !intersects
+	})
+	return intersects
+}
+
+// GeometryContains checks whether g2 is inside g1 and the interiors of them
+// share at least one point. Both geometries must be in the same spatial
+// reference system.
+func GeometryContains(g1, g2 *Geometry) bool {
+	mbr1, ok1 := g1.MBR()
+	mbr2, ok2 := g2.MBR()
+	if !ok1 || !ok2 || !mbr1.Contains(mbr2) {
+		return false
+	}
+	c1 := new(geometryComponents).collect(g1)
+	c2 := new(geometryComponents).collect(g2)
+	interior := false
+	for _, p := range c2.points {
+		switch c1.locate(p) {
+		case locExterior:
+			return false
+		case locInterior:
+			interior = true
+		}
+	}
+	// A segment is covered if every piece of it between the points where it
+	// meets the boundary of g1 is covered.
+	covered := c2.segments(func(a, b GeometryPoint) bool {
+		for _, p := range [2]GeometryPoint{a, b} {
+			if c1.locate(p) == locExterior {
+				return false
+			}
+		}
+		params := append(make([]float64, 0, 8), 0, 1)
+		c1.segments(func(c, d GeometryPoint) bool {
+			params = appendSplitParams(params, a, b, c, d)
+			return true
+		})
+		for _, p := range c1.points {
+			if onSegment(p, a, b) {
+				params = append(params, segmentParam(p, a, b))
+			}
+		}
+		slices.Sort(params)
+		for i := 1; i < len(params); i++ {
+			if params[i] == params[i-1] {
+				continue
+			}
+			t := (params[i-1] + params[i]) / 2
+			switch c1.locate(GeometryPoint{X: a.X + t*(b.X-a.X), Y: a.Y + t*(b.Y-a.Y)}) {
+			case locExterior:
+				return false
+			case locInterior:
+				interior = true
+			}
+		}
+		return true
+	})
+	if !covered {
+		return false
+	}
+	if len(c2.polygons) > 0 {
+		// The boundaries of the polygons are covered, so the polygons are
+		// covered unless a hole or another boundary of g1 is inside them.
+		if len(c1.polygons) == 0 {
+			return false
+		}
+		for _, polygon := range c2.polygons {
+			for _, other := range c1.polygons {
+				for _, ring := range other {
+					for _, p := range ring {
+						if locateInPolygon(p, polygon) == locInterior {
+							return false
+						}
+					}
+				}
+			}
+		}
+		interior = true
+	}
+	return interior
+}
+
+// segmentParam returns t where p = a + t * (b - a) for a point p on segment ab.
+func segmentParam(p, a, b GeometryPoint) float64 {
+	if a == b {
+		return 0
+	}
+	if math.Abs(b.X-a.X) >= math.Abs(b.Y-a.Y) {
+		return (p.X - a.X) / (b.X - a.X)
+	}
+	return (p.Y - a.Y) / (b.Y - a.Y)
+}
+
+// appendSplitParams appends the positions on segment ab where it meets
+// segment cd.
+func appendSplitParams(params []float64, a, b, c, d GeometryPoint) []float64 {
+	if a == b {
+		return params
+	}
+	for _, p := range [2]GeometryPoint{c, d} {
+		if onSegment(p, a, b) {
+			params = append(params, segmentParam(p, a, b))
+		}
+	}
+	o1, o2 := sign(orientation(a, b, c)), sign(orientation(a, b, d))
+	o3, o4 := sign(orientation(c, d, a)), sign(orientation(c, d, b))
+	if o1*o2 < 0 && o3*o4 < 0 {
+		// The segments cross at a single point.
+		denom := (b.X-a.X)*(d.Y-c.Y) - (b.Y-a.Y)*(d.X-c.X)
+		params = append(params, ((c.X-a.X)*(d.Y-c.Y)-(c.Y-a.Y)*(d.X-c.X))/denom)
+	}
+	return params
+}
+
+// GeometryDistance returns the minimum distance between two geometries. For
+// geographic geometries, only points and multipoints are supported and the
+// distance is in meters. Both geometries must be in the same spatial reference
+// system and not be empty.
+func GeometryDistance(fn string, g1, g2 *Geometry) (float64, error) {
+	c1 := new(geometryComponents).collect(g1)
+	c2 := new(geometryComponents).collect(g2)
+	if g1.IsGeographic() {
+		if len(c1.lines)+len(c1.polygons)+len(c2.lines)+len(c2.polygons) > 0 {
+			return 0, ErrNotImplementedForGeographicSRS.GenWithStackByArgs(fn, g1.TypeName()+", "+g2.TypeName())
+		}
+		dist := math.Inf(1)
+		for _, p := range c1.points {
+			for _, q := range c2.points {
+				dist = math.Min(dist, geodesicDistance(p, q))
+			}
+		}
+		return dist, nil
+	}
+	if componentsIntersect(c1, c2) {
+		return 0, nil
+	}
+	dist := math.Inf(1)
+	for _, c := range [2][2]*geometryComponents{{c1, c2}, {c2, c1}} {
+		for _, p := range c[0].points {
+			for _, q := range c[1].points {
+				dist = math.Min(dist, math.Hypot(p.X-q.X, p.Y-q.Y))
+			}
+			c[1].segments(func(a, b GeometryPoint) bool {
+				dist = math.Min(dist, pointSegmentDistance(p, a, b))
+				return true
+			})
+		}
+	}
+	// The segments don't intersect, so the distance between two segments is
+	// the distance from one of the endpoints to the other segment.
+	c1.segments(func(a, b GeometryPoint) bool {
+		c2.segments(func(c, d GeometryPoint) bool {
+			dist = math.Min(dist, math.Min(
+				math.Min(pointSegmentDistance(a, c, d), pointSegmentDistance(b, c, d)),
+				math.Min(pointSegmentDistance(c, a, b), pointSegmentDistance(d, a, b))))
+			return true
+		})
+		return true
+	})
+	return dist, nil
+}
+
+func pointSegmentDistance(p, a, b GeometryPoint) float64 {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	t := 0.0
+	if l := dx*dx + dy*dy; l > 0 {
+		t = math.Max(0, math.Min(1, ((p.X-a.X)*dx+(p.Y-a.Y)*dy)/l))
+	}
+	return math.Hypot(p.X-(a.X+t*dx), p.Y-(a.Y+t*dy))
+}
+
+// geodesicDistance returns the distance in meters between two points on the
+// WGS 84 ellipsoid, computed by the inverse formula of Vincenty.
+func geodesicDistance(p, q GeometryPoint) float64 {
+	const (
+		a = 6378137.0
+		f = 1 / 298.257223563
+		b = a * (1 - f)
+	)
+	if p == q {
+		return 0
+	}
+	rad := math.Pi / 180
+	l := (q.X - p.X) * rad
+	u1 := math.Atan((1 - f) * math.Tan(p.Y*rad))
+	u2 := math.Atan((1 - f) * math.Tan(q.Y*rad))
+	sinU1, cosU1 := math.Sincos(u1)
+	sinU2, cosU2 := math.Sincos(u2)
+
+	var sinSigma, cosSigma, sigma, cosSqAlpha, cos2SigmaM float64
+	lambda := l
+	for range 200 {
+		sinLambda, cosLambda := math.Sincos(lambda)
+		sinSigma = math.Hypot(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+		if sinSigma == 0 {
+			return 0
+		}
+		cosSigma = sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma = math.Atan2(sinSigma, cosSigma)
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cosSqAlpha = 1 - sinAlpha*sinAlpha
+		cos2SigmaM = 0
+		if cosSqAlpha != 0 {
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cosSqAlpha
+		}
+		c := f / 16 * cosSqAlpha * (4 + f*(4-3*cosSqAlpha))
+		prev := lambda
+		lambda = l + (1-c)*f*sinAlpha*(sigma+c*sinSigma*(cos2SigmaM+c*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+		if math.Abs(lambda-prev) < 1e-12 {
+			break
+		}
+	}
+	uSq := cosSqAlpha * (a*a - b*b) / (b * b)
+	bigA := 1 + uSq/16384*(4096+uSq*(-768+uSq*(320-175*uSq)))
+	bigB := uSq / 1024 * (256 + uSq*(-128+uSq*(74-47*uSq)))
+	deltaSigma := bigB * sinSigma * (cos2SigmaM + bigB/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+		bigB/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+	return b * bigA * (sigma - deltaSigma)
+}
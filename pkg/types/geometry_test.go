@@ -0,0 +1,206 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func mustParseGeometry(t *testing.T, wkt string, srid uint32) *types.Geometry {
+	g, err := types.ParseGeometryFromWKT("st_geomfromtext", wkt, srid)
+	require.NoError(t, err, wkt)
+	return g
+}
+
+func TestGeometryWKT(t *testing.T) {
+	tests := []struct {
+		wkt    string
+		result string
+	}{
+		{"POINT(1 2)", "POINT(1 2)"},
+		{" point ( -1.5   2e3 ) ", "POINT(-1.5 2000)"},
+		{"POINT(1e20 0)", "POINT(1e20 0)"},
+		{"LINESTRING(0 0,1 1,2 0)", "LINESTRING(0 0,1 1,2 0)"},
+		{"POLYGON((0 0,4 0,4 4,0 4,0 0),(1 1,2 1,2 2,1 1))", "POLYGON((0 0,4 0,4 4,0 4,0 0),(1 1,2 1,2 2,1 1))"},
+		{"MULTIPOINT(1 1,2 2)", "MULTIPOINT((1 1),(2 2))"},
+		{"MULTIPOINT((1 1),(2 2))", "MULTIPOINT((1 1),(2 2))"},
+		{"MULTILINESTRING((0 0,1 1),(2 2,3 3))", "MULTILINESTRING((0 0,1 1),(2 2,3 3))"},
+		{"MULTIPOLYGON(((0 0,1 0,1 1,0 0)),((2 2,3 2,3 3,2 2)))", "MULTIPOLYGON(((0 0,1 0,1 1,0 0)),((2 2,3 2,3 3,2 2)))"},
+		{"GEOMETRYCOLLECTION(POINT(1 1),LINESTRING(0 0,1 1))", "GEOMETRYCOLLECTION(POINT(1 1),LINESTRING(0 0,1 1))"},
+		{"GEOMCOLLECTION(POINT(1 1))", "GEOMETRYCOLLECTION(POINT(1 1))"},
+		{"GEOMETRYCOLLECTION EMPTY", "GEOMETRYCOLLECTION EMPTY"},
+		{"GEOMETRYCOLLECTION()", "GEOMETRYCOLLECTION EMPTY"},
+	}
+	for _, tt := range tests {
+		g := mustParseGeometry(t, tt.wkt, types.SRIDCartesian)
+		require.Equal(t, tt.result, g.WKT(), tt.wkt)
+
+		decoded, err := types.DecodeGeometry("st_astext", g.Encode())
+		require.NoError(t, err, tt.wkt)
+		require.Equal(t, tt.result, decoded.WKT(), tt.wkt)
+	}
+
+	for _, wkt := range []string{
+		"",
+		"POINT",
+		"POINT(1)",
+		"POINT(1 2",
+		"POINT(1 2) x",
+		"POINT(a b)",
+		"LINESTRING(0 0)",
+		"POLYGON((0 0,1 0,0 0))",
+		"POLYGON((0 0,1 0,1 1,0 1))",
+		"CIRCLE(0 0)",
+	} {
+		_, err := types.ParseGeometryFromWKT("st_geomfromtext", wkt, types.SRIDCartesian)
+		require.True(t, types.ErrGISInvalidData.Equal(err), wkt)
+	}
+
+	_, err := types.ParseGeometryFromWKT("st_geomfromtext", "POINT(1 1)", 1234)
+	require.True(t, types.ErrSRSNotFound.Equal(err))
+}
+
+func TestGeometryGeographic(t *testing.T) {
+	// WKT of geographic spatial reference systems is in latitude-longitude order,
+	// but the coordinates are stored as longitude-latitude.
+	g := mustParseGeometry(t, "POINT(30 120)", types.SRIDWGS84)
+	require.Equal(t, []types.GeometryPoint{{X: 120, Y: 30}}, g.Points)
+	require.Equal(t, "POINT(30 120)", g.WKT())
+	decoded, err := types.DecodeGeometry("st_astext", g.Encode())
+	require.NoError(t, err)
+	require.Equal(t, types.SRIDWGS84, decoded.SRID)
+	require.Equal(t, "POINT(30 120)", decoded.WKT())
+
+	_, err = types.ParseGeometryFromWKT("st_geomfromtext", "POINT(91 0)", types.SRIDWGS84)
+	require.True(t, types.ErrLatitudeOutOfRange.Equal(err))
+	_, err = types.ParseGeometryFromWKT("st_geomfromtext", "POINT(0 181)", types.SRIDWGS84)
+	require.True(t, types.ErrLongitudeOutOfRange.Equal(err))
+}
+
+func TestGeometryDecodeInvalid(t *testing.T) {
+	g := mustParseGeometry(t, "LINESTRING(0 0,1 1)", types.SRIDCartesian)
+	b := g.Encode()
+	for _, invalid := range [][]byte{nil, b[:4], b[:len(b)-1], append(b, 0)} {
+		_, err := types.DecodeGeometry("st_astext", invalid)
+		require.True(t, types.ErrGISInvalidData.Equal(err))
+		_, _, _, err = types.DecodeGeometryMBR("st_contains", invalid)
+		require.True(t, types.ErrGISInvalidData.Equal(err))
+	}
+}
+
+func TestGeometryMBR(t *testing.T) {
+	g := mustParseGeometry(t, "GEOMETRYCOLLECTION(POINT(-1 5),LINESTRING(0 0,3 -2))", types.SRIDCartesian)
+	mbr, ok := g.MBR()
+	require.True(t, ok)
+	require.Equal(t, types.GeometryMBR{MinX: -1, MinY: -2, MaxX: 3, MaxY: 5}, mbr)
+
+	srid, decodedMBR, ok, err := types.DecodeGeometryMBR("st_contains", g.Encode())
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, types.SRIDCartesian, srid)
+	require.Equal(t, mbr, decodedMBR)
+
+	empty := mustParseGeometry(t, "GEOMETRYCOLLECTION EMPTY", types.SRIDCartesian)
+	_, ok = empty.MBR()
+	require.False(t, ok)
+	_, _, ok, err = types.DecodeGeometryMBR("st_contains", empty.Encode())
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.True(t, mbr.Contains(types.GeometryMBR{MinX: 0, MinY: 0, MaxX: 1, MaxY: 1}))
+	require.False(t, mbr.Contains(types.GeometryMBR{MinX: 0, MinY: 0, MaxX: 4, MaxY: 1}))
+	require.True(t, mbr.Intersects(types.GeometryMBR{MinX: 3, MinY: 5, MaxX: 4, MaxY: 6}))
+	require.False(t, mbr.Intersects(types.GeometryMBR{MinX: 3.5, MinY: 5, MaxX: 4, MaxY: 6}))
+}
+
+func TestGeometryRelations(t *testing.T) {
+	square := "POLYGON((0 0,4 0,4 4,0 4,0 0))"
+	holed := "POLYGON((0 0,4 0,4 4,0 4,0 0),(1 1,3 1,3 3,1 3,1 1))"
+	tests := []struct {
+		g1, g2     string
+		contains   bool
+		intersects bool
+	}{
+		{square, "POINT(2 2)", true, true},
+		{square, "POINT(0 2)", false, true},
+		{square, "POINT(5 5)", false, false},
+		{square, "LINESTRING(1 1,3 3)", true, true},
+		{square, "LINESTRING(0 0,4 0)", false, true},
+		{square, "LINESTRING(1 1,5 5)", false, true},
+		{square, "POLYGON((1 1,2 1,2 2,1 1))", true, true},
+		{square, square, true, true},
+		{square, "POLYGON((4 0,8 0,8 4,4 4,4 0))", false, true},
+		{square, "POLYGON((5 5,6 5,6 6,5 5))", false, false},
+		{holed, "POINT(2 2)", false, false},
+		{holed, "POINT(0.5 0.5)", true, true},
+		{holed, "LINESTRING(0.5 0.5,3.5 3.5)", false, true},
+		{"POLYGON((0 0,4 0,4 4,2 1,0 4,0 0))", "LINESTRING(0 3,4 3)", false, true},
+		{"LINESTRING(0 0,4 4)", "POINT(2 2)", true, true},
+		{"LINESTRING(0 0,4 4)", "POINT(0 0)", false, true},
+		{"LINESTRING(0 0,4 4)", "LINESTRING(1 1,2 2)", true, true},
+		{"LINESTRING(0 0,4 4)", "LINESTRING(0 4,4 0)", false, true},
+		{"MULTIPOINT(1 1,2 2)", "POINT(2 2)", true, true},
+		{"MULTIPOLYGON(((0 0,1 0,1 1,0 0)),((2 2,3 2,3 3,2 2)))", "POINT(2.5 2.2)", true, true},
+	}
+	for _, tt := range tests {
+		g1 := mustParseGeometry(t, tt.g1, types.SRIDCartesian)
+		g2 := mustParseGeometry(t, tt.g2, types.SRIDCartesian)
+		require.Equal(t, tt.contains, types.GeometryContains(g1, g2), "%s contains %s", tt.g1, tt.g2)
+		require.Equal(t, tt.intersects, types.GeometryIntersects(g1, g2), "%s intersects %s", tt.g1, tt.g2)
+		require.Equal(t, tt.intersects, types.GeometryIntersects(g2, g1), "%s intersects %s", tt.g2, tt.g1)
+	}
+
+	g1 := mustParseGeometry(t, "POINT(1 1)", types.SRIDCartesian)
+	g2 := mustParseGeometry(t, "POINT(1 1)", types.SRIDWGS84)
+	err := types.CheckGeometrySRIDs("st_contains", g1, g2)
+	require.True(t, types.ErrGISDifferentSRIDs.Equal(err))
+}
+
+func TestGeometryDistance(t *testing.T) {
+	tests := []struct {
+		g1, g2   string
+		distance float64
+	}{
+		{"POINT(0 0)", "POINT(3 4)", 5},
+		{"POINT(0 2)", "LINESTRING(-1 0,1 0)", 2},
+		{"POINT(3 2)", "LINESTRING(-1 0,1 0)", 2.8284271247461903},
+		{"POINT(2 2)", "POLYGON((0 0,4 0,4 4,0 4,0 0))", 0},
+		{"POINT(6 2)", "POLYGON((0 0,4 0,4 4,0 4,0 0))", 2},
+		{"LINESTRING(0 0,4 4)", "LINESTRING(0 4,4 0)", 0},
+		{"MULTIPOINT(10 10,1 0)", "LINESTRING(0 0,0 5)", 1},
+	}
+	for _, tt := range tests {
+		g1 := mustParseGeometry(t, tt.g1, types.SRIDCartesian)
+		g2 := mustParseGeometry(t, tt.g2, types.SRIDCartesian)
+		d, err := types.GeometryDistance("st_distance", g1, g2)
+		require.NoError(t, err)
+		require.InDelta(t, tt.distance, d, 1e-9, "%s %s", tt.g1, tt.g2)
+	}
+
+	// The geodesic distance on WGS 84 between two points on the equator one
+	// degree apart.
+	g1 := mustParseGeometry(t, "POINT(0 0)", types.SRIDWGS84)
+	g2 := mustParseGeometry(t, "POINT(0 1)", types.SRIDWGS84)
+	d, err := types.GeometryDistance("st_distance", g1, g2)
+	require.NoError(t, err)
+	require.InDelta(t, 111319.49079327357, d, 1e-3)
+
+	line := mustParseGeometry(t, "LINESTRING(0 0,1 1)", types.SRIDWGS84)
+	_, err = types.GeometryDistance("st_distance", g1, line)
+	require.True(t, types.ErrNotImplementedForGeographicSRS.Equal(err))
+}
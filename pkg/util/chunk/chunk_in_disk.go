@@ -73,7 +73,7 @@ func (d *DataInDiskByChunks) initDiskFile() (err error) {
 	if err != nil {
 		return
 	}
-	err = d.dataFile.initWithFileName(defaultChunkDataInDiskByChunksPath + strconv.Itoa(d.diskTracker.Label()))
+	err = d.dataFile.initWithFileName(defaultChunkDataInDiskByChunksPath+strconv.Itoa(d.diskTracker.Label()), spillCompressionAlgorithm())
 	return
 }
 
@@ -101,6 +101,7 @@ func (d *DataInDiskByChunks) Add(chk *Chunk) (err error) {
 	}
 
 	serializedBytesNum := d.serializeDataToBuf(chk)
+	compressedBefore := d.dataFile.compressedSize()
 
 	var writeNum int
 	writeNum, err = d.dataFile.write(d.buf)
@@ -116,7 +117,7 @@ func (d *DataInDiskByChunks) Add(chk *Chunk) (err error) {
 	d.totalRowNum += int64(chk.NumRows())
 	d.dataFile.offWrite += serializedBytesNum
 
-	d.diskTracker.Consume(serializedBytesNum)
+	d.diskTracker.Consume(d.dataFile.diskUsage(serializedBytesNum, compressedBefore))
 	return
 }
 
@@ -185,6 +186,7 @@ func (d *DataInDiskByChunks) FillChunk(srcChkIdx int, destChk *Chunk) error {
 // Close releases the disk resource.
 func (d *DataInDiskByChunks) Close() {
 	if d.dataFile.file != nil {
+		d.dataFile.recordCompressionMetrics()
 		d.diskTracker.Consume(-d.diskTracker.BytesConsumed())
 		terror.Call(d.dataFile.file.Close)
 		terror.Log(os.Remove(d.dataFile.file.Name()))
@@ -18,6 +18,7 @@ import (
 	"math/rand"
 	"testing"
 
+	"github.com/pingcap/tidb/pkg/sessionctx/vardef"
 	"github.com/stretchr/testify/require"
 )
 
@@ -104,3 +105,22 @@ func TestDataInDiskByChunks(t *testing.T) {
 	testGetChunk(t)
 	testFillChunk(t)
 }
+
+func TestDataInDiskByChunksWithCompression(t *testing.T) {
+	defer vardef.SpillCompression.Store(vardef.DefTiDBSpillCompression)
+	for _, algorithm := range []string{"lz4", "zstd"} {
+		vardef.SpillCompression.Store(algorithm)
+		testGetChunk(t)
+		testFillChunk(t)
+
+		chks, fields := initChunks(10, 1000)
+		dataInDiskByChunks := NewDataInDiskByChunks(fields)
+		for _, chk := range chks {
+			require.NoError(t, dataInDiskByChunks.Add(chk))
+		}
+		// The disk tracker records the compressed size.
+		require.Less(t, dataInDiskByChunks.GetDiskTracker().BytesConsumed(), dataInDiskByChunks.GetTotalBytesInDisk()/2)
+		dataInDiskByChunks.Close()
+		require.Zero(t, dataInDiskByChunks.GetDiskTracker().BytesConsumed())
+	}
+}
@@ -21,7 +21,10 @@ import (
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/pkg/config"
+	"github.com/pingcap/tidb/pkg/metrics"
+	"github.com/pingcap/tidb/pkg/sessionctx/vardef"
 	"github.com/pingcap/tidb/pkg/util/checksum"
+	"github.com/pingcap/tidb/pkg/util/compress"
 	"github.com/pingcap/tidb/pkg/util/disjointset"
 	"github.com/pingcap/tidb/pkg/util/encrypt"
 	"github.com/pingcap/tidb/pkg/util/intest"
@@ -232,13 +235,20 @@ type diskFileReaderWriter struct {
 	offWrite int64
 
 	checksumWriter *checksum.Writer
-	cipherWriter   *encrypt.Writer // cipherWriter is only enable when config SpilledFileEncryptionMethod is "aes128-ctr"
+	cipherWriter   *encrypt.Writer  // cipherWriter is only enable when config SpilledFileEncryptionMethod is "aes128-ctr"
+	compressWriter *compress.Writer // compressWriter is only enable when the file is initialized with a compression algorithm
 
 	// ctrCipher stores the key and nonce using by aes encrypt io layer
 	ctrCipher *encrypt.CtrCipher
 }
 
-func (l *diskFileReaderWriter) initWithFileName(fileName string) (err error) {
+// spillCompressionAlgorithm returns the algorithm to compress the spilled data, it's controlled by
+// the global variable `tidb_spill_compression`.
+func spillCompressionAlgorithm() compress.Algorithm {
+	return compress.ParseAlgorithm(vardef.SpillCompression.Load())
+}
+
+func (l *diskFileReaderWriter) initWithFileName(fileName string, algorithm compress.Algorithm) (err error) {
 	// `os.CreateTemp` will insert random string so that a random file name will be generated.
 	l.file, err = os.CreateTemp(config.GetGlobalConfig().TempStoragePath, fileName)
 	if err != nil {
@@ -256,6 +266,12 @@ func (l *diskFileReaderWriter) initWithFileName(fileName string) (err error) {
 	}
 	l.checksumWriter = checksum.NewWriter(underlying)
 	l.writer = l.checksumWriter
+	if algorithm != compress.AlgorithmNone {
+		// The data is compressed before being checksummed and encrypted, because the
+		// encrypted data can hardly be compressed.
+		l.compressWriter = compress.NewWriter(l.checksumWriter, algorithm)
+		l.writer = l.compressWriter
+	}
 	l.offWrite = 0
 	return
 }
@@ -268,6 +284,9 @@ func (l *diskFileReaderWriter) getReader() io.ReaderAt {
 	if l.checksumWriter != nil {
 		underlying = NewReaderWithCache(checksum.NewReader(underlying), l.checksumWriter.GetCache(), l.checksumWriter.GetCacheDataOffset())
 	}
+	if l.compressWriter != nil {
+		underlying = NewReaderWithCache(compress.NewReader(underlying, l.compressWriter.Algorithm(), l.compressWriter.BlockOffsets()), l.compressWriter.GetCache(), l.compressWriter.GetCacheDataOffset())
+	}
 	return underlying
 }
 
@@ -287,6 +306,34 @@ func (l *diskFileReaderWriter) write(writeData []byte) (n int, err error) {
 	return writeNum, err
 }
 
+// compressedSize returns the number of compressed bytes written to the file, it's 0 if the
+// file is not compressed.
+func (l *diskFileReaderWriter) compressedSize() int64 {
+	if l.compressWriter == nil {
+		return 0
+	}
+	return l.compressWriter.CompressedSize()
+}
+
+// diskUsage returns the disk usage of the rawBytes just written to the file. compressedBefore is
+// the compressedSize before writing them. The data buffered by the compression layer is counted
+// when its block is flushed.
+func (l *diskFileReaderWriter) diskUsage(rawBytes, compressedBefore int64) int64 {
+	if l.compressWriter == nil {
+		return rawBytes
+	}
+	return l.compressWriter.CompressedSize() - compressedBefore
+}
+
+// recordCompressionMetrics records the size of the flushed data before and after compression.
+func (l *diskFileReaderWriter) recordCompressionMetrics() {
+	if l.compressWriter == nil {
+		return
+	}
+	metrics.SpillCompressionBytes.WithLabelValues("raw").Add(float64(l.compressWriter.GetCacheDataOffset()))
+	metrics.SpillCompressionBytes.WithLabelValues("compressed").Add(float64(l.compressWriter.CompressedSize()))
+}
+
 // ColumnSwapHelper is used to help swap columns in a chunk.
 type ColumnSwapHelper struct {
 	// InputIdxToOutputIdxes maps the input column index to the output column indexes.
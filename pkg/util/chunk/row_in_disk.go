@@ -23,6 +23,7 @@ import (
 	errors2 "github.com/pingcap/errors"
 	"github.com/pingcap/tidb/pkg/parser/terror"
 	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/compress"
 	"github.com/pingcap/tidb/pkg/util/disk"
 	"github.com/pingcap/tidb/pkg/util/memory"
 )
@@ -58,11 +59,12 @@ func (l *DataInDiskByRows) initDiskFile() (err error) {
 	if err != nil {
 		return
 	}
-	err = l.dataFile.initWithFileName(defaultChunkDataInDiskByRowsPath + strconv.Itoa(l.diskTracker.Label()))
+	err = l.dataFile.initWithFileName(defaultChunkDataInDiskByRowsPath+strconv.Itoa(l.diskTracker.Label()), spillCompressionAlgorithm())
 	if err != nil {
 		return
 	}
-	err = l.offsetFile.initWithFileName(defaultChunkDataInDiskByRowsOffsetPath + strconv.Itoa(l.diskTracker.Label()))
+	// The offsets are read one by one randomly, so they are not compressed.
+	err = l.offsetFile.initWithFileName(defaultChunkDataInDiskByRowsOffsetPath+strconv.Itoa(l.diskTracker.Label()), compress.AlgorithmNone)
 	return
 }
 
@@ -90,6 +92,7 @@ func (l *DataInDiskByRows) Add(chk *Chunk) (err error) {
 		}
 	}
 	// Append data
+	compressedBefore := l.dataFile.compressedSize()
 	chkInDisk := chunkInDisk{Chunk: chk, offWrite: l.dataFile.offWrite}
 	n, err := chkInDisk.WriteTo(l.dataFile.getWriter())
 	l.dataFile.offWrite += n
@@ -107,7 +110,7 @@ func (l *DataInDiskByRows) Add(chk *Chunk) (err error) {
 		return
 	}
 
-	l.diskTracker.Consume(n + n2)
+	l.diskTracker.Consume(l.dataFile.diskUsage(n, compressedBefore) + n2)
 	l.totalNumRows += chk.NumRows()
 	return
 }
@@ -198,6 +201,7 @@ func (l *DataInDiskByRows) NumChunks() int {
 // Close releases the disk resource.
 func (l *DataInDiskByRows) Close() error {
 	if l.dataFile.file != nil {
+		l.dataFile.recordCompressionMetrics()
 		l.diskTracker.Consume(-l.diskTracker.BytesConsumed())
 		terror.Call(l.dataFile.file.Close)
 		terror.Log(os.Remove(l.dataFile.file.Name()))
@@ -28,6 +28,7 @@ import (
 	errors2 "github.com/pingcap/errors"
 	"github.com/pingcap/tidb/pkg/config"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/sessionctx/vardef"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -326,6 +327,34 @@ func TestDataInDiskByRowsWithChecksumReaderWithCacheNoFlush(t *testing.T) {
 	testReaderWithCacheNoFlush(t)
 }
 
+func TestDataInDiskByRowsWithCompression(t *testing.T) {
+	defer vardef.SpillCompression.Store(vardef.DefTiDBSpillCompression)
+	defer config.RestoreFunc()()
+	for _, method := range []string{config.SpilledFileEncryptionMethodPlaintext, config.SpilledFileEncryptionMethodAES128CTR} {
+		config.UpdateGlobal(func(conf *config.Config) {
+			conf.Security.SpilledFileEncryptionMethod = method
+		})
+		for _, algorithm := range []string{"lz4", "zstd"} {
+			vardef.SpillCompression.Store(algorithm)
+			testDataInDiskByRows(t, 2)
+
+			chks, fields := initChunks(10, 1000)
+			l := NewDataInDiskByRows(fields)
+			for _, chk := range chks {
+				require.NoError(t, l.Add(chk))
+			}
+			for i, chk := range chks {
+				restored, err := l.GetChunk(i)
+				require.NoError(t, err)
+				for j := range chk.NumRows() {
+					checkRow(t, restored.GetRow(j), chk.GetRow(j))
+				}
+			}
+			require.NoError(t, l.Close())
+		}
+	}
+}
+
 func TestDataInDiskByRowsWithChecksumAndEncrypt1(t *testing.T) {
 	defer config.RestoreFunc()()
 	config.UpdateGlobal(func(conf *config.Config) {
@@ -0,0 +1,351 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compress
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
+)
+
+const (
+	// the size of the user data compressed as a whole block. A block is the unit of
+	// random access, so it should not be too large to read a single row.
+	blockSize = 32 * 1024
+	// the size of the block header, it stores the payload size and the raw size of a block.
+	blockHeaderSize = 8
+)
+
+// Algorithm is the algorithm used to compress the blocks.
+type Algorithm uint8
+
+const (
+	// AlgorithmNone means the data is not compressed.
+	AlgorithmNone Algorithm = iota
+	// AlgorithmLZ4 compresses the data with lz4.
+	AlgorithmLZ4
+	// AlgorithmZstd compresses the data with zstd.
+	AlgorithmZstd
+)
+
+// String implements the fmt.Stringer interface.
+func (a Algorithm) String() string {
+	switch a {
+	case AlgorithmLZ4:
+		return "lz4"
+	case AlgorithmZstd:
+		return "zstd"
+	default:
+		return "none"
+	}
+}
+
+// ParseAlgorithm parses the name of an algorithm. Unknown names are parsed as AlgorithmNone.
+func ParseAlgorithm(name string) Algorithm {
+	switch strings.ToLower(name) {
+	case "lz4":
+		return AlgorithmLZ4
+	case "zstd":
+		return AlgorithmZstd
+	default:
+		return AlgorithmNone
+	}
+}
+
+var (
+	// zstd.Encoder.EncodeAll and zstd.Decoder.DecodeAll can be called concurrently.
+	getZstdEncoder = sync.OnceValues(func() (*zstd.Encoder, error) {
+		return zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedFastest))
+	})
+	getZstdDecoder = sync.OnceValues(func() (*zstd.Decoder, error) {
+		return zstd.NewReader(nil)
+	})
+
+	errBlockCorrupted = errors.New("compressed block is corrupted")
+)
+
+// Writer implements an io.WriteCloser, it splits the payload into blocks of fixed size and
+// compresses each block independently before writing to the underlying object. The offsets of
+// the blocks are kept in memory, so any offset of the payload can be read by decompressing only
+// the blocks covering it.
+//
+// A block is stored as follow, the raw data is stored as payload directly if it can't be
+// compressed into a smaller size, in which case payload size equals raw size:
+//
+// | --      4B      -- | --    4B    -- | -- payload size -- |
+// | -- payload size -- | -- raw size -- | --   payload    -- |
+type Writer struct {
+	err       error
+	w         io.WriteCloser
+	algorithm Algorithm
+	buf       []byte
+	// compressed is the buffer of the header and the payload of a block.
+	compressed []byte
+	// blockOffsets[i] is the offset of the i-th block in the underlying object, the
+	// last element is the total size written to the underlying object.
+	blockOffsets       []int64
+	flushedUserDataCnt int64
+}
+
+// NewWriter returns a new Writer which compresses the payload with the algorithm before
+// writing to the underlying object.
+func NewWriter(w io.WriteCloser, algorithm Algorithm) *Writer {
+	return &Writer{
+		w:            w,
+		algorithm:    algorithm,
+		buf:          make([]byte, 0, blockSize),
+		blockOffsets: []int64{0},
+	}
+}
+
+// Write implements the io.Writer interface.
+func (w *Writer) Write(p []byte) (n int, err error) {
+	for len(p) > 0 && w.err == nil {
+		copiedNum := min(len(p), blockSize-len(w.buf))
+		w.buf = append(w.buf, p[:copiedNum]...)
+		n += copiedNum
+		p = p[copiedNum:]
+		if len(w.buf) == blockSize {
+			if err = w.flush(); err != nil {
+				return
+			}
+		}
+	}
+	return n, w.err
+}
+
+// flush compresses the buffered data as a block and writes it to the underlying object.
+// Only full blocks can be flushed before Close, otherwise the offset of the payload can't
+// be mapped to the block containing it.
+func (w *Writer) flush() error {
+	if w.err != nil {
+		return w.err
+	}
+	if len(w.buf) == 0 {
+		return nil
+	}
+	block, err := w.compressBlock()
+	if err != nil {
+		w.err = err
+		return err
+	}
+	n, err := w.w.Write(block)
+	if n < len(block) && err == nil {
+		err = io.ErrShortWrite
+	}
+	if err != nil {
+		w.err = err
+		return err
+	}
+	w.blockOffsets = append(w.blockOffsets, w.blockOffsets[len(w.blockOffsets)-1]+int64(len(block)))
+	w.flushedUserDataCnt += int64(len(w.buf))
+	w.buf = w.buf[:0]
+	return nil
+}
+
+func (w *Writer) compressBlock() ([]byte, error) {
+	rawSize := len(w.buf)
+	payloadSize := 0
+	switch w.algorithm {
+	case AlgorithmLZ4:
+		w.compressed = growBuf(w.compressed, blockHeaderSize+lz4.CompressBlockBound(rawSize))
+		n, err := lz4.CompressBlock(w.buf, w.compressed[blockHeaderSize:], nil)
+		if err != nil {
+			return nil, err
+		}
+		payloadSize = n
+	case AlgorithmZstd:
+		encoder, err := getZstdEncoder()
+		if err != nil {
+			return nil, err
+		}
+		w.compressed = growBuf(w.compressed, blockHeaderSize)
+		w.compressed = encoder.EncodeAll(w.buf, w.compressed)
+		payloadSize = len(w.compressed) - blockHeaderSize
+	}
+	// lz4 returns 0 if the data is incompressible.
+	if payloadSize == 0 || payloadSize >= rawSize {
+		w.compressed = append(growBuf(w.compressed, blockHeaderSize), w.buf...)
+		payloadSize = rawSize
+	}
+	binary.LittleEndian.PutUint32(w.compressed, uint32(payloadSize))
+	binary.LittleEndian.PutUint32(w.compressed[4:], uint32(rawSize))
+	return w.compressed[:blockHeaderSize+payloadSize], nil
+}
+
+// Algorithm returns the algorithm used to compress the blocks.
+func (w *Writer) Algorithm() Algorithm {
+	return w.algorithm
+}
+
+// BlockOffsets returns the offsets of the flushed blocks in the underlying object.
+func (w *Writer) BlockOffsets() []int64 {
+	return w.blockOffsets
+}
+
+// CompressedSize returns the number of bytes written to the underlying object.
+func (w *Writer) CompressedSize() int64 {
+	return w.blockOffsets[len(w.blockOffsets)-1]
+}
+
+// GetCache returns the byte slice that holds the data not flushed to the underlying object.
+func (w *Writer) GetCache() []byte {
+	return w.buf
+}
+
+// GetCacheDataOffset return the user data offset in cache.
+func (w *Writer) GetCacheDataOffset() int64 {
+	return w.flushedUserDataCnt
+}
+
+// Close implements the io.Closer interface.
+func (w *Writer) Close() (err error) {
+	err = w.flush()
+	if err != nil {
+		return
+	}
+	return w.w.Close()
+}
+
+// Reader implements an io.ReaderAt, reading from the input source and decompressing the blocks
+// written by Writer.
+type Reader struct {
+	r            io.ReaderAt
+	algorithm    Algorithm
+	blockOffsets []int64
+
+	buf []byte
+	// the last decompressed block is cached, so that the sequential small reads don't
+	// decompress the same block again and again.
+	cachedBlockIdx int
+	cachedBlock    []byte
+}
+
+// NewReader returns a new Reader which reads the blocks at blockOffsets from the input source.
+// The blockOffsets should be got from Writer.BlockOffsets.
+func NewReader(r io.ReaderAt, algorithm Algorithm, blockOffsets []int64) *Reader {
+	return &Reader{
+		r:              r,
+		algorithm:      algorithm,
+		blockOffsets:   blockOffsets,
+		cachedBlockIdx: -1,
+	}
+}
+
+// ReadAt implements the io.ReaderAt interface.
+func (r *Reader) ReadAt(p []byte, off int64) (nn int, err error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	blockIdx := int(off / blockSize)
+	offsetInBlock := int(off % blockSize)
+	for len(p) > 0 {
+		if blockIdx >= len(r.blockOffsets)-1 {
+			return nn, io.EOF
+		}
+		block, err := r.readBlock(blockIdx)
+		if err != nil {
+			return nn, err
+		}
+		if offsetInBlock >= len(block) {
+			return nn, io.EOF
+		}
+		n := copy(p, block[offsetInBlock:])
+		nn += n
+		p = p[n:]
+		blockIdx++
+		offsetInBlock = 0
+	}
+	return nn, nil
+}
+
+func (r *Reader) readBlock(idx int) ([]byte, error) {
+	if idx == r.cachedBlockIdx {
+		return r.cachedBlock, nil
+	}
+	size := int(r.blockOffsets[idx+1] - r.blockOffsets[idx])
+	if size < blockHeaderSize {
+		return nil, errBlockCorrupted
+	}
+	r.buf = growBuf(r.buf, size)
+	n, err := r.r.ReadAt(r.buf, r.blockOffsets[idx])
+	if n == size {
+		// io.EOF can be returned together with the last bytes of the input source.
+		err = nil
+	} else if err == nil || err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	payloadSize := int(binary.LittleEndian.Uint32(r.buf))
+	rawSize := int(binary.LittleEndian.Uint32(r.buf[4:]))
+	if payloadSize != size-blockHeaderSize || rawSize > blockSize || payloadSize > rawSize {
+		return nil, errBlockCorrupted
+	}
+	payload := r.buf[blockHeaderSize:]
+	r.cachedBlockIdx = -1
+	r.cachedBlock = growBuf(r.cachedBlock, rawSize)
+	if payloadSize == rawSize {
+		copy(r.cachedBlock, payload)
+	} else if err = r.decompressBlock(payload, r.cachedBlock); err != nil {
+		return nil, err
+	}
+	r.cachedBlockIdx = idx
+	return r.cachedBlock, nil
+}
+
+func (r *Reader) decompressBlock(payload, dst []byte) error {
+	switch r.algorithm {
+	case AlgorithmLZ4:
+		n, err := lz4.UncompressBlock(payload, dst)
+		if err != nil {
+			return err
+		}
+		if n != len(dst) {
+			return errBlockCorrupted
+		}
+	case AlgorithmZstd:
+		decoder, err := getZstdDecoder()
+		if err != nil {
+			return err
+		}
+		decoded, err := decoder.DecodeAll(payload, dst[:0])
+		if err != nil {
+			return err
+		}
+		if len(decoded) != len(dst) || &decoded[0] != &dst[0] {
+			return errBlockCorrupted
+		}
+	default:
+		return errBlockCorrupted
+	}
+	return nil
+}
+
+// growBuf returns a slice of buf with length n, the underlying array is reallocated if its
+// capacity is not enough.
+func growBuf(buf []byte, n int) []byte {
+	if cap(buf) < n {
+		return make([]byte, n)
+	}
+	return buf[:n]
+}
@@ -0,0 +1,121 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compress
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeFile struct {
+	bytes.Buffer
+}
+
+func (f *fakeFile) Close() error { return nil }
+
+func (f *fakeFile) ReadAt(p []byte, off int64) (int, error) {
+	return bytes.NewReader(f.Bytes()).ReadAt(p, off)
+}
+
+func TestBlockReadAt(t *testing.T) {
+	compressible := bytes.Repeat([]byte("0123456789"), 10000)
+	incompressible := make([]byte, 100000)
+	rand.New(rand.NewSource(0)).Read(incompressible)
+
+	for _, algorithm := range []Algorithm{AlgorithmNone, AlgorithmLZ4, AlgorithmZstd} {
+		for _, data := range [][]byte{compressible, incompressible} {
+			f := &fakeFile{}
+			w := NewWriter(f, algorithm)
+			// write in pieces which don't align with the blocks
+			for i := 0; i < len(data); i += 3000 {
+				n, err := w.Write(data[i:min(i+3000, len(data))])
+				require.NoError(t, err)
+				require.Equal(t, min(3000, len(data)-i), n)
+			}
+			require.Equal(t, int64(len(data)/blockSize*blockSize), w.GetCacheDataOffset())
+			require.Equal(t, data[w.GetCacheDataOffset():], w.GetCache())
+			require.NoError(t, w.Close())
+			require.Equal(t, int64(f.Len()), w.CompressedSize())
+			if algorithm != AlgorithmNone && &data[0] == &compressible[0] {
+				require.Less(t, w.CompressedSize(), int64(len(data))/5)
+			}
+
+			r := NewReader(f, algorithm, w.BlockOffsets())
+			for _, off := range []int{0, 5, blockSize - 3, blockSize, 2*blockSize + 7, len(data) - 10} {
+				p := make([]byte, 10)
+				n, err := r.ReadAt(p, int64(off))
+				require.NoError(t, err)
+				require.Equal(t, 10, n)
+				require.Equal(t, data[off:off+10], p)
+			}
+			// read across several blocks
+			p := make([]byte, 3*blockSize)
+			n, err := r.ReadAt(p, 100)
+			require.NoError(t, err)
+			require.Equal(t, len(p), n)
+			require.Equal(t, data[100:100+len(p)], p)
+			// read beyond the end
+			p = make([]byte, 20)
+			n, err = r.ReadAt(p, int64(len(data)-5))
+			require.ErrorIs(t, err, io.EOF)
+			require.Equal(t, 5, n)
+			require.Equal(t, data[len(data)-5:], p[:5])
+			n, err = r.ReadAt(p, int64(len(data)+5))
+			require.ErrorIs(t, err, io.EOF)
+			require.Equal(t, 0, n)
+		}
+	}
+}
+
+func TestBlockCorrupted(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), 10000)
+	for _, algorithm := range []Algorithm{AlgorithmLZ4, AlgorithmZstd} {
+		f := &fakeFile{}
+		w := NewWriter(f, algorithm)
+		_, err := w.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+
+		// break the payload size of the second block
+		corrupted := &fakeFile{}
+		corrupted.Write(f.Bytes())
+		corrupted.Bytes()[w.BlockOffsets()[1]]++
+		r := NewReader(corrupted, algorithm, w.BlockOffsets())
+		p := make([]byte, 10)
+		_, err = r.ReadAt(p, 0)
+		require.NoError(t, err)
+		_, err = r.ReadAt(p, blockSize)
+		require.ErrorIs(t, err, errBlockCorrupted)
+
+		// the file is truncated
+		truncated := &fakeFile{}
+		truncated.Write(f.Bytes()[:f.Len()-1])
+		r = NewReader(truncated, algorithm, w.BlockOffsets())
+		_, err = r.ReadAt(p, int64(len(data)-10))
+		require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	}
+}
+
+func TestParseAlgorithm(t *testing.T) {
+	for _, algorithm := range []Algorithm{AlgorithmNone, AlgorithmLZ4, AlgorithmZstd} {
+		require.Equal(t, algorithm, ParseAlgorithm(algorithm.String()))
+	}
+	require.Equal(t, AlgorithmZstd, ParseAlgorithm("ZSTD"))
+	require.Equal(t, AlgorithmNone, ParseAlgorithm("snappy"))
+}
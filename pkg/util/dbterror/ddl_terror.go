@@ -512,6 +512,22 @@ var (
 	ErrEngineAttributeInvalidFormat = ClassDDL.NewStd(mysql.ErrEngineAttributeInvalidFormat)
 	// ErrStorageClassInvalidSpec is reserved for future use.
 	ErrStorageClassInvalidSpec = ClassDDL.NewStd(mysql.ErrStorageClassInvalidSpec)
+
+	// ErrMViewNotFastRefreshable is returned when a materialized view can't be fast refreshed.
+	ErrMViewNotFastRefreshable = ClassDDL.NewStd(mysql.ErrMViewNotFastRefreshable)
+	// ErrOptOnMaterializedView is returned when executing an unsupported operation on a materialized view.
+	ErrOptOnMaterializedView = ClassDDL.NewStd(mysql.ErrOptOnMaterializedView)
+	// ErrOptOnMViewBaseTable is returned when executing an unsupported operation on the base table of materialized views.
+	ErrOptOnMViewBaseTable = ClassDDL.NewStd(mysql.ErrOptOnMViewBaseTable)
+
+	// ErrTrgAlreadyExists is returned when creating a trigger with an existing name.
+	ErrTrgAlreadyExists = ClassDDL.NewStd(mysql.ErrTrgAlreadyExists)
+	// ErrTrgDoesNotExist is returned when dropping a non-existent trigger.
+	ErrTrgDoesNotExist = ClassDDL.NewStd(mysql.ErrTrgDoesNotExist)
+	// ErrTrgOnViewOrTempTable is returned when creating a trigger on a view or a temporary table.
+	ErrTrgOnViewOrTempTable = ClassDDL.NewStd(mysql.ErrTrgOnViewOrTempTable)
+	// ErrTrgInWrongSchema is returned when the schema of a trigger is not the schema of its table.
+	ErrTrgInWrongSchema = ClassDDL.NewStd(mysql.ErrTrgInWrongSchema)
 )
 
 // ReorgRetryableErrCodes are the error codes that are retryable for reorganization.
@@ -101,4 +101,42 @@ var (
 	ErrLoadDataInvalidOperation       = dbterror.ClassExecutor.NewStd(mysql.ErrLoadDataInvalidOperation)
 	ErrLoadDataLocalUnsupportedOption = dbterror.ClassExecutor.NewStd(mysql.ErrLoadDataLocalUnsupportedOption)
 	ErrLoadDataPreCheckFailed         = dbterror.ClassExecutor.NewStd(mysql.ErrLoadDataPreCheckFailed)
+
+	ErrSpAlreadyExists      = dbterror.ClassExecutor.NewStd(mysql.ErrSpAlreadyExists)
+	ErrSpDoesNotExist       = dbterror.ClassExecutor.NewStd(mysql.ErrSpDoesNotExist)
+	ErrSpBadStatement       = dbterror.ClassExecutor.NewStd(mysql.ErrSpBadstatement)
+	ErrSpLilabelMismatch    = dbterror.ClassExecutor.NewStd(mysql.ErrSpLilabelMismatch)
+	ErrSpLabelRedefine      = dbterror.ClassExecutor.NewStd(mysql.ErrSpLabelRedefine)
+	ErrSpLabelMismatch      = dbterror.ClassExecutor.NewStd(mysql.ErrSpLabelMismatch)
+	ErrSpWrongNoOfArgs      = dbterror.ClassExecutor.NewStd(mysql.ErrSpWrongNoOfArgs)
+	ErrSpNotVarArg          = dbterror.ClassExecutor.NewStd(mysql.ErrSpNotVarArg)
+	ErrSpDupParam           = dbterror.ClassExecutor.NewStd(mysql.ErrSpDupParam)
+	ErrSpDupVar             = dbterror.ClassExecutor.NewStd(mysql.ErrSpDupVar)
+	ErrSpDupCurs            = dbterror.ClassExecutor.NewStd(mysql.ErrSpDupCurs)
+	ErrSpCursorMismatch     = dbterror.ClassExecutor.NewStd(mysql.ErrSpCursorMismatch)
+	ErrSpCursorAlreadyOpen  = dbterror.ClassExecutor.NewStd(mysql.ErrSpCursorAlreadyOpen)
+	ErrSpCursorNotOpen      = dbterror.ClassExecutor.NewStd(mysql.ErrSpCursorNotOpen)
+	ErrSpUndeclaredVar      = dbterror.ClassExecutor.NewStd(mysql.ErrSpUndeclaredVar)
+	ErrSpWrongNoOfFetchArgs = dbterror.ClassExecutor.NewStd(mysql.ErrSpWrongNoOfFetchArgs)
+	ErrSpFetchNoData        = dbterror.ClassExecutor.NewStd(mysql.ErrSpFetchNoData)
+	ErrSpCaseNotFound       = dbterror.ClassExecutor.NewStd(mysql.ErrSpCaseNotFound)
+	ErrSpRecursionLimit     = dbterror.ClassExecutor.NewStd(mysql.ErrSpRecursionLimit)
+	ErrNoSuchDefiner        = dbterror.ClassExecutor.NewStd(mysql.ErrNoSuchUser)
+
+	ErrTrgCantChangeRow             = dbterror.ClassExecutor.NewStd(mysql.ErrTrgCantChangeRow)
+	ErrTrgNoSuchRowInTrg            = dbterror.ClassExecutor.NewStd(mysql.ErrTrgNoSuchRowInTrg)
+	ErrSpNoRetset                   = dbterror.ClassExecutor.NewStd(mysql.ErrSpNoRetset)
+	ErrCommitNotAllowedInSfOrTrg    = dbterror.ClassExecutor.NewStd(mysql.ErrCommitNotAllowedInSfOrTrg)
+	ErrCantUpdateUsedTableInSfOrTrg = dbterror.ClassExecutor.NewStd(mysql.ErrCantUpdateUsedTableInSfOrTrg)
+
+	ErrEventAlreadyExists               = dbterror.ClassExecutor.NewStd(mysql.ErrEventAlreadyExists)
+	ErrEventDoesNotExist                = dbterror.ClassExecutor.NewStd(mysql.ErrEventDoesNotExist)
+	ErrEventIntervalNotPositiveOrTooBig = dbterror.ClassExecutor.NewStd(mysql.ErrEventIntervalNotPositiveOrTooBig)
+	ErrEventEndsBeforeStarts            = dbterror.ClassExecutor.NewStd(mysql.ErrEventEndsBeforeStarts)
+	ErrEventExecTimeInThePast           = dbterror.ClassExecutor.NewStd(mysql.ErrEventExecTimeInThePast)
+	ErrEventSameName                    = dbterror.ClassExecutor.NewStd(mysql.ErrEventSameName)
+	ErrEventCannotCreateInThePast       = dbterror.ClassExecutor.NewStd(mysql.ErrEventCannotCreateInThePast)
+	ErrEventCannotAlterInThePast        = dbterror.ClassExecutor.NewStd(mysql.ErrEventCannotAlterInThePast)
+
+	ErrMergeCardinalityViolation = dbterror.ClassExecutor.NewStd(mysql.ErrMergeCardinalityViolation)
 )
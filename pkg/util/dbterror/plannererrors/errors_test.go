@@ -39,6 +39,7 @@ func TestError(t *testing.T) {
 		ErrFieldNotInGroupBy,
 		ErrBadTable,
 		ErrKeyDoesNotExist,
+		ErrFtMatchingKeyNotFound,
 		ErrOperandColumns,
 		ErrInvalidGroupFuncUse,
 		ErrIllegalReference,
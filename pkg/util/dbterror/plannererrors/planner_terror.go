@@ -42,6 +42,7 @@ var (
 	ErrAggregateInOrderNotSelect             = dbterror.ClassOptimizer.NewStd(mysql.ErrAggregateInOrderNotSelect)
 	ErrBadTable                              = dbterror.ClassOptimizer.NewStd(mysql.ErrBadTable)
 	ErrKeyDoesNotExist                       = dbterror.ClassOptimizer.NewStd(mysql.ErrKeyDoesNotExist)
+	ErrFtMatchingKeyNotFound                 = dbterror.ClassOptimizer.NewStd(mysql.ErrFtMatchingKeyNotFound)
 	ErrOperandColumns                        = dbterror.ClassOptimizer.NewStd(mysql.ErrOperandColumns)
 	ErrInvalidGroupFuncUse                   = dbterror.ClassOptimizer.NewStd(mysql.ErrInvalidGroupFuncUse)
 	ErrIllegalReference                      = dbterror.ClassOptimizer.NewStd(mysql.ErrIllegalReference)
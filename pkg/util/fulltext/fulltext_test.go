@@ -0,0 +1,110 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fulltext
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenize(t *testing.T) {
+	standard, multilingual := model.FullTextParserTypeStandardV1, model.FullTextParserTypeMultilingualV1
+	require.Equal(t, []string{"hello", "world", "tidb_8", "hello"}, Tokenize(standard, "Hello, World! TiDB_8 hello"))
+	require.Equal(t, []string{"hello", "world", "tidb_8"}, DistinctTokens(standard, "Hello, World! TiDB_8 hello"))
+	require.Empty(t, Tokenize(standard, " ,.!? "))
+	require.Equal(t, []string{"数据库系统"}, Tokenize(standard, "数据库系统"))
+	require.Equal(t, []string{"分", "布", "式", "tidb", "数", "据", "库"}, Tokenize(multilingual, "分布式TiDB数据库"))
+	// Too long tokens are dropped.
+	require.Equal(t, []string{"a", "b"}, Tokenize(standard, "a "+strings.Repeat("x", MaxTokenLength+1)+" b"))
+}
+
+func TestQuery(t *testing.T) {
+	parser := model.FullTextParserTypeStandardV1
+	docs := []*Document{
+		NewDocument(parser, "MySQL is a database"),
+		NewDocument(parser, "TiDB is a distributed database, TiDB is compatible with MySQL"),
+		NewDocument(parser, "Apple pie recipe"),
+		NewDocument(parser, "pie apple"),
+	}
+	matches := func(q *Query) []int {
+		var ids []int
+		for i, doc := range docs {
+			if q.Relevance(doc) != 0 {
+				ids = append(ids, i)
+			}
+		}
+		return ids
+	}
+
+	q := ParseQuery(parser, "tidb mysql", false)
+	require.Equal(t, []int{0, 1}, matches(q))
+	// The second document matches more words.
+	require.Greater(t, q.Relevance(docs[1]), q.Relevance(docs[0]))
+	// The first document is shorter.
+	require.Greater(t, ParseQuery(parser, "mysql", false).Relevance(docs[0]), ParseQuery(parser, "mysql", false).Relevance(docs[1]))
+	tokens, intersection, ok := q.IndexTokens()
+	require.True(t, ok)
+	require.False(t, intersection)
+	require.Equal(t, []string{"mysql", "tidb"}, tokens)
+	// Operators have no meaning in natural language mode.
+	require.Equal(t, []int{0, 1}, matches(ParseQuery(parser, "+tidb -mysql", false)))
+	require.Equal(t, []int{2}, matches(ParseQuery(parser, `"apple pie"`, false)))
+
+	cases := []struct {
+		query        string
+		matches      []int
+		tokens       []string
+		intersection bool
+	}{
+		{"+database -tidb", []int{0}, []string{"database"}, true},
+		{"+database +distributed", []int{1}, []string{"database", "distributed"}, true},
+		{"mysql -tidb", []int{0}, []string{"mysql"}, false},
+		{"-mysql", nil, nil, false},
+		{"data*", []int{0, 1}, nil, false},
+		{"+data* +tidb", []int{1}, []string{"tidb"}, true},
+		{`+"apple pie"`, []int{2}, []string{"apple", "pie"}, true},
+		{`"apple pie" recipe`, []int{2}, []string{"apple", "recipe"}, false},
+		{"+pie +(recipe mysql)", []int{2}, []string{"pie"}, true},
+		{"+pie -(recipe mysql)", []int{3}, []string{"pie"}, true},
+		{"(tidb", []int{1}, nil, false},
+		{"+ mysql)", []int{0, 1}, []string{"mysql"}, false},
+	}
+	for _, c := range cases {
+		q := ParseQuery(parser, c.query, true)
+		require.Equal(t, c.matches, matches(q), c.query)
+		tokens, intersection, ok := q.IndexTokens()
+		require.Equal(t, c.tokens, tokens, c.query)
+		require.Equal(t, len(c.tokens) > 0, ok, c.query)
+		require.Equal(t, c.intersection, intersection, c.query)
+	}
+
+	// > and < change the weights of the words and ~ makes negative contribution.
+	q = ParseQuery(parser, "apple >recipe", true)
+	require.Greater(t, q.Relevance(docs[2]), ParseQuery(parser, "apple recipe", true).Relevance(docs[2]))
+	q = ParseQuery(parser, "apple ~recipe", true)
+	require.Greater(t, q.Relevance(docs[3]), q.Relevance(docs[2]))
+
+	multilingual := model.FullTextParserTypeMultilingualV1
+	doc := NewDocument(multilingual, "分布式数据库")
+	require.NotZero(t, ParseQuery(multilingual, "数据库", false).Relevance(doc))
+	require.Zero(t, ParseQuery(multilingual, "库数据", false).Relevance(doc))
+	tokens, intersection, ok = ParseQuery(multilingual, "+数据库", true).IndexTokens()
+	require.True(t, ok)
+	require.True(t, intersection)
+	require.Equal(t, []string{"库", "据", "数"}, tokens)
+}
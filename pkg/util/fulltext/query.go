@@ -0,0 +1,280 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fulltext
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pingcap/tidb/pkg/meta/model"
+)
+
+type operator byte
+
+const (
+	opNone operator = iota
+	// opRequire is `+`, the item must be present.
+	opRequire
+	// opExclude is `-`, the item must not be present.
+	opExclude
+	// opNegate is `~`, the item makes negative contribution to the relevance.
+	opNegate
+)
+
+// item is a word, a prefix word, a phrase or a parenthesized group of the search string.
+type item struct {
+	op operator
+	// weight is changed by `>` and `<`.
+	weight float64
+	// words is a single word, or the words of a phrase.
+	words  []string
+	prefix bool
+	group  *group
+}
+
+type group struct {
+	items []*item
+}
+
+// Query is a parsed search string of MATCH ... AGAINST.
+type Query struct {
+	root *group
+}
+
+// ParseQuery parses the search string of MATCH ... AGAINST.
+//
+// In natural language mode, all the words are optional and a double-quoted phrase must appear
+// literally. In boolean mode, the operators `+ - ~ < > ( ) * "` have the same meanings as MySQL.
+// Like MySQL, a misplaced operator is ignored instead of reporting an error.
+func ParseQuery(parser model.FullTextParserType, text string, booleanMode bool) *Query {
+	p := &queryParser{parser: parser, text: text, booleanMode: booleanMode}
+	return &Query{root: p.parseGroup(0)}
+}
+
+type queryParser struct {
+	parser      model.FullTextParserType
+	text        string
+	booleanMode bool
+}
+
+// parseGroup parses the items until the end of the text, or the `)` closing the group.
+func (p *queryParser) parseGroup(depth int) *group {
+	g := &group{}
+	op, weight := opNone, 1.0
+	for len(p.text) > 0 {
+		r, n := utf8.DecodeRuneInString(p.text)
+		if p.booleanMode && strings.ContainsRune("+-~<>()", r) {
+			p.text = p.text[n:]
+			switch r {
+			case '+':
+				op = opRequire
+			case '-':
+				op = opExclude
+			case '~':
+				op = opNegate
+			case '>':
+				weight *= 2
+			case '<':
+				weight /= 2
+			case '(':
+				if sub := p.parseGroup(depth + 1); len(sub.items) > 0 {
+					g.items = append(g.items, &item{op: op, weight: weight, group: sub})
+				}
+				op, weight = opNone, 1
+			case ')':
+				if depth > 0 {
+					return g
+				}
+			}
+			continue
+		}
+
+		var words []string
+		if r == '"' {
+			p.text = p.text[n:]
+			end := strings.IndexByte(p.text, '"')
+			if end < 0 {
+				end = len(p.text)
+			}
+			words = Tokenize(p.parser, p.text[:end])
+			p.text = p.text[min(end+1, len(p.text)):]
+		} else {
+			// Adjacent single character words of the MULTILINGUAL parser are taken as a phrase.
+			consumed := false
+			for len(p.text) > 0 {
+				word, size := nextWord(p.parser, p.text)
+				if word == "" {
+					break
+				}
+				p.text = p.text[size:]
+				consumed = true
+				if token, ok := normalize(word); ok {
+					words = append(words, token)
+				}
+			}
+			if !consumed {
+				// Skip the separator, it also discards the operators before it.
+				p.text = p.text[n:]
+				op, weight = opNone, 1
+				continue
+			}
+		}
+		if len(words) > 0 {
+			it := &item{op: op, weight: weight, words: words}
+			if p.booleanMode && len(words) == 1 && r != '"' && strings.HasPrefix(p.text, "*") {
+				p.text = p.text[1:]
+				it.prefix = true
+			}
+			g.items = append(g.items, it)
+		}
+		op, weight = opNone, 1
+	}
+	return g
+}
+
+// Document is the tokenized text of a row to compute the relevance.
+type Document struct {
+	tokens []string
+	// tf is the number of times each token appears.
+	tf map[string]int
+}
+
+// NewDocument tokenizes the text into a Document.
+func NewDocument(parser model.FullTextParserType, text string) *Document {
+	tokens := Tokenize(parser, text)
+	tf := make(map[string]int, len(tokens))
+	for _, token := range tokens {
+		tf[token]++
+	}
+	return &Document{tokens: tokens, tf: tf}
+}
+
+// Relevance returns the relevance of the document to the query, it's 0 if the document doesn't match.
+//
+// Every matched word or phrase contributes 1+ln(n), where n is the number of times it appears in
+// the document. The sum is divided by the square root of the number of distinct tokens in the
+// document, so a shorter document is more relevant. Unlike InnoDB, the relevance doesn't depend
+// on the other rows of the table.
+func (q *Query) Relevance(doc *Document) float64 {
+	score, matched := q.root.eval(doc)
+	if !matched || len(doc.tf) == 0 {
+		return 0
+	}
+	return score / math.Sqrt(float64(len(doc.tf)))
+}
+
+func (g *group) eval(doc *Document) (score float64, matched bool) {
+	var hasRequired, hasOptional bool
+	for _, it := range g.items {
+		s, ok := it.eval(doc)
+		switch it.op {
+		case opExclude:
+			if ok {
+				return 0, false
+			}
+		case opRequire:
+			if !ok {
+				return 0, false
+			}
+			hasRequired = true
+			score += it.weight * s
+		case opNegate:
+			if ok {
+				hasOptional = true
+				score -= it.weight * s
+			}
+		default:
+			if ok {
+				hasOptional = true
+				score += it.weight * s
+			}
+		}
+	}
+	return score, hasRequired || hasOptional
+}
+
+func (it *item) eval(doc *Document) (score float64, matched bool) {
+	if it.group != nil {
+		return it.group.eval(doc)
+	}
+	var n int
+	switch {
+	case it.prefix:
+		for token, tf := range doc.tf {
+			if strings.HasPrefix(token, it.words[0]) {
+				n += tf
+			}
+		}
+	case len(it.words) == 1:
+		n = doc.tf[it.words[0]]
+	default:
+		n = doc.phraseFreq(it.words)
+	}
+	if n == 0 {
+		return 0, false
+	}
+	return 1 + math.Log(float64(n)), true
+}
+
+func (doc *Document) phraseFreq(words []string) int {
+	for _, word := range words {
+		if doc.tf[word] == 0 {
+			return 0
+		}
+	}
+	n := 0
+	for i := 0; i+len(words) <= len(doc.tokens); i++ {
+		if slices.Equal(doc.tokens[i:i+len(words)], words) {
+			n++
+		}
+	}
+	return n
+}
+
+// IndexTokens returns the tokens to look up in a FULLTEXT index to find the rows which may match
+// the query. A matched row contains all the tokens if intersection is true, or any of them otherwise.
+// It returns false if the rows can't be found by the tokens, for example, the query only has
+// prefix words, then all the rows have to be checked.
+func (q *Query) IndexTokens() (tokens []string, intersection bool, ok bool) {
+	var required, optional []string
+	hasRequired, optionalCovered := false, true
+	for _, it := range q.root.items {
+		switch it.op {
+		case opRequire:
+			hasRequired = true
+			// A required prefix word or group narrows the result down further, it's fine to skip it.
+			if it.group == nil && !it.prefix {
+				required = append(required, it.words...)
+			}
+		case opNone, opNegate:
+			if it.group != nil || it.prefix {
+				optionalCovered = false
+			} else {
+				// Any word of a phrase is enough to find the rows containing the phrase.
+				optional = append(optional, it.words[0])
+			}
+		}
+	}
+	if hasRequired {
+		tokens, intersection = required, true
+	} else if optionalCovered {
+		tokens = optional
+	}
+	slices.Sort(tokens)
+	tokens = slices.Compact(tokens)
+	return tokens, intersection, len(tokens) > 0
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fulltext
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pingcap/tidb/pkg/meta/model"
+)
+
+// MaxTokenLength is the max number of characters of a token, longer tokens are not indexed or searched.
+// It's the same as the max value of innodb_ft_max_token_size.
+const MaxTokenLength = 84
+
+// Tokenize splits the text into lowercase tokens in the order they appear. A token may appear more than once.
+//
+// A token is a run of letters, digits and underscores. The MULTILINGUAL parser additionally
+// makes every Chinese or Japanese character a token by itself, because these languages
+// don't separate words by spaces.
+func Tokenize(parser model.FullTextParserType, text string) []string {
+	var tokens []string
+	for len(text) > 0 {
+		word, size := nextWord(parser, text)
+		text = text[size:]
+		if token, ok := normalize(word); ok {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+// DistinctTokens returns the distinct tokens of the text in the order they first appear.
+// They are the values written to a FULLTEXT index stored in TiKV.
+func DistinctTokens(parser model.FullTextParserType, text string) []string {
+	tokens := Tokenize(parser, text)
+	seen := make(map[string]struct{}, len(tokens))
+	distinct := tokens[:0]
+	for _, token := range tokens {
+		if _, ok := seen[token]; ok {
+			continue
+		}
+		seen[token] = struct{}{}
+		distinct = append(distinct, token)
+	}
+	return distinct
+}
+
+// nextWord returns the word at the beginning of text and the number of bytes consumed.
+// The word is empty if text starts with a separator.
+func nextWord(parser model.FullTextParserType, text string) (word string, size int) {
+	r, n := utf8.DecodeRuneInString(text)
+	if !isWordRune(r) {
+		return "", n
+	}
+	if isSingleCharWord(parser, r) {
+		return text[:n], n
+	}
+	for size < len(text) {
+		r, n = utf8.DecodeRuneInString(text[size:])
+		if !isWordRune(r) || isSingleCharWord(parser, r) {
+			break
+		}
+		size += n
+	}
+	return text[:size], size
+}
+
+func normalize(word string) (string, bool) {
+	if len(word) == 0 || utf8.RuneCountInString(word) > MaxTokenLength {
+		return "", false
+	}
+	return strings.ToLower(word), true
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func isSingleCharWord(parser model.FullTextParserType, r rune) bool {
+	return parser == model.FullTextParserTypeMultilingualV1 && unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana)
+}
@@ -438,6 +438,10 @@ func ColumnToProto(c *model.ColumnInfo, forIndex bool, isTiFlashStore bool) *tip
 	} else {
 		pc.Tp = int32(c.GetType())
 	}
+	if pc.Tp == int32(mysql.TypeGeometry) {
+		// The storage engines read the spatial values as binary strings.
+		pc.Tp = int32(mysql.TypeLongBlob)
+	}
 	return pc
 }
 
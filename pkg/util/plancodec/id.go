@@ -139,6 +139,8 @@ const (
 	TypeSequence = "Sequence"
 	// TypeScalarSubQuery is the type of ScalarQuery
 	TypeScalarSubQuery = "ScalarSubQuery"
+	// TypeMerge is the type of Merge.
+	TypeMerge = "Merge"
 )
 
 // plan id.
@@ -204,6 +206,7 @@ const (
 	typeExpandID              int = 58
 	typeImportIntoID          int = 59
 	TypeScalarSubQueryID      int = 60
+	typeMergeID               int = 61
 )
 
 // TypeStringToPhysicalID converts the plan type string to plan id.
@@ -329,6 +332,8 @@ func TypeStringToPhysicalID(tp string) int {
 		return typeImportIntoID
 	case TypeScalarSubQuery:
 		return TypeScalarSubQueryID
+	case TypeMerge:
+		return typeMergeID
 	}
 	// Should never reach here.
 	return 0
@@ -457,6 +462,8 @@ func PhysicalIDToTypeString(id int) string {
 		return TypeImportInto
 	case TypeScalarSubQueryID:
 		return TypeScalarSubQuery
+	case typeMergeID:
+		return TypeMerge
 	}
 
 	// Should never reach here.
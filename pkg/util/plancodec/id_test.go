@@ -82,6 +82,7 @@ func TestPlanIDChanged(t *testing.T) {
 		{typeShuffleID, 54},
 		{typeShuffleReceiverID, 55},
 		{typeImportIntoID, 59},
+		{typeMergeID, 61},
 	}
 
 	for _, testcase := range testCases {
@@ -324,7 +324,8 @@ func appendDatumForChecksum(loc *time.Location, buf []byte, dat *data.Datum, typ
 	switch typ {
 	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeLong, mysql.TypeLonglong, mysql.TypeInt24, mysql.TypeYear:
 		out = binary.LittleEndian.AppendUint64(buf, dat.GetUint64())
-	case mysql.TypeVarchar, mysql.TypeVarString, mysql.TypeString, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+	case mysql.TypeVarchar, mysql.TypeVarString, mysql.TypeString, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob,
+		mysql.TypeGeometry:
 		out = appendLengthValue(buf, dat.GetBytes())
 	case mysql.TypeTimestamp, mysql.TypeDatetime, mysql.TypeDate, mysql.TypeNewDate:
 		t := dat.GetMysqlTime()
@@ -357,7 +358,7 @@ func appendDatumForChecksum(loc *time.Location, buf []byte, dat *data.Datum, typ
 		out = appendLengthValue(buf, []byte(dat.GetMysqlJSON().String()))
 	case mysql.TypeTiDBVectorFloat32:
 		out = dat.GetVectorFloat32().SerializeTo(buf)
-	case mysql.TypeNull, mysql.TypeGeometry:
+	case mysql.TypeNull:
 		out = buf
 	default:
 		return buf, errInvalidChecksumTyp
@@ -905,7 +905,8 @@ func TestColumnEncode(t *testing.T) {
 		{"mismatch/decimal", types.NewFieldType(mysql.TypeNewDecimal), types.NewDatum(1), nil, false},
 
 		{"null", types.NewFieldType(mysql.TypeNull), types.NewDatum(1), nil, true},
-		{"geometry", types.NewFieldType(mysql.TypeGeometry), types.NewDatum(1), nil, true},
+		{"geometry", types.NewFieldType(mysql.TypeGeometry), types.NewDatum([]byte("foo")), encodeBytes([]byte("foo")), true},
+		{"geometry/empty", types.NewFieldType(mysql.TypeGeometry), types.NewDatum([]byte("")), encodeBytes([]byte{}), true},
 
 		{"tinyint/zero", types.NewFieldType(mysql.TypeTiny), types.NewDatum(intZero), encodeUint64(uint64(intZero)), true},
 		{"tinyint/pos", types.NewFieldType(mysql.TypeTiny), types.NewDatum(intPos), encodeUint64(uint64(intPos)), true},
@@ -66,6 +66,11 @@ func (s *mockGCSSuite) compareJobInfoWithoutTime(jobInfo *importer.JobInfo, row
 	}
 	s.Regexp(jobInfo.ErrorMessage, row[8])
 	s.Equal(jobInfo.CreatedBy, row[12])
+	if jobInfo.Summary == nil {
+		s.Equal("<nil>", row[13].(string))
+	} else {
+		s.Equal(strconv.Itoa(int(jobInfo.Summary.RejectedRows)), row[13])
+	}
 }
 
 func (s *mockGCSSuite) TestShowJob() {